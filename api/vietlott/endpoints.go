@@ -1,5 +1,7 @@
 package vietlott
 
+import "fmt"
+
 // Vietlott API endpoints configuration
 const (
 	// BaseURL is the base URL for Vietlott website
@@ -16,9 +18,23 @@ const (
 	// Mega 6/45 historical results
 	Mega645HistoryPath = "/vi/trung-thuong/ket-qua-trung-thuong/winning-number-645"
 
+	// Keno results, 20 numbers per draw
+	KenoResultsPath = "/vi/trung-thuong/ket-qua-trung-thuong/winning-number-keno"
+
+	// Result announcements, each linking the draw's official result PDF
+	Mega645AnnouncementsPath  = "/vi/trung-thuong/ket-qua-trung-thuong/thong-bao-ket-qua-645"
+	Power655AnnouncementsPath = "/vi/trung-thuong/ket-qua-trung-thuong/thong-bao-ket-qua-655"
+
 	// Common API parameters
 	DefaultPageNumber = 1
 	DefaultPageSize   = 100
+
+	// DetailIDParam is the query parameter selecting a draw on a detail page
+	DetailIDParam = "id"
+
+	// AnnouncementPageParam is the query parameter paging the announcements,
+	// 1 being the newest
+	AnnouncementPageParam = "pageindex"
 )
 
 // GameTypePathMap maps our internal game types to result page paths
@@ -26,3 +42,21 @@ var GameTypePathMap = map[string]string{
 	"mega_6_45":  Mega645ResultsPath,
 	"power_6_55": Power655ResultsPath,
 }
+
+// GameTypeDetailPathMap maps our internal game types to draw detail page paths
+var GameTypeDetailPathMap = map[string]string{
+	"mega_6_45":  Mega645DetailPath,
+	"power_6_55": Power655DetailPath,
+}
+
+// GameTypeAnnouncementPathMap maps our internal game types to result
+// announcement paths
+var GameTypeAnnouncementPathMap = map[string]string{
+	"mega_6_45":  Mega645AnnouncementsPath,
+	"power_6_55": Power655AnnouncementsPath,
+}
+
+// FormatDrawID formats a draw number as used by detail pages, e.g. 01234
+func FormatDrawID(drawNumber int) string {
+	return fmt.Sprintf("%05d", drawNumber)
+}
@@ -0,0 +1,14 @@
+// Command api-server serves draws, predictions and backtests as a JSON HTTP
+// API; it is the predictor's serve-api command as a standalone binary.
+package main
+
+import "github.com/tool_predict/internal/cli/predictor"
+
+// version is recorded in prediction provenance; override it at build time
+// with -ldflags "-X main.version=..."
+var version = "1.0.0"
+
+func main() {
+	predictor.SetVersion(version)
+	predictor.ExecuteServeAPI()
+}
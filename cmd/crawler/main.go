@@ -0,0 +1,7 @@
+package main
+
+import "github.com/tool_predict/internal/cli/crawler"
+
+func main() {
+	crawler.Execute()
+}
@@ -0,0 +1,71 @@
+// Command vietlott is the single binary bundling the predictor, backtester,
+// crawler and demo: vietlott predict, vietlott backtest, vietlott crawl,
+// vietlott stats and the predictor's other subcommands.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/tool_predict/internal/cli/backtester"
+	"github.com/tool_predict/internal/cli/crawler"
+	"github.com/tool_predict/internal/cli/demo"
+	"github.com/tool_predict/internal/cli/predictor"
+	"github.com/tool_predict/internal/infrastructure/logger"
+)
+
+// version is recorded in predictions and backtests; override it at build
+// time with -ldflags "-X main.version=..."
+var version = "1.0.0"
+
+func main() {
+	if err := newRootCommand().Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		logger.Exit(1)
+	}
+}
+
+// newRootCommand builds the vietlott command: the predictor's commands at
+// the top level, with the backtester under backtest, the crawler under crawl
+// and the demo under demo
+func newRootCommand() *cobra.Command {
+	predictor.SetVersion(version)
+	backtester.SetVersion(version)
+
+	root := predictor.Command()
+	root.Use = "vietlott"
+	root.Short = "Vietlott lottery prediction and backtesting tool"
+	root.Long = `Predicts Vietlott lottery numbers using ensemble algorithms, crawls draws
+(crawl), shows number statistics (stats) and backtests the algorithms
+(backtest). Run without a subcommand to predict.`
+
+	root.AddCommand(
+		mount("backtest", "Backtest the algorithms against stored draws", backtester.Command()),
+		mount("crawl", "Crawl draws for every game into storage", crawler.Command()),
+		demo.Command(),
+	)
+	return root
+}
+
+// mount wraps a standalone root command as a subcommand that passes every
+// argument through, so target keeps parsing its own flags. The backtester's
+// and crawler's flags clash with the predictor's persistent ones (-o, -c,
+// -g), which rules out adding them as plain children. target runs under a
+// bare "vietlott" parent so its help reads "vietlott <use> ...".
+func mount(use, short string, target *cobra.Command) *cobra.Command {
+	target.Use = use
+	holder := &cobra.Command{Use: "vietlott", SilenceUsage: true}
+	holder.AddCommand(target)
+
+	return &cobra.Command{
+		Use:                use,
+		Short:              short,
+		Long:               target.Long,
+		DisableFlagParsing: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			holder.SetArgs(append([]string{use}, args...))
+			return holder.Execute()
+		},
+	}
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRootCommand_Subcommands(t *testing.T) {
+	root := newRootCommand()
+	assert.Equal(t, "vietlott", root.Name())
+
+	for use, want := range map[string]string{
+		"predict":   "predict",
+		"backtest":  "backtest",
+		"crawl":     "crawl",
+		"fetch":     "fetch",
+		"stats":     "freq",
+		"keno":      "keno",
+		"demo":      "demo",
+		"serve":     "serve",
+		"serve-api": "serve-api",
+	} {
+		cmd, _, err := root.Find([]string{use})
+		require.NoError(t, err, use)
+		assert.Equal(t, want, cmd.Name(), use)
+	}
+
+	// The backtester parses its own flags, including -o, which the predictor
+	// uses for --output-format
+	for _, use := range []string{"backtest", "crawl"} {
+		cmd, _, err := root.Find([]string{use})
+		require.NoError(t, err)
+		assert.True(t, cmd.DisableFlagParsing, use)
+	}
+}
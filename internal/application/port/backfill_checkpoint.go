@@ -0,0 +1,27 @@
+package port
+
+import (
+	"time"
+
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// BackfillCheckpoint records how far a backfill of a game's archive got, so
+// an interrupted backfill resumes where it stopped
+type BackfillCheckpoint struct {
+	GameType   valueobject.GameType `json:"game_type"`
+	NextPage   int                  `json:"next_page"`   // First archive page not yet stored
+	Saved      int                  `json:"saved"`       // Draws stored over every run
+	OldestDraw int                  `json:"oldest_draw"` // Oldest draw number stored so far
+	Done       bool                 `json:"done"`        // The last archive page was stored
+	UpdatedAt  time.Time            `json:"updated_at"`
+}
+
+// BackfillCheckpointStore persists backfill checkpoints
+type BackfillCheckpointStore interface {
+	// Load returns a game's checkpoint, or nil if it has none
+	Load(gameType valueobject.GameType) (*BackfillCheckpoint, error)
+
+	// Save replaces a game's checkpoint
+	Save(checkpoint *BackfillCheckpoint) error
+}
@@ -0,0 +1,18 @@
+package port
+
+import (
+	"context"
+
+	"github.com/tool_predict/internal/domain/entity"
+)
+
+// DrawNotifier announces new draw results to an external channel
+type DrawNotifier interface {
+	// NotifyDraw announces a draw together with the stored ensemble
+	// prediction that targeted it, or nil if there was none
+	NotifyDraw(
+		ctx context.Context,
+		draw *entity.Draw,
+		prediction *entity.EnsemblePrediction,
+	) error
+}
@@ -0,0 +1,41 @@
+package port
+
+import (
+	"context"
+
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// NotificationEvent names what a notification is about
+type NotificationEvent string
+
+const (
+	// EventPrediction announces a newly generated prediction
+	EventPrediction NotificationEvent = "prediction"
+	// EventVerified announces how a prediction did against its draw
+	EventVerified NotificationEvent = "verified"
+	// EventAccuracyDrop warns that an algorithm's backtest accuracy dropped
+	EventAccuracyDrop NotificationEvent = "accuracy_drop"
+)
+
+// Notification is a message for an external system
+type Notification struct {
+	Event    NotificationEvent
+	GameType valueobject.GameType
+	Subject  string // One line summary, e.g. an email subject
+	Text     string // Full plain text message
+}
+
+// Notifier delivers notifications to an external system such as email, a
+// chat or a custom service
+type Notifier interface {
+	Notify(ctx context.Context, notification Notification) error
+}
+
+// NotifierGroup is a Notifier fanning notifications out to several others.
+// Recipients lists them, in a stable order, each sending only what the group
+// would send it, so a caller can track which ones a notification reached.
+type NotifierGroup interface {
+	Notifier
+	Recipients() []Notifier
+}
@@ -0,0 +1,22 @@
+package port
+
+import (
+	"context"
+
+	"github.com/tool_predict/internal/domain/entity"
+)
+
+// PredictionNotifier announces new predictions and how they did once
+// verified to an external channel
+type PredictionNotifier interface {
+	// NotifyPrediction announces a newly generated ensemble prediction
+	NotifyPrediction(ctx context.Context, prediction *entity.EnsemblePrediction) error
+
+	// NotifyVerified announces how a prediction did against the draw it
+	// targeted; prediction.Outcome is set
+	NotifyVerified(
+		ctx context.Context,
+		draw *entity.Draw,
+		prediction *entity.EnsemblePrediction,
+	) error
+}
@@ -0,0 +1,49 @@
+package port
+
+import "time"
+
+// ScraperBackendHealth is how one backend of the scraper fallback chain has
+// fared across runs
+type ScraperBackendHealth struct {
+	Name        string    `json:"name"`
+	Successes   int       `json:"successes"`
+	Failures    int       `json:"failures"`
+	LastSuccess time.Time `json:"last_success,omitempty"`
+	LastFailure time.Time `json:"last_failure,omitempty"`
+	LastError   string    `json:"last_error,omitempty"` // Error of the last failure
+}
+
+// Attempts returns how many requests the backend has served or failed
+func (h *ScraperBackendHealth) Attempts() int {
+	return h.Successes + h.Failures
+}
+
+// SuccessRate returns the share of attempts that succeeded, 0 without any
+func (h *ScraperBackendHealth) SuccessRate() float64 {
+	if h.Attempts() == 0 {
+		return 0
+	}
+	return float64(h.Successes) / float64(h.Attempts())
+}
+
+// ScraperHealth is the fallback chain's record of each backend and of the
+// backend that worked last, which is tried first next time
+type ScraperHealth struct {
+	Backends    map[string]*ScraperBackendHealth `json:"backends"`
+	LastWorking string                           `json:"last_working,omitempty"`
+	UpdatedAt   time.Time                        `json:"updated_at"`
+}
+
+// NewScraperHealth creates a health record without any attempts
+func NewScraperHealth() *ScraperHealth {
+	return &ScraperHealth{Backends: make(map[string]*ScraperBackendHealth)}
+}
+
+// ScraperHealthStore persists the scraper health between runs
+type ScraperHealthStore interface {
+	// Load returns the saved health, or an empty record if none was saved
+	Load() (*ScraperHealth, error)
+
+	// Save replaces the saved health
+	Save(health *ScraperHealth) error
+}
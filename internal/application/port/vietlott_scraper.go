@@ -45,3 +45,39 @@ type VietlottScraper interface {
 		gameType valueobject.GameType,
 	) (int, error)
 }
+
+// DrawDetail holds the prize data published on a draw's detail page, which
+// the results list omits
+type DrawDetail struct {
+	DrawNumber int
+	Jackpot    float64
+	Winners    int // Jackpot winners
+}
+
+// DrawDetailFetcher fetches the detail page of a single draw
+type DrawDetailFetcher interface {
+	// FetchDrawDetail fetches the jackpot and winners of a draw
+	FetchDrawDetail(
+		ctx context.Context,
+		gameType valueobject.GameType,
+		drawNumber int,
+	) (*DrawDetail, error)
+}
+
+// DrawPageFetcher fetches the results archive one page at a time, for
+// walking a game's whole history
+type DrawPageFetcher interface {
+	// FetchDrawPage fetches a page of draws, page 1 holding the newest. last
+	// reports that no older page follows.
+	FetchDrawPage(
+		ctx context.Context,
+		gameType valueobject.GameType,
+		page int,
+	) (draws []*entity.Draw, last bool, err error)
+}
+
+// KenoScraper fetches Keno draw results
+type KenoScraper interface {
+	// FetchLatestKenoDraws fetches the most recent Keno draws, newest first
+	FetchLatestKenoDraws(ctx context.Context, limit int) ([]*entity.KenoDraw, error)
+}
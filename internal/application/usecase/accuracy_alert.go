@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tool_predict/internal/application/port"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/repository"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+// AccuracyAlertUseCase compares each algorithm's latest backtest with the
+// one before it and notifies when its 3+ number hit rate dropped by more than
+// a threshold. A drop is reported once per latest backtest and recipient.
+type AccuracyAlertUseCase struct {
+	backtestRepo repository.BacktestRepository
+	notifier     port.Notifier
+	reported     map[string]bool         // Latest backtest IDs every recipient was sent
+	delivered    map[string]map[int]bool // Recipients each latest backtest ID was sent to
+}
+
+// NewAccuracyAlertUseCase creates a new accuracy alert use case
+func NewAccuracyAlertUseCase(backtestRepo repository.BacktestRepository, notifier port.Notifier) *AccuracyAlertUseCase {
+	return &AccuracyAlertUseCase{
+		backtestRepo: backtestRepo,
+		notifier:     notifier,
+		reported:     make(map[string]bool),
+		delivered:    make(map[string]map[int]bool),
+	}
+}
+
+// AccuracyAlertRequest contains the accuracy alert parameters
+type AccuracyAlertRequest struct {
+	GameType  valueobject.GameType
+	Threshold float64 // Smallest drop in the 3+ number hit rate reported, e.g. 0.01 for one point
+}
+
+// AccuracyDrop is an algorithm whose latest backtest did worse than the
+// previous one
+type AccuracyDrop struct {
+	AlgorithmName string
+	Previous      *entity.BacktestResult
+	Latest        *entity.BacktestResult
+}
+
+// Drop returns how much the 3+ number hit rate fell
+func (d *AccuracyDrop) Drop() float64 {
+	return d.Previous.GetThreeNumberAccuracy() - d.Latest.GetThreeNumberAccuracy()
+}
+
+// Execute finds the algorithms whose accuracy dropped since their previous
+// backtest and notifies each drop not reported before. When the notifier is a
+// port.NotifierGroup each recipient is sent to on its own: a failed one is
+// logged and retried on the next run, without sending the drop again to the
+// recipients that already got it.
+func (uc *AccuracyAlertUseCase) Execute(ctx context.Context, req AccuracyAlertRequest) ([]*AccuracyDrop, error) {
+	if req.Threshold <= 0 {
+		return nil, fmt.Errorf("threshold must be positive, got %v", req.Threshold)
+	}
+
+	results, err := uc.backtestRepo.FindByGameType(ctx, req.GameType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load backtest results: %w", err)
+	}
+
+	algorithms := make(map[string]bool)
+	for _, result := range results {
+		algorithms[result.AlgorithmName] = true
+	}
+	names := make([]string, 0, len(algorithms))
+	for name := range algorithms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var drops []*AccuracyDrop
+	for _, name := range names {
+		latest, err := uc.backtestRepo.FindByAlgorithm(ctx, name, req.GameType, 2)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load backtest results for %s: %w", name, err)
+		}
+		if len(latest) < 2 || uc.reported[latest[0].ID] {
+			continue
+		}
+
+		drop := &AccuracyDrop{AlgorithmName: name, Previous: latest[1], Latest: latest[0]}
+		if drop.Drop() < req.Threshold {
+			continue
+		}
+		drops = append(drops, drop)
+
+		if uc.notifier == nil {
+			continue
+		}
+		if uc.notifyDrop(ctx, req.GameType, drop) {
+			uc.reported[drop.Latest.ID] = true
+			delete(uc.delivered, drop.Latest.ID)
+		}
+	}
+
+	logger.Info("Checked backtest accuracy",
+		zap.String("game_type", string(req.GameType)),
+		zap.Int("algorithms", len(names)),
+		zap.Int("drops", len(drops)))
+	return drops, nil
+}
+
+// notifyDrop sends drop to every recipient it hasn't reached yet and reports
+// whether all of them have it now
+func (uc *AccuracyAlertUseCase) notifyDrop(ctx context.Context, gameType valueobject.GameType, drop *AccuracyDrop) bool {
+	recipients := []port.Notifier{uc.notifier}
+	if group, ok := uc.notifier.(port.NotifierGroup); ok {
+		recipients = group.Recipients()
+	}
+	delivered := uc.delivered[drop.Latest.ID]
+	if delivered == nil {
+		delivered = make(map[int]bool, len(recipients))
+		uc.delivered[drop.Latest.ID] = delivered
+	}
+
+	notification := newAccuracyDropNotification(gameType, drop)
+	all := true
+	for i, recipient := range recipients {
+		if delivered[i] {
+			continue
+		}
+		if err := recipient.Notify(ctx, notification); err != nil {
+			logger.Warn("Failed to send accuracy drop notification",
+				zap.String("algorithm", drop.AlgorithmName),
+				zap.Error(err))
+			all = false
+			continue
+		}
+		delivered[i] = true
+	}
+	return all
+}
+
+// newAccuracyDropNotification describes drop as an EventAccuracyDrop
+func newAccuracyDropNotification(gameType valueobject.GameType, drop *AccuracyDrop) port.Notification {
+	var b strings.Builder
+	fmt.Fprintf(&b, "📉 %s %s accuracy dropped %.1f points", gameType, drop.AlgorithmName, drop.Drop()*100)
+	for _, result := range []*entity.BacktestResult{drop.Previous, drop.Latest} {
+		fmt.Fprintf(&b, "\n  %s to %s: %.1f%% 3+ matches, %.1f%% 4+ over %d prediction(s)",
+			result.TestPeriod.StartDate.Format("2006-01-02"), result.TestPeriod.EndDate.Format("2006-01-02"),
+			result.GetThreeNumberAccuracy()*100, result.GetFourNumberAccuracy()*100, result.TotalPredictions)
+	}
+
+	return port.Notification{
+		Event:    port.EventAccuracyDrop,
+		GameType: gameType,
+		Subject:  fmt.Sprintf("%s %s backtest accuracy dropped", gameType, drop.AlgorithmName),
+		Text:     b.String(),
+	}
+}
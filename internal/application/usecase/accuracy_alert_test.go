@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/application/port"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// newMonthBacktest creates a Mega 6/45 backtest over the given month of
+// 2026 in which three of its 100 predictions matched 3+ numbers
+func newMonthBacktest(t *testing.T, algorithmName string, month time.Month, three int) *entity.BacktestResult {
+	t.Helper()
+
+	start := time.Date(2026, month, 1, 0, 0, 0, 0, time.UTC)
+	period, err := valueobject.NewDateRange(start, start.AddDate(0, 1, -1))
+	require.NoError(t, err)
+	result, err := entity.NewBacktestResult(valueobject.Mega645, algorithmName, period, 100)
+	require.NoError(t, err)
+	result.ThreeNumberMatches = three
+	return result
+}
+
+func TestAccuracyAlertUseCase_NotifiesDrops(t *testing.T) {
+	backtests := &mockBacktestRepository{results: []*entity.BacktestResult{
+		newMonthBacktest(t, "frequency_analysis", time.August, 2),
+		newMonthBacktest(t, "frequency_analysis", time.July, 9),
+		newMonthBacktest(t, "frequency_analysis", time.June, 1),
+		newMonthBacktest(t, "hot_cold_analysis", time.July, 4),
+		newMonthBacktest(t, "hot_cold_analysis", time.August, 4),
+		newMonthBacktest(t, "pattern_analysis", time.August, 1),
+	}}
+	notifier := &mockNotifier{}
+	uc := NewAccuracyAlertUseCase(backtests, notifier)
+
+	drops, err := uc.Execute(context.Background(), AccuracyAlertRequest{GameType: valueobject.Mega645, Threshold: 0.05})
+	require.NoError(t, err)
+
+	require.Len(t, drops, 1)
+	assert.Equal(t, "frequency_analysis", drops[0].AlgorithmName)
+	assert.InDelta(t, 0.07, drops[0].Drop(), 1e-9)
+
+	require.Len(t, notifier.notifications, 1)
+	notification := notifier.notifications[0]
+	assert.Equal(t, port.EventAccuracyDrop, notification.Event)
+	assert.Equal(t, valueobject.Mega645, notification.GameType)
+	assert.Equal(t, "MEGA_6_45 frequency_analysis backtest accuracy dropped", notification.Subject)
+	assert.Equal(t, "📉 MEGA_6_45 frequency_analysis accuracy dropped 7.0 points\n"+
+		"  2026-07-01 to 2026-07-31: 9.0% 3+ matches, 0.0% 4+ over 100 prediction(s)\n"+
+		"  2026-08-01 to 2026-08-31: 2.0% 3+ matches, 0.0% 4+ over 100 prediction(s)", notification.Text)
+}
+
+func TestAccuracyAlertUseCase_ReportsEachDropOnce(t *testing.T) {
+	backtests := &mockBacktestRepository{results: []*entity.BacktestResult{
+		newMonthBacktest(t, "frequency_analysis", time.July, 9),
+		newMonthBacktest(t, "frequency_analysis", time.August, 2),
+	}}
+	notifier := &mockNotifier{}
+	uc := NewAccuracyAlertUseCase(backtests, notifier)
+	req := AccuracyAlertRequest{GameType: valueobject.Mega645, Threshold: 0.05}
+
+	_, err := uc.Execute(context.Background(), req)
+	require.NoError(t, err)
+	drops, err := uc.Execute(context.Background(), req)
+	require.NoError(t, err)
+	assert.Empty(t, drops)
+	assert.Len(t, notifier.notifications, 1)
+
+	// A newer backtest that drops again is a new report
+	backtests.results = append(backtests.results, newMonthBacktest(t, "frequency_analysis", time.September, 0))
+	drops, err = uc.Execute(context.Background(), AccuracyAlertRequest{GameType: valueobject.Mega645, Threshold: 0.01})
+	require.NoError(t, err)
+	assert.Len(t, drops, 1)
+	assert.Len(t, notifier.notifications, 2)
+}
+
+func TestAccuracyAlertUseCase_RetriesFailedNotification(t *testing.T) {
+	backtests := &mockBacktestRepository{results: []*entity.BacktestResult{
+		newMonthBacktest(t, "frequency_analysis", time.July, 9),
+		newMonthBacktest(t, "frequency_analysis", time.August, 2),
+	}}
+	notifier := &mockNotifier{err: errors.New("smtp down")}
+	uc := NewAccuracyAlertUseCase(backtests, notifier)
+	req := AccuracyAlertRequest{GameType: valueobject.Mega645, Threshold: 0.05}
+
+	drops, err := uc.Execute(context.Background(), req)
+	require.NoError(t, err)
+	assert.Len(t, drops, 1)
+
+	notifier.err = nil
+	drops, err = uc.Execute(context.Background(), req)
+	require.NoError(t, err)
+	assert.Len(t, drops, 1)
+	assert.Len(t, notifier.notifications, 2)
+}
+
+// mockNotifierGroup fans out to its recipients like a notify.Dispatcher
+type mockNotifierGroup struct {
+	recipients []port.Notifier
+}
+
+func (m *mockNotifierGroup) Notify(ctx context.Context, notification port.Notification) error {
+	var errs []error
+	for _, recipient := range m.recipients {
+		errs = append(errs, recipient.Notify(ctx, notification))
+	}
+	return errors.Join(errs...)
+}
+
+func (m *mockNotifierGroup) Recipients() []port.Notifier {
+	return m.recipients
+}
+
+func TestAccuracyAlertUseCase_RetriesOnlyFailedRecipients(t *testing.T) {
+	backtests := &mockBacktestRepository{results: []*entity.BacktestResult{
+		newMonthBacktest(t, "frequency_analysis", time.July, 9),
+		newMonthBacktest(t, "frequency_analysis", time.August, 2),
+	}}
+	webhook := &mockNotifier{}
+	email := &mockNotifier{err: errors.New("smtp down")}
+	uc := NewAccuracyAlertUseCase(backtests, &mockNotifierGroup{recipients: []port.Notifier{webhook, email}})
+	req := AccuracyAlertRequest{GameType: valueobject.Mega645, Threshold: 0.05}
+
+	_, err := uc.Execute(context.Background(), req)
+	require.NoError(t, err)
+	assert.Len(t, webhook.notifications, 1)
+	assert.Len(t, email.notifications, 1)
+
+	// The retry only goes to the recipient that failed
+	email.err = nil
+	_, err = uc.Execute(context.Background(), req)
+	require.NoError(t, err)
+	assert.Len(t, webhook.notifications, 1)
+	assert.Len(t, email.notifications, 2)
+
+	// And once everyone has it, nobody gets it again
+	_, err = uc.Execute(context.Background(), req)
+	require.NoError(t, err)
+	assert.Len(t, webhook.notifications, 1)
+	assert.Len(t, email.notifications, 2)
+}
+
+func TestAccuracyAlertUseCase_RejectsThreshold(t *testing.T) {
+	uc := NewAccuracyAlertUseCase(&mockBacktestRepository{}, &mockNotifier{})
+
+	_, err := uc.Execute(context.Background(), AccuracyAlertRequest{GameType: valueobject.Mega645})
+	assert.Error(t, err)
+}
@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/repository"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"github.com/tool_predict/pkg/algorithm"
+	"go.uber.org/zap"
+)
+
+// AutoTuneUseCase sets algorithm weights from how well the algorithms did in
+// a backtest, so the ensemble listens most to the algorithms that matched
+// the most numbers
+type AutoTuneUseCase struct {
+	backtest  *BacktestUseCase
+	statsRepo repository.StatsRepository
+	registry  *algorithm.Registry
+}
+
+// NewAutoTuneUseCase creates a new auto-tune use case. The backtest use case
+// must backtest the algorithms of registry.
+func NewAutoTuneUseCase(
+	backtest *BacktestUseCase,
+	statsRepo repository.StatsRepository,
+	registry *algorithm.Registry,
+) *AutoTuneUseCase {
+	return &AutoTuneUseCase{
+		backtest:  backtest,
+		statsRepo: statsRepo,
+		registry:  registry,
+	}
+}
+
+// AutoTuneRequest contains the auto-tune parameters
+type AutoTuneRequest struct {
+	Backtest    BacktestRequest
+	WeightTotal float64 // Sum of the tuned weights; 1.0 if zero
+}
+
+// TunedWeight is an algorithm's weight before and after tuning
+type TunedWeight struct {
+	AlgorithmName string
+	Accuracy      float64 // Mean numbers matched per backtest prediction
+	OldWeight     float64
+	NewWeight     float64
+}
+
+// AutoTuneResult contains the backtest and the weights tuned from it
+type AutoTuneResult struct {
+	Backtest *BacktestResult
+	Weights  []TunedWeight // Sorted by algorithm name
+}
+
+// Execute runs the backtest and tunes the weights from its results, see
+// Apply
+func (uc *AutoTuneUseCase) Execute(ctx context.Context, req AutoTuneRequest) (*AutoTuneResult, error) {
+	backtest, err := uc.backtest.Execute(ctx, req.Backtest)
+	if err != nil {
+		return nil, fmt.Errorf("backtest failed: %w", err)
+	}
+
+	weights, err := uc.Apply(ctx, req.Backtest.GameType, backtest.Results, req.WeightTotal)
+	if err != nil {
+		return nil, err
+	}
+	return &AutoTuneResult{Backtest: backtest, Weights: weights}, nil
+}
+
+// Apply gives each backtested algorithm a weight proportional to its mean
+// numbers matched per prediction, the weights summing to weightTotal (1.0
+// if zero). The weights are set in the registry and, with the backtest's
+// accuracies, saved to the algorithm stats, where the registries of later
+// runs pick them up. Algorithms that weren't backtested keep their weight.
+func (uc *AutoTuneUseCase) Apply(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	results []*entity.BacktestResult,
+	weightTotal float64,
+) ([]TunedWeight, error) {
+	if weightTotal == 0 {
+		weightTotal = 1.0
+	}
+	if weightTotal < 0 {
+		return nil, fmt.Errorf("weight total must be positive, got %f", weightTotal)
+	}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("no backtest results to tune weights from")
+	}
+
+	sorted := make([]*entity.BacktestResult, len(results))
+	copy(sorted, results)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].AlgorithmName < sorted[j].AlgorithmName })
+
+	weights := make([]TunedWeight, 0, len(sorted))
+	sum := 0.0
+	for _, result := range sorted {
+		accuracy := AverageMatches(result)
+		weights = append(weights, TunedWeight{
+			AlgorithmName: result.AlgorithmName,
+			Accuracy:      accuracy,
+			OldWeight:     uc.registry.GetWeight(result.AlgorithmName),
+		})
+		sum += accuracy
+	}
+	if sum == 0 {
+		return nil, fmt.Errorf("no algorithm matched any number in the backtest; weights left unchanged")
+	}
+	for i := range weights {
+		weights[i].NewWeight = weights[i].Accuracy / sum * weightTotal
+	}
+
+	existing, err := uc.statsRepo.FindByGameType(ctx, gameType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load algorithm stats: %w", err)
+	}
+	statsByName := make(map[string]*entity.AlgorithmStats, len(existing))
+	for _, stats := range existing {
+		statsByName[stats.AlgorithmName] = stats
+	}
+
+	for i, tuned := range weights {
+		if err := uc.registry.UpdateWeight(tuned.AlgorithmName, tuned.NewWeight); err != nil {
+			return nil, fmt.Errorf("failed to update weight for %s: %w", tuned.AlgorithmName, err)
+		}
+
+		stats, ok := statsByName[tuned.AlgorithmName]
+		if !ok {
+			stats, err = entity.NewAlgorithmStats(tuned.AlgorithmName, gameType, tuned.NewWeight)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create stats for %s: %w", tuned.AlgorithmName, err)
+			}
+		}
+		result := sorted[i]
+		stats.UpdateMetrics(
+			result.GetThreeNumberAccuracy(),
+			result.GetFourNumberAccuracy(),
+			result.GetAccuracyRate(),
+			result.AverageConfidence,
+			result.TotalPredictions,
+		)
+		if err := stats.SetWeight(tuned.NewWeight); err != nil {
+			return nil, fmt.Errorf("failed to set weight for %s: %w", tuned.AlgorithmName, err)
+		}
+		stats.TunedAt = time.Now()
+		if err := uc.statsRepo.Save(ctx, stats); err != nil {
+			return nil, fmt.Errorf("failed to save stats for %s: %w", tuned.AlgorithmName, err)
+		}
+
+		logger.Info("Tuned algorithm weight",
+			zap.String("algorithm", tuned.AlgorithmName),
+			zap.String("game_type", string(gameType)),
+			zap.Float64("accuracy", tuned.Accuracy),
+			zap.Float64("old_weight", tuned.OldWeight),
+			zap.Float64("new_weight", tuned.NewWeight),
+		)
+	}
+
+	return weights, nil
+}
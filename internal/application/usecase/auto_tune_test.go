@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/pkg/algorithm"
+)
+
+// newTunedBacktest returns a backtest result of the named algorithm whose
+// predictions matched the given counts
+func newTunedBacktest(t *testing.T, name string, matchCounts ...int) *entity.BacktestResult {
+	t.Helper()
+
+	period, err := valueobject.NewDateRange(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	result, err := entity.NewBacktestResult(valueobject.Mega645, name, period, len(matchCounts))
+	require.NoError(t, err)
+	for _, count := range matchCounts {
+		result.AddMatchResult(entity.PredictionMatch{MatchCount: count, Confidence: 0.5})
+	}
+	result.CalculateMetrics()
+	return result
+}
+
+func TestAutoTuneUseCase_Apply(t *testing.T) {
+	registry := algorithm.NewRegistry()
+	require.NoError(t, registry.Register(algorithm.NewFrequencyAnalyzer(1), 1))
+	require.NoError(t, registry.Register(algorithm.NewHotColdAnalyzer(1), 1))
+	require.NoError(t, registry.Register(algorithm.NewPatternAnalyzer(1), 1))
+	existing, err := entity.NewAlgorithmStats("hot_cold_analysis", valueobject.Mega645, 1)
+	require.NoError(t, err)
+	existing.SetActive(false)
+	statsRepo := newMockStatsRepository(existing)
+	uc := NewAutoTuneUseCase(nil, statsRepo, registry)
+
+	weights, err := uc.Apply(context.Background(), valueobject.Mega645, []*entity.BacktestResult{
+		newTunedBacktest(t, "hot_cold_analysis", 1, 1, 1, 1),
+		newTunedBacktest(t, "frequency_analysis", 3, 3, 2, 4),
+	}, 2.0)
+	require.NoError(t, err)
+
+	require.Len(t, weights, 2)
+	assert.Equal(t, "frequency_analysis", weights[0].AlgorithmName)
+	assert.InDelta(t, 3.0, weights[0].Accuracy, 1e-9)
+	assert.InDelta(t, 1.0, weights[0].OldWeight, 1e-9)
+	assert.InDelta(t, 1.5, weights[0].NewWeight, 1e-9)
+	assert.InDelta(t, 0.5, weights[1].NewWeight, 1e-9)
+
+	assert.InDelta(t, 1.5, registry.GetWeight("frequency_analysis"), 1e-9)
+	assert.InDelta(t, 0.5, registry.GetWeight("hot_cold_analysis"), 1e-9)
+	assert.InDelta(t, 1.0, registry.GetWeight("pattern_analysis"), 1e-9, "algorithms not backtested keep their weight")
+
+	stored, err := statsRepo.FindByGameType(context.Background(), valueobject.Mega645)
+	require.NoError(t, err)
+	require.Len(t, stored, 2)
+	for _, stats := range stored {
+		assert.False(t, stats.TunedAt.IsZero(), "%s is marked tuned", stats.AlgorithmName)
+		switch stats.AlgorithmName {
+		case "frequency_analysis":
+			assert.InDelta(t, 1.5, stats.Weight, 1e-9)
+			assert.InDelta(t, 0.5, stats.Accuracy3Numbers, 1e-9)
+			assert.InDelta(t, 0.25, stats.Accuracy4Numbers, 1e-9)
+			assert.Equal(t, 4, stats.TotalPredictions)
+		case "hot_cold_analysis":
+			assert.InDelta(t, 0.5, stats.Weight, 1e-9)
+			assert.False(t, stats.IsActive, "existing stats keep their active flag")
+		}
+	}
+}
+
+func TestAutoTuneUseCase_Apply_NoMatches(t *testing.T) {
+	registry := algorithm.NewRegistry()
+	require.NoError(t, registry.Register(algorithm.NewFrequencyAnalyzer(1), 0.7))
+	uc := NewAutoTuneUseCase(nil, newMockStatsRepository(), registry)
+
+	_, err := uc.Apply(context.Background(), valueobject.Mega645, []*entity.BacktestResult{
+		newTunedBacktest(t, "frequency_analysis", 0, 0),
+	}, 1.0)
+	assert.Error(t, err)
+	assert.InDelta(t, 0.7, registry.GetWeight("frequency_analysis"), 1e-9)
+}
+
+func TestAutoTuneUseCase_Execute(t *testing.T) {
+	registry := algorithm.NewRegistry()
+	require.NoError(t, registry.Register(&fixedPickAlgorithm{numbers: []int{1, 2, 3, 4, 5, 6}}, 0.3))
+	statsRepo := newMockStatsRepository()
+	backtest := NewBacktestUseCase(newMockDrawRepository(createMockDraws(valueobject.Mega645, 40)...),
+		&mockBacktestRepository{}, statsRepo, registry, &mockScraper{})
+	uc := NewAutoTuneUseCase(backtest, statsRepo, registry)
+
+	result, err := uc.Execute(context.Background(), AutoTuneRequest{
+		Backtest: BacktestRequest{GameType: valueobject.Mega645, TestMode: "draws", TestSize: 20},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Weights, 1)
+	assert.InDelta(t, 1.0, result.Weights[0].NewWeight, 1e-9, "a lone algorithm gets the whole weight total")
+	assert.InDelta(t, 1.0, registry.GetWeight("fixed_pick"), 1e-9)
+}
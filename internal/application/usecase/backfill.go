@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tool_predict/internal/application/port"
+	"github.com/tool_predict/internal/domain/repository"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+// BackfillUseCase walks a game's whole results archive, page by page from the
+// newest, storing every draw. Progress is checkpointed after each page, so an
+// interrupted backfill resumes at the first page it hadn't stored.
+type BackfillUseCase struct {
+	drawRepo    repository.DrawRepository
+	pages       port.DrawPageFetcher
+	checkpoints port.BackfillCheckpointStore
+}
+
+// NewBackfillUseCase creates a new backfill use case
+func NewBackfillUseCase(
+	drawRepo repository.DrawRepository,
+	pages port.DrawPageFetcher,
+	checkpoints port.BackfillCheckpointStore,
+) *BackfillUseCase {
+	return &BackfillUseCase{
+		drawRepo:    drawRepo,
+		pages:       pages,
+		checkpoints: checkpoints,
+	}
+}
+
+// BackfillRequest contains the backfill parameters
+type BackfillRequest struct {
+	GameType valueobject.GameType
+	MaxPages int           // Most pages to fetch this run; 0 for the whole archive
+	Delay    time.Duration // Pause between pages, on top of the scraper's rate limit
+	Restart  bool          // Ignore the checkpoint and start again from the newest page
+}
+
+// BackfillResult reports what a backfill run fetched and how far it got
+type BackfillResult struct {
+	GameType   valueobject.GameType
+	StartPage  int // First page fetched this run
+	Pages      int // Pages fetched this run
+	Saved      int // Draws stored this run
+	TotalSaved int // Draws stored over every run since the checkpoint began
+	OldestDraw int // Oldest draw number stored so far
+	Done       bool
+}
+
+// Execute fetches archive pages from the checkpoint on until the last page,
+// a page with no draw older than those already stored, req.MaxPages pages or
+// the context ends. A failed page stops the run with
+// the checkpoint still pointing at it, so re-running retries it. A finished
+// backfill does nothing unless req.Restart is set.
+func (uc *BackfillUseCase) Execute(ctx context.Context, req BackfillRequest) (*BackfillResult, error) {
+	if err := req.GameType.Validate(); err != nil {
+		return nil, err
+	}
+	if req.MaxPages < 0 {
+		return nil, fmt.Errorf("max pages cannot be negative, got %d", req.MaxPages)
+	}
+
+	checkpoint, err := uc.checkpoints.Load(req.GameType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load backfill checkpoint: %w", err)
+	}
+	if checkpoint == nil || req.Restart {
+		checkpoint = &port.BackfillCheckpoint{GameType: req.GameType, NextPage: 1}
+	}
+
+	result := &BackfillResult{
+		GameType:   req.GameType,
+		StartPage:  checkpoint.NextPage,
+		TotalSaved: checkpoint.Saved,
+		OldestDraw: checkpoint.OldestDraw,
+		Done:       checkpoint.Done,
+	}
+	if checkpoint.Done {
+		logger.Info("Backfill already complete", zap.String("game_type", string(req.GameType)))
+		return result, nil
+	}
+
+	logger.Info("Backfilling draws",
+		zap.String("game_type", string(req.GameType)),
+		zap.Int("start_page", checkpoint.NextPage),
+		zap.Int("max_pages", req.MaxPages),
+	)
+
+	for req.MaxPages == 0 || result.Pages < req.MaxPages {
+		if result.Pages > 0 {
+			select {
+			case <-ctx.Done():
+				return result, ctx.Err()
+			case <-time.After(req.Delay):
+			}
+		}
+
+		page := checkpoint.NextPage
+		draws, last, err := uc.pages.FetchDrawPage(ctx, req.GameType, page)
+		if err != nil {
+			return result, fmt.Errorf("failed to fetch page %d: %w", page, err)
+		}
+		if len(draws) > 0 {
+			if err := uc.drawRepo.SaveBatch(ctx, draws); err != nil {
+				return result, fmt.Errorf("failed to save draws of page %d: %w", page, err)
+			}
+		}
+
+		checkpoint.NextPage++
+		checkpoint.Saved += len(draws)
+		addedOlder := false
+		for _, draw := range draws {
+			if checkpoint.OldestDraw == 0 || draw.DrawNumber < checkpoint.OldestDraw {
+				checkpoint.OldestDraw = draw.DrawNumber
+				addedOlder = true
+			}
+		}
+		if len(draws) > 0 && !addedOlder && !last {
+			// The source keeps serving draws we already walked past, so
+			// paging further would never reach the end of the archive.
+			logger.Warn("Backfill page added no older draw, treating archive as complete",
+				zap.String("game_type", string(req.GameType)),
+				zap.Int("page", page),
+				zap.Int("oldest_draw", checkpoint.OldestDraw),
+			)
+		}
+		checkpoint.Done = last || len(draws) == 0 || !addedOlder
+		checkpoint.UpdatedAt = time.Now()
+		if err := uc.checkpoints.Save(checkpoint); err != nil {
+			return result, fmt.Errorf("failed to save backfill checkpoint: %w", err)
+		}
+
+		result.Pages++
+		result.Saved += len(draws)
+		result.TotalSaved = checkpoint.Saved
+		result.OldestDraw = checkpoint.OldestDraw
+		result.Done = checkpoint.Done
+
+		logger.Info("Backfilled page",
+			zap.String("game_type", string(req.GameType)),
+			zap.Int("page", page),
+			zap.Int("draws", len(draws)),
+			zap.Int("oldest_draw", checkpoint.OldestDraw),
+		)
+		if checkpoint.Done {
+			break
+		}
+	}
+
+	logger.Info("Backfill run finished",
+		zap.String("game_type", string(req.GameType)),
+		zap.Int("pages", result.Pages),
+		zap.Int("saved", result.Saved),
+		zap.Bool("done", result.Done),
+	)
+	return result, nil
+}
@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/application/port"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// mockPageFetcher serves draws newest first in pages of pageSize, failing
+// the pages in failPages. A stuck fetcher serves page 1 for every page and
+// never reports the last one.
+type mockPageFetcher struct {
+	draws     []*entity.Draw // Oldest first, as createMockDraws returns them
+	pageSize  int
+	failPages map[int]bool
+	stuck     bool
+	fetched   []int
+}
+
+func (m *mockPageFetcher) FetchDrawPage(ctx context.Context, gameType valueobject.GameType, page int) ([]*entity.Draw, bool, error) {
+	m.fetched = append(m.fetched, page)
+	if m.failPages[page] {
+		return nil, false, errors.New("connection reset")
+	}
+	if m.stuck {
+		page = 1
+	}
+
+	var pageDraws []*entity.Draw
+	newest := len(m.draws) - (page-1)*m.pageSize
+	for i := newest - 1; i >= 0 && i >= newest-m.pageSize; i-- {
+		pageDraws = append(pageDraws, m.draws[i])
+	}
+	return pageDraws, !m.stuck && newest-m.pageSize <= 0, nil
+}
+
+type mockCheckpointStore struct {
+	checkpoints map[valueobject.GameType]port.BackfillCheckpoint
+}
+
+func newMockCheckpointStore() *mockCheckpointStore {
+	return &mockCheckpointStore{checkpoints: make(map[valueobject.GameType]port.BackfillCheckpoint)}
+}
+
+func (m *mockCheckpointStore) Load(gameType valueobject.GameType) (*port.BackfillCheckpoint, error) {
+	checkpoint, ok := m.checkpoints[gameType]
+	if !ok {
+		return nil, nil
+	}
+	return &checkpoint, nil
+}
+
+func (m *mockCheckpointStore) Save(checkpoint *port.BackfillCheckpoint) error {
+	m.checkpoints[checkpoint.GameType] = *checkpoint
+	return nil
+}
+
+func TestBackfillUseCase_FetchesWholeArchive(t *testing.T) {
+	ctx := context.Background()
+	gt := valueobject.Power655
+	drawRepo := newMockDrawRepository()
+	fetcher := &mockPageFetcher{draws: createMockDraws(gt, 25), pageSize: 10}
+	checkpoints := newMockCheckpointStore()
+	uc := NewBackfillUseCase(drawRepo, fetcher, checkpoints)
+
+	result, err := uc.Execute(ctx, BackfillRequest{GameType: gt})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.StartPage)
+	assert.Equal(t, 3, result.Pages)
+	assert.Equal(t, 25, result.Saved)
+	assert.Equal(t, 1, result.OldestDraw)
+	assert.True(t, result.Done)
+
+	count, err := drawRepo.Count(ctx, gt)
+	require.NoError(t, err)
+	assert.Equal(t, int64(25), count)
+	assert.Equal(t, 4, checkpoints.checkpoints[gt].NextPage)
+
+	// A finished backfill doesn't fetch again until restarted
+	result, err = uc.Execute(ctx, BackfillRequest{GameType: gt})
+	require.NoError(t, err)
+	assert.True(t, result.Done)
+	assert.Zero(t, result.Pages)
+	assert.Equal(t, []int{1, 2, 3}, fetcher.fetched)
+
+	result, err = uc.Execute(ctx, BackfillRequest{GameType: gt, Restart: true, MaxPages: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Pages)
+	assert.False(t, result.Done)
+}
+
+func TestBackfillUseCase_ResumesFromCheckpoint(t *testing.T) {
+	ctx := context.Background()
+	gt := valueobject.Power655
+	drawRepo := newMockDrawRepository()
+	fetcher := &mockPageFetcher{draws: createMockDraws(gt, 35), pageSize: 10, failPages: map[int]bool{3: true}}
+	checkpoints := newMockCheckpointStore()
+	uc := NewBackfillUseCase(drawRepo, fetcher, checkpoints)
+
+	// Page 3 fails; pages 1-2 stay stored and checkpointed
+	result, err := uc.Execute(ctx, BackfillRequest{GameType: gt})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "page 3")
+	assert.Equal(t, 2, result.Pages)
+	assert.Equal(t, 16, result.OldestDraw)
+	assert.Equal(t, 3, checkpoints.checkpoints[gt].NextPage)
+
+	// The next run retries page 3, stopping after MaxPages
+	fetcher.failPages = nil
+	result, err = uc.Execute(ctx, BackfillRequest{GameType: gt, MaxPages: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.StartPage)
+	assert.Equal(t, 1, result.Pages)
+	assert.Equal(t, 30, result.TotalSaved)
+	assert.False(t, result.Done)
+
+	result, err = uc.Execute(ctx, BackfillRequest{GameType: gt})
+	require.NoError(t, err)
+	assert.Equal(t, 4, result.StartPage)
+	assert.Equal(t, 35, result.TotalSaved)
+	assert.Equal(t, 1, result.OldestDraw)
+	assert.True(t, result.Done)
+	assert.Equal(t, []int{1, 2, 3, 3, 4}, fetcher.fetched)
+
+	count, err := drawRepo.Count(ctx, gt)
+	require.NoError(t, err)
+	assert.Equal(t, int64(35), count)
+}
+
+func TestBackfillUseCase_StopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	gt := valueobject.Power655
+	fetcher := &mockPageFetcher{draws: createMockDraws(gt, 30), pageSize: 10}
+	uc := NewBackfillUseCase(newMockDrawRepository(), fetcher, newMockCheckpointStore())
+
+	cancel()
+	result, err := uc.Execute(ctx, BackfillRequest{GameType: gt, Delay: time.Hour})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, result.Pages)
+}
+
+func TestBackfillUseCase_StopsWhenPagesAddNoOlderDraw(t *testing.T) {
+	ctx := context.Background()
+	gt := valueobject.Power655
+	fetcher := &mockPageFetcher{draws: createMockDraws(gt, 30), pageSize: 10, stuck: true}
+	uc := NewBackfillUseCase(newMockDrawRepository(), fetcher, newMockCheckpointStore())
+
+	result, err := uc.Execute(ctx, BackfillRequest{GameType: gt})
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Pages)
+	assert.Equal(t, 21, result.OldestDraw)
+	assert.True(t, result.Done)
+	assert.Equal(t, []int{1, 2}, fetcher.fetched)
+}
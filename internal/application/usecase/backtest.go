@@ -3,6 +3,9 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/tool_predict/internal/application/port"
@@ -21,6 +24,9 @@ type BacktestUseCase struct {
 	statsRepo    repository.StatsRepository
 	registry     *algorithm.Registry
 	scraper      port.VietlottScraper
+	toolVersion  string
+	configHash   string
+	concurrency  int
 }
 
 // NewBacktestUseCase creates a new backtest use case
@@ -37,9 +43,40 @@ func NewBacktestUseCase(
 		statsRepo:    statsRepo,
 		registry:     registry,
 		scraper:      scraper,
+		concurrency:  1,
 	}
 }
 
+// SetConcurrency sets how many predictions a backtest makes at once. The
+// algorithms are backtested side by side; the draws of one are predicted
+// side by side only when it is an algorithm.ConcurrentPredictor. Results are
+// the same for any concurrency.
+func (uc *BacktestUseCase) SetConcurrency(n int) error {
+	if n < 1 {
+		return fmt.Errorf("concurrency must be at least 1, got %d", n)
+	}
+	uc.concurrency = n
+	return nil
+}
+
+// SetVersionStamp sets the tool version and config hash recorded with every
+// backtest result
+func (uc *BacktestUseCase) SetVersionStamp(toolVersion, configHash string) {
+	uc.toolVersion = toolVersion
+	uc.configHash = configHash
+}
+
+// Walk-forward window modes: which earlier draws each backtest prediction
+// trains on
+const (
+	WindowExpanding = "expanding" // Every earlier draw of the test period (default)
+	WindowSliding   = "sliding"   // The latest WindowSize earlier draws
+)
+
+// minTrainingDraws is how many draws a backtest trains on before its first
+// prediction
+const minTrainingDraws = 7
+
 // BacktestRequest contains the backtest parameters
 type BacktestRequest struct {
 	GameType   valueobject.GameType
@@ -48,6 +85,27 @@ type BacktestRequest struct {
 	Algorithms []string
 	FromDate   *time.Time
 	ToDate     *time.Time
+	WindowMode string // WindowExpanding or WindowSliding; empty is WindowExpanding
+	WindowSize int    // Draws each prediction trains on with WindowSliding
+}
+
+// ValidateWindow checks the request's window settings and returns its
+// window mode, WindowExpanding when unset
+func (req BacktestRequest) ValidateWindow() (string, error) {
+	switch req.WindowMode {
+	case "", WindowExpanding:
+		if req.WindowSize != 0 {
+			return "", fmt.Errorf("window size %d needs the %s window mode", req.WindowSize, WindowSliding)
+		}
+		return WindowExpanding, nil
+	case WindowSliding:
+		if req.WindowSize < minTrainingDraws {
+			return "", fmt.Errorf("sliding window needs at least %d draws, got %d", minTrainingDraws, req.WindowSize)
+		}
+		return WindowSliding, nil
+	default:
+		return "", fmt.Errorf("unknown window mode %q (expected %s or %s)", req.WindowMode, WindowExpanding, WindowSliding)
+	}
 }
 
 // BacktestResult contains the backtest results
@@ -55,9 +113,13 @@ type BacktestResult struct {
 	GameType         valueobject.GameType
 	TestMode         string
 	TestPeriod       string
+	WindowMode       string
+	WindowSize       int
 	TotalPredictions int
 	Results          []*entity.BacktestResult
 	Duration         time.Duration
+	ToolVersion      string
+	ConfigHash       string
 }
 
 // Execute runs the backtest
@@ -67,10 +129,17 @@ func (uc *BacktestUseCase) Execute(
 ) (*BacktestResult, error) {
 	startTime := time.Now()
 
+	windowMode, err := req.ValidateWindow()
+	if err != nil {
+		return nil, err
+	}
+
 	logger.Info("Starting backtest workflow",
 		zap.String("game_type", string(req.GameType)),
 		zap.String("test_mode", req.TestMode),
 		zap.Int("test_size", req.TestSize),
+		zap.String("window_mode", windowMode),
+		zap.Int("window_size", req.WindowSize),
 	)
 
 	// Step 1: Determine test period
@@ -85,37 +154,53 @@ func (uc *BacktestUseCase) Execute(
 	)
 
 	// Step 2: For each algorithm, run backtest
-	algorithms := uc.registry.GetAll()
-	results := make([]*entity.BacktestResult, 0, len(algorithms))
-
-	for _, algo := range algorithms {
-		if len(req.Algorithms) > 0 {
-			// Filter if specific algorithms requested
-			found := false
-			for _, requested := range req.Algorithms {
-				if algo.Name() == requested {
-					found = true
-					break
-				}
-			}
-			if !found {
-				continue
-			}
+	var algorithms []algorithm.Algorithm
+	for _, algo := range uc.registry.GetAll() {
+		// Filter if specific algorithms requested
+		if len(req.Algorithms) == 0 || slices.Contains(req.Algorithms, algo.Name()) {
+			algorithms = append(algorithms, algo)
 		}
+	}
+	sort.Slice(algorithms, func(i, j int) bool { return algorithms[i].Name() < algorithms[j].Name() })
+
+	// Every Train and Predict call holds a slot, so at most uc.concurrency run
+	// at once
+	slots := make(chan struct{}, uc.concurrency)
+	backtests := make([]*entity.BacktestResult, len(algorithms))
+	var wg sync.WaitGroup
+	for i, algo := range algorithms {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			logger.Info("Backtesting algorithm",
+				zap.String("algorithm", algo.Name()),
+			)
 
-		logger.Info("Backtesting algorithm",
-			zap.String("algorithm", algo.Name()),
-		)
+			result, err := uc.backtestAlgorithm(ctx, req.GameType, algo, draws, req.WindowSize, slots)
+			if err != nil {
+				logger.Warn("Algorithm backtest failed",
+					zap.String("algorithm", algo.Name()),
+					zap.Error(err),
+				)
+				return
+			}
+			backtests[i] = result
+		}()
+	}
+	wg.Wait()
 
-		result, err := uc.backtestAlgorithm(ctx, req.GameType, algo, draws)
-		if err != nil {
-			logger.Warn("Algorithm backtest failed",
-				zap.String("algorithm", algo.Name()),
+	results := make([]*entity.BacktestResult, 0, len(algorithms))
+	for _, result := range backtests {
+		if result == nil {
+			continue
+		}
+		// Save to repository
+		if err := uc.backtestRepo.Save(ctx, result); err != nil {
+			logger.Warn("Failed to save backtest result",
+				zap.String("algorithm", result.AlgorithmName),
 				zap.Error(err),
 			)
-			continue
 		}
-
 		results = append(results, result)
 	}
 
@@ -134,36 +219,40 @@ func (uc *BacktestUseCase) Execute(
 		GameType:         req.GameType,
 		TestMode:         req.TestMode,
 		TestPeriod:       testPeriodDesc,
+		WindowMode:       windowMode,
+		WindowSize:       req.WindowSize,
 		TotalPredictions: len(draws),
 		Results:          results,
 		Duration:         duration,
+		ToolVersion:      uc.toolVersion,
+		ConfigHash:       uc.configHash,
 	}, nil
 }
 
-// getTestDraws gets the draws for the test period
+// getTestDraws gets the draws for the test period, oldest first. Local
+// storage is preferred; the scraper is only asked when storage has fewer
+// draws than requested or is missing scheduled draws at the end of the
+// period. If the scraper then fails, whatever storage had is used.
 func (uc *BacktestUseCase) getTestDraws(
 	ctx context.Context,
 	req BacktestRequest,
 ) ([]*entity.Draw, string, error) {
-	var draws []*entity.Draw
-	var err error
+	var local []*entity.Draw
+	var sufficient bool
+	var fetch func() ([]*entity.Draw, error)
 	var desc string
 
 	if req.TestMode == "draws" {
 		// Get last N draws
-		draws, err = uc.scraper.FetchLatestDraws(ctx, req.GameType, req.TestSize)
+		var err error
+		local, err = uc.drawRepo.FindLatest(ctx, req.GameType, req.TestSize)
 		if err != nil {
-			// Fallback to local storage
-			logger.Warn("Scraper failed, attempting to use local storage",
-				zap.Error(err),
-			)
-			draws, err = uc.drawRepo.FindLatest(ctx, req.GameType, req.TestSize)
-			if err != nil {
-				return nil, "", fmt.Errorf("failed to fetch historical data and no local data available: %w", err)
-			}
-			logger.Info("Using local storage data",
-				zap.Int("draws_count", len(draws)),
-			)
+			logger.Warn("Failed to read local storage", zap.Error(err))
+		}
+		// Storage that missed the latest draws would test an older window
+		sufficient = len(local) >= req.TestSize && drawsUpTo(req.GameType, local, time.Now())
+		fetch = func() ([]*entity.Draw, error) {
+			return uc.scraper.FetchLatestDraws(ctx, req.GameType, req.TestSize)
 		}
 		desc = fmt.Sprintf("Last %d draws", req.TestSize)
 	} else if req.TestMode == "days" {
@@ -171,57 +260,110 @@ func (uc *BacktestUseCase) getTestDraws(
 		fromDate := time.Now().AddDate(0, 0, -req.TestSize)
 		toDate := time.Now()
 
-		draws, err = uc.scraper.FetchDrawsByDateRange(ctx, req.GameType, fromDate, toDate)
-		if err != nil {
-			// Fallback to local storage
-			logger.Warn("Scraper failed, attempting to use local storage",
-				zap.Error(err),
-			)
-			dateRange, _ := valueobject.NewDateRange(fromDate, toDate)
-			draws, err = uc.drawRepo.FindByDateRange(ctx, req.GameType, dateRange)
-			if err != nil {
-				return nil, "", fmt.Errorf("failed to fetch historical data and no local data available: %w", err)
-			}
-			logger.Info("Using local storage data",
-				zap.Int("draws_count", len(draws)),
-			)
+		local, sufficient = uc.localDrawsInRange(ctx, req.GameType, fromDate, toDate)
+		fetch = func() ([]*entity.Draw, error) {
+			return uc.scraper.FetchDrawsByDateRange(ctx, req.GameType, fromDate, toDate)
 		}
 		desc = fmt.Sprintf("Last %d days", req.TestSize)
 	} else if req.FromDate != nil && req.ToDate != nil {
 		// Custom date range
-		draws, err = uc.scraper.FetchDrawsByDateRange(ctx, req.GameType, *req.FromDate, *req.ToDate)
-		if err != nil {
-			// Fallback to local storage
-			logger.Warn("Scraper failed, attempting to use local storage",
-				zap.Error(err),
-			)
-			dateRange, _ := valueobject.NewDateRange(*req.FromDate, *req.ToDate)
-			draws, err = uc.drawRepo.FindByDateRange(ctx, req.GameType, dateRange)
-			if err != nil {
-				return nil, "", fmt.Errorf("failed to fetch historical data and no local data available: %w", err)
-			}
-			logger.Info("Using local storage data",
-				zap.Int("draws_count", len(draws)),
-			)
+		local, sufficient = uc.localDrawsInRange(ctx, req.GameType, *req.FromDate, *req.ToDate)
+		fetch = func() ([]*entity.Draw, error) {
+			return uc.scraper.FetchDrawsByDateRange(ctx, req.GameType, *req.FromDate, *req.ToDate)
 		}
 		desc = fmt.Sprintf("%s to %s", req.FromDate.Format("2006-01-02"), req.ToDate.Format("2006-01-02"))
 	} else {
 		return nil, "", fmt.Errorf("invalid test mode: %s", req.TestMode)
 	}
 
+	draws := local
+	if sufficient {
+		logger.Info("Using local storage data",
+			zap.Int("draws_count", len(draws)),
+		)
+	} else {
+		logger.Info("Local storage data insufficient or out of date, fetching from scraper",
+			zap.Int("local_draws", len(local)),
+		)
+		fetched, err := fetch()
+		switch {
+		case err == nil && len(fetched) >= len(local):
+			draws = fetched
+		case err == nil:
+			logger.Warn("Scraper returned fewer draws than stored, using the local storage data",
+				zap.Int("draws_count", len(local)),
+				zap.Int("fetched", len(fetched)),
+			)
+		case len(local) > 0:
+			logger.Warn("Scraper failed, using the local storage data available",
+				zap.Int("draws_count", len(local)),
+				zap.Error(err),
+			)
+		default:
+			return nil, "", fmt.Errorf("failed to fetch historical data and no local data available: %w", err)
+		}
+	}
+
 	if len(draws) == 0 {
 		return nil, "", fmt.Errorf("no draws found")
 	}
 
+	sort.SliceStable(draws, func(i, j int) bool {
+		return draws[i].DrawDate.Before(draws[j].DrawDate)
+	})
+
 	return draws, desc, nil
 }
 
-// backtestAlgorithm backtests a single algorithm
+// localDrawsInRange returns the stored draws between from and to, and
+// whether they're complete: non-empty, with no scheduled draw after the
+// latest one up to to
+func (uc *BacktestUseCase) localDrawsInRange(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	from, to time.Time,
+) ([]*entity.Draw, bool) {
+	dateRange, err := valueobject.NewDateRange(from, to)
+	if err != nil {
+		return nil, false
+	}
+
+	draws, err := uc.drawRepo.FindByDateRange(ctx, gameType, dateRange)
+	if err != nil {
+		logger.Warn("Failed to read local storage", zap.Error(err))
+		return nil, false
+	}
+	return draws, drawsUpTo(gameType, draws, to)
+}
+
+// drawsUpTo reports whether draws are non-empty and no scheduled draw falls
+// after the latest of them up to the calendar day of to
+func drawsUpTo(gameType valueobject.GameType, draws []*entity.Draw, to time.Time) bool {
+	if len(draws) == 0 {
+		return false
+	}
+
+	latest := draws[0].DrawDate
+	for _, draw := range draws[1:] {
+		if draw.DrawDate.After(latest) {
+			latest = draw.DrawDate
+		}
+	}
+	year, month, day := to.Date()
+	return gameType.NextDrawDate(latest).After(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+// backtestAlgorithm backtests a single algorithm, walking forward through
+// draws. Each prediction trains on the windowSize draws before it, or on
+// every earlier draw when windowSize is 0. Each Train and Predict call holds
+// one of slots.
 func (uc *BacktestUseCase) backtestAlgorithm(
 	ctx context.Context,
 	gameType valueobject.GameType,
 	algo algorithm.Algorithm,
 	draws []*entity.Draw,
+	windowSize int,
+	slots chan struct{},
 ) (*entity.BacktestResult, error) {
 	// Create test period range
 	startDate := draws[0].DrawDate
@@ -237,64 +379,52 @@ func (uc *BacktestUseCase) backtestAlgorithm(
 	if err != nil {
 		return nil, err
 	}
+	result.WindowMode = WindowExpanding
+	if windowSize > 0 {
+		result.WindowMode, result.WindowSize = WindowSliding, windowSize
+	}
 
 	// Walk through each draw (except last few used for training)
 	// Use minimum of 7 draws for training to allow at least 1 prediction test
-	minTrainingDraws := 7
 	if len(draws) <= minTrainingDraws {
 		return nil, fmt.Errorf("not enough draws for backtesting: need at least %d draws, got %d", minTrainingDraws+1, len(draws))
 	}
 
-	for i := minTrainingDraws; i < len(draws); i++ {
-		// Train on previous data
-		trainingDraws := draws[:i]
-		if err := algo.Train(ctx, trainingDraws); err != nil {
-			logger.Warn("Training failed",
-				zap.String("algorithm", algo.Name()),
-				zap.Int("iteration", i),
-				zap.Error(err),
-			)
-			continue
-		}
+	// Matches are collected by draw so concurrent predictions are recorded
+	// in draw order
+	matches := make([]*entity.PredictionMatch, len(draws))
+	predict := func(i int) {
+		slots <- struct{}{}
+		defer func() { <-slots }()
+		matches[i] = uc.predictDraw(ctx, gameType, algo, draws, i, windowSize)
+	}
 
-		// Predict next draw
-		actualDraw := draws[i]
-		prediction, err := algo.Predict(ctx, gameType, trainingDraws)
-		if err != nil {
-			logger.Warn("Prediction failed",
-				zap.String("algorithm", algo.Name()),
-				zap.Int("iteration", i),
-				zap.Error(err),
-			)
-			continue
+	if cp, ok := algo.(algorithm.ConcurrentPredictor); ok && cp.PredictsConcurrently() {
+		var wg sync.WaitGroup
+		for i := minTrainingDraws; i < len(draws); i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				predict(i)
+			}()
 		}
-
-		// Calculate match count
-		matchCount := actualDraw.Numbers.MatchCount(prediction.Numbers)
-
-		// Record match
-		match := entity.PredictionMatch{
-			PredictedNumbers: prediction.Numbers,
-			ActualNumbers:    actualDraw.Numbers,
-			MatchCount:       matchCount,
-			Confidence:       prediction.Confidence,
-			PredictionDate:   prediction.GeneratedAt,
-			ActualDrawDate:   actualDraw.DrawDate,
+		wg.Wait()
+	} else {
+		for i := minTrainingDraws; i < len(draws); i++ {
+			predict(i)
 		}
+	}
 
-		result.AddMatchResult(match)
+	for _, match := range matches {
+		if match != nil {
+			result.AddMatchResult(*match)
+		}
 	}
 
 	// Calculate metrics
 	result.CalculateMetrics()
-
-	// Save to repository
-	if err := uc.backtestRepo.Save(ctx, result); err != nil {
-		logger.Warn("Failed to save backtest result",
-			zap.String("algorithm", algo.Name()),
-			zap.Error(err),
-		)
-	}
+	result.ToolVersion = uc.toolVersion
+	result.ConfigHash = uc.configHash
 
 	logger.Info("Algorithm backtest completed",
 		zap.String("algorithm", algo.Name()),
@@ -306,3 +436,51 @@ func (uc *BacktestUseCase) backtestAlgorithm(
 
 	return result, nil
 }
+
+// predictDraw trains algo on the draws before draws[i] and predicts it,
+// returning nil when training or prediction fails
+func (uc *BacktestUseCase) predictDraw(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	algo algorithm.Algorithm,
+	draws []*entity.Draw,
+	i int,
+	windowSize int,
+) *entity.PredictionMatch {
+	// Train on previous data
+	trainingDraws := draws[:i]
+	if windowSize > 0 && i > windowSize {
+		trainingDraws = draws[i-windowSize : i]
+	}
+	if err := algo.Train(ctx, trainingDraws); err != nil {
+		logger.Warn("Training failed",
+			zap.String("algorithm", algo.Name()),
+			zap.Int("iteration", i),
+			zap.Error(err),
+		)
+		return nil
+	}
+
+	// Predict next draw
+	actualDraw := draws[i]
+	prediction, err := algo.Predict(ctx, gameType, trainingDraws)
+	if err != nil {
+		logger.Warn("Prediction failed",
+			zap.String("algorithm", algo.Name()),
+			zap.Int("iteration", i),
+			zap.Error(err),
+		)
+		return nil
+	}
+
+	// Record match
+	return &entity.PredictionMatch{
+		PredictedNumbers: prediction.Numbers,
+		ActualNumbers:    actualDraw.Numbers,
+		MatchCount:       actualDraw.Numbers.MatchCount(prediction.Numbers),
+		Confidence:       prediction.Confidence,
+		PredictionDate:   prediction.GeneratedAt,
+		ActualDrawDate:   actualDraw.DrawDate,
+		Winnings:         actualDraw.Payout(prediction.Numbers),
+	}
+}
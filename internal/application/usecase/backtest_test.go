@@ -0,0 +1,202 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/pkg/algorithm"
+)
+
+func newBacktestUseCase(t *testing.T, drawRepo *mockDrawRepository, scraper *mockScraper) (*BacktestUseCase, *mockBacktestRepository) {
+	t.Helper()
+
+	registry := algorithm.NewRegistry()
+	require.NoError(t, registry.Register(&fixedPickAlgorithm{numbers: []int{1, 2, 3, 4, 5, 6}}, 1.0))
+	backtestRepo := &mockBacktestRepository{}
+	return NewBacktestUseCase(drawRepo, backtestRepo, newMockStatsRepository(), registry, scraper), backtestRepo
+}
+
+func TestBacktestUseCase_Execute_Offline(t *testing.T) {
+	drawRepo := newMockDrawRepository(createMockDraws(valueobject.Mega645, 40)...)
+	uc, backtestRepo := newBacktestUseCase(t, drawRepo, &mockScraper{err: errors.New("network unreachable")})
+
+	result, err := uc.Execute(context.Background(), BacktestRequest{
+		GameType: valueobject.Mega645,
+		TestMode: "draws",
+		TestSize: 20,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 20, result.TotalPredictions)
+	require.Len(t, result.Results, 1)
+	assert.Len(t, backtestRepo.results, 1)
+
+	// The latest 20 stored draws, oldest first
+	draws := createMockDraws(valueobject.Mega645, 40)
+	assert.Equal(t, draws[20].DrawDate, result.Results[0].TestPeriod.StartDate)
+
+	// Each prediction is played as a ticket
+	backtest := result.Results[0]
+	assert.Equal(t, 13*entity.TicketPrice, backtest.TotalCost)
+	winnings := 0.0
+	for i, match := range backtest.DetailedResults {
+		assert.Equal(t, draws[27+i].Payout(match.PredictedNumbers), match.Winnings)
+		winnings += match.Winnings
+	}
+	assert.Equal(t, winnings, backtest.TotalWinnings)
+	assert.InDelta(t, (winnings-backtest.TotalCost)/backtest.TotalCost, backtest.ROI, 1e-9)
+}
+
+func TestBacktestUseCase_Execute_ScraperFillsInsufficientStorage(t *testing.T) {
+	drawRepo := newMockDrawRepository(createMockDraws(valueobject.Mega645, 10)...)
+	scraper := &mockScraper{draws: createMockDraws(valueobject.Mega645, 40)}
+	uc, _ := newBacktestUseCase(t, drawRepo, scraper)
+
+	result, err := uc.Execute(context.Background(), BacktestRequest{
+		GameType: valueobject.Mega645,
+		TestMode: "draws",
+		TestSize: 20,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 20, result.TotalPredictions)
+
+	// Falls back to what storage has when the scraper is down too
+	scraper.err = errors.New("network unreachable")
+	result, err = uc.Execute(context.Background(), BacktestRequest{
+		GameType: valueobject.Mega645,
+		TestMode: "draws",
+		TestSize: 20,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 10, result.TotalPredictions)
+}
+
+func TestBacktestUseCase_Execute_ScraperRefreshesStaleStorage(t *testing.T) {
+	// Storage has enough draws, but they stop long before today
+	drawRepo := newMockDrawRepository(createMockDraws(valueobject.Mega645, 40)...)
+	scraper := &mockScraper{draws: createMockDraws(valueobject.Mega645, 45)}
+	uc, _ := newBacktestUseCase(t, drawRepo, scraper)
+
+	result, err := uc.Execute(context.Background(), BacktestRequest{
+		GameType: valueobject.Mega645,
+		TestMode: "draws",
+		TestSize: 20,
+	})
+	require.NoError(t, err)
+
+	// The latest 20 fetched draws, not the latest 20 stored
+	require.Len(t, result.Results, 1)
+	assert.Equal(t, scraper.draws[25].DrawDate, result.Results[0].TestPeriod.StartDate)
+}
+
+func TestDrawsUpTo(t *testing.T) {
+	draws := createMockDraws(valueobject.Mega645, 7) // 1-7 Jan 2025, the 7th a Tuesday
+
+	assert.True(t, drawsUpTo(valueobject.Mega645, draws, time.Date(2025, 1, 7, 20, 0, 0, 0, time.UTC)))
+	// Wed 8 Jan is a Mega 6/45 draw day
+	assert.False(t, drawsUpTo(valueobject.Mega645, draws, time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, drawsUpTo(valueobject.Mega645, nil, time.Date(2025, 1, 7, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestBacktestUseCase_Execute_SlidingWindow(t *testing.T) {
+	drawRepo := newMockDrawRepository(createMockDraws(valueobject.Mega645, 40)...)
+	algo := &fixedPickAlgorithm{numbers: []int{1, 2, 3, 4, 5, 6}}
+	registry := algorithm.NewRegistry()
+	require.NoError(t, registry.Register(algo, 1.0))
+	uc := NewBacktestUseCase(drawRepo, &mockBacktestRepository{}, newMockStatsRepository(), registry, &mockScraper{})
+
+	result, err := uc.Execute(context.Background(), BacktestRequest{
+		GameType:   valueobject.Mega645,
+		TestMode:   "draws",
+		TestSize:   20,
+		WindowMode: WindowSliding,
+		WindowSize: 10,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, WindowSliding, result.WindowMode)
+	assert.Equal(t, WindowSliding, result.Results[0].WindowMode)
+	assert.Equal(t, 10, result.Results[0].WindowSize)
+
+	// Draws 8-10 train on all before them, later ones on the latest 10
+	assert.Equal(t, []int{7, 8, 9, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10}, algo.trainedOn)
+
+	// Expanding trains on every earlier draw
+	algo.trainedOn = nil
+	result, err = uc.Execute(context.Background(), BacktestRequest{
+		GameType: valueobject.Mega645,
+		TestMode: "draws",
+		TestSize: 20,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, WindowExpanding, result.Results[0].WindowMode)
+	assert.Zero(t, result.Results[0].WindowSize)
+	assert.Equal(t, 19, algo.trainedOn[len(algo.trainedOn)-1])
+}
+
+func TestBacktestUseCase_Execute_ConcurrencyKeepsResults(t *testing.T) {
+	run := func(concurrency int) *BacktestResult {
+		hotCold := algorithm.NewHotColdAnalyzer(1)
+		require.NoError(t, hotCold.SetMinDraws(10))
+		registry := algorithm.NewRegistry()
+		require.NoError(t, registry.Register(algorithm.NewFrequencyAnalyzer(1), 1))
+		require.NoError(t, registry.Register(hotCold, 1))
+		require.NoError(t, registry.Register(&fixedPickAlgorithm{numbers: []int{1, 2, 3, 4, 5, 6}}, 1))
+
+		drawRepo := newMockDrawRepository(createMockDraws(valueobject.Mega645, 60)...)
+		uc := NewBacktestUseCase(drawRepo, &mockBacktestRepository{}, newMockStatsRepository(), registry, &mockScraper{})
+		require.NoError(t, uc.SetConcurrency(concurrency))
+
+		result, err := uc.Execute(context.Background(), BacktestRequest{
+			GameType: valueobject.Mega645,
+			TestMode: "draws",
+			TestSize: 60,
+		})
+		require.NoError(t, err)
+		return result
+	}
+
+	sequential, concurrent := run(1), run(8)
+	require.Len(t, concurrent.Results, 3)
+	for i, result := range sequential.Results {
+		other := concurrent.Results[i]
+		assert.Equal(t, result.AlgorithmName, other.AlgorithmName)
+		require.Len(t, other.DetailedResults, len(result.DetailedResults))
+		for j, match := range result.DetailedResults {
+			assert.Equal(t, match.ActualDrawDate, other.DetailedResults[j].ActualDrawDate)
+			assert.Equal(t, match.PredictedNumbers, other.DetailedResults[j].PredictedNumbers)
+		}
+	}
+
+	uc, _ := newBacktestUseCase(t, newMockDrawRepository(), &mockScraper{})
+	assert.Error(t, uc.SetConcurrency(0))
+}
+
+func TestBacktestRequest_ValidateWindow(t *testing.T) {
+	mode, err := BacktestRequest{}.ValidateWindow()
+	require.NoError(t, err)
+	assert.Equal(t, WindowExpanding, mode)
+
+	_, err = BacktestRequest{WindowSize: 10}.ValidateWindow()
+	assert.ErrorContains(t, err, WindowSliding)
+	_, err = BacktestRequest{WindowMode: WindowSliding, WindowSize: 3}.ValidateWindow()
+	assert.Error(t, err)
+	_, err = BacktestRequest{WindowMode: "rolling"}.ValidateWindow()
+	assert.Error(t, err)
+}
+
+func TestBacktestUseCase_Execute_NoData(t *testing.T) {
+	uc, _ := newBacktestUseCase(t, newMockDrawRepository(), &mockScraper{err: errors.New("network unreachable")})
+
+	_, err := uc.Execute(context.Background(), BacktestRequest{
+		GameType: valueobject.Mega645,
+		TestMode: "draws",
+		TestSize: 20,
+	})
+	assert.ErrorContains(t, err, "no local data available")
+}
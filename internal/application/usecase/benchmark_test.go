@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/adapter/storage"
+	"github.com/tool_predict/internal/testutil/fixtures"
+)
+
+// BenchmarkFetchFromDate_Save measures saving a full history into a fresh
+// JSON store at different save concurrencies
+func BenchmarkFetchFromDate_Save(b *testing.B) {
+	ctx := context.Background()
+	scraper := &mockScraper{draws: fixtures.Draws(valueobject.Mega645, 500)}
+
+	for _, workers := range []int{1, 4, 8} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				b.StopTimer()
+				drawStorage, err := storage.NewJSONStorage(b.TempDir())
+				if err != nil {
+					b.Fatal(err)
+				}
+				uc := NewFetchHistoricalDataUseCase(drawStorage, scraper)
+				if err := uc.SetSaveConcurrency(workers); err != nil {
+					b.Fatal(err)
+				}
+				b.StartTimer()
+
+				if _, err := uc.FetchFromDate(ctx, valueobject.Mega645, time.Time{}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
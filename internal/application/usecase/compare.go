@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/repository"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"github.com/tool_predict/pkg/algorithm"
+	"go.uber.org/zap"
+)
+
+// CompareUseCase runs two registered algorithms on the same draws
+type CompareUseCase struct {
+	drawRepo repository.DrawRepository
+	registry *algorithm.Registry
+}
+
+// NewCompareUseCase creates a new algorithm comparison use case
+func NewCompareUseCase(
+	drawRepo repository.DrawRepository,
+	registry *algorithm.Registry,
+) *CompareUseCase {
+	return &CompareUseCase{
+		drawRepo: drawRepo,
+		registry: registry,
+	}
+}
+
+// CompareRequest contains the comparison parameters
+type CompareRequest struct {
+	GameType   valueobject.GameType
+	AlgorithmA string
+	AlgorithmB string
+	MaxDraws   int // Number of latest draws both algorithms see
+}
+
+// CompareResult contains the side-by-side comparison
+type CompareResult struct {
+	Comparison *algorithm.Comparison
+	DrawsUsed  int
+}
+
+// Execute predicts with both algorithms and compares their picks
+func (uc *CompareUseCase) Execute(ctx context.Context, req CompareRequest) (*CompareResult, error) {
+	if req.AlgorithmA == req.AlgorithmB {
+		return nil, fmt.Errorf("algorithms to compare must differ, got %s twice", req.AlgorithmA)
+	}
+
+	algoA, err := uc.registry.Get(req.AlgorithmA)
+	if err != nil {
+		return nil, err
+	}
+	algoB, err := uc.registry.Get(req.AlgorithmB)
+	if err != nil {
+		return nil, err
+	}
+
+	draws, err := uc.drawRepo.FindLatest(ctx, req.GameType, req.MaxDraws)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load historical data: %w", err)
+	}
+
+	logger.Info("Comparing algorithms",
+		zap.String("game_type", string(req.GameType)),
+		zap.String("algorithm_a", req.AlgorithmA),
+		zap.String("algorithm_b", req.AlgorithmB),
+		zap.Int("draws_count", len(draws)),
+	)
+
+	predA, rankA, err := predictAndRank(ctx, algoA, req.GameType, draws)
+	if err != nil {
+		return nil, err
+	}
+	predB, rankB, err := predictAndRank(ctx, algoB, req.GameType, draws)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CompareResult{
+		Comparison: algorithm.Compare(predA, predB, rankA, rankB),
+		DrawsUsed:  len(draws),
+	}, nil
+}
+
+// predictAndRank runs a single algorithm and, when it supports it, its full ranking
+func predictAndRank(
+	ctx context.Context,
+	algo algorithm.Algorithm,
+	gameType valueobject.GameType,
+	draws []*entity.Draw,
+) (*entity.Prediction, []int, error) {
+	pred, err := algo.Predict(ctx, gameType, draws)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s prediction failed: %w", algo.Name(), err)
+	}
+
+	ranker, ok := algo.(algorithm.Ranker)
+	if !ok {
+		return pred, nil, nil
+	}
+
+	ranking, err := ranker.RankNumbers(gameType, draws)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s ranking failed: %w", algo.Name(), err)
+	}
+	return pred, ranking, nil
+}
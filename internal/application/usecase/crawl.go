@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+// CrawlRequest contains the crawl parameters
+type CrawlRequest struct {
+	GameType valueobject.GameType
+	MaxDraws int        // Most recent draws to fetch; 0 for no cap when Since is set
+	Since    *time.Time // Only fetch draws on or after this date; nil for the latest MaxDraws
+}
+
+// CrawlResult reports what a crawl fetched and stored
+type CrawlResult struct {
+	GameType valueobject.GameType
+	Fetched  int // Draws the scraper returned within the request's bounds
+	Saved    int // Fetched draws written to the repository
+	New      int // Draws stored now that weren't before
+	Newest   *entity.Draw
+}
+
+// Crawl fetches draws from the scraper into the repository: every draw since
+// req.Since when set, capped at the req.MaxDraws newest, otherwise the latest
+// req.MaxDraws draws. Draws already stored are overwritten with the scraped
+// result, so re-crawling is safe.
+func (uc *FetchHistoricalDataUseCase) Crawl(ctx context.Context, req CrawlRequest) (*CrawlResult, error) {
+	if err := req.GameType.Validate(); err != nil {
+		return nil, err
+	}
+	if req.MaxDraws < 0 || (req.MaxDraws == 0 && req.Since == nil) {
+		return nil, fmt.Errorf("max draws must be positive without a since date, got %d", req.MaxDraws)
+	}
+
+	logger.Info("Crawling draws",
+		zap.String("game_type", string(req.GameType)),
+		zap.Int("max_draws", req.MaxDraws),
+		zap.Timep("since", req.Since),
+	)
+
+	before, err := uc.drawRepo.Count(ctx, req.GameType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count stored draws: %w", err)
+	}
+
+	var draws []*entity.Draw
+	if req.Since != nil {
+		draws, err = uc.scraper.FetchAllDraws(ctx, req.GameType, *req.Since)
+	} else {
+		draws, err = uc.scraper.FetchLatestDraws(ctx, req.GameType, req.MaxDraws)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch draws from scraper: %w", err)
+	}
+	draws = boundCrawledDraws(draws, req)
+
+	uc.enrichDraws(ctx, req.GameType, draws)
+	saved := uc.saveDraws(ctx, draws)
+
+	after, err := uc.drawRepo.Count(ctx, req.GameType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count stored draws: %w", err)
+	}
+
+	result := &CrawlResult{
+		GameType: req.GameType,
+		Fetched:  len(draws),
+		Saved:    saved,
+		New:      int(max(after-before, 0)),
+	}
+	if len(draws) > 0 {
+		result.Newest = draws[0]
+	}
+
+	logger.Info("Crawl finished",
+		zap.String("game_type", string(req.GameType)),
+		zap.Int("fetched", result.Fetched),
+		zap.Int("saved", result.Saved),
+		zap.Int("new", result.New),
+	)
+	return result, nil
+}
+
+// boundCrawledDraws orders draws newest first and drops those before
+// req.Since and beyond the req.MaxDraws newest
+func boundCrawledDraws(draws []*entity.Draw, req CrawlRequest) []*entity.Draw {
+	bounded := make([]*entity.Draw, 0, len(draws))
+	for _, draw := range draws {
+		if req.Since != nil && draw.DrawDate.Before(*req.Since) {
+			continue
+		}
+		bounded = append(bounded, draw)
+	}
+	sort.SliceStable(bounded, func(i, j int) bool {
+		return bounded[i].DrawNumber > bounded[j].DrawNumber
+	})
+	if req.MaxDraws > 0 && len(bounded) > req.MaxDraws {
+		bounded = bounded[:req.MaxDraws]
+	}
+	return bounded
+}
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func TestCrawl_LatestDraws(t *testing.T) {
+	ctx := context.Background()
+	gt := valueobject.Mega645
+	scraped := createMockDraws(gt, 30)
+
+	// The five newest are already stored, so only the other five are new
+	drawRepo := newMockDrawRepository(scraped[25:]...)
+	uc := NewFetchHistoricalDataUseCase(drawRepo, &mockScraper{draws: scraped})
+
+	result, err := uc.Crawl(ctx, CrawlRequest{GameType: gt, MaxDraws: 10})
+	require.NoError(t, err)
+
+	assert.Equal(t, 10, result.Fetched)
+	assert.Equal(t, 10, result.Saved)
+	assert.Equal(t, 5, result.New)
+	require.NotNil(t, result.Newest)
+	assert.Equal(t, 30, result.Newest.DrawNumber)
+
+	count, err := drawRepo.Count(ctx, gt)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), count)
+}
+
+func TestCrawl_SinceCappedAtMaxDraws(t *testing.T) {
+	ctx := context.Background()
+	gt := valueobject.Power655
+	scraped := createMockDraws(gt, 30)
+	since := scraped[10].DrawDate
+
+	drawRepo := newMockDrawRepository()
+	uc := NewFetchHistoricalDataUseCase(drawRepo, &mockScraper{draws: scraped})
+
+	result, err := uc.Crawl(ctx, CrawlRequest{GameType: gt, Since: &since})
+	require.NoError(t, err)
+	assert.Equal(t, 20, result.Fetched)
+	assert.Equal(t, 20, result.New)
+
+	// A cap keeps the newest draws since the date
+	drawRepo = newMockDrawRepository()
+	uc = NewFetchHistoricalDataUseCase(drawRepo, &mockScraper{draws: scraped})
+
+	result, err = uc.Crawl(ctx, CrawlRequest{GameType: gt, Since: &since, MaxDraws: 5})
+	require.NoError(t, err)
+	assert.Equal(t, 5, result.Fetched)
+	assert.Equal(t, 30, result.Newest.DrawNumber)
+	_, err = drawRepo.FindByID(ctx, scraped[24].ID)
+	assert.Error(t, err, "draws beyond the cap must not be stored")
+}
+
+func TestCrawl_InvalidRequest(t *testing.T) {
+	uc := NewFetchHistoricalDataUseCase(newMockDrawRepository(), &mockScraper{})
+
+	_, err := uc.Crawl(context.Background(), CrawlRequest{GameType: valueobject.Mega645})
+	assert.Error(t, err)
+
+	_, err = uc.Crawl(context.Background(), CrawlRequest{GameType: "LOTO", MaxDraws: 10})
+	assert.Error(t, err)
+}
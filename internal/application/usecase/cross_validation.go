@@ -0,0 +1,226 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"sort"
+
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/repository"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"github.com/tool_predict/pkg/algorithm"
+	"go.uber.org/zap"
+)
+
+// CrossValidationUseCase backtests algorithms on test periods sampled at
+// random from the whole stored history rather than only the latest draws, so
+// comparisons aren't decided by how the algorithms did recently
+type CrossValidationUseCase struct {
+	drawRepo repository.DrawRepository
+	registry *algorithm.Registry
+}
+
+// NewCrossValidationUseCase creates a new cross-validation use case
+func NewCrossValidationUseCase(drawRepo repository.DrawRepository, registry *algorithm.Registry) *CrossValidationUseCase {
+	return &CrossValidationUseCase{
+		drawRepo: drawRepo,
+		registry: registry,
+	}
+}
+
+// CrossValidationRequest contains the cross-validation parameters
+type CrossValidationRequest struct {
+	GameType   valueobject.GameType
+	Folds      int      // Disjoint test periods sampled
+	FoldSize   int      // Consecutive draws predicted per fold
+	MaxDraws   int      // Latest draws before each predicted draw the algorithms see
+	Seed       uint64   // Seeds the fold sampling; the same seed samples the same folds
+	Algorithms []string // Algorithms to validate; empty validates all
+}
+
+// CrossValidationFold is one sampled test period
+type CrossValidationFold struct {
+	Period    valueobject.DateRange
+	FirstDraw int
+	LastDraw  int
+}
+
+// AlgorithmCrossValidation is an algorithm's record across the folds
+type AlgorithmCrossValidation struct {
+	AlgorithmName string
+	FoldAverages  []float64 // Mean numbers matched per draw in each fold
+	Mean          float64   // Mean of FoldAverages
+	StdDev        float64   // Sample standard deviation of FoldAverages
+	Failed        int       // Draws the algorithm couldn't predict
+}
+
+// CrossValidationResult contains the cross-validation results
+type CrossValidationResult struct {
+	GameType   valueobject.GameType
+	Folds      []CrossValidationFold
+	Algorithms []AlgorithmCrossValidation
+}
+
+// Execute samples req.Folds disjoint blocks of req.FoldSize stored draws,
+// each with enough draws before it for every algorithm, and predicts every
+// draw of every block from the draws before it. Algorithms are reported by
+// name; folds oldest first.
+func (uc *CrossValidationUseCase) Execute(ctx context.Context, req CrossValidationRequest) (*CrossValidationResult, error) {
+	if err := req.GameType.Validate(); err != nil {
+		return nil, err
+	}
+	if req.Folds < 2 {
+		return nil, fmt.Errorf("cross-validation needs at least 2 folds, got %d", req.Folds)
+	}
+	if req.FoldSize <= 0 {
+		return nil, fmt.Errorf("fold size must be positive, got %d", req.FoldSize)
+	}
+	if req.MaxDraws <= 0 {
+		return nil, fmt.Errorf("max draws must be positive, got %d", req.MaxDraws)
+	}
+
+	var algorithms []algorithm.Algorithm
+	minHistory := 1
+	for _, algo := range uc.registry.GetAll() {
+		if len(req.Algorithms) == 0 || slices.Contains(req.Algorithms, algo.Name()) {
+			algorithms = append(algorithms, algo)
+			minHistory = max(minHistory, algo.GetMinDraws())
+		}
+	}
+	if len(algorithms) == 0 {
+		return nil, fmt.Errorf("no algorithms to cross-validate")
+	}
+	sort.Slice(algorithms, func(i, j int) bool { return algorithms[i].Name() < algorithms[j].Name() })
+	if minHistory > req.MaxDraws {
+		return nil, fmt.Errorf("max draws %d is below the %d draws the algorithms need", req.MaxDraws, minHistory)
+	}
+
+	count, err := uc.drawRepo.Count(ctx, req.GameType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count draws: %w", err)
+	}
+	var history []*entity.Draw
+	if count > 0 {
+		history, err = uc.drawRepo.FindLatest(ctx, req.GameType, int(count))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load draws: %w", err)
+		}
+	}
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].DrawDate.Before(history[j].DrawDate)
+	})
+
+	starts, err := sampleFolds(len(history), minHistory, req.FoldSize, req.Folds, req.Seed)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CrossValidationResult{GameType: req.GameType}
+	for _, start := range starts {
+		first, last := history[start], history[start+req.FoldSize-1]
+		period, err := valueobject.NewDateRange(first.DrawDate, last.DrawDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fold period: %w", err)
+		}
+		result.Folds = append(result.Folds, CrossValidationFold{
+			Period:    period,
+			FirstDraw: first.DrawNumber,
+			LastDraw:  last.DrawNumber,
+		})
+	}
+
+	logger.Info("Starting cross-validation",
+		zap.String("game_type", string(req.GameType)),
+		zap.Int("folds", req.Folds),
+		zap.Int("fold_size", req.FoldSize),
+		zap.Int("algorithms", len(algorithms)),
+	)
+
+	for _, algo := range algorithms {
+		record := AlgorithmCrossValidation{AlgorithmName: algo.Name()}
+		for _, start := range starts {
+			matches, predicted := 0, 0
+			for i := start; i < start+req.FoldSize; i++ {
+				select {
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				default:
+				}
+
+				training := history[max(0, i-req.MaxDraws):i]
+				if err := algo.Train(ctx, training); err != nil {
+					record.Failed++
+					continue
+				}
+				prediction, err := algo.Predict(ctx, req.GameType, training)
+				if err != nil {
+					record.Failed++
+					continue
+				}
+				matches += history[i].Numbers.MatchCount(prediction.Numbers)
+				predicted++
+			}
+
+			average := 0.0
+			if predicted > 0 {
+				average = float64(matches) / float64(predicted)
+			}
+			record.FoldAverages = append(record.FoldAverages, average)
+		}
+		record.Mean, record.StdDev = meanStdDev(record.FoldAverages)
+		result.Algorithms = append(result.Algorithms, record)
+
+		logger.Info("Algorithm cross-validated",
+			zap.String("algorithm", algo.Name()),
+			zap.Float64("mean", record.Mean),
+			zap.Float64("std_dev", record.StdDev),
+			zap.Int("failed", record.Failed),
+		)
+	}
+
+	return result, nil
+}
+
+// sampleFolds picks folds disjoint blocks of size draws from the n draws,
+// none starting before minStart, and returns their start indexes ascending.
+// Blocks are laid end to end from the end of history, so the latest draws
+// always belong to one that can be sampled.
+func sampleFolds(n, minStart, size, folds int, seed uint64) ([]int, error) {
+	blocks := (n - minStart) / size
+	if blocks < folds {
+		return nil, fmt.Errorf("not enough draws for %d folds of %d: %d stored, %d needed before the first fold, room for %d",
+			folds, size, n, minStart, max(blocks, 0))
+	}
+
+	rng := rand.New(rand.NewPCG(seed, seed))
+	starts := make([]int, 0, folds)
+	for _, block := range rng.Perm(blocks)[:folds] {
+		starts = append(starts, n-(block+1)*size)
+	}
+	sort.Ints(starts)
+	return starts, nil
+}
+
+// meanStdDev returns the mean and sample standard deviation of values
+func meanStdDev(values []float64) (mean, stdDev float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+	if len(values) < 2 {
+		return mean, 0
+	}
+
+	variance := 0.0
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(variance / float64(len(values)-1))
+}
@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/pkg/algorithm"
+)
+
+func TestSampleFolds(t *testing.T) {
+	starts, err := sampleFolds(100, 10, 15, 4, 7)
+	require.NoError(t, err)
+	require.Len(t, starts, 4)
+	assert.IsIncreasing(t, starts)
+	for i, start := range starts {
+		assert.GreaterOrEqual(t, start, 10)
+		assert.Zero(t, (100-start)%15, "blocks end at the latest draw")
+		if i > 0 {
+			assert.GreaterOrEqual(t, start-starts[i-1], 15, "folds are disjoint")
+		}
+	}
+
+	again, err := sampleFolds(100, 10, 15, 4, 7)
+	require.NoError(t, err)
+	assert.Equal(t, starts, again)
+
+	// 90 draws after the first 10 hold only 6 blocks of 15
+	_, err = sampleFolds(100, 10, 15, 7, 7)
+	assert.Error(t, err)
+}
+
+func TestCrossValidationUseCase_Execute(t *testing.T) {
+	draws := createMockDraws(valueobject.Mega645, 60)
+	registry := algorithm.NewRegistry()
+	require.NoError(t, registry.Register(&fixedPickAlgorithm{numbers: []int{1, 2, 3, 4, 5, 6}}, 1))
+	uc := NewCrossValidationUseCase(newMockDrawRepository(draws...), registry)
+
+	result, err := uc.Execute(context.Background(), CrossValidationRequest{
+		GameType: valueobject.Mega645,
+		Folds:    3,
+		FoldSize: 10,
+		MaxDraws: 20,
+		Seed:     42,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Folds, 3)
+	require.Len(t, result.Algorithms, 1)
+
+	record := result.Algorithms[0]
+	assert.Equal(t, "fixed_pick", record.AlgorithmName)
+	require.Len(t, record.FoldAverages, 3)
+	assert.Zero(t, record.Failed)
+	mean, stdDev := meanStdDev(record.FoldAverages)
+	assert.Equal(t, mean, record.Mean)
+	assert.Equal(t, stdDev, record.StdDev)
+	for i, fold := range result.Folds {
+		assert.Equal(t, fold.LastDraw-fold.FirstDraw, 9)
+		if i > 0 {
+			assert.Greater(t, fold.FirstDraw, result.Folds[i-1].LastDraw)
+		}
+	}
+
+	_, err = uc.Execute(context.Background(), CrossValidationRequest{
+		GameType: valueobject.Mega645,
+		Folds:    6,
+		FoldSize: 10,
+		MaxDraws: 20,
+	})
+	assert.Error(t, err, "60 draws can't hold 6 folds after the first")
+
+	_, err = uc.Execute(context.Background(), CrossValidationRequest{
+		GameType: valueobject.Mega645,
+		Folds:    1,
+		FoldSize: 10,
+		MaxDraws: 20,
+	})
+	assert.Error(t, err)
+}
+
+func TestMeanStdDev(t *testing.T) {
+	mean, stdDev := meanStdDev([]float64{1, 2, 3, 4})
+	assert.InDelta(t, 2.5, mean, 1e-9)
+	assert.InDelta(t, 1.2910, stdDev, 1e-4)
+
+	mean, stdDev = meanStdDev([]float64{0.8})
+	assert.Equal(t, 0.8, mean)
+	assert.Zero(t, stdDev)
+}
@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+// DrawCycleUseCase runs a game's routine after each draw: fetch the latest
+// draws, verify the stored predictions for them and predict the next draw.
+// The daemon runs it on each game's schedule.
+type DrawCycleUseCase struct {
+	fetch   *FetchHistoricalDataUseCase
+	verify  *VerifyPredictionsUseCase
+	predict *PredictUseCase
+}
+
+// NewDrawCycleUseCase creates a new draw cycle use case
+func NewDrawCycleUseCase(
+	fetch *FetchHistoricalDataUseCase,
+	verify *VerifyPredictionsUseCase,
+	predict *PredictUseCase,
+) *DrawCycleUseCase {
+	return &DrawCycleUseCase{
+		fetch:   fetch,
+		verify:  verify,
+		predict: predict,
+	}
+}
+
+// DrawCycleRequest contains the draw cycle parameters
+type DrawCycleRequest struct {
+	GameType      valueobject.GameType
+	DrawDate      time.Time     // Day of the draw to wait for; zero takes whatever is published
+	FetchLimit    int           // Latest draws fetched, and verified, per attempt; 1 if zero
+	MaxDraws      int           // Latest draws the prediction learns from
+	RetryInterval time.Duration // Wait between fetches while the DrawDate draw isn't published
+	MaxRetries    int           // Fetches retried before going on without the DrawDate draw
+}
+
+// DrawCycleResult reports what a draw cycle fetched, verified and predicted
+type DrawCycleResult struct {
+	GameType   valueobject.GameType
+	Attempts   int          // Fetches made
+	Latest     *entity.Draw // Newest fetched draw; nil when none was
+	Published  bool         // The DrawDate draw was fetched
+	Verified   []VerifiedPrediction
+	Prediction *EnsembleResult
+}
+
+// Execute fetches the latest draws, retrying while the DrawDate draw isn't
+// published yet, then verifies the predictions for the fetched draws and
+// predicts the next draw. A failed verification is logged and the next
+// draw is still predicted; once retries run out the prediction is made
+// without the missing draw.
+func (uc *DrawCycleUseCase) Execute(ctx context.Context, req DrawCycleRequest) (*DrawCycleResult, error) {
+	if err := req.GameType.Validate(); err != nil {
+		return nil, err
+	}
+	if req.FetchLimit < 0 || req.MaxRetries < 0 || req.RetryInterval < 0 {
+		return nil, fmt.Errorf("fetch limit, retries and retry interval cannot be negative")
+	}
+	fetchLimit := max(req.FetchLimit, 1)
+
+	result := &DrawCycleResult{GameType: req.GameType}
+	for {
+		result.Attempts++
+		draws, err := uc.fetch.FetchLatest(ctx, req.GameType, fetchLimit)
+		if err == nil {
+			result.Latest = newestDraw(draws)
+			result.Published = req.DrawDate.IsZero() || publishedOn(draws, req.DrawDate)
+		}
+		if err == nil && result.Published {
+			break
+		}
+		if result.Attempts > req.MaxRetries {
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch draws after %d attempt(s): %w", result.Attempts, err)
+			}
+			logger.Warn("Draw not published, predicting without it",
+				zap.String("game_type", string(req.GameType)),
+				zap.String("draw_date", req.DrawDate.Format("2006-01-02")),
+				zap.Int("attempts", result.Attempts),
+			)
+			break
+		}
+
+		logger.Info("Draw not fetched yet, retrying",
+			zap.String("game_type", string(req.GameType)),
+			zap.String("draw_date", req.DrawDate.Format("2006-01-02")),
+			zap.Int("attempt", result.Attempts),
+			zap.Duration("retry_in", req.RetryInterval),
+			zap.Error(err),
+		)
+		// Checked first since select picks at random when the wait is over too
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(req.RetryInterval):
+		}
+	}
+
+	if result.Latest != nil {
+		verified, err := uc.verify.Execute(ctx, VerifyPredictionsRequest{GameType: req.GameType, Draws: fetchLimit})
+		if err != nil {
+			logger.Warn("Failed to verify predictions", zap.String("game_type", string(req.GameType)), zap.Error(err))
+		}
+		result.Verified = verified
+	}
+
+	prediction, err := uc.predict.Execute(ctx, PredictRequest{GameType: req.GameType, MaxDraws: req.MaxDraws})
+	if err != nil {
+		return nil, fmt.Errorf("failed to predict the next draw: %w", err)
+	}
+	result.Prediction = prediction
+	return result, nil
+}
+
+// newestDraw returns the highest numbered of draws, or nil if there are none
+func newestDraw(draws []*entity.Draw) *entity.Draw {
+	var newest *entity.Draw
+	for _, draw := range draws {
+		if newest == nil || draw.DrawNumber > newest.DrawNumber {
+			newest = draw
+		}
+	}
+	return newest
+}
+
+// publishedOn reports whether one of draws was drawn on date's calendar day
+func publishedOn(draws []*entity.Draw, date time.Time) bool {
+	year, month, day := date.Date()
+	for _, draw := range draws {
+		y, m, d := draw.DrawDate.Date()
+		if y == year && m == month && d == day {
+			return true
+		}
+	}
+	return false
+}
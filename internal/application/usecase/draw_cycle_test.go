@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/pkg/algorithm"
+)
+
+// publishingScraper serves its draws but the last until the given fetch,
+// as the website does before a draw's results are out
+type publishingScraper struct {
+	mockScraper
+	publishOnFetch int
+	fetches        int
+}
+
+func (s *publishingScraper) FetchLatestDraws(ctx context.Context, gameType valueobject.GameType, limit int) ([]*entity.Draw, error) {
+	s.fetches++
+	draws := s.draws
+	if s.fetches < s.publishOnFetch {
+		draws = draws[:len(draws)-1]
+	}
+	return (&mockScraper{draws: draws, err: s.err}).FetchLatestDraws(ctx, gameType, limit)
+}
+
+// newTestDrawCycle wires a draw cycle over in-memory storage holding all of
+// draws but the last, which scraper publishes on its publishOnFetch fetch
+func newTestDrawCycle(t *testing.T, draws []*entity.Draw, publishOnFetch int) (*DrawCycleUseCase, *publishingScraper, *mockPredictionRepository) {
+	t.Helper()
+
+	drawRepo := newMockDrawRepository(draws[:len(draws)-1]...)
+	predictionRepo := &mockPredictionRepository{}
+	scraper := &publishingScraper{mockScraper: mockScraper{draws: draws}, publishOnFetch: publishOnFetch}
+
+	registry := algorithm.NewRegistry()
+	require.NoError(t, registry.Register(algorithm.NewFrequencyAnalyzer(1.0), 1.0))
+	require.NoError(t, registry.Register(algorithm.NewHotColdAnalyzer(1.0), 1.0))
+
+	uc := NewDrawCycleUseCase(
+		NewFetchHistoricalDataUseCase(drawRepo, scraper),
+		NewVerifyPredictionsUseCase(drawRepo, predictionRepo, newMockStatsRepository()),
+		NewPredictUseCase(drawRepo, predictionRepo, algorithm.NewEnsemble(registry, algorithm.WeightedVoting), scraper, nil),
+	)
+	return uc, scraper, predictionRepo
+}
+
+func TestDrawCycleUseCase_Execute(t *testing.T) {
+	draws := createMockDraws(valueobject.Mega645, 60)
+	latest := draws[len(draws)-1]
+	uc, _, predictionRepo := newTestDrawCycle(t, draws, 2)
+
+	ensemble := newTargetedEnsemble(t, latest, []int(latest.Numbers), map[string][]int{
+		"frequency_analysis": []int(latest.Numbers),
+	})
+	require.NoError(t, predictionRepo.SaveEnsemble(context.Background(), ensemble))
+
+	result, err := uc.Execute(context.Background(), DrawCycleRequest{
+		GameType:   valueobject.Mega645,
+		DrawDate:   latest.DrawDate,
+		FetchLimit: 3,
+		MaxDraws:   50,
+		MaxRetries: 2,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, result.Attempts, "retried once until the draw was published")
+	assert.True(t, result.Published)
+	assert.Equal(t, latest.DrawNumber, result.Latest.DrawNumber)
+
+	require.Len(t, result.Verified, 3)
+	require.NotNil(t, result.Verified[0].Prediction)
+	assert.Equal(t, 6, result.Verified[0].Prediction.Outcome.MatchCount)
+
+	require.NotNil(t, result.Prediction)
+	assert.True(t, result.Prediction.Prediction.ForDate.After(latest.DrawDate), "the new prediction targets a later draw")
+}
+
+func TestDrawCycleUseCase_Execute_PredictsWithoutUnpublishedDraw(t *testing.T) {
+	draws := createMockDraws(valueobject.Mega645, 60)
+	uc, _, _ := newTestDrawCycle(t, draws, 10)
+
+	result, err := uc.Execute(context.Background(), DrawCycleRequest{
+		GameType:   valueobject.Mega645,
+		DrawDate:   draws[len(draws)-1].DrawDate,
+		MaxDraws:   50,
+		MaxRetries: 1,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Attempts)
+	assert.False(t, result.Published)
+	assert.Equal(t, draws[len(draws)-2].DrawNumber, result.Latest.DrawNumber)
+	assert.NotNil(t, result.Prediction)
+}
+
+func TestDrawCycleUseCase_Execute_FetchFails(t *testing.T) {
+	draws := createMockDraws(valueobject.Mega645, 60)
+	uc, scraper, _ := newTestDrawCycle(t, draws, 1)
+	scraper.err = errors.New("site down")
+
+	_, err := uc.Execute(context.Background(), DrawCycleRequest{GameType: valueobject.Mega645, MaxDraws: 50, MaxRetries: 1})
+	require.Error(t, err)
+	assert.Equal(t, 2, scraper.fetches)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = uc.Execute(ctx, DrawCycleRequest{GameType: valueobject.Mega645, MaxRetries: 3, RetryInterval: 1})
+	assert.ErrorIs(t, err, context.Canceled)
+}
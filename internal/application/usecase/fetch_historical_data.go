@@ -2,7 +2,11 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/tool_predict/internal/application/port"
@@ -15,8 +19,12 @@ import (
 
 // FetchHistoricalDataUseCase fetches historical lottery data from Vietlott
 type FetchHistoricalDataUseCase struct {
-	drawRepo repository.DrawRepository
-	scraper  port.VietlottScraper
+	drawRepo      repository.DrawRepository
+	scraper       port.VietlottScraper
+	detailFetcher port.DrawDetailFetcher // Optional, nil skips detail enrichment
+	workers       int                    // Concurrent draw saves
+	notifier      port.DrawNotifier      // Optional, nil skips new draw notifications
+	predictions   repository.PredictionRepository
 }
 
 // NewFetchHistoricalDataUseCase creates a new use case
@@ -27,9 +35,36 @@ func NewFetchHistoricalDataUseCase(
 	return &FetchHistoricalDataUseCase{
 		drawRepo: drawRepo,
 		scraper:  scraper,
+		workers:  1,
 	}
 }
 
+// SetDetailFetcher enables filling in the jackpot and winners of newly
+// fetched draws from their detail pages. This costs one extra rate-limited
+// request per new draw; nil disables it.
+func (uc *FetchHistoricalDataUseCase) SetDetailFetcher(fetcher port.DrawDetailFetcher) {
+	uc.detailFetcher = fetcher
+}
+
+// SetSaveConcurrency sets how many draws are saved at once, which speeds up
+// large backfills. The default of 1 saves draws one at a time.
+func (uc *FetchHistoricalDataUseCase) SetSaveConcurrency(workers int) error {
+	if workers < 1 {
+		return fmt.Errorf("save concurrency must be at least 1, got %d", workers)
+	}
+	uc.workers = workers
+	return nil
+}
+
+// SetDrawNotifier makes FetchLatest announce the newest draw it stores that
+// wasn't stored before, with the ensemble prediction predictionRepo holds
+// for its date. predictionRepo may be nil to announce the draw alone; a nil
+// notifier disables notifications.
+func (uc *FetchHistoricalDataUseCase) SetDrawNotifier(notifier port.DrawNotifier, predictionRepo repository.PredictionRepository) {
+	uc.notifier = notifier
+	uc.predictions = predictionRepo
+}
+
 // FetchLatest fetches the latest draws for a game type
 func (uc *FetchHistoricalDataUseCase) FetchLatest(
 	ctx context.Context,
@@ -47,23 +82,152 @@ func (uc *FetchHistoricalDataUseCase) FetchLatest(
 		return nil, fmt.Errorf("failed to fetch draws from scraper: %w", err)
 	}
 
+	uc.enrichDraws(ctx, gameType, draws)
+
+	// Draws above the latest stored one are new
+	previousLatest := 0
+	if uc.notifier != nil {
+		previousLatest, _ = uc.drawRepo.GetLatestDrawNumber(ctx, gameType)
+	}
+
 	// Save to repository
+	uc.saveDraws(ctx, draws)
+
+	logger.Info("Successfully fetched and saved draws",
+		zap.String("game_type", string(gameType)),
+		zap.Int("count", len(draws)),
+	)
+
+	if uc.notifier != nil {
+		uc.notifyNewDraw(ctx, gameType, draws, previousLatest)
+	}
+
+	return draws, nil
+}
+
+// notifyNewDraw announces the newest of draws if it's above previousLatest.
+// A failed notification only logs a warning.
+func (uc *FetchHistoricalDataUseCase) notifyNewDraw(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	draws []*entity.Draw,
+	previousLatest int,
+) {
+	var newest *entity.Draw
 	for _, draw := range draws {
-		if err := uc.drawRepo.Save(ctx, draw); err != nil {
-			logger.Warn("Failed to save draw",
-				zap.String("draw_id", draw.ID),
+		if draw.DrawNumber > previousLatest && (newest == nil || draw.DrawNumber > newest.DrawNumber) {
+			newest = draw
+		}
+	}
+	if newest == nil {
+		return
+	}
+
+	var prediction *entity.EnsemblePrediction
+	if uc.predictions != nil {
+		year, month, day := newest.DrawDate.Date()
+		forDate := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		found, err := uc.predictions.FindCurrentEnsemble(ctx, gameType, forDate)
+		switch {
+		case err == nil:
+			prediction = found
+		case !errors.Is(err, repository.ErrNotFound):
+			logger.Warn("Failed to load prediction for draw notification, sending draw alone",
+				zap.Int("draw_number", newest.DrawNumber),
 				zap.Error(err),
 			)
-			// Continue saving other draws
 		}
 	}
 
-	logger.Info("Successfully fetched and saved draws",
+	if err := uc.notifier.NotifyDraw(ctx, newest, prediction); err != nil {
+		logger.Warn("Failed to send draw notification",
+			zap.Int("draw_number", newest.DrawNumber),
+			zap.Error(err),
+		)
+		return
+	}
+
+	logger.Info("Sent draw notification",
+		zap.Int("draw_number", newest.DrawNumber),
+		zap.Bool("with_prediction", prediction != nil),
+	)
+}
+
+// DrawMismatchReason describes how a scraped draw differs from storage
+type DrawMismatchReason string
+
+const (
+	MismatchMissing DrawMismatchReason = "missing_locally" // Scraped but not stored
+	MismatchNumbers DrawMismatchReason = "numbers_differ"
+	MismatchDate    DrawMismatchReason = "date_differs"
+)
+
+// DrawMismatch is a scraped draw that doesn't match its stored copy
+type DrawMismatch struct {
+	DrawNumber int
+	Reasons    []DrawMismatchReason
+	Scraped    *entity.Draw
+	Stored     *entity.Draw // Nil when missing locally
+}
+
+// VerifyLatest scrapes the latest draws and compares them with storage
+// without writing anything. It returns the draws checked and those that
+// don't match, catching both scraper drift and corrupted storage. Failing to
+// read a stored draw is an error, not a draw missing locally.
+func (uc *FetchHistoricalDataUseCase) VerifyLatest(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	limit int,
+) ([]*entity.Draw, []DrawMismatch, error) {
+	logger.Info("Verifying stored draws against scraped draws",
 		zap.String("game_type", string(gameType)),
-		zap.Int("count", len(draws)),
+		zap.Int("limit", limit),
 	)
 
-	return draws, nil
+	scraped, err := uc.scraper.FetchLatestDraws(ctx, gameType, limit)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch draws from scraper: %w", err)
+	}
+
+	mismatches := make([]DrawMismatch, 0)
+	for _, draw := range scraped {
+		stored, err := uc.drawRepo.FindByGameTypeAndDrawNumber(ctx, gameType, draw.DrawNumber)
+		if err != nil && !errors.Is(err, repository.ErrNotFound) {
+			return nil, nil, fmt.Errorf("failed to load stored draw #%d: %w", draw.DrawNumber, err)
+		}
+		if stored == nil {
+			mismatches = append(mismatches, DrawMismatch{
+				DrawNumber: draw.DrawNumber,
+				Reasons:    []DrawMismatchReason{MismatchMissing},
+				Scraped:    draw,
+			})
+			continue
+		}
+		if stored.Equals(draw) {
+			continue
+		}
+
+		mismatch := DrawMismatch{DrawNumber: draw.DrawNumber, Scraped: draw, Stored: stored}
+		if len(stored.Numbers) != len(draw.Numbers) || stored.Numbers.MatchCount(draw.Numbers) != len(draw.Numbers) {
+			mismatch.Reasons = append(mismatch.Reasons, MismatchNumbers)
+		}
+		if !stored.DrawDate.Equal(draw.DrawDate) {
+			mismatch.Reasons = append(mismatch.Reasons, MismatchDate)
+		}
+		mismatches = append(mismatches, mismatch)
+	}
+
+	sort.Slice(mismatches, func(i, j int) bool {
+		return mismatches[i].DrawNumber > mismatches[j].DrawNumber
+	})
+
+	logger.Info("Verified stored draws",
+		zap.String("game_type", string(gameType)),
+		zap.Int("checked", len(scraped)),
+		zap.Int("mismatches", len(mismatches)),
+	)
+
+	return scraped, mismatches, nil
 }
 
 // FetchFromDate fetches all draws from a specified date onwards
@@ -83,18 +247,10 @@ func (uc *FetchHistoricalDataUseCase) FetchFromDate(
 		return nil, fmt.Errorf("failed to fetch draws from scraper: %w", err)
 	}
 
+	uc.enrichDraws(ctx, gameType, draws)
+
 	// Save to repository
-	savedCount := 0
-	for _, draw := range draws {
-		if err := uc.drawRepo.Save(ctx, draw); err != nil {
-			logger.Warn("Failed to save draw",
-				zap.String("draw_id", draw.ID),
-				zap.Error(err),
-			)
-			continue
-		}
-		savedCount++
-	}
+	savedCount := uc.saveDraws(ctx, draws)
 
 	logger.Info("Successfully fetched and saved historical draws",
 		zap.String("game_type", string(gameType)),
@@ -137,17 +293,90 @@ func (uc *FetchHistoricalDataUseCase) FetchByRange(
 		return nil, fmt.Errorf("failed to fetch draws from scraper: %w", err)
 	}
 
+	uc.enrichDraws(ctx, gameType, draws)
+
 	// Save to repository
+	uc.saveDraws(ctx, draws)
+
+	return draws, nil
+}
+
+// saveDraws saves draws with up to uc.workers saves in flight and returns
+// how many were saved. A failed save only logs a warning; the other draws
+// are still saved.
+func (uc *FetchHistoricalDataUseCase) saveDraws(ctx context.Context, draws []*entity.Draw) int {
+	jobs := make(chan *entity.Draw)
+	var saved atomic.Int64
+	var wg sync.WaitGroup
+
+	for w := 0; w < min(uc.workers, len(draws)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for draw := range jobs {
+				if err := uc.drawRepo.Save(ctx, draw); err != nil {
+					logger.Warn("Failed to save draw",
+						zap.String("draw_id", draw.ID),
+						zap.Error(err),
+					)
+					continue
+				}
+				saved.Add(1)
+			}
+		}()
+	}
+
+	for _, draw := range draws {
+		jobs <- draw
+	}
+	close(jobs)
+	wg.Wait()
+
+	return int(saved.Load())
+}
+
+// enrichDraws fills in the jackpot and winners of draws the list page left
+// empty. Draws already stored with prize data reuse it instead of refetching.
+// A failed detail fetch only logs a warning; the draw is kept without it.
+func (uc *FetchHistoricalDataUseCase) enrichDraws(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	draws []*entity.Draw,
+) {
+	if uc.detailFetcher == nil {
+		return
+	}
+
+	enriched := 0
 	for _, draw := range draws {
-		if err := uc.drawRepo.Save(ctx, draw); err != nil {
-			logger.Warn("Failed to save draw",
-				zap.String("draw_id", draw.ID),
+		if draw.Jackpot > 0 {
+			continue
+		}
+
+		if stored, err := uc.drawRepo.FindByGameTypeAndDrawNumber(ctx, gameType, draw.DrawNumber); err == nil && stored.Jackpot > 0 {
+			draw.Jackpot = stored.Jackpot
+			draw.Winners = stored.Winners
+			continue
+		}
+
+		detail, err := uc.detailFetcher.FetchDrawDetail(ctx, gameType, draw.DrawNumber)
+		if err != nil {
+			logger.Warn("Failed to fetch draw detail",
+				zap.Int("draw_number", draw.DrawNumber),
 				zap.Error(err),
 			)
+			continue
 		}
+
+		draw.Jackpot = detail.Jackpot
+		draw.Winners = detail.Winners
+		enriched++
 	}
 
-	return draws, nil
+	logger.Info("Enriched draws from detail pages",
+		zap.String("game_type", string(gameType)),
+		zap.Int("enriched", enriched),
+	)
 }
 
 // GetLatestDrawNumber returns the most recent draw number
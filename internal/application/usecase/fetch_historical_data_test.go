@@ -0,0 +1,227 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/application/port"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// mockDetailFetcher serves canned detail pages and records requests
+type mockDetailFetcher struct {
+	details   map[int]*port.DrawDetail
+	requested []int
+}
+
+func (m *mockDetailFetcher) FetchDrawDetail(ctx context.Context, gameType valueobject.GameType, drawNumber int) (*port.DrawDetail, error) {
+	m.requested = append(m.requested, drawNumber)
+	if detail, ok := m.details[drawNumber]; ok {
+		return detail, nil
+	}
+	return nil, fmt.Errorf("no detail page for draw %d", drawNumber)
+}
+
+// mockDrawNotifier records the draws it was asked to announce
+type mockDrawNotifier struct {
+	draws       []*entity.Draw
+	predictions []*entity.EnsemblePrediction
+	err         error
+}
+
+func (m *mockDrawNotifier) NotifyDraw(ctx context.Context, draw *entity.Draw, prediction *entity.EnsemblePrediction) error {
+	m.draws = append(m.draws, draw)
+	m.predictions = append(m.predictions, prediction)
+	return m.err
+}
+
+// withoutPrizeData strips jackpot and winners, as the list page does
+func withoutPrizeData(draws []*entity.Draw) []*entity.Draw {
+	for _, d := range draws {
+		d.Jackpot = 0
+		d.Winners = 0
+	}
+	return draws
+}
+
+func TestFetchLatest_EnrichesNewDrawsFromDetailPages(t *testing.T) {
+	ctx := context.Background()
+	gt := valueobject.Mega645
+
+	// Draw 1 is already stored with prize data; draw 2 has it on the list
+	stored := createMockDraws(gt, 1)[0]
+	listed := withoutPrizeData(createMockDraws(gt, 4))
+	listed[1].Jackpot = 55000000000
+	listed[1].Winners = 1
+
+	fetcher := &mockDetailFetcher{details: map[int]*port.DrawDetail{
+		3: {DrawNumber: 3, Jackpot: 60000000000, Winners: 2},
+	}}
+	drawRepo := newMockDrawRepository(stored)
+	uc := NewFetchHistoricalDataUseCase(drawRepo, &mockScraper{draws: listed})
+	uc.SetDetailFetcher(fetcher)
+
+	draws, err := uc.FetchLatest(ctx, gt, 10)
+	require.NoError(t, err)
+	require.Len(t, draws, 4)
+
+	// Only new draws missing prize data hit the detail page
+	assert.Equal(t, []int{3, 4}, fetcher.requested)
+
+	byNumber := make(map[int]*entity.Draw)
+	for _, d := range draws {
+		byNumber[d.DrawNumber] = d
+	}
+	assert.Equal(t, stored.Jackpot, byNumber[1].Jackpot)
+	assert.Equal(t, stored.Winners, byNumber[1].Winners)
+	assert.Equal(t, 55000000000.0, byNumber[2].Jackpot)
+	assert.Equal(t, 60000000000.0, byNumber[3].Jackpot)
+	assert.Equal(t, 2, byNumber[3].Winners)
+
+	// A failed detail fetch keeps the draw without prize data
+	assert.Zero(t, byNumber[4].Jackpot)
+	saved, err := drawRepo.FindByGameTypeAndDrawNumber(ctx, gt, 4)
+	require.NoError(t, err)
+	assert.Zero(t, saved.Jackpot)
+}
+
+func TestFetchLatest_SkipsDetailPagesByDefault(t *testing.T) {
+	listed := withoutPrizeData(createMockDraws(valueobject.Mega645, 3))
+	uc := NewFetchHistoricalDataUseCase(newMockDrawRepository(), &mockScraper{draws: listed})
+
+	draws, err := uc.FetchLatest(context.Background(), valueobject.Mega645, 10)
+	require.NoError(t, err)
+
+	for _, d := range draws {
+		assert.Zero(t, d.Jackpot)
+	}
+}
+
+func TestVerifyLatest_ReportsMismatchesWithoutWriting(t *testing.T) {
+	ctx := context.Background()
+	gt := valueobject.Mega645
+
+	stored := createMockDraws(gt, 3)
+	scraped := createMockDraws(gt, 4)
+
+	// Draw 2 has different numbers on the site, draw 3 a different date;
+	// draw 4 was never stored
+	scraped[1].Numbers = valueobject.MustNewNumbers([]int{1, 2, 3, 4, 5, 6})
+	scraped[2].DrawDate = scraped[2].DrawDate.AddDate(0, 0, 1)
+
+	drawRepo := newMockDrawRepository(stored...)
+	uc := NewFetchHistoricalDataUseCase(drawRepo, &mockScraper{draws: scraped})
+
+	checked, mismatches, err := uc.VerifyLatest(ctx, gt, 10)
+	require.NoError(t, err)
+	assert.Len(t, checked, 4)
+
+	require.Len(t, mismatches, 3)
+	assert.Equal(t, 4, mismatches[0].DrawNumber)
+	assert.Equal(t, []DrawMismatchReason{MismatchMissing}, mismatches[0].Reasons)
+	assert.Nil(t, mismatches[0].Stored)
+
+	assert.Equal(t, 3, mismatches[1].DrawNumber)
+	assert.Equal(t, []DrawMismatchReason{MismatchDate}, mismatches[1].Reasons)
+
+	assert.Equal(t, 2, mismatches[2].DrawNumber)
+	assert.Equal(t, []DrawMismatchReason{MismatchNumbers}, mismatches[2].Reasons)
+	assert.Equal(t, stored[1].Numbers, mismatches[2].Stored.Numbers)
+
+	// Nothing was written
+	count, err := drawRepo.Count(ctx, gt)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+}
+
+// failingLookupDrawRepository fails every lookup by draw number
+type failingLookupDrawRepository struct {
+	*mockDrawRepository
+}
+
+func (r *failingLookupDrawRepository) FindByGameTypeAndDrawNumber(ctx context.Context, gameType valueobject.GameType, drawNumber int) (*entity.Draw, error) {
+	return nil, errors.New("permission denied")
+}
+
+func TestVerifyLatest_ReturnsStorageErrors(t *testing.T) {
+	gt := valueobject.Mega645
+	drawRepo := &failingLookupDrawRepository{newMockDrawRepository(createMockDraws(gt, 3)...)}
+	uc := NewFetchHistoricalDataUseCase(drawRepo, &mockScraper{draws: createMockDraws(gt, 3)})
+
+	_, _, err := uc.VerifyLatest(context.Background(), gt, 10)
+	assert.ErrorContains(t, err, "permission denied")
+}
+
+func TestFetchFromDate_SavesAllDrawsConcurrently(t *testing.T) {
+	ctx := context.Background()
+	gt := valueobject.Mega645
+	scraped := createMockDraws(gt, 500)
+
+	drawRepo := newMockDrawRepository()
+	uc := NewFetchHistoricalDataUseCase(drawRepo, &mockScraper{draws: scraped})
+	require.NoError(t, uc.SetSaveConcurrency(8))
+
+	draws, err := uc.FetchFromDate(ctx, gt, scraped[0].DrawDate)
+	require.NoError(t, err)
+	assert.Len(t, draws, 500)
+
+	count, err := drawRepo.Count(ctx, gt)
+	require.NoError(t, err)
+	assert.Equal(t, int64(500), count)
+	for _, draw := range scraped {
+		stored, err := drawRepo.FindByID(ctx, draw.ID)
+		require.NoError(t, err, "draw #%d", draw.DrawNumber)
+		assert.Equal(t, draw.Numbers, stored.Numbers)
+	}
+
+	assert.Error(t, uc.SetSaveConcurrency(0))
+}
+
+func TestFetchLatest_NotifiesNewestNewDraw(t *testing.T) {
+	ctx := context.Background()
+	gt := valueobject.Mega645
+	listed := createMockDraws(gt, 5)
+
+	ensemble := &entity.EnsemblePrediction{GameType: gt, FinalNumbers: listed[4].Numbers, ForDate: listed[4].DrawDate}
+	predictionRepo := &mockPredictionRepository{ensembles: []*entity.EnsemblePrediction{ensemble}}
+
+	notifier := &mockDrawNotifier{}
+	uc := NewFetchHistoricalDataUseCase(newMockDrawRepository(listed[:3]...), &mockScraper{draws: listed})
+	uc.SetDrawNotifier(notifier, predictionRepo)
+
+	_, err := uc.FetchLatest(ctx, gt, 10)
+	require.NoError(t, err)
+
+	// Draws 4 and 5 are new; only the newest is announced
+	require.Len(t, notifier.draws, 1)
+	assert.Equal(t, 5, notifier.draws[0].DrawNumber)
+	assert.Same(t, ensemble, notifier.predictions[0])
+
+	// Nothing new on the next fetch
+	_, err = uc.FetchLatest(ctx, gt, 10)
+	require.NoError(t, err)
+	assert.Len(t, notifier.draws, 1)
+}
+
+func TestFetchLatest_NotificationFailureIsNotFatal(t *testing.T) {
+	gt := valueobject.Mega645
+	drawRepo := newMockDrawRepository()
+	notifier := &mockDrawNotifier{err: fmt.Errorf("webhook down")}
+	uc := NewFetchHistoricalDataUseCase(drawRepo, &mockScraper{draws: createMockDraws(gt, 3)})
+	uc.SetDrawNotifier(notifier, nil)
+
+	draws, err := uc.FetchLatest(context.Background(), gt, 10)
+	require.NoError(t, err)
+	assert.Len(t, draws, 3)
+	require.Len(t, notifier.predictions, 1)
+	assert.Nil(t, notifier.predictions[0])
+
+	count, err := drawRepo.Count(context.Background(), gt)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+}
@@ -0,0 +1,496 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/tool_predict/internal/application/port"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/repository"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// createMockDraws generates deterministic draws, oldest first
+func createMockDraws(gameType valueobject.GameType, count int) []*entity.Draw {
+	draws := make([]*entity.Draw, count)
+	baseDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	minRange, maxRange := gameType.NumberRange()
+
+	for i := 0; i < count; i++ {
+		nums := make([]int, 6)
+		for j := 0; j < 6; j++ {
+			nums[j] = minRange + (i+j*7)%(maxRange-minRange+1)
+		}
+
+		draw, err := entity.NewDraw(
+			gameType,
+			i+1,
+			valueobject.MustNewNumbers(nums),
+			baseDate.AddDate(0, 0, i),
+			float64(100000000+i*1000000),
+			i%5,
+		)
+		if err != nil {
+			panic(err)
+		}
+		draws[i] = draw
+	}
+
+	return draws
+}
+
+// mockScraper serves a fixed set of draws
+type mockScraper struct {
+	draws []*entity.Draw
+	err   error
+}
+
+func (m *mockScraper) FetchLatestDraws(ctx context.Context, gameType valueobject.GameType, limit int) ([]*entity.Draw, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	result := filterDraws(m.draws, gameType)
+	if limit > 0 && len(result) > limit {
+		result = result[len(result)-limit:]
+	}
+	return result, nil
+}
+
+func (m *mockScraper) FetchAllDraws(ctx context.Context, gameType valueobject.GameType, fromDate time.Time) ([]*entity.Draw, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	var result []*entity.Draw
+	for _, d := range filterDraws(m.draws, gameType) {
+		if !d.DrawDate.Before(fromDate) {
+			result = append(result, d)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockScraper) FetchDrawByNumber(ctx context.Context, gameType valueobject.GameType, drawNumber int) (*entity.Draw, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	for _, d := range filterDraws(m.draws, gameType) {
+		if d.DrawNumber == drawNumber {
+			return d, nil
+		}
+	}
+	return nil, fmt.Errorf("draw #%d not found", drawNumber)
+}
+
+func (m *mockScraper) FetchDrawsByDateRange(ctx context.Context, gameType valueobject.GameType, startDate, endDate time.Time) ([]*entity.Draw, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	var result []*entity.Draw
+	for _, d := range filterDraws(m.draws, gameType) {
+		if !d.DrawDate.Before(startDate) && !d.DrawDate.After(endDate) {
+			result = append(result, d)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockScraper) GetLatestDrawNumber(ctx context.Context, gameType valueobject.GameType) (int, error) {
+	if m.err != nil {
+		return 0, m.err
+	}
+	latest := 0
+	for _, d := range filterDraws(m.draws, gameType) {
+		if d.DrawNumber > latest {
+			latest = d.DrawNumber
+		}
+	}
+	return latest, nil
+}
+
+func filterDraws(draws []*entity.Draw, gameType valueobject.GameType) []*entity.Draw {
+	var result []*entity.Draw
+	for _, d := range draws {
+		if d.GameType == gameType {
+			result = append(result, d)
+		}
+	}
+	return result
+}
+
+// mockDrawRepository is an in-memory DrawRepository
+type mockDrawRepository struct {
+	mu    sync.Mutex
+	draws map[string]*entity.Draw
+}
+
+func newMockDrawRepository(draws ...*entity.Draw) *mockDrawRepository {
+	repo := &mockDrawRepository{draws: make(map[string]*entity.Draw)}
+	for _, d := range draws {
+		repo.draws[d.ID] = d
+	}
+	return repo
+}
+
+func (m *mockDrawRepository) all(gameType valueobject.GameType) []*entity.Draw {
+	var result []*entity.Draw
+	for _, d := range m.draws {
+		if d.GameType == gameType {
+			result = append(result, d)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].DrawNumber > result[j].DrawNumber
+	})
+	return result
+}
+
+func (m *mockDrawRepository) Save(ctx context.Context, draw *entity.Draw) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.draws[draw.ID] = draw
+	return nil
+}
+
+func (m *mockDrawRepository) SaveBatch(ctx context.Context, draws []*entity.Draw) error {
+	for _, d := range draws {
+		if err := m.Save(ctx, d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *mockDrawRepository) FindByID(ctx context.Context, id string) (*entity.Draw, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if d, ok := m.draws[id]; ok {
+		return d, nil
+	}
+	return nil, fmt.Errorf("draw not found: %s", id)
+}
+
+func (m *mockDrawRepository) FindByGameTypeAndDrawNumber(ctx context.Context, gameType valueobject.GameType, drawNumber int) (*entity.Draw, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, d := range m.all(gameType) {
+		if d.DrawNumber == drawNumber {
+			return d, nil
+		}
+	}
+	return nil, fmt.Errorf("draw %s #%d: %w", gameType, drawNumber, repository.ErrNotFound)
+}
+
+func (m *mockDrawRepository) FindLatest(ctx context.Context, gameType valueobject.GameType, limit int) ([]*entity.Draw, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	result := m.all(gameType)
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (m *mockDrawRepository) FindByDateRange(ctx context.Context, gameType valueobject.GameType, dateRange valueobject.DateRange) ([]*entity.Draw, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var result []*entity.Draw
+	for _, d := range m.all(gameType) {
+		if dateRange.Contains(d.DrawDate) {
+			result = append(result, d)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockDrawRepository) FindByDrawNumberRange(ctx context.Context, gameType valueobject.GameType, startDrawNumber, endDrawNumber int) ([]*entity.Draw, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var result []*entity.Draw
+	for _, d := range m.all(gameType) {
+		if d.DrawNumber >= startDrawNumber && d.DrawNumber <= endDrawNumber {
+			result = append(result, d)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockDrawRepository) FindPage(ctx context.Context, gameType valueobject.GameType, offset, limit int) ([]*entity.Draw, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	result := m.all(gameType)
+	return result[min(offset, len(result)):min(offset+limit, len(result))], nil
+}
+
+func (m *mockDrawRepository) ForEachDraw(ctx context.Context, gameType valueobject.GameType, fn func(draw *entity.Draw) error) error {
+	m.mu.Lock()
+	draws := m.all(gameType)
+	m.mu.Unlock()
+	for i := len(draws) - 1; i >= 0; i-- {
+		if err := fn(draws[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *mockDrawRepository) Count(ctx context.Context, gameType valueobject.GameType) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return int64(len(m.all(gameType))), nil
+}
+
+func (m *mockDrawRepository) DeleteAll(ctx context.Context, gameType valueobject.GameType) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id, d := range m.draws {
+		if d.GameType == gameType {
+			delete(m.draws, id)
+		}
+	}
+	return nil
+}
+
+func (m *mockDrawRepository) GetLatestDrawNumber(ctx context.Context, gameType valueobject.GameType) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	draws := m.all(gameType)
+	if len(draws) == 0 {
+		return 0, nil
+	}
+	return draws[0].DrawNumber, nil
+}
+
+// mockPredictionRepository is an in-memory PredictionRepository
+type mockPredictionRepository struct {
+	mu          sync.Mutex
+	predictions []*entity.Prediction
+	ensembles   []*entity.EnsemblePrediction
+}
+
+func (m *mockPredictionRepository) Save(ctx context.Context, prediction *entity.Prediction) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.predictions = append(m.predictions, prediction)
+	return nil
+}
+
+func (m *mockPredictionRepository) SaveBatch(ctx context.Context, predictions []*entity.Prediction) error {
+	for _, p := range predictions {
+		if err := m.Save(ctx, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *mockPredictionRepository) SaveEnsemble(ctx context.Context, ensemble *entity.EnsemblePrediction) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ensembles = append(m.ensembles, ensemble)
+	return nil
+}
+
+func (m *mockPredictionRepository) FindByID(ctx context.Context, id string) (*entity.Prediction, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, p := range m.predictions {
+		if p.ID == id {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("prediction not found: %s", id)
+}
+
+func (m *mockPredictionRepository) FindEnsembleByID(ctx context.Context, id string) (*entity.EnsemblePrediction, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, e := range m.ensembles {
+		if e.ID == id {
+			return e, nil
+		}
+	}
+	return nil, fmt.Errorf("ensemble prediction not found: %s", id)
+}
+
+func (m *mockPredictionRepository) FindCurrentEnsemble(ctx context.Context, gameType valueobject.GameType, forDate time.Time) (*entity.EnsemblePrediction, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := len(m.ensembles) - 1; i >= 0; i-- {
+		if e := m.ensembles[i]; e.GameType == gameType && e.ForDate.Equal(forDate) {
+			return e, nil
+		}
+	}
+	return nil, fmt.Errorf("ensemble prediction %s %s: %w", gameType, forDate, repository.ErrNotFound)
+}
+
+func (m *mockPredictionRepository) FindLatest(ctx context.Context, gameType valueobject.GameType, limit int) ([]*entity.Prediction, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var result []*entity.Prediction
+	for i := len(m.predictions) - 1; i >= 0 && (limit <= 0 || len(result) < limit); i-- {
+		if m.predictions[i].GameType == gameType {
+			result = append(result, m.predictions[i])
+		}
+	}
+	return result, nil
+}
+
+func (m *mockPredictionRepository) FindLatestEnsembles(ctx context.Context, gameType valueobject.GameType, limit int) ([]*entity.EnsemblePrediction, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var result []*entity.EnsemblePrediction
+	for i := len(m.ensembles) - 1; i >= 0 && (limit <= 0 || len(result) < limit); i-- {
+		if m.ensembles[i].GameType == gameType {
+			result = append(result, m.ensembles[i])
+		}
+	}
+	return result, nil
+}
+
+func (m *mockPredictionRepository) FindByAlgorithm(ctx context.Context, algorithmName string, gameType valueobject.GameType, limit int) ([]*entity.Prediction, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var result []*entity.Prediction
+	for i := len(m.predictions) - 1; i >= 0 && (limit <= 0 || len(result) < limit); i-- {
+		p := m.predictions[i]
+		if p.GameType == gameType && p.AlgorithmName == algorithmName {
+			result = append(result, p)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockPredictionRepository) FindByDateRange(ctx context.Context, gameType valueobject.GameType, dateRange valueobject.DateRange) ([]*entity.Prediction, error) {
+	return nil, nil
+}
+
+func (m *mockPredictionRepository) Count(ctx context.Context, gameType valueobject.GameType) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var count int64
+	for _, p := range m.predictions {
+		if p.GameType == gameType {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *mockPredictionRepository) DeleteOld(ctx context.Context, before time.Time) error {
+	return nil
+}
+
+// mockBacktestRepository serves a fixed set of backtest results. Only the
+// methods used by the use cases under test are implemented; the embedded nil
+// interface panics on anything else.
+type mockBacktestRepository struct {
+	repository.BacktestRepository
+	results []*entity.BacktestResult
+}
+
+func (m *mockBacktestRepository) Save(ctx context.Context, result *entity.BacktestResult) error {
+	m.results = append(m.results, result)
+	return nil
+}
+
+func (m *mockBacktestRepository) FindByGameType(ctx context.Context, gameType valueobject.GameType) ([]*entity.BacktestResult, error) {
+	var result []*entity.BacktestResult
+	for _, r := range m.results {
+		if r.GameType == gameType {
+			result = append(result, r)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockBacktestRepository) FindByAlgorithm(ctx context.Context, algorithmName string, gameType valueobject.GameType, limit int) ([]*entity.BacktestResult, error) {
+	var result []*entity.BacktestResult
+	for _, r := range m.results {
+		if r.GameType == gameType && r.AlgorithmName == algorithmName {
+			result = append(result, r)
+		}
+	}
+	// Latest test period first, like the JSON storage
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].TestPeriod.EndDate.After(result[j].TestPeriod.EndDate)
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// mockStatsRepository is an in-memory StatsRepository keyed by game type and
+// algorithm name, implementing only what the use cases under test need
+type mockStatsRepository struct {
+	repository.StatsRepository
+	mu    sync.Mutex
+	stats map[string]*entity.AlgorithmStats
+}
+
+func newMockStatsRepository(stats ...*entity.AlgorithmStats) *mockStatsRepository {
+	m := &mockStatsRepository{stats: make(map[string]*entity.AlgorithmStats)}
+	for _, s := range stats {
+		m.stats[string(s.GameType)+"/"+s.AlgorithmName] = s
+	}
+	return m
+}
+
+func (m *mockStatsRepository) Save(ctx context.Context, stats *entity.AlgorithmStats) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stats[string(stats.GameType)+"/"+stats.AlgorithmName] = stats
+	return nil
+}
+
+func (m *mockStatsRepository) FindByGameType(ctx context.Context, gameType valueobject.GameType) ([]*entity.AlgorithmStats, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var result []*entity.AlgorithmStats
+	for _, s := range m.stats {
+		if s.GameType == gameType {
+			result = append(result, s)
+		}
+	}
+	return result, nil
+}
+
+// mockPredictionNotifier records the predictions it's asked to announce
+type mockPredictionNotifier struct {
+	mu        sync.Mutex
+	predicted []*entity.EnsemblePrediction
+	verified  []*entity.EnsemblePrediction
+	err       error
+}
+
+func (m *mockPredictionNotifier) NotifyPrediction(ctx context.Context, prediction *entity.EnsemblePrediction) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.predicted = append(m.predicted, prediction)
+	return m.err
+}
+
+func (m *mockPredictionNotifier) NotifyVerified(ctx context.Context, draw *entity.Draw, prediction *entity.EnsemblePrediction) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.verified = append(m.verified, prediction)
+	return m.err
+}
+
+// mockNotifier records the notifications it's asked to send
+type mockNotifier struct {
+	mu            sync.Mutex
+	notifications []port.Notification
+	err           error
+}
+
+func (m *mockNotifier) Notify(ctx context.Context, notification port.Notification) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.notifications = append(m.notifications, notification)
+	return m.err
+}
@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"math"
 	"sort"
 	"time"
 
@@ -22,6 +23,10 @@ type PredictUseCase struct {
 	ensemble       *algorithm.Ensemble
 	scraper        port.VietlottScraper
 	grpcClient     port.PredictionService
+	notifier       port.PredictionNotifier // Optional, nil disables notifications
+	cache          *predictionCache        // Optional, nil disables caching
+	toolVersion    string
+	configHash     string
 }
 
 // NewPredictUseCase creates a new prediction use case
@@ -41,23 +46,90 @@ func NewPredictUseCase(
 	}
 }
 
+// EnableCache keeps up to maxEntries results in memory so that repeated calls
+// with unchanged history return the cached ensemble. A non-positive value
+// disables caching.
+func (uc *PredictUseCase) EnableCache(maxEntries int) {
+	if maxEntries <= 0 {
+		uc.cache = nil
+		return
+	}
+	uc.cache = newPredictionCache(maxEntries)
+}
+
+// SetProvenance sets the tool version and config hash recorded in the
+// provenance of every prediction
+func (uc *PredictUseCase) SetProvenance(toolVersion, configHash string) {
+	uc.toolVersion = toolVersion
+	uc.configHash = configHash
+}
+
+// SetNotifier announces each newly generated prediction through notifier;
+// nil disables notifications. Cached results aren't announced again.
+func (uc *PredictUseCase) SetNotifier(notifier port.PredictionNotifier) {
+	uc.notifier = notifier
+}
+
+// PredictRequest contains the prediction parameters
+type PredictRequest struct {
+	GameType valueobject.GameType
+	MaxDraws int   // Number of latest draws to use for prediction
+	Mine     []int // Player's own numbers to blend in; empty for a pure ensemble pick
+	FromDraw int   // Train only on stored draws numbered from FromDraw; 0 leaves the start open
+	ToDraw   int   // Train only on stored draws numbered up to ToDraw; 0 leaves the end open
+	Stored   bool  // Train on the stored draws without fetching new ones, for callers that mustn't reach the site
+}
+
+// hasDrawRange reports whether the request restricts training to a draw
+// number range
+func (req PredictRequest) hasDrawRange() bool {
+	return req.FromDraw > 0 || req.ToDraw > 0
+}
+
 // Execute generates and sends a prediction
 func (uc *PredictUseCase) Execute(
 	ctx context.Context,
-	gameType valueobject.GameType,
-	algorithmCount int,
-	maxDraws int,
+	req PredictRequest,
 ) (*EnsembleResult, error) {
 	startTime := time.Now()
+	gameType := req.GameType
+	maxDraws := req.MaxDraws
 
 	logger.Info("Starting prediction workflow",
 		zap.String("game_type", string(gameType)),
 		zap.Int("max_draws", maxDraws),
 	)
 
-	// Step 1: Fetch latest historical data
-	logger.Info("Fetching historical data")
-	draws, err := uc.scraper.FetchLatestDraws(ctx, gameType, 200)
+	// Step 1: Fetch latest historical data, or the stored draws in the
+	// requested range
+	var draws []*entity.Draw
+	var err error
+	if req.hasDrawRange() {
+		if req.ToDraw > 0 && req.FromDraw > req.ToDraw {
+			return nil, fmt.Errorf("draw range start %d is after its end %d", req.FromDraw, req.ToDraw)
+		}
+		toDraw := req.ToDraw
+		if toDraw == 0 {
+			toDraw = math.MaxInt
+		}
+		logger.Info("Loading stored draws in range",
+			zap.Int("from_draw", req.FromDraw),
+			zap.Int("to_draw", req.ToDraw),
+		)
+		draws, err = uc.drawRepo.FindByDrawNumberRange(ctx, gameType, req.FromDraw, toDraw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load draws %d-%d: %w", req.FromDraw, req.ToDraw, err)
+		}
+	} else if req.Stored {
+		logger.Info("Loading stored draws")
+		draws, err = uc.drawRepo.FindLatest(ctx, gameType, 200)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load stored draws: %w", err)
+		}
+	} else {
+		logger.Info("Fetching historical data")
+		draws, err = uc.scraper.FetchLatestDraws(ctx, gameType, 200)
+	}
 	if err != nil {
 		// Fallback to local storage if scraper fails
 		logger.Warn("Scraper failed, attempting to use local storage",
@@ -72,12 +144,14 @@ func (uc *PredictUseCase) Execute(
 		)
 	}
 
-	// Step 1.5: Sort draws by date (newest first) and limit to maxDraws
+	// Step 1.5: Drop sample draws once real ones are stored, then sort draws
+	// by date (newest first) and limit to maxDraws
+	draws = withoutSampleDraws(draws)
 	draws = sortAndLimitDraws(draws, maxDraws)
 
-	if len(draws) < algorithmCount {
-		return nil, fmt.Errorf("insufficient historical data: need at least %d draws, got %d",
-			algorithmCount, len(draws))
+	if required, algoName := uc.ensemble.MinDrawsRequired(); len(draws) < required {
+		return nil, fmt.Errorf("insufficient historical data: need at least %d draws (%s), have %d",
+			required, algoName, len(draws))
 	}
 
 	logger.Info("Historical data fetched and filtered",
@@ -85,13 +159,46 @@ func (uc *PredictUseCase) Execute(
 		zap.Int("max_draws_used", maxDraws),
 	)
 
+	// A cached result is still valid as long as no new draw has arrived
+	var cacheKey predictionCacheKey
+	if uc.cache != nil && len(draws) > 0 {
+		cacheKey = predictionCacheKey{
+			gameType:         gameType,
+			latestDrawNumber: draws[0].DrawNumber,
+			maxDraws:         maxDraws,
+			votingStrategy:   string(uc.ensemble.GetVotingStrategy()),
+			mine:             fmt.Sprint(req.Mine),
+			fromDraw:         req.FromDraw,
+			toDraw:           req.ToDraw,
+		}
+		if cached, ok := uc.cache.get(cacheKey); ok {
+			logger.Info("Returning cached prediction",
+				zap.String("prediction_id", cached.Prediction.ID),
+				zap.Int("latest_draw_number", cacheKey.latestDrawNumber),
+			)
+			result := *cached
+			result.Duration = time.Since(startTime)
+			result.Cached = true
+			return &result, nil
+		}
+	}
+
 	// Step 2: Generate predictions using ensemble
 	logger.Info("Generating ensemble predictions")
-	ensemblePred, err := uc.ensemble.GeneratePredictions(ctx, gameType, draws)
+	var ensemblePred *entity.EnsemblePrediction
+	if len(req.Mine) > 0 {
+		logger.Info("Blending in player's numbers", zap.Ints("mine", req.Mine))
+		ensemblePred, err = uc.ensemble.GenerateBlendedPredictions(ctx, gameType, draws, req.Mine)
+	} else {
+		ensemblePred, err = uc.ensemble.GeneratePredictions(ctx, gameType, draws)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("ensemble prediction failed: %w", err)
 	}
 
+	ensemblePred.Provenance = entity.NewProvenance(uc.toolVersion, uc.configHash,
+		draws, ensemblePred.Predictions, ensemblePred.AlgorithmStats)
+
 	logger.Info("Ensemble prediction generated",
 		zap.String("prediction_id", ensemblePred.ID),
 		zap.Strings("numbers", formatNumbers(ensemblePred.FinalNumbers)),
@@ -127,6 +234,16 @@ func (uc *PredictUseCase) Execute(
 		logger.Info("gRPC client not configured, skipping send to too_predict")
 	}
 
+	// Step 5: Announce the prediction (optional)
+	if uc.notifier != nil {
+		if err := uc.notifier.NotifyPrediction(ctx, ensemblePred); err != nil {
+			logger.Warn("Failed to notify prediction",
+				zap.String("prediction_id", ensemblePred.ID),
+				zap.Error(err),
+			)
+		}
+	}
+
 	duration := time.Since(startTime)
 
 	logger.Info("Prediction workflow completed successfully",
@@ -134,13 +251,19 @@ func (uc *PredictUseCase) Execute(
 		zap.Duration("duration", duration),
 	)
 
-	// Return result
-	return &EnsembleResult{
+	result := &EnsembleResult{
 		Prediction:     ensemblePred,
 		Duration:       duration,
 		DrawsUsed:      len(draws),
 		AlgorithmsUsed: len(ensemblePred.Predictions),
-	}, nil
+	}
+
+	if uc.cache != nil && len(draws) > 0 {
+		uc.cache.put(cacheKey, result)
+	}
+
+	// Return result
+	return result, nil
 }
 
 // EnsembleResult contains the prediction result and metadata
@@ -149,6 +272,7 @@ type EnsembleResult struct {
 	Duration       time.Duration
 	DrawsUsed      int
 	AlgorithmsUsed int
+	Cached         bool // True when served from the prediction cache
 }
 
 func formatNumbers(numbers valueobject.Numbers) []string {
@@ -159,6 +283,30 @@ func formatNumbers(numbers valueobject.Numbers) []string {
 	return result
 }
 
+// withoutSampleDraws drops the bundled sample draws from draws when it also
+// holds real results, so samples never mix into a real history. When every
+// draw is a sample they are kept, with a warning.
+func withoutSampleDraws(draws []*entity.Draw) []*entity.Draw {
+	actual := make([]*entity.Draw, 0, len(draws))
+	for _, draw := range draws {
+		if !draw.Sample {
+			actual = append(actual, draw)
+		}
+	}
+	switch {
+	case len(actual) == len(draws):
+		return draws
+	case len(actual) == 0:
+		logger.Warn("Predicting from sample draws, not real results; fetch real draws and run init --remove-sample")
+		return draws
+	default:
+		logger.Info("Ignoring sample draws stored alongside real ones",
+			zap.Int("sample_draws", len(draws)-len(actual)),
+		)
+		return actual
+	}
+}
+
 // sortAndLimitDraws sorts draws by date (newest first) and limits to maxDraws
 func sortAndLimitDraws(draws []*entity.Draw, maxDraws int) []*entity.Draw {
 	// Sort by draw date, newest first
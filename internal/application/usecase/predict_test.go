@@ -0,0 +1,282 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/pkg/algorithm"
+)
+
+func newTestPredictUseCase(t *testing.T, draws int, algos ...algorithm.Algorithm) (*PredictUseCase, *mockPredictionRepository) {
+	t.Helper()
+
+	registry := algorithm.NewRegistry()
+	for _, algo := range algos {
+		require.NoError(t, registry.Register(algo, algo.GetWeight()))
+	}
+
+	predictionRepo := &mockPredictionRepository{}
+	uc := NewPredictUseCase(
+		newMockDrawRepository(),
+		predictionRepo,
+		algorithm.NewEnsemble(registry, algorithm.WeightedVoting),
+		&mockScraper{draws: createMockDraws(valueobject.Mega645, draws)},
+		nil,
+	)
+	return uc, predictionRepo
+}
+
+func TestPredictUseCase_Execute_InsufficientDraws(t *testing.T) {
+	uc, predictionRepo := newTestPredictUseCase(t, 50,
+		algorithm.NewFrequencyAnalyzer(1.0),
+		algorithm.NewPatternAnalyzer(1.0),
+	)
+
+	result, err := uc.Execute(context.Background(), PredictRequest{
+		GameType: valueobject.Mega645,
+		MaxDraws: 200,
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "need at least 100 draws (pattern_analysis), have 50")
+	assert.Empty(t, predictionRepo.ensembles)
+}
+
+func TestPredictUseCase_Execute_EnoughDraws(t *testing.T) {
+	uc, predictionRepo := newTestPredictUseCase(t, 60,
+		algorithm.NewFrequencyAnalyzer(1.0),
+		algorithm.NewHotColdAnalyzer(1.0),
+	)
+
+	result, err := uc.Execute(context.Background(), PredictRequest{
+		GameType: valueobject.Mega645,
+		MaxDraws: 200,
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, 60, result.DrawsUsed)
+	assert.Len(t, predictionRepo.ensembles, 1)
+}
+
+func TestPredictUseCase_Execute_NotifiesNewPredictions(t *testing.T) {
+	uc, _ := newTestPredictUseCase(t, 60,
+		algorithm.NewFrequencyAnalyzer(1.0),
+		algorithm.NewHotColdAnalyzer(1.0),
+	)
+	uc.EnableCache(4)
+	notifier := &mockPredictionNotifier{err: errors.New("chat not found")}
+	uc.SetNotifier(notifier)
+
+	req := PredictRequest{GameType: valueobject.Mega645, MaxDraws: 200}
+	result, err := uc.Execute(context.Background(), req)
+	require.NoError(t, err, "a failed notification doesn't fail the prediction")
+	require.Len(t, notifier.predicted, 1)
+	assert.Equal(t, result.Prediction.ID, notifier.predicted[0].ID)
+
+	cached, err := uc.Execute(context.Background(), req)
+	require.NoError(t, err)
+	assert.True(t, cached.Cached)
+	assert.Len(t, notifier.predicted, 1, "a cached result isn't announced again")
+}
+
+func TestPredictUseCase_Execute_CachesUntilNewDraw(t *testing.T) {
+	registry := algorithm.NewRegistry()
+	require.NoError(t, registry.Register(algorithm.NewFrequencyAnalyzer(1.0), 1.0))
+
+	draws := createMockDraws(valueobject.Mega645, 40)
+	scraper := &mockScraper{draws: draws[:39]}
+	predictionRepo := &mockPredictionRepository{}
+
+	uc := NewPredictUseCase(
+		newMockDrawRepository(),
+		predictionRepo,
+		algorithm.NewEnsemble(registry, algorithm.WeightedVoting),
+		scraper,
+		nil,
+	)
+	uc.EnableCache(8)
+
+	req := PredictRequest{GameType: valueobject.Mega645, MaxDraws: 30}
+	ctx := context.Background()
+
+	first, err := uc.Execute(ctx, req)
+	require.NoError(t, err)
+	assert.False(t, first.Cached)
+
+	// No new draws: served from the cache without recomputing or saving
+	second, err := uc.Execute(ctx, req)
+	require.NoError(t, err)
+	assert.True(t, second.Cached)
+	assert.Same(t, first.Prediction, second.Prediction)
+	assert.Len(t, predictionRepo.ensembles, 1)
+
+	// A new draw busts the cache
+	scraper.draws = draws
+	third, err := uc.Execute(ctx, req)
+	require.NoError(t, err)
+	assert.False(t, third.Cached)
+	assert.NotSame(t, first.Prediction, third.Prediction)
+	assert.Len(t, predictionRepo.ensembles, 2)
+	assert.Equal(t, 1, uc.cache.len())
+}
+
+func TestPredictUseCase_Execute_CacheDisabledByDefault(t *testing.T) {
+	uc, predictionRepo := newTestPredictUseCase(t, 40, algorithm.NewFrequencyAnalyzer(1.0))
+	req := PredictRequest{GameType: valueobject.Mega645, MaxDraws: 30}
+
+	for i := 0; i < 2; i++ {
+		result, err := uc.Execute(context.Background(), req)
+		require.NoError(t, err)
+		assert.False(t, result.Cached)
+	}
+	assert.Len(t, predictionRepo.ensembles, 2)
+}
+
+func TestPredictionCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := newPredictionCache(2)
+	key := func(gt valueobject.GameType, maxDraws int) predictionCacheKey {
+		return predictionCacheKey{gameType: gt, latestDrawNumber: 10, maxDraws: maxDraws, votingStrategy: "weighted"}
+	}
+
+	cache.put(key(valueobject.Mega645, 30), &EnsembleResult{DrawsUsed: 30})
+	cache.put(key(valueobject.Power655, 30), &EnsembleResult{DrawsUsed: 30})
+	_, ok := cache.get(key(valueobject.Mega645, 30)) // Mega is now most recent
+	require.True(t, ok)
+
+	cache.put(key(valueobject.Mega645, 50), &EnsembleResult{DrawsUsed: 50})
+
+	_, ok = cache.get(key(valueobject.Power655, 30))
+	assert.False(t, ok)
+	_, ok = cache.get(key(valueobject.Mega645, 30))
+	assert.True(t, ok)
+	assert.Equal(t, 2, cache.len())
+}
+
+func TestPredictUseCase_Execute_RecordsProvenance(t *testing.T) {
+	uc, predictionRepo := newTestPredictUseCase(t, 80,
+		algorithm.NewFrequencyAnalyzer(1.5),
+		algorithm.NewHotColdAnalyzer(1.0),
+	)
+	uc.SetProvenance("1.2.3", "abc123")
+
+	result, err := uc.Execute(context.Background(), PredictRequest{
+		GameType: valueobject.Mega645,
+		MaxDraws: 60,
+	})
+	require.NoError(t, err)
+
+	// The 60 latest of draws #1-#80 were used
+	provenance := result.Prediction.Provenance
+	require.NotNil(t, provenance)
+	assert.Equal(t, "1.2.3", provenance.ToolVersion)
+	assert.Equal(t, "abc123", provenance.ConfigHash)
+	assert.Equal(t, 21, provenance.FirstDrawNumber)
+	assert.Equal(t, 80, provenance.LastDrawNumber)
+	assert.Equal(t, 60, provenance.DrawCount)
+
+	weights := make(map[string]float64)
+	for _, algo := range provenance.Algorithms {
+		weights[algo.Name] = algo.Weight
+	}
+	assert.Equal(t, map[string]float64{"frequency_analysis": 1.5, "hot_cold_analysis": 1.0}, weights)
+
+	require.Len(t, predictionRepo.ensembles, 1)
+	assert.Equal(t, provenance, predictionRepo.ensembles[0].Provenance)
+}
+
+func TestPredictUseCase_Execute_DrawRange(t *testing.T) {
+	registry := algorithm.NewRegistry()
+	require.NoError(t, registry.Register(algorithm.NewFrequencyAnalyzer(1.0), 1.0))
+	require.NoError(t, registry.Register(algorithm.NewHotColdAnalyzer(1.0), 1.0))
+	ensemble := algorithm.NewEnsemble(registry, algorithm.WeightedVoting)
+
+	draws := createMockDraws(valueobject.Mega645, 100)
+	uc := NewPredictUseCase(
+		newMockDrawRepository(draws...),
+		&mockPredictionRepository{},
+		ensemble,
+		&mockScraper{err: assert.AnError},
+		nil,
+	)
+
+	result, err := uc.Execute(context.Background(), PredictRequest{
+		GameType: valueobject.Mega645,
+		MaxDraws: 200,
+		FromDraw: 21,
+		ToDraw:   80,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 60, result.DrawsUsed)
+	assert.Equal(t, 21, result.Prediction.Provenance.FirstDrawNumber)
+	assert.Equal(t, 80, result.Prediction.Provenance.LastDrawNumber)
+
+	// Only draws #21-#80 influence the pick
+	inRange, err := ensemble.GeneratePredictions(context.Background(), valueobject.Mega645,
+		sortAndLimitDraws(append([]*entity.Draw(nil), draws[20:80]...), 200))
+	require.NoError(t, err)
+	assert.Equal(t, inRange.FinalNumbers, result.Prediction.FinalNumbers)
+
+	_, err = uc.Execute(context.Background(), PredictRequest{
+		GameType: valueobject.Mega645,
+		MaxDraws: 200,
+		FromDraw: 80,
+		ToDraw:   21,
+	})
+	assert.ErrorContains(t, err, "after its end")
+}
+
+func TestPredictUseCase_Execute_StoredDrawsOnly(t *testing.T) {
+	registry := algorithm.NewRegistry()
+	require.NoError(t, registry.Register(algorithm.NewFrequencyAnalyzer(1.0), 1.0))
+	require.NoError(t, registry.Register(algorithm.NewHotColdAnalyzer(1.0), 1.0))
+
+	// The site has draws the store hasn't caught up with yet
+	uc := NewPredictUseCase(
+		newMockDrawRepository(createMockDraws(valueobject.Mega645, 60)...),
+		&mockPredictionRepository{},
+		algorithm.NewEnsemble(registry, algorithm.WeightedVoting),
+		&mockScraper{draws: createMockDraws(valueobject.Mega645, 100)},
+		nil,
+	)
+
+	result, err := uc.Execute(context.Background(), PredictRequest{
+		GameType: valueobject.Mega645,
+		MaxDraws: 200,
+		Stored:   true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 60, result.DrawsUsed)
+}
+
+func TestPredictUseCase_Execute_IgnoresSampleDrawsOnceRealOnesStored(t *testing.T) {
+	registry := algorithm.NewRegistry()
+	require.NoError(t, registry.Register(algorithm.NewFrequencyAnalyzer(1.0), 1.0))
+
+	draws := createMockDraws(valueobject.Mega645, 80)
+	for _, draw := range draws[:30] {
+		draw.Sample = true
+	}
+	uc := NewPredictUseCase(
+		newMockDrawRepository(draws...),
+		&mockPredictionRepository{},
+		algorithm.NewEnsemble(registry, algorithm.WeightedVoting),
+		&mockScraper{},
+		nil,
+	)
+
+	result, err := uc.Execute(context.Background(), PredictRequest{
+		GameType: valueobject.Mega645,
+		MaxDraws: 200,
+		Stored:   true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 50, result.DrawsUsed)
+}
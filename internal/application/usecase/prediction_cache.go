@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// predictionCacheKey identifies a prediction computed from a given history
+type predictionCacheKey struct {
+	gameType         valueobject.GameType
+	latestDrawNumber int
+	maxDraws         int
+	votingStrategy   string
+	mine             string // Blended player numbers, formatted
+	fromDraw         int
+	toDraw           int
+}
+
+type predictionCacheEntry struct {
+	key    predictionCacheKey
+	result *EnsembleResult
+}
+
+// predictionCache is a bounded LRU cache of ensemble results
+type predictionCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	entries    map[predictionCacheKey]*list.Element
+	order      *list.List // Front is most recently used
+}
+
+func newPredictionCache(maxEntries int) *predictionCache {
+	return &predictionCache{
+		maxEntries: maxEntries,
+		entries:    make(map[predictionCacheKey]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// get returns the cached result for key, if any
+func (c *predictionCache) get(key predictionCacheKey) (*EnsembleResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*predictionCacheEntry).result, true
+}
+
+// put stores a result and drops entries for the same game type computed from
+// older history, since a newer draw has made them stale
+func (c *predictionCache) put(key predictionCacheKey, result *EnsembleResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for k, elem := range c.entries {
+		if k.gameType == key.gameType && k.latestDrawNumber < key.latestDrawNumber {
+			c.order.Remove(elem)
+			delete(c.entries, k)
+		}
+	}
+
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*predictionCacheEntry).result = result
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&predictionCacheEntry{key: key, result: result})
+
+	for c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*predictionCacheEntry).key)
+	}
+}
+
+// len returns the number of cached results
+func (c *predictionCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
@@ -0,0 +1,185 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/repository"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+// maxHistoryEnsembles caps how many stored ensembles a history query scans
+const maxHistoryEnsembles = 10000
+
+// PredictionHistoryUseCase lists stored ensemble predictions with the draws
+// they targeted, so past predictions can be reviewed once drawn
+type PredictionHistoryUseCase struct {
+	drawRepo       repository.DrawRepository
+	predictionRepo repository.PredictionRepository
+}
+
+// NewPredictionHistoryUseCase creates a new prediction history use case
+func NewPredictionHistoryUseCase(
+	drawRepo repository.DrawRepository,
+	predictionRepo repository.PredictionRepository,
+) *PredictionHistoryUseCase {
+	return &PredictionHistoryUseCase{
+		drawRepo:       drawRepo,
+		predictionRepo: predictionRepo,
+	}
+}
+
+// PredictionHistoryRequest contains the history filters
+type PredictionHistoryRequest struct {
+	GameType      valueobject.GameType
+	Since         time.Time // Only predictions generated at or after this time; zero for all
+	Algorithm     string    // Only predictions this algorithm took part in; empty for all
+	MinConfidence float64   // Only predictions with at least this overall confidence
+	Limit         int       // Most predictions to list, newest first; 0 for all
+}
+
+// PredictionHistoryEntry is a stored prediction and the draw it targeted
+type PredictionHistoryEntry struct {
+	Prediction *entity.EnsemblePrediction
+	Draw       *entity.Draw // Nil while the target draw isn't stored
+	MatchCount int          // Final numbers drawn; 0 while Draw is nil
+}
+
+// Execute returns the stored predictions matching the filters, newest
+// first. Each is paired with its target draw: the draw it was verified
+// against, else the draw on its target date, else, for predictions without
+// one, the first draw on or after the day it was generated.
+func (uc *PredictionHistoryUseCase) Execute(ctx context.Context, req PredictionHistoryRequest) ([]PredictionHistoryEntry, error) {
+	if err := req.GameType.Validate(); err != nil {
+		return nil, err
+	}
+	if req.Limit < 0 {
+		return nil, fmt.Errorf("limit cannot be negative, got %d", req.Limit)
+	}
+	if req.MinConfidence < 0 || req.MinConfidence > 1 {
+		return nil, fmt.Errorf("minimum confidence must be between 0 and 1, got %v", req.MinConfidence)
+	}
+
+	ensembles, err := uc.predictionRepo.FindLatestEnsembles(ctx, req.GameType, maxHistoryEnsembles)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load predictions: %w", err)
+	}
+
+	var selected []*entity.EnsemblePrediction
+	for _, ensemble := range ensembles {
+		if !req.Since.IsZero() && ensemble.GeneratedAt.Before(req.Since) {
+			continue
+		}
+		if req.Algorithm != "" && !hasAlgorithm(ensemble, req.Algorithm) {
+			continue
+		}
+		if ensemble.Confidence < req.MinConfidence {
+			continue
+		}
+		selected = append(selected, ensemble)
+		if req.Limit > 0 && len(selected) == req.Limit {
+			break
+		}
+	}
+	if len(selected) == 0 {
+		return nil, nil
+	}
+
+	draws, err := uc.loadDrawsSince(ctx, req.GameType, selected)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]PredictionHistoryEntry, 0, len(selected))
+	for _, ensemble := range selected {
+		entry := PredictionHistoryEntry{Prediction: ensemble, Draw: targetDraw(ensemble, draws)}
+		switch {
+		case ensemble.Outcome != nil:
+			entry.MatchCount = ensemble.Outcome.MatchCount
+		case entry.Draw != nil:
+			entry.MatchCount = ensemble.FinalNumbers.MatchCount(entry.Draw.Numbers)
+		}
+		entries = append(entries, entry)
+	}
+
+	logger.Info("Loaded prediction history",
+		zap.String("game_type", string(req.GameType)),
+		zap.Int("scanned", len(ensembles)),
+		zap.Int("listed", len(entries)),
+	)
+	return entries, nil
+}
+
+// loadDrawsSince loads the stored draws from the day the oldest of the
+// predictions was generated or targeted, oldest first
+func (uc *PredictionHistoryUseCase) loadDrawsSince(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	ensembles []*entity.EnsemblePrediction,
+) ([]*entity.Draw, error) {
+	start := ensembles[0].GeneratedAt
+	for _, ensemble := range ensembles {
+		if ensemble.GeneratedAt.Before(start) {
+			start = ensemble.GeneratedAt
+		}
+		if !ensemble.ForDate.IsZero() && ensemble.ForDate.Before(start) {
+			start = ensemble.ForDate
+		}
+	}
+	start = start.AddDate(0, 0, -1)
+
+	draws, err := uc.drawRepo.FindByDateRange(ctx, gameType, valueobject.DateRange{
+		StartDate: start,
+		EndDate:   time.Now().AddDate(0, 0, 1),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load draws: %w", err)
+	}
+	sort.Slice(draws, func(i, j int) bool {
+		return draws[i].DrawNumber < draws[j].DrawNumber
+	})
+	return draws, nil
+}
+
+// targetDraw finds the draw ensemble targeted among draws, oldest first
+func targetDraw(ensemble *entity.EnsemblePrediction, draws []*entity.Draw) *entity.Draw {
+	if ensemble.Outcome != nil {
+		for _, draw := range draws {
+			if draw.DrawNumber == ensemble.Outcome.DrawNumber {
+				return draw
+			}
+		}
+	}
+	if !ensemble.ForDate.IsZero() {
+		day := ensemble.ForDate.Format("2006-01-02")
+		for _, draw := range draws {
+			if draw.DrawDate.Format("2006-01-02") == day {
+				return draw
+			}
+		}
+		return nil
+	}
+
+	generated := ensemble.GeneratedAt.Format("2006-01-02")
+	for _, draw := range draws {
+		if draw.DrawDate.Format("2006-01-02") >= generated {
+			return draw
+		}
+	}
+	return nil
+}
+
+// hasAlgorithm reports whether the named algorithm took part in ensemble
+func hasAlgorithm(ensemble *entity.EnsemblePrediction, name string) bool {
+	for _, prediction := range ensemble.Predictions {
+		if prediction.AlgorithmName == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func TestPredictionHistoryUseCase_Execute(t *testing.T) {
+	draws := createMockDraws(valueobject.Mega645, 5)
+	ctx := context.Background()
+	predictionRepo := &mockPredictionRepository{}
+
+	// One prediction per draw, generated the day before it and the newest
+	// targeting a draw that hasn't happened yet
+	targets := append(draws[1:], nil)
+	for i, draw := range targets {
+		target := draw
+		if target == nil {
+			target = createMockDraws(valueobject.Mega645, 6)[5]
+		}
+		actual := []int(target.Numbers)
+		picks := map[string][]int{"frequency_analysis": actual}
+		if i%2 == 0 {
+			picks["hot_cold_analysis"] = []int{40, 41, 42, 43, 44, 45}
+		}
+		ensemble := newTargetedEnsemble(t, target, []int{actual[0], actual[1], 40, 41, 42, 43}, picks)
+		ensemble.GeneratedAt = target.DrawDate.Add(-12 * time.Hour)
+		ensemble.Confidence = 0.1 * float64(i+1)
+		require.NoError(t, predictionRepo.SaveEnsemble(ctx, ensemble))
+	}
+
+	uc := NewPredictionHistoryUseCase(newMockDrawRepository(draws...), predictionRepo)
+
+	t.Run("lists newest first with target draws", func(t *testing.T) {
+		entries, err := uc.Execute(ctx, PredictionHistoryRequest{GameType: valueobject.Mega645})
+		require.NoError(t, err)
+		require.Len(t, entries, 5)
+
+		assert.Nil(t, entries[0].Draw, "the newest prediction's draw isn't stored yet")
+		assert.Zero(t, entries[0].MatchCount)
+		for i, entry := range entries[1:] {
+			require.NotNil(t, entry.Draw)
+			assert.Equal(t, 5-i, entry.Draw.DrawNumber)
+			assert.GreaterOrEqual(t, entry.MatchCount, 2)
+		}
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		entries, err := uc.Execute(ctx, PredictionHistoryRequest{
+			GameType:      valueobject.Mega645,
+			Since:         draws[2].DrawDate.Add(-24 * time.Hour),
+			Algorithm:     "hot_cold_analysis",
+			MinConfidence: 0.25,
+		})
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.InDelta(t, 0.5, entries[0].Prediction.Confidence, 1e-9)
+		assert.InDelta(t, 0.3, entries[1].Prediction.Confidence, 1e-9)
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		entries, err := uc.Execute(ctx, PredictionHistoryRequest{GameType: valueobject.Mega645, Limit: 2})
+		require.NoError(t, err)
+		assert.Len(t, entries, 2)
+	})
+
+	t.Run("verified outcome wins", func(t *testing.T) {
+		ensemble := predictionRepo.ensembles[0]
+		require.NoError(t, ensemble.Verify(draws[1]))
+		entries, err := uc.Execute(ctx, PredictionHistoryRequest{GameType: valueobject.Mega645})
+		require.NoError(t, err)
+		last := entries[len(entries)-1]
+		assert.Equal(t, ensemble.Outcome.MatchCount, last.MatchCount)
+		assert.Equal(t, draws[1].DrawNumber, last.Draw.DrawNumber)
+	})
+
+	t.Run("rejects bad filters", func(t *testing.T) {
+		_, err := uc.Execute(ctx, PredictionHistoryRequest{GameType: valueobject.Mega645, MinConfidence: 1.5})
+		assert.Error(t, err)
+		_, err = uc.Execute(ctx, PredictionHistoryRequest{GameType: valueobject.Mega645, Limit: -1})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/tool_predict/internal/domain/repository"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/pkg/algorithm"
+)
+
+// ReadinessUseCase reports whether enough draws are stored for every
+// registered algorithm to take part in a prediction
+type ReadinessUseCase struct {
+	drawRepo repository.DrawRepository
+	registry *algorithm.Registry
+}
+
+// NewReadinessUseCase creates a new readiness use case
+func NewReadinessUseCase(
+	drawRepo repository.DrawRepository,
+	registry *algorithm.Registry,
+) *ReadinessUseCase {
+	return &ReadinessUseCase{
+		drawRepo: drawRepo,
+		registry: registry,
+	}
+}
+
+// AlgorithmReadiness is one algorithm's draw requirement
+type AlgorithmReadiness struct {
+	Name        string `json:"name"`
+	MinDraws    int    `json:"min_draws"`
+	Satisfied   bool   `json:"satisfied"`
+	DrawsNeeded int    `json:"draws_needed"` // Draws still missing; 0 when satisfied
+}
+
+// ReadinessReport tells whether a full-ensemble prediction is possible for a
+// game and, if not, how many more draws it needs
+type ReadinessReport struct {
+	GameType    valueobject.GameType `json:"game_type"`
+	DrawCount   int                  `json:"draw_count"`
+	Ready       bool                 `json:"ready"`
+	DrawsNeeded int                  `json:"draws_needed"` // Draws missing for the most demanding algorithm
+	Algorithms  []AlgorithmReadiness `json:"algorithms"`
+}
+
+// Readiness compares the stored draw count of gameType with the minimum
+// draws of each registered algorithm. Algorithms are sorted by name.
+func (uc *ReadinessUseCase) Readiness(ctx context.Context, gameType valueobject.GameType) (*ReadinessReport, error) {
+	if err := gameType.Validate(); err != nil {
+		return nil, err
+	}
+
+	count, err := uc.drawRepo.Count(ctx, gameType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count draws: %w", err)
+	}
+
+	report := &ReadinessReport{
+		GameType:  gameType,
+		DrawCount: int(count),
+		Ready:     true,
+	}
+
+	for _, algo := range uc.registry.GetAlgorithmsForGameType(gameType) {
+		entry := AlgorithmReadiness{
+			Name:      algo.Name(),
+			MinDraws:  algo.GetMinDraws(),
+			Satisfied: report.DrawCount >= algo.GetMinDraws(),
+		}
+		if !entry.Satisfied {
+			entry.DrawsNeeded = entry.MinDraws - report.DrawCount
+			report.Ready = false
+			if entry.DrawsNeeded > report.DrawsNeeded {
+				report.DrawsNeeded = entry.DrawsNeeded
+			}
+		}
+		report.Algorithms = append(report.Algorithms, entry)
+	}
+
+	sort.Slice(report.Algorithms, func(i, j int) bool {
+		return report.Algorithms[i].Name < report.Algorithms[j].Name
+	})
+
+	return report, nil
+}
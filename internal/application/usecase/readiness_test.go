@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/pkg/algorithm"
+)
+
+func newReadinessRegistry(t *testing.T) *algorithm.Registry {
+	t.Helper()
+
+	registry := algorithm.NewRegistry()
+	require.NoError(t, registry.Register(algorithm.NewFrequencyAnalyzer(1.0), 1.0))
+	require.NoError(t, registry.Register(algorithm.NewHotColdAnalyzer(1.0), 1.0))
+	require.NoError(t, registry.Register(algorithm.NewPatternAnalyzer(1.0), 1.0))
+	return registry
+}
+
+func TestReadinessUseCase_PartiallySatisfied(t *testing.T) {
+	drawRepo := newMockDrawRepository(createMockDraws(valueobject.Mega645, 60)...)
+	uc := NewReadinessUseCase(drawRepo, newReadinessRegistry(t))
+
+	report, err := uc.Readiness(context.Background(), valueobject.Mega645)
+	require.NoError(t, err)
+
+	assert.Equal(t, 60, report.DrawCount)
+	assert.False(t, report.Ready)
+	assert.Equal(t, 40, report.DrawsNeeded)
+	assert.Equal(t, []AlgorithmReadiness{
+		{Name: "frequency_analysis", MinDraws: 8, Satisfied: true},
+		{Name: "hot_cold_analysis", MinDraws: 50, Satisfied: true},
+		{Name: "pattern_analysis", MinDraws: 100, Satisfied: false, DrawsNeeded: 40},
+	}, report.Algorithms)
+}
+
+func TestReadinessUseCase_Ready(t *testing.T) {
+	drawRepo := newMockDrawRepository(createMockDraws(valueobject.Mega645, 100)...)
+	uc := NewReadinessUseCase(drawRepo, newReadinessRegistry(t))
+
+	report, err := uc.Readiness(context.Background(), valueobject.Mega645)
+	require.NoError(t, err)
+
+	assert.True(t, report.Ready)
+	assert.Zero(t, report.DrawsNeeded)
+	for _, algo := range report.Algorithms {
+		assert.True(t, algo.Satisfied, algo.Name)
+	}
+}
+
+func TestReadinessUseCase_CountsOnlyRequestedGame(t *testing.T) {
+	drawRepo := newMockDrawRepository(createMockDraws(valueobject.Mega645, 100)...)
+	uc := NewReadinessUseCase(drawRepo, newReadinessRegistry(t))
+
+	report, err := uc.Readiness(context.Background(), valueobject.Power655)
+	require.NoError(t, err)
+
+	assert.Zero(t, report.DrawCount)
+	assert.False(t, report.Ready)
+	assert.Equal(t, 100, report.DrawsNeeded)
+}
@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/repository"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+// RebuildStatsUseCase recomputes algorithm statistics from the stored
+// backtest history, so stats that drifted or were edited by hand can be
+// reconstructed deterministically
+type RebuildStatsUseCase struct {
+	backtestRepo repository.BacktestRepository
+	statsRepo    repository.StatsRepository
+}
+
+// NewRebuildStatsUseCase creates a new stats rebuild use case
+func NewRebuildStatsUseCase(
+	backtestRepo repository.BacktestRepository,
+	statsRepo repository.StatsRepository,
+) *RebuildStatsUseCase {
+	return &RebuildStatsUseCase{
+		backtestRepo: backtestRepo,
+		statsRepo:    statsRepo,
+	}
+}
+
+// RebuildStatsRequest contains the rebuild parameters
+type RebuildStatsRequest struct {
+	GameType valueobject.GameType
+	Weights  map[string]float64 // Weight for algorithms with no stored stats; 1.0 if absent
+}
+
+// Execute aggregates every stored backtest of the game type per algorithm and
+// rewrites the stats. Accuracies are pooled over all predictions and the
+// average confidence is weighted by prediction count. Existing weights and
+// active flags are kept; stored stats of algorithms without any backtest are
+// reset to zero. Stats are returned sorted by algorithm name.
+func (uc *RebuildStatsUseCase) Execute(
+	ctx context.Context,
+	req RebuildStatsRequest,
+) ([]*entity.AlgorithmStats, error) {
+	if err := req.GameType.Validate(); err != nil {
+		return nil, err
+	}
+
+	results, err := uc.backtestRepo.FindByGameType(ctx, req.GameType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load backtest results: %w", err)
+	}
+
+	totals := aggregateBacktests(results)
+
+	existing, err := uc.statsRepo.FindByGameType(ctx, req.GameType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load algorithm stats: %w", err)
+	}
+	statsByName := make(map[string]*entity.AlgorithmStats, len(existing))
+	for _, stats := range existing {
+		statsByName[stats.AlgorithmName] = stats
+	}
+
+	for name := range totals {
+		if _, ok := statsByName[name]; ok {
+			continue
+		}
+		weight, ok := req.Weights[name]
+		if !ok {
+			weight = 1.0
+		}
+		stats, err := entity.NewAlgorithmStats(name, req.GameType, weight)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create stats for %s: %w", name, err)
+		}
+		statsByName[name] = stats
+	}
+
+	names := make([]string, 0, len(statsByName))
+	for name := range statsByName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	rebuilt := make([]*entity.AlgorithmStats, 0, len(names))
+	for _, name := range names {
+		stats := statsByName[name]
+		total := totals[name]
+		stats.UpdateMetrics(
+			total.rate(total.threeMatches),
+			total.rate(total.fourMatches),
+			total.rate(total.exactMatches),
+			total.averageConfidence(),
+			total.predictions,
+		)
+
+		if err := uc.statsRepo.Save(ctx, stats); err != nil {
+			return nil, fmt.Errorf("failed to save stats for %s: %w", name, err)
+		}
+
+		logger.Info("Rebuilt algorithm stats",
+			zap.String("algorithm", name),
+			zap.String("game_type", string(req.GameType)),
+			zap.Int("backtests", total.backtests),
+			zap.Int("total_predictions", total.predictions),
+		)
+		rebuilt = append(rebuilt, stats)
+	}
+
+	return rebuilt, nil
+}
+
+// backtestTotals accumulates backtest results of one algorithm
+type backtestTotals struct {
+	backtests        int
+	predictions      int
+	exactMatches     int
+	threeMatches     int
+	fourMatches      int
+	confidenceWeight float64 // Sum of average confidence times predictions
+}
+
+// rate returns count as a share of all predictions
+func (t backtestTotals) rate(count int) float64 {
+	if t.predictions == 0 {
+		return 0.0
+	}
+	return float64(count) / float64(t.predictions)
+}
+
+// averageConfidence returns the prediction-weighted average confidence
+func (t backtestTotals) averageConfidence() float64 {
+	if t.predictions == 0 {
+		return 0.0
+	}
+	return t.confidenceWeight / float64(t.predictions)
+}
+
+// aggregateBacktests sums backtest results per algorithm
+func aggregateBacktests(results []*entity.BacktestResult) map[string]backtestTotals {
+	totals := make(map[string]backtestTotals)
+	for _, result := range results {
+		total := totals[result.AlgorithmName]
+		total.backtests++
+		total.predictions += result.TotalPredictions
+		total.exactMatches += result.ExactMatches
+		total.threeMatches += result.ThreeNumberMatches
+		total.fourMatches += result.FourNumberMatches
+		total.confidenceWeight += result.AverageConfidence * float64(result.TotalPredictions)
+		totals[result.AlgorithmName] = total
+	}
+	return totals
+}
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func newTestBacktest(
+	t *testing.T,
+	gameType valueobject.GameType,
+	algorithmName string,
+	predictions, exact, three, four int,
+	confidence float64,
+) *entity.BacktestResult {
+	t.Helper()
+
+	period, err := valueobject.NewDateRange(
+		time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+	)
+	require.NoError(t, err)
+
+	result, err := entity.NewBacktestResult(gameType, algorithmName, period, predictions)
+	require.NoError(t, err)
+	result.ExactMatches = exact
+	result.ThreeNumberMatches = three
+	result.FourNumberMatches = four
+	result.AverageConfidence = confidence
+	return result
+}
+
+func TestRebuildStatsUseCase_AggregatesBacktests(t *testing.T) {
+	backtests := &mockBacktestRepository{results: []*entity.BacktestResult{
+		newTestBacktest(t, valueobject.Mega645, "frequency_analysis", 10, 1, 2, 1, 0.5),
+		newTestBacktest(t, valueobject.Mega645, "frequency_analysis", 30, 0, 6, 3, 0.7),
+		newTestBacktest(t, valueobject.Power655, "frequency_analysis", 50, 5, 5, 5, 0.9),
+	}}
+
+	// Drifted stats: the weight and active flag must survive the rebuild
+	drifted, err := entity.NewAlgorithmStats("frequency_analysis", valueobject.Mega645, 2.0)
+	require.NoError(t, err)
+	drifted.UpdateMetrics(0.9, 0.9, 0.9, 0.9, 999)
+	drifted.IsActive = false
+
+	// Stats with no backtest behind them are reset
+	orphan, err := entity.NewAlgorithmStats("pattern_analysis", valueobject.Mega645, 0.8)
+	require.NoError(t, err)
+	orphan.UpdateMetrics(0.3, 0.2, 0.1, 0.6, 12)
+
+	statsRepo := newMockStatsRepository(drifted, orphan)
+	uc := NewRebuildStatsUseCase(backtests, statsRepo)
+
+	rebuilt, err := uc.Execute(context.Background(), RebuildStatsRequest{GameType: valueobject.Mega645})
+	require.NoError(t, err)
+	require.Len(t, rebuilt, 2)
+
+	frequency := rebuilt[0]
+	assert.Equal(t, "frequency_analysis", frequency.AlgorithmName)
+	assert.Equal(t, 40, frequency.TotalPredictions)
+	assert.InDelta(t, 1.0/40, frequency.AccuracyExact, 1e-9)
+	assert.InDelta(t, 8.0/40, frequency.Accuracy3Numbers, 1e-9)
+	assert.InDelta(t, 4.0/40, frequency.Accuracy4Numbers, 1e-9)
+	assert.InDelta(t, (10*0.5+30*0.7)/40, frequency.AverageConfidence, 1e-9)
+	assert.Equal(t, 2.0, frequency.Weight)
+	assert.False(t, frequency.IsActive)
+
+	pattern := rebuilt[1]
+	assert.Equal(t, "pattern_analysis", pattern.AlgorithmName)
+	assert.Equal(t, 0, pattern.TotalPredictions)
+	assert.Zero(t, pattern.Accuracy3Numbers)
+	assert.Zero(t, pattern.AverageConfidence)
+	assert.Equal(t, 0.8, pattern.Weight)
+
+	// Power 6/55 stats were not touched
+	power, err := statsRepo.FindByGameType(context.Background(), valueobject.Power655)
+	require.NoError(t, err)
+	assert.Empty(t, power)
+}
+
+func TestRebuildStatsUseCase_CreatesMissingStats(t *testing.T) {
+	backtests := &mockBacktestRepository{results: []*entity.BacktestResult{
+		newTestBacktest(t, valueobject.Power655, "hot_cold_analysis", 20, 0, 4, 1, 0.6),
+		newTestBacktest(t, valueobject.Power655, "random_analysis", 20, 0, 2, 0, 0.1),
+	}}
+	statsRepo := newMockStatsRepository()
+	uc := NewRebuildStatsUseCase(backtests, statsRepo)
+
+	rebuilt, err := uc.Execute(context.Background(), RebuildStatsRequest{
+		GameType: valueobject.Power655,
+		Weights:  map[string]float64{"hot_cold_analysis": 1.2},
+	})
+	require.NoError(t, err)
+	require.Len(t, rebuilt, 2)
+
+	assert.Equal(t, "hot_cold_analysis", rebuilt[0].AlgorithmName)
+	assert.Equal(t, 1.2, rebuilt[0].Weight)
+	assert.InDelta(t, 0.2, rebuilt[0].Accuracy3Numbers, 1e-9)
+	assert.True(t, rebuilt[0].IsActive)
+
+	assert.Equal(t, "random_analysis", rebuilt[1].AlgorithmName)
+	assert.Equal(t, 1.0, rebuilt[1].Weight)
+
+	stored, err := statsRepo.FindByGameType(context.Background(), valueobject.Power655)
+	require.NoError(t, err)
+	assert.Len(t, stored, 2)
+
+	_, err = uc.Execute(context.Background(), RebuildStatsRequest{GameType: "KENO"})
+	assert.Error(t, err)
+}
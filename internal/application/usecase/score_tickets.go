@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/repository"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// ScoreTicketsUseCase checks played tickets against stored draw results
+type ScoreTicketsUseCase struct {
+	drawRepo repository.DrawRepository
+}
+
+// NewScoreTicketsUseCase creates a new ticket scoring use case
+func NewScoreTicketsUseCase(drawRepo repository.DrawRepository) *ScoreTicketsUseCase {
+	return &ScoreTicketsUseCase{
+		drawRepo: drawRepo,
+	}
+}
+
+// Ticket is one played line for a given draw
+type Ticket struct {
+	DrawNumber int
+	Numbers    valueobject.Numbers
+}
+
+// ScoreTicketsRequest contains the tickets to score
+type ScoreTicketsRequest struct {
+	GameType valueobject.GameType
+	Tickets  []Ticket
+}
+
+// TicketScore is the outcome of a single ticket
+type TicketScore struct {
+	Ticket  Ticket
+	Draw    *entity.Draw // Nil when the draw couldn't be loaded
+	Matches int
+	Tier    entity.PrizeTier
+	Err     error // Why the draw couldn't be loaded
+}
+
+// Execute scores every ticket. A ticket whose draw isn't stored is reported
+// through TicketScore.Err rather than failing the whole batch.
+func (uc *ScoreTicketsUseCase) Execute(ctx context.Context, req ScoreTicketsRequest) ([]TicketScore, error) {
+	if err := req.GameType.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Many tickets usually share a draw, so load each draw once
+	draws := make(map[int]*entity.Draw)
+	drawErrs := make(map[int]error)
+
+	scores := make([]TicketScore, 0, len(req.Tickets))
+	for _, ticket := range req.Tickets {
+		score := TicketScore{Ticket: ticket, Tier: entity.PrizeNone}
+
+		draw, loaded := draws[ticket.DrawNumber]
+		err := drawErrs[ticket.DrawNumber]
+		if !loaded && err == nil {
+			draw, err = uc.drawRepo.FindByGameTypeAndDrawNumber(ctx, req.GameType, ticket.DrawNumber)
+			if err != nil {
+				drawErrs[ticket.DrawNumber] = err
+			} else {
+				draws[ticket.DrawNumber] = draw
+			}
+		}
+
+		if err != nil {
+			score.Err = err
+		} else {
+			score.Draw = draw
+			score.Matches = draw.Numbers.MatchCount(ticket.Numbers)
+			score.Tier = draw.PrizeTier(ticket.Numbers)
+		}
+		scores = append(scores, score)
+	}
+
+	return scores, nil
+}
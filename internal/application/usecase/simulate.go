@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/repository"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"github.com/tool_predict/pkg/algorithm"
+	"go.uber.org/zap"
+)
+
+// SimulateUseCase replays stored draws as if the ensemble's prediction had
+// been bought every draw, reinvesting winnings
+type SimulateUseCase struct {
+	drawRepo repository.DrawRepository
+	ensemble *algorithm.Ensemble
+}
+
+// NewSimulateUseCase creates a new budget simulation use case
+func NewSimulateUseCase(
+	drawRepo repository.DrawRepository,
+	ensemble *algorithm.Ensemble,
+) *SimulateUseCase {
+	return &SimulateUseCase{
+		drawRepo: drawRepo,
+		ensemble: ensemble,
+	}
+}
+
+// SimulateRequest contains the simulation parameters
+type SimulateRequest struct {
+	GameType valueobject.GameType
+	Budget   float64 // Starting balance in VND
+	Draws    int     // Number of latest stored draws to play
+	MaxDraws int     // Latest draws before each played draw the ensemble sees
+}
+
+// SimulationStep is the balance after playing one draw
+type SimulationStep struct {
+	DrawNumber int              `json:"draw_number"`
+	DrawDate   time.Time        `json:"draw_date"`
+	Ticket     []int            `json:"ticket"`
+	Matches    int              `json:"matches"`
+	Tier       entity.PrizeTier `json:"tier"`
+	Cost       float64          `json:"cost"`
+	Winnings   float64          `json:"winnings"`
+	Balance    float64          `json:"balance"`
+}
+
+// SimulationResult is the balance trajectory of a simulation
+type SimulationResult struct {
+	GameType      valueobject.GameType `json:"game_type"`
+	Budget        float64              `json:"budget"`
+	FinalBalance  float64              `json:"final_balance"`
+	TotalCost     float64              `json:"total_cost"`
+	TotalWinnings float64              `json:"total_winnings"`
+	DrawsPlayed   int                  `json:"draws_played"`
+	Bankrupt      bool                 `json:"bankrupt"` // Stopped early because a ticket was no longer affordable
+	Steps         []SimulationStep     `json:"steps"`
+}
+
+// Execute plays one line of the ensemble's pick on each of the latest
+// req.Draws stored draws, oldest first, predicting each from the draws before
+// it. The ticket price is paid before the draw and winnings (see
+// entity.Draw.Payout) are added after. The simulation stops once the balance
+// can't pay for a ticket.
+func (uc *SimulateUseCase) Execute(ctx context.Context, req SimulateRequest) (*SimulationResult, error) {
+	if err := req.GameType.Validate(); err != nil {
+		return nil, err
+	}
+	if req.Budget < entity.TicketPrice {
+		return nil, fmt.Errorf("budget must cover at least one ticket of %.0f VND, got %.0f", entity.TicketPrice, req.Budget)
+	}
+	if req.Draws <= 0 {
+		return nil, fmt.Errorf("draws to play must be positive, got %d", req.Draws)
+	}
+	if req.MaxDraws <= 0 {
+		return nil, fmt.Errorf("max draws must be positive, got %d", req.MaxDraws)
+	}
+
+	count, err := uc.drawRepo.Count(ctx, req.GameType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count draws: %w", err)
+	}
+	var history []*entity.Draw
+	if count > 0 {
+		history, err = uc.drawRepo.FindLatest(ctx, req.GameType, int(count))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load draws: %w", err)
+		}
+	}
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].DrawDate.Before(history[j].DrawDate)
+	})
+
+	start := len(history) - req.Draws
+	if start < 0 {
+		start = 0
+	}
+	required, algoName := uc.ensemble.MinDrawsRequired()
+	if available := min(start, req.MaxDraws); available < required {
+		return nil, fmt.Errorf("insufficient historical data: need at least %d draws (%s) before the first played draw, have %d",
+			required, algoName, available)
+	}
+
+	logger.Info("Starting budget simulation",
+		zap.String("game_type", string(req.GameType)),
+		zap.Float64("budget", req.Budget),
+		zap.Int("draws", len(history)-start),
+	)
+
+	result := &SimulationResult{
+		GameType:     req.GameType,
+		Budget:       req.Budget,
+		FinalBalance: req.Budget,
+		Steps:        make([]SimulationStep, 0, len(history)-start),
+	}
+	for i := start; i < len(history); i++ {
+		if result.FinalBalance < entity.TicketPrice {
+			result.Bankrupt = true
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		before := make([]*entity.Draw, i)
+		copy(before, history[:i])
+		prediction, err := uc.ensemble.GeneratePredictions(ctx, req.GameType, sortAndLimitDraws(before, req.MaxDraws))
+		if err != nil {
+			return nil, fmt.Errorf("prediction for draw #%d failed: %w", history[i].DrawNumber, err)
+		}
+
+		draw := history[i]
+		ticket := prediction.FinalNumbers
+		winnings := draw.Payout(ticket)
+		result.FinalBalance += winnings - entity.TicketPrice
+		result.TotalCost += entity.TicketPrice
+		result.TotalWinnings += winnings
+		result.DrawsPlayed++
+		result.Steps = append(result.Steps, SimulationStep{
+			DrawNumber: draw.DrawNumber,
+			DrawDate:   draw.DrawDate,
+			Ticket:     ticket.AsSlice(),
+			Matches:    draw.Numbers.MatchCount(ticket),
+			Tier:       draw.PrizeTier(ticket),
+			Cost:       entity.TicketPrice,
+			Winnings:   winnings,
+			Balance:    result.FinalBalance,
+		})
+	}
+
+	logger.Info("Budget simulation completed",
+		zap.Int("draws_played", result.DrawsPlayed),
+		zap.Float64("final_balance", result.FinalBalance),
+		zap.Bool("bankrupt", result.Bankrupt),
+	)
+
+	return result, nil
+}
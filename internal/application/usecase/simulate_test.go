@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/pkg/algorithm"
+)
+
+// fixedPickAlgorithm always picks the same numbers
+type fixedPickAlgorithm struct {
+	numbers   []int
+	trainedOn []int // Draws each Train call was given
+}
+
+func (f *fixedPickAlgorithm) Name() string {
+	return "fixed_pick"
+}
+
+func (f *fixedPickAlgorithm) Predict(ctx context.Context, gameType valueobject.GameType, historicalData []*entity.Draw) (*entity.Prediction, error) {
+	return entity.NewPrediction(gameType, f.Name(), valueobject.MustNewNumbers(f.numbers), 0.5, time.Now())
+}
+
+func (f *fixedPickAlgorithm) Train(ctx context.Context, historicalData []*entity.Draw) error {
+	f.trainedOn = append(f.trainedOn, len(historicalData))
+	return nil
+}
+
+func (f *fixedPickAlgorithm) Validate(historicalData []*entity.Draw) error {
+	return nil
+}
+
+func (f *fixedPickAlgorithm) GetMinDraws() int {
+	return 1
+}
+
+func (f *fixedPickAlgorithm) GetWeight() float64 {
+	return 1
+}
+
+func (f *fixedPickAlgorithm) SetWeight(weight float64) error {
+	return nil
+}
+
+// newSimulationDraws stores one warm-up draw followed by the given draws
+func newSimulationDraws(t *testing.T, played ...[]int) *mockDrawRepository {
+	t.Helper()
+
+	baseDate := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	all := append([][]int{{40, 41, 42, 43, 44, 45}}, played...)
+	draws := make([]*entity.Draw, 0, len(all))
+	for i, nums := range all {
+		draw, err := entity.NewDraw(valueobject.Mega645, i+1, valueobject.MustNewNumbers(nums),
+			baseDate.AddDate(0, 0, 2*i), 0, 0)
+		require.NoError(t, err)
+		draws = append(draws, draw)
+	}
+	return newMockDrawRepository(draws...)
+}
+
+func newSimulateUseCase(t *testing.T, drawRepo *mockDrawRepository) *SimulateUseCase {
+	t.Helper()
+
+	registry := algorithm.NewRegistry()
+	require.NoError(t, registry.Register(&fixedPickAlgorithm{numbers: []int{1, 2, 3, 4, 5, 6}}, 1.0))
+	return NewSimulateUseCase(drawRepo, algorithm.NewEnsemble(registry, algorithm.WeightedVoting))
+}
+
+func TestSimulateUseCase_Execute_BalanceMath(t *testing.T) {
+	drawRepo := newSimulationDraws(t,
+		[]int{1, 2, 3, 20, 21, 22}, // 3 numbers: 30,000
+		[]int{1, 2, 3, 4, 21, 22},  // 4 numbers: 300,000
+		[]int{30, 31, 32, 33, 34, 35},
+	)
+	uc := newSimulateUseCase(t, drawRepo)
+
+	result, err := uc.Execute(context.Background(), SimulateRequest{
+		GameType: valueobject.Mega645,
+		Budget:   50000,
+		Draws:    3,
+		MaxDraws: 10,
+	})
+	require.NoError(t, err)
+
+	// 50,000 - 3 tickets of 10,000 + 30,000 + 300,000
+	assert.Equal(t, 350000.0, result.FinalBalance)
+	assert.Equal(t, 30000.0, result.TotalCost)
+	assert.Equal(t, 330000.0, result.TotalWinnings)
+	assert.Equal(t, 3, result.DrawsPlayed)
+	assert.False(t, result.Bankrupt)
+
+	require.Len(t, result.Steps, 3)
+	assert.Equal(t, []float64{70000, 360000, 350000},
+		[]float64{result.Steps[0].Balance, result.Steps[1].Balance, result.Steps[2].Balance})
+	assert.Equal(t, entity.PrizeThird, result.Steps[0].Tier)
+	assert.Equal(t, 4, result.Steps[1].Matches)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, result.Steps[2].Ticket)
+	assert.Equal(t, 2, result.Steps[0].DrawNumber)
+}
+
+func TestSimulateUseCase_Execute_StopsWhenBankrupt(t *testing.T) {
+	drawRepo := newSimulationDraws(t,
+		[]int{30, 31, 32, 33, 34, 35},
+		[]int{30, 31, 32, 33, 34, 36},
+		[]int{30, 31, 32, 33, 34, 37},
+	)
+	uc := newSimulateUseCase(t, drawRepo)
+
+	result, err := uc.Execute(context.Background(), SimulateRequest{
+		GameType: valueobject.Mega645,
+		Budget:   25000,
+		Draws:    3,
+		MaxDraws: 10,
+	})
+	require.NoError(t, err)
+
+	assert.True(t, result.Bankrupt)
+	assert.Equal(t, 2, result.DrawsPlayed)
+	assert.Equal(t, 5000.0, result.FinalBalance)
+}
+
+func TestSimulateUseCase_Execute_Validation(t *testing.T) {
+	uc := newSimulateUseCase(t, newSimulationDraws(t, []int{1, 2, 3, 4, 5, 6}))
+	ctx := context.Background()
+
+	_, err := uc.Execute(ctx, SimulateRequest{GameType: valueobject.Mega645, Budget: 5000, Draws: 1, MaxDraws: 10})
+	assert.Error(t, err)
+
+	// Playing every stored draw leaves no history to predict the first from
+	_, err = uc.Execute(ctx, SimulateRequest{GameType: valueobject.Mega645, Budget: 50000, Draws: 2, MaxDraws: 10})
+	assert.ErrorContains(t, err, "insufficient historical data")
+}
@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tool_predict/internal/application/port"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/repository"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"github.com/tool_predict/pkg/analytics"
+	"go.uber.org/zap"
+)
+
+// SyncUseCase brings stored draws up to date with the website by fetching
+// only the draws storage lacks: those newer than the latest stored draw and
+// the gaps in the stored draw numbers
+type SyncUseCase struct {
+	drawRepo repository.DrawRepository
+	scraper  port.VietlottScraper
+}
+
+// NewSyncUseCase creates a new draw sync use case
+func NewSyncUseCase(drawRepo repository.DrawRepository, scraper port.VietlottScraper) *SyncUseCase {
+	return &SyncUseCase{
+		drawRepo: drawRepo,
+		scraper:  scraper,
+	}
+}
+
+// SyncRequest contains the sync parameters
+type SyncRequest struct {
+	GameType valueobject.GameType
+	MaxDraws int // Most missing draws to fetch, newest first; 0 for no cap
+}
+
+// SyncResult reports what a sync found missing and fetched
+type SyncResult struct {
+	GameType     valueobject.GameType
+	LocalLatest  int   // Latest stored draw number before the sync
+	RemoteLatest int   // Latest draw number on the website
+	Gaps         []int // Draw numbers missing below LocalLatest, ascending
+	Missing      int   // Draws missing in all, gaps and newer draws
+	Saved        []int // Draw numbers fetched and stored, ascending
+	Failed       []int // Missing draw numbers that couldn't be fetched or stored, ascending
+	Skipped      int   // Missing draws left for a later sync by MaxDraws
+}
+
+// Execute compares the latest stored draw number with the website's and
+// the stored draw numbers with each other, then fetches the missing draws:
+// newer draws in one listing request, gaps one draw at a time. A draw that
+// fails to fetch or save is reported in Failed and the others are still
+// synced. Storage must hold at least one draw; crawl an empty store first.
+func (uc *SyncUseCase) Execute(ctx context.Context, req SyncRequest) (*SyncResult, error) {
+	if err := req.GameType.Validate(); err != nil {
+		return nil, err
+	}
+	if req.MaxDraws < 0 {
+		return nil, fmt.Errorf("max draws cannot be negative, got %d", req.MaxDraws)
+	}
+
+	count, err := uc.drawRepo.Count(ctx, req.GameType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count stored draws: %w", err)
+	}
+	if count == 0 {
+		return nil, fmt.Errorf("no %s draws stored to sync from; crawl first", req.GameType)
+	}
+	stored, err := uc.drawRepo.FindLatest(ctx, req.GameType, int(count))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load stored draws: %w", err)
+	}
+
+	result := &SyncResult{GameType: req.GameType}
+	for _, draw := range stored {
+		result.LocalLatest = max(result.LocalLatest, draw.DrawNumber)
+	}
+	result.Gaps = analytics.FindDrawNumberGaps(stored, req.GameType)
+
+	result.RemoteLatest, err = uc.scraper.GetLatestDrawNumber(ctx, req.GameType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest draw number from scraper: %w", err)
+	}
+
+	missing := append([]int{}, result.Gaps...)
+	for num := result.LocalLatest + 1; num <= result.RemoteLatest; num++ {
+		missing = append(missing, num)
+	}
+	result.Missing = len(missing)
+	if req.MaxDraws > 0 && len(missing) > req.MaxDraws {
+		result.Skipped = len(missing) - req.MaxDraws
+		missing = missing[result.Skipped:]
+	}
+
+	logger.Info("Syncing draws",
+		zap.String("game_type", string(req.GameType)),
+		zap.Int("local_latest", result.LocalLatest),
+		zap.Int("remote_latest", result.RemoteLatest),
+		zap.Int("gaps", len(result.Gaps)),
+		zap.Int("missing", result.Missing),
+		zap.Int("fetching", len(missing)),
+	)
+
+	fetched := uc.fetchNewer(ctx, req.GameType, missing, result.LocalLatest)
+	for _, num := range missing {
+		draw, ok := fetched[num]
+		if !ok {
+			draw, err = uc.scraper.FetchDrawByNumber(ctx, req.GameType, num)
+			if err != nil {
+				logger.Warn("Failed to fetch missing draw", zap.Int("draw_number", num), zap.Error(err))
+				result.Failed = append(result.Failed, num)
+				continue
+			}
+		}
+		if err := uc.drawRepo.Save(ctx, draw); err != nil {
+			logger.Warn("Failed to save draw", zap.Int("draw_number", num), zap.Error(err))
+			result.Failed = append(result.Failed, num)
+			continue
+		}
+		result.Saved = append(result.Saved, num)
+	}
+
+	logger.Info("Sync finished",
+		zap.String("game_type", string(req.GameType)),
+		zap.Int("saved", len(result.Saved)),
+		zap.Int("failed", len(result.Failed)),
+		zap.Int("skipped", result.Skipped),
+	)
+	return result, nil
+}
+
+// fetchNewer lists the latest draws once to pick up every missing draw newer
+// than localLatest, keyed by draw number. A failed listing only logs a
+// warning; the draws are then fetched one at a time.
+func (uc *SyncUseCase) fetchNewer(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	missing []int,
+	localLatest int,
+) map[int]*entity.Draw {
+	wanted := make(map[int]bool)
+	newest := 0
+	for _, num := range missing {
+		if num > localLatest {
+			wanted[num] = true
+			newest = max(newest, num)
+		}
+	}
+	if len(wanted) == 0 {
+		return nil
+	}
+
+	draws, err := uc.scraper.FetchLatestDraws(ctx, gameType, newest-localLatest)
+	if err != nil {
+		logger.Warn("Failed to list new draws, fetching them one at a time", zap.Error(err))
+		return nil
+	}
+
+	fetched := make(map[int]*entity.Draw, len(wanted))
+	for _, draw := range draws {
+		if wanted[draw.DrawNumber] {
+			fetched[draw.DrawNumber] = draw
+		}
+	}
+	return fetched
+}
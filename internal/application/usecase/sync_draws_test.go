@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func TestSyncUseCase_FetchesGapsAndNewDraws(t *testing.T) {
+	ctx := context.Background()
+	gt := valueobject.Mega645
+	remote := createMockDraws(gt, 30)
+
+	// Draws #1-#25 are stored except #5 and #12-#13
+	var local []*entity.Draw
+	for _, draw := range remote[:25] {
+		if draw.DrawNumber != 5 && draw.DrawNumber != 12 && draw.DrawNumber != 13 {
+			local = append(local, draw)
+		}
+	}
+	drawRepo := newMockDrawRepository(local...)
+	uc := NewSyncUseCase(drawRepo, &mockScraper{draws: remote})
+
+	result, err := uc.Execute(ctx, SyncRequest{GameType: gt})
+	require.NoError(t, err)
+	assert.Equal(t, 25, result.LocalLatest)
+	assert.Equal(t, 30, result.RemoteLatest)
+	assert.Equal(t, []int{5, 12, 13}, result.Gaps)
+	assert.Equal(t, 8, result.Missing)
+	assert.Equal(t, []int{5, 12, 13, 26, 27, 28, 29, 30}, result.Saved)
+	assert.Empty(t, result.Failed)
+
+	count, err := drawRepo.Count(ctx, gt)
+	require.NoError(t, err)
+	assert.Equal(t, int64(30), count)
+
+	// Nothing is left to fetch
+	result, err = uc.Execute(ctx, SyncRequest{GameType: gt})
+	require.NoError(t, err)
+	assert.Zero(t, result.Missing)
+	assert.Empty(t, result.Saved)
+}
+
+func TestSyncUseCase_MaxDrawsKeepsNewest(t *testing.T) {
+	gt := valueobject.Mega645
+	remote := createMockDraws(gt, 20)
+	drawRepo := newMockDrawRepository(remote[0], remote[9])
+	uc := NewSyncUseCase(drawRepo, &mockScraper{draws: remote})
+
+	result, err := uc.Execute(context.Background(), SyncRequest{GameType: gt, MaxDraws: 4})
+	require.NoError(t, err)
+	assert.Equal(t, 18, result.Missing)
+	assert.Equal(t, 14, result.Skipped)
+	assert.Equal(t, []int{17, 18, 19, 20}, result.Saved)
+}
+
+func TestSyncUseCase_Errors(t *testing.T) {
+	gt := valueobject.Mega645
+
+	_, err := NewSyncUseCase(newMockDrawRepository(), &mockScraper{}).Execute(context.Background(), SyncRequest{GameType: gt})
+	assert.Error(t, err, "an empty store has nothing to sync from")
+
+	uc := NewSyncUseCase(newMockDrawRepository(createMockDraws(gt, 3)...), &mockScraper{err: errors.New("offline")})
+	_, err = uc.Execute(context.Background(), SyncRequest{GameType: gt})
+	assert.Error(t, err)
+}
@@ -0,0 +1,184 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tool_predict/internal/application/port"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/repository"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+// VerifyPredictionsUseCase scores stored ensemble predictions against the
+// real draws they targeted once those are fetched, recording the outcome
+// with the prediction and in the algorithm stats
+type VerifyPredictionsUseCase struct {
+	drawRepo       repository.DrawRepository
+	predictionRepo repository.PredictionRepository
+	statsRepo      repository.StatsRepository
+	notifier       port.PredictionNotifier // Optional, nil disables notifications
+}
+
+// NewVerifyPredictionsUseCase creates a new prediction verification use case
+func NewVerifyPredictionsUseCase(
+	drawRepo repository.DrawRepository,
+	predictionRepo repository.PredictionRepository,
+	statsRepo repository.StatsRepository,
+) *VerifyPredictionsUseCase {
+	return &VerifyPredictionsUseCase{
+		drawRepo:       drawRepo,
+		predictionRepo: predictionRepo,
+		statsRepo:      statsRepo,
+	}
+}
+
+// SetNotifier announces each newly verified prediction's outcome through
+// notifier; nil disables notifications
+func (uc *VerifyPredictionsUseCase) SetNotifier(notifier port.PredictionNotifier) {
+	uc.notifier = notifier
+}
+
+// VerifyPredictionsRequest contains the verification parameters
+type VerifyPredictionsRequest struct {
+	GameType   valueobject.GameType
+	DrawNumber int // Draw to verify; 0 verifies the latest Draws stored draws
+	Draws      int // Latest stored draws to verify when DrawNumber is 0; 1 if zero
+}
+
+// VerifiedPrediction is the ensemble prediction for one draw and how it did
+type VerifiedPrediction struct {
+	Draw       *entity.Draw
+	Prediction *entity.EnsemblePrediction // Nil when no prediction targeted the draw
+	Skipped    bool                       // Already verified before; stats were left alone
+}
+
+// Execute verifies the prediction for each requested draw, latest draw
+// first. A prediction is verified once: every algorithm's match count is
+// added to that algorithm's stats, then its outcome is saved with it. Stats
+// remember the prediction they recorded last, so re-running after a failure
+// between the two doesn't count it twice. Draws without a stored prediction
+// are reported with a nil Prediction; failing to look one up is an error.
+func (uc *VerifyPredictionsUseCase) Execute(ctx context.Context, req VerifyPredictionsRequest) ([]VerifiedPrediction, error) {
+	if err := req.GameType.Validate(); err != nil {
+		return nil, err
+	}
+	if req.Draws < 0 {
+		return nil, fmt.Errorf("draws to verify cannot be negative, got %d", req.Draws)
+	}
+
+	var draws []*entity.Draw
+	if req.DrawNumber > 0 {
+		draw, err := uc.drawRepo.FindByGameTypeAndDrawNumber(ctx, req.GameType, req.DrawNumber)
+		if err != nil {
+			return nil, fmt.Errorf("draw #%d not stored: %w", req.DrawNumber, err)
+		}
+		draws = []*entity.Draw{draw}
+	} else {
+		limit := max(req.Draws, 1)
+		latest, err := uc.drawRepo.FindLatest(ctx, req.GameType, limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load draws: %w", err)
+		}
+		if len(latest) == 0 {
+			return nil, fmt.Errorf("no %s draws stored", req.GameType)
+		}
+		draws = latest
+	}
+
+	existing, err := uc.statsRepo.FindByGameType(ctx, req.GameType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load algorithm stats: %w", err)
+	}
+	statsByName := make(map[string]*entity.AlgorithmStats, len(existing))
+	for _, stats := range existing {
+		statsByName[stats.AlgorithmName] = stats
+	}
+
+	verified := make([]VerifiedPrediction, 0, len(draws))
+	for _, draw := range draws {
+		year, month, day := draw.DrawDate.Date()
+		forDate := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		prediction, err := uc.predictionRepo.FindCurrentEnsemble(ctx, req.GameType, forDate)
+		if errors.Is(err, repository.ErrNotFound) {
+			verified = append(verified, VerifiedPrediction{Draw: draw})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to load prediction for draw #%d: %w", draw.DrawNumber, err)
+		}
+		if prediction.Outcome != nil {
+			verified = append(verified, VerifiedPrediction{Draw: draw, Prediction: prediction, Skipped: true})
+			continue
+		}
+
+		if err := prediction.Verify(draw); err != nil {
+			return nil, fmt.Errorf("failed to verify prediction %s: %w", prediction.ID, err)
+		}
+		if err := uc.recordStats(ctx, prediction, statsByName); err != nil {
+			return nil, err
+		}
+		if err := uc.predictionRepo.SaveEnsemble(ctx, prediction); err != nil {
+			return nil, fmt.Errorf("failed to save outcome of prediction %s: %w", prediction.ID, err)
+		}
+
+		logger.Info("Verified prediction",
+			zap.String("prediction_id", prediction.ID),
+			zap.Int("draw_number", draw.DrawNumber),
+			zap.Int("match_count", prediction.Outcome.MatchCount),
+		)
+		if uc.notifier != nil {
+			if err := uc.notifier.NotifyVerified(ctx, draw, prediction); err != nil {
+				logger.Warn("Failed to notify verified prediction",
+					zap.String("prediction_id", prediction.ID),
+					zap.Error(err),
+				)
+			}
+		}
+		verified = append(verified, VerifiedPrediction{Draw: draw, Prediction: prediction})
+	}
+
+	return verified, nil
+}
+
+// recordStats adds each algorithm's outcome in a verified prediction to its
+// stats, creating stats with the algorithm's ensemble weight when missing.
+// Stats that already recorded the prediction are left alone.
+func (uc *VerifyPredictionsUseCase) recordStats(
+	ctx context.Context,
+	prediction *entity.EnsemblePrediction,
+	statsByName map[string]*entity.AlgorithmStats,
+) error {
+	weights := make(map[string]float64, len(prediction.AlgorithmStats))
+	for _, contribution := range prediction.AlgorithmStats {
+		weights[contribution.AlgorithmName] = contribution.Weight
+	}
+
+	for _, algoPrediction := range prediction.Predictions {
+		name := algoPrediction.AlgorithmName
+		stats, ok := statsByName[name]
+		if !ok {
+			var err error
+			stats, err = entity.NewAlgorithmStats(name, prediction.GameType, weights[name])
+			if err != nil {
+				return fmt.Errorf("failed to create stats for %s: %w", name, err)
+			}
+			statsByName[name] = stats
+		}
+
+		if stats.LastPredictionID == prediction.ID {
+			continue
+		}
+
+		stats.RecordOutcome(prediction.Outcome.AlgorithmMatches[name], algoPrediction.Confidence)
+		stats.LastPredictionID = prediction.ID
+		if err := uc.statsRepo.Save(ctx, stats); err != nil {
+			return fmt.Errorf("failed to save stats for %s: %w", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// newTargetedEnsemble returns an ensemble prediction for draw's date in which
+// each named algorithm predicted the given numbers
+func newTargetedEnsemble(t *testing.T, draw *entity.Draw, final []int, picks map[string][]int) *entity.EnsemblePrediction {
+	t.Helper()
+
+	var predictions []*entity.Prediction
+	var contributions []entity.AlgorithmContribution
+	for name, nums := range picks {
+		prediction, err := entity.NewPrediction(draw.GameType, name, valueobject.MustNewNumbers(nums), 0.4, draw.DrawDate)
+		require.NoError(t, err)
+		predictions = append(predictions, prediction)
+		contributions = append(contributions, entity.AlgorithmContribution{AlgorithmName: name, Weight: 0.5})
+	}
+	ensemble, err := entity.NewEnsemblePrediction(draw.GameType, predictions, valueobject.MustNewNumbers(final), "weighted", contributions)
+	require.NoError(t, err)
+	year, month, day := draw.DrawDate.Date()
+	ensemble.ForDate = time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	return ensemble
+}
+
+func TestVerifyPredictionsUseCase_Execute(t *testing.T) {
+	draws := createMockDraws(valueobject.Mega645, 3)
+	latest := draws[2]
+	actual := []int(latest.Numbers)
+
+	predictionRepo := &mockPredictionRepository{}
+	ensemble := newTargetedEnsemble(t, latest, []int{actual[0], actual[1], actual[2], 40, 41, 42}, map[string][]int{
+		"frequency_analysis": {actual[0], actual[1], actual[2], actual[3], 41, 42},
+		"hot_cold_analysis":  {40, 41, 42, 43, 44, 45},
+	})
+	require.NoError(t, predictionRepo.SaveEnsemble(context.Background(), ensemble))
+
+	existing, err := entity.NewAlgorithmStats("hot_cold_analysis", valueobject.Mega645, 0.9)
+	require.NoError(t, err)
+	existing.UpdateMetrics(0.5, 0, 0, 0.6, 2)
+	statsRepo := newMockStatsRepository(existing)
+
+	uc := NewVerifyPredictionsUseCase(newMockDrawRepository(draws...), predictionRepo, statsRepo)
+	notifier := &mockPredictionNotifier{}
+	uc.SetNotifier(notifier)
+	verified, err := uc.Execute(context.Background(), VerifyPredictionsRequest{GameType: valueobject.Mega645, Draws: 2})
+	require.NoError(t, err)
+	require.Len(t, verified, 2)
+
+	assert.Equal(t, latest.DrawNumber, verified[0].Draw.DrawNumber)
+	require.NotNil(t, verified[0].Prediction)
+	assert.False(t, verified[0].Skipped)
+	outcome := verified[0].Prediction.Outcome
+	require.NotNil(t, outcome)
+	assert.Equal(t, 3, outcome.MatchCount)
+	assert.Equal(t, map[string]int{"frequency_analysis": 4, "hot_cold_analysis": 0}, outcome.AlgorithmMatches)
+	assert.Nil(t, verified[1].Prediction, "no prediction targeted the earlier draw")
+
+	stats, err := statsRepo.FindByGameType(context.Background(), valueobject.Mega645)
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+	for _, s := range stats {
+		switch s.AlgorithmName {
+		case "frequency_analysis":
+			assert.Equal(t, 1, s.TotalPredictions)
+			assert.InDelta(t, 1.0, s.Accuracy4Numbers, 1e-9)
+			assert.InDelta(t, 0.5, s.Weight, 1e-9, "new stats take the ensemble weight")
+		case "hot_cold_analysis":
+			assert.Equal(t, 3, s.TotalPredictions)
+			assert.InDelta(t, 1.0/3, s.Accuracy3Numbers, 1e-9)
+			assert.InDelta(t, (0.6*2+0.4)/3, s.AverageConfidence, 1e-9)
+			assert.InDelta(t, 0.9, s.Weight, 1e-9)
+		}
+	}
+
+	// Verifying again leaves the stats alone
+	verified, err = uc.Execute(context.Background(), VerifyPredictionsRequest{GameType: valueobject.Mega645, DrawNumber: latest.DrawNumber})
+	require.NoError(t, err)
+	require.Len(t, verified, 1)
+	assert.True(t, verified[0].Skipped)
+	assert.Equal(t, 3, existing.TotalPredictions)
+	require.Len(t, notifier.verified, 1, "only the first verification is announced")
+	assert.Equal(t, ensemble.ID, notifier.verified[0].ID)
+}
+
+func TestVerifyPredictionsUseCase_Execute_UnknownDraw(t *testing.T) {
+	uc := NewVerifyPredictionsUseCase(newMockDrawRepository(), &mockPredictionRepository{}, newMockStatsRepository())
+
+	_, err := uc.Execute(context.Background(), VerifyPredictionsRequest{GameType: valueobject.Mega645, DrawNumber: 7})
+	assert.Error(t, err)
+	_, err = uc.Execute(context.Background(), VerifyPredictionsRequest{GameType: valueobject.Mega645})
+	assert.Error(t, err, "no draws stored")
+}
+
+// copyingPredictionRepository hands out copies of stored ensembles like real
+// storage, so an outcome only sticks once saved, and fails with the set errors
+type copyingPredictionRepository struct {
+	*mockPredictionRepository
+	findErr error
+	saveErr error
+}
+
+func (r *copyingPredictionRepository) FindCurrentEnsemble(ctx context.Context, gameType valueobject.GameType, forDate time.Time) (*entity.EnsemblePrediction, error) {
+	if r.findErr != nil {
+		return nil, r.findErr
+	}
+	ensemble, err := r.mockPredictionRepository.FindCurrentEnsemble(ctx, gameType, forDate)
+	if err != nil {
+		return nil, err
+	}
+	copied := *ensemble
+	return &copied, nil
+}
+
+func (r *copyingPredictionRepository) SaveEnsemble(ctx context.Context, ensemble *entity.EnsemblePrediction) error {
+	if r.saveErr != nil {
+		return r.saveErr
+	}
+	return r.mockPredictionRepository.SaveEnsemble(ctx, ensemble)
+}
+
+func TestVerifyPredictionsUseCase_Execute_LookupFailure(t *testing.T) {
+	draws := createMockDraws(valueobject.Mega645, 3)
+	predictionRepo := &copyingPredictionRepository{
+		mockPredictionRepository: &mockPredictionRepository{},
+		findErr:                  errors.New("permission denied"),
+	}
+	uc := NewVerifyPredictionsUseCase(newMockDrawRepository(draws...), predictionRepo, newMockStatsRepository())
+
+	_, err := uc.Execute(context.Background(), VerifyPredictionsRequest{GameType: valueobject.Mega645})
+	assert.ErrorContains(t, err, "permission denied")
+}
+
+func TestVerifyPredictionsUseCase_Execute_RetryDoesNotCountTwice(t *testing.T) {
+	draws := createMockDraws(valueobject.Mega645, 3)
+	latest := draws[2]
+	predictionRepo := &copyingPredictionRepository{mockPredictionRepository: &mockPredictionRepository{}}
+	ensemble := newTargetedEnsemble(t, latest, []int{40, 41, 42, 43, 44, 45}, map[string][]int{
+		"frequency_analysis": {40, 41, 42, 43, 44, 45},
+	})
+	require.NoError(t, predictionRepo.SaveEnsemble(context.Background(), ensemble))
+	statsRepo := newMockStatsRepository()
+	uc := NewVerifyPredictionsUseCase(newMockDrawRepository(draws...), predictionRepo, statsRepo)
+
+	// The outcome fails to save after the stats were recorded
+	predictionRepo.saveErr = errors.New("disk full")
+	_, err := uc.Execute(context.Background(), VerifyPredictionsRequest{GameType: valueobject.Mega645})
+	require.ErrorContains(t, err, "disk full")
+
+	predictionRepo.saveErr = nil
+	verified, err := uc.Execute(context.Background(), VerifyPredictionsRequest{GameType: valueobject.Mega645})
+	require.NoError(t, err)
+	require.Len(t, verified, 1)
+	assert.False(t, verified[0].Skipped)
+
+	stats, err := statsRepo.FindByGameType(context.Background(), valueobject.Mega645)
+	require.NoError(t, err)
+	require.Len(t, stats, 1)
+	assert.Equal(t, 1, stats[0].TotalPredictions)
+	assert.Equal(t, ensemble.ID, stats[0].LastPredictionID)
+}
@@ -0,0 +1,215 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/repository"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"github.com/tool_predict/pkg/algorithm"
+	"go.uber.org/zap"
+)
+
+// VotingStrategies are the voting strategies ensemble backtests compare.
+// Per-number weighting and stacking are left out: they learn from backtests
+// themselves.
+var VotingStrategies = []algorithm.VotingStrategy{
+	algorithm.WeightedVoting,
+	algorithm.MajorityVoting,
+	algorithm.ConfidenceWeighted,
+}
+
+// EnsembleBacktestName is the algorithm name an ensemble backtest of strategy
+// is saved under, e.g. "ensemble_majority"
+func EnsembleBacktestName(strategy algorithm.VotingStrategy) string {
+	return "ensemble_" + string(strategy)
+}
+
+// VotingBacktestUseCase replays stored draws through the ensemble once per
+// voting strategy and saves each strategy's record as a backtest result
+type VotingBacktestUseCase struct {
+	drawRepo     repository.DrawRepository
+	backtestRepo repository.BacktestRepository
+	ensemble     *algorithm.Ensemble
+}
+
+// NewVotingBacktestUseCase creates a new voting strategy backtest use case
+func NewVotingBacktestUseCase(
+	drawRepo repository.DrawRepository,
+	backtestRepo repository.BacktestRepository,
+	ensemble *algorithm.Ensemble,
+) *VotingBacktestUseCase {
+	return &VotingBacktestUseCase{
+		drawRepo:     drawRepo,
+		backtestRepo: backtestRepo,
+		ensemble:     ensemble,
+	}
+}
+
+// VotingBacktestRequest contains the voting strategy backtest parameters
+type VotingBacktestRequest struct {
+	GameType valueobject.GameType
+	Draws    int // Number of latest stored draws to predict
+	MaxDraws int // Latest draws before each predicted draw the ensemble sees
+}
+
+// Execute predicts each of the latest req.Draws stored draws from the draws
+// before it, once with every strategy in VotingStrategies, and saves one
+// backtest result per strategy named by EnsembleBacktestName. The ensemble's
+// own strategy is restored afterwards.
+func (uc *VotingBacktestUseCase) Execute(ctx context.Context, req VotingBacktestRequest) ([]*entity.BacktestResult, error) {
+	if err := req.GameType.Validate(); err != nil {
+		return nil, err
+	}
+	if req.Draws <= 0 {
+		return nil, fmt.Errorf("draws to predict must be positive, got %d", req.Draws)
+	}
+	if req.MaxDraws <= 0 {
+		return nil, fmt.Errorf("max draws must be positive, got %d", req.MaxDraws)
+	}
+
+	count, err := uc.drawRepo.Count(ctx, req.GameType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count draws: %w", err)
+	}
+	var history []*entity.Draw
+	if count > 0 {
+		history, err = uc.drawRepo.FindLatest(ctx, req.GameType, int(count))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load draws: %w", err)
+		}
+	}
+	if len(history) == 0 {
+		return nil, fmt.Errorf("no stored %s draws to backtest", req.GameType)
+	}
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].DrawDate.Before(history[j].DrawDate)
+	})
+
+	start := max(len(history)-req.Draws, 0)
+	required, algoName := uc.ensemble.MinDrawsRequired()
+	if available := min(start, req.MaxDraws); available < required {
+		return nil, fmt.Errorf("insufficient historical data: need at least %d draws (%s) before the first predicted draw, have %d",
+			required, algoName, available)
+	}
+	testPeriod, err := valueobject.NewDateRange(history[start].DrawDate, history[len(history)-1].DrawDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid test period: %w", err)
+	}
+
+	logger.Info("Starting voting strategy backtest",
+		zap.String("game_type", string(req.GameType)),
+		zap.Int("draws", len(history)-start),
+		zap.Int("strategies", len(VotingStrategies)),
+	)
+
+	original := uc.ensemble.GetVotingStrategy()
+	defer uc.ensemble.SetVotingStrategy(original)
+
+	results := make([]*entity.BacktestResult, 0, len(VotingStrategies))
+	for _, strategy := range VotingStrategies {
+		uc.ensemble.SetVotingStrategy(strategy)
+		startTime := time.Now()
+
+		result, err := entity.NewBacktestResult(req.GameType, EnsembleBacktestName(strategy), testPeriod, len(history)-start)
+		if err != nil {
+			return nil, err
+		}
+		for i := start; i < len(history); i++ {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			default:
+			}
+
+			before := make([]*entity.Draw, i)
+			copy(before, history[:i])
+			before = sortAndLimitDraws(before, req.MaxDraws)
+			prediction, err := uc.ensemble.GeneratePredictions(ctx, req.GameType, before)
+			if err != nil {
+				return nil, fmt.Errorf("%s prediction for draw #%d failed: %w", strategy, history[i].DrawNumber, err)
+			}
+
+			draw := history[i]
+			result.AddMatchResult(entity.PredictionMatch{
+				PredictedNumbers: prediction.FinalNumbers,
+				ActualNumbers:    draw.Numbers,
+				MatchCount:       draw.Numbers.MatchCount(prediction.FinalNumbers),
+				Confidence:       prediction.Confidence,
+				PredictionDate:   before[0].DrawDate,
+				ActualDrawDate:   draw.DrawDate,
+				AverageRank:      entity.PoolRank(prediction.RankedPool, draw.Numbers),
+				Winnings:         draw.Payout(prediction.FinalNumbers),
+			})
+		}
+		result.CalculateMetrics()
+		result.ExecutionTime = time.Since(startTime)
+
+		if err := uc.backtestRepo.Save(ctx, result); err != nil {
+			return nil, fmt.Errorf("failed to save %s backtest: %w", strategy, err)
+		}
+		logger.Info("Voting strategy backtested",
+			zap.String("strategy", string(strategy)),
+			zap.Float64("average_matches", AverageMatches(result)),
+		)
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// StrategyChoice is the voting strategy that did best in its latest ensemble
+// backtest
+type StrategyChoice struct {
+	Strategy       algorithm.VotingStrategy
+	Backtest       *entity.BacktestResult
+	AverageMatches float64
+}
+
+// SelectVotingStrategy returns the strategy among VotingStrategies whose
+// latest ensemble backtest for gameType has the most average matches per
+// draw. Strategies without a backtest are skipped; an equal score keeps the
+// strategy listed first. It fails when no strategy has been backtested.
+func SelectVotingStrategy(
+	ctx context.Context,
+	backtests repository.BacktestRepository,
+	gameType valueobject.GameType,
+) (*StrategyChoice, error) {
+	var best *StrategyChoice
+	for _, strategy := range VotingStrategies {
+		results, err := backtests.FindByAlgorithm(ctx, EnsembleBacktestName(strategy), gameType, 1)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s backtest: %w", strategy, err)
+		}
+		if len(results) == 0 || len(results[0].DetailedResults) == 0 {
+			continue
+		}
+
+		avg := AverageMatches(results[0])
+		if best == nil || avg > best.AverageMatches {
+			best = &StrategyChoice{Strategy: strategy, Backtest: results[0], AverageMatches: avg}
+		}
+	}
+
+	if best == nil {
+		return nil, fmt.Errorf("no ensemble backtests for %s; run the backtester's voting command first", gameType)
+	}
+	return best, nil
+}
+
+// AverageMatches returns the mean numbers matched per draw in a backtest's
+// detailed results, 0 without any
+func AverageMatches(result *entity.BacktestResult) float64 {
+	if len(result.DetailedResults) == 0 {
+		return 0
+	}
+	total := 0
+	for _, match := range result.DetailedResults {
+		total += match.MatchCount
+	}
+	return float64(total) / float64(len(result.DetailedResults))
+}
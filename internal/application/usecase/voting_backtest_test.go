@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/pkg/algorithm"
+)
+
+// newStrategyBacktest builds an ensemble backtest of strategy ending on end
+// whose draws matched the given counts
+func newStrategyBacktest(t *testing.T, strategy algorithm.VotingStrategy, end time.Time, matches ...int) *entity.BacktestResult {
+	t.Helper()
+
+	result, err := entity.NewBacktestResult(valueobject.Mega645, EnsembleBacktestName(strategy),
+		valueobject.MustNewDateRange(end.AddDate(0, 0, -30), end), len(matches))
+	require.NoError(t, err)
+	for _, count := range matches {
+		result.AddMatchResult(entity.PredictionMatch{MatchCount: count})
+	}
+	return result
+}
+
+func TestSelectVotingStrategy_PicksBestLatestBacktest(t *testing.T) {
+	recent := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	backtests := &mockBacktestRepository{results: []*entity.BacktestResult{
+		newStrategyBacktest(t, algorithm.WeightedVoting, recent, 1, 0, 1, 2),
+		newStrategyBacktest(t, algorithm.MajorityVoting, recent, 2, 1, 3, 2),
+		newStrategyBacktest(t, algorithm.ConfidenceWeighted, recent, 0, 1, 1, 0),
+		// An older, better run of confidence weighting no longer counts
+		newStrategyBacktest(t, algorithm.ConfidenceWeighted, recent.AddDate(0, -2, 0), 4, 4, 4, 4),
+	}}
+
+	choice, err := SelectVotingStrategy(context.Background(), backtests, valueobject.Mega645)
+	require.NoError(t, err)
+
+	assert.Equal(t, algorithm.MajorityVoting, choice.Strategy)
+	assert.Equal(t, 2.0, choice.AverageMatches)
+	assert.Equal(t, backtests.results[1].ID, choice.Backtest.ID)
+}
+
+func TestSelectVotingStrategy_NoBacktests(t *testing.T) {
+	backtests := &mockBacktestRepository{results: []*entity.BacktestResult{
+		newStrategyBacktest(t, algorithm.MajorityVoting, time.Now(), 3),
+	}}
+
+	_, err := SelectVotingStrategy(context.Background(), backtests, valueobject.Power655)
+	assert.Error(t, err)
+}
+
+func TestVotingBacktestUseCase_Execute(t *testing.T) {
+	drawRepo := newSimulationDraws(t,
+		[]int{1, 2, 3, 20, 21, 22},
+		[]int{1, 2, 3, 4, 21, 22},
+		[]int{30, 31, 32, 33, 34, 35},
+	)
+	registry := algorithm.NewRegistry()
+	require.NoError(t, registry.Register(&fixedPickAlgorithm{numbers: []int{1, 2, 3, 4, 5, 6}}, 1.0))
+	ensemble := algorithm.NewEnsemble(registry, algorithm.WeightedVoting)
+	backtests := &mockBacktestRepository{}
+
+	results, err := NewVotingBacktestUseCase(drawRepo, backtests, ensemble).Execute(context.Background(), VotingBacktestRequest{
+		GameType: valueobject.Mega645,
+		Draws:    3,
+		MaxDraws: 10,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, results, len(VotingStrategies))
+	assert.Len(t, backtests.results, len(VotingStrategies))
+	for i, result := range results {
+		assert.Equal(t, EnsembleBacktestName(VotingStrategies[i]), result.AlgorithmName)
+		assert.Equal(t, 3, result.TotalPredictions)
+		assert.Equal(t, []int{3, 4, 0}, []int{
+			result.DetailedResults[0].MatchCount,
+			result.DetailedResults[1].MatchCount,
+			result.DetailedResults[2].MatchCount,
+		})
+		assert.InDelta(t, 7.0/3, AverageMatches(result), 1e-9)
+		// Pool 1-15: ranks average 54/6, 42/6 and 16 (all outside the pool)
+		assert.InDelta(t, 9.0, result.DetailedResults[0].AverageRank, 1e-9)
+		assert.InDelta(t, 7.0, result.DetailedResults[1].AverageRank, 1e-9)
+		assert.InDelta(t, 16.0, result.DetailedResults[2].AverageRank, 1e-9)
+		assert.InDelta(t, 32.0/3, result.AverageRank, 1e-9)
+	}
+	assert.Equal(t, algorithm.WeightedVoting, ensemble.GetVotingStrategy())
+}
+
+func TestVotingBacktestUseCase_Execute_Validation(t *testing.T) {
+	uc := NewVotingBacktestUseCase(newMockDrawRepository(), &mockBacktestRepository{},
+		algorithm.NewEnsemble(algorithm.NewRegistry(), algorithm.WeightedVoting))
+
+	_, err := uc.Execute(context.Background(), VotingBacktestRequest{GameType: valueobject.Mega645, Draws: 0, MaxDraws: 10})
+	assert.Error(t, err)
+	_, err = uc.Execute(context.Background(), VotingBacktestRequest{GameType: valueobject.Mega645, Draws: 5, MaxDraws: 10})
+	assert.Error(t, err)
+}
@@ -0,0 +1,288 @@
+package backtester
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tool_predict/internal/application/usecase"
+	"github.com/tool_predict/internal/cli/wiring"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/adapter/scraper"
+	"github.com/tool_predict/internal/infrastructure/adapter/storage"
+	"github.com/tool_predict/internal/infrastructure/config"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"github.com/tool_predict/pkg/algorithm"
+	"go.uber.org/zap"
+)
+
+// version is stamped on backtest results; binaries set it with SetVersion
+var version = "1.0.0"
+
+var (
+	cfgFile     string
+	gameType    string
+	testMode    string
+	testSize    int
+	algorithms  []string
+	outputFile  string
+	windowMode  string
+	windowSize  int
+	concurrency int
+)
+
+var rootCmd = &cobra.Command{
+	Use:   "backtester",
+	Short: "Vietlott lottery backtesting tool",
+	Long:  `A CLI tool that backtests prediction algorithms against historical data.`,
+	Run:   runBacktest,
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVarP(&cfgFile, "config", "c", "./configs/config.dev.yaml", "Config file path")
+	rootCmd.PersistentFlags().StringVarP(&gameType, "game-type", "g", "MEGA_6_45", "Game type (MEGA_6_45 or POWER_6_55, aliases like mega, 6/55 accepted)")
+	rootCmd.Flags().StringVarP(&testMode, "test-mode", "m", "draws", "Test mode (draws or days)")
+	rootCmd.Flags().IntVarP(&testSize, "test-size", "s", 30, "Test size (number of draws or days)")
+	rootCmd.Flags().StringSliceVarP(&algorithms, "algorithms", "a", []string{}, "Algorithms to test (default: all)")
+	rootCmd.Flags().StringVarP(&outputFile, "output", "o", "", "Output file path (JSON format)")
+	rootCmd.Flags().StringVar(&windowMode, "window", usecase.WindowExpanding, "Training window: expanding (every earlier draw) or sliding (the latest --window-size draws)")
+	rootCmd.Flags().IntVar(&windowSize, "window-size", 0, "Draws each prediction trains on; implies --window sliding")
+	rootCmd.Flags().IntVar(&concurrency, "concurrency", runtime.NumCPU(), "Predictions made at once; results don't depend on it")
+}
+
+// Command returns the backtester's root command, for mounting in another CLI
+func Command() *cobra.Command {
+	return rootCmd
+}
+
+// SetVersion sets the version stamped on backtest results
+func SetVersion(v string) {
+	version = v
+}
+
+// Execute runs the backtester CLI, exiting with status 1 when a command fails
+func Execute() {
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		logger.Exit(1)
+	}
+}
+
+func runBacktest(cmd *cobra.Command, args []string) {
+	// Load configuration
+	cfg, err := config.Load(cfgFile)
+	if err != nil {
+		fmt.Printf("Failed to load config: %v\n", err)
+		logger.Exit(1)
+	}
+
+	// Initialize logger
+	ctx, shutdown, err := logger.Setup(cfg.App.LogLevel, "stdout")
+	if err != nil {
+		fmt.Printf("Failed to initialize logger: %v\n", err)
+		logger.Exit(1)
+	}
+	defer shutdown()
+
+	useGamesFile(cfg)
+
+	configHash, err := cfg.Hash()
+	if err != nil {
+		logger.Warn("Failed to hash config", zap.Error(err))
+	}
+
+	logger.Info("Starting backtester application",
+		zap.String("version", version),
+		zap.String("config_hash", configHash),
+		zap.String("environment", cfg.App.Environment),
+	)
+
+	// Parse game type
+	gt, err := valueobject.ParseGameType(gameType)
+	if err != nil {
+		logger.Fatal("Invalid game type", zap.Error(err))
+	}
+
+	// Initialize algorithm registry and use case
+	registry := newRegistryFromConfig(cfg, gt)
+	backtestUseCase, statsStorage := newBacktestUseCase(cfg, registry, configHash)
+
+	// Create request
+	if windowSize != 0 && !cmd.Flags().Changed("window") {
+		windowMode = usecase.WindowSliding
+	}
+	req := usecase.BacktestRequest{
+		GameType:   gt,
+		TestMode:   testMode,
+		TestSize:   testSize,
+		Algorithms: algorithms,
+		WindowMode: windowMode,
+		WindowSize: windowSize,
+	}
+	if _, err := req.ValidateWindow(); err != nil {
+		logger.Fatal("Invalid backtest window", zap.Error(err))
+	}
+
+	// Execute backtest
+	fmt.Printf("\n🔬 Running backtest for %s (%s: %d)...\n\n", gt, testMode, testSize)
+
+	startTime := time.Now()
+	result, err := backtestUseCase.Execute(ctx, req)
+	if err != nil {
+		logger.Fatal("Backtest failed", zap.Error(err))
+	}
+
+	// Display results
+	displayBacktestResults(result)
+
+	duration := time.Since(startTime)
+	fmt.Printf("\n✅ Backtest completed in %v\n", duration)
+
+	// Save to file if requested
+	if outputFile != "" {
+		if err := saveResultsToFile(result, outputFile); err != nil {
+			logger.Warn("Failed to save results to file", zap.Error(err))
+		} else {
+			fmt.Printf("📁 Results saved to: %s\n", outputFile)
+		}
+	}
+
+	// Tune the weights from a backtest of every algorithm, as backtester tune
+	// does
+	if cfg.Backtest.EnableAutoWeightUpdate && len(algorithms) == 0 {
+		weights, err := usecase.NewAutoTuneUseCase(backtestUseCase, statsStorage, registry).
+			Apply(ctx, gt, result.Results, cfg.Ensemble.WeightTotal)
+		if err != nil {
+			logger.Warn("Failed to tune algorithm weights", zap.Error(err))
+			return
+		}
+		displayTunedWeights(weights)
+	}
+}
+
+// newBacktestUseCase sets up the storage and scraper a backtest of registry
+// needs, exiting on failure. The stats storage is returned for tuning weights.
+func newBacktestUseCase(cfg *config.Config, registry *algorithm.Registry, configHash string) (*usecase.BacktestUseCase, *storage.StatsJSONStorage) {
+	// Initialize storage
+	drawStorage, err := wiring.NewDrawRepository(cfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize draw storage", zap.Error(err))
+	}
+
+	backtestStorage, err := storage.NewBacktestJSONStorage(cfg.Storage.JSON.BasePath)
+	if err != nil {
+		logger.Fatal("Failed to initialize backtest storage", zap.Error(err))
+	}
+
+	statsStorage, err := storage.NewStatsJSONStorage(cfg.Storage.JSON.BasePath)
+	if err != nil {
+		logger.Fatal("Failed to initialize stats storage", zap.Error(err))
+	}
+
+	// Initialize scraper
+	apiScraper := scraper.NewVietlottAPIScraper(
+		cfg.Scraper.Vietlott.BaseURL,
+		cfg.Scraper.Vietlott.Timeout,
+		cfg.Scraper.Vietlott.RetryCount,
+		cfg.Scraper.Vietlott.RateLimit,
+	)
+	if err := apiScraper.SetPageSize(cfg.Scraper.Vietlott.PageSize); err != nil {
+		logger.Warn("Invalid scraper page size, using default", zap.Error(err))
+	}
+	vietlottScraper, err := scraper.WithMode(apiScraper, cfg.Scraper.Vietlott.Mode, cfg.Scraper.Vietlott.RecordingsDir)
+	if err != nil {
+		logger.Fatal("Failed to initialize scraper", zap.Error(err))
+	}
+
+	// Initialize use case
+	backtestUseCase := usecase.NewBacktestUseCase(
+		drawStorage,
+		backtestStorage, // backtestRepo
+		statsStorage,    // statsRepo
+		registry,
+		vietlottScraper,
+	)
+	backtestUseCase.SetVersionStamp(version, configHash)
+	if err := backtestUseCase.SetConcurrency(concurrency); err != nil {
+		logger.Fatal("Invalid concurrency", zap.Error(err))
+	}
+	return backtestUseCase, statsStorage
+}
+
+func displayBacktestResults(result *usecase.BacktestResult) {
+	fmt.Printf("📊 Backtest Results for %s\n", result.GameType)
+	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+	fmt.Printf("Test Period:     %s\n", result.TestPeriod)
+	fmt.Printf("Total Draws:     %d\n", result.TotalPredictions)
+	if result.WindowMode == usecase.WindowSliding {
+		fmt.Printf("Window:          sliding, %d draws\n", result.WindowSize)
+	} else {
+		fmt.Printf("Window:          expanding\n")
+	}
+	fmt.Printf("Test Duration:   %v\n", result.Duration)
+	if result.ToolVersion != "" {
+		fmt.Printf("Version:         %s (config %s)\n", result.ToolVersion, result.ConfigHash)
+	}
+	fmt.Printf("\n")
+
+	// Display per-algorithm results
+	for _, res := range result.Results {
+		fmt.Printf("🔬 %s\n", res.AlgorithmName)
+		fmt.Printf("   Exact Matches (6/6):     %d\n", res.ExactMatches)
+		fmt.Printf("   4-Number Matches (4/6):   %d\n", res.FourNumberMatches)
+		fmt.Printf("   3-Number Matches (3/6):   %d\n", res.ThreeNumberMatches)
+		fmt.Printf("   Average Confidence:       %.2f%%\n", res.AverageConfidence*100)
+		fmt.Printf("   Winnings / Cost:          %.0f / %.0f VND (ROI %+.1f%%)\n", res.TotalWinnings, res.TotalCost, res.ROI*100)
+
+		fmt.Printf("   Accuracy Rates:\n")
+		fmt.Printf("      6/6:  %s\n", formatAccuracy(res.ExactMatches, res.TotalPredictions))
+		fmt.Printf("      4/6:  %s\n", formatAccuracy(res.FourNumberMatches, res.TotalPredictions))
+		fmt.Printf("      3/6:  %s\n", formatAccuracy(res.ThreeNumberMatches, res.TotalPredictions))
+		fmt.Printf("\n")
+	}
+	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+}
+
+// formatAccuracy formats matches out of predictions as a percentage, or
+// "n/a" when an algorithm made no predictions
+func formatAccuracy(matches, predictions int) string {
+	if predictions == 0 {
+		return "n/a"
+	}
+	return fmt.Sprintf("%.2f%%", float64(matches)/float64(predictions)*100)
+}
+
+func saveResultsToFile(result *usecase.BacktestResult, filename string) error {
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filename, data, 0644)
+}
+
+// newRegistryFromConfig registers the algorithms enabled for gameType with
+// their configured weights and settings
+func newRegistryFromConfig(cfg *config.Config, gameType valueobject.GameType) *algorithm.Registry {
+	registry, err := wiring.NewRegistry(cfg, gameType)
+	if err != nil {
+		logger.Fatal("Failed to set up algorithms", zap.Error(err))
+	}
+	return registry
+}
+
+// useGamesFile makes game types follow the rules in the configured games
+// file, if any
+func useGamesFile(cfg *config.Config) {
+	if cfg.App.GamesFile == "" {
+		return
+	}
+	games, err := config.LoadGames(cfg.App.GamesFile)
+	if err != nil {
+		logger.Fatal("Failed to load game rules", zap.String("file", cfg.App.GamesFile), zap.Error(err))
+	}
+	valueobject.UseGameRegistry(games)
+}
@@ -0,0 +1,13 @@
+package backtester
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatAccuracy(t *testing.T) {
+	assert.Equal(t, "25.00%", formatAccuracy(1, 4))
+	assert.Equal(t, "0.00%", formatAccuracy(0, 4))
+	assert.Equal(t, "n/a", formatAccuracy(0, 0))
+}
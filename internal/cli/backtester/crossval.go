@@ -0,0 +1,111 @@
+package backtester
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/tool_predict/internal/application/usecase"
+	"github.com/tool_predict/internal/cli/wiring"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/config"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+var (
+	crossvalFolds      int
+	crossvalFoldSize   int
+	crossvalHistory    int
+	crossvalSeed       uint64
+	crossvalAlgorithms []string
+)
+
+var crossvalCmd = &cobra.Command{
+	Use:   "crossval",
+	Short: "Cross-validate algorithms on test periods sampled from the whole history",
+	Long: `Samples --folds disjoint blocks of --fold-size consecutive stored draws at
+random from the whole history and predicts every draw of each block from the
+draws before it, like a backtest.
+
+Each algorithm's mean numbers matched per draw is reported per fold, with the
+mean and standard deviation across folds. A backtest of only the latest draws
+can favour whichever algorithm suits recent draws; a large deviation shows an
+algorithm's record depends on the period tested. The same --seed samples the
+same folds.`,
+	Args: cobra.NoArgs,
+	Run:  runCrossval,
+}
+
+func init() {
+	crossvalCmd.Flags().IntVar(&crossvalFolds, "folds", 5, "Disjoint test periods to sample")
+	crossvalCmd.Flags().IntVar(&crossvalFoldSize, "fold-size", 20, "Consecutive draws predicted per fold")
+	crossvalCmd.Flags().IntVar(&crossvalHistory, "history", 100, "Latest draws before each predicted draw the algorithms see")
+	crossvalCmd.Flags().Uint64Var(&crossvalSeed, "seed", 1, "Seed of the fold sampling")
+	crossvalCmd.Flags().StringSliceVarP(&crossvalAlgorithms, "algorithms", "a", []string{}, "Algorithms to cross-validate (default: all)")
+	rootCmd.AddCommand(crossvalCmd)
+}
+
+func runCrossval(cmd *cobra.Command, args []string) {
+	cfg, err := config.Load(cfgFile)
+	if err != nil {
+		fmt.Printf("Failed to load config: %v\n", err)
+		logger.Exit(1)
+	}
+
+	ctx, shutdown, err := logger.Setup(cfg.App.LogLevel, "stdout")
+	if err != nil {
+		fmt.Printf("Failed to initialize logger: %v\n", err)
+		logger.Exit(1)
+	}
+	defer shutdown()
+
+	useGamesFile(cfg)
+
+	gt, err := valueobject.ParseGameType(gameType)
+	if err != nil {
+		logger.Fatal("Invalid game type", zap.Error(err))
+	}
+
+	drawStorage, err := wiring.NewDrawRepository(cfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize draw storage", zap.Error(err))
+	}
+
+	fmt.Printf("\n🎲 Cross-validating %s (%d folds of %d draws)...\n\n", gt, crossvalFolds, crossvalFoldSize)
+
+	result, err := usecase.NewCrossValidationUseCase(drawStorage, newRegistryFromConfig(cfg, gt)).Execute(ctx,
+		usecase.CrossValidationRequest{
+			GameType:   gt,
+			Folds:      crossvalFolds,
+			FoldSize:   crossvalFoldSize,
+			MaxDraws:   crossvalHistory,
+			Seed:       crossvalSeed,
+			Algorithms: crossvalAlgorithms,
+		})
+	if err != nil {
+		logger.Fatal("Cross-validation failed", zap.Error(err))
+	}
+
+	for i, fold := range result.Folds {
+		fmt.Printf("Fold %d: draws #%d-#%d (%s)\n", i+1, fold.FirstDraw, fold.LastDraw, fold.Period)
+	}
+	fmt.Printf("\n")
+
+	header := fmt.Sprintf("%-22s %7s %7s", "Algorithm", "Mean", "StdDev")
+	for i := range result.Folds {
+		header += fmt.Sprintf(" %7s", fmt.Sprintf("Fold %d", i+1))
+	}
+	fmt.Println(header)
+	for _, record := range result.Algorithms {
+		line := fmt.Sprintf("%-22s %7.2f %7.2f", record.AlgorithmName, record.Mean, record.StdDev)
+		for _, average := range record.FoldAverages {
+			line += fmt.Sprintf(" %7.2f", average)
+		}
+		if record.Failed > 0 {
+			line += fmt.Sprintf("  (%d draws failed)", record.Failed)
+		}
+		fmt.Println(line)
+	}
+
+	fmt.Printf("\n✅ Cross-validated %d algorithms\n", len(result.Algorithms))
+}
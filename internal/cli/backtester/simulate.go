@@ -0,0 +1,166 @@
+package backtester
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tool_predict/internal/application/usecase"
+	"github.com/tool_predict/internal/cli/wiring"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/config"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"github.com/tool_predict/pkg/algorithm"
+	"go.uber.org/zap"
+)
+
+var (
+	simulateBudget  float64
+	simulateDraws   int
+	simulateHistory int
+	simulateFormat  string
+	simulateOutput  string
+)
+
+var simulateCmd = &cobra.Command{
+	Use:   "simulate",
+	Short: "Simulate buying the ensemble's prediction every draw with a budget",
+	Long: `Replays the latest stored draws as if one line of the ensemble's prediction had
+been bought for each of them, predicting every draw from the draws before it.
+Each ticket costs 10,000 VND and winnings are added back to the balance, which
+keeps playing until the period ends or a ticket is no longer affordable.
+
+Prints the balance after every draw as CSV or JSON. Jackpots pay only when the
+stored draw has jackpot data and are shared with its recorded winners.`,
+	Args: cobra.NoArgs,
+	Run:  runSimulate,
+}
+
+func init() {
+	simulateCmd.Flags().Float64Var(&simulateBudget, "budget", 1000000, "Starting balance in VND")
+	simulateCmd.Flags().IntVarP(&simulateDraws, "test-size", "s", 30, "Number of latest stored draws to play")
+	simulateCmd.Flags().IntVar(&simulateHistory, "history", 100, "Latest draws before each played draw the ensemble sees")
+	simulateCmd.Flags().StringVarP(&simulateFormat, "format", "f", "csv", "Output format (csv or json)")
+	simulateCmd.Flags().StringVarP(&simulateOutput, "output", "o", "", "Output file path (default: stdout)")
+	rootCmd.AddCommand(simulateCmd)
+}
+
+func runSimulate(cmd *cobra.Command, args []string) {
+	cfg, err := config.Load(cfgFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load config: %v\n", err)
+		logger.Exit(1)
+	}
+
+	// Keep stdout clean for the trajectory
+	ctx, shutdown, err := logger.Setup(cfg.App.LogLevel, "stderr")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize logger: %v\n", err)
+		logger.Exit(1)
+	}
+	defer shutdown()
+
+	useGamesFile(cfg)
+
+	gt, err := valueobject.ParseGameType(gameType)
+	if err != nil {
+		logger.Fatal("Invalid game type", zap.Error(err))
+	}
+
+	write, err := simulationWriter(simulateFormat)
+	if err != nil {
+		logger.Fatal("Invalid output format", zap.Error(err))
+	}
+
+	drawStorage, err := wiring.NewDrawRepository(cfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize draw storage", zap.Error(err))
+	}
+
+	ensemble := wiring.NewEnsemble(cfg, newRegistryFromConfig(cfg, gt), algorithm.VotingStrategy(cfg.Ensemble.VotingStrategy))
+
+	result, err := usecase.NewSimulateUseCase(drawStorage, ensemble).Execute(ctx, usecase.SimulateRequest{
+		GameType: gt,
+		Budget:   simulateBudget,
+		Draws:    simulateDraws,
+		MaxDraws: simulateHistory,
+	})
+	if err != nil {
+		logger.Fatal("Simulation failed", zap.Error(err))
+	}
+
+	var out io.Writer = os.Stdout
+	if simulateOutput != "" {
+		file, err := os.Create(simulateOutput)
+		if err != nil {
+			logger.Fatal("Failed to create output file", zap.Error(err))
+		}
+		defer file.Close()
+		out = file
+	}
+
+	if err := write(out, result); err != nil {
+		logger.Fatal("Failed to write simulation", zap.Error(err))
+	}
+
+	fmt.Fprintf(os.Stderr, "💰 %s: %d draws played, balance %.0f → %.0f VND",
+		gt, result.DrawsPlayed, result.Budget, result.FinalBalance)
+	if result.Bankrupt {
+		fmt.Fprintf(os.Stderr, " (ran out of budget)")
+	}
+	fmt.Fprintln(os.Stderr)
+}
+
+// simulationWriter returns the writer for a --format value
+func simulationWriter(format string) (func(io.Writer, *usecase.SimulationResult) error, error) {
+	switch strings.ToLower(format) {
+	case "csv":
+		return writeSimulationCSV, nil
+	case "json":
+		return writeSimulationJSON, nil
+	default:
+		return nil, fmt.Errorf("unknown format %q (expected csv or json)", format)
+	}
+}
+
+// writeSimulationCSV writes one row per played draw
+func writeSimulationCSV(w io.Writer, result *usecase.SimulationResult) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"draw_number", "draw_date", "ticket", "matches", "tier", "cost", "winnings", "balance"}); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, step := range result.Steps {
+		ticket := make([]string, len(step.Ticket))
+		for i, num := range step.Ticket {
+			ticket[i] = fmt.Sprintf("%02d", num)
+		}
+		row := []string{
+			fmt.Sprintf("%d", step.DrawNumber),
+			step.DrawDate.Format("2006-01-02"),
+			strings.Join(ticket, " "),
+			fmt.Sprintf("%d", step.Matches),
+			string(step.Tier),
+			fmt.Sprintf("%.0f", step.Cost),
+			fmt.Sprintf("%.0f", step.Winnings),
+			fmt.Sprintf("%.0f", step.Balance),
+		}
+		if err := cw.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeSimulationJSON writes the whole result, summary and steps
+func writeSimulationJSON(w io.Writer, result *usecase.SimulationResult) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
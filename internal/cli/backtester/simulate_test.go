@@ -0,0 +1,40 @@
+package backtester
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/application/usecase"
+	"github.com/tool_predict/internal/domain/entity"
+)
+
+func TestWriteSimulationCSV(t *testing.T) {
+	result := &usecase.SimulationResult{
+		Steps: []usecase.SimulationStep{{
+			DrawNumber: 1201,
+			DrawDate:   time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC),
+			Ticket:     []int{3, 9, 17, 22, 30, 41},
+			Matches:    3,
+			Tier:       entity.PrizeThird,
+			Cost:       10000,
+			Winnings:   30000,
+			Balance:    1020000,
+		}},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, writeSimulationCSV(&out, result))
+	assert.Equal(t, "draw_number,draw_date,ticket,matches,tier,cost,winnings,balance\n"+
+		"1201,2026-01-15,03 09 17 22 30 41,3,third,10000,30000,1020000\n", out.String())
+}
+
+func TestSimulationWriter(t *testing.T) {
+	_, err := simulationWriter("JSON")
+	assert.NoError(t, err)
+
+	_, err = simulationWriter("xml")
+	assert.ErrorContains(t, err, "expected csv or json")
+}
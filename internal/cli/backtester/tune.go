@@ -0,0 +1,96 @@
+package backtester
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/spf13/cobra"
+	"github.com/tool_predict/internal/application/usecase"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/config"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+var (
+	tuneTestMode string
+	tuneTestSize int
+)
+
+var tuneCmd = &cobra.Command{
+	Use:   "tune",
+	Short: "Set algorithm weights from a backtest",
+	Long: `Backtests every enabled algorithm and gives each a weight proportional to its
+mean numbers matched per draw, the weights summing to ensemble.weight_total.
+The weights and the backtest's accuracies are saved to the algorithm stats,
+and predictions vote with them from then on while ensemble.use_tuned_weights
+is set.
+
+With backtest.enable_auto_weight_update, off by default, a plain backtest of
+all algorithms tunes the weights the same way.`,
+	Args: cobra.NoArgs,
+	Run:  runTune,
+}
+
+func init() {
+	tuneCmd.Flags().StringVarP(&tuneTestMode, "test-mode", "m", "draws", "Test mode (draws or days)")
+	tuneCmd.Flags().IntVarP(&tuneTestSize, "test-size", "s", 30, "Test size (number of draws or days)")
+	tuneCmd.Flags().IntVar(&concurrency, "concurrency", runtime.NumCPU(), "Predictions made at once; results don't depend on it")
+	rootCmd.AddCommand(tuneCmd)
+}
+
+func runTune(cmd *cobra.Command, args []string) {
+	cfg, err := config.Load(cfgFile)
+	if err != nil {
+		fmt.Printf("Failed to load config: %v\n", err)
+		logger.Exit(1)
+	}
+
+	ctx, shutdown, err := logger.Setup(cfg.App.LogLevel, "stdout")
+	if err != nil {
+		fmt.Printf("Failed to initialize logger: %v\n", err)
+		logger.Exit(1)
+	}
+	defer shutdown()
+
+	useGamesFile(cfg)
+
+	configHash, err := cfg.Hash()
+	if err != nil {
+		logger.Warn("Failed to hash config", zap.Error(err))
+	}
+
+	gt, err := valueobject.ParseGameType(gameType)
+	if err != nil {
+		logger.Fatal("Invalid game type", zap.Error(err))
+	}
+
+	registry := newRegistryFromConfig(cfg, gt)
+	backtestUseCase, statsStorage := newBacktestUseCase(cfg, registry, configHash)
+
+	fmt.Printf("\n⚖️  Tuning algorithm weights for %s (%s: %d)...\n\n", gt, tuneTestMode, tuneTestSize)
+
+	result, err := usecase.NewAutoTuneUseCase(backtestUseCase, statsStorage, registry).Execute(ctx,
+		usecase.AutoTuneRequest{
+			Backtest: usecase.BacktestRequest{
+				GameType: gt,
+				TestMode: tuneTestMode,
+				TestSize: tuneTestSize,
+			},
+			WeightTotal: cfg.Ensemble.WeightTotal,
+		})
+	if err != nil {
+		logger.Fatal("Weight tuning failed", zap.Error(err))
+	}
+
+	displayTunedWeights(result.Weights)
+	fmt.Printf("\n✅ Tuned %d algorithms over %s\n", len(result.Weights), result.Backtest.TestPeriod)
+}
+
+// displayTunedWeights prints each algorithm's accuracy and weight change
+func displayTunedWeights(weights []usecase.TunedWeight) {
+	fmt.Printf("\n%-22s %9s %8s %8s\n", "Algorithm", "Avg match", "Old", "New")
+	for _, w := range weights {
+		fmt.Printf("%-22s %9.2f %8.3f %8.3f\n", w.AlgorithmName, w.Accuracy, w.OldWeight, w.NewWeight)
+	}
+}
@@ -0,0 +1,103 @@
+package backtester
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/tool_predict/internal/application/usecase"
+	"github.com/tool_predict/internal/cli/wiring"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/adapter/storage"
+	"github.com/tool_predict/internal/infrastructure/config"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"github.com/tool_predict/pkg/algorithm"
+	"go.uber.org/zap"
+)
+
+var (
+	votingDraws   int
+	votingHistory int
+)
+
+var votingCmd = &cobra.Command{
+	Use:   "voting",
+	Short: "Backtest the ensemble with each voting strategy",
+	Long: `Replays the latest stored draws through the ensemble once per voting strategy
+(weighted, majority and confidence_weighted), predicting every draw from the
+draws before it, and saves each strategy's record as a backtest named
+ensemble_<strategy>.
+
+The predictor's --auto-strategy flag votes with whichever strategy matched the
+most numbers per draw in its latest run. Avg rank is the mean position of the
+winning numbers in the ensemble's top-15 ranking (16 when outside it): a
+continuous measure of how close misses were, lower is better.`,
+	Args: cobra.NoArgs,
+	Run:  runVoting,
+}
+
+func init() {
+	votingCmd.Flags().IntVarP(&votingDraws, "test-size", "s", 30, "Number of latest stored draws to predict")
+	votingCmd.Flags().IntVar(&votingHistory, "history", 100, "Latest draws before each predicted draw the ensemble sees")
+	rootCmd.AddCommand(votingCmd)
+}
+
+func runVoting(cmd *cobra.Command, args []string) {
+	cfg, err := config.Load(cfgFile)
+	if err != nil {
+		fmt.Printf("Failed to load config: %v\n", err)
+		logger.Exit(1)
+	}
+
+	ctx, shutdown, err := logger.Setup(cfg.App.LogLevel, "stdout")
+	if err != nil {
+		fmt.Printf("Failed to initialize logger: %v\n", err)
+		logger.Exit(1)
+	}
+	defer shutdown()
+
+	useGamesFile(cfg)
+
+	gt, err := valueobject.ParseGameType(gameType)
+	if err != nil {
+		logger.Fatal("Invalid game type", zap.Error(err))
+	}
+
+	drawStorage, err := wiring.NewDrawRepository(cfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize draw storage", zap.Error(err))
+	}
+
+	backtestStorage, err := storage.NewBacktestJSONStorage(cfg.Storage.JSON.BasePath)
+	if err != nil {
+		logger.Fatal("Failed to initialize backtest storage", zap.Error(err))
+	}
+
+	ensemble := wiring.NewEnsemble(cfg, newRegistryFromConfig(cfg, gt), algorithm.VotingStrategy(cfg.Ensemble.VotingStrategy))
+
+	fmt.Printf("\n🗳️  Backtesting voting strategies for %s (last %d draws)...\n\n", gt, votingDraws)
+
+	results, err := usecase.NewVotingBacktestUseCase(drawStorage, backtestStorage, ensemble).Execute(ctx,
+		usecase.VotingBacktestRequest{
+			GameType: gt,
+			Draws:    votingDraws,
+			MaxDraws: votingHistory,
+		})
+	if err != nil {
+		logger.Fatal("Voting strategy backtest failed", zap.Error(err))
+	}
+
+	fmt.Printf("%-22s %9s %9s %9s %5s %5s %5s\n", "Strategy", "Draws", "Avg hits", "Avg rank", "3/6", "4/6", "6/6")
+	for _, result := range results {
+		fmt.Printf("%-22s %9d %9.2f %9.2f %5d %5d %5d\n",
+			result.AlgorithmName,
+			result.TotalPredictions,
+			usecase.AverageMatches(result),
+			result.AverageRank,
+			result.ThreeNumberMatches,
+			result.FourNumberMatches,
+			result.ExactMatches,
+		)
+	}
+
+	fmt.Printf("\n✅ Saved %d ensemble backtests\n", len(results))
+}
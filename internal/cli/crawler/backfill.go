@@ -0,0 +1,142 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tool_predict/internal/application/usecase"
+	"github.com/tool_predict/internal/cli/wiring"
+	"github.com/tool_predict/internal/infrastructure/adapter/storage"
+	"github.com/tool_predict/internal/infrastructure/config"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+// backfillCheckpointFile is the checkpoint's file name under the storage
+// base path when --checkpoint isn't given
+const backfillCheckpointFile = "backfill_checkpoint.json"
+
+var (
+	backfillMaxPages   int
+	backfillDelay      time.Duration
+	backfillCheckpoint string
+	backfillRestart    bool
+)
+
+var backfillCmd = &cobra.Command{
+	Use:   "backfill",
+	Short: "Fetch a game's whole draw history, resumably",
+	Long: `Walks the results archive page by page from the newest draws back to the
+first, storing every draw, to build a complete history (over 1000 draws for
+Power 6/55). Without --game-type every game is backfilled. The backfill is
+finished at the archive's last page, or at a page holding no draw older than
+those already stored, so a source that keeps repeating pages can't loop it.
+
+Progress is checkpointed after each page, by default in
+backfill_checkpoint.json under the storage base path. An interrupted or failed
+backfill resumes at the first page it hadn't stored; a finished one does
+nothing until run with --restart. Pages are fetched --delay apart, on top of
+scraper.vietlott.rate_limit, to go easy on the website.
+
+Backfill always fetches live, whatever scraper.vietlott.mode says.`,
+	Args: cobra.NoArgs,
+	Run:  runBackfill,
+}
+
+func init() {
+	backfillCmd.Flags().IntVar(&backfillMaxPages, "max-pages", 0, "Most archive pages to fetch per game this run (0 for the whole archive)")
+	backfillCmd.Flags().DurationVar(&backfillDelay, "delay", 5*time.Second, "Pause between archive pages")
+	backfillCmd.Flags().StringVar(&backfillCheckpoint, "checkpoint", "", "Checkpoint file (default: backfill_checkpoint.json under the storage base path)")
+	backfillCmd.Flags().BoolVar(&backfillRestart, "restart", false, "Ignore the checkpoint and start again from the newest page")
+	rootCmd.AddCommand(backfillCmd)
+}
+
+func runBackfill(cmd *cobra.Command, args []string) {
+	cfg, err := config.Load(cfgFile)
+	if err != nil {
+		fmt.Printf("Failed to load config: %v\n", err)
+		logger.Exit(1)
+	}
+
+	ctx, shutdown, err := logger.Setup(cfg.App.LogLevel, "stdout")
+	if err != nil {
+		fmt.Printf("Failed to initialize logger: %v\n", err)
+		logger.Exit(1)
+	}
+	defer shutdown()
+
+	useGamesFile(cfg)
+
+	gameTypes, err := parseGameTypes(gameType)
+	if err != nil {
+		logger.Fatal("Invalid game type", zap.Error(err))
+	}
+
+	drawStorage, err := wiring.NewDrawRepository(cfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize draw storage", zap.Error(err))
+	}
+
+	_, apiScraper, err := wiring.NewScraper(cfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize scraper", zap.Error(err))
+	}
+
+	checkpointPath := backfillCheckpoint
+	if checkpointPath == "" {
+		checkpointPath = filepath.Join(cfg.Storage.JSON.BasePath, backfillCheckpointFile)
+	}
+	backfillUseCase := usecase.NewBackfillUseCase(drawStorage, apiScraper, storage.NewBackfillCheckpointFile(checkpointPath))
+
+	// Ctrl-C cancels ctx, stopping after the current page; the checkpoint
+	// keeps the progress
+	failed := false
+	for _, gt := range gameTypes {
+		result, err := backfillUseCase.Execute(ctx, usecase.BackfillRequest{
+			GameType: gt,
+			MaxPages: backfillMaxPages,
+			Delay:    backfillDelay,
+			Restart:  backfillRestart,
+		})
+		if result != nil {
+			printBackfillResult(os.Stdout, result)
+		}
+		if err != nil {
+			logger.Error("Backfill stopped", zap.String("game_type", string(gt)), zap.Error(err))
+			failed = true
+			if errors.Is(err, context.Canceled) {
+				break
+			}
+		}
+	}
+	if failed {
+		logger.Exit(1)
+	}
+}
+
+// printBackfillResult prints a summary of a game's backfill run
+func printBackfillResult(w io.Writer, result *usecase.BackfillResult) {
+	if result.Done && result.Pages == 0 {
+		fmt.Fprintf(w, "✅ %s: backfill already complete, %d draw(s) back to #%d (--restart to run again)\n",
+			result.GameType, result.TotalSaved, result.OldestDraw)
+		return
+	}
+
+	fmt.Fprintf(w, "✅ %s: fetched %d page(s) from page %d, saved %d draw(s)",
+		result.GameType, result.Pages, result.StartPage, result.Saved)
+	if result.OldestDraw > 0 {
+		fmt.Fprintf(w, ", back to #%d", result.OldestDraw)
+	}
+	fmt.Fprintln(w)
+	if result.Done {
+		fmt.Fprintf(w, "   reached the first draw; %d draw(s) stored in all\n", result.TotalSaved)
+	} else {
+		fmt.Fprintf(w, "   run again to resume at page %d\n", result.StartPage+result.Pages)
+	}
+}
@@ -0,0 +1,175 @@
+package crawler
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tool_predict/api/vietlott"
+	"github.com/tool_predict/internal/application/usecase"
+	"github.com/tool_predict/internal/cli/wiring"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/config"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+var (
+	cfgFile  string
+	gameType string
+	pages    int
+	since    string
+)
+
+var rootCmd = &cobra.Command{
+	Use:   "crawler",
+	Short: "Crawl Vietlott draws into storage",
+	Long: `Crawls draws from Vietlott through the configured scraper and saves them to
+the configured draw storage.
+
+Without --game-type every game is crawled. --pages sets how many result pages
+(scraper.vietlott.page_size draws each) to fetch; with --since every draw on or
+after the date is fetched instead, capped at --pages when it is set.
+Draws already stored are overwritten, so re-running a crawl is safe.`,
+	Args: cobra.NoArgs,
+	Run:  runCrawl,
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVarP(&cfgFile, "config", "c", "./configs/config.dev.yaml", "Config file path")
+	rootCmd.PersistentFlags().StringVarP(&gameType, "game-type", "g", "", "Game type to crawl (default: all; aliases like mega, 6/55 accepted)")
+	rootCmd.Flags().IntVar(&pages, "pages", 1, "Result pages to fetch per game (0 for no cap with --since)")
+	rootCmd.Flags().StringVar(&since, "since", "", "Only crawl draws on or after this date (YYYY-MM-DD)")
+}
+
+// Command returns the crawler's root command, for mounting in another CLI
+func Command() *cobra.Command {
+	return rootCmd
+}
+
+// Execute runs the crawler CLI, exiting with status 1 when a command fails
+func Execute() {
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		logger.Exit(1)
+	}
+}
+
+func runCrawl(cmd *cobra.Command, args []string) {
+	cfg, err := config.Load(cfgFile)
+	if err != nil {
+		fmt.Printf("Failed to load config: %v\n", err)
+		logger.Exit(1)
+	}
+
+	ctx, shutdown, err := logger.Setup(cfg.App.LogLevel, "stdout")
+	if err != nil {
+		fmt.Printf("Failed to initialize logger: %v\n", err)
+		logger.Exit(1)
+	}
+	defer shutdown()
+
+	useGamesFile(cfg)
+
+	gameTypes, err := parseGameTypes(gameType)
+	if err != nil {
+		logger.Fatal("Invalid game type", zap.Error(err))
+	}
+
+	req, err := buildRequest(pages, cfg.Scraper.Vietlott.PageSize, since)
+	if err != nil {
+		logger.Fatal("Invalid crawl bounds", zap.Error(err))
+	}
+
+	drawStorage, err := wiring.NewDrawRepository(cfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize draw storage", zap.Error(err))
+	}
+
+	vietlottScraper, apiScraper, err := wiring.NewScraper(cfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize scraper", zap.Error(err))
+	}
+
+	crawlUseCase := usecase.NewFetchHistoricalDataUseCase(drawStorage, vietlottScraper)
+	if err := crawlUseCase.SetSaveConcurrency(cfg.Storage.JSON.SaveConcurrency); err != nil {
+		logger.Warn("Invalid save concurrency, saving one draw at a time", zap.Error(err))
+	}
+	if cfg.Scraper.Vietlott.FetchDetails {
+		crawlUseCase.SetDetailFetcher(apiScraper)
+	}
+
+	failed := false
+	for _, gt := range gameTypes {
+		req.GameType = gt
+		result, err := crawlUseCase.Crawl(ctx, req)
+		if err != nil {
+			logger.Error("Failed to crawl draws", zap.String("game_type", string(gt)), zap.Error(err))
+			failed = true
+			continue
+		}
+		printCrawlResult(os.Stdout, result)
+	}
+	if failed {
+		logger.Exit(1)
+	}
+}
+
+// useGamesFile makes game types follow the rules in the configured games
+// file, if any
+func useGamesFile(cfg *config.Config) {
+	if cfg.App.GamesFile == "" {
+		return
+	}
+	games, err := config.LoadGames(cfg.App.GamesFile)
+	if err != nil {
+		logger.Fatal("Failed to load game rules", zap.String("file", cfg.App.GamesFile), zap.Error(err))
+	}
+	valueobject.UseGameRegistry(games)
+}
+
+// parseGameTypes resolves the --game-type flag, where empty means every game
+func parseGameTypes(value string) ([]valueobject.GameType, error) {
+	if value == "" {
+		return valueobject.AllGameTypes(), nil
+	}
+	gt, err := valueobject.ParseGameType(value)
+	if err != nil {
+		return nil, err
+	}
+	return []valueobject.GameType{gt}, nil
+}
+
+// buildRequest turns the --pages and --since flags into crawl bounds. A
+// page holds pageSize draws, or the API default when pageSize is unset.
+func buildRequest(pages, pageSize int, since string) (usecase.CrawlRequest, error) {
+	if pages < 0 {
+		return usecase.CrawlRequest{}, fmt.Errorf("pages must not be negative, got %d", pages)
+	}
+	if pageSize <= 0 {
+		pageSize = vietlott.DefaultPageSize
+	}
+
+	req := usecase.CrawlRequest{MaxDraws: pages * pageSize}
+	if since != "" {
+		date, err := time.Parse("2006-01-02", since)
+		if err != nil {
+			return usecase.CrawlRequest{}, fmt.Errorf("invalid since date %q, want YYYY-MM-DD: %w", since, err)
+		}
+		req.Since = &date
+	} else if pages == 0 {
+		return usecase.CrawlRequest{}, fmt.Errorf("pages must be positive without --since")
+	}
+	return req, nil
+}
+
+// printCrawlResult prints a one-line summary of a game's crawl
+func printCrawlResult(w io.Writer, result *usecase.CrawlResult) {
+	fmt.Fprintf(w, "✅ %s: fetched %d draw(s), saved %d, %d new", result.GameType, result.Fetched, result.Saved, result.New)
+	if result.Newest != nil {
+		fmt.Fprintf(w, " (latest #%d on %s)", result.Newest.DrawNumber, result.Newest.DrawDate.Format("2006-01-02"))
+	}
+	fmt.Fprintln(w)
+}
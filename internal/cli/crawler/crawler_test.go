@@ -0,0 +1,99 @@
+package crawler
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/api/vietlott"
+	"github.com/tool_predict/internal/application/usecase"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func TestBuildRequest(t *testing.T) {
+	req, err := buildRequest(3, 50, "")
+	require.NoError(t, err)
+	assert.Equal(t, 150, req.MaxDraws)
+	assert.Nil(t, req.Since)
+
+	// An unset page size falls back to the API default
+	req, err = buildRequest(2, 0, "")
+	require.NoError(t, err)
+	assert.Equal(t, 2*vietlott.DefaultPageSize, req.MaxDraws)
+
+	// --since without a page cap fetches every draw since the date
+	req, err = buildRequest(0, 100, "2025-06-01")
+	require.NoError(t, err)
+	assert.Equal(t, 0, req.MaxDraws)
+	require.NotNil(t, req.Since)
+	assert.Equal(t, time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), *req.Since)
+
+	_, err = buildRequest(0, 100, "")
+	assert.Error(t, err)
+	_, err = buildRequest(-1, 100, "")
+	assert.Error(t, err)
+	_, err = buildRequest(1, 100, "01/06/2025")
+	assert.Error(t, err)
+}
+
+func TestParseGameTypes(t *testing.T) {
+	all, err := parseGameTypes("")
+	require.NoError(t, err)
+	assert.Equal(t, valueobject.AllGameTypes(), all)
+
+	one, err := parseGameTypes("mega")
+	require.NoError(t, err)
+	assert.Equal(t, []valueobject.GameType{valueobject.Mega645}, one)
+
+	_, err = parseGameTypes("keno")
+	assert.Error(t, err)
+}
+
+func TestPrintSyncResult(t *testing.T) {
+	var out bytes.Buffer
+	printSyncResult(&out, &usecase.SyncResult{GameType: valueobject.Mega645, LocalLatest: 1200, RemoteLatest: 1200})
+	assert.Equal(t, "✅ MEGA_6_45: up to date at #1200\n", out.String())
+
+	out.Reset()
+	printSyncResult(&out, &usecase.SyncResult{
+		GameType:     valueobject.Mega645,
+		LocalLatest:  1200,
+		RemoteLatest: 1203,
+		Gaps:         []int{1100},
+		Missing:      4,
+		Saved:        []int{1201, 1203},
+		Failed:       []int{1202},
+		Skipped:      1,
+	})
+	assert.Contains(t, out.String(), "local #1200, website #1203, 1 gap(s); synced 2 of 4 missing draw(s)")
+	assert.Contains(t, out.String(), "failed: #1202\n")
+	assert.Contains(t, out.String(), "1 older missing draw(s) left for the next sync")
+}
+
+func TestPrintBackfillResult(t *testing.T) {
+	var out bytes.Buffer
+	printBackfillResult(&out, &usecase.BackfillResult{
+		GameType:   valueobject.Power655,
+		StartPage:  3,
+		Pages:      2,
+		Saved:      200,
+		TotalSaved: 400,
+		OldestDraw: 900,
+	})
+	assert.Contains(t, out.String(), "POWER_6_55: fetched 2 page(s) from page 3, saved 200 draw(s), back to #900")
+	assert.Contains(t, out.String(), "resume at page 5")
+
+	out.Reset()
+	printBackfillResult(&out, &usecase.BackfillResult{
+		GameType: valueobject.Power655, StartPage: 5, Pages: 1, Saved: 50, TotalSaved: 450, OldestDraw: 1, Done: true,
+	})
+	assert.Contains(t, out.String(), "reached the first draw; 450 draw(s) stored in all")
+
+	out.Reset()
+	printBackfillResult(&out, &usecase.BackfillResult{
+		GameType: valueobject.Power655, StartPage: 6, TotalSaved: 450, OldestDraw: 1, Done: true,
+	})
+	assert.Contains(t, out.String(), "backfill already complete, 450 draw(s) back to #1")
+}
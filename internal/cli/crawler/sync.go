@@ -0,0 +1,110 @@
+package crawler
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/tool_predict/internal/application/usecase"
+	"github.com/tool_predict/internal/cli/wiring"
+	"github.com/tool_predict/internal/infrastructure/config"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+var syncMaxDraws int
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Fetch only the draws storage is missing",
+	Long: `Compares the latest stored draw number with the website's and looks for gaps
+in the stored draw numbers, then fetches just the missing draws instead of
+re-crawling the latest pages.
+
+Storage must already hold some draws of each game; crawl an empty store first.
+--max-draws caps how many missing draws one run fetches, newest first.`,
+	Args: cobra.NoArgs,
+	Run:  runSync,
+}
+
+func init() {
+	syncCmd.Flags().IntVar(&syncMaxDraws, "max-draws", 0, "Most missing draws to fetch per game (0 for no cap)")
+	rootCmd.AddCommand(syncCmd)
+}
+
+func runSync(cmd *cobra.Command, args []string) {
+	cfg, err := config.Load(cfgFile)
+	if err != nil {
+		fmt.Printf("Failed to load config: %v\n", err)
+		logger.Exit(1)
+	}
+
+	ctx, shutdown, err := logger.Setup(cfg.App.LogLevel, "stdout")
+	if err != nil {
+		fmt.Printf("Failed to initialize logger: %v\n", err)
+		logger.Exit(1)
+	}
+	defer shutdown()
+
+	useGamesFile(cfg)
+
+	gameTypes, err := parseGameTypes(gameType)
+	if err != nil {
+		logger.Fatal("Invalid game type", zap.Error(err))
+	}
+
+	drawStorage, err := wiring.NewDrawRepository(cfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize draw storage", zap.Error(err))
+	}
+
+	vietlottScraper, _, err := wiring.NewScraper(cfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize scraper", zap.Error(err))
+	}
+
+	syncUseCase := usecase.NewSyncUseCase(drawStorage, vietlottScraper)
+	failed := false
+	for _, gt := range gameTypes {
+		result, err := syncUseCase.Execute(ctx, usecase.SyncRequest{GameType: gt, MaxDraws: syncMaxDraws})
+		if err != nil {
+			logger.Error("Failed to sync draws", zap.String("game_type", string(gt)), zap.Error(err))
+			failed = true
+			continue
+		}
+		printSyncResult(os.Stdout, result)
+		if len(result.Failed) > 0 {
+			failed = true
+		}
+	}
+	if failed {
+		logger.Exit(1)
+	}
+}
+
+// printSyncResult prints a summary of a game's sync
+func printSyncResult(w io.Writer, result *usecase.SyncResult) {
+	if result.Missing == 0 {
+		fmt.Fprintf(w, "✅ %s: up to date at #%d\n", result.GameType, result.LocalLatest)
+		return
+	}
+
+	fmt.Fprintf(w, "✅ %s: local #%d, website #%d, %d gap(s); synced %d of %d missing draw(s)\n",
+		result.GameType, result.LocalLatest, result.RemoteLatest, len(result.Gaps), len(result.Saved), result.Missing)
+	if len(result.Failed) > 0 {
+		fmt.Fprintf(w, "   ❌ failed:%s\n", formatDrawNumbers(result.Failed))
+	}
+	if result.Skipped > 0 {
+		fmt.Fprintf(w, "   %d older missing draw(s) left for the next sync\n", result.Skipped)
+	}
+}
+
+// formatDrawNumbers lists draw numbers as " #1 #2 ..."
+func formatDrawNumbers(numbers []int) string {
+	s := ""
+	for _, num := range numbers {
+		s += fmt.Sprintf(" #%d", num)
+	}
+	return s
+}
@@ -0,0 +1,101 @@
+package predictor
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tool_predict/internal/cli/wiring"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"github.com/tool_predict/pkg/algorithm"
+	"go.uber.org/zap"
+)
+
+var (
+	anomaliesRecent    int
+	anomaliesThreshold float64
+)
+
+var anomaliesCmd = &cobra.Command{
+	Use:   "anomalies",
+	Short: "Flag recent draws that are statistically unusual",
+	Long: `Checks the latest draws against the stored draws before them and flags the
+unusual ones: a sum, most numbers in one decade, longest consecutive run or odd
+count whose z-score reaches --threshold, or a combination shape (such as a run
+of 4 consecutive numbers) that came up in under 5% of the earlier draws.
+
+Unusual draws are expected now and then by chance; a cluster of them may point
+to bad data. Unless --draws is given, all stored draws are used.`,
+	Args: cobra.NoArgs,
+	Run:  runAnomalies,
+}
+
+func init() {
+	anomaliesCmd.Flags().IntVar(&anomaliesRecent, "recent", 10, "Number of latest draws to check")
+	anomaliesCmd.Flags().Float64Var(&anomaliesThreshold, "threshold", algorithm.DefaultAnomalyThreshold,
+		"Absolute z-score from which a feature is unusual")
+	rootCmd.AddCommand(anomaliesCmd)
+}
+
+func runAnomalies(cmd *cobra.Command, args []string) {
+	cfg, ctx, shutdown := loadConfigAndLogger(os.Stdout, "stdout")
+	defer shutdown()
+
+	gt, err := valueobject.ParseGameType(gameType)
+	if err != nil {
+		logger.Fatal("Invalid game type", zap.Error(err))
+	}
+
+	drawStorage, err := wiring.NewDrawRepository(cfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize draw storage", zap.Error(err))
+	}
+
+	limit := maxDraws
+	if !cmd.Flags().Changed("draws") {
+		count, err := drawStorage.Count(ctx, gt)
+		if err != nil {
+			logger.Fatal("Failed to count draws", zap.Error(err))
+		}
+		limit = int(count)
+	}
+
+	draws, err := drawStorage.FindLatest(ctx, gt, limit)
+	if err != nil {
+		logger.Fatal("Failed to load draws", zap.Error(err))
+	}
+
+	anomalies, err := algorithm.DetectAnomalies(draws, anomaliesRecent, anomaliesThreshold)
+	if err != nil {
+		logger.Fatal("Failed to detect anomalies", zap.Error(err))
+	}
+
+	printAnomalies(os.Stdout, anomalies, gt, min(anomaliesRecent, len(draws)))
+}
+
+// printAnomalies prints each flagged draw with the reasons it is unusual
+func printAnomalies(w io.Writer, anomalies []algorithm.DrawAnomaly, gameType valueobject.GameType, checked int) {
+	fmt.Fprintf(w, "\n🔎 Unusual Draws for %s (%d of the latest %d)\n", gameType, len(anomalies), checked)
+	fmt.Fprintf(w, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+	if len(anomalies) == 0 {
+		fmt.Fprintf(w, "  ✅ No unusual draws\n")
+	}
+	for _, anomaly := range anomalies {
+		fmt.Fprintf(w, "  #%d %s  %s\n",
+			anomaly.Draw.DrawNumber,
+			anomaly.Draw.DrawDate.Format("2006-01-02"),
+			strings.Join(formatTwoDigits(anomaly.Draw.Numbers), " - "),
+		)
+		for _, score := range anomaly.Features {
+			fmt.Fprintf(w, "      %-20s %5.0f  (mean %.1f, z %+.1f)  %s\n",
+				score.Feature.Name, score.Value, score.Mean, score.ZScore, score.Feature.Description)
+		}
+		for _, shape := range anomaly.Shapes {
+			fmt.Fprintf(w, "      %-20s %s\n", shape.Name, shape.Description)
+		}
+	}
+	fmt.Fprintf(w, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+}
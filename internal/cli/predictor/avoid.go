@@ -0,0 +1,90 @@
+package predictor
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/tool_predict/internal/cli/wiring"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"github.com/tool_predict/pkg/algorithm"
+	"go.uber.org/zap"
+)
+
+var avoidReportCmd = &cobra.Command{
+	Use:   "avoid-report",
+	Short: "List combination shapes likely to share a jackpot",
+	Long: `Lists combination shapes many players pick for non-random reasons, such as
+birthdays or evenly spaced numbers, with how often each came up in the stored draws.
+
+Every combination is equally likely to be drawn. This report is guidance about
+payouts: a jackpot on a popular shape is more likely to be split.
+
+Unless --draws is given, all stored draws are used.`,
+	Args: cobra.NoArgs,
+	Run:  runAvoidReport,
+}
+
+func init() {
+	rootCmd.AddCommand(avoidReportCmd)
+}
+
+func runAvoidReport(cmd *cobra.Command, args []string) {
+	cfg, ctx, shutdown := loadConfigAndLogger(os.Stdout, "stdout")
+	defer shutdown()
+
+	gt, err := valueobject.ParseGameType(gameType)
+	if err != nil {
+		logger.Fatal("Invalid game type", zap.Error(err))
+	}
+
+	drawStorage, err := wiring.NewDrawRepository(cfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize draw storage", zap.Error(err))
+	}
+
+	limit := maxDraws
+	if !cmd.Flags().Changed("draws") {
+		count, err := drawStorage.Count(ctx, gt)
+		if err != nil {
+			logger.Fatal("Failed to count draws", zap.Error(err))
+		}
+		limit = int(count)
+	}
+
+	draws, err := drawStorage.FindLatest(ctx, gt, limit)
+	if err != nil {
+		logger.Fatal("Failed to load draws", zap.Error(err))
+	}
+	if len(draws) == 0 {
+		logger.Fatal("No stored draws to analyze", zap.String("game_type", string(gt)))
+	}
+
+	printAvoidReport(os.Stdout, algorithm.AvoidReport(draws), gt)
+}
+
+// printAvoidReport prints one line per combination shape
+func printAvoidReport(w io.Writer, reports []algorithm.ShapeReport, gameType valueobject.GameType) {
+	draws := 0
+	if len(reports) > 0 {
+		draws = reports[0].Draws
+	}
+
+	fmt.Fprintf(w, "\n🚫 Combinations to Avoid for %s\n", gameType)
+	fmt.Fprintf(w, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+	for _, report := range reports {
+		fmt.Fprintf(w, "  [%-6s] %-20s %3d of %d draws (%5.1f%%)  %s\n",
+			report.Shape.Risk,
+			report.Shape.Name,
+			report.Occurrences,
+			draws,
+			report.Rate*100,
+			report.Shape.Description,
+		)
+	}
+	fmt.Fprintf(w, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+	fmt.Fprintf(w, "Every combination is equally likely to be drawn; popular shapes\n")
+	fmt.Fprintf(w, "only make a shared jackpot more likely. This is not a prediction.\n")
+}
@@ -0,0 +1,141 @@
+package predictor
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/tool_predict/internal/cli/wiring"
+	"github.com/tool_predict/internal/domain/repository"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"github.com/tool_predict/internal/infrastructure/sampledata"
+	"go.uber.org/zap"
+)
+
+var (
+	initSample       bool
+	initRemoveSample bool
+)
+
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Create the draw storage, optionally with sample draws",
+	Long: `Creates the draw storage configured by storage.type, e.g. the directories
+under storage.json.base_path.
+
+With --sample, also stores a bundled history of sample draws for every game so
+predict, freq and the backtester work straight away. Sample draws are not real
+results: fetch real draws before relying on any prediction. A game that
+already has stored draws is left untouched.
+
+Sample draws are marked "sample": true in their files. A fetched real draw
+replaces the sample with its draw number, and predictions ignore samples once
+a game has real draws. To delete the remaining samples, run
+init --remove-sample; real draws are left alone. Only JSON storage, the
+default, can remove sample draws, so --sample and --remove-sample refuse any
+other storage.type.`,
+	Args: cobra.NoArgs,
+	Run:  runInit,
+}
+
+func init() {
+	initCmd.Flags().BoolVar(&initSample, "sample", false, "Store bundled sample draws for every game without draws")
+	initCmd.Flags().BoolVar(&initRemoveSample, "remove-sample", false, "Delete the stored sample draws of every game, keeping real draws")
+	initCmd.MarkFlagsMutuallyExclusive("sample", "remove-sample")
+	rootCmd.AddCommand(initCmd)
+}
+
+// sampleDrawStore is a draw repository that can delete the sample draws it
+// stores, which only the JSON storage tracks
+type sampleDrawStore interface {
+	repository.DrawRepository
+	DeleteSampleDraws(ctx context.Context, gameType valueobject.GameType) (int, error)
+}
+
+func runInit(cmd *cobra.Command, args []string) {
+	cfg, ctx, shutdown := loadConfigAndLogger(os.Stdout, "stdout")
+	defer shutdown()
+
+	drawStorage, err := wiring.NewDrawRepository(cfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize draw storage", zap.Error(err))
+	}
+	fmt.Printf("📁 Storage ready: %s\n", wiring.DrawStorageLocation(cfg))
+	if !initSample && !initRemoveSample {
+		fmt.Println("Run fetch to download draws, or init --sample to try things out first")
+		return
+	}
+
+	samples, ok := drawStorage.(sampleDrawStore)
+	if !ok {
+		logger.Fatal("Sample draws need JSON storage, the only one that can remove them again",
+			zap.String("storage_type", cfg.Storage.Type))
+	}
+
+	if initRemoveSample {
+		removed, err := removeSampleDraws(ctx, samples)
+		if err != nil {
+			logger.Fatal("Failed to remove sample draws", zap.Error(err))
+		}
+		for _, gt := range valueobject.AllGameTypes() {
+			fmt.Printf("🗑️  Removed %d sample %s draws\n", removed[gt], gt)
+		}
+		return
+	}
+
+	seeded, err := seedSampleDraws(ctx, samples)
+	if err != nil {
+		logger.Fatal("Failed to store sample draws", zap.Error(err))
+	}
+	for _, gt := range valueobject.AllGameTypes() {
+		if n, ok := seeded[gt]; ok {
+			fmt.Printf("🎲 Stored %d sample %s draws\n", n, gt)
+		} else {
+			fmt.Printf("⏭️  %s already has draws, no samples added\n", gt)
+		}
+	}
+}
+
+// seedSampleDraws stores the bundled sample draws of every game type that has
+// no draws yet in drawStorage. It returns how many sample draws each seeded
+// game type got.
+func seedSampleDraws(ctx context.Context, drawStorage sampleDrawStore) (map[valueobject.GameType]int, error) {
+	seeded := make(map[valueobject.GameType]int)
+	for _, gt := range valueobject.AllGameTypes() {
+		count, err := drawStorage.Count(ctx, gt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count %s draws: %w", gt, err)
+		}
+		if count > 0 {
+			logger.Info("Draws already stored, skipping sample draws",
+				zap.String("game_type", string(gt)), zap.Int64("draws", count))
+			continue
+		}
+
+		draws, err := sampledata.Draws(gt)
+		if err != nil {
+			return nil, err
+		}
+		if err := drawStorage.SaveBatch(ctx, draws); err != nil {
+			return nil, fmt.Errorf("failed to store %s sample draws: %w", gt, err)
+		}
+		seeded[gt] = len(draws)
+	}
+	return seeded, nil
+}
+
+// removeSampleDraws deletes the sample draws in drawStorage for every game
+// type and returns how many each game type lost
+func removeSampleDraws(ctx context.Context, drawStorage sampleDrawStore) (map[valueobject.GameType]int, error) {
+	removed := make(map[valueobject.GameType]int)
+	for _, gt := range valueobject.AllGameTypes() {
+		n, err := drawStorage.DeleteSampleDraws(ctx, gt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to remove %s sample draws: %w", gt, err)
+		}
+		removed[gt] = n
+	}
+	return removed, nil
+}
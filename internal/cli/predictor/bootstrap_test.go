@@ -0,0 +1,88 @@
+package predictor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/cli/wiring"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/adapter/storage"
+	"github.com/tool_predict/internal/infrastructure/config"
+	"github.com/tool_predict/internal/infrastructure/sampledata"
+)
+
+func TestSeedSampleDraws(t *testing.T) {
+	ctx := context.Background()
+	cfg, err := config.Load("../../../configs/config.dev.yaml")
+	require.NoError(t, err)
+
+	drawStorage, err := storage.NewJSONStorage(t.TempDir())
+	require.NoError(t, err)
+	seeded, err := seedSampleDraws(ctx, drawStorage)
+	require.NoError(t, err)
+
+	for _, gt := range valueobject.AllGameTypes() {
+		samples, err := sampledata.Draws(gt)
+		require.NoError(t, err)
+		assert.Equal(t, len(samples), seeded[gt])
+
+		latest, err := drawStorage.FindLatest(ctx, gt, 5)
+		require.NoError(t, err)
+		require.Len(t, latest, 5)
+		for i, draw := range latest {
+			sample := samples[len(samples)-1-i]
+			assert.Equal(t, sample.DrawNumber, draw.DrawNumber)
+			assert.Equal(t, sample.Numbers, draw.Numbers)
+			assert.True(t, sample.DrawDate.Equal(draw.DrawDate))
+			assert.True(t, draw.Sample)
+		}
+
+		// Enough history for every configured algorithm to predict
+		required, name := newRegistryFromConfig(cfg, gt).MinDrawsRequired()
+		assert.GreaterOrEqual(t, len(samples), required, name)
+	}
+
+	// A second init leaves the stored draws alone
+	seeded, err = seedSampleDraws(ctx, drawStorage)
+	require.NoError(t, err)
+	assert.Empty(t, seeded)
+	count, err := drawStorage.Count(ctx, valueobject.Mega645)
+	require.NoError(t, err)
+	samples, err := sampledata.Draws(valueobject.Mega645)
+	require.NoError(t, err)
+	assert.EqualValues(t, len(samples), count)
+}
+
+func TestRemoveSampleDraws(t *testing.T) {
+	ctx := context.Background()
+	drawStorage, err := storage.NewJSONStorage(t.TempDir())
+	require.NoError(t, err)
+
+	seeded, err := seedSampleDraws(ctx, drawStorage)
+	require.NoError(t, err)
+
+	removed, err := removeSampleDraws(ctx, drawStorage)
+	require.NoError(t, err)
+	assert.Equal(t, seeded, removed)
+
+	for _, gt := range valueobject.AllGameTypes() {
+		count, err := drawStorage.Count(ctx, gt)
+		require.NoError(t, err)
+		assert.Zero(t, count, gt)
+	}
+}
+
+func TestSampleDrawStore_OnlyJSONStorage(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Storage.JSON.BasePath = t.TempDir()
+
+	for storageType, supported := range map[string]bool{"": true, "json": true, "jsonl": false} {
+		cfg.Storage.Type = storageType
+		drawStorage, err := wiring.NewDrawRepository(cfg)
+		require.NoError(t, err)
+		_, ok := drawStorage.(sampleDrawStore)
+		assert.Equal(t, supported, ok, storageType)
+	}
+}
@@ -0,0 +1,66 @@
+package predictor
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/tool_predict/internal/application/usecase"
+)
+
+// csvLogHeader is written once, when the CSV history file is empty
+var csvLogHeader = []string{"date", "game", "numbers", "confidence", "strategy"}
+
+// appendPredictionCSV appends one row describing result to the CSV file at
+// path, creating it with a header if needed. The file is locked while writing
+// so concurrent runs don't interleave rows or both write the header.
+func appendPredictionCSV(path string, result *usecase.EnsembleResult) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open CSV log: %w", err)
+	}
+	defer file.Close()
+
+	if err := lockFile(file); err != nil {
+		return fmt.Errorf("failed to lock CSV log: %w", err)
+	}
+	defer unlockFile(file)
+
+	// Check the size only once locked, so exactly one run writes the header
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat CSV log: %w", err)
+	}
+
+	w := csv.NewWriter(file)
+	if info.Size() == 0 {
+		if err := w.Write(csvLogHeader); err != nil {
+			return fmt.Errorf("failed to write CSV header: %w", err)
+		}
+	}
+
+	prediction := result.Prediction
+	numbers := make([]string, len(prediction.FinalNumbers))
+	for i, num := range prediction.FinalNumbers {
+		numbers[i] = fmt.Sprintf("%02d", num)
+	}
+
+	row := []string{
+		prediction.GeneratedAt.Format(time.RFC3339),
+		string(prediction.GameType),
+		strings.Join(numbers, " "),
+		fmt.Sprintf("%.2f", prediction.Confidence),
+		prediction.VotingStrategy,
+	}
+	if err := w.Write(row); err != nil {
+		return fmt.Errorf("failed to write CSV row: %w", err)
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV log: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+//go:build !unix
+
+package predictor
+
+import "os"
+
+// lockFile is a no-op on platforms without flock; appends are still
+// atomic per write but concurrent runs may both write the header
+func lockFile(f *os.File) error {
+	return nil
+}
+
+// unlockFile is a no-op on platforms without flock
+func unlockFile(f *os.File) error {
+	return nil
+}
@@ -0,0 +1,35 @@
+package predictor
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendPredictionCSV_WritesHeaderOnce(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.csv")
+	result := newTestResult(t)
+
+	require.NoError(t, appendPredictionCSV(path, result))
+	require.NoError(t, appendPredictionCSV(path, result))
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	rows, err := csv.NewReader(file).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+
+	assert.Equal(t, csvLogHeader, rows[0])
+	for _, row := range rows[1:] {
+		assert.Equal(t, "POWER_6_55", row[1])
+		assert.Equal(t, "01 05 09 23 41 55", row[2])
+		assert.Equal(t, "0.72", row[3])
+		assert.Equal(t, "weighted", row[4])
+	}
+}
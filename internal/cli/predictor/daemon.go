@@ -0,0 +1,205 @@
+package predictor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+	_ "time/tzdata" // The daemon's timezone must load on hosts without a zone database
+
+	"github.com/spf13/cobra"
+	"github.com/tool_predict/internal/application/usecase"
+	"github.com/tool_predict/internal/cli/wiring"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/adapter/notify"
+	"github.com/tool_predict/internal/infrastructure/adapter/storage"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"github.com/tool_predict/internal/infrastructure/scheduler"
+	"go.uber.org/zap"
+)
+
+// daemonPruneSchedule is when the daemon prunes old predictions, daily in
+// daemon.timezone, away from the evening draws
+const daemonPruneSchedule = "0 4 * * *"
+
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Fetch, verify and predict on each game's draw schedule",
+	Long: `Runs until interrupted, and on each game's cron schedule (daemon.mega_6_45 and
+daemon.power_6_55, read in daemon.timezone) fetches the latest draws, verifies
+the stored predictions for them and predicts the next draw.
+
+The defaults run at 18:30 ICT on draw days: Tuesday, Thursday and Saturday
+for Power 6/55 and Wednesday, Friday and Sunday for Mega 6/45. While the
+day's draw isn't published the fetch is retried every daemon.retry_interval,
+up to daemon.max_retries times, before predicting without it.
+
+After each run the game's backtests are checked, and an algorithm whose 3+
+number hit rate fell by notify.accuracy_drop_threshold since its previous
+backtest is reported to the configured notifiers.
+
+With storage.prediction_retention_days set, predictions older than that are
+moved to the trash daily at 04:00, as prune does.
+
+Every scheduled game runs unless --game-type is given.`,
+	Args: cobra.NoArgs,
+	Run:  runDaemon,
+}
+
+func init() {
+	rootCmd.AddCommand(daemonCmd)
+}
+
+func runDaemon(cmd *cobra.Command, args []string) {
+	cfg, ctx, shutdown := loadConfigAndLogger(os.Stdout, "stdout")
+	defer shutdown()
+
+	gameTypes := valueobject.AllGameTypes()
+	if cmd.Flags().Changed("game-type") {
+		gt, err := valueobject.ParseGameType(gameType)
+		if err != nil {
+			logger.Fatal("Invalid game type", zap.Error(err))
+		}
+		gameTypes = []valueobject.GameType{gt}
+	}
+
+	location, err := time.LoadLocation(cfg.Daemon.Timezone)
+	if err != nil {
+		logger.Fatal("Invalid daemon timezone", zap.String("timezone", cfg.Daemon.Timezone), zap.Error(err))
+	}
+
+	configHash, err := cfg.Hash()
+	if err != nil {
+		logger.Warn("Failed to hash config for provenance", zap.Error(err))
+	}
+
+	drawStorage, predictionStorage, predictUseCases := newServedPredictUseCases(ctx, cfg, configHash)
+
+	vietlottScraper, apiScraper, err := wiring.NewScraper(cfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize scraper", zap.Error(err))
+	}
+
+	statsStorage, err := storage.NewStatsJSONStorage(cfg.Storage.JSON.BasePath)
+	if err != nil {
+		logger.Fatal("Failed to initialize stats storage", zap.Error(err))
+	}
+
+	fetchUseCase := usecase.NewFetchHistoricalDataUseCase(drawStorage, vietlottScraper)
+	if err := fetchUseCase.SetSaveConcurrency(cfg.Storage.JSON.SaveConcurrency); err != nil {
+		logger.Warn("Invalid save concurrency, saving one draw at a time", zap.Error(err))
+	}
+	if cfg.Scraper.Vietlott.FetchDetails {
+		fetchUseCase.SetDetailFetcher(apiScraper)
+	}
+	if cfg.Notify.WebhookURL != "" {
+		fetchUseCase.SetDrawNotifier(notify.NewWebhookNotifier(cfg.Notify.WebhookURL, cfg.Notify.Timeout), predictionStorage)
+	}
+	notifier := wiring.NewPredictionNotifier(cfg)
+	verifyUseCase := usecase.NewVerifyPredictionsUseCase(drawStorage, predictionStorage, statsStorage)
+	verifyUseCase.SetNotifier(notifier)
+
+	var accuracyAlert *usecase.AccuracyAlertUseCase
+	if alertNotifier := wiring.NewNotifier(cfg); alertNotifier != nil && cfg.Notify.AccuracyDropThreshold > 0 {
+		backtestStorage, err := storage.NewBacktestJSONStorage(cfg.Storage.JSON.BasePath)
+		if err != nil {
+			logger.Fatal("Failed to initialize backtest storage", zap.Error(err))
+		}
+		accuracyAlert = usecase.NewAccuracyAlertUseCase(backtestStorage, alertNotifier)
+	}
+
+	daemon := scheduler.New(location)
+	for _, gt := range gameTypes {
+		spec := cfg.ScheduleFor(gt)
+		if spec == "" {
+			continue
+		}
+		predictUseCases[gt].SetNotifier(notifier)
+		cycle := usecase.NewDrawCycleUseCase(fetchUseCase, verifyUseCase, predictUseCases[gt])
+		err := daemon.Add(string(gt), spec, func(ctx context.Context) error {
+			result, err := cycle.Execute(ctx, usecase.DrawCycleRequest{
+				GameType:      gt,
+				DrawDate:      time.Now().In(location),
+				FetchLimit:    cfg.Daemon.FetchLimit,
+				MaxDraws:      maxDraws,
+				RetryInterval: cfg.Daemon.RetryInterval,
+				MaxRetries:    cfg.Daemon.MaxRetries,
+			})
+			if err != nil {
+				return err
+			}
+			printDrawCycleResult(os.Stdout, result)
+
+			if accuracyAlert != nil {
+				_, err := accuracyAlert.Execute(ctx, usecase.AccuracyAlertRequest{
+					GameType:  gt,
+					Threshold: cfg.Notify.AccuracyDropThreshold,
+				})
+				if err != nil {
+					logger.Warn("Failed to check backtest accuracy", zap.String("game_type", string(gt)), zap.Error(err))
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			logger.Fatal("Invalid daemon schedule", zap.String("game_type", string(gt)), zap.Error(err))
+		}
+	}
+
+	if len(daemon.Jobs()) == 0 {
+		logger.Fatal("No game scheduled; set daemon.mega_6_45 or daemon.power_6_55")
+	}
+
+	if retentionDays := cfg.Storage.PredictionRetentionDays; retentionDays > 0 {
+		// Prune the configured storage the predictions are written to
+		err := daemon.Add("prune", daemonPruneSchedule, func(ctx context.Context) error {
+			_, err := pruneOldPredictions(ctx, predictionStorage, retentionDays, time.Now())
+			return err
+		})
+		if err != nil {
+			logger.Fatal("Invalid prune schedule", zap.Error(err))
+		}
+	}
+
+	jobs := daemon.Jobs()
+
+	fmt.Printf("⏰ Daemon started in %s (Ctrl+C to stop)\n", location)
+	for _, job := range jobs {
+		fmt.Printf("  %-12s %-18s next run %s\n", job.Name, job.Schedule,
+			daemon.NextRun(job, time.Now()).Format("2006-01-02 15:04 MST"))
+	}
+
+	if err := daemon.Run(ctx); err != nil {
+		logger.Fatal("Daemon stopped", zap.Error(err))
+	}
+	logger.Info("Daemon stopped")
+}
+
+// printDrawCycleResult prints what a scheduled run fetched, verified and
+// predicted
+func printDrawCycleResult(w io.Writer, result *usecase.DrawCycleResult) {
+	fmt.Fprintf(w, "\n⏰ %s draw cycle\n", result.GameType)
+	switch {
+	case result.Latest == nil:
+		fmt.Fprintf(w, "  No draws fetched\n")
+	case result.Published:
+		fmt.Fprintf(w, "  Latest draw #%d (%s): %s\n",
+			result.Latest.DrawNumber, result.Latest.DrawDate.Format("2006-01-02"), result.Latest.Numbers)
+	default:
+		fmt.Fprintf(w, "  Today's draw not published after %d fetch(es); latest is #%d (%s)\n",
+			result.Attempts, result.Latest.DrawNumber, result.Latest.DrawDate.Format("2006-01-02"))
+	}
+
+	for _, v := range result.Verified {
+		if v.Prediction == nil || v.Skipped {
+			continue
+		}
+		fmt.Fprintf(w, "  Verified prediction for #%d: %d matched\n", v.Draw.DrawNumber, v.Prediction.Outcome.MatchCount)
+	}
+
+	if result.Prediction != nil {
+		prediction := result.Prediction.Prediction
+		fmt.Fprintf(w, "  Next draw %s: %s\n", prediction.ForDate.Format("2006-01-02"), prediction.FinalNumbers)
+	}
+}
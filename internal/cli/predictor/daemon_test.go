@@ -0,0 +1,40 @@
+package predictor
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/application/usecase"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func TestPrintDrawCycleResult(t *testing.T) {
+	drawDate := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+	draw, err := entity.NewDraw(valueobject.Mega645, 1201, valueobject.MustNewNumbers([]int{3, 9, 17, 22, 30, 41}), drawDate, 0, 0)
+	require.NoError(t, err)
+
+	verified := newHistoryEnsemble(t, drawDate, []int{3, 9, 12, 22, 31, 42}, map[string][]int{"frequency_analysis": {1, 2, 3, 4, 5, 6}})
+	require.NoError(t, verified.Verify(draw))
+	next := newHistoryEnsemble(t, time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC), []int{1, 2, 3, 4, 5, 6}, map[string][]int{"frequency_analysis": {1, 2, 3, 4, 5, 6}})
+
+	var out bytes.Buffer
+	printDrawCycleResult(&out, &usecase.DrawCycleResult{
+		GameType:   valueobject.Mega645,
+		Attempts:   1,
+		Latest:     draw,
+		Published:  true,
+		Verified:   []usecase.VerifiedPrediction{{Draw: draw, Prediction: verified}},
+		Prediction: &usecase.EnsembleResult{Prediction: next},
+	})
+	assert.Contains(t, out.String(), "Latest draw #1201 (2026-03-04): [03, 09, 17, 22, 30, 41]")
+	assert.Contains(t, out.String(), "Verified prediction for #1201: 3 matched")
+	assert.Contains(t, out.String(), "Next draw 2026-03-06: [01, 02, 03, 04, 05, 06]")
+
+	out.Reset()
+	printDrawCycleResult(&out, &usecase.DrawCycleResult{GameType: valueobject.Mega645, Attempts: 5, Latest: draw})
+	assert.Contains(t, out.String(), "Today's draw not published after 5 fetch(es); latest is #1201 (2026-03-04)")
+}
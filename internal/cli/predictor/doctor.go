@@ -0,0 +1,168 @@
+package predictor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/tool_predict/internal/cli/wiring"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/repository"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"github.com/tool_predict/pkg/analytics"
+	"go.uber.org/zap"
+)
+
+// doctorMaxListed caps how many missing draws are listed per check
+const doctorMaxListed = 10
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check stored draw history for gaps and unreadable files",
+	Long: `Checks the draws in the configured storage for missing draw numbers and
+scheduled draw dates with no stored draw, and, with JSON storage, for
+unreadable (corrupt or partially written) draw files. Checks every game type
+unless --game-type is given.
+
+Exits with status 1 when a problem is found.`,
+	Args: cobra.NoArgs,
+	Run:  runDoctor,
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
+
+func runDoctor(cmd *cobra.Command, args []string) {
+	cfg, ctx, shutdown := loadConfigAndLogger(os.Stdout, "stdout")
+	defer shutdown()
+
+	gameTypes := valueobject.AllGameTypes()
+	if cmd.Flags().Changed("game-type") {
+		gt, err := valueobject.ParseGameType(gameType)
+		if err != nil {
+			logger.Fatal("Invalid game type", zap.Error(err))
+		}
+		gameTypes = []valueobject.GameType{gt}
+	}
+
+	drawStorage, err := wiring.NewDrawRepository(cfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize draw storage", zap.Error(err))
+	}
+
+	healthy := true
+	for _, gt := range gameTypes {
+		draws, corrupt, err := loadDoctorDraws(ctx, drawStorage, gt)
+		if err != nil {
+			logger.Fatal("Failed to load draws", zap.String("game_type", string(gt)), zap.Error(err))
+		}
+
+		if !printDoctorReport(os.Stdout, gt, draws, corrupt) {
+			healthy = false
+		}
+	}
+
+	if !healthy {
+		logger.Exit(1)
+	}
+}
+
+// corruptFileCounter is a draw repository that counts the unreadable draw
+// files it skipped; only the JSON storage has draw files to count
+type corruptFileCounter interface {
+	SkippedCorruptFiles() int64
+}
+
+// loadDoctorDraws loads every stored draw of gameType and, when drawStorage
+// counts them, how many unreadable draw files were skipped on the way
+func loadDoctorDraws(
+	ctx context.Context,
+	drawStorage repository.DrawRepository,
+	gameType valueobject.GameType,
+) ([]*entity.Draw, int64, error) {
+	count, err := drawStorage.Count(ctx, gameType)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count draws: %w", err)
+	}
+
+	counter, countsCorrupt := drawStorage.(corruptFileCounter)
+	var skippedBefore int64
+	if countsCorrupt {
+		skippedBefore = counter.SkippedCorruptFiles()
+	}
+	draws, err := drawStorage.FindLatest(ctx, gameType, int(count))
+	if err != nil {
+		return nil, 0, err
+	}
+	if !countsCorrupt {
+		return draws, 0, nil
+	}
+	return draws, counter.SkippedCorruptFiles() - skippedBefore, nil
+}
+
+// printDoctorReport prints the checks for one game type and reports whether
+// they all passed. corrupt is the number of draw files that failed to load.
+func printDoctorReport(w io.Writer, gameType valueobject.GameType, draws []*entity.Draw, corrupt int64) bool {
+	fmt.Fprintf(w, "\n🩺 %s\n", gameType)
+
+	if corrupt > 0 {
+		fmt.Fprintf(w, "  ❌ %d unreadable draw file(s) skipped; see the warnings above for names\n", corrupt)
+	}
+
+	if len(draws) == 0 {
+		fmt.Fprintf(w, "  ⚠️  No stored draws\n")
+		return false
+	}
+
+	first, last := draws[0], draws[0]
+	for _, draw := range draws {
+		if draw.DrawDate.Before(first.DrawDate) {
+			first = draw
+		}
+		if draw.DrawDate.After(last.DrawDate) {
+			last = draw
+		}
+	}
+	fmt.Fprintf(w, "  %d draws from %s to %s\n",
+		len(draws), first.DrawDate.Format("2006-01-02"), last.DrawDate.Format("2006-01-02"))
+
+	healthy := corrupt == 0
+
+	numberGaps := analytics.FindDrawNumberGaps(draws, gameType)
+	if len(numberGaps) == 0 {
+		fmt.Fprintf(w, "  ✅ No missing draw numbers\n")
+	} else {
+		healthy = false
+		fmt.Fprintf(w, "  ❌ %d missing draw number(s):", len(numberGaps))
+		for i, num := range numberGaps {
+			if i == doctorMaxListed {
+				fmt.Fprintf(w, " ...")
+				break
+			}
+			fmt.Fprintf(w, " #%d", num)
+		}
+		fmt.Fprintf(w, "\n")
+	}
+
+	dateGaps := analytics.FindDateGaps(draws, gameType)
+	if len(dateGaps) == 0 {
+		fmt.Fprintf(w, "  ✅ No missing scheduled draw dates\n")
+	} else {
+		healthy = false
+		fmt.Fprintf(w, "  ❌ %d scheduled draw date(s) with no draw (holidays may explain some):", len(dateGaps))
+		for i, date := range dateGaps {
+			if i == doctorMaxListed {
+				fmt.Fprintf(w, " ...")
+				break
+			}
+			fmt.Fprintf(w, " %s", date.Format("2006-01-02 Mon"))
+		}
+		fmt.Fprintf(w, "\n")
+	}
+
+	return healthy
+}
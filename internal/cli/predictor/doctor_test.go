@@ -0,0 +1,85 @@
+package predictor
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/adapter/storage"
+)
+
+func TestPrintDoctorReport_MissingScheduledDate(t *testing.T) {
+	draws := make([]*entity.Draw, 0, 3)
+	// Wed 7, Fri 9 and Wed 14 Jan 2026; Sunday the 11th is missing
+	for i, day := range []int{7, 9, 14} {
+		draw, err := entity.NewDraw(valueobject.Mega645, 100+i, valueobject.MustNewNumbers([]int{1, 2, 3, 4, 5, 6}),
+			time.Date(2026, 1, day, 0, 0, 0, 0, time.UTC), 0, 0)
+		require.NoError(t, err)
+		draws = append(draws, draw)
+	}
+
+	var out bytes.Buffer
+	healthy := printDoctorReport(&out, valueobject.Mega645, draws, 0)
+
+	assert.False(t, healthy)
+	assert.Contains(t, out.String(), "No missing draw numbers")
+	assert.Contains(t, out.String(), "2026-01-11 Sun")
+	assert.NotContains(t, out.String(), "unreadable")
+}
+
+func TestPrintDoctorReport_CorruptFiles(t *testing.T) {
+	draws := make([]*entity.Draw, 0, 3)
+	// Wed 7, Fri 9 and Sun 11 Jan 2026: no gaps
+	for i, day := range []int{7, 9, 11} {
+		draw, err := entity.NewDraw(valueobject.Mega645, 100+i, valueobject.MustNewNumbers([]int{1, 2, 3, 4, 5, 6}),
+			time.Date(2026, 1, day, 0, 0, 0, 0, time.UTC), 0, 0)
+		require.NoError(t, err)
+		draws = append(draws, draw)
+	}
+
+	var out bytes.Buffer
+	assert.True(t, printDoctorReport(&out, valueobject.Mega645, draws, 0))
+
+	out.Reset()
+	assert.False(t, printDoctorReport(&out, valueobject.Mega645, draws, 2))
+	assert.Contains(t, out.String(), "2 unreadable draw file(s)")
+}
+
+func TestLoadDoctorDraws(t *testing.T) {
+	ctx := context.Background()
+	draw, err := entity.NewDraw(valueobject.Mega645, 100, valueobject.MustNewNumbers([]int{1, 2, 3, 4, 5, 6}),
+		time.Date(2026, 1, 7, 0, 0, 0, 0, time.UTC), 0, 0)
+	require.NoError(t, err)
+
+	t.Run("json counts unreadable files", func(t *testing.T) {
+		basePath := t.TempDir()
+		drawStorage, err := storage.NewJSONStorage(basePath)
+		require.NoError(t, err)
+		require.NoError(t, drawStorage.Save(ctx, draw))
+		corruptPath := filepath.Join(basePath, "draws", "mega_6_45", "corrupt.json")
+		require.NoError(t, os.WriteFile(corruptPath, []byte("{"), 0o644))
+
+		draws, corrupt, err := loadDoctorDraws(ctx, drawStorage, valueobject.Mega645)
+		require.NoError(t, err)
+		assert.Len(t, draws, 1)
+		assert.EqualValues(t, 1, corrupt)
+	})
+
+	t.Run("jsonl has no files to count", func(t *testing.T) {
+		drawStorage, err := storage.NewJSONLDrawStorage(t.TempDir())
+		require.NoError(t, err)
+		require.NoError(t, drawStorage.Save(ctx, draw))
+
+		draws, corrupt, err := loadDoctorDraws(ctx, drawStorage, valueobject.Mega645)
+		require.NoError(t, err)
+		assert.Len(t, draws, 1)
+		assert.Zero(t, corrupt)
+	})
+}
@@ -0,0 +1,177 @@
+package predictor
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/tool_predict/internal/cli/wiring"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/adapter/storage"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+var (
+	datasetFormat string
+	exportFile    string
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export stored draws as CSV, JSON lines or Parquet",
+	Long: `Writes the stored draws, oldest first, as CSV (one row per draw with the
+numbers space separated), JSON lines (one stored draw per line) or Parquet
+(the CSV columns, typed, with draw_date a timestamp), for pandas, Excel and
+other tools. Exports every game type unless --game-type is given.
+
+Writes to stdout unless --file is given; the format defaults to the file's
+extension, or CSV.`,
+	Args: cobra.NoArgs,
+	Run:  runExport,
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import FILE",
+	Short: "Import draws from a CSV, JSON lines or Parquet file",
+	Long: `Loads draws from a file in the export format into storage. CSV and Parquet
+need the game_type, draw_number, draw_date and numbers columns; jackpot and
+winners are optional. Dates may be YYYY-MM-DD. Parquet files may be written
+by pandas (flat columns, uncompressed or Snappy). A stored draw with the same
+number is replaced, recording a correction if its numbers differ.
+
+Every draw is validated first; an invalid one aborts the import before
+anything is saved. The format defaults to the file's extension.`,
+	Args: cobra.ExactArgs(1),
+	Run:  runImport,
+}
+
+func init() {
+	exportCmd.Flags().StringVar(&datasetFormat, "format", "", "File format: csv, jsonl or parquet (default from --file's extension, else csv)")
+	exportCmd.Flags().StringVar(&exportFile, "file", "", "Write to this file instead of stdout")
+	importCmd.Flags().StringVar(&datasetFormat, "format", "", "File format: csv, jsonl or parquet (default from the file's extension)")
+	rootCmd.AddCommand(exportCmd, importCmd)
+}
+
+func runExport(cmd *cobra.Command, args []string) {
+	// Logs go to stderr so an export to stdout stays parseable
+	cfg, ctx, shutdown := loadConfigAndLogger(os.Stderr, "stderr")
+	defer shutdown()
+
+	format, err := resolveDrawFormat(datasetFormat, exportFile)
+	if err != nil {
+		logger.Fatal("Invalid export format", zap.Error(err))
+	}
+
+	gameTypes := valueobject.AllGameTypes()
+	if cmd.Flags().Changed("game-type") {
+		gt, err := valueobject.ParseGameType(gameType)
+		if err != nil {
+			logger.Fatal("Invalid game type", zap.Error(err))
+		}
+		gameTypes = []valueobject.GameType{gt}
+	}
+
+	drawStorage, err := wiring.NewDrawRepository(cfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize draw storage", zap.Error(err))
+	}
+
+	var out io.Writer = os.Stdout
+	if exportFile != "" {
+		file, err := os.Create(exportFile)
+		if err != nil {
+			logger.Fatal("Failed to create export file", zap.Error(err))
+		}
+		defer file.Close()
+		out = file
+	}
+
+	writer, err := storage.NewDrawWriter(out, format)
+	if err != nil {
+		logger.Fatal("Failed to export draws", zap.Error(err))
+	}
+
+	// Draws are streamed, oldest first, so large histories aren't held in
+	// memory
+	exported := 0
+	for _, gt := range gameTypes {
+		err := drawStorage.ForEachDraw(ctx, gt, func(draw *entity.Draw) error {
+			exported++
+			return writer.Write(draw)
+		})
+		if err != nil {
+			logger.Fatal("Failed to export draws", zap.String("game_type", string(gt)), zap.Error(err))
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		logger.Fatal("Failed to export draws", zap.Error(err))
+	}
+	logger.Info("Exported draws", zap.Int("count", exported), zap.String("format", string(format)))
+}
+
+func runImport(cmd *cobra.Command, args []string) {
+	cfg, ctx, shutdown := loadConfigAndLogger(os.Stdout, "stdout")
+	defer shutdown()
+
+	path := args[0]
+	format, err := resolveDrawFormat(datasetFormat, path)
+	if err != nil {
+		logger.Fatal("Invalid import format", zap.Error(err))
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		logger.Fatal("Failed to open import file", zap.Error(err))
+	}
+	defer file.Close()
+
+	draws, err := storage.ReadDraws(file, format)
+	if err != nil {
+		logger.Fatal("Failed to read draws", zap.String("file", path), zap.Error(err))
+	}
+
+	drawStorage, err := wiring.NewDrawRepository(cfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize draw storage", zap.Error(err))
+	}
+
+	saveErr := drawStorage.SaveBatch(ctx, draws)
+	printImportSummary(os.Stdout, path, draws, saveErr)
+	if saveErr != nil {
+		logger.Exit(1)
+	}
+}
+
+// resolveDrawFormat returns the --format flag's format, else the one named
+// by path's extension, else CSV when there's no path
+func resolveDrawFormat(flag, path string) (storage.DrawFormat, error) {
+	if flag != "" {
+		return storage.ParseDrawFormat(flag)
+	}
+	if path == "" {
+		return storage.DrawFormatCSV, nil
+	}
+	return storage.DrawFormatFromPath(path)
+}
+
+// printImportSummary prints how many draws per game type a file held and
+// whether saving them failed
+func printImportSummary(w io.Writer, path string, draws []*entity.Draw, saveErr error) {
+	counts := make(map[valueobject.GameType]int)
+	for _, draw := range draws {
+		counts[draw.GameType]++
+	}
+
+	fmt.Fprintf(w, "\n📥 Imported %d draw(s) from %s\n", len(draws), path)
+	for _, gt := range valueobject.AllGameTypes() {
+		if counts[gt] > 0 {
+			fmt.Fprintf(w, "  %s: %d\n", gt, counts[gt])
+		}
+	}
+	if saveErr != nil {
+		fmt.Fprintf(w, "  ❌ Some draws weren't saved: %v\n", saveErr)
+	}
+}
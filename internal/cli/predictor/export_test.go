@@ -0,0 +1,55 @@
+package predictor
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/adapter/storage"
+)
+
+func TestResolveDrawFormat(t *testing.T) {
+	format, err := resolveDrawFormat("", "")
+	require.NoError(t, err)
+	assert.Equal(t, storage.DrawFormatCSV, format)
+
+	format, err = resolveDrawFormat("", "draws.jsonl")
+	require.NoError(t, err)
+	assert.Equal(t, storage.DrawFormatJSONL, format)
+
+	format, err = resolveDrawFormat("csv", "draws.txt")
+	require.NoError(t, err)
+	assert.Equal(t, storage.DrawFormatCSV, format)
+
+	format, err = resolveDrawFormat("", "draws.parquet")
+	require.NoError(t, err)
+	assert.Equal(t, storage.DrawFormatParquet, format)
+
+	_, err = resolveDrawFormat("", "draws.xlsx")
+	assert.Error(t, err)
+}
+
+func TestPrintImportSummary(t *testing.T) {
+	var draws []*entity.Draw
+	for i, gt := range []valueobject.GameType{valueobject.Mega645, valueobject.Mega645, valueobject.Power655} {
+		draw, err := entity.NewDraw(gt, 100+i, valueobject.Numbers{1, 2, 3, 4, 5, 6}, time.Now(), 0, 0)
+		require.NoError(t, err)
+		draws = append(draws, draw)
+	}
+
+	var out bytes.Buffer
+	printImportSummary(&out, "draws.csv", draws, nil)
+	assert.Contains(t, out.String(), "Imported 3 draw(s) from draws.csv")
+	assert.Contains(t, out.String(), "MEGA_6_45: 2")
+	assert.Contains(t, out.String(), "POWER_6_55: 1")
+	assert.NotContains(t, out.String(), "❌")
+
+	out.Reset()
+	printImportSummary(&out, "draws.csv", draws, errors.New("disk full"))
+	assert.Contains(t, out.String(), "Some draws weren't saved: disk full")
+}
@@ -0,0 +1,131 @@
+package predictor
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tool_predict/internal/application/usecase"
+	"github.com/tool_predict/internal/cli/wiring"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/adapter/notify"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+var (
+	fetchLimit      int
+	fetchVerifyOnly bool
+)
+
+var fetchCmd = &cobra.Command{
+	Use:   "fetch",
+	Short: "Fetch the latest draws into storage",
+	Long: `Scrapes the latest draws and saves them to storage.
+
+With --verify-only nothing is written; the scraped draws are compared with the
+stored ones and any that are missing locally or whose numbers or date differ
+are reported. Exits with status 1 when a mismatch is found.
+
+When notify.webhook_url is set, a newly stored draw is posted to the webhook
+together with how the stored prediction for its date did.`,
+	Args: cobra.NoArgs,
+	Run:  runFetch,
+}
+
+func init() {
+	fetchCmd.Flags().IntVar(&fetchLimit, "limit", 30, "Number of latest draws to fetch")
+	fetchCmd.Flags().BoolVar(&fetchVerifyOnly, "verify-only", false, "Compare scraped draws with storage without writing")
+
+	rootCmd.AddCommand(fetchCmd)
+}
+
+func runFetch(cmd *cobra.Command, args []string) {
+	cfg, ctx, shutdown := loadConfigAndLogger(os.Stdout, "stdout")
+	defer shutdown()
+
+	gt, err := valueobject.ParseGameType(gameType)
+	if err != nil {
+		logger.Fatal("Invalid game type", zap.Error(err))
+	}
+
+	drawStorage, err := wiring.NewDrawRepository(cfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize draw storage", zap.Error(err))
+	}
+
+	vietlottScraper, apiScraper, err := wiring.NewScraper(cfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize scraper", zap.Error(err))
+	}
+
+	fetchUseCase := usecase.NewFetchHistoricalDataUseCase(drawStorage, vietlottScraper)
+	if err := fetchUseCase.SetSaveConcurrency(cfg.Storage.JSON.SaveConcurrency); err != nil {
+		logger.Warn("Invalid save concurrency, saving one draw at a time", zap.Error(err))
+	}
+
+	if fetchVerifyOnly {
+		checked, mismatches, err := fetchUseCase.VerifyLatest(ctx, gt, fetchLimit)
+		if err != nil {
+			logger.Fatal("Failed to verify draws", zap.Error(err))
+		}
+		if !printVerifyReport(os.Stdout, gt, checked, mismatches) {
+			logger.Exit(1)
+		}
+		return
+	}
+
+	if cfg.Scraper.Vietlott.FetchDetails {
+		fetchUseCase.SetDetailFetcher(apiScraper)
+	}
+
+	if cfg.Notify.WebhookURL != "" {
+		notifier := notify.NewWebhookNotifier(cfg.Notify.WebhookURL, cfg.Notify.Timeout)
+		predictionStorage, err := wiring.NewPredictionRepository(cfg)
+		if err != nil {
+			logger.Warn("Failed to initialize prediction storage, notifying draws without predictions", zap.Error(err))
+			fetchUseCase.SetDrawNotifier(notifier, nil)
+		} else {
+			fetchUseCase.SetDrawNotifier(notifier, predictionStorage)
+		}
+	}
+
+	draws, err := fetchUseCase.FetchLatest(ctx, gt, fetchLimit)
+	if err != nil {
+		logger.Fatal("Failed to fetch draws", zap.Error(err))
+	}
+	fmt.Printf("✅ Fetched %d %s draws into %s\n", len(draws), gt, wiring.DrawStorageLocation(cfg))
+}
+
+// printVerifyReport prints how scraped draws compare with storage and
+// reports whether they all matched
+func printVerifyReport(w io.Writer, gameType valueobject.GameType, checked []*entity.Draw, mismatches []usecase.DrawMismatch) bool {
+	fmt.Fprintf(w, "\n🔍 %s: %d scraped draw(s) checked against storage\n", gameType, len(checked))
+
+	if len(mismatches) == 0 {
+		fmt.Fprintf(w, "  ✅ All draws match\n")
+		return true
+	}
+
+	fmt.Fprintf(w, "  ❌ %d mismatch(es):\n", len(mismatches))
+	for _, m := range mismatches {
+		reasons := make([]string, 0, len(m.Reasons))
+		for _, reason := range m.Reasons {
+			reasons = append(reasons, strings.ReplaceAll(string(reason), "_", " "))
+		}
+		fmt.Fprintf(w, "    #%d %s\n", m.DrawNumber, strings.Join(reasons, ", "))
+		fmt.Fprintf(w, "      scraped: %s\n", describeDraw(m.Scraped))
+		if m.Stored != nil {
+			fmt.Fprintf(w, "      stored:  %s\n", describeDraw(m.Stored))
+		}
+	}
+	return false
+}
+
+// describeDraw formats a draw's numbers and date for the verify report
+func describeDraw(draw *entity.Draw) string {
+	return fmt.Sprintf("%s on %s", draw.Numbers, draw.DrawDate.Format("2006-01-02"))
+}
@@ -0,0 +1,38 @@
+package predictor
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/application/usecase"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func TestPrintVerifyReport(t *testing.T) {
+	date := time.Date(2026, 1, 14, 0, 0, 0, 0, time.UTC)
+	scraped, err := entity.NewDraw(valueobject.Mega645, 1234, valueobject.MustNewNumbers([]int{3, 9, 17, 22, 30, 44}), date, 0, 0)
+	require.NoError(t, err)
+	stored, err := entity.NewDraw(valueobject.Mega645, 1234, valueobject.MustNewNumbers([]int{3, 9, 17, 22, 30, 45}), date.AddDate(0, 0, -1), 0, 0)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	assert.True(t, printVerifyReport(&out, valueobject.Mega645, []*entity.Draw{scraped}, nil))
+	assert.Contains(t, out.String(), "All draws match")
+
+	out.Reset()
+	ok := printVerifyReport(&out, valueobject.Mega645, []*entity.Draw{scraped}, []usecase.DrawMismatch{{
+		DrawNumber: 1234,
+		Reasons:    []usecase.DrawMismatchReason{usecase.MismatchNumbers, usecase.MismatchDate},
+		Scraped:    scraped,
+		Stored:     stored,
+	}})
+
+	assert.False(t, ok)
+	assert.Contains(t, out.String(), "#1234 numbers differ, date differs")
+	assert.Contains(t, out.String(), "scraped: [03, 09, 17, 22, 30, 44] on 2026-01-14")
+	assert.Contains(t, out.String(), "stored:  [03, 09, 17, 22, 30, 45] on 2026-01-13")
+}
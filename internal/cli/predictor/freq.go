@@ -0,0 +1,161 @@
+package predictor
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/tool_predict/internal/cli/wiring"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"github.com/tool_predict/pkg/analytics"
+	"go.uber.org/zap"
+)
+
+var (
+	freqFrom int
+	freqTo   int
+)
+
+var freqCmd = &cobra.Command{
+	Use:     "freq",
+	Aliases: []string{"stats"},
+	Short:   "Print how often each number was drawn in a range of draws",
+	Long: `Prints a frequency table of the stored draws numbered --from to --to, most
+drawn numbers first. Leaving out --from or --to leaves that end of the range
+open; without either, the latest --draws stored draws are used.
+
+The numbers of the latest stored prediction are marked with how often each was
+drawn when the ensemble recommended it before, scored against stored draws.`,
+	Args: cobra.NoArgs,
+	Run:  runFreq,
+}
+
+func init() {
+	freqCmd.Flags().IntVar(&freqFrom, "from", 0, "First draw number of the range")
+	freqCmd.Flags().IntVar(&freqTo, "to", 0, "Last draw number of the range")
+	rootCmd.AddCommand(freqCmd)
+}
+
+func runFreq(cmd *cobra.Command, args []string) {
+	cfg, ctx, shutdown := loadConfigAndLogger(os.Stdout, "stdout")
+	defer shutdown()
+
+	gt, err := valueobject.ParseGameType(gameType)
+	if err != nil {
+		logger.Fatal("Invalid game type", zap.Error(err))
+	}
+
+	if freqTo > 0 && freqFrom > freqTo {
+		logger.Fatal("Invalid draw range", zap.Int("from", freqFrom), zap.Int("to", freqTo))
+	}
+
+	drawStorage, err := wiring.NewDrawRepository(cfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize draw storage", zap.Error(err))
+	}
+
+	var draws []*entity.Draw
+	if freqFrom > 0 || freqTo > 0 {
+		to := freqTo
+		if to == 0 {
+			to = math.MaxInt
+		}
+		draws, err = drawStorage.FindByDrawNumberRange(ctx, gt, freqFrom, to)
+	} else {
+		draws, err = drawStorage.FindLatest(ctx, gt, maxDraws)
+	}
+	if err != nil {
+		logger.Fatal("Failed to load draws", zap.Error(err))
+	}
+	if len(draws) == 0 {
+		logger.Fatal("No stored draws in range", zap.String("game_type", string(gt)))
+	}
+
+	predictionStorage, err := wiring.NewPredictionRepository(cfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize prediction storage", zap.Error(err))
+	}
+	var hitRates map[int]analytics.NumberHitRate
+	ensembles, err := predictionStorage.FindLatestEnsembles(ctx, gt, agreementHistoryLimit)
+	if err == nil {
+		var history []*entity.Draw
+		history, err = drawStorage.FindLatest(ctx, gt, agreementHistoryLimit)
+		hitRates = recommendedHitRates(ensembles, history)
+	}
+	if err != nil {
+		logger.Warn("No stored predictions for hit rates", zap.Error(err))
+	}
+
+	printFrequencyTable(os.Stdout, analytics.FrequencyTable(draws, gt), gt, draws, hitRates)
+}
+
+// recommendedHitRates returns the hit rate of each number the latest of
+// ensembles recommends, from the outcomes of ensembles against draws. It is
+// nil without ensembles.
+func recommendedHitRates(ensembles []*entity.EnsemblePrediction, draws []*entity.Draw) map[int]analytics.NumberHitRate {
+	if len(ensembles) == 0 {
+		return nil
+	}
+	latest := ensembles[0]
+	for _, ensemble := range ensembles[1:] {
+		if ensemble.GeneratedAt.After(latest.GeneratedAt) {
+			latest = ensemble
+		}
+	}
+
+	all := analytics.RecommendationHitRates(ensembles, draws)
+	rates := make(map[int]analytics.NumberHitRate, len(latest.FinalNumbers))
+	for _, num := range latest.FinalNumbers {
+		rate := all[num]
+		rate.Number = num
+		rates[num] = rate
+	}
+	return rates
+}
+
+// printFrequencyTable prints one line per number with its count and rate.
+// Numbers in hitRates, the current recommendation, are marked with their hit
+// rate when recommended before.
+func printFrequencyTable(
+	w io.Writer,
+	table []analytics.NumberFrequency,
+	gameType valueobject.GameType,
+	draws []*entity.Draw,
+	hitRates map[int]analytics.NumberHitRate,
+) {
+	first, last := draws[0].DrawNumber, draws[0].DrawNumber
+	for _, draw := range draws {
+		first = min(first, draw.DrawNumber)
+		last = max(last, draw.DrawNumber)
+	}
+
+	fmt.Fprintf(w, "\n📈 Number Frequency for %s (draws #%d-#%d, %d draws)\n", gameType, first, last, len(draws))
+	fmt.Fprintf(w, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+	for _, entry := range table {
+		lastDrawn := "never"
+		if entry.LastDrawn > 0 {
+			lastDrawn = fmt.Sprintf("#%d", entry.LastDrawn)
+		}
+		fmt.Fprintf(w, "  %02d  %4d  (%5.1f%%)  last %s%s\n",
+			entry.Number, entry.Count, entry.Rate*100, lastDrawn, formatHitRate(hitRates, entry.Number))
+	}
+	fmt.Fprintf(w, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+}
+
+// formatHitRate describes num's hit rate when recommended, or returns "" when
+// num isn't recommended now
+func formatHitRate(hitRates map[int]analytics.NumberHitRate, num int) string {
+	rate, ok := hitRates[num]
+	switch {
+	case !ok:
+		return ""
+	case rate.Recommended == 0:
+		return "  ★ recommended, no outcomes yet"
+	default:
+		return fmt.Sprintf("  ★ hit %d/%d when recommended (%.1f%%)", rate.Hits, rate.Recommended, rate.Rate()*100)
+	}
+}
@@ -0,0 +1,61 @@
+package predictor
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/pkg/analytics"
+)
+
+func TestPrintFrequencyTable_HitRateColumn(t *testing.T) {
+	start := time.Date(2026, 1, 2, 18, 0, 0, 0, time.UTC)
+	draws := make([]*entity.Draw, 0, 4)
+	ensembles := make([]*entity.EnsemblePrediction, 0, 5)
+	for i, nums := range [][]int{
+		{7, 2, 3, 4, 5, 6},
+		{7, 10, 11, 12, 13, 14},
+		{9, 20, 21, 22, 23, 24},
+		{7, 30, 31, 32, 33, 34},
+	} {
+		date := start.AddDate(0, 0, 2*i)
+		draw, err := entity.NewDraw(valueobject.Mega645, i+1, valueobject.MustNewNumbers(nums), date, 0, 0)
+		require.NoError(t, err)
+		draws = append(draws, draw)
+
+		ensembles = append(ensembles, &entity.EnsemblePrediction{
+			GameType:     valueobject.Mega645,
+			GeneratedAt:  date.Add(-24 * time.Hour),
+			ForDate:      time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC),
+			FinalNumbers: valueobject.MustNewNumbers([]int{7, 9, 40, 41, 42, 43}),
+		})
+	}
+	// The current recommendation, for a draw not stored yet
+	ensembles = append(ensembles, &entity.EnsemblePrediction{
+		GameType:     valueobject.Mega645,
+		GeneratedAt:  start.AddDate(0, 0, 8),
+		ForDate:      start.AddDate(0, 0, 9),
+		FinalNumbers: valueobject.MustNewNumbers([]int{7, 9, 15, 40, 41, 42}),
+	})
+
+	hitRates := recommendedHitRates(ensembles, draws)
+	require.Len(t, hitRates, 6)
+
+	var out bytes.Buffer
+	printFrequencyTable(&out, analytics.FrequencyTable(draws, valueobject.Mega645), valueobject.Mega645, draws, hitRates)
+	text := out.String()
+
+	assert.Contains(t, text, "  07     3  ( 75.0%)  last #4  ★ hit 3/4 when recommended (75.0%)\n")
+	assert.Contains(t, text, "  09     1  ( 25.0%)  last #3  ★ hit 1/4 when recommended (25.0%)\n")
+	assert.Contains(t, text, "  40     0  (  0.0%)  last never  ★ hit 0/4 when recommended (0.0%)\n")
+	assert.Contains(t, text, "  15     0  (  0.0%)  last never  ★ recommended, no outcomes yet\n")
+	// Numbers not recommended now have no hit rate
+	assert.Contains(t, text, "  43     0  (  0.0%)  last never\n")
+	assert.Contains(t, text, "  02     1  ( 25.0%)  last #1\n")
+
+	assert.Nil(t, recommendedHitRates(nil, draws))
+}
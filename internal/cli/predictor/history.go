@@ -0,0 +1,229 @@
+package predictor
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tool_predict/internal/application/usecase"
+	"github.com/tool_predict/internal/cli/wiring"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+var (
+	historySince         string
+	historyAlgorithm     string
+	historyMinConfidence float64
+	historyLimit         int
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List stored predictions and the draws they targeted",
+	Long: `Lists the stored ensemble predictions for --game-type, newest first, each with
+the draw it targeted and how many of its final numbers were drawn. Predictions
+whose draw isn't stored yet show as pending; run fetch to score them.
+
+Filter with --since, --algorithm and --min-confidence, and compare two
+predictions with history diff.`,
+	Args: cobra.NoArgs,
+	Run:  runHistory,
+}
+
+var historyDiffCmd = &cobra.Command{
+	Use:   "diff <id> <id>",
+	Short: "Compare two stored predictions side by side",
+	Args:  cobra.ExactArgs(2),
+	Run:   runHistoryDiff,
+}
+
+func init() {
+	historyCmd.Flags().StringVar(&historySince, "since", "", "Only predictions generated on or after this date (YYYY-MM-DD)")
+	historyCmd.Flags().StringVar(&historyAlgorithm, "algorithm", "", "Only predictions this algorithm took part in")
+	historyCmd.Flags().Float64Var(&historyMinConfidence, "min-confidence", 0, "Only predictions with at least this confidence (0-1)")
+	historyCmd.Flags().IntVar(&historyLimit, "limit", 20, "Most predictions to list (0 for all)")
+	historyCmd.AddCommand(historyDiffCmd)
+	rootCmd.AddCommand(historyCmd)
+}
+
+func runHistory(cmd *cobra.Command, args []string) {
+	cfg, ctx, shutdown := loadConfigAndLogger(os.Stdout, "stdout")
+	defer shutdown()
+
+	gt, err := valueobject.ParseGameType(gameType)
+	if err != nil {
+		logger.Fatal("Invalid game type", zap.Error(err))
+	}
+
+	var since time.Time
+	if historySince != "" {
+		since, err = time.ParseInLocation("2006-01-02", historySince, time.Local)
+		if err != nil {
+			logger.Fatal("Invalid --since date, want YYYY-MM-DD", zap.String("since", historySince), zap.Error(err))
+		}
+	}
+
+	drawStorage, err := wiring.NewDrawRepository(cfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize draw storage", zap.Error(err))
+	}
+
+	predictionStorage, err := wiring.NewPredictionRepository(cfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize prediction storage", zap.Error(err))
+	}
+
+	entries, err := usecase.NewPredictionHistoryUseCase(drawStorage, predictionStorage).Execute(ctx,
+		usecase.PredictionHistoryRequest{
+			GameType:      gt,
+			Since:         since,
+			Algorithm:     historyAlgorithm,
+			MinConfidence: historyMinConfidence,
+			Limit:         historyLimit,
+		})
+	if err != nil {
+		logger.Fatal("Failed to load prediction history", zap.Error(err))
+	}
+
+	printPredictionHistory(os.Stdout, gt, entries)
+}
+
+func runHistoryDiff(cmd *cobra.Command, args []string) {
+	cfg, ctx, shutdown := loadConfigAndLogger(os.Stdout, "stdout")
+	defer shutdown()
+
+	predictionStorage, err := wiring.NewPredictionRepository(cfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize prediction storage", zap.Error(err))
+	}
+
+	predictions := make([]*entity.EnsemblePrediction, len(args))
+	for i, id := range args {
+		predictions[i], err = predictionStorage.FindEnsembleByID(ctx, id)
+		if err != nil {
+			logger.Fatal("Failed to load prediction", zap.String("id", id), zap.Error(err))
+		}
+	}
+
+	printPredictionDiff(os.Stdout, predictions[0], predictions[1])
+}
+
+// printPredictionHistory prints one line per prediction with its target
+// draw and match count, or pending when the draw isn't stored
+func printPredictionHistory(w io.Writer, gameType valueobject.GameType, entries []usecase.PredictionHistoryEntry) {
+	fmt.Fprintf(w, "\n📜 Prediction history for %s\n", gameType)
+	fmt.Fprintf(w, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+
+	if len(entries) == 0 {
+		fmt.Fprintf(w, "No stored predictions match\n")
+		return
+	}
+
+	for _, entry := range entries {
+		p := entry.Prediction
+		fmt.Fprintf(w, "%s  %s  %s  conf %.2f  ",
+			p.ID, p.GeneratedAt.Format("2006-01-02 15:04"), p.FinalNumbers, p.Confidence)
+		if entry.Draw == nil {
+			fmt.Fprintf(w, "pending\n")
+			continue
+		}
+		fmt.Fprintf(w, "draw #%d (%s) %s: %d matched\n",
+			entry.Draw.DrawNumber, entry.Draw.DrawDate.Format("2006-01-02"), entry.Draw.Numbers, entry.MatchCount)
+	}
+}
+
+// printPredictionDiff prints two predictions side by side, then the numbers
+// they share and the ones only each picked
+func printPredictionDiff(w io.Writer, a, b *entity.EnsemblePrediction) {
+	row := func(label, left, right string) {
+		fmt.Fprintf(w, "%-22s %-26s %s\n", label, left, right)
+	}
+	targetDate := func(p *entity.EnsemblePrediction) string {
+		if p.ForDate.IsZero() {
+			return "unknown"
+		}
+		return p.ForDate.Format("2006-01-02")
+	}
+	outcome := func(p *entity.EnsemblePrediction) string {
+		if p.Outcome == nil {
+			return "not verified"
+		}
+		return fmt.Sprintf("draw #%d: %d matched", p.Outcome.DrawNumber, p.Outcome.MatchCount)
+	}
+
+	fmt.Fprintf(w, "\n🔀 Prediction diff\n")
+	fmt.Fprintf(w, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+	row("", "A", "B")
+	row("ID", shortID(a.ID), shortID(b.ID))
+	row("Game", string(a.GameType), string(b.GameType))
+	row("Generated", a.GeneratedAt.Format("2006-01-02 15:04"), b.GeneratedAt.Format("2006-01-02 15:04"))
+	row("Target draw", targetDate(a), targetDate(b))
+	row("Strategy", a.VotingStrategy, b.VotingStrategy)
+	row("Confidence", fmt.Sprintf("%.2f", a.Confidence), fmt.Sprintf("%.2f", b.Confidence))
+	row("Numbers", a.FinalNumbers.String(), b.FinalNumbers.String())
+	row("Outcome", outcome(a), outcome(b))
+
+	var common, onlyA, onlyB []int
+	for _, n := range a.FinalNumbers {
+		if b.FinalNumbers.Contains(n) {
+			common = append(common, n)
+		} else {
+			onlyA = append(onlyA, n)
+		}
+	}
+	for _, n := range b.FinalNumbers {
+		if !a.FinalNumbers.Contains(n) {
+			onlyB = append(onlyB, n)
+		}
+	}
+	fmt.Fprintf(w, "\nIn both:   %s\n", formatNumberList(common))
+	fmt.Fprintf(w, "Only in A: %s\n", formatNumberList(onlyA))
+	fmt.Fprintf(w, "Only in B: %s\n", formatNumberList(onlyB))
+
+	picksA, picksB := algorithmPicks(a), algorithmPicks(b)
+	names := make([]string, 0, len(picksA)+len(picksB))
+	for name := range picksA {
+		names = append(names, name)
+	}
+	for name := range picksB {
+		if _, ok := picksA[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	fmt.Fprintf(w, "\nPer algorithm:\n")
+	for _, name := range names {
+		left, right := picksA[name], picksB[name]
+		if left == "" {
+			left = "-"
+		}
+		if right == "" {
+			right = "-"
+		}
+		row("  "+name, left, right)
+	}
+}
+
+// algorithmPicks maps each algorithm in p to its own predicted numbers
+func algorithmPicks(p *entity.EnsemblePrediction) map[string]string {
+	picks := make(map[string]string, len(p.Predictions))
+	for _, prediction := range p.Predictions {
+		picks[prediction.AlgorithmName] = prediction.Numbers.String()
+	}
+	return picks
+}
+
+// shortID shortens a prediction ID to its first 8 characters
+func shortID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
@@ -0,0 +1,74 @@
+package predictor
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/application/usecase"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// newHistoryEnsemble returns an ensemble for the given date whose named
+// algorithms each predicted the given numbers
+func newHistoryEnsemble(t *testing.T, forDate time.Time, final []int, picks map[string][]int) *entity.EnsemblePrediction {
+	t.Helper()
+
+	var predictions []*entity.Prediction
+	for name, nums := range picks {
+		prediction, err := entity.NewPrediction(valueobject.Mega645, name, valueobject.MustNewNumbers(nums), 0.5, forDate)
+		require.NoError(t, err)
+		predictions = append(predictions, prediction)
+	}
+	ensemble, err := entity.NewEnsemblePrediction(valueobject.Mega645, predictions, valueobject.MustNewNumbers(final), "weighted", nil)
+	require.NoError(t, err)
+	ensemble.ForDate = forDate
+	ensemble.GeneratedAt = forDate.Add(-6 * time.Hour)
+	ensemble.Confidence = 0.42
+	return ensemble
+}
+
+func TestPrintPredictionHistory(t *testing.T) {
+	drawDate := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+	draw, err := entity.NewDraw(valueobject.Mega645, 1201, valueobject.MustNewNumbers([]int{3, 9, 17, 22, 30, 41}), drawDate, 0, 0)
+	require.NoError(t, err)
+
+	drawn := newHistoryEnsemble(t, drawDate, []int{3, 9, 12, 22, 31, 42}, map[string][]int{"frequency_analysis": {1, 2, 3, 4, 5, 6}})
+	pending := newHistoryEnsemble(t, drawDate.AddDate(0, 0, 2), []int{1, 2, 3, 4, 5, 6}, map[string][]int{"frequency_analysis": {1, 2, 3, 4, 5, 6}})
+
+	var out bytes.Buffer
+	printPredictionHistory(&out, valueobject.Mega645, []usecase.PredictionHistoryEntry{
+		{Prediction: pending},
+		{Prediction: drawn, Draw: draw, MatchCount: 3},
+	})
+	assert.Contains(t, out.String(), pending.ID+"  2026-03-05 18:00")
+	assert.Contains(t, out.String(), "conf 0.42  pending\n")
+	assert.Contains(t, out.String(), "draw #1201 (2026-03-04) [03, 09, 17, 22, 30, 41]: 3 matched\n")
+
+	out.Reset()
+	printPredictionHistory(&out, valueobject.Mega645, nil)
+	assert.Contains(t, out.String(), "No stored predictions match")
+}
+
+func TestPrintPredictionDiff(t *testing.T) {
+	forDate := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+	a := newHistoryEnsemble(t, forDate, []int{3, 9, 17, 22, 30, 41}, map[string][]int{
+		"frequency_analysis": {3, 9, 17, 22, 30, 41},
+		"hot_cold_analysis":  {1, 2, 3, 4, 5, 6},
+	})
+	b := newHistoryEnsemble(t, forDate, []int{3, 9, 17, 22, 30, 44}, map[string][]int{
+		"frequency_analysis": {3, 9, 17, 22, 30, 44},
+	})
+
+	var out bytes.Buffer
+	printPredictionDiff(&out, a, b)
+	assert.Contains(t, out.String(), "Numbers                [03, 09, 17, 22, 30, 41]   [03, 09, 17, 22, 30, 44]\n")
+	assert.Contains(t, out.String(), "In both:   (03, 09, 17, 22, 30)\n")
+	assert.Contains(t, out.String(), "Only in A: (41)\n")
+	assert.Contains(t, out.String(), "Only in B: (44)\n")
+	assert.Contains(t, out.String(), "  hot_cold_analysis    [01, 02, 03, 04, 05, 06]   -\n")
+	assert.Contains(t, out.String(), "Outcome                not verified")
+}
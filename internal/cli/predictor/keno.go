@@ -0,0 +1,118 @@
+package predictor
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/adapter/scraper"
+	"github.com/tool_predict/internal/infrastructure/adapter/storage"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"github.com/tool_predict/pkg/algorithm"
+	"go.uber.org/zap"
+)
+
+var (
+	kenoFetchLimit int
+	kenoSpots      int
+	kenoHistory    int
+)
+
+var kenoCmd = &cobra.Command{
+	Use:   "keno",
+	Short: "Fetch Keno results and pick Keno numbers",
+	Long: `Keno draws 20 of the numbers 01-80 every few minutes through the day and
+tickets pick 1 to 10 of them. Keno draws are stored apart from the 6-number
+games under draws/keno, so --game-type does not apply.`,
+}
+
+var kenoFetchCmd = &cobra.Command{
+	Use:   "fetch",
+	Short: "Fetch the latest Keno draws from Vietlott",
+	Args:  cobra.NoArgs,
+	Run:   runKenoFetch,
+}
+
+var kenoPredictCmd = &cobra.Command{
+	Use:   "predict",
+	Short: "Pick Keno numbers from the most often drawn ones",
+	Long: `Picks the --spots numbers drawn most often in the latest --history stored Keno
+draws, ties going to the lower number. Run keno fetch first.`,
+	Args: cobra.NoArgs,
+	Run:  runKenoPredict,
+}
+
+func init() {
+	kenoFetchCmd.Flags().IntVar(&kenoFetchLimit, "limit", 100, "Latest Keno draws to fetch")
+	kenoPredictCmd.Flags().IntVar(&kenoSpots, "spots", valueobject.KenoMaxSpots, "Numbers to pick (1-10)")
+	kenoPredictCmd.Flags().IntVar(&kenoHistory, "history", 200, "Latest stored Keno draws to count")
+	kenoCmd.AddCommand(kenoFetchCmd, kenoPredictCmd)
+	rootCmd.AddCommand(kenoCmd)
+}
+
+func runKenoFetch(cmd *cobra.Command, args []string) {
+	cfg, ctx, shutdown := loadConfigAndLogger(os.Stdout, "stdout")
+	defer shutdown()
+
+	kenoStorage, err := storage.NewKenoJSONStorage(cfg.Storage.JSON.BasePath)
+	if err != nil {
+		logger.Fatal("Failed to initialize keno storage", zap.Error(err))
+	}
+
+	webScraper := scraper.NewVietlottWebScraper(
+		cfg.Scraper.Vietlott.BaseURL,
+		cfg.Scraper.Vietlott.Timeout,
+		cfg.Scraper.Vietlott.RetryCount,
+		cfg.Scraper.Vietlott.RateLimit,
+	)
+
+	draws, err := webScraper.FetchLatestKenoDraws(ctx, kenoFetchLimit)
+	if err != nil {
+		logger.Fatal("Failed to fetch keno draws", zap.Error(err))
+	}
+	if err := kenoStorage.SaveBatch(ctx, draws); err != nil {
+		logger.Fatal("Failed to save keno draws", zap.Error(err))
+	}
+
+	fmt.Printf("✅ Saved %d Keno draws (latest #%d)\n", len(draws), draws[0].DrawNumber)
+}
+
+func runKenoPredict(cmd *cobra.Command, args []string) {
+	cfg, ctx, shutdown := loadConfigAndLogger(os.Stdout, "stdout")
+	defer shutdown()
+
+	if kenoHistory < 1 {
+		logger.Fatal("Invalid keno history", zap.Int("history", kenoHistory))
+	}
+
+	kenoStorage, err := storage.NewKenoJSONStorage(cfg.Storage.JSON.BasePath)
+	if err != nil {
+		logger.Fatal("Failed to initialize keno storage", zap.Error(err))
+	}
+
+	draws, err := kenoStorage.FindLatest(ctx, kenoHistory)
+	if err != nil {
+		logger.Fatal("Failed to load keno draws", zap.Error(err))
+	}
+
+	pick, err := algorithm.PredictKeno(draws, kenoSpots)
+	if err != nil {
+		logger.Fatal("Keno prediction failed", zap.Error(err))
+	}
+
+	printKenoPick(os.Stdout, pick)
+}
+
+// printKenoPick prints a Keno pick with how often each number was drawn
+func printKenoPick(w io.Writer, pick *algorithm.KenoPick) {
+	fmt.Fprintf(w, "\n🎯 Keno %d-spot pick (from the last %d draws)\n", len(pick.Numbers), pick.Draws)
+	fmt.Fprintf(w, "   %s\n\n", pick.Numbers)
+	for _, num := range pick.Numbers {
+		hits := pick.Hits[num]
+		fmt.Fprintf(w, "  %02d  drawn %d/%d (%.1f%%)\n", num, hits, pick.Draws, float64(hits)/float64(pick.Draws)*100)
+	}
+	fmt.Fprintf(w, "\n  Each number is drawn in about %d%% of Keno draws by chance.\n",
+		valueobject.KenoDrawSize*100/valueobject.KenoMaxNumber)
+}
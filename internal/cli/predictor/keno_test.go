@@ -0,0 +1,24 @@
+package predictor
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/pkg/algorithm"
+)
+
+func TestPrintKenoPick(t *testing.T) {
+	var out bytes.Buffer
+	printKenoPick(&out, &algorithm.KenoPick{
+		Numbers: valueobject.KenoNumbers{7, 42, 80},
+		Hits:    map[int]int{7: 12, 42: 10, 80: 9},
+		Draws:   40,
+	})
+
+	assert.Contains(t, out.String(), "Keno 3-spot pick (from the last 40 draws)")
+	assert.Contains(t, out.String(), "[07, 42, 80]")
+	assert.Contains(t, out.String(), "  07  drawn 12/40 (30.0%)")
+	assert.Contains(t, out.String(), "about 25% of Keno draws")
+}
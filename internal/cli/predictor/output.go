@@ -0,0 +1,271 @@
+package predictor
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/tool_predict/internal/application/usecase"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/pkg/algorithm"
+)
+
+// outputFormatter renders a prediction result
+type outputFormatter interface {
+	// MachineReadable reports whether stdout must carry only the formatted result
+	MachineReadable() bool
+
+	// Format writes the prediction result to w
+	Format(w io.Writer, result *usecase.EnsembleResult, gameType valueobject.GameType) error
+}
+
+// outputFormatters holds the available formatters keyed by --output-format value
+var outputFormatters = map[string]outputFormatter{
+	"text": textFormatter{},
+	"env":  envFormatter{},
+}
+
+// newOutputFormatter returns the formatter for the given format name
+func newOutputFormatter(format string) (outputFormatter, error) {
+	formatter, ok := outputFormatters[strings.ToLower(format)]
+	if !ok {
+		return nil, fmt.Errorf("unknown output format %q (available: %s)",
+			format, strings.Join(outputFormatNames(), ", "))
+	}
+	return formatter, nil
+}
+
+// outputFormatNames returns the sorted list of formatter names
+func outputFormatNames() []string {
+	names := make([]string, 0, len(outputFormatters))
+	for name := range outputFormatters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// textFormatter prints the human-readable prediction summary
+type textFormatter struct{}
+
+func (textFormatter) MachineReadable() bool { return false }
+
+func (textFormatter) Format(w io.Writer, result *usecase.EnsembleResult, gameType valueobject.GameType) error {
+	fmt.Fprintf(w, "📊 Prediction Results for %s\n", gameType)
+	fmt.Fprintf(w, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+	fmt.Fprintf(w, "Prediction ID:  %s\n", result.Prediction.ID)
+	if !result.Prediction.ForDate.IsZero() {
+		fmt.Fprintf(w, "Target Draw:    %s\n", result.Prediction.ForDate.Format("2006-01-02 Mon"))
+	}
+	if core := result.Prediction.Core; len(core) > 0 {
+		fmt.Fprintf(w, "Core Numbers:\n")
+		for _, num := range core {
+			fmt.Fprintf(w, "  %02d  confidence %5.1f%%\n", num.Number, num.Confidence*100)
+		}
+		fmt.Fprintf(w, "Pick the other %d yourself\n", len(result.Prediction.FinalNumbers)-len(core))
+	} else {
+		fmt.Fprintf(w, "Predicted Numbers:  ")
+		for i, num := range result.Prediction.FinalNumbers {
+			fmt.Fprintf(w, "%02d", num)
+			if i < len(result.Prediction.FinalNumbers)-1 {
+				fmt.Fprintf(w, " - ")
+			}
+		}
+		fmt.Fprintf(w, "\n")
+	}
+	if result.Prediction.Metadata[algorithm.MetadataBlend] != "" {
+		fmt.Fprintf(w, "Blend:              yours %s + ensemble %s\n",
+			formatMetadataNumbers(result.Prediction.Metadata[algorithm.MetadataBlendMine]),
+			formatMetadataNumbers(result.Prediction.Metadata[algorithm.MetadataBlendVoted]))
+	}
+	if len(result.Prediction.BetPool) > 0 {
+		fmt.Fprintf(w, "Bet:                %s: %s\n", formatBetType(result.Prediction.BetType, gameType),
+			strings.Join(formatTwoDigits(result.Prediction.BetPool), " - "))
+	}
+	if len(result.Prediction.Alternates) > 0 {
+		fmt.Fprintf(w, "Consider Also:      %s\n", strings.Join(formatTwoDigits(result.Prediction.Alternates), " - "))
+	}
+	if sets := result.Prediction.CandidateSets; len(sets) > 0 {
+		fmt.Fprintf(w, "Candidate Sets:\n")
+		for i, set := range sets {
+			fmt.Fprintf(w, "  %2d. %s  confidence %5.1f%%\n", i+1, strings.Join(formatTwoDigits(set.Numbers), " - "), set.Confidence*100)
+		}
+	}
+	if wheel := result.Prediction.Wheel; wheel != nil {
+		fmt.Fprintf(w, "Wheel:              %s over %s\n", wheel.Guarantee, strings.Join(formatTwoDigits(wheel.Candidates), " - "))
+		for i, ticket := range wheel.Tickets {
+			fmt.Fprintf(w, "  Ticket %2d:        %s\n", i+1, strings.Join(formatTwoDigits(ticket), " - "))
+		}
+	}
+	fmt.Fprintf(w, "Voting Strategy: %s\n", result.Prediction.VotingStrategy)
+	fmt.Fprintf(w, "Algorithms Used:  %d\n", result.AlgorithmsUsed)
+	fmt.Fprintf(w, "Confidence:       %.2f%%\n", result.Prediction.Confidence*100)
+	if p := result.Prediction.Provenance; p != nil && p.ToolVersion != "" {
+		fmt.Fprintf(w, "Version:          %s (config %s)\n", p.ToolVersion, p.ConfigHash)
+	}
+	fmt.Fprintf(w, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+
+	// Show algorithm contributions
+	fmt.Fprintf(w, "\n🔬 Algorithm Contributions:\n")
+	for _, stat := range result.Prediction.AlgorithmStats {
+		fmt.Fprintf(w, "  • %s: %d matches, confidence: %.2f%%\n",
+			stat.AlgorithmName,
+			stat.MatchCount,
+			stat.Confidence*100,
+		)
+	}
+	return nil
+}
+
+// envFormatter prints KEY=value lines that shell scripts can eval or source
+type envFormatter struct{}
+
+func (envFormatter) MachineReadable() bool { return true }
+
+func (envFormatter) Format(w io.Writer, result *usecase.EnsembleResult, gameType valueobject.GameType) error {
+	numbers := make([]string, len(result.Prediction.FinalNumbers))
+	for i, num := range result.Prediction.FinalNumbers {
+		numbers[i] = fmt.Sprintf("%d", num)
+	}
+
+	alternates := make([]string, len(result.Prediction.Alternates))
+	for i, num := range result.Prediction.Alternates {
+		alternates[i] = fmt.Sprintf("%d", num)
+	}
+
+	betPool := make([]string, len(result.Prediction.BetPool))
+	for i, num := range result.Prediction.BetPool {
+		betPool[i] = fmt.Sprintf("%d", num)
+	}
+
+	core := make([]string, len(result.Prediction.Core))
+	coreConfidence := make([]string, len(result.Prediction.Core))
+	for i, num := range result.Prediction.Core {
+		core[i] = fmt.Sprintf("%d", num.Number)
+		coreConfidence[i] = fmt.Sprintf("%.2f", num.Confidence)
+	}
+
+	sets := make([]string, len(result.Prediction.CandidateSets))
+	setConfidence := make([]string, len(result.Prediction.CandidateSets))
+	for i, set := range result.Prediction.CandidateSets {
+		nums := make([]string, len(set.Numbers))
+		for j, num := range set.Numbers {
+			nums[j] = fmt.Sprintf("%d", num)
+		}
+		sets[i] = strings.Join(nums, ",")
+		setConfidence[i] = fmt.Sprintf("%.2f", set.Confidence)
+	}
+
+	wheelGuarantee := ""
+	var wheelCandidates, wheelTickets []string
+	if wheel := result.Prediction.Wheel; wheel != nil {
+		wheelGuarantee = wheel.Guarantee
+		for _, num := range wheel.Candidates {
+			wheelCandidates = append(wheelCandidates, fmt.Sprintf("%d", num))
+		}
+		for _, ticket := range wheel.Tickets {
+			nums := make([]string, len(ticket))
+			for i, num := range ticket {
+				nums[i] = fmt.Sprintf("%d", num)
+			}
+			wheelTickets = append(wheelTickets, strings.Join(nums, ","))
+		}
+	}
+
+	forDate := ""
+	if !result.Prediction.ForDate.IsZero() {
+		forDate = result.Prediction.ForDate.Format("2006-01-02")
+	}
+
+	toolVersion, configHash := "", ""
+	if p := result.Prediction.Provenance; p != nil {
+		toolVersion, configHash = p.ToolVersion, p.ConfigHash
+	}
+
+	vars := []struct {
+		key   string
+		value string
+	}{
+		{"PREDICTION_ID", result.Prediction.ID},
+		{"PREDICTION_GAME", string(gameType)},
+		{"PREDICTION_FOR_DATE", forDate},
+		{"PREDICTION_NUMBERS", strings.Join(numbers, ",")},
+		{"PREDICTION_ALTERNATES", strings.Join(alternates, ",")},
+		{"PREDICTION_BET_TYPE", result.Prediction.BetType},
+		{"PREDICTION_BET_POOL", strings.Join(betPool, ",")},
+		{"PREDICTION_CORE", strings.Join(core, ",")},
+		{"PREDICTION_CORE_CONFIDENCE", strings.Join(coreConfidence, ",")},
+		{"PREDICTION_BLEND_MINE", result.Prediction.Metadata[algorithm.MetadataBlendMine]},
+		{"PREDICTION_SETS", strings.Join(sets, ":")}, // Sets separated by colons
+		{"PREDICTION_SETS_CONFIDENCE", strings.Join(setConfidence, ",")},
+		{"PREDICTION_WHEEL_GUARANTEE", wheelGuarantee},
+		{"PREDICTION_WHEEL_CANDIDATES", strings.Join(wheelCandidates, ",")},
+		{"PREDICTION_WHEEL_TICKETS", strings.Join(wheelTickets, ":")}, // Tickets separated by colons
+		{"PREDICTION_CONFIDENCE", fmt.Sprintf("%.2f", result.Prediction.Confidence)},
+		{"PREDICTION_VOTING_STRATEGY", result.Prediction.VotingStrategy},
+		{"PREDICTION_ALGORITHMS_USED", fmt.Sprintf("%d", result.AlgorithmsUsed)},
+		{"PREDICTION_DRAWS_USED", fmt.Sprintf("%d", result.DrawsUsed)},
+		{"PREDICTION_TOOL_VERSION", toolVersion},
+		{"PREDICTION_CONFIG_HASH", configHash},
+	}
+
+	for _, v := range vars {
+		if _, err := fmt.Fprintf(w, "%s=%s\n", v.key, shellQuote(v.value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatTwoDigits zero-pads numbers to two digits, as on Vietlott tickets
+func formatTwoDigits(nums []int) []string {
+	formatted := make([]string, len(nums))
+	for i, num := range nums {
+		formatted[i] = fmt.Sprintf("%02d", num)
+	}
+	return formatted
+}
+
+// formatBetType describes a bao bet with the number of lines it covers, e.g.
+// "bao8 (28 lines)"
+func formatBetType(name string, gameType valueobject.GameType) string {
+	betType, err := valueobject.ParseBetType(name)
+	if err != nil {
+		return name
+	}
+	return fmt.Sprintf("%s (%d lines)", betType, betType.Lines(gameType))
+}
+
+// formatMetadataNumbers renders a comma-separated metadata number list the
+// way formatTwoDigits does
+func formatMetadataNumbers(value string) string {
+	if value == "" {
+		return "-"
+	}
+	parts := strings.Split(value, ",")
+	for i, part := range parts {
+		if num, err := strconv.Atoi(part); err == nil {
+			parts[i] = fmt.Sprintf("%02d", num)
+		}
+	}
+	return strings.Join(parts, " - ")
+}
+
+// shellQuote single-quotes a value when it contains characters the shell
+// would otherwise interpret
+func shellQuote(value string) string {
+	safe := true
+	for _, r := range value {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' ||
+			strings.ContainsRune("_-.,:/", r)) {
+			safe = false
+			break
+		}
+	}
+	if safe && value != "" {
+		return value
+	}
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
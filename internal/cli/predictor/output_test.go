@@ -0,0 +1,241 @@
+package predictor
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/application/usecase"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/pkg/algorithm"
+)
+
+func newTestResult(t *testing.T) *usecase.EnsembleResult {
+	t.Helper()
+
+	numbers := valueobject.MustNewNumbers([]int{1, 5, 9, 23, 41, 55})
+	forDate := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	freq, err := entity.NewPrediction(valueobject.Power655, "frequency_analysis", numbers, 0.80, forDate)
+	require.NoError(t, err)
+	hotCold, err := entity.NewPrediction(valueobject.Power655, "hot_cold_analysis", numbers, 0.64, forDate)
+	require.NoError(t, err)
+
+	ensemble, err := entity.NewEnsemblePrediction(
+		valueobject.Power655,
+		[]*entity.Prediction{freq, hotCold},
+		numbers,
+		"weighted",
+		nil,
+	)
+	require.NoError(t, err)
+	ensemble.Confidence = 0.72 // The mean, as the ensemble sets it by default
+
+	return &usecase.EnsembleResult{
+		Prediction:     ensemble,
+		DrawsUsed:      30,
+		AlgorithmsUsed: 2,
+	}
+}
+
+// parseEnv reads KEY=value lines back into a map
+func parseEnv(t *testing.T, output string) map[string]string {
+	t.Helper()
+
+	vars := make(map[string]string)
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), "=")
+		require.True(t, ok, "line without '=': %q", scanner.Text())
+		vars[key] = value
+	}
+	require.NoError(t, scanner.Err())
+	return vars
+}
+
+func TestEnvFormatter_Format(t *testing.T) {
+	result := newTestResult(t)
+
+	var buf bytes.Buffer
+	require.NoError(t, envFormatter{}.Format(&buf, result, valueobject.Power655))
+
+	vars := parseEnv(t, buf.String())
+	assert.Equal(t, "1,5,9,23,41,55", vars["PREDICTION_NUMBERS"])
+	assert.Equal(t, "0.72", vars["PREDICTION_CONFIDENCE"])
+	assert.Equal(t, "POWER_6_55", vars["PREDICTION_GAME"])
+	assert.Equal(t, result.Prediction.ID, vars["PREDICTION_ID"])
+	assert.Equal(t, "weighted", vars["PREDICTION_VOTING_STRATEGY"])
+	assert.Equal(t, "2", vars["PREDICTION_ALGORITHMS_USED"])
+	assert.Equal(t, "30", vars["PREDICTION_DRAWS_USED"])
+	assert.Equal(t, "''", vars["PREDICTION_FOR_DATE"])
+	assert.Equal(t, "''", vars["PREDICTION_ALTERNATES"])
+	assert.Equal(t, "''", vars["PREDICTION_BLEND_MINE"])
+
+	result.Prediction.ForDate = time.Date(2026, 1, 17, 0, 0, 0, 0, time.UTC)
+	buf.Reset()
+	require.NoError(t, envFormatter{}.Format(&buf, result, valueobject.Power655))
+	assert.Equal(t, "2026-01-17", parseEnv(t, buf.String())["PREDICTION_FOR_DATE"])
+}
+
+func TestTextFormatter_ShowsAlternates(t *testing.T) {
+	result := newTestResult(t)
+
+	var buf bytes.Buffer
+	require.NoError(t, textFormatter{}.Format(&buf, result, valueobject.Power655))
+	assert.NotContains(t, buf.String(), "Consider Also")
+
+	result.Prediction.Alternates = []int{7, 12, 30}
+	buf.Reset()
+	require.NoError(t, textFormatter{}.Format(&buf, result, valueobject.Power655))
+	assert.Contains(t, buf.String(), "Consider Also:      07 - 12 - 30")
+
+	buf.Reset()
+	require.NoError(t, envFormatter{}.Format(&buf, result, valueobject.Power655))
+	assert.Equal(t, "7,12,30", parseEnv(t, buf.String())["PREDICTION_ALTERNATES"])
+}
+
+func TestTextFormatter_ShowsBetPool(t *testing.T) {
+	result := newTestResult(t)
+
+	var buf bytes.Buffer
+	require.NoError(t, textFormatter{}.Format(&buf, result, valueobject.Power655))
+	assert.NotContains(t, buf.String(), "Bet:")
+
+	result.Prediction.BetType = "bao8"
+	result.Prediction.BetPool = []int{3, 7, 12, 21, 30, 41, 44, 52}
+	buf.Reset()
+	require.NoError(t, textFormatter{}.Format(&buf, result, valueobject.Power655))
+	assert.Contains(t, buf.String(), "Bet:                bao8 (28 lines): 03 - 07 - 12 - 21 - 30 - 41 - 44 - 52")
+
+	buf.Reset()
+	require.NoError(t, envFormatter{}.Format(&buf, result, valueobject.Power655))
+	env := parseEnv(t, buf.String())
+	assert.Equal(t, "bao8", env["PREDICTION_BET_TYPE"])
+	assert.Equal(t, "3,7,12,21,30,41,44,52", env["PREDICTION_BET_POOL"])
+}
+
+func TestTextFormatter_ShowsWheel(t *testing.T) {
+	result := newTestResult(t)
+
+	var buf bytes.Buffer
+	require.NoError(t, textFormatter{}.Format(&buf, result, valueobject.Power655))
+	assert.NotContains(t, buf.String(), "Wheel:")
+
+	result.Prediction.Wheel = &entity.Wheel{
+		Guarantee:  "3if4",
+		Candidates: []int{1, 5, 7, 9, 12, 23, 41, 55},
+		Tickets:    [][]int{{1, 5, 9, 12, 23, 41}, {1, 5, 7, 9, 41, 55}},
+	}
+	buf.Reset()
+	require.NoError(t, textFormatter{}.Format(&buf, result, valueobject.Power655))
+	assert.Contains(t, buf.String(), "Wheel:              3if4 over 01 - 05 - 07 - 09 - 12 - 23 - 41 - 55")
+	assert.Contains(t, buf.String(), "  Ticket  2:        01 - 05 - 07 - 09 - 41 - 55")
+
+	buf.Reset()
+	require.NoError(t, envFormatter{}.Format(&buf, result, valueobject.Power655))
+	env := parseEnv(t, buf.String())
+	assert.Equal(t, "3if4", env["PREDICTION_WHEEL_GUARANTEE"])
+	assert.Equal(t, "1,5,7,9,12,23,41,55", env["PREDICTION_WHEEL_CANDIDATES"])
+	assert.Equal(t, "1,5,9,12,23,41:1,5,7,9,41,55", env["PREDICTION_WHEEL_TICKETS"])
+}
+
+func TestTextFormatter_ShowsCandidateSets(t *testing.T) {
+	result := newTestResult(t)
+	result.Prediction.CandidateSets = []entity.CandidateSet{
+		{Numbers: []int{1, 5, 9, 23, 41, 55}, Confidence: 0.8},
+		{Numbers: []int{1, 5, 9, 12, 23, 41}, Confidence: 0.65},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, textFormatter{}.Format(&buf, result, valueobject.Power655))
+	assert.Contains(t, buf.String(), "Candidate Sets:")
+	assert.Contains(t, buf.String(), "   2. 01 - 05 - 09 - 12 - 23 - 41  confidence  65.0%")
+
+	buf.Reset()
+	require.NoError(t, envFormatter{}.Format(&buf, result, valueobject.Power655))
+	env := parseEnv(t, buf.String())
+	assert.Equal(t, "1,5,9,23,41,55:1,5,9,12,23,41", env["PREDICTION_SETS"])
+	assert.Equal(t, "0.80,0.65", env["PREDICTION_SETS_CONFIDENCE"])
+}
+
+func TestTextFormatter_ShowsCore(t *testing.T) {
+	result := newTestResult(t)
+	result.Prediction.Core = []entity.CoreNumber{
+		{Number: 23, Confidence: 1},
+		{Number: 5, Confidence: 0.75},
+		{Number: 41, Confidence: 0.5},
+		{Number: 9, Confidence: 0.5},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, textFormatter{}.Format(&buf, result, valueobject.Power655))
+	out := buf.String()
+	assert.NotContains(t, out, "Predicted Numbers")
+	assert.Contains(t, out, "  23  confidence 100.0%")
+	assert.Contains(t, out, "  05  confidence  75.0%")
+	assert.Contains(t, out, "Pick the other 2 yourself")
+
+	buf.Reset()
+	require.NoError(t, envFormatter{}.Format(&buf, result, valueobject.Power655))
+	env := parseEnv(t, buf.String())
+	assert.Equal(t, "23,5,41,9", env["PREDICTION_CORE"])
+	assert.Equal(t, "1.00,0.75,0.50,0.50", env["PREDICTION_CORE_CONFIDENCE"])
+}
+
+func TestTextFormatter_ShowsBlend(t *testing.T) {
+	result := newTestResult(t)
+	result.Prediction.Metadata = map[string]string{
+		algorithm.MetadataBlend:      "mine",
+		algorithm.MetadataBlendMine:  "7,21",
+		algorithm.MetadataBlendVoted: "3,12,30,41",
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, textFormatter{}.Format(&buf, result, valueobject.Power655))
+	assert.Contains(t, buf.String(), "Blend:              yours 07 - 21 + ensemble 03 - 12 - 30 - 41")
+
+	buf.Reset()
+	require.NoError(t, envFormatter{}.Format(&buf, result, valueobject.Power655))
+	assert.Equal(t, "7,21", parseEnv(t, buf.String())["PREDICTION_BLEND_MINE"])
+}
+
+func TestFormatters_ShowVersionStamp(t *testing.T) {
+	result := newTestResult(t)
+	result.Prediction.Provenance = &entity.Provenance{ToolVersion: "1.2.0", ConfigHash: "3f9c2a71d04b8e65"}
+
+	var buf bytes.Buffer
+	require.NoError(t, textFormatter{}.Format(&buf, result, valueobject.Power655))
+	assert.Contains(t, buf.String(), "Version:          1.2.0 (config 3f9c2a71d04b8e65)")
+
+	buf.Reset()
+	require.NoError(t, envFormatter{}.Format(&buf, result, valueobject.Power655))
+	vars := parseEnv(t, buf.String())
+	assert.Equal(t, "1.2.0", vars["PREDICTION_TOOL_VERSION"])
+	assert.Equal(t, "3f9c2a71d04b8e65", vars["PREDICTION_CONFIG_HASH"])
+}
+
+func TestNewOutputFormatter(t *testing.T) {
+	formatter, err := newOutputFormatter("ENV")
+	require.NoError(t, err)
+	assert.True(t, formatter.MachineReadable())
+
+	formatter, err = newOutputFormatter("text")
+	require.NoError(t, err)
+	assert.False(t, formatter.MachineReadable())
+
+	_, err = newOutputFormatter("yaml")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "env, text")
+}
+
+func TestShellQuote(t *testing.T) {
+	assert.Equal(t, "1,5,9", shellQuote("1,5,9"))
+	assert.Equal(t, "''", shellQuote(""))
+	assert.Equal(t, "'a b'", shellQuote("a b"))
+	assert.Equal(t, `'it'\''s'`, shellQuote("it's"))
+}
@@ -0,0 +1,99 @@
+package predictor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/adapter/scraper"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+var parsePDFJSON bool
+
+var parsePDFCmd = &cobra.Command{
+	Use:   "parse-pdf <draw-result.pdf>...",
+	Short: "Parse official draw result PDFs",
+	Long: `Parses official draw result PDFs, such as those downloaded by the pdf scraper
+backend into scraper.pdf.cache_dir, and prints each draw: its winning numbers
+and every prize tier's winners and value.
+
+The PDF's text is read directly; scanned PDFs with no text are read with
+tesseract in scraper.pdf.ocr_language (pdftoppm and tesseract must be
+installed). Nothing is saved.
+
+With --json the draws are printed as JSON, for UIs and scripts.`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  runParsePDF,
+}
+
+func init() {
+	parsePDFCmd.Flags().BoolVar(&parsePDFJSON, "json", false, "Print the draws as JSON")
+	rootCmd.AddCommand(parsePDFCmd)
+}
+
+func runParsePDF(cmd *cobra.Command, args []string) {
+	// Keep stdout clean for JSON
+	var status io.Writer = os.Stdout
+	logOutput := "stdout"
+	if parsePDFJSON {
+		status = os.Stderr
+		logOutput = "stderr"
+	}
+	cfg, ctx, shutdown := loadConfigAndLogger(status, logOutput)
+	defer shutdown()
+
+	gt, err := valueobject.ParseGameType(gameType)
+	if err != nil {
+		logger.Fatal("Invalid game type", zap.Error(err))
+	}
+
+	parser := scraper.NewDrawResultPDFParser(cfg.Scraper.PDF.OCRLanguage)
+	draws := make([]*entity.Draw, 0, len(args))
+	failed := 0
+	for _, path := range args {
+		draw, err := parser.ParseFile(ctx, gt, path)
+		if err != nil {
+			logger.Error("Failed to parse draw result PDF", zap.String("file", path), zap.Error(err))
+			failed++
+			continue
+		}
+		draws = append(draws, draw)
+	}
+
+	if parsePDFJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(draws); err != nil {
+			logger.Fatal("Failed to write draws", zap.Error(err))
+		}
+	} else {
+		for _, draw := range draws {
+			printDrawResult(os.Stdout, draw)
+		}
+	}
+
+	if failed > 0 {
+		logger.Fatal("Some draw result PDFs didn't parse", zap.Int("failed", failed))
+	}
+}
+
+// printDrawResult prints a draw and its prize tiers, one per line
+func printDrawResult(w io.Writer, draw *entity.Draw) {
+	fmt.Fprintf(w, "\n📄 %s draw #%d on %s: %s\n",
+		draw.GameType, draw.DrawNumber, draw.DrawDate.Format("2006-01-02"), draw.Numbers)
+	if len(draw.Prizes) == 0 {
+		fmt.Fprintf(w, "  No prize table found\n")
+		return
+	}
+
+	fmt.Fprintf(w, "  %-10s %8s %20s\n", "PRIZE", "WINNERS", "VALUE (VND)")
+	for _, prize := range draw.Prizes {
+		fmt.Fprintf(w, "  %-10s %8d %20.0f\n", prize.Tier, prize.Winners, prize.Amount)
+	}
+}
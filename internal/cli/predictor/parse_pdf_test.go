@@ -0,0 +1,35 @@
+package predictor
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func TestPrintDrawResult(t *testing.T) {
+	draw := &entity.Draw{
+		GameType:   valueobject.Power655,
+		DrawNumber: 1285,
+		Numbers:    valueobject.MustNewNumbers([]int{5, 12, 19, 27, 33, 41}),
+		DrawDate:   time.Date(2025, 12, 23, 0, 0, 0, 0, time.UTC),
+		Prizes: []entity.PrizeResult{
+			{Tier: entity.PrizeJackpot, Winners: 0, Amount: 45123456789},
+			{Tier: entity.PrizeThird, Winners: 17245, Amount: 50000},
+		},
+	}
+
+	var out bytes.Buffer
+	printDrawResult(&out, draw)
+	assert.Contains(t, out.String(), "📄 POWER_6_55 draw #1285 on 2025-12-23: "+draw.Numbers.String())
+	assert.Contains(t, out.String(), "  jackpot           0          45123456789\n")
+	assert.Contains(t, out.String(), "  third         17245                50000\n")
+
+	out.Reset()
+	draw.Prizes = nil
+	printDrawResult(&out, draw)
+	assert.Contains(t, out.String(), "No prize table found")
+}
@@ -0,0 +1,540 @@
+package predictor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tool_predict/internal/application/port"
+	"github.com/tool_predict/internal/application/usecase"
+	"github.com/tool_predict/internal/cli/wiring"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/repository"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/adapter/grpc/client"
+	"github.com/tool_predict/internal/infrastructure/adapter/storage"
+	"github.com/tool_predict/internal/infrastructure/config"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"github.com/tool_predict/pkg/algorithm"
+	"github.com/tool_predict/pkg/analytics"
+	"go.uber.org/zap"
+)
+
+// version is recorded in prediction provenance; binaries set it with SetVersion
+var version = "1.0.0"
+
+var (
+	cfgFile        string
+	gameType       string
+	verbose        bool
+	maxDraws       int
+	outputFormat   string
+	logCSV         string
+	mine           []int
+	blend          bool
+	betType        string
+	core           int
+	fromDraw       int
+	toDraw         int
+	autoStrategy   bool
+	tickets        int
+	wheel          string
+	wheelGuarantee string
+	count          int
+)
+
+var rootCmd = &cobra.Command{
+	Use:   "predictor",
+	Short: "Vietlott lottery prediction tool",
+	Long:  `A CLI tool that predicts Vietlott lottery numbers using ensemble algorithms.`,
+	Run:   runPredict,
+}
+
+var predictCmd = &cobra.Command{
+	Use:   "predict",
+	Short: "Generate a prediction",
+	Run:   runPredict,
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVarP(&cfgFile, "config", "c", "./configs/config.dev.yaml", "Config file path")
+	rootCmd.PersistentFlags().StringVarP(&gameType, "game-type", "g", "MEGA_6_45", "Game type (MEGA_6_45 or POWER_6_55, aliases like mega, 6/55 accepted)")
+	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Verbose output")
+	rootCmd.PersistentFlags().IntVarP(&maxDraws, "draws", "d", 30, "Number of latest draws to use for prediction (default: 30)")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output-format", "o", "text",
+		fmt.Sprintf("Prediction output format (%s)", strings.Join(outputFormatNames(), ", ")))
+	rootCmd.PersistentFlags().StringVar(&logCSV, "log-csv", "", "Append each prediction as a row to this CSV file")
+
+	// Prediction flags, shared by the root command and predict
+	for _, cmd := range []*cobra.Command{rootCmd, predictCmd} {
+		cmd.Flags().IntSliceVar(&mine, "mine", nil, "Your own numbers to keep in the ticket, e.g. 7,21 (requires --blend)")
+		cmd.Flags().BoolVar(&blend, "blend", false, "Fix the --mine numbers and let the ensemble fill the remaining slots")
+		cmd.Flags().IntVar(&core, "core", 0, "Suggest only this many best ranked numbers (1-5) with their confidence and leave the rest to you")
+		cmd.Flags().IntVar(&fromDraw, "from", 0, "Train only on stored draws numbered from this one (--draws still caps to the latest in range)")
+		cmd.Flags().IntVar(&toDraw, "to", 0, "Train only on stored draws numbered up to this one")
+		cmd.Flags().BoolVar(&autoStrategy, "auto-strategy", false, "Vote with the strategy that did best in the latest ensemble backtests (backtester voting)")
+		cmd.Flags().StringVar(&betType, "bet-type", "single", "Bet to pick numbers for: single, or a bao bet like bao8 covering every line of its pool")
+		cmd.Flags().IntVar(&count, "count", 0, fmt.Sprintf("Also suggest this many ranked lines (up to %d), the predicted line first, each with a confidence", algorithm.MaxCandidateSets))
+		cmd.Flags().StringVar(&wheel, "wheel", "", "Also suggest a wheel of tickets over the best ranked numbers: abbreviated (requires --tickets)")
+		cmd.Flags().IntVar(&tickets, "tickets", 0, "Most tickets the --wheel may use; it covers as many ranked numbers as fit")
+		cmd.Flags().StringVar(&wheelGuarantee, "wheel-guarantee", string(algorithm.Wheel3If4),
+			fmt.Sprintf("What the wheel guarantees: %s (3 matched if 4 drawn are covered) or %s", algorithm.Wheel3If4, algorithm.Wheel4If5))
+	}
+
+	rootCmd.AddCommand(predictCmd)
+}
+
+// Command returns the predictor's root command, for mounting in another CLI
+func Command() *cobra.Command {
+	return rootCmd
+}
+
+// SetVersion sets the version recorded in prediction provenance
+func SetVersion(v string) {
+	version = v
+}
+
+// Execute runs the predictor CLI, exiting with status 1 when a command fails
+func Execute() {
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		logger.Exit(1)
+	}
+}
+
+func runPredict(cmd *cobra.Command, args []string) {
+	formatter, err := newOutputFormatter(outputFormat)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		logger.Exit(1)
+	}
+
+	// Keep stdout clean for machine-readable formats
+	var status io.Writer = os.Stdout
+	logOutput := "stdout"
+	if formatter.MachineReadable() {
+		status = os.Stderr
+		logOutput = "stderr"
+	}
+
+	// Load configuration and initialize logger
+	cfg, ctx, shutdown := loadConfigAndLogger(status, logOutput)
+	defer shutdown()
+
+	configHash, err := cfg.Hash()
+	if err != nil {
+		logger.Warn("Failed to hash config for provenance", zap.Error(err))
+	}
+
+	logger.Info("Starting predictor application",
+		zap.String("version", version),
+		zap.String("config_hash", configHash),
+		zap.String("environment", cfg.App.Environment),
+	)
+
+	// Parse game type
+	gt, err := valueobject.ParseGameType(gameType)
+	if err != nil {
+		logger.Fatal("Invalid game type", zap.Error(err))
+	}
+
+	if err := validateBlendFlags(gt, mine, blend); err != nil {
+		logger.Fatal("Invalid blend options", zap.Error(err))
+	}
+
+	if core > 0 && blend {
+		logger.Fatal("--core can't be combined with --blend")
+	}
+
+	guarantee, err := validateWheelFlags(wheel, tickets, wheelGuarantee)
+	if err != nil {
+		logger.Fatal("Invalid wheel options", zap.Error(err))
+	}
+
+	bt, err := valueobject.ParseBetType(betType)
+	if err == nil {
+		err = bt.ValidateFor(gt)
+	}
+	if err != nil {
+		logger.Fatal("Invalid bet type", zap.Error(err))
+	}
+
+	// Initialize components
+
+	// Initialize storage
+	drawStorage, err := wiring.NewDrawRepository(cfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize draw storage", zap.Error(err))
+	}
+
+	predictionStorage, err := wiring.NewPredictionRepository(cfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize prediction storage", zap.Error(err))
+	}
+
+	// Initialize scraper
+	vietlottScraper, _, err := wiring.NewScraper(cfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize scraper", zap.Error(err))
+	}
+
+	ensemble := newEnsembleFromConfig(ctx, cfg, gt, drawStorage, predictionStorage, ensembleOptions{
+		autoStrategy: autoStrategy,
+		core:         core,
+		betType:      bt,
+		wheel:        guarantee,
+		tickets:      tickets,
+		count:        count,
+	})
+
+	// Initialize gRPC client
+	var grpcClient port.PredictionService
+	if cfg.GRPC.TooPredict.Address != "" {
+		grpcClient, err = client.NewTooPredictClient(cfg.GRPC.TooPredict.Address)
+		if err != nil {
+			logger.Warn("Failed to create gRPC client, predictions will not be sent",
+				zap.Error(err),
+			)
+			grpcClient = nil
+		}
+	}
+
+	// Initialize use case
+	predictUseCase := usecase.NewPredictUseCase(
+		drawStorage,
+		predictionStorage,
+		ensemble,
+		vietlottScraper,
+		grpcClient,
+	)
+	predictUseCase.EnableCache(cfg.Ensemble.CacheSize)
+	predictUseCase.SetProvenance(version, configHash)
+	predictUseCase.SetNotifier(wiring.NewPredictionNotifier(cfg))
+
+	// Execute prediction
+	fmt.Fprintf(status, "\n🎯 Generating prediction for %s...\n", gt)
+	if fromDraw > 0 || toDraw > 0 {
+		fmt.Fprintf(status, "📊 Using up to %d latest stored draws numbered %s\n\n", maxDraws, formatDrawRange(fromDraw, toDraw))
+	} else {
+		fmt.Fprintf(status, "📊 Using %d latest draws by date\n\n", maxDraws)
+	}
+
+	result, err := predictUseCase.Execute(ctx, usecase.PredictRequest{
+		GameType: gt,
+		MaxDraws: maxDraws,
+		Mine:     mine,
+		FromDraw: fromDraw,
+		ToDraw:   toDraw,
+	})
+	if err != nil {
+		logger.Fatal("Prediction failed", zap.Error(err))
+	}
+
+	// Display results
+	if err := formatter.Format(os.Stdout, result, gt); err != nil {
+		logger.Fatal("Failed to write prediction output", zap.Error(err))
+	}
+
+	if logCSV != "" {
+		if err := appendPredictionCSV(logCSV, result); err != nil {
+			logger.Fatal("Failed to append prediction to CSV log", zap.Error(err))
+		}
+		fmt.Fprintf(status, "\n📝 Prediction appended to %s\n", logCSV)
+	}
+
+	fmt.Fprintf(status, "\n✅ Prediction completed in %v\n", result.Duration)
+}
+
+// ensembleOptions are the per-run ensemble settings that come from flags
+// rather than the config
+type ensembleOptions struct {
+	autoStrategy bool                // Pick the voting strategy from stored backtests
+	core         int                 // Only suggest this many numbers; 0 for a full line
+	betType      valueobject.BetType // Bao bet to pick a pool for
+	wheel        algorithm.WheelGuarantee
+	tickets      int // Most tickets of the wheel; 0 for no wheel
+	count        int // Ranked candidate sets to suggest; 0 for none
+}
+
+// newEnsembleFromConfig builds the ensemble for gameType from the config,
+// exiting on invalid settings
+func newEnsembleFromConfig(
+	ctx context.Context,
+	cfg *config.Config,
+	gt valueobject.GameType,
+	drawStorage repository.DrawRepository,
+	predictionStorage repository.PredictionRepository,
+	opts ensembleOptions,
+) *algorithm.Ensemble {
+	// Initialize algorithm registry
+	registry := newRegistryFromConfig(cfg, gt)
+
+	logger.Info("Algorithms registered",
+		zap.Int("count", registry.Count()),
+	)
+
+	// Initialize ensemble
+	votingStrategy := algorithm.VotingStrategy(cfg.Ensemble.VotingStrategy)
+	if opts.autoStrategy {
+		backtestStorage, err := storage.NewBacktestJSONStorage(cfg.Storage.JSON.BasePath)
+		if err != nil {
+			logger.Fatal("Failed to initialize backtest storage", zap.Error(err))
+		}
+		votingStrategy = selectVotingStrategy(ctx, backtestStorage, gt, votingStrategy)
+	}
+	if votingStrategy == algorithm.PerNumberWeighted {
+		backtestStorage, err := storage.NewBacktestJSONStorage(cfg.Storage.JSON.BasePath)
+		if err != nil {
+			logger.Fatal("Failed to initialize backtest storage", zap.Error(err))
+		}
+		loadPerNumberWeights(ctx, registry, backtestStorage, gt)
+	}
+	ensemble := wiring.NewEnsemble(cfg, registry, votingStrategy)
+	if votingStrategy == algorithm.Stacking {
+		backtestStorage, err := storage.NewBacktestJSONStorage(cfg.Storage.JSON.BasePath)
+		if err != nil {
+			logger.Fatal("Failed to initialize backtest storage", zap.Error(err))
+		}
+		ensemble.SetStackingModel(loadStackingModel(ctx, registry, backtestStorage, gt))
+	}
+	if err := ensemble.SetCoreCount(opts.core); err != nil {
+		logger.Fatal("Invalid core count", zap.Error(err))
+	}
+	if err := ensemble.SetBetType(opts.betType); err != nil {
+		logger.Fatal("Invalid bet type", zap.Error(err))
+	}
+	if err := ensemble.SetWheel(opts.wheel, opts.tickets); err != nil {
+		logger.Fatal("Invalid wheel", zap.Error(err))
+	}
+	if err := ensemble.SetCandidateSetCount(opts.count); err != nil {
+		logger.Fatal("Invalid candidate set count", zap.Error(err))
+	}
+	if cfg.Ensemble.AgreementScores {
+		loadAgreementScores(ctx, ensemble, predictionStorage, drawStorage, gt)
+	}
+
+	return ensemble
+}
+
+// loadConfigAndLogger loads the --config file and sets up the logger,
+// exiting on failure. Errors are reported to status. The returned context is
+// cancelled on SIGINT or SIGTERM; callers run on it and defer the returned
+// shutdown function to flush the logs.
+func loadConfigAndLogger(status io.Writer, logOutput string) (*config.Config, context.Context, func()) {
+	cfg, err := config.Load(cfgFile)
+	if err != nil {
+		fmt.Fprintf(status, "Failed to load config: %v\n", err)
+		logger.Exit(1)
+	}
+
+	logLevel := cfg.App.LogLevel
+	if verbose {
+		logLevel = "debug"
+	}
+	ctx, shutdown, err := logger.Setup(logLevel, logOutput)
+	if err != nil {
+		fmt.Fprintf(status, "Failed to initialize logger: %v\n", err)
+		logger.Exit(1)
+	}
+
+	if cfg.App.GamesFile != "" {
+		games, err := config.LoadGames(cfg.App.GamesFile)
+		if err != nil {
+			logger.Fatal("Failed to load game rules", zap.String("file", cfg.App.GamesFile), zap.Error(err))
+		}
+		valueobject.UseGameRegistry(games)
+	}
+
+	return cfg, ctx, shutdown
+}
+
+// formatDrawRange describes a --from/--to range, where 0 leaves an end open
+func formatDrawRange(from, to int) string {
+	switch {
+	case to == 0:
+		return fmt.Sprintf("#%d and later", from)
+	case from == 0:
+		return fmt.Sprintf("up to #%d", to)
+	default:
+		return fmt.Sprintf("#%d-#%d", from, to)
+	}
+}
+
+// validateBlendFlags checks that --mine and --blend are used together and
+// that the numbers can be blended into a gameType ticket
+func validateBlendFlags(gameType valueobject.GameType, mine []int, blend bool) error {
+	switch {
+	case len(mine) > 0 && !blend:
+		return fmt.Errorf("--mine requires --blend")
+	case blend && len(mine) == 0:
+		return fmt.Errorf("--blend requires --mine")
+	case !blend:
+		return nil
+	}
+	return algorithm.ValidateMine(gameType, mine)
+}
+
+// validateWheelFlags checks that --wheel and --tickets are used together
+// and returns the --wheel-guarantee
+func validateWheelFlags(wheel string, tickets int, guarantee string) (algorithm.WheelGuarantee, error) {
+	switch {
+	case wheel != "" && wheel != "abbreviated":
+		return "", fmt.Errorf("unknown wheel %q (expected abbreviated)", wheel)
+	case wheel != "" && tickets < 1:
+		return "", fmt.Errorf("--wheel requires --tickets of at least 1")
+	case wheel == "" && tickets != 0:
+		return "", fmt.Errorf("--tickets requires --wheel")
+	}
+	return algorithm.ParseWheelGuarantee(guarantee)
+}
+
+// newRegistryFromConfig registers the algorithms the config enables for gameType
+func newRegistryFromConfig(cfg *config.Config, gameType valueobject.GameType) *algorithm.Registry {
+	registry, err := wiring.NewRegistry(cfg, gameType)
+	if err != nil {
+		logger.Fatal("Failed to set up algorithms", zap.Error(err))
+	}
+	return registry
+}
+
+// loadPerNumberWeights learns each registered algorithm's per-number weights
+// from its latest stored backtest. Algorithms without detailed backtest
+// results keep voting with their plain weight.
+func loadPerNumberWeights(
+	ctx context.Context,
+	registry *algorithm.Registry,
+	backtests repository.BacktestRepository,
+	gameType valueobject.GameType,
+) {
+	for _, name := range registry.GetNames() {
+		results, err := backtests.FindByAlgorithm(ctx, name, gameType, 1)
+		if err != nil || len(results) == 0 || len(results[0].DetailedResults) == 0 {
+			logger.Warn("No detailed backtest for per-number weights, using plain weight",
+				zap.String("algorithm", name),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		weights := algorithm.PerNumberReliability(gameType, results[0].DetailedResults)
+		if err := registry.SetPerNumberWeights(name, weights); err != nil {
+			logger.Warn("Failed to set per-number weights", zap.String("algorithm", name), zap.Error(err))
+			continue
+		}
+		logger.Info("Loaded per-number weights",
+			zap.String("algorithm", name),
+			zap.String("backtest_id", results[0].ID),
+			zap.Int("predictions", len(results[0].DetailedResults)),
+		)
+	}
+}
+
+// loadStackingModel trains the stacking meta-model on the latest stored
+// backtest of each registered algorithm. Without detailed backtest results it
+// returns nil, leaving stacking to vote like weighted voting.
+func loadStackingModel(
+	ctx context.Context,
+	registry *algorithm.Registry,
+	backtests repository.BacktestRepository,
+	gameType valueobject.GameType,
+) *algorithm.StackingModel {
+	matches := make(map[string][]entity.PredictionMatch)
+	for _, name := range registry.GetNames() {
+		results, err := backtests.FindByAlgorithm(ctx, name, gameType, 1)
+		if err != nil || len(results) == 0 || len(results[0].DetailedResults) == 0 {
+			logger.Warn("No detailed backtest for the stacking model, algorithm adds nothing",
+				zap.String("algorithm", name),
+				zap.Error(err),
+			)
+			continue
+		}
+		matches[name] = results[0].DetailedResults
+	}
+
+	model, err := algorithm.TrainStackingModel(gameType, matches)
+	if err != nil {
+		logger.Warn("Failed to train stacking model, using weighted voting", zap.Error(err))
+		return nil
+	}
+	for name, weight := range model.Weights {
+		logger.Info("Learned stacking coefficient",
+			zap.String("algorithm", name),
+			zap.Float64("coefficient", weight),
+		)
+	}
+	logger.Info("Trained stacking model",
+		zap.Float64("bias", model.Bias),
+		zap.Int("samples", model.Samples),
+	)
+	return model
+}
+
+// selectVotingStrategy returns the voting strategy that did best in the
+// latest ensemble backtests for gameType, or fallback when there are none
+func selectVotingStrategy(
+	ctx context.Context,
+	backtests repository.BacktestRepository,
+	gameType valueobject.GameType,
+	fallback algorithm.VotingStrategy,
+) algorithm.VotingStrategy {
+	choice, err := usecase.SelectVotingStrategy(ctx, backtests, gameType)
+	if err != nil {
+		logger.Warn("No ensemble backtests to pick a voting strategy from, using configured one",
+			zap.String("strategy", string(fallback)),
+			zap.Error(err),
+		)
+		return fallback
+	}
+
+	logger.Info("Auto-selected voting strategy",
+		zap.String("strategy", string(choice.Strategy)),
+		zap.String("configured", string(fallback)),
+		zap.Float64("average_matches", choice.AverageMatches),
+		zap.String("backtest_id", choice.Backtest.ID),
+		zap.Time("backtest_end", choice.Backtest.TestPeriod.EndDate),
+	)
+	return choice.Strategy
+}
+
+// agreementHistoryLimit caps how many stored ensembles and draws are read to
+// score algorithm agreement
+const agreementHistoryLimit = 1000
+
+// loadAgreementScores scores each algorithm by how often its stored
+// predictions matched the draw they targeted and uses the scores as
+// confidence multipliers. Without stored outcomes confidence is unchanged.
+func loadAgreementScores(
+	ctx context.Context,
+	ensemble *algorithm.Ensemble,
+	predictions repository.PredictionRepository,
+	draws repository.DrawRepository,
+	gameType valueobject.GameType,
+) {
+	ensembles, err := predictions.FindLatestEnsembles(ctx, gameType, agreementHistoryLimit)
+	if err != nil {
+		logger.Warn("No stored predictions for agreement scores", zap.Error(err))
+		return
+	}
+	history, err := draws.FindLatest(ctx, gameType, agreementHistoryLimit)
+	if err != nil {
+		logger.Warn("No stored draws for agreement scores", zap.Error(err))
+		return
+	}
+
+	index := analytics.NewAgreementIndex(ensembles, history)
+	scores := index.Scores(gameType)
+	if err := ensemble.SetConfidenceMultipliers(scores); err != nil {
+		logger.Warn("Failed to apply agreement scores", zap.Error(err))
+		return
+	}
+	for name, score := range scores {
+		logger.Info("Loaded agreement score",
+			zap.String("algorithm", name),
+			zap.Float64("score", score),
+			zap.Int("outcomes", index.Outcomes(name, gameType)),
+		)
+	}
+}
@@ -0,0 +1,242 @@
+package predictor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/application/usecase"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/adapter/storage"
+	"github.com/tool_predict/internal/infrastructure/config"
+	"github.com/tool_predict/internal/testutil/fixtures"
+	"github.com/tool_predict/pkg/algorithm"
+)
+
+func TestNewRegistryFromConfig_DisablesAlgorithmPerGame(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+algorithms:
+  enabled:
+    - "frequency_analysis"
+    - "pattern_analysis"
+  frequency_analysis:
+    weight: 1.0
+  pattern_analysis:
+    weight: 0.8
+  power_6_55:
+    disabled: ["pattern_analysis"]
+`), 0644))
+
+	cfg, err := config.Load(path)
+	require.NoError(t, err)
+
+	// The game section must not be mistaken for an algorithm's settings
+	assert.NotContains(t, cfg.Algorithms.Configs, "power_6_55")
+
+	power := newRegistryFromConfig(cfg, valueobject.Power655)
+	assert.ElementsMatch(t, []string{"frequency_analysis"}, power.GetNames())
+
+	mega := newRegistryFromConfig(cfg, valueobject.Mega645)
+	assert.ElementsMatch(t, []string{"frequency_analysis", "pattern_analysis"}, mega.GetNames())
+}
+
+func TestNewRegistryFromConfig_AppliesRecencyHalfLife(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+algorithms:
+  recency_half_life: 12
+  enabled:
+    - "frequency_analysis"
+    - "pattern_analysis"
+  frequency_analysis:
+    weight: 1.0
+  pattern_analysis:
+    weight: 0.8
+`), 0644))
+
+	cfg, err := config.Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, 12.0, cfg.Algorithms.RecencyHalfLife)
+	assert.NotContains(t, cfg.Algorithms.Configs, "recency_half_life")
+
+	registry := newRegistryFromConfig(cfg, valueobject.Mega645)
+	frequency, err := registry.Get("frequency_analysis")
+	require.NoError(t, err)
+
+	prediction, err := frequency.Predict(context.Background(), valueobject.Mega645,
+		fixtures.Draws(valueobject.Mega645, 30))
+	require.NoError(t, err)
+	assert.Equal(t, "12", prediction.Metadata["recency_half_life"])
+}
+
+func TestNewRegistryFromConfig_AppliesHotColdThresholds(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+algorithms:
+  enabled:
+    - "hot_cold_analysis"
+  hot_cold_analysis:
+    weight: 1.2
+    hot_threshold: 30
+    cold_threshold: 8
+`), 0644))
+
+	cfg, err := config.Load(path)
+	require.NoError(t, err)
+
+	registry := newRegistryFromConfig(cfg, valueobject.Mega645)
+	algo, err := registry.Get("hot_cold_analysis")
+	require.NoError(t, err)
+	hotCold, ok := algo.(*algorithm.HotColdAnalyzer)
+	require.True(t, ok)
+	assert.Equal(t, 30, hotCold.GetHotThreshold())
+	assert.Equal(t, 8, hotCold.GetColdThreshold())
+
+	// Unset thresholds keep the defaults
+	cfg.Algorithms.Configs["hot_cold_analysis"] = config.AlgorithmDetails{Weight: 1.2}
+	algo, err = newRegistryFromConfig(cfg, valueobject.Mega645).Get("hot_cold_analysis")
+	require.NoError(t, err)
+	assert.Equal(t, 20, algo.(*algorithm.HotColdAnalyzer).GetHotThreshold())
+	assert.Equal(t, 15, algo.(*algorithm.HotColdAnalyzer).GetColdThreshold())
+}
+
+func TestNewRegistryFromConfig_AppliesMonteCarloSettings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+algorithms:
+  enabled:
+    - "monte_carlo"
+  monte_carlo:
+    weight: 1.0
+    simulations: 500
+    seed: 42
+`), 0644))
+
+	cfg, err := config.Load(path)
+	require.NoError(t, err)
+
+	algo, err := newRegistryFromConfig(cfg, valueobject.Mega645).Get("monte_carlo")
+	require.NoError(t, err)
+	monteCarlo, ok := algo.(*algorithm.MonteCarloAnalyzer)
+	require.True(t, ok)
+	assert.Equal(t, 500, monteCarlo.GetSimulations())
+
+	prediction, err := monteCarlo.Predict(context.Background(), valueobject.Mega645,
+		fixtures.Draws(valueobject.Mega645, 30))
+	require.NoError(t, err)
+	assert.Equal(t, "42", prediction.Metadata["seed"])
+}
+
+func TestNewRegistryFromConfig_AppliesBayesianPrior(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+algorithms:
+  enabled:
+    - "bayesian"
+  bayesian:
+    weight: 1.0
+    prior_alpha: 2.5
+`), 0644))
+
+	cfg, err := config.Load(path)
+	require.NoError(t, err)
+
+	algo, err := newRegistryFromConfig(cfg, valueobject.Mega645).Get("bayesian")
+	require.NoError(t, err)
+	bayesian, ok := algo.(*algorithm.BayesianAnalyzer)
+	require.True(t, ok)
+	assert.Equal(t, 2.5, bayesian.GetPriorAlpha())
+}
+
+func TestSelectVotingStrategy_UsesBestBacktest(t *testing.T) {
+	ctx := context.Background()
+	backtests, err := storage.NewBacktestJSONStorage(t.TempDir())
+	require.NoError(t, err)
+
+	// No ensemble backtests yet: keep the configured strategy
+	assert.Equal(t, algorithm.WeightedVoting, selectVotingStrategy(ctx, backtests, valueobject.Mega645, algorithm.WeightedVoting))
+
+	end := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	for strategy, matches := range map[algorithm.VotingStrategy][]int{
+		algorithm.WeightedVoting:     {1, 1, 0},
+		algorithm.MajorityVoting:     {0, 1, 1},
+		algorithm.ConfidenceWeighted: {2, 3, 1},
+	} {
+		result, err := entity.NewBacktestResult(valueobject.Mega645, usecase.EnsembleBacktestName(strategy),
+			valueobject.MustNewDateRange(end.AddDate(0, 0, -10), end), len(matches))
+		require.NoError(t, err)
+		for _, count := range matches {
+			result.AddMatchResult(entity.PredictionMatch{MatchCount: count})
+		}
+		require.NoError(t, backtests.Save(ctx, result))
+	}
+
+	assert.Equal(t, algorithm.ConfidenceWeighted, selectVotingStrategy(ctx, backtests, valueobject.Mega645, algorithm.WeightedVoting))
+}
+
+func TestLoadStackingModel(t *testing.T) {
+	ctx := context.Background()
+	backtests, err := storage.NewBacktestJSONStorage(t.TempDir())
+	require.NoError(t, err)
+
+	registry := algorithm.NewRegistry()
+	require.NoError(t, registry.Register(algorithm.NewFrequencyAnalyzer(1), 1))
+	require.NoError(t, registry.Register(algorithm.NewPatternAnalyzer(1), 1))
+
+	// No backtests yet: stacking votes like weighted voting
+	assert.Nil(t, loadStackingModel(ctx, registry, backtests, valueobject.Mega645))
+
+	end := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	result, err := entity.NewBacktestResult(valueobject.Mega645, "frequency_analysis",
+		valueobject.MustNewDateRange(end.AddDate(0, 0, -10), end), 3)
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		result.AddMatchResult(entity.PredictionMatch{
+			PredictedNumbers: valueobject.MustNewNumbers([]int{1, 2, 3, 4, 5, 6}),
+			ActualNumbers:    valueobject.MustNewNumbers([]int{1, 2, 3, 40, 41, 42}),
+			MatchCount:       3,
+			ActualDrawDate:   end.AddDate(0, 0, -3*i),
+		})
+	}
+	require.NoError(t, backtests.Save(ctx, result))
+
+	model := loadStackingModel(ctx, registry, backtests, valueobject.Mega645)
+	require.NotNil(t, model)
+	assert.Equal(t, 3*45, model.Samples)
+	assert.Contains(t, model.Weights, "frequency_analysis")
+	assert.NotContains(t, model.Weights, "pattern_analysis")
+}
+
+func TestValidateBlendFlags(t *testing.T) {
+	assert.NoError(t, validateBlendFlags(valueobject.Mega645, nil, false))
+	assert.NoError(t, validateBlendFlags(valueobject.Mega645, []int{7, 21}, true))
+
+	assert.ErrorContains(t, validateBlendFlags(valueobject.Mega645, []int{7, 21}, false), "--blend")
+	assert.ErrorContains(t, validateBlendFlags(valueobject.Mega645, nil, true), "--mine")
+	assert.Error(t, validateBlendFlags(valueobject.Mega645, []int{50}, true))
+}
+
+func TestValidateWheelFlags(t *testing.T) {
+	guarantee, err := validateWheelFlags("", 0, "3if4")
+	require.NoError(t, err)
+	assert.Equal(t, algorithm.Wheel3If4, guarantee)
+
+	guarantee, err = validateWheelFlags("abbreviated", 10, "4if5")
+	require.NoError(t, err)
+	assert.Equal(t, algorithm.Wheel4If5, guarantee)
+
+	_, err = validateWheelFlags("abbreviated", 0, "3if4")
+	assert.ErrorContains(t, err, "--tickets")
+	_, err = validateWheelFlags("", 10, "3if4")
+	assert.ErrorContains(t, err, "--wheel")
+	_, err = validateWheelFlags("full", 10, "3if4")
+	assert.Error(t, err)
+	_, err = validateWheelFlags("abbreviated", 10, "5if6")
+	assert.Error(t, err)
+}
@@ -0,0 +1,107 @@
+package predictor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tool_predict/internal/cli/wiring"
+	"github.com/tool_predict/internal/domain/repository"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+var pruneEvery time.Duration
+
+var pruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove predictions older than the retention period",
+	Long: `Removes stored predictions and ensemble predictions generated more than
+storage.prediction_retention_days ago from the configured storage, logging
+each one. JSON storage moves them to the trash, where they can be restored
+until empty-trash is run; S3 storage deletes them. With a retention of 0, the
+default, nothing is pruned.
+
+With --every, keeps running and prunes on that interval until interrupted, for
+long-running deployments.`,
+	Args: cobra.NoArgs,
+	Run:  runPrune,
+}
+
+func init() {
+	pruneCmd.Flags().DurationVar(&pruneEvery, "every", 0, "Prune again on this interval until interrupted, e.g. 24h (default: once)")
+	rootCmd.AddCommand(pruneCmd)
+}
+
+func runPrune(cmd *cobra.Command, args []string) {
+	cfg, ctx, shutdown := loadConfigAndLogger(os.Stdout, "stdout")
+	defer shutdown()
+
+	retentionDays := cfg.Storage.PredictionRetentionDays
+	if retentionDays < 0 {
+		logger.Fatal("Invalid prediction retention", zap.Int("days", retentionDays))
+	}
+	if retentionDays == 0 {
+		logger.Info("Prediction retention disabled, nothing to prune (set storage.prediction_retention_days)")
+		return
+	}
+	if pruneEvery < 0 {
+		logger.Fatal("Invalid prune interval", zap.Duration("every", pruneEvery))
+	}
+
+	predictionStorage, err := wiring.NewPredictionRepository(cfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize prediction storage", zap.Error(err))
+	}
+
+	prune := func() {
+		cutoff, err := pruneOldPredictions(ctx, predictionStorage, retentionDays, time.Now())
+		if err != nil {
+			logger.Error("Failed to prune predictions", zap.Error(err))
+			return
+		}
+		fmt.Printf("🗑️  Pruned predictions generated before %s (older than %d days)\n",
+			cutoff.Format("2006-01-02 15:04"), retentionDays)
+	}
+
+	prune()
+	if pruneEvery == 0 {
+		return
+	}
+
+	logger.Info("Pruning predictions on a schedule", zap.Duration("every", pruneEvery))
+	ticker := time.NewTicker(pruneEvery)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Stopped pruning predictions")
+			return
+		case <-ticker.C:
+			prune()
+		}
+	}
+}
+
+// pruneOldPredictions removes the predictions generated more than
+// retentionDays before now through the repository's DeleteOld and returns the
+// cutoff used
+func pruneOldPredictions(
+	ctx context.Context,
+	predictions repository.PredictionRepository,
+	retentionDays int,
+	now time.Time,
+) (time.Time, error) {
+	cutoff := now.AddDate(0, 0, -retentionDays)
+	if err := predictions.DeleteOld(ctx, cutoff); err != nil {
+		return cutoff, err
+	}
+
+	logger.Info("Pruned old predictions",
+		zap.Int("retention_days", retentionDays),
+		zap.Time("cutoff", cutoff),
+	)
+	return cutoff, nil
+}
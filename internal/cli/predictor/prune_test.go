@@ -0,0 +1,96 @@
+package predictor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/repository"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/adapter/storage"
+)
+
+func TestPruneOldPredictions_KeepsRetentionPeriod(t *testing.T) {
+	ctx := context.Background()
+	store, err := storage.NewPredictionJSONStorage(t.TempDir())
+	require.NoError(t, err)
+
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	numbers := valueobject.MustNewNumbers([]int{3, 9, 17, 22, 30, 41})
+	save := func(age time.Duration, gameType valueobject.GameType) (*entity.Prediction, *entity.EnsemblePrediction) {
+		generatedAt := now.Add(-age)
+		pred, err := entity.NewPrediction(gameType, "frequency_analysis", numbers, 0.5, generatedAt.Add(24*time.Hour))
+		require.NoError(t, err)
+		pred.GeneratedAt = generatedAt
+		require.NoError(t, store.Save(ctx, pred))
+
+		ensemble, err := entity.NewEnsemblePrediction(gameType, []*entity.Prediction{pred}, numbers, "weighted", nil)
+		require.NoError(t, err)
+		ensemble.GeneratedAt = generatedAt
+		ensemble.ForDate = generatedAt.Add(24 * time.Hour)
+		require.NoError(t, store.SaveEnsemble(ctx, ensemble))
+		return pred, ensemble
+	}
+
+	oldMega, oldMegaEnsemble := save(45*24*time.Hour, valueobject.Mega645)
+	oldPower, _ := save(31*24*time.Hour, valueobject.Power655)
+	recent, recentEnsemble := save(29*24*time.Hour, valueobject.Mega645)
+
+	cutoff, err := pruneOldPredictions(ctx, store, 30, now)
+	require.NoError(t, err)
+	assert.Equal(t, now.AddDate(0, 0, -30), cutoff)
+
+	// Older than 30 days: gone
+	_, err = store.FindByID(ctx, oldMega.ID)
+	assert.Error(t, err)
+	_, err = store.FindByID(ctx, oldPower.ID)
+	assert.Error(t, err)
+	_, err = store.FindEnsembleByID(ctx, oldMegaEnsemble.ID)
+	assert.Error(t, err)
+
+	// Newer: kept
+	_, err = store.FindByID(ctx, recent.ID)
+	assert.NoError(t, err)
+	_, err = store.FindEnsembleByID(ctx, recentEnsemble.ID)
+	assert.NoError(t, err)
+
+	// Trashed, so still recoverable
+	require.NoError(t, store.Restore(ctx, oldMega.ID))
+
+	// Running again prunes the restored one
+	_, err = pruneOldPredictions(ctx, store, 30, now)
+	require.NoError(t, err)
+	_, err = store.FindByID(ctx, oldMega.ID)
+	assert.Error(t, err)
+}
+
+// deleteOldRecorder is a non-JSON prediction repository recording the cutoffs
+// DeleteOld is called with
+type deleteOldRecorder struct {
+	repository.PredictionRepository
+	cutoffs []time.Time
+	err     error
+}
+
+func (r *deleteOldRecorder) DeleteOld(ctx context.Context, before time.Time) error {
+	r.cutoffs = append(r.cutoffs, before)
+	return r.err
+}
+
+func TestPruneOldPredictions_UsesRepositoryDeleteOld(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	store := &deleteOldRecorder{}
+
+	cutoff, err := pruneOldPredictions(context.Background(), store, 7, now)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{now.AddDate(0, 0, -7)}, store.cutoffs)
+	assert.Equal(t, now.AddDate(0, 0, -7), cutoff)
+
+	store.err = errors.New("bucket unavailable")
+	_, err = pruneOldPredictions(context.Background(), store, 7, now)
+	assert.ErrorIs(t, err, store.err)
+}
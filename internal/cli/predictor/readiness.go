@@ -0,0 +1,95 @@
+package predictor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/tool_predict/internal/application/usecase"
+	"github.com/tool_predict/internal/cli/wiring"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+var readinessJSON bool
+
+var readinessCmd = &cobra.Command{
+	Use:   "readiness",
+	Short: "Check whether enough draws are stored for a full-ensemble prediction",
+	Long: `Lists every enabled algorithm of the game type with its minimum draw count and
+whether the stored draws satisfy it. When some algorithm is not satisfied, the
+report tells how many more draws are needed.
+
+With --json the report is printed as JSON, for UIs and scripts.`,
+	Args: cobra.NoArgs,
+	Run:  runReadiness,
+}
+
+func init() {
+	readinessCmd.Flags().BoolVar(&readinessJSON, "json", false, "Print the report as JSON")
+	rootCmd.AddCommand(readinessCmd)
+}
+
+func runReadiness(cmd *cobra.Command, args []string) {
+	// Keep stdout clean for JSON
+	var status io.Writer = os.Stdout
+	logOutput := "stdout"
+	if readinessJSON {
+		status = os.Stderr
+		logOutput = "stderr"
+	}
+	cfg, ctx, shutdown := loadConfigAndLogger(status, logOutput)
+	defer shutdown()
+
+	gt, err := valueobject.ParseGameType(gameType)
+	if err != nil {
+		logger.Fatal("Invalid game type", zap.Error(err))
+	}
+
+	drawStorage, err := wiring.NewDrawRepository(cfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize draw storage", zap.Error(err))
+	}
+
+	report, err := usecase.NewReadinessUseCase(drawStorage, newRegistryFromConfig(cfg, gt)).
+		Readiness(ctx, gt)
+	if err != nil {
+		logger.Fatal("Failed to check readiness", zap.Error(err))
+	}
+
+	if readinessJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			logger.Fatal("Failed to write readiness report", zap.Error(err))
+		}
+		return
+	}
+	printReadinessReport(os.Stdout, report)
+}
+
+// printReadinessReport prints one line per algorithm and a verdict
+func printReadinessReport(w io.Writer, report *usecase.ReadinessReport) {
+	fmt.Fprintf(w, "\n🚦 Readiness for %s (%d draws stored)\n", report.GameType, report.DrawCount)
+	if len(report.Algorithms) == 0 {
+		fmt.Fprintf(w, "  No algorithms enabled\n")
+		return
+	}
+
+	for _, algo := range report.Algorithms {
+		if algo.Satisfied {
+			fmt.Fprintf(w, "  ✅ %-22s needs %4d draws\n", algo.Name, algo.MinDraws)
+		} else {
+			fmt.Fprintf(w, "  ❌ %-22s needs %4d draws, %d more to go\n", algo.Name, algo.MinDraws, algo.DrawsNeeded)
+		}
+	}
+
+	if report.Ready {
+		fmt.Fprintf(w, "\nReady for a full-ensemble prediction\n")
+	} else {
+		fmt.Fprintf(w, "\nNot ready: %d more draw(s) needed for a full-ensemble prediction\n", report.DrawsNeeded)
+	}
+}
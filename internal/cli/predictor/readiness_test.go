@@ -0,0 +1,35 @@
+package predictor
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tool_predict/internal/application/usecase"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func TestPrintReadinessReport(t *testing.T) {
+	report := &usecase.ReadinessReport{
+		GameType:    valueobject.Mega645,
+		DrawCount:   60,
+		DrawsNeeded: 40,
+		Algorithms: []usecase.AlgorithmReadiness{
+			{Name: "frequency_analysis", MinDraws: 8, Satisfied: true},
+			{Name: "pattern_analysis", MinDraws: 100, DrawsNeeded: 40},
+		},
+	}
+
+	var out bytes.Buffer
+	printReadinessReport(&out, report)
+	assert.Contains(t, out.String(), "MEGA_6_45 (60 draws stored)")
+	assert.Contains(t, out.String(), "✅ frequency_analysis     needs    8 draws")
+	assert.Contains(t, out.String(), "❌ pattern_analysis       needs  100 draws, 40 more to go")
+	assert.Contains(t, out.String(), "Not ready: 40 more draw(s) needed")
+
+	out.Reset()
+	report.Ready = true
+	report.Algorithms = report.Algorithms[:1]
+	printReadinessReport(&out, report)
+	assert.Contains(t, out.String(), "Ready for a full-ensemble prediction")
+}
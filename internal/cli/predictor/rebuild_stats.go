@@ -0,0 +1,91 @@
+package predictor
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/tool_predict/internal/application/usecase"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/adapter/storage"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+var rebuildStatsCmd = &cobra.Command{
+	Use:   "rebuild-stats",
+	Short: "Recompute algorithm stats from stored backtests",
+	Long: `Scans every stored backtest result of the game type, aggregates accuracy per
+algorithm and rewrites the algorithm stats files.
+
+Weights and active flags of existing stats are kept. Stats of algorithms with no
+stored backtest are reset to zero.`,
+	Args: cobra.NoArgs,
+	Run:  runRebuildStats,
+}
+
+func init() {
+	rootCmd.AddCommand(rebuildStatsCmd)
+}
+
+func runRebuildStats(cmd *cobra.Command, args []string) {
+	cfg, ctx, shutdown := loadConfigAndLogger(os.Stdout, "stdout")
+	defer shutdown()
+
+	gt, err := valueobject.ParseGameType(gameType)
+	if err != nil {
+		logger.Fatal("Invalid game type", zap.Error(err))
+	}
+
+	backtestStorage, err := storage.NewBacktestJSONStorage(cfg.Storage.JSON.BasePath)
+	if err != nil {
+		logger.Fatal("Failed to initialize backtest storage", zap.Error(err))
+	}
+
+	statsStorage, err := storage.NewStatsJSONStorage(cfg.Storage.JSON.BasePath)
+	if err != nil {
+		logger.Fatal("Failed to initialize stats storage", zap.Error(err))
+	}
+
+	weights := make(map[string]float64, len(cfg.Algorithms.Configs))
+	for name, details := range cfg.Algorithms.Configs {
+		weights[name] = details.Weight
+	}
+
+	stats, err := usecase.NewRebuildStatsUseCase(backtestStorage, statsStorage).Execute(ctx,
+		usecase.RebuildStatsRequest{
+			GameType: gt,
+			Weights:  weights,
+		})
+	if err != nil {
+		logger.Fatal("Failed to rebuild stats", zap.Error(err))
+	}
+
+	if skipped := backtestStorage.SkippedCorruptFiles(); skipped > 0 {
+		fmt.Fprintf(os.Stdout, "⚠️  %d unreadable backtest file(s) were skipped\n", skipped)
+	}
+	printRebuiltStats(os.Stdout, gt, stats)
+}
+
+// printRebuiltStats prints one line per rebuilt algorithm
+func printRebuiltStats(w io.Writer, gameType valueobject.GameType, stats []*entity.AlgorithmStats) {
+	fmt.Fprintf(w, "\n📈 Rebuilt stats for %s\n", gameType)
+	if len(stats) == 0 {
+		fmt.Fprintf(w, "  No backtests or stats stored\n")
+		return
+	}
+
+	fmt.Fprintf(w, "  %-22s %11s %8s %8s %8s %10s\n", "Algorithm", "Predictions", "3-num", "4-num", "Exact", "Confidence")
+	for _, s := range stats {
+		fmt.Fprintf(w, "  %-22s %11d %7.2f%% %7.2f%% %7.2f%% %9.2f%%\n",
+			s.AlgorithmName,
+			s.TotalPredictions,
+			s.Accuracy3Numbers*100,
+			s.Accuracy4Numbers*100,
+			s.AccuracyExact*100,
+			s.AverageConfidence*100,
+		)
+	}
+}
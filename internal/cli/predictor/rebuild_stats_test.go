@@ -0,0 +1,26 @@
+package predictor
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func TestPrintRebuiltStats(t *testing.T) {
+	stats, err := entity.NewAlgorithmStats("frequency_analysis", valueobject.Mega645, 1.0)
+	require.NoError(t, err)
+	stats.UpdateMetrics(0.2, 0.1, 0.025, 0.65, 40)
+
+	var out bytes.Buffer
+	printRebuiltStats(&out, valueobject.Mega645, []*entity.AlgorithmStats{stats})
+	assert.Contains(t, out.String(), "frequency_analysis")
+	assert.Contains(t, out.String(), "40   20.00%   10.00%    2.50%     65.00%")
+
+	out.Reset()
+	printRebuiltStats(&out, valueobject.Mega645, nil)
+	assert.Contains(t, out.String(), "No backtests or stats stored")
+}
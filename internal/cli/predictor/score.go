@@ -0,0 +1,154 @@
+package predictor
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tool_predict/internal/application/usecase"
+	"github.com/tool_predict/internal/cli/wiring"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+var ticketsFile string
+
+var scoreTicketsCmd = &cobra.Command{
+	Use:   "score-tickets",
+	Short: "Check a CSV of tickets against stored draws",
+	Long: `Reads tickets from a CSV file and prints each ticket's match count and prize tier
+against the stored draw.
+
+Each row holds a draw number followed by the ticket's six numbers:
+
+  draw_number,n1,n2,n3,n4,n5,n6
+  1201,3,9,17,22,30,41
+
+A header row is optional.`,
+	Args: cobra.NoArgs,
+	Run:  runScoreTickets,
+}
+
+func init() {
+	scoreTicketsCmd.Flags().StringVar(&ticketsFile, "file", "", "CSV file of tickets to score")
+	_ = scoreTicketsCmd.MarkFlagRequired("file")
+
+	rootCmd.AddCommand(scoreTicketsCmd)
+}
+
+func runScoreTickets(cmd *cobra.Command, args []string) {
+	cfg, ctx, shutdown := loadConfigAndLogger(os.Stdout, "stdout")
+	defer shutdown()
+
+	gt, err := valueobject.ParseGameType(gameType)
+	if err != nil {
+		logger.Fatal("Invalid game type", zap.Error(err))
+	}
+
+	file, err := os.Open(ticketsFile)
+	if err != nil {
+		logger.Fatal("Failed to open tickets file", zap.Error(err))
+	}
+	defer file.Close()
+
+	tickets, err := parseTicketsCSV(file, gt)
+	if err != nil {
+		logger.Fatal("Failed to parse tickets file", zap.String("file", ticketsFile), zap.Error(err))
+	}
+
+	drawStorage, err := wiring.NewDrawRepository(cfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize draw storage", zap.Error(err))
+	}
+
+	scores, err := usecase.NewScoreTicketsUseCase(drawStorage).Execute(ctx, usecase.ScoreTicketsRequest{
+		GameType: gt,
+		Tickets:  tickets,
+	})
+	if err != nil {
+		logger.Fatal("Failed to score tickets", zap.Error(err))
+	}
+
+	printTicketScores(os.Stdout, scores, gt)
+}
+
+// parseTicketsCSV reads draw_number,n1..n6 rows, skipping an optional header
+func parseTicketsCSV(r io.Reader, gameType valueobject.GameType) ([]usecase.Ticket, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1 // Row lengths are checked below with a clearer error
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	tickets := make([]usecase.Ticket, 0, len(rows))
+	for i, row := range rows {
+		line := i + 1
+		if i == 0 && len(row) > 0 {
+			if _, err := strconv.Atoi(strings.TrimSpace(row[0])); err != nil {
+				continue // Header
+			}
+		}
+
+		if len(row) != 7 {
+			return nil, fmt.Errorf("line %d: expected a draw number and 6 numbers, got %d fields", line, len(row))
+		}
+
+		values := make([]int, len(row))
+		for j, field := range row {
+			values[j], err = strconv.Atoi(strings.TrimSpace(field))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid number %q", line, field)
+			}
+		}
+
+		numbers, err := valueobject.NewNumbersForGame(values[1:], gameType)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		tickets = append(tickets, usecase.Ticket{
+			DrawNumber: values[0],
+			Numbers:    numbers,
+		})
+	}
+
+	if len(tickets) == 0 {
+		return nil, fmt.Errorf("no tickets found")
+	}
+	return tickets, nil
+}
+
+// printTicketScores prints one line per ticket followed by a summary
+func printTicketScores(w io.Writer, scores []usecase.TicketScore, gameType valueobject.GameType) {
+	fmt.Fprintf(w, "\n🎟️  Ticket Results for %s\n", gameType)
+	fmt.Fprintf(w, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+
+	winners := 0
+	for i, score := range scores {
+		if score.Err != nil {
+			fmt.Fprintf(w, "%3d. Draw #%d  %s  ⚠️  draw not available: %v\n",
+				i+1, score.Ticket.DrawNumber, score.Ticket.Numbers, score.Err)
+			continue
+		}
+
+		marker := ""
+		if score.Tier != entity.PrizeNone {
+			winners++
+			marker = " 🏆"
+		}
+		fmt.Fprintf(w, "%3d. Draw #%d  %s  %d matches  %s%s\n",
+			i+1, score.Ticket.DrawNumber, score.Ticket.Numbers, score.Matches, score.Tier, marker)
+	}
+
+	fmt.Fprintf(w, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+	fmt.Fprintf(w, "Winning tickets: %d of %d\n", winners, len(scores))
+}
@@ -0,0 +1,75 @@
+package predictor
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/application/usecase"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/adapter/storage"
+)
+
+func TestScoreTickets_FromCSV(t *testing.T) {
+	ctx := context.Background()
+
+	drawStorage, err := storage.NewJSONStorage(t.TempDir())
+	require.NoError(t, err)
+
+	draw, err := entity.NewDraw(
+		valueobject.Mega645,
+		1201,
+		valueobject.MustNewNumbers([]int{3, 9, 17, 22, 30, 41}),
+		time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC),
+		0,
+		0,
+	)
+	require.NoError(t, err)
+	require.NoError(t, drawStorage.Save(ctx, draw))
+
+	csvData := `draw_number,n1,n2,n3,n4,n5,n6
+1201,3,9,17,22,1,2
+1201,4,5,6,7,8,10
+1202,3,9,17,22,30,41
+`
+	tickets, err := parseTicketsCSV(strings.NewReader(csvData), valueobject.Mega645)
+	require.NoError(t, err)
+	require.Len(t, tickets, 3)
+
+	scores, err := usecase.NewScoreTicketsUseCase(drawStorage).Execute(ctx, usecase.ScoreTicketsRequest{
+		GameType: valueobject.Mega645,
+		Tickets:  tickets,
+	})
+	require.NoError(t, err)
+	require.Len(t, scores, 3)
+
+	// Winning ticket
+	require.NoError(t, scores[0].Err)
+	assert.Equal(t, 4, scores[0].Matches)
+	assert.Equal(t, entity.PrizeSecond, scores[0].Tier)
+
+	// Losing ticket
+	require.NoError(t, scores[1].Err)
+	assert.Equal(t, 0, scores[1].Matches)
+	assert.Equal(t, entity.PrizeNone, scores[1].Tier)
+
+	// Draw not stored
+	assert.Error(t, scores[2].Err)
+
+	var out bytes.Buffer
+	printTicketScores(&out, scores, valueobject.Mega645)
+	assert.Contains(t, out.String(), "Winning tickets: 1 of 3")
+}
+
+func TestParseTicketsCSV_RejectsBadRows(t *testing.T) {
+	_, err := parseTicketsCSV(strings.NewReader("1201,3,9,17,22,30\n"), valueobject.Mega645)
+	assert.ErrorContains(t, err, "line 1")
+
+	_, err = parseTicketsCSV(strings.NewReader("1201,3,9,17,22,30,50\n"), valueobject.Mega645)
+	assert.ErrorContains(t, err, "out of range")
+}
@@ -0,0 +1,129 @@
+package predictor
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/adapter/storage"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+var (
+	scrapeAuditLimit  int
+	scrapeAuditFailed bool
+)
+
+var scrapeAuditCmd = &cobra.Command{
+	Use:   "scrape-audit",
+	Short: "Show the log of results page crawls",
+	Long: `Lists the latest crawls of each game's results pages from the scrape audit log
+(scraper.vietlott.audit): when each ran, whether it used the API or the web
+page, the HTTP status, how many items the page had, how many parsed into draws
+and why the others didn't.
+
+A run of crawls finding items but no draws usually means the selectors or the
+API format drifted. --failed shows only crawls that failed or hit parse errors.
+
+Every game is listed unless --game-type is given.`,
+	Args: cobra.NoArgs,
+	Run:  runScrapeAudit,
+}
+
+func init() {
+	scrapeAuditCmd.Flags().IntVar(&scrapeAuditLimit, "limit", 20, "Crawls to show per game")
+	scrapeAuditCmd.Flags().BoolVar(&scrapeAuditFailed, "failed", false, "Only show crawls that failed or had parse errors")
+	rootCmd.AddCommand(scrapeAuditCmd)
+}
+
+func runScrapeAudit(cmd *cobra.Command, args []string) {
+	cfg, ctx, shutdown := loadConfigAndLogger(os.Stdout, "stdout")
+	defer shutdown()
+
+	gameTypes := valueobject.AllGameTypes()
+	if cmd.Flags().Changed("game-type") {
+		gt, err := valueobject.ParseGameType(gameType)
+		if err != nil {
+			logger.Fatal("Invalid game type", zap.Error(err))
+		}
+		gameTypes = []valueobject.GameType{gt}
+	}
+
+	auditStorage, err := storage.NewScrapeAuditJSONLStorage(cfg.Storage.JSON.BasePath)
+	if err != nil {
+		logger.Fatal("Failed to initialize scrape audit log", zap.Error(err))
+	}
+
+	for _, gt := range gameTypes {
+		limit := scrapeAuditLimit
+		if scrapeAuditFailed {
+			limit = math.MaxInt
+		}
+		audits, err := auditStorage.FindLatest(ctx, gt, limit)
+		if err != nil {
+			logger.Fatal("Failed to read scrape audit log", zap.String("game_type", string(gt)), zap.Error(err))
+		}
+		if scrapeAuditFailed {
+			audits = troubledAudits(audits, scrapeAuditLimit)
+		}
+		printScrapeAudits(os.Stdout, gt, audits)
+	}
+}
+
+// troubledAudits returns up to limit audits of crawls that failed or had
+// parse errors, keeping their order
+func troubledAudits(audits []*entity.ScrapeAudit, limit int) []*entity.ScrapeAudit {
+	troubled := make([]*entity.ScrapeAudit, 0)
+	for _, audit := range audits {
+		if len(troubled) == limit {
+			break
+		}
+		if !audit.Succeeded() || len(audit.ParseErrors) > 0 {
+			troubled = append(troubled, audit)
+		}
+	}
+	return troubled
+}
+
+// printScrapeAudits prints a game's audits one crawl per row, with each
+// crawl's error and parse errors beneath it
+func printScrapeAudits(w io.Writer, gameType valueobject.GameType, audits []*entity.ScrapeAudit) {
+	fmt.Fprintf(w, "\n🧾 Scrape audit for %s\n", gameType)
+	if len(audits) == 0 {
+		fmt.Fprintf(w, "  No crawls recorded\n")
+		return
+	}
+
+	fmt.Fprintf(w, "  %-16s %-6s %-6s %5s %5s %5s  %s\n", "STARTED", "SOURCE", "STATUS", "TRIES", "ITEMS", "DRAWS", "RESULT")
+	for _, audit := range audits {
+		status := "-"
+		if audit.StatusCode != 0 {
+			status = fmt.Sprintf("%d", audit.StatusCode)
+		}
+
+		result := "✅"
+		switch {
+		case audit.Error != "":
+			result = "❌ failed"
+		case audit.DrawsFound == 0:
+			result = "❌ no draws"
+		case len(audit.ParseErrors) > 0:
+			result = fmt.Sprintf("⚠️  %d parse error(s)", len(audit.ParseErrors))
+		}
+
+		fmt.Fprintf(w, "  %-16s %-6s %-6s %5d %5d %5d  %s\n",
+			audit.Timestamp.Format("2006-01-02 15:04"), audit.Source, status,
+			audit.Attempts, audit.ItemsFound, audit.DrawsFound, result)
+		if audit.Error != "" {
+			fmt.Fprintf(w, "      %s\n", audit.Error)
+		}
+		for _, parseErr := range audit.ParseErrors {
+			fmt.Fprintf(w, "      %s\n", parseErr)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package predictor
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func newTestAudits() []*entity.ScrapeAudit {
+	started := time.Date(2026, 3, 1, 18, 30, 0, 0, time.UTC)
+
+	clean := entity.NewScrapeAudit(valueobject.Mega645, entity.ScrapeSourceAPI, "https://vietlott.vn/api?page=1")
+	clean.Timestamp = started
+	clean.Attempts, clean.StatusCode, clean.ItemsFound, clean.DrawsFound = 1, 200, 5, 5
+
+	partial := entity.NewScrapeAudit(valueobject.Mega645, entity.ScrapeSourceWeb, "https://vietlott.vn/results")
+	partial.Timestamp = started.Add(-time.Hour)
+	partial.Attempts, partial.StatusCode, partial.ItemsFound, partial.DrawsFound = 1, 200, 3, 1
+	partial.AddParseError(errors.New("row 1: expected 6 numbers, got 5"))
+
+	failed := entity.NewScrapeAudit(valueobject.Mega645, entity.ScrapeSourceAPI, "https://vietlott.vn/api?page=1")
+	failed.Timestamp = started.Add(-2 * time.Hour)
+	failed.Attempts = 3
+	failed.Finish(errors.New("failed to fetch from API after 3 attempts: timeout"))
+
+	return []*entity.ScrapeAudit{clean, partial, failed}
+}
+
+func TestPrintScrapeAudits(t *testing.T) {
+	var out bytes.Buffer
+	printScrapeAudits(&out, valueobject.Mega645, newTestAudits())
+
+	assert.Contains(t, out.String(), "🧾 Scrape audit for MEGA_6_45")
+	assert.Contains(t, out.String(), "  2026-03-01 18:30 api    200        1     5     5  ✅\n")
+	assert.Contains(t, out.String(), "  2026-03-01 17:30 web    200        1     3     1  ⚠️  1 parse error(s)\n"+
+		"      row 1: expected 6 numbers, got 5\n")
+	assert.Contains(t, out.String(), "  2026-03-01 16:30 api    -          3     0     0  ❌ failed\n"+
+		"      failed to fetch from API after 3 attempts: timeout\n")
+
+	out.Reset()
+	printScrapeAudits(&out, valueobject.Power655, nil)
+	assert.Contains(t, out.String(), "No crawls recorded")
+}
+
+func TestTroubledAudits(t *testing.T) {
+	audits := newTestAudits()
+
+	troubled := troubledAudits(audits, 10)
+	assert.Equal(t, audits[1:], troubled)
+
+	assert.Len(t, troubledAudits(audits, 1), 1)
+}
@@ -0,0 +1,81 @@
+package predictor
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/adapter/scraper"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+var scrapeTestCmd = &cobra.Command{
+	Use:   "scrape-test",
+	Short: "Check the web scraper's selectors against the live results page",
+	Long: `Fetches the game's results page once and reports how many rows matched the
+draw row selectors, how many of them parsed into valid draws and why the others
+didn't, and prints the first parsed draw. Nothing is saved.
+
+Run it when Vietlott changes its layout: zero matched rows or parsed draws
+means the selectors have drifted. Exits with status 1 when no draw parsed.`,
+	Args: cobra.NoArgs,
+	Run:  runScrapeTest,
+}
+
+func init() {
+	rootCmd.AddCommand(scrapeTestCmd)
+}
+
+func runScrapeTest(cmd *cobra.Command, args []string) {
+	cfg, ctx, shutdown := loadConfigAndLogger(os.Stdout, "stdout")
+	defer shutdown()
+
+	gt, err := valueobject.ParseGameType(gameType)
+	if err != nil {
+		logger.Fatal("Invalid game type", zap.Error(err))
+	}
+
+	webScraper := scraper.NewVietlottWebScraper(
+		cfg.Scraper.Vietlott.BaseURL,
+		cfg.Scraper.Vietlott.Timeout,
+		cfg.Scraper.Vietlott.RetryCount,
+		cfg.Scraper.Vietlott.RateLimit,
+	)
+
+	report, err := webScraper.CheckSelectors(ctx, gt)
+	if err != nil {
+		logger.Fatal("Failed to fetch results page", zap.Error(err))
+	}
+
+	printSelectorReport(os.Stdout, report, gt)
+	if len(report.Draws) == 0 {
+		logger.Exit(1)
+	}
+}
+
+// printSelectorReport prints the rows matched and parsed, each row's parse
+// error and the first parsed draw
+func printSelectorReport(w io.Writer, report *scraper.SelectorReport, gameType valueobject.GameType) {
+	fmt.Fprintf(w, "\n🔎 Selector check for %s\n", gameType)
+	fmt.Fprintf(w, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+	fmt.Fprintf(w, "Page:          %s\n", report.URL)
+	fmt.Fprintf(w, "Rows matched:  %d\n", report.RowsMatched)
+	fmt.Fprintf(w, "Valid draws:   %d\n", len(report.Draws))
+
+	for _, rowErr := range report.RowErrors {
+		fmt.Fprintf(w, "  ⚠️  row %d: %v\n", rowErr.Row+1, rowErr.Err)
+	}
+
+	if len(report.Draws) > 0 {
+		draw := report.Draws[0]
+		fmt.Fprintf(w, "\nFirst draw:    #%d on %s: %s\n", draw.DrawNumber, draw.DrawDate.Format("2006-01-02"), draw.Numbers)
+	} else if report.RowsMatched == 0 {
+		fmt.Fprintf(w, "\n❌ No rows matched; the page layout has likely changed\n")
+	} else {
+		fmt.Fprintf(w, "\n❌ No row parsed into a draw; the field selectors have likely changed\n")
+	}
+	fmt.Fprintf(w, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+}
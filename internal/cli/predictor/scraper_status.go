@@ -0,0 +1,128 @@
+package predictor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tool_predict/internal/application/port"
+	"github.com/tool_predict/internal/cli/wiring"
+	"github.com/tool_predict/internal/infrastructure/adapter/scraper"
+	"github.com/tool_predict/internal/infrastructure/adapter/storage"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+var scraperStatusJSON bool
+
+var scraperStatusCmd = &cobra.Command{
+	Use:   "scraper-status",
+	Short: "Show the health of each scraper backend",
+	Long: `Shows how each backend of the fallback scraper (scraper.engine: fallback) has
+fared: its success rate, when it last succeeded and failed and why it last
+failed. The backend that worked last is marked; it's tried first on the next
+fetch, before the rest of scraper.fallback.backends in order.
+
+Nothing is recorded until the fallback engine has run.
+
+With --json the health is printed as JSON, for UIs and scripts.`,
+	Args: cobra.NoArgs,
+	Run:  runScraperStatus,
+}
+
+func init() {
+	scraperStatusCmd.Flags().BoolVar(&scraperStatusJSON, "json", false, "Print the health as JSON")
+	rootCmd.AddCommand(scraperStatusCmd)
+}
+
+func runScraperStatus(cmd *cobra.Command, args []string) {
+	// Keep stdout clean for JSON
+	var status io.Writer = os.Stdout
+	logOutput := "stdout"
+	if scraperStatusJSON {
+		status = os.Stderr
+		logOutput = "stderr"
+	}
+	cfg, _, shutdown := loadConfigAndLogger(status, logOutput)
+	defer shutdown()
+
+	health, err := storage.NewScraperHealthFile(wiring.ScraperHealthPath(cfg)).Load()
+	if err != nil {
+		logger.Fatal("Failed to read scraper health", zap.Error(err))
+	}
+
+	if scraperStatusJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(health); err != nil {
+			logger.Fatal("Failed to write scraper health", zap.Error(err))
+		}
+		return
+	}
+
+	if cfg.Scraper.Engine != scraper.EngineFallback {
+		fmt.Fprintf(os.Stdout, "ℹ️  scraper.engine is %q; health is only tracked by the %s engine\n",
+			cfg.Scraper.Engine, scraper.EngineFallback)
+	}
+	printScraperHealth(os.Stdout, health, cfg.Scraper.Fallback.Backends)
+}
+
+// printScraperHealth prints one row per backend, the configured backends
+// first in order and then any others with recorded health, followed by
+// each backend's last error
+func printScraperHealth(w io.Writer, health *port.ScraperHealth, configured []string) {
+	fmt.Fprintf(w, "\n🩺 Scraper health\n")
+	if len(health.Backends) == 0 {
+		fmt.Fprintf(w, "  No fetches recorded\n")
+		return
+	}
+
+	names := slices.Clone(configured)
+	others := make([]string, 0)
+	for name := range health.Backends {
+		if !slices.Contains(names, name) {
+			others = append(others, name)
+		}
+	}
+	sort.Strings(others)
+	names = append(names, others...)
+
+	fmt.Fprintf(w, "  %-2s %-10s %6s %5s %5s  %-16s %-16s\n", "", "BACKEND", "RATE", "OK", "FAIL", "LAST SUCCESS", "LAST FAILURE")
+	for _, name := range names {
+		marker := ""
+		if name == health.LastWorking {
+			marker = "▶"
+		}
+
+		backend, ok := health.Backends[name]
+		if !ok {
+			fmt.Fprintf(w, "  %-2s %-10s %6s %5d %5d  %-16s %-16s\n", marker, name, "-", 0, 0, "-", "-")
+			continue
+		}
+		fmt.Fprintf(w, "  %-2s %-10s %5.1f%% %5d %5d  %-16s %-16s\n",
+			marker, name, backend.SuccessRate()*100, backend.Successes, backend.Failures,
+			formatHealthTime(backend.LastSuccess), formatHealthTime(backend.LastFailure))
+	}
+
+	if health.LastWorking != "" {
+		fmt.Fprintf(w, "  ▶ worked last, tried first on the next fetch\n")
+	}
+	for _, name := range names {
+		if backend, ok := health.Backends[name]; ok && backend.LastError != "" {
+			fmt.Fprintf(w, "  %s last failed: %s\n", name, backend.LastError)
+		}
+	}
+}
+
+// formatHealthTime formats a success or failure time, "-" if there was none
+func formatHealthTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format("2006-01-02 15:04")
+}
@@ -0,0 +1,37 @@
+package predictor
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tool_predict/internal/application/port"
+)
+
+func TestPrintScraperHealth(t *testing.T) {
+	failed := time.Date(2026, 3, 1, 18, 30, 0, 0, time.UTC)
+	health := &port.ScraperHealth{
+		Backends: map[string]*port.ScraperBackendHealth{
+			"api": {Name: "api", Successes: 1, Failures: 3, LastSuccess: failed.Add(-48 * time.Hour), LastFailure: failed, LastError: "status 503"},
+			"web": {Name: "web", Successes: 4, LastSuccess: failed},
+			"old": {Name: "old", Failures: 1, LastFailure: failed},
+		},
+		LastWorking: "web",
+	}
+
+	var out bytes.Buffer
+	printScraperHealth(&out, health, []string{"api", "web", "chromedp"})
+
+	assert.Contains(t, out.String(), "🩺 Scraper health")
+	assert.Contains(t, out.String(), "     api         25.0%     1     3  2026-02-27 18:30 2026-03-01 18:30\n"+
+		"  ▶  web        100.0%     4     0  2026-03-01 18:30 -               \n"+
+		"     chromedp        -     0     0  -                -               \n"+
+		"     old          0.0%     0     1  -                2026-03-01 18:30\n")
+	assert.Contains(t, out.String(), "api last failed: status 503")
+	assert.Contains(t, out.String(), "▶ worked last")
+
+	out.Reset()
+	printScraperHealth(&out, port.NewScraperHealth(), []string{"api"})
+	assert.Contains(t, out.String(), "No fetches recorded")
+}
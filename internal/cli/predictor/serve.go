@@ -0,0 +1,138 @@
+package predictor
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/tool_predict/internal/application/usecase"
+	"github.com/tool_predict/internal/cli/wiring"
+	"github.com/tool_predict/internal/domain/repository"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/adapter/grpc/server"
+	"github.com/tool_predict/internal/infrastructure/config"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+var servePort int
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve predictions over gRPC",
+	Long: `Serves the PredictionService gRPC API on grpc.server.port until interrupted:
+GeneratePrediction runs the ensemble for a game on demand and stores the
+result, GetPredictionStatus looks up a stored ensemble prediction and
+ListLatestEnsembles lists the most recent ones.
+
+Every game is served with the configured algorithms and voting strategy;
+--draws is the training window when a request doesn't set max_draws. Server
+reflection is registered when grpc.server.enable_reflection is set.`,
+	Args: cobra.NoArgs,
+	Run:  runServe,
+}
+
+func init() {
+	serveCmd.Flags().IntVar(&servePort, "port", 0, "Port to listen on (default: grpc.server.port)")
+	rootCmd.AddCommand(serveCmd)
+}
+
+// ExecuteServe runs the serve command as a standalone CLI, for binaries
+// that only serve gRPC
+func ExecuteServe() {
+	executeAs(serveCmd)
+}
+
+// executeAs runs cmd as if the binary were that subcommand
+func executeAs(cmd *cobra.Command) {
+	rootCmd.SetArgs(append([]string{cmd.Name()}, os.Args[1:]...))
+	Execute()
+}
+
+func runServe(cmd *cobra.Command, args []string) {
+	cfg, ctx, shutdown := loadConfigAndLogger(os.Stdout, "stdout")
+	defer shutdown()
+
+	configHash, err := cfg.Hash()
+	if err != nil {
+		logger.Warn("Failed to hash config for provenance", zap.Error(err))
+	}
+
+	port := servePort
+	if port == 0 {
+		port = cfg.GRPC.Server.Port
+	}
+	if port <= 0 {
+		logger.Fatal("Invalid gRPC server port", zap.Int("port", port))
+	}
+
+	_, predictionStorage, predictUseCases := newServedPredictUseCases(ctx, cfg, configHash)
+	predictors := make(map[valueobject.GameType]server.Predictor, len(predictUseCases))
+	for gt, predictUseCase := range predictUseCases {
+		predictors[gt] = predictUseCase
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		logger.Fatal("Failed to listen", zap.Int("port", port), zap.Error(err))
+	}
+
+	grpcServer := server.NewGRPCServer(
+		server.NewPredictionServer(predictors, predictionStorage, maxDraws),
+		cfg.GRPC.Server.EnableReflection,
+	)
+	go func() {
+		<-ctx.Done()
+		logger.Info("Stopping gRPC server")
+		grpcServer.GracefulStop()
+	}()
+
+	logger.Info("Serving PredictionService over gRPC",
+		zap.String("version", version),
+		zap.String("address", listener.Addr().String()),
+		zap.Bool("reflection", cfg.GRPC.Server.EnableReflection),
+	)
+	fmt.Printf("🚀 Serving predictions over gRPC on %s (Ctrl+C to stop)\n", listener.Addr())
+
+	if err := grpcServer.Serve(listener); err != nil {
+		logger.Fatal("gRPC server failed", zap.Error(err))
+	}
+}
+
+// newServedPredictUseCases sets up the storage and, for every game, a
+// prediction use case with its own configured ensemble, for the servers.
+// Served predictions aren't forwarded to too_predict.
+func newServedPredictUseCases(
+	ctx context.Context,
+	cfg *config.Config,
+	configHash string,
+) (repository.DrawRepository, repository.PredictionRepository, map[valueobject.GameType]*usecase.PredictUseCase) {
+	drawStorage, err := wiring.NewDrawRepository(cfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize draw storage", zap.Error(err))
+	}
+
+	predictionStorage, err := wiring.NewPredictionRepository(cfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize prediction storage", zap.Error(err))
+	}
+
+	vietlottScraper, _, err := wiring.NewScraper(cfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize scraper", zap.Error(err))
+	}
+
+	predictUseCases := make(map[valueobject.GameType]*usecase.PredictUseCase)
+	for _, gt := range valueobject.AllGameTypes() {
+		ensemble := newEnsembleFromConfig(ctx, cfg, gt, drawStorage, predictionStorage, ensembleOptions{
+			betType: valueobject.BetSingle,
+		})
+		predictUseCase := usecase.NewPredictUseCase(drawStorage, predictionStorage, ensemble, vietlottScraper, nil)
+		predictUseCase.EnableCache(cfg.Ensemble.CacheSize)
+		predictUseCase.SetProvenance(version, configHash)
+		predictUseCases[gt] = predictUseCase
+	}
+	return drawStorage, predictionStorage, predictUseCases
+}
@@ -0,0 +1,157 @@
+package predictor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/adapter/rest"
+	"github.com/tool_predict/internal/infrastructure/adapter/storage"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+// apiShutdownTimeout is how long in-flight API requests get to finish on
+// interrupt
+const apiShutdownTimeout = 10 * time.Second
+
+var (
+	serveAPIHost string
+	serveAPIPort int
+)
+
+var serveAPICmd = &cobra.Command{
+	Use:   "serve-api",
+	Short: "Serve draws, predictions and backtests as a JSON HTTP API",
+	Long: `Serves a JSON HTTP API on api.host and api.port until interrupted, for web
+and mobile clients that don't speak gRPC:
+
+  GET  /health
+  GET  /draws/{gameType}?limit=N        latest stored draws, newest first
+  GET  /predictions/{gameType}?limit=N  latest ensemble predictions, newest first
+  POST /predictions/{gameType}          generate a prediction, body {"max_draws": N} optional
+  GET  /backtests?game_type=G&limit=N   latest backtest results
+
+Every game is predicted from the stored draws with the configured algorithms
+and voting strategy; --draws is the training window when a request doesn't
+set max_draws. POST requests must carry api.token (or PREDICTOR_API_TOKEN)
+as a bearer token when one is set, and are refused within
+api.prediction_interval of the last prediction. Browsers on the
+api.cors_origins origins may call it.
+
+The API listens on 127.0.0.1 by default; set api.host to "0.0.0.0" to serve
+other machines, with a token.`,
+	Args: cobra.NoArgs,
+	Run:  runServeAPI,
+}
+
+func init() {
+	serveAPICmd.Flags().StringVar(&serveAPIHost, "host", "", "Interface to listen on (default: api.host)")
+	serveAPICmd.Flags().IntVar(&serveAPIPort, "port", 0, "Port to listen on (default: api.port)")
+	rootCmd.AddCommand(serveAPICmd)
+}
+
+// ExecuteServeAPI runs the serve-api command as a standalone CLI, for
+// binaries that only serve the HTTP API
+func ExecuteServeAPI() {
+	executeAs(serveAPICmd)
+}
+
+func runServeAPI(cmd *cobra.Command, args []string) {
+	cfg, ctx, shutdown := loadConfigAndLogger(os.Stdout, "stdout")
+	defer shutdown()
+
+	configHash, err := cfg.Hash()
+	if err != nil {
+		logger.Warn("Failed to hash config for provenance", zap.Error(err))
+	}
+
+	host := serveAPIHost
+	if host == "" {
+		host = cfg.API.Host
+	}
+	port := serveAPIPort
+	if port == 0 {
+		port = cfg.API.Port
+	}
+	if port <= 0 {
+		logger.Fatal("Invalid API server port", zap.Int("port", port))
+	}
+
+	drawStorage, predictionStorage, predictUseCases := newServedPredictUseCases(ctx, cfg, configHash)
+	predictors := make(map[valueobject.GameType]rest.Predictor, len(predictUseCases))
+	for gt, predictUseCase := range predictUseCases {
+		predictors[gt] = predictUseCase
+	}
+
+	backtestStorage, err := storage.NewBacktestJSONStorage(cfg.Storage.JSON.BasePath)
+	if err != nil {
+		logger.Fatal("Failed to initialize backtest storage", zap.Error(err))
+	}
+
+	api := rest.NewServer(drawStorage, predictionStorage, backtestStorage, predictors, maxDraws)
+	api.SetCORSOrigins(cfg.API.CORSOrigins)
+	api.SetPredictionInterval(cfg.API.PredictionInterval)
+	token := cfg.API.Token
+	if token == "" {
+		token = os.Getenv("PREDICTOR_API_TOKEN")
+	}
+	api.SetToken(token)
+	if token == "" && !isLoopbackHost(host) {
+		logger.Warn("Serving the HTTP API beyond this machine without a token; anyone who can reach it may generate predictions",
+			zap.String("host", host),
+		)
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+	if err != nil {
+		logger.Fatal("Failed to listen", zap.Int("port", port), zap.Error(err))
+	}
+
+	httpServer := &http.Server{
+		Handler:           api.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		<-ctx.Done()
+		logger.Info("Stopping API server")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), apiShutdownTimeout)
+		defer cancel()
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			logger.Warn("API server did not shut down cleanly", zap.Error(err))
+		}
+	}()
+
+	logger.Info("Serving the HTTP API",
+		zap.String("version", version),
+		zap.String("address", listener.Addr().String()),
+		zap.Strings("cors_origins", cfg.API.CORSOrigins),
+		zap.Bool("token_required", token != ""),
+	)
+	fmt.Printf("🚀 Serving the HTTP API on %s (Ctrl+C to stop)\n", listener.Addr())
+
+	if err := httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logger.Fatal("API server failed", zap.Error(err))
+	}
+	// Serve returns as soon as shutdown starts; let in-flight requests finish
+	<-stopped
+}
+
+// isLoopbackHost reports whether host only accepts connections from this
+// machine
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,90 @@
+package predictor
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/tool_predict/internal/infrastructure/adapter/storage"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+var emptyTrashCmd = &cobra.Command{
+	Use:   "empty-trash",
+	Short: "Permanently delete trashed predictions and backtest results",
+	Args:  cobra.NoArgs,
+	Run:   runEmptyTrash,
+}
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore <id>",
+	Short: "Restore a trashed prediction or backtest result",
+	Args:  cobra.ExactArgs(1),
+	Run:   runRestore,
+}
+
+func init() {
+	rootCmd.AddCommand(emptyTrashCmd)
+	rootCmd.AddCommand(restoreCmd)
+}
+
+func runEmptyTrash(cmd *cobra.Command, args []string) {
+	cfg, ctx, shutdown := loadConfigAndLogger(os.Stdout, "stdout")
+	defer shutdown()
+
+	predictionStorage, err := storage.NewPredictionJSONStorage(cfg.Storage.JSON.BasePath)
+	if err != nil {
+		logger.Fatal("Failed to initialize prediction storage", zap.Error(err))
+	}
+	backtestStorage, err := storage.NewBacktestJSONStorage(cfg.Storage.JSON.BasePath)
+	if err != nil {
+		logger.Fatal("Failed to initialize backtest storage", zap.Error(err))
+	}
+
+	predictionsPurged, err := predictionStorage.EmptyTrash(ctx)
+	if err != nil {
+		logger.Fatal("Failed to empty prediction trash", zap.Error(err))
+	}
+	backtestsPurged, err := backtestStorage.EmptyTrash(ctx)
+	if err != nil {
+		logger.Fatal("Failed to empty backtest trash", zap.Error(err))
+	}
+
+	fmt.Printf("🗑️  Purged %d prediction(s) and %d backtest result(s) from trash\n",
+		predictionsPurged, backtestsPurged)
+}
+
+func runRestore(cmd *cobra.Command, args []string) {
+	cfg, ctx, shutdown := loadConfigAndLogger(os.Stdout, "stdout")
+	defer shutdown()
+
+	id := args[0]
+
+	predictionStorage, err := storage.NewPredictionJSONStorage(cfg.Storage.JSON.BasePath)
+	if err != nil {
+		logger.Fatal("Failed to initialize prediction storage", zap.Error(err))
+	}
+	backtestStorage, err := storage.NewBacktestJSONStorage(cfg.Storage.JSON.BasePath)
+	if err != nil {
+		logger.Fatal("Failed to initialize backtest storage", zap.Error(err))
+	}
+
+	predictionErr := predictionStorage.Restore(ctx, id)
+	if predictionErr == nil {
+		fmt.Printf("♻️  Restored prediction %s\n", id)
+		return
+	}
+
+	backtestErr := backtestStorage.Restore(ctx, id)
+	if backtestErr == nil {
+		fmt.Printf("♻️  Restored backtest result %s\n", id)
+		return
+	}
+
+	logger.Fatal("Failed to restore from trash",
+		zap.String("id", id),
+		zap.NamedError("prediction_error", predictionErr),
+		zap.NamedError("backtest_error", backtestErr),
+	)
+}
@@ -0,0 +1,115 @@
+package predictor
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/adapter/storage"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+var validateQuarantine bool
+
+var validateDataCmd = &cobra.Command{
+	Use:   "validate-data",
+	Short: "Check stored draw files for invalid data",
+	Long: `Scans the stored draw files for unreadable JSON, draw numbers stored in more
+than one file, numbers outside the game's range or of the wrong count,
+malformed draw dates and draws filed under another game type's folder. Checks
+every game type unless --game-type is given. Bonus numbers aren't stored, so
+they aren't checked.
+
+With --quarantine each bad file is moved to a .quarantine folder next to it,
+where queries no longer see it. Of several files holding the same draw number
+one valid file is kept. Exits with status 1 when bad files are left in place.`,
+	Args: cobra.NoArgs,
+	Run:  runValidateData,
+}
+
+func init() {
+	validateDataCmd.Flags().BoolVar(&validateQuarantine, "quarantine", false, "Move bad files to a .quarantine folder")
+	rootCmd.AddCommand(validateDataCmd)
+}
+
+func runValidateData(cmd *cobra.Command, args []string) {
+	cfg, _, shutdown := loadConfigAndLogger(os.Stdout, "stdout")
+	defer shutdown()
+
+	gameTypes := valueobject.AllGameTypes()
+	if cmd.Flags().Changed("game-type") {
+		gt, err := valueobject.ParseGameType(gameType)
+		if err != nil {
+			logger.Fatal("Invalid game type", zap.Error(err))
+		}
+		gameTypes = []valueobject.GameType{gt}
+	}
+
+	drawStorage, err := storage.NewJSONStorage(cfg.Storage.JSON.BasePath)
+	if err != nil {
+		logger.Fatal("Failed to initialize draw storage", zap.Error(err))
+	}
+
+	clean := true
+	for _, gt := range gameTypes {
+		issues, err := drawStorage.ValidateFiles(gt)
+		if err != nil {
+			logger.Fatal("Failed to validate draw files", zap.String("game_type", string(gt)), zap.Error(err))
+		}
+
+		quarantined := make(map[string]bool)
+		if validateQuarantine {
+			for _, issue := range issues {
+				if err := drawStorage.QuarantineFile(gt, issue.File); err != nil {
+					logger.Warn("Failed to quarantine draw file", zap.String("file", issue.File), zap.Error(err))
+					continue
+				}
+				quarantined[issue.File] = true
+			}
+		}
+
+		if !printValidationReport(os.Stdout, gt, issues, quarantined) {
+			clean = false
+		}
+	}
+
+	if !clean {
+		logger.Exit(1)
+	}
+}
+
+// printValidationReport prints one line per bad draw file of a game type and
+// reports whether none is left in place
+func printValidationReport(w io.Writer, gameType valueobject.GameType, issues []storage.DrawFileIssue, quarantined map[string]bool) bool {
+	fmt.Fprintf(w, "\n🔎 %s\n", gameType)
+	if len(issues) == 0 {
+		fmt.Fprintf(w, "  ✅ All draw files valid\n")
+		return true
+	}
+
+	left := 0
+	for _, issue := range issues {
+		marker := "❌"
+		if quarantined[issue.File] {
+			marker = "📦"
+		} else {
+			left++
+		}
+		fmt.Fprintf(w, "  %s %s", marker, filepath.Base(issue.File))
+		if issue.DrawNumber > 0 {
+			fmt.Fprintf(w, " (draw #%d)", issue.DrawNumber)
+		}
+		fmt.Fprintf(w, ":")
+		for _, problem := range issue.Problems {
+			fmt.Fprintf(w, " %s", problem)
+		}
+		fmt.Fprintf(w, " - %s\n", issue.Detail)
+	}
+
+	fmt.Fprintf(w, "  %d bad file(s), %d quarantined\n", len(issues), len(issues)-left)
+	return left == 0
+}
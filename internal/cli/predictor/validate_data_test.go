@@ -0,0 +1,40 @@
+package predictor
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/adapter/storage"
+)
+
+func TestPrintValidationReport(t *testing.T) {
+	var out bytes.Buffer
+	assert.True(t, printValidationReport(&out, valueobject.Mega645, nil, nil))
+	assert.Contains(t, out.String(), "All draw files valid")
+
+	issues := []storage.DrawFileIssue{
+		{
+			File:       "/data/draws/mega_6_45/a.json",
+			DrawNumber: 1201,
+			Problems:   []storage.DrawFileProblem{storage.ProblemOutOfRange, storage.ProblemNumberCount},
+			Detail:     "number 50 outside 1-45",
+		},
+		{File: "/data/draws/mega_6_45/b.json", Problems: []storage.DrawFileProblem{storage.ProblemUnreadable}, Detail: "unexpected end of JSON input"},
+	}
+
+	out.Reset()
+	assert.False(t, printValidationReport(&out, valueobject.Mega645, issues, nil))
+	assert.Contains(t, out.String(), "❌ a.json (draw #1201): number_out_of_range wrong_number_count - number 50 outside 1-45")
+	assert.Contains(t, out.String(), "❌ b.json: unreadable - unexpected end of JSON input")
+	assert.Contains(t, out.String(), "2 bad file(s), 0 quarantined")
+
+	out.Reset()
+	assert.True(t, printValidationReport(&out, valueobject.Mega645, issues, map[string]bool{
+		"/data/draws/mega_6_45/a.json": true,
+		"/data/draws/mega_6_45/b.json": true,
+	}))
+	assert.Contains(t, out.String(), "📦 a.json")
+	assert.Contains(t, out.String(), "2 bad file(s), 2 quarantined")
+}
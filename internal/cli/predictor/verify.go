@@ -0,0 +1,112 @@
+package predictor
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/tool_predict/internal/application/usecase"
+	"github.com/tool_predict/internal/cli/wiring"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/adapter/storage"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+var (
+	verifyDrawNumber int
+	verifyDraws      int
+)
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Score stored predictions against the real draws",
+	Long: `Compares the stored ensemble prediction for each of the latest stored draws
+with the numbers actually drawn. Run it after fetch.
+
+The match counts of the final numbers and of every algorithm's own prediction
+are saved with the prediction, and each algorithm's stats are updated with its
+result. A prediction is only scored once, so running verify again is safe.`,
+	Args: cobra.NoArgs,
+	Run:  runVerify,
+}
+
+func init() {
+	verifyCmd.Flags().IntVar(&verifyDrawNumber, "draw", 0, "Draw number to verify (default: the latest --draws draws)")
+	verifyCmd.Flags().IntVar(&verifyDraws, "draws", 1, "Latest stored draws to verify")
+
+	rootCmd.AddCommand(verifyCmd)
+}
+
+func runVerify(cmd *cobra.Command, args []string) {
+	cfg, ctx, shutdown := loadConfigAndLogger(os.Stdout, "stdout")
+	defer shutdown()
+
+	gt, err := valueobject.ParseGameType(gameType)
+	if err != nil {
+		logger.Fatal("Invalid game type", zap.Error(err))
+	}
+
+	drawStorage, err := wiring.NewDrawRepository(cfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize draw storage", zap.Error(err))
+	}
+
+	predictionStorage, err := wiring.NewPredictionRepository(cfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize prediction storage", zap.Error(err))
+	}
+
+	statsStorage, err := storage.NewStatsJSONStorage(cfg.Storage.JSON.BasePath)
+	if err != nil {
+		logger.Fatal("Failed to initialize stats storage", zap.Error(err))
+	}
+
+	verifyUseCase := usecase.NewVerifyPredictionsUseCase(drawStorage, predictionStorage, statsStorage)
+	verifyUseCase.SetNotifier(wiring.NewPredictionNotifier(cfg))
+	verified, err := verifyUseCase.Execute(ctx,
+		usecase.VerifyPredictionsRequest{
+			GameType:   gt,
+			DrawNumber: verifyDrawNumber,
+			Draws:      verifyDraws,
+		})
+	if err != nil {
+		logger.Fatal("Failed to verify predictions", zap.Error(err))
+	}
+
+	printVerifiedPredictions(os.Stdout, gt, verified)
+}
+
+// printVerifiedPredictions prints each draw's prediction outcome with the
+// per-algorithm match counts
+func printVerifiedPredictions(w io.Writer, gameType valueobject.GameType, verified []usecase.VerifiedPrediction) {
+	fmt.Fprintf(w, "\n🎯 Prediction results for %s\n", gameType)
+	fmt.Fprintf(w, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+
+	for _, v := range verified {
+		fmt.Fprintf(w, "Draw #%d (%s): %s\n", v.Draw.DrawNumber, v.Draw.DrawDate.Format("2006-01-02"), v.Draw.Numbers)
+		if v.Prediction == nil {
+			fmt.Fprintf(w, "  No stored prediction for this draw\n\n")
+			continue
+		}
+
+		outcome := v.Prediction.Outcome
+		note := ""
+		if v.Skipped {
+			note = " (verified before)"
+		}
+		fmt.Fprintf(w, "  Predicted %s: %d matched%s\n", v.Prediction.FinalNumbers, outcome.MatchCount, note)
+
+		names := make([]string, 0, len(outcome.AlgorithmMatches))
+		for name := range outcome.AlgorithmMatches {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(w, "    %-22s %d matched\n", name, outcome.AlgorithmMatches[name])
+		}
+		fmt.Fprintf(w, "\n")
+	}
+}
@@ -0,0 +1,39 @@
+package predictor
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/application/usecase"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func TestPrintVerifiedPredictions(t *testing.T) {
+	drawDate := time.Date(2026, 3, 4, 18, 0, 0, 0, time.UTC)
+	draw, err := entity.NewDraw(valueobject.Mega645, 1201, valueobject.MustNewNumbers([]int{3, 9, 17, 22, 30, 41}), drawDate, 0, 0)
+	require.NoError(t, err)
+	earlier, err := entity.NewDraw(valueobject.Mega645, 1200, valueobject.MustNewNumbers([]int{1, 2, 3, 4, 5, 6}), drawDate.AddDate(0, 0, -2), 0, 0)
+	require.NoError(t, err)
+
+	pick, err := entity.NewPrediction(valueobject.Mega645, "frequency_analysis", valueobject.MustNewNumbers([]int{3, 9, 17, 22, 31, 42}), 0.5, drawDate)
+	require.NoError(t, err)
+	ensemble, err := entity.NewEnsemblePrediction(valueobject.Mega645, []*entity.Prediction{pick},
+		valueobject.MustNewNumbers([]int{3, 9, 12, 22, 31, 42}), "weighted", nil)
+	require.NoError(t, err)
+	ensemble.ForDate = time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, ensemble.Verify(draw))
+
+	var out bytes.Buffer
+	printVerifiedPredictions(&out, valueobject.Mega645, []usecase.VerifiedPrediction{
+		{Draw: draw, Prediction: ensemble},
+		{Draw: earlier},
+	})
+	assert.Contains(t, out.String(), "Draw #1201 (2026-03-04)")
+	assert.Contains(t, out.String(), ": 3 matched\n")
+	assert.Contains(t, out.String(), "frequency_analysis     4 matched")
+	assert.Contains(t, out.String(), "No stored prediction for this draw")
+}
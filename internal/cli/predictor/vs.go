@@ -0,0 +1,128 @@
+package predictor
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tool_predict/internal/application/usecase"
+	"github.com/tool_predict/internal/cli/wiring"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"github.com/tool_predict/pkg/algorithm"
+	"go.uber.org/zap"
+)
+
+var (
+	vsAlgorithmA string
+	vsAlgorithmB string
+)
+
+var vsCmd = &cobra.Command{
+	Use:   "vs",
+	Short: "Compare two algorithms on the same draws",
+	Long: `Runs two algorithms on the same stored history and shows their picks side by side,
+the overlap count and each number's rank in both algorithms.
+
+Unless --draws is given, the window is widened to what both algorithms need.`,
+	Run: runVs,
+}
+
+func init() {
+	vsCmd.Flags().StringVar(&vsAlgorithmA, "a", "frequency_analysis", "First algorithm")
+	vsCmd.Flags().StringVar(&vsAlgorithmB, "b", "hot_cold_analysis", "Second algorithm")
+
+	rootCmd.AddCommand(vsCmd)
+}
+
+func runVs(cmd *cobra.Command, args []string) {
+	cfg, ctx, shutdown := loadConfigAndLogger(os.Stdout, "stdout")
+	defer shutdown()
+
+	gt, err := valueobject.ParseGameType(gameType)
+	if err != nil {
+		logger.Fatal("Invalid game type", zap.Error(err))
+	}
+
+	drawStorage, err := wiring.NewDrawRepository(cfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize draw storage", zap.Error(err))
+	}
+
+	// Register just the two contenders, even if disabled in the config
+	registry := algorithm.NewRegistry()
+	window := maxDraws
+	for _, name := range []string{vsAlgorithmA, vsAlgorithmB} {
+		details := cfg.Algorithms.Configs[name]
+		algo, err := wiring.NewAlgorithm(name, details)
+		if err != nil {
+			logger.Fatal("Failed to set up algorithm", zap.String("algorithm", name), zap.Error(err))
+		}
+		if err := registry.RegisterOrUpdate(algo, details.Weight); err != nil {
+			logger.Fatal("Failed to register algorithm", zap.String("algorithm", name), zap.Error(err))
+		}
+		if !cmd.Flags().Changed("draws") && algo.GetMinDraws() > window {
+			window = algo.GetMinDraws()
+		}
+	}
+
+	result, err := usecase.NewCompareUseCase(drawStorage, registry).Execute(ctx, usecase.CompareRequest{
+		GameType:   gt,
+		AlgorithmA: vsAlgorithmA,
+		AlgorithmB: vsAlgorithmB,
+		MaxDraws:   window,
+	})
+	if err != nil {
+		logger.Fatal("Comparison failed", zap.Error(err))
+	}
+
+	displayComparison(result, gt)
+}
+
+func displayComparison(result *usecase.CompareResult, gameType valueobject.GameType) {
+	c := result.Comparison
+
+	fmt.Printf("\n⚖️  %s vs %s (%s, %d draws)\n", c.A.AlgorithmName, c.B.AlgorithmName, gameType, result.DrawsUsed)
+	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+	fmt.Printf("A %-22s %s  (confidence: %.2f%%)\n", c.A.AlgorithmName+":", c.A.Numbers, c.A.Confidence*100)
+	fmt.Printf("B %-22s %s  (confidence: %.2f%%)\n", c.B.AlgorithmName+":", c.B.Numbers, c.B.Confidence*100)
+	fmt.Printf("Overlap: %d/6 %s\n", c.OverlapCount(), formatNumberList(c.Overlap))
+	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+
+	fmt.Printf("\n%-8s %-4s %-4s %-8s %-8s\n", "Number", "A", "B", "Rank A", "Rank B")
+	for _, nc := range c.Numbers {
+		fmt.Printf("%-8s %-4s %-4s %-8s %-8s\n",
+			fmt.Sprintf("%02d", nc.Number),
+			pickMark(nc.InA),
+			pickMark(nc.InB),
+			formatRank(nc.RankA),
+			formatRank(nc.RankB),
+		)
+	}
+}
+
+func formatNumberList(nums []int) string {
+	if len(nums) == 0 {
+		return "(none)"
+	}
+	parts := make([]string, len(nums))
+	for i, n := range nums {
+		parts[i] = fmt.Sprintf("%02d", n)
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+func pickMark(picked bool) string {
+	if picked {
+		return "✓"
+	}
+	return "·"
+}
+
+func formatRank(rank int) string {
+	if rank == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("#%d", rank)
+}
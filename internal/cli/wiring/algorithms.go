@@ -0,0 +1,214 @@
+package wiring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/adapter/storage"
+	"github.com/tool_predict/internal/infrastructure/config"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"github.com/tool_predict/pkg/algorithm"
+	"go.uber.org/zap"
+)
+
+// ErrUnknownAlgorithm is returned for an algorithm name no analyzer answers to
+var ErrUnknownAlgorithm = errors.New("unknown algorithm")
+
+// NewAlgorithm creates the named algorithm with the weight and settings of
+// its config section
+func NewAlgorithm(name string, details config.AlgorithmDetails) (algorithm.Algorithm, error) {
+	var algo algorithm.Algorithm
+	switch name {
+	case "frequency_analysis":
+		algo = algorithm.NewFrequencyAnalyzer(details.Weight)
+	case "hot_cold_analysis":
+		algo = algorithm.NewHotColdAnalyzer(details.Weight)
+	case "pattern_analysis":
+		algo = algorithm.NewPatternAnalyzer(details.Weight)
+	case "random_analysis":
+		algo = algorithm.NewRandomAnalyzer(details.Weight)
+	case "combined_score":
+		algo = algorithm.NewCombinedScoreAnalyzer(details.Weight)
+	case "digit_analysis":
+		algo = algorithm.NewDigitAnalyzer(details.Weight)
+	case "gap_analysis":
+		algo = algorithm.NewGapAnalyzer(details.Weight)
+	case "monte_carlo":
+		algo = algorithm.NewMonteCarloAnalyzer(details.Weight)
+	case "bayesian":
+		algo = algorithm.NewBayesianAnalyzer(details.Weight)
+	default:
+		return nil, fmt.Errorf("%w %q", ErrUnknownAlgorithm, name)
+	}
+
+	if err := applyAlgorithmSettings(algo, details); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return algo, nil
+}
+
+// applyAlgorithmSettings sets the settings details configures on algo. A
+// setting the algorithm has no use for is ignored.
+func applyAlgorithmSettings(algo algorithm.Algorithm, details config.AlgorithmDetails) error {
+	if mc, ok := algo.(algorithm.MinDrawsConfigurable); ok && details.MinDraws != nil {
+		if err := mc.SetMinDraws(*details.MinDraws); err != nil {
+			return fmt.Errorf("invalid min_draws: %w", err)
+		}
+	}
+
+	switch a := algo.(type) {
+	case *algorithm.HotColdAnalyzer:
+		if details.HotThreshold != nil {
+			if err := a.SetHotThreshold(*details.HotThreshold); err != nil {
+				return fmt.Errorf("invalid hot_threshold: %w", err)
+			}
+		}
+		if details.ColdThreshold != nil {
+			if err := a.SetColdThreshold(*details.ColdThreshold); err != nil {
+				return fmt.Errorf("invalid cold_threshold: %w", err)
+			}
+		}
+	case *algorithm.CombinedScoreAnalyzer:
+		if details.Alpha != nil {
+			if err := a.SetAlpha(*details.Alpha); err != nil {
+				return fmt.Errorf("invalid alpha: %w", err)
+			}
+		}
+	case *algorithm.MonteCarloAnalyzer:
+		if details.Simulations != nil {
+			if err := a.SetSimulations(*details.Simulations); err != nil {
+				return fmt.Errorf("invalid simulations: %w", err)
+			}
+		}
+		if details.Seed != nil {
+			a.SetSeed(*details.Seed)
+		}
+	case *algorithm.BayesianAnalyzer:
+		if details.PriorAlpha != nil {
+			if err := a.SetPriorAlpha(*details.PriorAlpha); err != nil {
+				return fmt.Errorf("invalid prior_alpha: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// NewRegistry registers the algorithms the config enables for gameType with
+// their configured weights and settings, normalizing the weights when the
+// ensemble asks for it. With ensemble.use_tuned_weights, an algorithm that
+// tuning has given a weight votes with that instead. Unknown algorithm names
+// are skipped with a warning.
+func NewRegistry(cfg *config.Config, gameType valueobject.GameType) (*algorithm.Registry, error) {
+	registry := algorithm.NewRegistry()
+
+	rangeChanges, err := cfg.RangeChangesFor(gameType)
+	if err != nil {
+		return nil, fmt.Errorf("invalid range changes: %w", err)
+	}
+
+	var tuned map[string]float64
+	if cfg.Ensemble.UseTunedWeights {
+		tuned = loadTunedWeights(cfg, gameType)
+	}
+
+	for _, name := range cfg.EnabledAlgorithmsFor(gameType) {
+		details := cfg.Algorithms.Configs[name]
+
+		algo, err := NewAlgorithm(name, details)
+		if errors.Is(err, ErrUnknownAlgorithm) {
+			logger.Warn("Unknown algorithm, skipping", zap.String("algorithm", name))
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if rw, ok := algo.(algorithm.RecencyWeighted); ok && cfg.RecencyHalfLifeFor(name) > 0 {
+			if err := rw.SetRecencyHalfLife(cfg.RecencyHalfLifeFor(name)); err != nil {
+				return nil, fmt.Errorf("%s: invalid recency half-life: %w", name, err)
+			}
+		}
+
+		if pn, ok := algo.(algorithm.PoolNormalized); ok && len(rangeChanges) > 0 {
+			if err := pn.SetRangeChanges(gameType, rangeChanges); err != nil {
+				return nil, fmt.Errorf("%s: invalid range changes: %w", name, err)
+			}
+		}
+
+		weight := details.Weight
+		if tunedWeight, ok := tuned[name]; ok {
+			weight = tunedWeight
+		}
+		if err := registry.Register(algo, weight); err != nil {
+			return nil, fmt.Errorf("failed to register %s: %w", name, err)
+		}
+	}
+
+	if cfg.Ensemble.NormalizeWeights {
+		if err := registry.NormalizeWeights(cfg.Ensemble.WeightTotal); err != nil {
+			return nil, fmt.Errorf("failed to normalize algorithm weights: %w", err)
+		}
+	}
+
+	return registry, nil
+}
+
+// NewEnsemble creates the ensemble voting with strategy over registry and
+// applies the ensemble settings of the config: alternates, tie break,
+// confidence method, minimum voters and minimum algorithm diversity. Invalid
+// settings are logged and left at their defaults. Predicting and backtesting
+// build their ensembles with it so they vote the same way.
+func NewEnsemble(cfg *config.Config, registry *algorithm.Registry, strategy algorithm.VotingStrategy) *algorithm.Ensemble {
+	ensemble := algorithm.NewEnsemble(registry, strategy)
+	if err := ensemble.SetAlternateCount(cfg.Ensemble.Alternates); err != nil {
+		logger.Warn("Invalid alternates count, using default", zap.Error(err))
+	}
+	if err := ensemble.SetTieBreak(algorithm.TieBreak(cfg.Ensemble.TieBreak)); err != nil {
+		logger.Warn("Invalid tie break, using low", zap.Error(err))
+	}
+	if err := ensemble.SetConfidenceMethod(algorithm.ConfidenceMethod(cfg.Ensemble.ConfidenceMethod)); err != nil {
+		logger.Warn("Invalid confidence method, using mean", zap.Error(err))
+	}
+	if err := ensemble.SetMinVoters(cfg.Ensemble.MinVoters); err != nil {
+		logger.Warn("Invalid min voters, not requiring consensus", zap.Error(err))
+	}
+	if err := ensemble.SetMinAlgorithmDiversity(cfg.Ensemble.MinAlgorithmDiversity); err != nil {
+		logger.Warn("Invalid min algorithm diversity, not requiring diversity", zap.Error(err))
+	}
+	return ensemble
+}
+
+// loadTunedWeights returns the weights tuning saved to gameType's algorithm
+// stats, by algorithm. Stats that can't be read are logged and leave every
+// algorithm on its configured weight.
+func loadTunedWeights(cfg *config.Config, gameType valueobject.GameType) map[string]float64 {
+	// Nothing has been tuned, and opening the storage would create it
+	if _, err := os.Stat(filepath.Join(cfg.Storage.JSON.BasePath, "stats")); err != nil {
+		return nil
+	}
+	statsStorage, err := storage.NewStatsJSONStorage(cfg.Storage.JSON.BasePath)
+	if err != nil {
+		logger.Warn("Failed to open algorithm stats, using configured weights", zap.Error(err))
+		return nil
+	}
+	allStats, err := statsStorage.FindByGameType(context.Background(), gameType)
+	if err != nil {
+		logger.Warn("Failed to load tuned weights, using configured weights",
+			zap.String("game_type", string(gameType)),
+			zap.Error(err),
+		)
+		return nil
+	}
+
+	weights := make(map[string]float64)
+	for _, stats := range allStats {
+		if !stats.TunedAt.IsZero() {
+			weights[stats.AlgorithmName] = stats.Weight
+		}
+	}
+	return weights
+}
@@ -0,0 +1,159 @@
+package wiring
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/adapter/storage"
+	"github.com/tool_predict/internal/infrastructure/config"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"github.com/tool_predict/pkg/algorithm"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestNewRegistry_AppliesAlgorithmSettings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+algorithms:
+  enabled:
+    - "hot_cold_analysis"
+    - "gap_analysis"
+    - "astrology"
+  hot_cold_analysis:
+    weight: 1.2
+    hot_threshold: 30
+    min_draws: 40
+  gap_analysis:
+    weight: 0.8
+`), 0644))
+
+	cfg, err := config.Load(path)
+	require.NoError(t, err)
+
+	registry, err := NewRegistry(cfg, valueobject.Mega645)
+	require.NoError(t, err)
+	// The unknown algorithm is skipped
+	assert.ElementsMatch(t, []string{"hot_cold_analysis", "gap_analysis"}, registry.GetNames())
+
+	hotCold, err := registry.Get("hot_cold_analysis")
+	require.NoError(t, err)
+	assert.Equal(t, 40, hotCold.GetMinDraws())
+	assert.Equal(t, 30, hotCold.(*algorithm.HotColdAnalyzer).GetHotThreshold())
+
+	// Unset min_draws keeps the default
+	gap, err := registry.Get("gap_analysis")
+	require.NoError(t, err)
+	assert.Equal(t, 30, gap.GetMinDraws())
+
+	minDraws := 0
+	cfg.Algorithms.Configs["gap_analysis"] = config.AlgorithmDetails{Weight: 0.8, MinDraws: &minDraws}
+	_, err = NewRegistry(cfg, valueobject.Mega645)
+	assert.ErrorContains(t, err, "gap_analysis: invalid min_draws")
+}
+
+func TestNewRegistry_UsesTunedWeights(t *testing.T) {
+	ctx := context.Background()
+	basePath := t.TempDir()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+storage:
+  json:
+    base_path: "`+basePath+`"
+algorithms:
+  enabled:
+    - "hot_cold_analysis"
+    - "gap_analysis"
+  hot_cold_analysis:
+    weight: 1.2
+  gap_analysis:
+    weight: 0.8
+`), 0644))
+	cfg, err := config.Load(path)
+	require.NoError(t, err)
+	require.True(t, cfg.Ensemble.UseTunedWeights)
+
+	statsStorage, err := storage.NewStatsJSONStorage(basePath)
+	require.NoError(t, err)
+	tuned, err := entity.NewAlgorithmStats("hot_cold_analysis", valueobject.Mega645, 0.3)
+	require.NoError(t, err)
+	tuned.TunedAt = time.Now()
+	require.NoError(t, statsStorage.Save(ctx, tuned))
+	// Stats verification created, whose weight tuning never set
+	untuned, err := entity.NewAlgorithmStats("gap_analysis", valueobject.Mega645, 0.5)
+	require.NoError(t, err)
+	require.NoError(t, statsStorage.Save(ctx, untuned))
+
+	registry, err := NewRegistry(cfg, valueobject.Mega645)
+	require.NoError(t, err)
+	assert.Equal(t, 0.3, registry.GetWeight("hot_cold_analysis"))
+	assert.Equal(t, 0.8, registry.GetWeight("gap_analysis"))
+
+	// Tuned weights are per game
+	registry, err = NewRegistry(cfg, valueobject.Power655)
+	require.NoError(t, err)
+	assert.Equal(t, 1.2, registry.GetWeight("hot_cold_analysis"))
+
+	cfg.Ensemble.UseTunedWeights = false
+	registry, err = NewRegistry(cfg, valueobject.Mega645)
+	require.NoError(t, err)
+	assert.Equal(t, 1.2, registry.GetWeight("hot_cold_analysis"))
+}
+
+func TestNewEnsemble_AppliesEnsembleSettings(t *testing.T) {
+	core, logs := observer.New(zapcore.WarnLevel)
+	t.Cleanup(logger.Replace(zap.New(core)))
+
+	cfg := &config.Config{}
+	cfg.Ensemble.Alternates = 4
+	cfg.Ensemble.TieBreak = string(algorithm.TieBreakLow)
+	cfg.Ensemble.ConfidenceMethod = string(algorithm.ConfidenceMethodMean)
+	cfg.Ensemble.MinVoters = 2
+	cfg.Ensemble.MinAlgorithmDiversity = 3
+
+	ensemble := NewEnsemble(cfg, algorithm.NewRegistry(), algorithm.MajorityVoting)
+	assert.Equal(t, algorithm.MajorityVoting, ensemble.GetVotingStrategy())
+	assert.Zero(t, logs.Len())
+
+	// Every setting is applied, so each invalid one is reported
+	cfg.Ensemble.TieBreak = "coin_flip"
+	cfg.Ensemble.ConfidenceMethod = "median"
+	cfg.Ensemble.MinVoters = -1
+	cfg.Ensemble.MinAlgorithmDiversity = -1
+	NewEnsemble(cfg, algorithm.NewRegistry(), algorithm.MajorityVoting)
+
+	var messages []string
+	for _, entry := range logs.All() {
+		messages = append(messages, entry.Message)
+	}
+	assert.Equal(t, []string{
+		"Invalid tie break, using low",
+		"Invalid confidence method, using mean",
+		"Invalid min voters, not requiring consensus",
+		"Invalid min algorithm diversity, not requiring diversity",
+	}, messages)
+}
+
+func TestNewAlgorithm(t *testing.T) {
+	minDraws := 25
+	algo, err := NewAlgorithm("pattern_analysis", config.AlgorithmDetails{Weight: 0.5, MinDraws: &minDraws})
+	require.NoError(t, err)
+	assert.Equal(t, "pattern_analysis", algo.Name())
+	assert.Equal(t, 0.5, algo.GetWeight())
+	assert.Equal(t, 25, algo.GetMinDraws())
+
+	_, err = NewAlgorithm("astrology", config.AlgorithmDetails{Weight: 1})
+	assert.ErrorIs(t, err, ErrUnknownAlgorithm)
+
+	alpha := 2.0
+	_, err = NewAlgorithm("combined_score", config.AlgorithmDetails{Weight: 1, Alpha: &alpha})
+	assert.ErrorContains(t, err, "invalid alpha")
+}
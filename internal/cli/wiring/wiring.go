@@ -0,0 +1,396 @@
+// Package wiring builds the adapters every CLI needs from the configuration
+package wiring
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/tool_predict/internal/application/port"
+	"github.com/tool_predict/internal/domain/repository"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/adapter/notify"
+	"github.com/tool_predict/internal/infrastructure/adapter/scraper"
+	"github.com/tool_predict/internal/infrastructure/adapter/storage"
+	"github.com/tool_predict/internal/infrastructure/config"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+// scraperHealthFile is the fallback engine's health file under the storage
+// base path
+const scraperHealthFile = "scraper_health.json"
+
+// NewScraper creates the Vietlott scraper for the configured engine and
+// mode, along with the live API scraper the http engine uses, which also
+// serves draw details and archive pages whatever the engine. With
+// scraper.vietlott.audit every results page the live scraper crawls is
+// logged as a scrape audit.
+func NewScraper(cfg *config.Config) (port.VietlottScraper, *scraper.VietlottAPIScraper, error) {
+	var audits repository.ScrapeAuditRepository
+	if cfg.Scraper.Vietlott.Audit {
+		auditStorage, err := storage.NewScrapeAuditJSONLStorage(cfg.Storage.JSON.BasePath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to initialize scrape audit log: %w", err)
+		}
+		audits = auditStorage
+	}
+
+	apiScraper := newAPIScraper(cfg, audits)
+
+	var live port.VietlottScraper = apiScraper
+	switch cfg.Scraper.Engine {
+	case "", scraper.EngineHTTP:
+	case scraper.EngineChromedp:
+		live = newChromedpScraper(cfg, audits)
+	case scraper.EngineFallback:
+		fallbackScraper, err := newFallbackScraper(cfg, audits)
+		if err != nil {
+			return nil, nil, err
+		}
+		live = fallbackScraper
+	default:
+		return nil, nil, fmt.Errorf("unknown scraper engine %q (expected %s, %s or %s)",
+			cfg.Scraper.Engine, scraper.EngineHTTP, scraper.EngineChromedp, scraper.EngineFallback)
+	}
+
+	vietlottScraper, err := scraper.WithMode(live, cfg.Scraper.Vietlott.Mode, cfg.Scraper.Vietlott.RecordingsDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	return vietlottScraper, apiScraper, nil
+}
+
+// ScraperHealthPath returns where the fallback engine keeps each backend's
+// health
+func ScraperHealthPath(cfg *config.Config) string {
+	return filepath.Join(cfg.Storage.JSON.BasePath, scraperHealthFile)
+}
+
+// newAPIScraper creates the API scraper, auditing into audits when not nil
+func newAPIScraper(cfg *config.Config, audits repository.ScrapeAuditRepository) *scraper.VietlottAPIScraper {
+	apiScraper := scraper.NewVietlottAPIScraper(
+		cfg.Scraper.Vietlott.BaseURL,
+		cfg.Scraper.Vietlott.Timeout,
+		cfg.Scraper.Vietlott.RetryCount,
+		cfg.Scraper.Vietlott.RateLimit,
+	)
+	if err := apiScraper.SetPageSize(cfg.Scraper.Vietlott.PageSize); err != nil {
+		logger.Warn("Invalid scraper page size, using default", zap.Error(err))
+	}
+	apiScraper.SetAuditRepository(audits)
+	return apiScraper
+}
+
+// newChromedpScraper creates the headless browser scraper, auditing into
+// audits when not nil
+func newChromedpScraper(cfg *config.Config, audits repository.ScrapeAuditRepository) *scraper.VietlottChromedpScraper {
+	browserScraper := scraper.NewVietlottChromedpScraper(cfg.Scraper.Vietlott.BaseURL, scraper.ChromedpOptions{
+		ExecPath:  cfg.Scraper.Chromedp.ExecPath,
+		Timeout:   cfg.Scraper.Chromedp.Timeout,
+		Settle:    cfg.Scraper.Chromedp.Settle,
+		RateLimit: cfg.Scraper.Vietlott.RateLimit,
+	})
+	browserScraper.SetAuditRepository(audits)
+	return browserScraper
+}
+
+// newFallbackScraper creates the fallback chain over
+// scraper.fallback.backends, with its health kept in ScraperHealthPath. The
+// chain's API backend doesn't fall back to the web pages itself, so the web
+// backend is only tried once.
+func newFallbackScraper(cfg *config.Config, audits repository.ScrapeAuditRepository) (*scraper.FallbackScraper, error) {
+	if len(cfg.Scraper.Fallback.Backends) == 0 {
+		return nil, fmt.Errorf("fallback scraper needs at least one backend")
+	}
+
+	backends := make([]scraper.ScraperBackend, 0, len(cfg.Scraper.Fallback.Backends))
+	for _, name := range cfg.Scraper.Fallback.Backends {
+		var backend port.VietlottScraper
+		switch name {
+		case scraper.BackendAPI:
+			apiScraper := newAPIScraper(cfg, audits)
+			apiScraper.SetWebFallback(false)
+			backend = apiScraper
+		case scraper.BackendWeb:
+			webScraper := scraper.NewVietlottWebScraper(
+				cfg.Scraper.Vietlott.BaseURL,
+				cfg.Scraper.Vietlott.Timeout,
+				cfg.Scraper.Vietlott.RetryCount,
+				cfg.Scraper.Vietlott.RateLimit,
+			)
+			webScraper.SetAuditRepository(audits)
+			backend = webScraper
+		case scraper.BackendChromedp:
+			backend = newChromedpScraper(cfg, audits)
+		case scraper.BackendPDF:
+			pdfScraper, err := scraper.NewVietlottPDFScraper(cfg.Scraper.Vietlott.BaseURL, scraper.PDFOptions{
+				Timeout:     cfg.Scraper.Vietlott.Timeout,
+				RetryCount:  cfg.Scraper.Vietlott.RetryCount,
+				RateLimit:   cfg.Scraper.Vietlott.RateLimit,
+				CacheDir:    cfg.Scraper.PDF.CacheDir,
+				OCRLanguage: cfg.Scraper.PDF.OCRLanguage,
+			})
+			if err != nil {
+				return nil, err
+			}
+			pdfScraper.SetAuditRepository(audits)
+			backend = pdfScraper
+		default:
+			return nil, fmt.Errorf("unknown scraper backend %q (expected %s, %s, %s or %s)",
+				name, scraper.BackendAPI, scraper.BackendWeb, scraper.BackendChromedp, scraper.BackendPDF)
+		}
+		backends = append(backends, scraper.ScraperBackend{Name: name, Scraper: backend})
+	}
+
+	store := storage.NewScraperHealthFile(ScraperHealthPath(cfg))
+	return scraper.NewFallbackScraper(store, backends...), nil
+}
+
+// NewDrawRepository creates the draw storage. With storage.type
+// "composite" draws are written to every backend in storage.composite.backends
+// and read from the first; "json" (or no type) uses the JSON storage, "jsonl"
+// the single-file JSON lines storage and "s3" the storage.s3 bucket. Any other
+// type is an error rather than silently falling back to JSON.
+func NewDrawRepository(cfg *config.Config) (repository.DrawRepository, error) {
+	switch cfg.Storage.Type {
+	case "", "json":
+		return newDrawBackend(cfg, "json")
+	case "jsonl", "s3":
+		return newDrawBackend(cfg, cfg.Storage.Type)
+	case "composite":
+	default:
+		return nil, fmt.Errorf("unsupported storage type %q (expected json, jsonl, s3 or composite)", cfg.Storage.Type)
+	}
+
+	// One backend would make composite a no-op, and the same one twice would
+	// write every draw twice to the same files
+	if len(cfg.Storage.Composite.Backends) < 2 {
+		return nil, fmt.Errorf("composite storage needs at least two backends, got %d", len(cfg.Storage.Composite.Backends))
+	}
+	seen := make(map[string]bool, len(cfg.Storage.Composite.Backends))
+	backends := make([]repository.DrawRepository, 0, len(cfg.Storage.Composite.Backends))
+	for _, name := range cfg.Storage.Composite.Backends {
+		if seen[name] {
+			return nil, fmt.Errorf("composite storage lists backend %q twice", name)
+		}
+		seen[name] = true
+		backend, err := newDrawBackend(cfg, name)
+		if err != nil {
+			return nil, err
+		}
+		backends = append(backends, backend)
+	}
+	return storage.NewCompositeDrawRepository(backends[0], backends[1:]...), nil
+}
+
+// DrawStorageLocation describes where NewDrawRepository stores draws, e.g.
+// "JSON files in data" or "s3://bucket/prefix", for messages to the user
+func DrawStorageLocation(cfg *config.Config) string {
+	if cfg.Storage.Type != "composite" {
+		return drawBackendLocation(cfg, cfg.Storage.Type)
+	}
+	locations := make([]string, 0, len(cfg.Storage.Composite.Backends))
+	for _, name := range cfg.Storage.Composite.Backends {
+		locations = append(locations, drawBackendLocation(cfg, name))
+	}
+	return strings.Join(locations, " and ")
+}
+
+// drawBackendLocation describes where the named draw backend stores draws
+func drawBackendLocation(cfg *config.Config, name string) string {
+	switch name {
+	case "", "json":
+		return "JSON files in " + cfg.Storage.JSON.BasePath
+	case "jsonl":
+		return "JSON lines files in " + cfg.Storage.JSON.BasePath
+	case "s3":
+		return "s3://" + path.Join(cfg.Storage.S3.Bucket, cfg.Storage.S3.Prefix)
+	default:
+		return name + " storage"
+	}
+}
+
+// newDrawBackend creates a single draw storage backend by name
+func newDrawBackend(cfg *config.Config, name string) (repository.DrawRepository, error) {
+	switch name {
+	case "json":
+		jsonStorage, err := storage.NewJSONStorage(cfg.Storage.JSON.BasePath)
+		if err != nil {
+			return nil, err
+		}
+		return jsonStorage, nil
+	case "jsonl":
+		jsonlStorage, err := storage.NewJSONLDrawStorage(cfg.Storage.JSON.BasePath)
+		if err != nil {
+			return nil, err
+		}
+		return jsonlStorage, nil
+	case "s3":
+		client, err := newS3Client(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return storage.NewS3DrawStorage(client, cfg.Storage.S3.Prefix), nil
+	default:
+		return nil, fmt.Errorf("unsupported draw storage backend %q", name)
+	}
+}
+
+// NewPredictionRepository creates the prediction storage: the storage.s3
+// bucket with storage.type "s3", the JSON storage otherwise. Either keeps
+// superseded ensembles if storage.json.keep_prediction_history is set.
+func NewPredictionRepository(cfg *config.Config) (repository.PredictionRepository, error) {
+	if cfg.Storage.Type == "s3" {
+		client, err := newS3Client(cfg)
+		if err != nil {
+			return nil, err
+		}
+		s3Storage := storage.NewS3PredictionStorage(client, cfg.Storage.S3.Prefix)
+		s3Storage.SetKeepHistory(cfg.Storage.JSON.KeepPredictionHistory)
+		return s3Storage, nil
+	}
+
+	jsonStorage, err := storage.NewPredictionJSONStorage(cfg.Storage.JSON.BasePath)
+	if err != nil {
+		return nil, err
+	}
+	jsonStorage.SetKeepHistory(cfg.Storage.JSON.KeepPredictionHistory)
+	return jsonStorage, nil
+}
+
+// NewNotifier creates the notifiers configured under notify: the Telegram
+// bot, sent every event, and the webhook and email, sent only
+// notify.events. It returns nil when none is configured.
+func NewNotifier(cfg *config.Config) *notify.Dispatcher {
+	var notifiers []port.Notifier
+	if telegram := newTelegramNotifier(cfg); telegram != nil {
+		notifiers = append(notifiers, telegram)
+	}
+
+	var subscribed []port.Notifier
+	if cfg.Notify.WebhookURL != "" {
+		subscribed = append(subscribed, notify.NewWebhookNotifier(cfg.Notify.WebhookURL, cfg.Notify.Timeout))
+	}
+	if email := newEmailNotifier(cfg); email != nil {
+		subscribed = append(subscribed, email)
+	}
+	if len(subscribed) > 0 {
+		notifiers = append(notifiers, notify.NewDispatcher(notificationEvents(cfg.Notify.Events), subscribed...))
+	}
+
+	if len(notifiers) == 0 {
+		return nil
+	}
+	return notify.NewDispatcher(nil, notifiers...)
+}
+
+// NewPredictionNotifier creates the notifiers announcing new predictions
+// and verified outcomes, or returns nil when none is configured
+func NewPredictionNotifier(cfg *config.Config) port.PredictionNotifier {
+	if notifier := NewNotifier(cfg); notifier != nil {
+		return notifier
+	}
+	return nil
+}
+
+// newTelegramNotifier creates the Telegram bot, or returns nil when
+// notify.telegram has no bot token (nor TELEGRAM_BOT_TOKEN) or no game has a
+// chat
+func newTelegramNotifier(cfg *config.Config) *notify.TelegramNotifier {
+	token := cfg.Notify.Telegram.BotToken
+	if token == "" {
+		token = os.Getenv("TELEGRAM_BOT_TOKEN")
+	}
+	if token == "" {
+		return nil
+	}
+
+	chatIDs := make(map[valueobject.GameType]string)
+	for _, gt := range valueobject.AllGameTypes() {
+		if chat := cfg.TelegramChatFor(gt); chat != "" {
+			chatIDs[gt] = chat
+		}
+	}
+	if len(chatIDs) == 0 {
+		return nil
+	}
+	return notify.NewTelegramNotifier(token, chatIDs, cfg.Notify.Timeout)
+}
+
+// newEmailNotifier creates the SMTP notifier, or returns nil when
+// notify.email has no host or recipient. An empty password is taken from
+// SMTP_PASSWORD and an empty sender is the username.
+func newEmailNotifier(cfg *config.Config) *notify.EmailNotifier {
+	emailCfg := cfg.Notify.Email
+	if emailCfg.SMTPHost == "" || len(emailCfg.To) == 0 {
+		return nil
+	}
+
+	password := emailCfg.Password
+	if password == "" {
+		password = os.Getenv("SMTP_PASSWORD")
+	}
+	from := emailCfg.From
+	if from == "" {
+		from = emailCfg.Username
+	}
+
+	notifier, err := notify.NewEmailNotifier(notify.EmailConfig{
+		Host:     emailCfg.SMTPHost,
+		Port:     emailCfg.SMTPPort,
+		Username: emailCfg.Username,
+		Password: password,
+		From:     from,
+		To:       emailCfg.To,
+		Timeout:  cfg.Notify.Timeout,
+	})
+	if err != nil {
+		logger.Warn("Invalid email notification config, not sending email", zap.Error(err))
+		return nil
+	}
+	return notifier
+}
+
+// notificationEvents parses notify.events, skipping unknown names
+func notificationEvents(names []string) []port.NotificationEvent {
+	events := make([]port.NotificationEvent, 0, len(names))
+	for _, name := range names {
+		switch event := port.NotificationEvent(name); event {
+		case port.EventPrediction, port.EventVerified, port.EventAccuracyDrop:
+			events = append(events, event)
+		default:
+			logger.Warn("Unknown notification event, ignoring", zap.String("event", name))
+		}
+	}
+	return events
+}
+
+// newS3Client creates the client for the storage.s3 bucket. Credentials left
+// out of the config are taken from the standard AWS environment variables,
+// as CI secrets usually provide them.
+func newS3Client(cfg *config.Config) (*storage.S3Client, error) {
+	s3Cfg := cfg.Storage.S3
+	clientCfg := storage.S3Config{
+		Bucket:          s3Cfg.Bucket,
+		Region:          s3Cfg.Region,
+		Endpoint:        s3Cfg.Endpoint,
+		UsePathStyle:    s3Cfg.UsePathStyle,
+		AccessKeyID:     s3Cfg.AccessKeyID,
+		SecretAccessKey: s3Cfg.SecretAccessKey,
+		Timeout:         s3Cfg.Timeout,
+	}
+	if clientCfg.AccessKeyID == "" && clientCfg.SecretAccessKey == "" {
+		clientCfg.AccessKeyID = os.Getenv("AWS_ACCESS_KEY_ID")
+		clientCfg.SecretAccessKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+		clientCfg.SessionToken = os.Getenv("AWS_SESSION_TOKEN")
+	}
+
+	client, err := storage.NewS3Client(clientCfg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid s3 storage config: %w", err)
+	}
+	return client, nil
+}
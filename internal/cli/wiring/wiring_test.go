@@ -0,0 +1,201 @@
+package wiring
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/application/port"
+	"github.com/tool_predict/internal/infrastructure/adapter/notify"
+	"github.com/tool_predict/internal/infrastructure/adapter/scraper"
+	"github.com/tool_predict/internal/infrastructure/adapter/storage"
+	"github.com/tool_predict/internal/infrastructure/config"
+)
+
+func TestNewDrawRepository_Composite(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+storage:
+  type: "composite"
+  json:
+    base_path: "`+filepath.Join(dir, "data")+`"
+  composite:
+    backends: ["json", "jsonl"]
+`), 0644))
+
+	cfg, err := config.Load(path)
+	require.NoError(t, err)
+
+	repo, err := NewDrawRepository(cfg)
+	require.NoError(t, err)
+	assert.IsType(t, &storage.CompositeDrawRepository{}, repo)
+
+	cfg.Storage.Composite.Backends = []string{"json", "mongo"}
+	_, err = NewDrawRepository(cfg)
+	assert.ErrorContains(t, err, `"mongo"`)
+
+	// The same backend twice would write every draw twice
+	cfg.Storage.Composite.Backends = []string{"json", "jsonl", "json"}
+	_, err = NewDrawRepository(cfg)
+	assert.ErrorContains(t, err, `"json" twice`)
+
+	cfg.Storage.Composite.Backends = []string{"json"}
+	_, err = NewDrawRepository(cfg)
+	assert.ErrorContains(t, err, "at least two backends")
+
+	cfg.Storage.Type = "json"
+	repo, err = NewDrawRepository(cfg)
+	require.NoError(t, err)
+	assert.IsType(t, &storage.JSONStorage{}, repo)
+
+	// An unknown type is an error, not a silent fallback to JSON
+	cfg.Storage.Type = "sqlite"
+	_, err = NewDrawRepository(cfg)
+	assert.ErrorContains(t, err, `"sqlite"`)
+}
+
+func TestNewDrawRepository_ShippedConfigs(t *testing.T) {
+	for _, name := range []string{"config.dev.yaml", "config.prod.yaml"} {
+		cfg, err := config.Load(filepath.Join("../../../configs", name))
+		require.NoError(t, err)
+		cfg.Storage.JSON.BasePath = t.TempDir()
+
+		_, err = NewDrawRepository(cfg)
+		assert.NoError(t, err, name)
+	}
+}
+
+func TestDrawStorageLocation(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Storage.JSON.BasePath = "data"
+	cfg.Storage.S3.Bucket = "draws"
+	cfg.Storage.S3.Prefix = "vietlott/"
+
+	assert.Equal(t, "JSON files in data", DrawStorageLocation(cfg))
+
+	cfg.Storage.Type = "s3"
+	assert.Equal(t, "s3://draws/vietlott", DrawStorageLocation(cfg))
+
+	cfg.Storage.Type = "composite"
+	cfg.Storage.Composite.Backends = []string{"jsonl", "s3"}
+	assert.Equal(t, "JSON lines files in data and s3://draws/vietlott", DrawStorageLocation(cfg))
+}
+
+func TestNewRepositories_S3(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+storage:
+  type: "s3"
+  s3:
+    bucket: "vietlott-results"
+    endpoint: "http://localhost:9000"
+    use_path_style: true
+`), 0644))
+
+	cfg, err := config.Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, "data", cfg.Storage.S3.Prefix)
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+	_, err = NewDrawRepository(cfg)
+	assert.ErrorContains(t, err, "access key")
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "key")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	drawRepo, err := NewDrawRepository(cfg)
+	require.NoError(t, err)
+	assert.IsType(t, &storage.S3DrawStorage{}, drawRepo)
+
+	predictionRepo, err := NewPredictionRepository(cfg)
+	require.NoError(t, err)
+	assert.IsType(t, &storage.S3PredictionStorage{}, predictionRepo)
+}
+
+func TestNewPredictionNotifier(t *testing.T) {
+	cfg := &config.Config{}
+	t.Setenv("TELEGRAM_BOT_TOKEN", "")
+	assert.Nil(t, NewPredictionNotifier(cfg), "no bot token")
+
+	cfg.Notify.Telegram.BotToken = "123:secret"
+	assert.Nil(t, NewPredictionNotifier(cfg), "no chat")
+
+	cfg.Notify.Telegram.Power655 = "-100300"
+	assert.IsType(t, &notify.Dispatcher{}, NewPredictionNotifier(cfg))
+
+	cfg.Notify.Telegram.BotToken = ""
+	t.Setenv("TELEGRAM_BOT_TOKEN", "123:secret")
+	assert.NotNil(t, NewPredictionNotifier(cfg), "token from the environment")
+}
+
+func TestNewNotifier(t *testing.T) {
+	cfg := &config.Config{}
+	t.Setenv("TELEGRAM_BOT_TOKEN", "")
+	assert.Nil(t, NewNotifier(cfg), "nothing configured")
+
+	cfg.Notify.Email.SMTPHost = "smtp.example.com"
+	assert.Nil(t, NewNotifier(cfg), "no recipient")
+
+	cfg.Notify.Email.To = []string{"ops@example.com"}
+	assert.Nil(t, NewNotifier(cfg), "no sender")
+
+	cfg.Notify.Email.Username = "bot@example.com"
+	notifier := NewNotifier(cfg)
+	require.NotNil(t, notifier)
+	assert.Equal(t, 1, notifier.Len())
+
+	cfg.Notify.WebhookURL = "https://hooks.example.com/abc"
+	cfg.Notify.Telegram.BotToken = "123:secret"
+	cfg.Notify.Telegram.ChatID = "-100200"
+	notifier = NewNotifier(cfg)
+	require.NotNil(t, notifier)
+	assert.Equal(t, 2, notifier.Len(), "telegram next to the email and webhook dispatcher")
+}
+
+func TestNotificationEvents(t *testing.T) {
+	assert.Equal(t, []port.NotificationEvent{port.EventPrediction, port.EventAccuracyDrop},
+		notificationEvents([]string{"prediction", "draw", "accuracy_drop"}))
+}
+
+func TestNewScraper_Engine(t *testing.T) {
+	cfg := &config.Config{}
+
+	live, apiScraper, err := NewScraper(cfg)
+	require.NoError(t, err)
+	assert.Same(t, apiScraper, live)
+
+	cfg.Scraper.Engine = "chromedp"
+	live, apiScraper, err = NewScraper(cfg)
+	require.NoError(t, err)
+	assert.IsType(t, &scraper.VietlottChromedpScraper{}, live)
+	assert.NotNil(t, apiScraper, "detail and archive fetches still use the API")
+
+	cfg.Scraper.Engine = "selenium"
+	_, _, err = NewScraper(cfg)
+	assert.ErrorContains(t, err, `unknown scraper engine "selenium"`)
+}
+
+func TestNewScraper_FallbackEngine(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Storage.JSON.BasePath = t.TempDir()
+	cfg.Scraper.Engine = "fallback"
+	cfg.Scraper.Fallback.Backends = []string{"api", "web", "chromedp", "pdf"}
+	cfg.Scraper.PDF.CacheDir = filepath.Join(cfg.Storage.JSON.BasePath, "result_pdfs")
+
+	live, apiScraper, err := NewScraper(cfg)
+	require.NoError(t, err)
+	assert.IsType(t, &scraper.FallbackScraper{}, live)
+	assert.NotNil(t, apiScraper)
+
+	cfg.Scraper.Fallback.Backends = []string{"api", "curl"}
+	_, _, err = NewScraper(cfg)
+	assert.ErrorContains(t, err, `unknown scraper backend "curl"`)
+
+	cfg.Scraper.Fallback.Backends = nil
+	_, _, err = NewScraper(cfg)
+	assert.Error(t, err)
+}
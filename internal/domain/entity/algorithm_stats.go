@@ -21,9 +21,14 @@ type AlgorithmStats struct {
 	AccuracyExact     float64 `json:"accuracy_exact"`
 	AverageConfidence float64 `json:"average_confidence"`
 
+	// Ensemble prediction whose outcome was recorded last, so verifying it
+	// again after a failed run doesn't count it twice
+	LastPredictionID string `json:"last_prediction_id,omitempty"`
+
 	// Metadata
 	IsActive    bool      `json:"is_active"`
-	Weight      float64   `json:"weight"` // For ensemble voting
+	Weight      float64   `json:"weight"`             // For ensemble voting
+	TunedAt     time.Time `json:"tuned_at,omitempty"` // When tuning last set Weight; zero if it never has
 	LastUpdated time.Time `json:"last_updated"`
 	CreatedAt   time.Time `json:"created_at"`
 }
@@ -82,6 +87,25 @@ func (as *AlgorithmStats) UpdateMetrics(
 	as.LastUpdated = time.Now()
 }
 
+// RecordOutcome folds one more prediction, which matched matchCount numbers
+// with the given confidence, into the accuracies and average confidence
+func (as *AlgorithmStats) RecordOutcome(matchCount int, confidence float64) {
+	n := float64(as.TotalPredictions)
+	hit := func(count int) float64 {
+		if matchCount == count {
+			return 1
+		}
+		return 0
+	}
+
+	as.Accuracy3Numbers = (as.Accuracy3Numbers*n + hit(3)) / (n + 1)
+	as.Accuracy4Numbers = (as.Accuracy4Numbers*n + hit(4)) / (n + 1)
+	as.AccuracyExact = (as.AccuracyExact*n + hit(6)) / (n + 1)
+	as.AverageConfidence = (as.AverageConfidence*n + confidence) / (n + 1)
+	as.TotalPredictions++
+	as.LastUpdated = time.Now()
+}
+
 // SetWeight updates the algorithm's weight for ensemble voting
 func (as *AlgorithmStats) SetWeight(weight float64) error {
 	if weight < 0 {
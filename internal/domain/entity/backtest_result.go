@@ -16,6 +16,8 @@ type PredictionMatch struct {
 	Confidence       float64             `json:"confidence"`
 	PredictionDate   time.Time           `json:"prediction_date"`
 	ActualDrawDate   time.Time           `json:"actual_draw_date"`
+	AverageRank      float64             `json:"average_rank,omitempty"` // Mean rank of the actual numbers in the ranked pool; 0 without a pool
+	Winnings         float64             `json:"winnings,omitempty"`     // VND the prediction would have won as a ticket, see Draw.Payout
 }
 
 // BacktestResult represents the results of backtesting an algorithm
@@ -33,10 +35,26 @@ type BacktestResult struct {
 
 	// Performance metrics
 	AverageConfidence float64       `json:"average_confidence"`
+	AverageRank       float64       `json:"average_rank,omitempty"` // Mean of the matches' average ranks, lower is closer; 0 without ranked pools
 	ExecutionTime     time.Duration `json:"execution_time"`
 	CreatedAt         time.Time     `json:"created_at"`
 	LastUpdated       time.Time     `json:"last_updated"`
 
+	// Playing every prediction as a ticket: its cost and winnings in VND, and
+	// the return on investment, (winnings - cost) / cost; -1 lost everything
+	TotalCost     float64 `json:"total_cost"`
+	TotalWinnings float64 `json:"total_winnings"`
+	ROI           float64 `json:"roi"`
+
+	// Walk-forward window each prediction trained on: "expanding" over every
+	// earlier draw, or "sliding" over the latest WindowSize
+	WindowMode string `json:"window_mode,omitempty"`
+	WindowSize int    `json:"window_size,omitempty"`
+
+	// Version stamp of the binary and configuration that ran the backtest
+	ToolVersion string `json:"tool_version,omitempty"`
+	ConfigHash  string `json:"config_hash,omitempty"`
+
 	// Detailed results (optional, can be large)
 	DetailedResults []PredictionMatch `json:"detailed_results,omitempty"`
 }
@@ -101,14 +119,55 @@ func (br *BacktestResult) AddMatchResult(match PredictionMatch) {
 func (br *BacktestResult) CalculateMetrics() {
 	if len(br.DetailedResults) == 0 {
 		br.AverageConfidence = 0.0
+		br.TotalCost, br.TotalWinnings, br.ROI = 0, 0, 0
 		return
 	}
 
 	totalConfidence := 0.0
+	totalRank, ranked := 0.0, 0
+	totalWinnings := 0.0
 	for _, result := range br.DetailedResults {
 		totalConfidence += result.Confidence
+		if result.AverageRank > 0 {
+			totalRank += result.AverageRank
+			ranked++
+		}
+		totalWinnings += result.Winnings
 	}
 	br.AverageConfidence = totalConfidence / float64(len(br.DetailedResults))
+	br.TotalCost = TicketPrice * float64(len(br.DetailedResults))
+	br.TotalWinnings = totalWinnings
+	br.ROI = (br.TotalWinnings - br.TotalCost) / br.TotalCost
+	br.AverageRank = 0
+	if ranked > 0 {
+		br.AverageRank = totalRank / float64(ranked)
+	}
+}
+
+// PoolRank returns the mean 1-based rank of the actual numbers in a ranked
+// pool, best first. Numbers missing from the pool count as ranked just below
+// it, so a miss by a whisker scores better than one far off. It returns 0 for
+// an empty pool.
+func PoolRank(pool []int, actual valueobject.Numbers) float64 {
+	if len(pool) == 0 || len(actual) == 0 {
+		return 0
+	}
+	rank := make(map[int]int, len(pool))
+	for i, num := range pool {
+		if _, ok := rank[num]; !ok {
+			rank[num] = i + 1
+		}
+	}
+
+	total := 0
+	for _, num := range actual {
+		if r, ok := rank[num]; ok {
+			total += r
+		} else {
+			total += len(pool) + 1
+		}
+	}
+	return float64(total) / float64(len(actual))
 }
 
 // GetAccuracyRate returns the exact match accuracy rate
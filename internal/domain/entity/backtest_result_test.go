@@ -0,0 +1,58 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func TestPoolRank(t *testing.T) {
+	pool := []int{10, 20, 30, 40, 5, 6, 7, 8, 9, 11, 12, 13, 14, 15, 16}
+
+	// Every winner in the top six: ranks 1-6
+	assert.InDelta(t, 3.5, PoolRank(pool, valueobject.MustNewNumbers([]int{5, 6, 10, 20, 30, 40})), 1e-9)
+
+	// Winners ranked 7th-12th: a miss, but a close one
+	assert.InDelta(t, 9.5, PoolRank(pool, valueobject.MustNewNumbers([]int{7, 8, 9, 11, 12, 13})), 1e-9)
+
+	// Winners outside the pool rank just below it
+	assert.InDelta(t, (1+2+16*4)/6.0, PoolRank(pool, valueobject.MustNewNumbers([]int{10, 20, 41, 42, 43, 44})), 1e-9)
+
+	assert.Zero(t, PoolRank(nil, valueobject.MustNewNumbers([]int{1, 2, 3, 4, 5, 6})))
+}
+
+func TestBacktestResult_CalculateMetrics_AverageRank(t *testing.T) {
+	result, err := NewBacktestResult(valueobject.Mega645, "ensemble_weighted", valueobject.DateRange{}, 3)
+	require.NoError(t, err)
+
+	result.AddMatchResult(PredictionMatch{Confidence: 0.5, AverageRank: 4})
+	result.AddMatchResult(PredictionMatch{Confidence: 0.5, AverageRank: 10})
+	// Matches without a ranked pool are left out of the average
+	result.AddMatchResult(PredictionMatch{Confidence: 0.5})
+	result.CalculateMetrics()
+
+	assert.InDelta(t, 7.0, result.AverageRank, 1e-9)
+	assert.InDelta(t, 0.5, result.AverageConfidence, 1e-9)
+}
+
+func TestBacktestResult_CalculateMetrics_ROI(t *testing.T) {
+	result, err := NewBacktestResult(valueobject.Mega645, "frequency_analysis", valueobject.DateRange{}, 4)
+	require.NoError(t, err)
+
+	result.AddMatchResult(PredictionMatch{MatchCount: 3, Winnings: 30000})
+	result.AddMatchResult(PredictionMatch{MatchCount: 1})
+	result.AddMatchResult(PredictionMatch{MatchCount: 0})
+	result.AddMatchResult(PredictionMatch{MatchCount: 2})
+	result.CalculateMetrics()
+
+	assert.Equal(t, 4*TicketPrice, result.TotalCost)
+	assert.Equal(t, 30000.0, result.TotalWinnings)
+	assert.InDelta(t, -0.25, result.ROI, 1e-9)
+
+	empty, err := NewBacktestResult(valueobject.Mega645, "frequency_analysis", valueobject.DateRange{}, 0)
+	require.NoError(t, err)
+	empty.CalculateMetrics()
+	assert.Zero(t, empty.ROI)
+}
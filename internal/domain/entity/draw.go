@@ -2,9 +2,9 @@ package entity
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/tool_predict/internal/domain/valueobject"
 )
 
@@ -17,6 +17,8 @@ type Draw struct {
 	DrawDate   time.Time            `json:"draw_date"`
 	Jackpot    float64              `json:"jackpot"`
 	Winners    int                  `json:"winners"`
+	Prizes     []PrizeResult        `json:"prizes,omitempty"` // Every tier's winners, when the official results were parsed
+	Sample     bool                 `json:"sample,omitempty"` // Bundled sample draw stored by init --sample, not a real result
 	CreatedAt  time.Time            `json:"created_at"`
 }
 
@@ -40,11 +42,9 @@ func NewDraw(
 	}
 
 	// Validate numbers against game type range
-	minRange, maxRange := gameType.NumberRange()
 	for _, num := range numbers {
-		if num < minRange || num > maxRange {
-			return nil, fmt.Errorf("number %d is out of range for game type %s (%d-%d)",
-				num, gameType, minRange, maxRange)
+		if err := gameType.ValidateNumber(num); err != nil {
+			return nil, err
 		}
 	}
 
@@ -59,7 +59,7 @@ func NewDraw(
 	}
 
 	return &Draw{
-		ID:         uuid.New().String(),
+		ID:         DrawID(gameType, drawNumber),
 		GameType:   gameType,
 		DrawNumber: drawNumber,
 		Numbers:    numbers,
@@ -70,6 +70,13 @@ func NewDraw(
 	}, nil
 }
 
+// DrawID returns the ID of gameType's draw drawNumber, such as power_01288,
+// the name its JSON file is stored under
+func DrawID(gameType valueobject.GameType, drawNumber int) string {
+	prefix, _, _ := strings.Cut(strings.ToLower(string(gameType)), "_")
+	return fmt.Sprintf("%s_%05d", prefix, drawNumber)
+}
+
 // GetID returns the unique identifier of the draw
 func (d *Draw) GetID() string {
 	return d.ID
@@ -80,6 +87,102 @@ func (d *Draw) GetGameType() valueobject.GameType {
 	return d.GameType
 }
 
+// Equals reports whether two draws describe the same official result: same
+// game type, draw number, draw date and winning numbers. Jackpot and winner
+// counts are not compared since they are often filled in after the draw.
+func (d *Draw) Equals(other *Draw) bool {
+	if other == nil {
+		return false
+	}
+	if d.GameType != other.GameType || d.DrawNumber != other.DrawNumber {
+		return false
+	}
+	if !d.DrawDate.Equal(other.DrawDate) {
+		return false
+	}
+	if len(d.Numbers) != len(other.Numbers) {
+		return false
+	}
+	return d.Numbers.MatchCount(other.Numbers) == len(d.Numbers)
+}
+
+// PrizeTier is the Vietlott prize level won by a ticket
+type PrizeTier string
+
+const (
+	PrizeJackpot  PrizeTier = "jackpot"  // All 6 numbers
+	PrizeJackpot2 PrizeTier = "jackpot2" // 5 numbers plus the bonus, Power 6/55 only
+	PrizeFirst    PrizeTier = "first"    // 5 numbers
+	PrizeSecond   PrizeTier = "second"   // 4 numbers
+	PrizeThird    PrizeTier = "third"    // 3 numbers
+	PrizeNone     PrizeTier = "none"
+)
+
+// PrizeResult is one prize tier of a draw as officially published: how many
+// tickets won it and the prize value
+type PrizeResult struct {
+	Tier    PrizeTier `json:"tier"`
+	Winners int       `json:"winners"`
+	Amount  float64   `json:"amount"` // VND; the whole pool for the jackpot tiers, per ticket otherwise
+}
+
+// Prize returns the published result of a prize tier, if there is one
+func (d *Draw) Prize(tier PrizeTier) (PrizeResult, bool) {
+	for _, prize := range d.Prizes {
+		if prize.Tier == tier {
+			return prize, true
+		}
+	}
+	return PrizeResult{}, false
+}
+
+// PrizeTier returns the prize tier a ticket wins against this draw. Bonus
+// numbers aren't stored, so Power 6/55's Jackpot 2 (5 numbers plus the bonus)
+// is reported as PrizeFirst.
+func (d *Draw) PrizeTier(ticket valueobject.Numbers) PrizeTier {
+	switch d.Numbers.MatchCount(ticket) {
+	case 6:
+		return PrizeJackpot
+	case 5:
+		return PrizeFirst
+	case 4:
+		return PrizeSecond
+	case 3:
+		return PrizeThird
+	default:
+		return PrizeNone
+	}
+}
+
+// TicketPrice is the price in VND of one Mega 6/45 or Power 6/55 line
+const TicketPrice = 10000.0
+
+// fixedPrizes are the published non-jackpot prizes in VND
+var fixedPrizes = map[valueobject.GameType]map[PrizeTier]float64{
+	valueobject.Mega645: {
+		PrizeFirst:  10000000,
+		PrizeSecond: 300000,
+		PrizeThird:  30000,
+	},
+	valueobject.Power655: {
+		PrizeFirst:  40000000,
+		PrizeSecond: 500000,
+		PrizeThird:  50000,
+	},
+}
+
+// Payout returns what a ticket would have won against this draw, in VND. A
+// jackpot is shared with the draw's recorded winners, so an unknown (zero)
+// jackpot pays nothing. Power 6/55's Jackpot 2 pays as PrizeFirst, see
+// PrizeTier.
+func (d *Draw) Payout(ticket valueobject.Numbers) float64 {
+	tier := d.PrizeTier(ticket)
+	if tier == PrizeJackpot {
+		return d.Jackpot / float64(d.Winners+1)
+	}
+	return fixedPrizes[d.GameType][tier]
+}
+
 // String returns a string representation of the draw
 func (d *Draw) String() string {
 	return fmt.Sprintf("Draw #%d (%s) on %s: %s, Jackpot: %.0f VND",
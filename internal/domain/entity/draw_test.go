@@ -0,0 +1,86 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func TestNewDraw_RejectsNumbersOutsideGameRange(t *testing.T) {
+	// 55 is a valid Power 6/55 number but not a Mega 6/45 one
+	numbers := valueobject.MustNewNumbers([]int{3, 9, 17, 22, 30, 55})
+	drawDate := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	_, err := NewDraw(valueobject.Mega645, 1201, numbers, drawDate, 0, 0)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "number 55 is out of range for game type MEGA_6_45 (1-45)")
+
+	draw, err := NewDraw(valueobject.Power655, 1295, numbers, drawDate, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, numbers, draw.Numbers)
+}
+
+func TestNewDraw_IDFollowsDrawNumber(t *testing.T) {
+	numbers := valueobject.MustNewNumbers([]int{11, 30, 35, 41, 48, 55})
+	drawDate := time.Date(2025, 12, 30, 0, 0, 0, 0, time.UTC)
+
+	draw, err := NewDraw(valueobject.Power655, 1288, numbers, drawDate, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "power_01288", draw.ID)
+	assert.Equal(t, "mega_01201", DrawID(valueobject.Mega645, 1201))
+}
+
+func TestDraw_PrizeTier(t *testing.T) {
+	numbers := valueobject.MustNewNumbers([]int{3, 9, 17, 22, 30, 41})
+	draw, err := NewDraw(valueobject.Mega645, 1201, numbers, time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC), 0, 0)
+	require.NoError(t, err)
+
+	tests := []struct {
+		ticket []int
+		want   PrizeTier
+	}{
+		{[]int{3, 9, 17, 22, 30, 41}, PrizeJackpot},
+		{[]int{3, 9, 17, 22, 30, 44}, PrizeFirst},
+		{[]int{3, 9, 17, 22, 1, 2}, PrizeSecond},
+		{[]int{3, 9, 17, 1, 2, 4}, PrizeThird},
+		{[]int{3, 9, 1, 2, 4, 5}, PrizeNone},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, draw.PrizeTier(valueobject.MustNewNumbers(tt.ticket)), "ticket %v", tt.ticket)
+	}
+}
+
+func TestDraw_Payout(t *testing.T) {
+	numbers := valueobject.MustNewNumbers([]int{3, 9, 17, 22, 30, 41})
+	drawDate := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	mega, err := NewDraw(valueobject.Mega645, 1201, numbers, drawDate, 30000000000, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 15000000000.0, mega.Payout(numbers), "jackpot shared with the recorded winner")
+	assert.Equal(t, 10000000.0, mega.Payout(valueobject.MustNewNumbers([]int{3, 9, 17, 22, 30, 44})))
+	assert.Equal(t, 300000.0, mega.Payout(valueobject.MustNewNumbers([]int{3, 9, 17, 22, 1, 2})))
+	assert.Equal(t, 30000.0, mega.Payout(valueobject.MustNewNumbers([]int{3, 9, 17, 1, 2, 4})))
+	assert.Zero(t, mega.Payout(valueobject.MustNewNumbers([]int{3, 9, 1, 2, 4, 5})))
+
+	power, err := NewDraw(valueobject.Power655, 1295, numbers, drawDate, 0, 0)
+	require.NoError(t, err)
+	assert.Zero(t, power.Payout(numbers), "unknown jackpot")
+	assert.Equal(t, 50000.0, power.Payout(valueobject.MustNewNumbers([]int{3, 9, 17, 1, 2, 4})))
+}
+
+func TestDraw_Prize(t *testing.T) {
+	draw := &Draw{Prizes: []PrizeResult{
+		{Tier: PrizeJackpot, Winners: 0, Amount: 45123456789},
+		{Tier: PrizeFirst, Winners: 15, Amount: 40000000},
+	}}
+
+	prize, ok := draw.Prize(PrizeFirst)
+	require.True(t, ok)
+	assert.Equal(t, 15, prize.Winners)
+
+	_, ok = draw.Prize(PrizeJackpot2)
+	assert.False(t, ok)
+}
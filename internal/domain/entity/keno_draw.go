@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// KenoDraw represents a Vietlott Keno draw result: 20 numbers out of 01-80.
+// Keno is drawn many times a day, so draws carry their time as well as date.
+type KenoDraw struct {
+	ID         string                  `json:"id"`
+	DrawNumber int                     `json:"draw_number"`
+	Numbers    valueobject.KenoNumbers `json:"numbers"`
+	DrawTime   time.Time               `json:"draw_time"`
+	CreatedAt  time.Time               `json:"created_at"`
+}
+
+// NewKenoDraw creates a new KenoDraw entity with validation
+func NewKenoDraw(drawNumber int, numbers valueobject.KenoNumbers, drawTime time.Time) (*KenoDraw, error) {
+	if drawNumber <= 0 {
+		return nil, fmt.Errorf("draw number must be positive, got %d", drawNumber)
+	}
+	if len(numbers) != valueobject.KenoDrawSize {
+		return nil, fmt.Errorf("a keno draw has %d numbers, got %d", valueobject.KenoDrawSize, len(numbers))
+	}
+
+	return &KenoDraw{
+		ID:         uuid.New().String(),
+		DrawNumber: drawNumber,
+		Numbers:    numbers,
+		DrawTime:   drawTime,
+		CreatedAt:  time.Now(),
+	}, nil
+}
+
+// String returns a string representation of the draw
+func (d *KenoDraw) String() string {
+	return fmt.Sprintf("Keno #%d (%s): %s", d.DrawNumber, d.DrawTime.Format("2006-01-02 15:04"), d.Numbers)
+}
@@ -71,7 +71,118 @@ type EnsemblePrediction struct {
 	FinalNumbers   valueobject.Numbers     `json:"final_numbers"`
 	VotingStrategy string                  `json:"voting_strategy"`
 	GeneratedAt    time.Time               `json:"generated_at"`
+	ForDate        time.Time               `json:"for_date"` // Target draw date; zero if unknown
 	AlgorithmStats []AlgorithmContribution `json:"algorithm_stats"`
+	Confidence     float64                 `json:"confidence,omitempty"`     // Overall confidence, 0-1, by the ensemble's confidence method
+	Alternates     []int                   `json:"alternates,omitempty"`     // Next best numbers by vote, best first
+	RankedPool     []int                   `json:"ranked_pool,omitempty"`    // Best ranked numbers by vote, best first, for rank metrics
+	BetType        string                  `json:"bet_type,omitempty"`       // Bao bet the pool is for, e.g. "bao8"; empty for a single line
+	BetPool        []int                   `json:"bet_pool,omitempty"`       // Numbers to mark for BetType, ascending
+	Core           []CoreNumber            `json:"core,omitempty"`           // Best ranked numbers when only some are predicted, best first
+	Wheel          *Wheel                  `json:"wheel,omitempty"`          // Abbreviated wheel of tickets over the best ranked numbers; nil when not asked for
+	CandidateSets  []CandidateSet          `json:"candidate_sets,omitempty"` // Best lines by vote, best first; the first is FinalNumbers
+	Metadata       map[string]string       `json:"metadata,omitempty"`
+	Provenance     *Provenance             `json:"provenance,omitempty"` // Inputs that produced it; nil for older predictions
+	Outcome        *PredictionOutcome      `json:"outcome,omitempty"`    // How it did against the target draw; nil until verified
+}
+
+// PredictionOutcome records how an ensemble prediction did against the draw
+// it targeted
+type PredictionOutcome struct {
+	DrawNumber       int                 `json:"draw_number"`
+	ActualNumbers    valueobject.Numbers `json:"actual_numbers"`
+	MatchCount       int                 `json:"match_count"`       // Final numbers drawn
+	AlgorithmMatches map[string]int      `json:"algorithm_matches"` // Numbers of each algorithm's own prediction drawn
+	VerifiedAt       time.Time           `json:"verified_at"`
+}
+
+// CandidateSet is one of several ranked lines a prediction suggests, with
+// its numbers' mean share of the algorithms' votes
+type CandidateSet struct {
+	Numbers    []int   `json:"numbers"` // Ascending
+	Confidence float64 `json:"confidence"`
+}
+
+// Wheel is an abbreviated wheel: tickets over the best ranked candidates
+// such that, whenever enough winning numbers are among the candidates, some
+// ticket matches the guaranteed count
+type Wheel struct {
+	Guarantee  string  `json:"guarantee"`  // e.g. "3if4": 3 matched on a ticket if 4 winning numbers are candidates
+	Candidates []int   `json:"candidates"` // Numbers the wheel covers, ascending
+	Tickets    [][]int `json:"tickets"`    // Lines to play, each ascending
+}
+
+// CoreNumber is one of the numbers a partial prediction suggests, with the
+// share of the algorithms' votes it got: 1 when every algorithm picked it
+type CoreNumber struct {
+	Number     int     `json:"number"`
+	Confidence float64 `json:"confidence"`
+}
+
+// Provenance records the inputs that produced an ensemble prediction, so a
+// past prediction can be audited and reproduced
+type Provenance struct {
+	ToolVersion     string                `json:"tool_version"`
+	ConfigHash      string                `json:"config_hash,omitempty"`
+	FirstDrawNumber int                   `json:"first_draw_number"` // Oldest draw in the history used
+	LastDrawNumber  int                   `json:"last_draw_number"`  // Newest draw in the history used
+	DrawCount       int                   `json:"draw_count"`
+	Algorithms      []AlgorithmProvenance `json:"algorithms"`
+}
+
+// AlgorithmProvenance records how one algorithm took part in a prediction.
+// Algorithms are versioned with the tool.
+type AlgorithmProvenance struct {
+	Name       string            `json:"name"`
+	Weight     float64           `json:"weight"`
+	Parameters map[string]string `json:"parameters,omitempty"` // The prediction's metadata
+}
+
+// NewProvenance describes a prediction made by toolVersion with the config
+// hashed to configHash from draws, using the weights in contributions
+func NewProvenance(
+	toolVersion string,
+	configHash string,
+	draws []*Draw,
+	predictions []*Prediction,
+	contributions []AlgorithmContribution,
+) *Provenance {
+	p := &Provenance{
+		ToolVersion: toolVersion,
+		ConfigHash:  configHash,
+		DrawCount:   len(draws),
+		Algorithms:  make([]AlgorithmProvenance, 0, len(predictions)),
+	}
+
+	for i, draw := range draws {
+		if i == 0 || draw.DrawNumber < p.FirstDrawNumber {
+			p.FirstDrawNumber = draw.DrawNumber
+		}
+		if draw.DrawNumber > p.LastDrawNumber {
+			p.LastDrawNumber = draw.DrawNumber
+		}
+	}
+
+	weights := make(map[string]float64, len(contributions))
+	for _, c := range contributions {
+		weights[c.AlgorithmName] = c.Weight
+	}
+	for _, pred := range predictions {
+		var params map[string]string
+		if len(pred.Metadata) > 0 {
+			params = make(map[string]string, len(pred.Metadata))
+			for k, v := range pred.Metadata {
+				params[k] = v
+			}
+		}
+		p.Algorithms = append(p.Algorithms, AlgorithmProvenance{
+			Name:       pred.AlgorithmName,
+			Weight:     weights[pred.AlgorithmName],
+			Parameters: params,
+		})
+	}
+
+	return p
 }
 
 // NewEnsemblePrediction creates a new EnsemblePrediction entity
@@ -113,6 +224,41 @@ func (ep *EnsemblePrediction) GetID() string {
 	return ep.ID
 }
 
+// SameTarget reports whether both ensemble predictions are for the same game
+// and target draw date. Predictions without a target date never match.
+func (ep *EnsemblePrediction) SameTarget(other *EnsemblePrediction) bool {
+	if other == nil || ep.ForDate.IsZero() || other.ForDate.IsZero() {
+		return false
+	}
+	return ep.GameType == other.GameType && ep.ForDate.Equal(other.ForDate)
+}
+
+// Verify records how the prediction did against draw, the draw of its
+// game type on its target date
+func (ep *EnsemblePrediction) Verify(draw *Draw) error {
+	if draw.GameType != ep.GameType {
+		return fmt.Errorf("draw is for %s, prediction for %s", draw.GameType, ep.GameType)
+	}
+	year, month, day := draw.DrawDate.Date()
+	if !ep.ForDate.Equal(time.Date(year, month, day, 0, 0, 0, 0, time.UTC)) {
+		return fmt.Errorf("draw #%d on %s isn't the prediction's target %s",
+			draw.DrawNumber, draw.DrawDate.Format("2006-01-02"), ep.ForDate.Format("2006-01-02"))
+	}
+
+	outcome := &PredictionOutcome{
+		DrawNumber:       draw.DrawNumber,
+		ActualNumbers:    draw.Numbers,
+		MatchCount:       draw.Numbers.MatchCount(ep.FinalNumbers),
+		AlgorithmMatches: make(map[string]int, len(ep.Predictions)),
+		VerifiedAt:       time.Now(),
+	}
+	for _, prediction := range ep.Predictions {
+		outcome.AlgorithmMatches[prediction.AlgorithmName] = draw.Numbers.MatchCount(prediction.Numbers)
+	}
+	ep.Outcome = outcome
+	return nil
+}
+
 // GetFinalNumbers returns the final predicted numbers
 func (ep *EnsemblePrediction) GetFinalNumbers() valueobject.Numbers {
 	return ep.FinalNumbers
@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func TestEnsemblePrediction_Verify_RejectsOtherDraws(t *testing.T) {
+	drawDate := time.Date(2026, 3, 4, 18, 0, 0, 0, time.UTC)
+	pick, err := NewPrediction(valueobject.Mega645, "frequency_analysis", valueobject.MustNewNumbers([]int{1, 2, 3, 4, 5, 6}), 0.5, drawDate)
+	require.NoError(t, err)
+	ensemble, err := NewEnsemblePrediction(valueobject.Mega645, []*Prediction{pick}, pick.Numbers, "weighted", nil)
+	require.NoError(t, err)
+	ensemble.ForDate = time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+
+	other, err := NewDraw(valueobject.Mega645, 1200, valueobject.MustNewNumbers([]int{1, 2, 3, 7, 8, 9}), drawDate.AddDate(0, 0, -2), 0, 0)
+	require.NoError(t, err)
+	assert.Error(t, ensemble.Verify(other))
+	assert.Nil(t, ensemble.Outcome)
+
+	target, err := NewDraw(valueobject.Mega645, 1201, valueobject.MustNewNumbers([]int{1, 2, 3, 7, 8, 9}), drawDate, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, ensemble.Verify(target))
+	assert.Equal(t, 3, ensemble.Outcome.MatchCount)
+	assert.Equal(t, 3, ensemble.Outcome.AlgorithmMatches["frequency_analysis"])
+}
+
+func TestAlgorithmStats_RecordOutcome(t *testing.T) {
+	stats, err := NewAlgorithmStats("frequency_analysis", valueobject.Mega645, 1)
+	require.NoError(t, err)
+
+	stats.RecordOutcome(3, 0.4)
+	stats.RecordOutcome(1, 0.6)
+	assert.Equal(t, 2, stats.TotalPredictions)
+	assert.InDelta(t, 0.5, stats.Accuracy3Numbers, 1e-9)
+	assert.InDelta(t, 0.0, stats.Accuracy4Numbers, 1e-9)
+	assert.InDelta(t, 0.5, stats.AverageConfidence, 1e-9)
+}
@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// Scrape sources recorded in ScrapeAudit.Source
+const (
+	ScrapeSourceAPI     = "api"     // The JSON results API
+	ScrapeSourceWeb     = "web"     // The HTML results and detail pages
+	ScrapeSourceBrowser = "browser" // The results pages rendered in a headless browser
+	ScrapeSourcePDF     = "pdf"     // The result announcements and their official PDFs
+)
+
+// ScrapeAudit records one crawl of a results page: what was requested, what
+// came back and what parsed out of it. A run of audits finding rows but no
+// draws is how selector drift and other data-quality problems show up after
+// the fact.
+type ScrapeAudit struct {
+	ID          string               `json:"id"`
+	GameType    valueobject.GameType `json:"game_type"`
+	Source      string               `json:"source"` // One of the ScrapeSource constants
+	URL         string               `json:"url"`
+	Timestamp   time.Time            `json:"timestamp"` // When the crawl started
+	Duration    time.Duration        `json:"duration"`
+	Attempts    int                  `json:"attempts"`              // Requests made, retries included
+	StatusCode  int                  `json:"status_code,omitempty"` // Last response's; 0 when none came back
+	ItemsFound  int                  `json:"items_found"`           // API items or HTML rows on the page
+	DrawsFound  int                  `json:"draws_found"`           // Items that parsed into valid draws
+	ParseErrors []string             `json:"parse_errors,omitempty"`
+	Error       string               `json:"error,omitempty"` // Why the crawl failed; empty when it succeeded
+}
+
+// NewScrapeAudit starts the audit of a crawl of url beginning now
+func NewScrapeAudit(gameType valueobject.GameType, source string, url string) *ScrapeAudit {
+	return &ScrapeAudit{
+		ID:        uuid.New().String(),
+		GameType:  gameType,
+		Source:    source,
+		URL:       url,
+		Timestamp: time.Now(),
+	}
+}
+
+// AddParseError records an item that didn't parse into a draw
+func (a *ScrapeAudit) AddParseError(err error) {
+	a.ParseErrors = append(a.ParseErrors, err.Error())
+}
+
+// Finish records how the crawl ended: err is nil on success
+func (a *ScrapeAudit) Finish(err error) {
+	a.Duration = time.Since(a.Timestamp)
+	if err != nil {
+		a.Error = err.Error()
+	}
+}
+
+// Succeeded reports whether the crawl returned at least one valid draw
+func (a *ScrapeAudit) Succeeded() bool {
+	return a.Error == "" && a.DrawsFound > 0
+}
@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func TestScrapeAudit_Succeeded(t *testing.T) {
+	audit := NewScrapeAudit(valueobject.Mega645, ScrapeSourceWeb, "https://vietlott.vn/results")
+	assert.NotEmpty(t, audit.ID)
+	assert.False(t, audit.Timestamp.IsZero())
+
+	// Rows on the page but none parsed: the crawl didn't succeed
+	audit.ItemsFound = 3
+	audit.AddParseError(errors.New("row 0: expected 6 numbers, got 5"))
+	audit.Finish(nil)
+	assert.False(t, audit.Succeeded())
+	assert.Equal(t, []string{"row 0: expected 6 numbers, got 5"}, audit.ParseErrors)
+
+	audit.DrawsFound = 2
+	assert.True(t, audit.Succeeded())
+
+	audit.Finish(errors.New("server returned status 503"))
+	assert.Equal(t, "server returned status 503", audit.Error)
+	assert.False(t, audit.Succeeded())
+}
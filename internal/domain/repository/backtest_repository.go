@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/tool_predict/internal/domain/entity"
 	"github.com/tool_predict/internal/domain/valueobject"
@@ -29,11 +30,11 @@ type BacktestRepository interface {
 	// FindByGameType finds all backtest results for a specific game type
 	FindByGameType(ctx context.Context, gameType valueobject.GameType) ([]*entity.BacktestResult, error)
 
-	// FindByDateRange finds backtest results within a date range
+	// FindByDateRange finds backtest results whose test period overlaps a
+	// date range, excluding periods starting at its end
 	FindByDateRange(
 		ctx context.Context,
-		startDate interface{}, // time.Time
-		endDate interface{}, // time.Time
+		dateRange valueobject.DateRange,
 	) ([]*entity.BacktestResult, error)
 
 	// FindBestPerforming finds the best performing algorithm for a game type
@@ -44,5 +45,5 @@ type BacktestRepository interface {
 	) (*entity.BacktestResult, error)
 
 	// DeleteOld removes backtest results older than a certain date
-	DeleteOld(ctx context.Context, beforeDate interface{}) error // time.Time
+	DeleteOld(ctx context.Context, before time.Time) error
 }
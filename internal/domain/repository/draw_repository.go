@@ -47,6 +47,25 @@ type DrawRepository interface {
 		endDrawNumber int,
 	) ([]*entity.Draw, error)
 
+	// FindPage finds up to limit draws for a game type, newest first, after
+	// skipping the offset newest; FindPage(ctx, gameType, 0, n) matches
+	// FindLatest(ctx, gameType, n)
+	FindPage(
+		ctx context.Context,
+		gameType valueobject.GameType,
+		offset int,
+		limit int,
+	) ([]*entity.Draw, error)
+
+	// ForEachDraw calls fn with each draw of a game type, oldest first,
+	// without holding them all in memory. It stops at the first error fn
+	// returns, or when ctx is done, and returns that error.
+	ForEachDraw(
+		ctx context.Context,
+		gameType valueobject.GameType,
+		fn func(draw *entity.Draw) error,
+	) error
+
 	// Count returns the total number of draws for a game type
 	Count(ctx context.Context, gameType valueobject.GameType) (int64, error)
 
@@ -0,0 +1,7 @@
+package repository
+
+import "errors"
+
+// ErrNotFound is wrapped by the errors of lookups that found nothing, so
+// callers can tell a missing record from a storage failure with errors.Is
+var ErrNotFound = errors.New("not found")
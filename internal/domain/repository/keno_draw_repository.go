@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/tool_predict/internal/domain/entity"
+)
+
+// KenoDrawRepository defines the interface for Keno draw persistence
+type KenoDrawRepository interface {
+	// Save saves a Keno draw, replacing a stored draw with the same number
+	Save(ctx context.Context, draw *entity.KenoDraw) error
+
+	// SaveBatch saves multiple Keno draws
+	SaveBatch(ctx context.Context, draws []*entity.KenoDraw) error
+
+	// FindLatest finds the most recent Keno draws, newest first
+	FindLatest(ctx context.Context, limit int) ([]*entity.KenoDraw, error)
+
+	// Count returns the number of stored Keno draws
+	Count(ctx context.Context) (int64, error)
+}
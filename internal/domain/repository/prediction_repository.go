@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/tool_predict/internal/domain/entity"
 	"github.com/tool_predict/internal/domain/valueobject"
@@ -15,9 +16,19 @@ type PredictionRepository interface {
 	// SaveBatch saves multiple predictions in a single transaction
 	SaveBatch(ctx context.Context, predictions []*entity.Prediction) error
 
-	// SaveEnsemble saves an ensemble prediction to the repository
+	// SaveEnsemble saves an ensemble prediction to the repository. Unless the
+	// repository keeps history, it supersedes older ensembles for the same
+	// game type and target draw date.
 	SaveEnsemble(ctx context.Context, ensemble *entity.EnsemblePrediction) error
 
+	// FindCurrentEnsemble finds the newest ensemble prediction for a game type
+	// and target draw date
+	FindCurrentEnsemble(
+		ctx context.Context,
+		gameType valueobject.GameType,
+		forDate time.Time,
+	) (*entity.EnsemblePrediction, error)
+
 	// FindByID finds a prediction by its unique identifier
 	FindByID(ctx context.Context, id string) (*entity.Prediction, error)
 
@@ -46,17 +57,17 @@ type PredictionRepository interface {
 		limit int,
 	) ([]*entity.Prediction, error)
 
-	// FindByDateRange finds predictions within a date range for a game type
+	// FindByDateRange finds predictions for a game type generated within a
+	// date range, from its start up to but excluding its end
 	FindByDateRange(
 		ctx context.Context,
 		gameType valueobject.GameType,
-		startDate interface{}, // time.Time
-		endDate interface{}, // time.Time
+		dateRange valueobject.DateRange,
 	) ([]*entity.Prediction, error)
 
 	// Count returns the total number of predictions for a game type
 	Count(ctx context.Context, gameType valueobject.GameType) (int64, error)
 
 	// DeleteOld removes predictions older than a certain date
-	DeleteOld(ctx context.Context, beforeDate interface{}) error // time.Time
+	DeleteOld(ctx context.Context, before time.Time) error
 }
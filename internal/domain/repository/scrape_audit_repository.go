@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// ScrapeAuditRepository defines the interface for the scrape audit log
+type ScrapeAuditRepository interface {
+	// Save appends an audit to the log
+	Save(ctx context.Context, audit *entity.ScrapeAudit) error
+
+	// FindLatest finds a game's most recent audits, newest first
+	FindLatest(ctx context.Context, gameType valueobject.GameType, limit int) ([]*entity.ScrapeAudit, error)
+
+	// FindByDateRange finds a game's audits of crawls started within a date
+	// range, newest first
+	FindByDateRange(
+		ctx context.Context,
+		gameType valueobject.GameType,
+		dateRange valueobject.DateRange,
+	) ([]*entity.ScrapeAudit, error)
+}
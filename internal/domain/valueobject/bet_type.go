@@ -0,0 +1,92 @@
+package valueobject
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// BetType is how many numbers a Vietlott slip marks. A single line marks
+// the game's six; a bao (system) bet marks more or fewer and plays every
+// six-number line they form.
+type BetType int
+
+// BetSingle is a single six-number line
+const BetSingle BetType = 6
+
+// baoSizes are the bao bets Vietlott sells for each game
+var baoSizes = map[GameType][]int{
+	Mega645:  {5, 7, 8, 9, 10, 11, 12, 13, 14, 15, 18},
+	Power655: {5, 7, 8, 9, 10, 11, 12, 13, 14, 15, 18},
+}
+
+// ParseBetType parses "single" (or an empty string) and bao bets such as
+// "bao8" or "bao 8". It doesn't check the game offers the bet; see
+// ValidateFor.
+func ParseBetType(s string) (BetType, error) {
+	normalized := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(s)), " ", "")
+	if normalized == "" || normalized == "single" {
+		return BetSingle, nil
+	}
+
+	size, err := strconv.Atoi(strings.TrimPrefix(normalized, "bao"))
+	if err != nil || size < 1 || !strings.HasPrefix(normalized, "bao") {
+		return 0, fmt.Errorf("invalid bet type: %q (expected single or bao followed by a size, e.g. bao8)", s)
+	}
+	return BetType(size), nil
+}
+
+// NumberCount returns how many numbers the slip marks
+func (bt BetType) NumberCount() int {
+	return int(bt)
+}
+
+// IsBao reports whether the bet marks other than six numbers
+func (bt BetType) IsBao() bool {
+	return bt != BetSingle
+}
+
+// ValidateFor checks that Vietlott sells this bet for gameType
+func (bt BetType) ValidateFor(gameType GameType) error {
+	if bt == BetSingle || slices.Contains(baoSizes[gameType], int(bt)) {
+		return nil
+	}
+
+	offered := make([]string, 0, len(baoSizes[gameType]))
+	for _, size := range baoSizes[gameType] {
+		offered = append(offered, BetType(size).String())
+	}
+	return fmt.Errorf("%s is not sold for %s (available: single, %s)", bt, gameType, strings.Join(offered, ", "))
+}
+
+// Lines returns how many six-number lines the bet plays. A bao 5 plays its
+// five numbers with each other number of the game.
+func (bt BetType) Lines(gameType GameType) int {
+	n := bt.NumberCount()
+	if n >= 6 {
+		return binomial(n, 6)
+	}
+	minRange, maxRange := gameType.NumberRange()
+	return binomial(maxRange-minRange+1-n, 6-n)
+}
+
+// String returns "single" or "bao" followed by the size
+func (bt BetType) String() string {
+	if bt == BetSingle {
+		return "single"
+	}
+	return fmt.Sprintf("bao%d", int(bt))
+}
+
+// binomial returns n choose k
+func binomial(n, k int) int {
+	if k < 0 || k > n {
+		return 0
+	}
+	result := 1
+	for i := 1; i <= k; i++ {
+		result = result * (n - k + i) / i
+	}
+	return result
+}
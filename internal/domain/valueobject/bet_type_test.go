@@ -0,0 +1,52 @@
+package valueobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBetType(t *testing.T) {
+	tests := []struct {
+		input string
+		want  BetType
+	}{
+		{"", BetSingle},
+		{"single", BetSingle},
+		{"bao8", 8},
+		{"Bao 10", 10},
+		{" BAO5 ", 5},
+	}
+	for _, tt := range tests {
+		got, err := ParseBetType(tt.input)
+		require.NoError(t, err, tt.input)
+		assert.Equal(t, tt.want, got, tt.input)
+	}
+
+	for _, input := range []string{"bao", "bao-8", "eight", "8"} {
+		_, err := ParseBetType(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestBetType_ValidateFor(t *testing.T) {
+	assert.NoError(t, BetSingle.ValidateFor(Mega645))
+	assert.NoError(t, BetType(8).ValidateFor(Mega645))
+	assert.NoError(t, BetType(18).ValidateFor(Power655))
+
+	assert.ErrorContains(t, BetType(16).ValidateFor(Mega645), "bao16 is not sold")
+	assert.Error(t, BetType(4).ValidateFor(Power655))
+	assert.Error(t, BetType(8).ValidateFor(GameType("KENO")))
+}
+
+func TestBetType_Lines(t *testing.T) {
+	assert.Equal(t, 1, BetSingle.Lines(Mega645))
+	assert.Equal(t, 7, BetType(7).Lines(Mega645))
+	assert.Equal(t, 28, BetType(8).Lines(Mega645))
+	assert.Equal(t, 18564, BetType(18).Lines(Power655))
+	assert.Equal(t, 40, BetType(5).Lines(Mega645))
+	assert.Equal(t, 50, BetType(5).Lines(Power655))
+	assert.Equal(t, "bao8", BetType(8).String())
+	assert.Equal(t, "single", BetSingle.String())
+}
@@ -0,0 +1,114 @@
+package valueobject
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// GameDefinition is the rules of a game: its number range, how many numbers
+// a ticket picks and the weekdays it is drawn on
+type GameDefinition struct {
+	MinNumber int
+	MaxNumber int
+	PickCount int
+	DrawDays  []time.Weekday
+}
+
+// Validate checks that the definition describes a playable game
+func (d GameDefinition) Validate() error {
+	if d.MinNumber < 1 {
+		return fmt.Errorf("lowest number must be at least 1, got %d", d.MinNumber)
+	}
+	// Numbers holds exactly six, so a ticket can't pick any other count yet
+	if d.PickCount != 6 {
+		return fmt.Errorf("pick count must be 6, got %d", d.PickCount)
+	}
+	if d.MaxNumber-d.MinNumber+1 < d.PickCount {
+		return fmt.Errorf("number range %d-%d is too small to pick %d numbers", d.MinNumber, d.MaxNumber, d.PickCount)
+	}
+	seen := make(map[time.Weekday]bool, len(d.DrawDays))
+	for _, day := range d.DrawDays {
+		if day < time.Sunday || day > time.Saturday {
+			return fmt.Errorf("invalid draw day %d", day)
+		}
+		if seen[day] {
+			return fmt.Errorf("draw day %s listed twice", day)
+		}
+		seen[day] = true
+	}
+	return nil
+}
+
+// GameRegistry holds the definition of every supported game type
+type GameRegistry struct {
+	games map[GameType]GameDefinition
+}
+
+// NewGameRegistry creates a registry with the built-in definitions of every
+// game type, to be overridden with Define
+func NewGameRegistry() *GameRegistry {
+	return &GameRegistry{
+		games: map[GameType]GameDefinition{
+			Mega645: {
+				MinNumber: 1,
+				MaxNumber: 45,
+				PickCount: 6,
+				DrawDays:  []time.Weekday{time.Wednesday, time.Friday, time.Sunday},
+			},
+			Power655: {
+				MinNumber: 1,
+				MaxNumber: 55,
+				PickCount: 6,
+				DrawDays:  []time.Weekday{time.Tuesday, time.Thursday, time.Saturday},
+			},
+		},
+	}
+}
+
+// Define replaces the definition of a supported game type
+func (r *GameRegistry) Define(gameType GameType, def GameDefinition) error {
+	if _, ok := r.games[gameType]; !ok {
+		return fmt.Errorf("invalid game type: %s", gameType)
+	}
+	if err := def.Validate(); err != nil {
+		return fmt.Errorf("invalid definition for %s: %w", gameType, err)
+	}
+	def.DrawDays = append([]time.Weekday(nil), def.DrawDays...)
+	r.games[gameType] = def
+	return nil
+}
+
+// Lookup returns the definition of a game type
+func (r *GameRegistry) Lookup(gameType GameType) (GameDefinition, bool) {
+	def, ok := r.games[gameType]
+	return def, ok
+}
+
+// games is the registry GameType methods consult
+var games atomic.Pointer[GameRegistry]
+
+func init() {
+	games.Store(NewGameRegistry())
+}
+
+// UseGameRegistry makes GameType methods consult registry and returns a
+// function restoring the previous one. The registry must not be changed
+// afterwards.
+func UseGameRegistry(registry *GameRegistry) (restore func()) {
+	previous := games.Swap(registry)
+	return func() {
+		games.Store(previous)
+	}
+}
+
+// definition returns the game type's definition in the active registry, or
+// Mega 6/45's for an unknown game type
+func (gt GameType) definition() GameDefinition {
+	registry := games.Load()
+	if def, ok := registry.Lookup(gt); ok {
+		return def
+	}
+	def, _ := registry.Lookup(Mega645)
+	return def
+}
@@ -2,6 +2,8 @@ package valueobject
 
 import (
 	"fmt"
+	"strings"
+	"time"
 )
 
 // GameType represents the type of Vietlott lottery game
@@ -14,21 +16,100 @@ const (
 	Power655 GameType = "POWER_6_55"
 )
 
-// NumberRange returns the minimum and maximum valid numbers for this game type
+// gameTypeAliases maps normalized user input to canonical game types.
+// Keys have case, spaces and the separators "_", "-", "/" and "." removed.
+var gameTypeAliases = map[string]GameType{
+	"mega645": Mega645,
+	"mega":    Mega645,
+	"645":     Mega645,
+	"m645":    Mega645,
+
+	"power655": Power655,
+	"power":    Power655,
+	"655":      Power655,
+	"p655":     Power655,
+}
+
+// ParseGameType parses a game type from user input, accepting the canonical
+// names and common aliases such as "mega", "6/45" or "power655"
+func ParseGameType(s string) (GameType, error) {
+	normalized := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '_', '-', '/', '.':
+			return -1
+		}
+		return r
+	}, strings.ToLower(strings.TrimSpace(s)))
+
+	if gt, ok := gameTypeAliases[normalized]; ok {
+		return gt, nil
+	}
+	return "", fmt.Errorf("invalid game type: %q (expected %s or %s)", s, Mega645, Power655)
+}
+
+// NumberRange returns the minimum and maximum valid numbers for this game
+// type, as defined in the game registry (see UseGameRegistry)
 func (gt GameType) NumberRange() (int, int) {
-	switch gt {
-	case Mega645:
-		return 1, 45
-	case Power655:
-		return 1, 55
-	default:
-		return 1, 45
+	def := gt.definition()
+	return def.MinNumber, def.MaxNumber
+}
+
+// InRange reports whether n is a valid number for this game type
+func (gt GameType) InRange(n int) bool {
+	minRange, maxRange := gt.NumberRange()
+	return n >= minRange && n <= maxRange
+}
+
+// ValidateNumber checks that n is within this game type's number range
+func (gt GameType) ValidateNumber(n int) error {
+	if !gt.InRange(n) {
+		minRange, maxRange := gt.NumberRange()
+		return fmt.Errorf("number %d is out of range for game type %s (%d-%d)",
+			n, gt, minRange, maxRange)
 	}
+	return nil
 }
 
-// NumberCount returns the count of numbers to select (always 6 for Vietlott)
+// DrawDays returns the weekdays on which this game type is drawn, as defined
+// in the game registry; nil for an unknown game type
+func (gt GameType) DrawDays() []time.Weekday {
+	if gt.Validate() != nil {
+		return nil
+	}
+	return append([]time.Weekday(nil), gt.definition().DrawDays...)
+}
+
+// IsDrawDay reports whether this game type is drawn on the given weekday
+func (gt GameType) IsDrawDay(day time.Weekday) bool {
+	for _, drawDay := range gt.DrawDays() {
+		if drawDay == day {
+			return true
+		}
+	}
+	return false
+}
+
+// NextDrawDate returns the first scheduled draw date after the calendar day
+// of t (read in t's location), as midnight UTC
+func (gt GameType) NextDrawDate(t time.Time) time.Time {
+	year, month, day := t.Date()
+	date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	if len(gt.DrawDays()) == 0 {
+		return date.AddDate(0, 0, 1)
+	}
+
+	for {
+		date = date.AddDate(0, 0, 1)
+		if gt.IsDrawDay(date.Weekday()) {
+			return date
+		}
+	}
+}
+
+// NumberCount returns the count of numbers to select, as defined in the game
+// registry (6 for every Vietlott game)
 func (gt GameType) NumberCount() int {
-	return 6
+	return gt.definition().PickCount
 }
 
 // Validate checks if the game type is valid
@@ -39,6 +120,11 @@ func (gt GameType) Validate() error {
 	return nil
 }
 
+// AllGameTypes returns every supported game type
+func AllGameTypes() []GameType {
+	return []GameType{Mega645, Power655}
+}
+
 // String returns the string representation of the game type
 func (gt GameType) String() string {
 	return string(gt)
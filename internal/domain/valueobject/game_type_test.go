@@ -0,0 +1,112 @@
+package valueobject
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGameType(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected GameType
+	}{
+		{"MEGA_6_45", Mega645},
+		{"mega_6_45", Mega645},
+		{"mega", Mega645},
+		{"Mega", Mega645},
+		{"6/45", Mega645},
+		{"645", Mega645},
+		{"mega645", Mega645},
+		{"Mega 6/45", Mega645},
+		{" mega-6-45 ", Mega645},
+		{"POWER_6_55", Power655},
+		{"power", Power655},
+		{"POWER", Power655},
+		{"6/55", Power655},
+		{"655", Power655},
+		{"power655", Power655},
+		{"Power 6/55", Power655},
+		{"p655", Power655},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			gt, err := ParseGameType(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, gt)
+			assert.NoError(t, gt.Validate())
+		})
+	}
+}
+
+func TestParseGameType_Invalid(t *testing.T) {
+	for _, input := range []string{"", "keno", "6/49", "megapower"} {
+		t.Run(input, func(t *testing.T) {
+			gt, err := ParseGameType(input)
+			require.Error(t, err)
+			assert.Empty(t, gt)
+			assert.Contains(t, err.Error(), "invalid game type")
+		})
+	}
+}
+
+func TestGameType_ValidateNumber(t *testing.T) {
+	assert.NoError(t, Mega645.ValidateNumber(1))
+	assert.NoError(t, Mega645.ValidateNumber(45))
+	assert.Error(t, Mega645.ValidateNumber(0))
+	assert.Error(t, Mega645.ValidateNumber(55))
+
+	assert.NoError(t, Power655.ValidateNumber(55))
+	assert.Error(t, Power655.ValidateNumber(56))
+}
+
+func TestGameType_DrawDays(t *testing.T) {
+	assert.Equal(t, []time.Weekday{time.Wednesday, time.Friday, time.Sunday}, Mega645.DrawDays())
+	assert.Equal(t, []time.Weekday{time.Tuesday, time.Thursday, time.Saturday}, Power655.DrawDays())
+
+	assert.True(t, Mega645.IsDrawDay(time.Sunday))
+	assert.False(t, Mega645.IsDrawDay(time.Saturday))
+	assert.True(t, Power655.IsDrawDay(time.Saturday))
+}
+
+func TestGameType_NextDrawDate(t *testing.T) {
+	ict := time.FixedZone("ICT", 7*60*60)
+
+	// Power 6/55 draws Tue/Thu/Sat: a Thursday evening draw is followed by Saturday
+	thursday := time.Date(2026, 1, 8, 18, 0, 0, 0, ict)
+	assert.Equal(t, time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC), Power655.NextDrawDate(thursday))
+
+	// From a non-draw day (Monday) Mega 6/45's next draw is Wednesday
+	monday := time.Date(2026, 1, 12, 9, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2026, 1, 14, 0, 0, 0, 0, time.UTC), Mega645.NextDrawDate(monday))
+}
+
+func TestGameRegistry_Define(t *testing.T) {
+	registry := NewGameRegistry()
+
+	require.NoError(t, registry.Define(Mega645, GameDefinition{
+		MinNumber: 1, MaxNumber: 50, PickCount: 6, DrawDays: []time.Weekday{time.Monday},
+	}))
+	restore := UseGameRegistry(registry)
+	t.Cleanup(restore)
+
+	low, high := Mega645.NumberRange()
+	assert.Equal(t, 1, low)
+	assert.Equal(t, 50, high)
+	assert.Equal(t, []time.Weekday{time.Monday}, Mega645.DrawDays())
+	assert.NoError(t, Mega645.ValidateNumber(50))
+
+	// Power 6/55 keeps its built-in rules
+	_, high = Power655.NumberRange()
+	assert.Equal(t, 55, high)
+
+	assert.Error(t, registry.Define(GameType("KENO"), GameDefinition{MinNumber: 1, MaxNumber: 80, PickCount: 6}))
+	assert.Error(t, registry.Define(Mega645, GameDefinition{MinNumber: 1, MaxNumber: 45, PickCount: 5}))
+	assert.Error(t, registry.Define(Mega645, GameDefinition{MinNumber: 1, MaxNumber: 5, PickCount: 6}))
+	assert.Error(t, registry.Define(Mega645, GameDefinition{
+		MinNumber: 1, MaxNumber: 45, PickCount: 6, DrawDays: []time.Weekday{time.Friday, time.Friday},
+	}))
+}
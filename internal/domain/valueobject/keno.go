@@ -0,0 +1,89 @@
+package valueobject
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Keno is the Vietlott Keno game: 20 of the numbers 01-80 are drawn every few
+// minutes through the day and players pick 1 to 10 of them. Keno draws and
+// tickets vary in length, so they use KenoNumbers rather than Numbers, and
+// Keno is not among AllGameTypes, whose draws all have six numbers.
+const Keno GameType = "KENO"
+
+// Keno game rules
+const (
+	KenoMinNumber = 1
+	KenoMaxNumber = 80
+	KenoDrawSize  = 20 // Numbers drawn per Keno draw
+	KenoMaxSpots  = 10 // Most numbers a Keno ticket can pick
+)
+
+// KenoNumbers is a sorted set of distinct Keno numbers: a draw's 20 or a
+// ticket's 1 to 10
+type KenoNumbers []int
+
+// NewKenoNumbers creates a KenoNumbers value object holding between 1 and
+// KenoDrawSize distinct numbers within 01-80
+func NewKenoNumbers(nums []int) (KenoNumbers, error) {
+	if len(nums) < 1 || len(nums) > KenoDrawSize {
+		return nil, fmt.Errorf("keno numbers must have between 1 and %d numbers, got %d", KenoDrawSize, len(nums))
+	}
+
+	seen := make(map[int]bool, len(nums))
+	for _, n := range nums {
+		if n < KenoMinNumber || n > KenoMaxNumber {
+			return nil, fmt.Errorf("keno numbers must be between %d-%d, got %d", KenoMinNumber, KenoMaxNumber, n)
+		}
+		if seen[n] {
+			return nil, fmt.Errorf("numbers must be unique, duplicate found: %d", n)
+		}
+		seen[n] = true
+	}
+
+	sorted := make(KenoNumbers, len(nums))
+	copy(sorted, nums)
+	sort.Ints(sorted)
+	return sorted, nil
+}
+
+// NewKenoTicket creates the KenoNumbers of a ticket, which picks between 1
+// and KenoMaxSpots numbers
+func NewKenoTicket(nums []int) (KenoNumbers, error) {
+	if len(nums) > KenoMaxSpots {
+		return nil, fmt.Errorf("a keno ticket picks at most %d numbers, got %d", KenoMaxSpots, len(nums))
+	}
+	return NewKenoNumbers(nums)
+}
+
+// Contains reports whether num is one of the numbers
+func (k KenoNumbers) Contains(num int) bool {
+	i := sort.SearchInts(k, num)
+	return i < len(k) && k[i] == num
+}
+
+// MatchCount returns how many of other's numbers are among these
+func (k KenoNumbers) MatchCount(other KenoNumbers) int {
+	count := 0
+	for _, num := range other {
+		if k.Contains(num) {
+			count++
+		}
+	}
+	return count
+}
+
+// AsSlice returns the numbers as a plain slice
+func (k KenoNumbers) AsSlice() []int {
+	return []int(k)
+}
+
+// String returns the numbers two digits each, e.g. "[03, 17, 80]"
+func (k KenoNumbers) String() string {
+	parts := make([]string, len(k))
+	for i, num := range k {
+		parts[i] = fmt.Sprintf("%02d", num)
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
@@ -0,0 +1,43 @@
+package valueobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewKenoNumbers(t *testing.T) {
+	draw := []int{80, 1, 5, 12, 19, 23, 27, 33, 38, 41, 44, 50, 52, 57, 61, 66, 70, 72, 75, 79}
+	numbers, err := NewKenoNumbers(draw)
+	require.NoError(t, err)
+	assert.Len(t, numbers, KenoDrawSize)
+	assert.Equal(t, 1, numbers[0])
+	assert.Equal(t, 80, numbers[len(numbers)-1])
+	assert.Equal(t, 80, draw[0], "input must not be reordered")
+
+	ticket, err := NewKenoTicket([]int{5, 80, 3})
+	require.NoError(t, err)
+	assert.Equal(t, "[03, 05, 80]", ticket.String())
+	assert.Equal(t, 2, numbers.MatchCount(ticket))
+	assert.True(t, numbers.Contains(80))
+	assert.False(t, numbers.Contains(3))
+
+	_, err = NewKenoNumbers(nil)
+	assert.Error(t, err)
+	_, err = NewKenoNumbers(append(draw, 2))
+	assert.Error(t, err)
+	_, err = NewKenoNumbers([]int{0, 5})
+	assert.Error(t, err)
+	_, err = NewKenoNumbers([]int{81})
+	assert.Error(t, err)
+	_, err = NewKenoNumbers([]int{7, 7})
+	assert.Error(t, err)
+	_, err = NewKenoTicket(draw[:11])
+	assert.Error(t, err)
+}
+
+func TestKeno_NotASixNumberGame(t *testing.T) {
+	assert.Error(t, Keno.Validate())
+	assert.NotContains(t, AllGameTypes(), Keno)
+}
@@ -8,8 +8,59 @@ import (
 // Numbers represents a set of 6 unique lottery numbers
 type Numbers []int
 
-// NewNumbers creates a new Numbers value object with validation
+// NewNumbers creates a new Numbers value object with validation. Without a
+// game type, numbers are checked against the widest supported game range; use
+// NewNumbersForGame to enforce a specific game's range.
 func NewNumbers(nums []int) (Numbers, error) {
+	minRange, maxRange := supportedNumberRange()
+	return newNumbers(nums, func(n int) error {
+		if n < minRange || n > maxRange {
+			return fmt.Errorf("numbers must be between %d-%d, got %d", minRange, maxRange, n)
+		}
+		return nil
+	})
+}
+
+// NewNumbersForGame creates a new Numbers value object, validating each number
+// against the game type's range
+func NewNumbersForGame(nums []int, gameType GameType) (Numbers, error) {
+	return newNumbers(nums, gameType.ValidateNumber)
+}
+
+// ValidateNumbers checks nums against every rule NewNumbersForGame enforces
+// and returns all problems found, in input order, rather than stopping at
+// the first. Each duplicated value is reported once. It returns nil when nums
+// is a valid ticket for gameType.
+func ValidateNumbers(nums []int, gameType GameType) []error {
+	var errs []error
+
+	gameErr := gameType.Validate()
+	if gameErr != nil {
+		errs = append(errs, gameErr)
+	}
+	if count := gameType.NumberCount(); len(nums) != count {
+		errs = append(errs, fmt.Errorf("must have exactly %d numbers, got %d", count, len(nums)))
+	}
+
+	seen := make(map[int]int, len(nums))
+	for _, n := range nums {
+		// Out-of-range values are reported once, like duplicates
+		if gameErr == nil && seen[n] == 0 {
+			if err := gameType.ValidateNumber(n); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		seen[n]++
+		if seen[n] == 2 {
+			errs = append(errs, fmt.Errorf("numbers must be unique, duplicate found: %d", n))
+		}
+	}
+
+	return errs
+}
+
+// newNumbers validates count, range and uniqueness and returns a sorted copy
+func newNumbers(nums []int, validateRange func(int) error) (Numbers, error) {
 	if len(nums) != 6 {
 		return nil, fmt.Errorf("must have exactly 6 numbers, got %d", len(nums))
 	}
@@ -17,8 +68,8 @@ func NewNumbers(nums []int) (Numbers, error) {
 	// Validate range and uniqueness
 	seen := make(map[int]bool)
 	for _, n := range nums {
-		if n < 1 || n > 55 {
-			return nil, fmt.Errorf("numbers must be between 1-55, got %d", n)
+		if err := validateRange(n); err != nil {
+			return nil, err
 		}
 		if seen[n] {
 			return nil, fmt.Errorf("numbers must be unique, duplicate found: %d", n)
@@ -34,6 +85,21 @@ func NewNumbers(nums []int) (Numbers, error) {
 	return sorted, nil
 }
 
+// supportedNumberRange returns the union of all game types' number ranges
+func supportedNumberRange() (int, int) {
+	minRange, maxRange := 0, 0
+	for i, gt := range AllGameTypes() {
+		lo, hi := gt.NumberRange()
+		if i == 0 || lo < minRange {
+			minRange = lo
+		}
+		if i == 0 || hi > maxRange {
+			maxRange = hi
+		}
+	}
+	return minRange, maxRange
+}
+
 // MustNewNumbers creates a Numbers value object and panics on error
 // Useful for tests with known valid data
 func MustNewNumbers(nums []int) Numbers {
@@ -60,6 +126,60 @@ func (n Numbers) MatchCount(other Numbers) int {
 	return count
 }
 
+// IntersectInts returns the numbers in both a and b, ascending and without
+// duplicates. It works on plain slices, so it also fits pools and rankings of
+// any size, not just six-number tickets.
+func IntersectInts(a, b []int) []int {
+	inA := make(map[int]bool, len(a))
+	for _, num := range a {
+		inA[num] = true
+	}
+
+	result := make([]int, 0, min(len(a), len(b)))
+	for _, num := range b {
+		if inA[num] {
+			result = append(result, num)
+			inA[num] = false // Keep duplicates in b out
+		}
+	}
+	sort.Ints(result)
+	return result
+}
+
+// UnionInts returns the numbers in any of sets, ascending and without
+// duplicates
+func UnionInts(sets ...[]int) []int {
+	seen := make(map[int]bool)
+	result := make([]int, 0)
+	for _, set := range sets {
+		for _, num := range set {
+			if !seen[num] {
+				seen[num] = true
+				result = append(result, num)
+			}
+		}
+	}
+	sort.Ints(result)
+	return result
+}
+
+// DifferenceInts returns the numbers in a but not in b, ascending and
+// without duplicates
+func DifferenceInts(a, b []int) []int {
+	excluded := make(map[int]bool, len(b))
+	for _, num := range b {
+		excluded[num] = true
+	}
+
+	result := make([]int, 0, len(a))
+	for _, num := range UnionInts(a) {
+		if !excluded[num] {
+			result = append(result, num)
+		}
+	}
+	return result
+}
+
 // Contains checks if a number is present in the set
 func (n Numbers) Contains(num int) bool {
 	for _, v := range n {
@@ -79,6 +199,58 @@ func (n Numbers) Sum() int {
 	return sum
 }
 
+// Min returns the smallest number, or 0 for an empty set
+func (n Numbers) Min() int {
+	if len(n) == 0 {
+		return 0
+	}
+	lowest := n[0]
+	for _, num := range n[1:] {
+		if num < lowest {
+			lowest = num
+		}
+	}
+	return lowest
+}
+
+// Max returns the largest number, or 0 for an empty set
+func (n Numbers) Max() int {
+	if len(n) == 0 {
+		return 0
+	}
+	highest := n[0]
+	for _, num := range n[1:] {
+		if num > highest {
+			highest = num
+		}
+	}
+	return highest
+}
+
+// Range returns the spread between the largest and smallest numbers
+func (n Numbers) Range() int {
+	return n.Max() - n.Min()
+}
+
+// Median returns the median of the numbers. For an even count it is the
+// mean of the two middle values; an empty set returns 0.
+func (n Numbers) Median() float64 {
+	if len(n) == 0 {
+		return 0
+	}
+
+	// Don't assume the set is sorted, it may have been built as a literal
+	sorted := make([]int, len(n))
+	copy(sorted, n)
+	sort.Ints(sorted)
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return float64(sorted[mid-1]+sorted[mid]) / 2
+	}
+	return float64(sorted[mid])
+}
+
 // AsSlice returns the numbers as a slice
 func (n Numbers) AsSlice() []int {
 	return []int(n)
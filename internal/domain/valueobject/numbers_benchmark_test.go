@@ -0,0 +1,20 @@
+package valueobject_test
+
+import (
+	"testing"
+
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/testutil/fixtures"
+)
+
+func BenchmarkNumbers_MatchCount(b *testing.B) {
+	draws := fixtures.Draws(valueobject.Mega645, 1000)
+	ticket := valueobject.MustNewNumbers([]int{3, 11, 19, 27, 35, 43})
+
+	b.ReportAllocs()
+	i := 0
+	for b.Loop() {
+		ticket.MatchCount(draws[i%len(draws)].Numbers)
+		i++
+	}
+}
@@ -0,0 +1,137 @@
+package valueobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNumbers_Median(t *testing.T) {
+	tests := []struct {
+		name     string
+		numbers  Numbers
+		expected float64
+	}{
+		{"six numbers", MustNewNumbers([]int{3, 9, 17, 22, 30, 41}), 19.5},
+		{"even sum of middles", MustNewNumbers([]int{1, 2, 10, 12, 40, 55}), 11},
+		{"four numbers", Numbers{4, 8, 15, 16}, 11.5},
+		{"unsorted even", Numbers{40, 2, 30, 10}, 20},
+		{"odd count", Numbers{5, 1, 9}, 5},
+		{"single", Numbers{7}, 7},
+		{"empty", Numbers{}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.numbers.Median())
+		})
+	}
+}
+
+func TestNumbers_Median_DoesNotReorder(t *testing.T) {
+	n := Numbers{40, 2, 30, 10}
+	n.Median()
+	assert.Equal(t, Numbers{40, 2, 30, 10}, n)
+}
+
+func TestNumbers_MinMaxRange(t *testing.T) {
+	n := MustNewNumbers([]int{45, 3, 22, 17, 30, 9})
+	assert.Equal(t, 3, n.Min())
+	assert.Equal(t, 45, n.Max())
+	assert.Equal(t, 42, n.Range())
+
+	unsorted := Numbers{12, 50, 7, 33}
+	assert.Equal(t, 7, unsorted.Min())
+	assert.Equal(t, 50, unsorted.Max())
+	assert.Equal(t, 43, unsorted.Range())
+
+	empty := Numbers{}
+	assert.Equal(t, 0, empty.Min())
+	assert.Equal(t, 0, empty.Max())
+	assert.Equal(t, 0, empty.Range())
+}
+
+func TestNewNumbersForGame_RespectsGameRange(t *testing.T) {
+	withFiftyFive := []int{3, 9, 17, 22, 30, 55}
+
+	_, err := NewNumbersForGame(withFiftyFive, Mega645)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "out of range for game type MEGA_6_45")
+
+	n, err := NewNumbersForGame(withFiftyFive, Power655)
+	require.NoError(t, err)
+	assert.Equal(t, 55, n.Max())
+}
+
+func TestNewNumbers_UsesWidestGameRange(t *testing.T) {
+	_, err := NewNumbers([]int{3, 9, 17, 22, 30, 55})
+	assert.NoError(t, err)
+
+	_, err = NewNumbers([]int{3, 9, 17, 22, 30, 56})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "between 1-55")
+
+	_, err = NewNumbers([]int{0, 9, 17, 22, 30, 45})
+	assert.Error(t, err)
+}
+
+func TestValidateNumbers_ReportsEveryProblem(t *testing.T) {
+	// Too many numbers, 12 twice, 50 out of range for Mega 6/45 (twice)
+	errs := ValidateNumbers([]int{3, 12, 50, 12, 7, 50, 20}, Mega645)
+	require.Len(t, errs, 4)
+	assert.ErrorContains(t, errs[0], "exactly 6 numbers, got 7")
+	assert.ErrorContains(t, errs[1], "number 50 is out of range")
+	assert.ErrorContains(t, errs[2], "duplicate found: 12")
+	assert.ErrorContains(t, errs[3], "duplicate found: 50")
+
+	// NewNumbersForGame stops at the first of them
+	_, err := NewNumbersForGame([]int{3, 12, 50, 12, 7, 50, 20}, Mega645)
+	assert.ErrorContains(t, err, "exactly 6 numbers")
+}
+
+func TestValidateNumbers_Valid(t *testing.T) {
+	assert.Nil(t, ValidateNumbers([]int{41, 3, 9, 17, 22, 30}, Mega645))
+	assert.Nil(t, ValidateNumbers([]int{1, 13, 21, 34, 48, 55}, Power655))
+	assert.Len(t, ValidateNumbers([]int{1, 13, 21, 34, 48, 55}, Mega645), 2)
+}
+
+func TestValidateNumbers_InvalidGameType(t *testing.T) {
+	errs := ValidateNumbers([]int{1, 1, 2, 3, 4, 5}, GameType("KENO"))
+	require.Len(t, errs, 2)
+	assert.ErrorContains(t, errs[0], "invalid game type")
+	assert.ErrorContains(t, errs[1], "duplicate found: 1")
+}
+
+func TestIntersectInts(t *testing.T) {
+	// Overlapping: ascending whatever the input order, duplicates once
+	assert.Equal(t, []int{7, 21}, IntersectInts([]int{21, 3, 7, 40}, []int{7, 21, 21, 9}))
+	// Disjoint
+	assert.Empty(t, IntersectInts([]int{1, 2, 3}, []int{4, 5, 6}))
+	assert.Empty(t, IntersectInts(nil, []int{4, 5, 6}))
+	// Identical, and works on Numbers and larger pools
+	nums := MustNewNumbers([]int{3, 9, 17, 22, 30, 41})
+	assert.Equal(t, nums.AsSlice(), IntersectInts(nums, nums))
+	assert.Equal(t, []int{9, 30}, IntersectInts(nums, []int{1, 9, 12, 30, 44, 50, 55, 2}))
+}
+
+func TestUnionInts(t *testing.T) {
+	// Overlapping
+	assert.Equal(t, []int{3, 7, 9, 21, 40}, UnionInts([]int{21, 3, 7, 40}, []int{7, 21, 9}))
+	// Disjoint, any number of sets
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, UnionInts([]int{4, 1}, []int{6}, []int{2, 5, 3}))
+	// Identical, duplicates within a set kept out
+	assert.Equal(t, []int{5, 8}, UnionInts([]int{8, 5, 5}, []int{5, 8}))
+	assert.Empty(t, UnionInts())
+}
+
+func TestDifferenceInts(t *testing.T) {
+	// Overlapping: ascending whatever the input order, duplicates once
+	assert.Equal(t, []int{3, 40}, DifferenceInts([]int{40, 21, 3, 3, 7}, []int{7, 21, 9}))
+	// Disjoint and empty
+	assert.Equal(t, []int{1, 2}, DifferenceInts([]int{2, 1}, []int{4, 5}))
+	assert.Equal(t, []int{1, 2}, DifferenceInts([]int{2, 1}, nil))
+	assert.Empty(t, DifferenceInts(nil, []int{4, 5}))
+	// Identical
+	assert.Empty(t, DifferenceInts([]int{5, 8}, []int{8, 5}))
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/tool_predict/internal/application/port"
 	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/infrastructure/adapter/grpc/convert"
 	"github.com/tool_predict/internal/infrastructure/logger"
 	predictionpb "github.com/tool_predict/proto"
 	"go.uber.org/zap"
@@ -67,7 +68,7 @@ func (c *TooPredictClient) SendPrediction(
 	}
 
 	// Convert domain entity to protobuf
-	req := c.convertToProto(prediction)
+	req := convert.EnsembleToProto(prediction)
 
 	// Send via gRPC
 	resp, err := c.client.SendPrediction(ctx, req)
@@ -125,52 +126,5 @@ func (c *TooPredictClient) Close() error {
 	return nil
 }
 
-// convertToProto converts a domain entity to protobuf message
-func (c *TooPredictClient) convertToProto(
-	ensemble *entity.EnsemblePrediction,
-) *predictionpb.EnsemblePredictionRequest {
-	// Convert individual predictions
-	predictions := make([]*predictionpb.IndividualPrediction, len(ensemble.Predictions))
-	for i, pred := range ensemble.Predictions {
-		predictions[i] = &predictionpb.IndividualPrediction{
-			Id:            pred.ID,
-			AlgorithmName: pred.AlgorithmName,
-			Numbers:       convertIntSliceToInt32(pred.Numbers.AsSlice()),
-			Confidence:    pred.Confidence,
-			GeneratedAt:   pred.GeneratedAt.Unix(),
-		}
-	}
-
-	// Convert algorithm stats
-	stats := make([]*predictionpb.AlgorithmContribution, len(ensemble.AlgorithmStats))
-	for i, stat := range ensemble.AlgorithmStats {
-		stats[i] = &predictionpb.AlgorithmContribution{
-			AlgorithmName: stat.AlgorithmName,
-			Weight:        stat.Weight,
-			MatchCount:    int32(stat.MatchCount),
-			Confidence:    stat.Confidence,
-		}
-	}
-
-	return &predictionpb.EnsemblePredictionRequest{
-		Id:             ensemble.ID,
-		GameType:       string(ensemble.GameType),
-		FinalNumbers:   convertIntSliceToInt32(ensemble.FinalNumbers.AsSlice()),
-		VotingStrategy: ensemble.VotingStrategy,
-		GeneratedAt:    ensemble.GeneratedAt.Unix(),
-		Predictions:    predictions,
-		AlgorithmStats: stats,
-	}
-}
-
-// convertIntSliceToInt32 converts []int to []int32
-func convertIntSliceToInt32(input []int) []int32 {
-	result := make([]int32, len(input))
-	for i, v := range input {
-		result[i] = int32(v)
-	}
-	return result
-}
-
 // Ensure TooPredictClient implements port.PredictionService
 var _ port.PredictionService = (*TooPredictClient)(nil)
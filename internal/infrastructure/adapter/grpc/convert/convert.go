@@ -0,0 +1,53 @@
+// Package convert maps domain entities to the PredictionService protobuf
+// messages shared by the gRPC client and server
+package convert
+
+import (
+	"github.com/tool_predict/internal/domain/entity"
+	predictionpb "github.com/tool_predict/proto"
+)
+
+// EnsembleToProto converts an ensemble prediction to its protobuf message
+func EnsembleToProto(ensemble *entity.EnsemblePrediction) *predictionpb.EnsemblePredictionRequest {
+	// Convert individual predictions
+	predictions := make([]*predictionpb.IndividualPrediction, len(ensemble.Predictions))
+	for i, pred := range ensemble.Predictions {
+		predictions[i] = &predictionpb.IndividualPrediction{
+			Id:            pred.ID,
+			AlgorithmName: pred.AlgorithmName,
+			Numbers:       Int32s(pred.Numbers.AsSlice()),
+			Confidence:    pred.Confidence,
+			GeneratedAt:   pred.GeneratedAt.Unix(),
+		}
+	}
+
+	// Convert algorithm stats
+	stats := make([]*predictionpb.AlgorithmContribution, len(ensemble.AlgorithmStats))
+	for i, stat := range ensemble.AlgorithmStats {
+		stats[i] = &predictionpb.AlgorithmContribution{
+			AlgorithmName: stat.AlgorithmName,
+			Weight:        stat.Weight,
+			MatchCount:    int32(stat.MatchCount),
+			Confidence:    stat.Confidence,
+		}
+	}
+
+	return &predictionpb.EnsemblePredictionRequest{
+		Id:             ensemble.ID,
+		GameType:       string(ensemble.GameType),
+		FinalNumbers:   Int32s(ensemble.FinalNumbers.AsSlice()),
+		VotingStrategy: ensemble.VotingStrategy,
+		GeneratedAt:    ensemble.GeneratedAt.Unix(),
+		Predictions:    predictions,
+		AlgorithmStats: stats,
+	}
+}
+
+// Int32s converts []int to []int32
+func Int32s(input []int) []int32 {
+	result := make([]int32, len(input))
+	for i, v := range input {
+		result[i] = int32(v)
+	}
+	return result
+}
@@ -0,0 +1,163 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+
+	"github.com/tool_predict/internal/application/usecase"
+	"github.com/tool_predict/internal/domain/repository"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/adapter/grpc/convert"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	predictionpb "github.com/tool_predict/proto"
+	"go.uber.org/zap"
+)
+
+// DefaultListLimit is how many ensembles ListLatestEnsembles returns when
+// the request doesn't set a limit
+const DefaultListLimit = 10
+
+// Predictor generates ensemble predictions; *usecase.PredictUseCase implements it
+type Predictor interface {
+	Execute(ctx context.Context, req usecase.PredictRequest) (*usecase.EnsembleResult, error)
+}
+
+// PredictionServer serves PredictionService on top of the prediction use
+// case and the prediction storage. SendPrediction is left unimplemented:
+// this service produces predictions rather than receiving them.
+type PredictionServer struct {
+	predictionpb.UnimplementedPredictionServiceServer
+
+	predictors   map[valueobject.GameType]Predictor
+	predictions  repository.PredictionRepository
+	defaultDraws int
+}
+
+// NewPredictionServer creates a PredictionService server. predictors holds
+// the use case for each game type the server predicts; defaultDraws is the
+// training window used when a request doesn't set max_draws.
+func NewPredictionServer(
+	predictors map[valueobject.GameType]Predictor,
+	predictions repository.PredictionRepository,
+	defaultDraws int,
+) *PredictionServer {
+	return &PredictionServer{
+		predictors:   predictors,
+		predictions:  predictions,
+		defaultDraws: defaultDraws,
+	}
+}
+
+// NewGRPCServer creates a gRPC server serving srv, with server reflection
+// registered when enableReflection is set so tools like grpcurl can list it
+func NewGRPCServer(srv *PredictionServer, enableReflection bool) *grpc.Server {
+	grpcServer := grpc.NewServer()
+	predictionpb.RegisterPredictionServiceServer(grpcServer, srv)
+	if enableReflection {
+		reflection.Register(grpcServer)
+	}
+	return grpcServer
+}
+
+// GeneratePrediction runs the ensemble for the requested game type and
+// returns the resulting prediction, which the use case also stores
+func (s *PredictionServer) GeneratePrediction(
+	ctx context.Context,
+	req *predictionpb.GeneratePredictionRequest,
+) (*predictionpb.EnsemblePredictionRequest, error) {
+	gameType, err := valueobject.ParseGameType(req.GameType)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	predictor, ok := s.predictors[gameType]
+	if !ok {
+		return nil, status.Errorf(codes.FailedPrecondition, "predictions for %s are not served", gameType)
+	}
+	if req.MaxDraws < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "max_draws must not be negative, got %d", req.MaxDraws)
+	}
+
+	maxDraws := int(req.MaxDraws)
+	if maxDraws == 0 {
+		maxDraws = s.defaultDraws
+	}
+
+	result, err := predictor.Execute(ctx, usecase.PredictRequest{
+		GameType: gameType,
+		MaxDraws: maxDraws,
+	})
+	if err != nil {
+		logger.Warn("gRPC prediction failed",
+			zap.String("game_type", string(gameType)),
+			zap.Error(err),
+		)
+		return nil, status.Errorf(codes.Internal, "prediction failed: %v", err)
+	}
+
+	return convert.EnsembleToProto(result.Prediction), nil
+}
+
+// GetPredictionStatus reports a stored ensemble prediction as processed at
+// the time it was generated
+func (s *PredictionServer) GetPredictionStatus(
+	ctx context.Context,
+	req *predictionpb.PredictionStatusRequest,
+) (*predictionpb.PredictionStatusResponse, error) {
+	if req.PredictionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "prediction_id is required")
+	}
+
+	ensemble, err := s.predictions.FindEnsembleByID(ctx, req.PredictionId)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "prediction %s not found", req.PredictionId)
+	}
+
+	return &predictionpb.PredictionStatusResponse{
+		PredictionId: ensemble.ID,
+		Status:       "processed",
+		SentAt:       ensemble.GeneratedAt.Unix(),
+		ProcessedAt:  ensemble.GeneratedAt.Unix(),
+	}, nil
+}
+
+// ListLatestEnsembles returns the most recent stored ensemble predictions
+// for a game type, newest first
+func (s *PredictionServer) ListLatestEnsembles(
+	ctx context.Context,
+	req *predictionpb.ListLatestEnsemblesRequest,
+) (*predictionpb.ListLatestEnsemblesResponse, error) {
+	gameType, err := valueobject.ParseGameType(req.GameType)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if req.Limit < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must not be negative, got %d", req.Limit)
+	}
+
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = DefaultListLimit
+	}
+
+	ensembles, err := s.predictions.FindLatestEnsembles(ctx, gameType, limit)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, status.Errorf(codes.Internal, "failed to load ensembles: %v", err)
+	}
+
+	resp := &predictionpb.ListLatestEnsemblesResponse{
+		Ensembles: make([]*predictionpb.EnsemblePredictionRequest, len(ensembles)),
+	}
+	for i, ensemble := range ensembles {
+		resp.Ensembles[i] = convert.EnsembleToProto(ensemble)
+	}
+	return resp, nil
+}
+
+// Ensure PredictionServer implements the generated service interface
+var _ predictionpb.PredictionServiceServer = (*PredictionServer)(nil)
@@ -0,0 +1,136 @@
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/tool_predict/internal/application/usecase"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/repository"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/adapter/storage"
+	predictionpb "github.com/tool_predict/proto"
+)
+
+// stubPredictor stores a fixed ensemble, as the prediction use case would
+type stubPredictor struct {
+	predictions repository.PredictionRepository
+	requests    []usecase.PredictRequest
+}
+
+func (p *stubPredictor) Execute(ctx context.Context, req usecase.PredictRequest) (*usecase.EnsembleResult, error) {
+	p.requests = append(p.requests, req)
+	ensemble := newEnsemble(req.GameType, time.Now(), []int{3, 9, 17, 22, 30, 41})
+	if err := p.predictions.SaveEnsemble(ctx, ensemble); err != nil {
+		return nil, err
+	}
+	return &usecase.EnsembleResult{Prediction: ensemble}, nil
+}
+
+func newEnsemble(gameType valueobject.GameType, generatedAt time.Time, numbers []int) *entity.EnsemblePrediction {
+	return &entity.EnsemblePrediction{
+		ID:             "ens-" + generatedAt.Format("150405.000000000"),
+		GameType:       gameType,
+		FinalNumbers:   valueobject.MustNewNumbers(numbers),
+		VotingStrategy: "weighted",
+		GeneratedAt:    generatedAt,
+		ForDate:        generatedAt,
+	}
+}
+
+// dialServer serves srv over an in-memory listener and returns a client
+func dialServer(t *testing.T, srv *PredictionServer) predictionpb.PredictionServiceClient {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := NewGRPCServer(srv, true)
+	go func() { _ = grpcServer.Serve(listener) }()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return predictionpb.NewPredictionServiceClient(conn)
+}
+
+func TestPredictionServer_GenerateAndQuery(t *testing.T) {
+	ctx := context.Background()
+	predictionStorage, err := storage.NewPredictionJSONStorage(t.TempDir())
+	require.NoError(t, err)
+	predictionStorage.SetKeepHistory(true)
+
+	predictor := &stubPredictor{predictions: predictionStorage}
+	client := dialServer(t, NewPredictionServer(
+		map[valueobject.GameType]Predictor{valueobject.Mega645: predictor},
+		predictionStorage,
+		30,
+	))
+
+	generated, err := client.GeneratePrediction(ctx, &predictionpb.GeneratePredictionRequest{GameType: "mega"})
+	require.NoError(t, err)
+	assert.Equal(t, string(valueobject.Mega645), generated.GameType)
+	assert.Equal(t, []int32{3, 9, 17, 22, 30, 41}, generated.FinalNumbers)
+	require.Len(t, predictor.requests, 1)
+	assert.Equal(t, 30, predictor.requests[0].MaxDraws, "max_draws 0 uses the server default")
+
+	statusResp, err := client.GetPredictionStatus(ctx, &predictionpb.PredictionStatusRequest{PredictionId: generated.Id})
+	require.NoError(t, err)
+	assert.Equal(t, "processed", statusResp.Status)
+	assert.Equal(t, generated.GeneratedAt, statusResp.ProcessedAt)
+
+	older := newEnsemble(valueobject.Mega645, time.Now().Add(-48*time.Hour), []int{1, 2, 3, 4, 5, 6})
+	require.NoError(t, predictionStorage.SaveEnsemble(ctx, older))
+
+	list, err := client.ListLatestEnsembles(ctx, &predictionpb.ListLatestEnsemblesRequest{GameType: "MEGA_6_45"})
+	require.NoError(t, err)
+	require.Len(t, list.Ensembles, 2)
+	assert.Equal(t, generated.Id, list.Ensembles[0].Id)
+	assert.Equal(t, older.ID, list.Ensembles[1].Id)
+
+	list, err = client.ListLatestEnsembles(ctx, &predictionpb.ListLatestEnsemblesRequest{GameType: "MEGA_6_45", Limit: 1})
+	require.NoError(t, err)
+	assert.Len(t, list.Ensembles, 1)
+}
+
+func TestPredictionServer_Errors(t *testing.T) {
+	ctx := context.Background()
+	predictionStorage, err := storage.NewPredictionJSONStorage(t.TempDir())
+	require.NoError(t, err)
+
+	client := dialServer(t, NewPredictionServer(
+		map[valueobject.GameType]Predictor{valueobject.Mega645: &stubPredictor{predictions: predictionStorage}},
+		predictionStorage,
+		30,
+	))
+
+	_, err = client.GeneratePrediction(ctx, &predictionpb.GeneratePredictionRequest{GameType: "LOTO"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.GeneratePrediction(ctx, &predictionpb.GeneratePredictionRequest{GameType: "POWER_6_55"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = client.GetPredictionStatus(ctx, &predictionpb.PredictionStatusRequest{PredictionId: "missing"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// Nothing stored yet is an empty list, not an error
+	list, err := client.ListLatestEnsembles(ctx, &predictionpb.ListLatestEnsemblesRequest{GameType: "POWER_6_55"})
+	require.NoError(t, err)
+	assert.Empty(t, list.Ensembles)
+
+	_, err = client.SendPrediction(ctx, &predictionpb.EnsemblePredictionRequest{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
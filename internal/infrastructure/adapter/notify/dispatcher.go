@@ -0,0 +1,103 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/tool_predict/internal/application/port"
+	"github.com/tool_predict/internal/domain/entity"
+)
+
+// Dispatcher fans notifications out to several notifiers, passing on only
+// the events it's subscribed to. It also announces predictions and their
+// verification as EventPrediction and EventVerified notifications, so any
+// port.Notifier can stand in for a port.PredictionNotifier.
+type Dispatcher struct {
+	notifiers []port.Notifier
+	events    map[port.NotificationEvent]bool // Nil passes every event
+}
+
+// NewDispatcher creates a dispatcher sending the given events to every
+// notifier. No events means all of them.
+func NewDispatcher(events []port.NotificationEvent, notifiers ...port.Notifier) *Dispatcher {
+	d := &Dispatcher{notifiers: notifiers}
+	if len(events) > 0 {
+		d.events = make(map[port.NotificationEvent]bool, len(events))
+		for _, event := range events {
+			d.events[event] = true
+		}
+	}
+	return d
+}
+
+// Len returns how many notifiers the dispatcher sends to
+func (d *Dispatcher) Len() int {
+	return len(d.notifiers)
+}
+
+// Notify sends notification to every notifier if its event is subscribed.
+// Every notifier is tried; their errors are joined.
+func (d *Dispatcher) Notify(ctx context.Context, notification port.Notification) error {
+	if d.events != nil && !d.events[notification.Event] {
+		return nil
+	}
+
+	var errs []error
+	for _, notifier := range d.notifiers {
+		if err := notifier.Notify(ctx, notification); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Recipients returns the notifiers the dispatcher sends to, those of nested
+// groups included, each limited to the events this dispatcher passes on
+func (d *Dispatcher) Recipients() []port.Notifier {
+	var recipients []port.Notifier
+	for _, notifier := range d.notifiers {
+		members := []port.Notifier{notifier}
+		if group, ok := notifier.(port.NotifierGroup); ok {
+			members = group.Recipients()
+		}
+		for _, member := range members {
+			if d.events != nil {
+				member = &Dispatcher{notifiers: []port.Notifier{member}, events: d.events}
+			}
+			recipients = append(recipients, member)
+		}
+	}
+	return recipients
+}
+
+// NotifyPrediction sends a new prediction as an EventPrediction
+func (d *Dispatcher) NotifyPrediction(ctx context.Context, prediction *entity.EnsemblePrediction) error {
+	return d.Notify(ctx, port.Notification{
+		Event:    port.EventPrediction,
+		GameType: prediction.GameType,
+		Subject:  fmt.Sprintf("%s prediction %s", prediction.GameType, prediction.FinalNumbers),
+		Text:     FormatPredictionMessage(prediction),
+	})
+}
+
+// NotifyVerified sends how a verified prediction did as an EventVerified
+func (d *Dispatcher) NotifyVerified(
+	ctx context.Context,
+	draw *entity.Draw,
+	prediction *entity.EnsemblePrediction,
+) error {
+	return d.Notify(ctx, port.Notification{
+		Event:    port.EventVerified,
+		GameType: draw.GameType,
+		Subject: fmt.Sprintf("%s draw #%d: prediction matched %d number(s)",
+			draw.GameType, draw.DrawNumber, draw.Numbers.MatchCount(prediction.FinalNumbers)),
+		Text: FormatVerifiedMessage(draw, prediction),
+	})
+}
+
+// Ensure Dispatcher implements port.NotifierGroup and port.PredictionNotifier
+var (
+	_ port.NotifierGroup      = (*Dispatcher)(nil)
+	_ port.PredictionNotifier = (*Dispatcher)(nil)
+)
@@ -0,0 +1,85 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/application/port"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// recordingNotifier records what it's sent and fails with err
+type recordingNotifier struct {
+	notifications []port.Notification
+	err           error
+}
+
+func (r *recordingNotifier) Notify(ctx context.Context, notification port.Notification) error {
+	r.notifications = append(r.notifications, notification)
+	return r.err
+}
+
+func TestDispatcher_FiltersEvents(t *testing.T) {
+	recorder := &recordingNotifier{}
+	dispatcher := NewDispatcher([]port.NotificationEvent{port.EventAccuracyDrop}, recorder)
+
+	require.NoError(t, dispatcher.NotifyPrediction(context.Background(), newTestEnsemble(t, []int{3, 9, 12, 22, 31, 42})))
+	require.NoError(t, dispatcher.Notify(context.Background(), port.Notification{Event: port.EventAccuracyDrop, Text: "dropped"}))
+
+	require.Len(t, recorder.notifications, 1)
+	assert.Equal(t, "dropped", recorder.notifications[0].Text)
+}
+
+func TestDispatcher_SendsToEveryNotifier(t *testing.T) {
+	failing := &recordingNotifier{err: errors.New("smtp down")}
+	working := &recordingNotifier{}
+	dispatcher := NewDispatcher(nil, failing, working)
+
+	prediction := newTestEnsemble(t, []int{3, 9, 12, 22, 31, 42})
+	draw := newTestDraw(t)
+	require.NoError(t, prediction.Verify(draw))
+	err := dispatcher.NotifyVerified(context.Background(), draw, prediction)
+
+	assert.ErrorContains(t, err, "smtp down")
+	require.Len(t, working.notifications, 1)
+	notification := working.notifications[0]
+	assert.Equal(t, port.EventVerified, notification.Event)
+	assert.Equal(t, valueobject.Mega645, notification.GameType)
+	assert.Equal(t, "MEGA_6_45 draw #1201: prediction matched 3 number(s)", notification.Subject)
+	assert.Equal(t, FormatVerifiedMessage(draw, prediction), notification.Text)
+}
+
+func TestDispatcher_Recipients(t *testing.T) {
+	telegram := &recordingNotifier{}
+	webhook := &recordingNotifier{}
+	email := &recordingNotifier{}
+	subscribed := NewDispatcher([]port.NotificationEvent{port.EventAccuracyDrop}, webhook, email)
+	dispatcher := NewDispatcher(nil, telegram, subscribed)
+
+	recipients := dispatcher.Recipients()
+	require.Len(t, recipients, 3)
+	for _, recipient := range recipients {
+		require.NoError(t, recipient.Notify(context.Background(), port.Notification{Event: port.EventVerified}))
+		require.NoError(t, recipient.Notify(context.Background(), port.Notification{Event: port.EventAccuracyDrop}))
+	}
+
+	// The nested dispatcher's recipients keep its event filter
+	assert.Len(t, telegram.notifications, 2)
+	assert.Len(t, webhook.notifications, 1)
+	assert.Len(t, email.notifications, 1)
+}
+
+func TestDispatcher_NotifyPrediction(t *testing.T) {
+	recorder := &recordingNotifier{}
+	prediction := newTestEnsemble(t, []int{3, 9, 12, 22, 31, 42})
+
+	require.NoError(t, NewDispatcher(nil, recorder).NotifyPrediction(context.Background(), prediction))
+
+	require.Len(t, recorder.notifications, 1)
+	assert.Equal(t, port.EventPrediction, recorder.notifications[0].Event)
+	assert.Equal(t, "MEGA_6_45 prediction [03, 09, 12, 22, 31, 42]", recorder.notifications[0].Subject)
+	assert.Equal(t, FormatPredictionMessage(prediction), recorder.notifications[0].Text)
+}
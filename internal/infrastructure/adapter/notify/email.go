@@ -0,0 +1,179 @@
+package notify
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tool_predict/internal/application/port"
+)
+
+// EmailConfig is where and as whom EmailNotifier sends mail
+type EmailConfig struct {
+	Host     string
+	Port     int // 587 if zero
+	Username string
+	Password string
+	From     string
+	To       []string
+	Timeout  time.Duration // Limit on sending one mail, connecting included; 10s if zero
+}
+
+// EmailNotifier sends notifications as plain text email over SMTP, using
+// STARTTLS when the server offers it and PLAIN auth when a username is set
+type EmailNotifier struct {
+	host    string
+	addr    string
+	auth    smtp.Auth
+	from    string
+	to      []string
+	timeout time.Duration
+	send    func(ctx context.Context, addr string, auth smtp.Auth, from string, to []string, msg []byte) error
+	now     func() time.Time
+}
+
+// NewEmailNotifier creates a notifier sending through cfg's SMTP server
+func NewEmailNotifier(cfg EmailConfig) (*EmailNotifier, error) {
+	if cfg.Host == "" {
+		return nil, fmt.Errorf("smtp host is required")
+	}
+	if cfg.From == "" || len(cfg.To) == 0 {
+		return nil, fmt.Errorf("email sender and recipients are required")
+	}
+	port := cfg.Port
+	if port == 0 {
+		port = 587
+	}
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	var auth smtp.Auth
+	if cfg.Username != "" {
+		auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+	n := &EmailNotifier{
+		host:    cfg.Host,
+		addr:    net.JoinHostPort(cfg.Host, strconv.Itoa(port)),
+		auth:    auth,
+		from:    cfg.From,
+		to:      cfg.To,
+		timeout: timeout,
+		now:     time.Now,
+	}
+	n.send = n.sendMail
+	return n, nil
+}
+
+// Notify mails the notification to every recipient, giving up after the
+// timeout or when ctx is done
+func (n *EmailNotifier) Notify(ctx context.Context, notification port.Notification) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, n.timeout)
+	defer cancel()
+	if err := n.send(ctx, n.addr, n.auth, n.from, n.to, n.message(notification)); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// sendMail does what smtp.SendMail does, but over a connection dialed with
+// ctx and closed when ctx is done, so a stalled server can't hang the caller
+func (n *EmailNotifier) sendMail(
+	ctx context.Context,
+	addr string,
+	auth smtp.Auth,
+	from string,
+	to []string,
+	msg []byte,
+) (err error) {
+	dialer := &net.Dialer{Timeout: n.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			conn.Close()
+			return err
+		}
+	}
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer func() {
+		if !stop() && err == nil {
+			err = ctx.Err()
+		}
+	}()
+
+	client, err := smtp.NewClient(conn, n.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: n.host}); err != nil {
+			return err
+		}
+	}
+	if auth != nil {
+		if ok, _ := client.Extension("AUTH"); ok {
+			if err := client.Auth(auth); err != nil {
+				return err
+			}
+		}
+	}
+	if err := client.Mail(from); err != nil {
+		return err
+	}
+	for _, rcpt := range to {
+		if err := client.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// message builds the RFC 5322 message for notification
+func (n *EmailNotifier) message(notification port.Notification) []byte {
+	subject := notification.Subject
+	if subject == "" {
+		subject = strings.SplitN(notification.Text, "\n", 2)[0]
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", n.from)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(n.to, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", n.now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(notification.Text, "\n", "\r\n"))
+	b.WriteString("\r\n")
+	return []byte(b.String())
+}
+
+// Ensure EmailNotifier implements port.Notifier
+var _ port.Notifier = (*EmailNotifier)(nil)
@@ -0,0 +1,197 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/application/port"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// sentMail is what EmailNotifier handed to its send func
+type sentMail struct {
+	addr string
+	auth smtp.Auth
+	from string
+	to   []string
+	msg  string
+}
+
+func newTestEmail(t *testing.T, cfg EmailConfig) (*EmailNotifier, *[]sentMail) {
+	t.Helper()
+
+	notifier, err := NewEmailNotifier(cfg)
+	require.NoError(t, err)
+
+	var sent []sentMail
+	notifier.send = func(ctx context.Context, addr string, auth smtp.Auth, from string, to []string, msg []byte) error {
+		sent = append(sent, sentMail{addr: addr, auth: auth, from: from, to: to, msg: string(msg)})
+		return nil
+	}
+	notifier.now = func() time.Time { return time.Date(2026, 10, 14, 18, 30, 0, 0, time.UTC) }
+	return notifier, &sent
+}
+
+func TestEmailNotifier_Notify(t *testing.T) {
+	notifier, sent := newTestEmail(t, EmailConfig{
+		Host:     "smtp.example.com",
+		Username: "bot@example.com",
+		Password: "secret",
+		From:     "Vietlott Bot <bot@example.com>",
+		To:       []string{"ops@example.com", "dev@example.com"},
+	})
+
+	require.NoError(t, notifier.Notify(context.Background(), port.Notification{
+		Event:    port.EventPrediction,
+		GameType: valueobject.Mega645,
+		Subject:  "MEGA_6_45 dự đoán",
+		Text:     "line one\nline two",
+	}))
+
+	require.Len(t, *sent, 1)
+	mail := (*sent)[0]
+	assert.Equal(t, "smtp.example.com:587", mail.addr)
+	assert.NotNil(t, mail.auth)
+	assert.Equal(t, "Vietlott Bot <bot@example.com>", mail.from)
+	assert.Equal(t, []string{"ops@example.com", "dev@example.com"}, mail.to)
+	assert.Equal(t, "From: Vietlott Bot <bot@example.com>\r\n"+
+		"To: ops@example.com, dev@example.com\r\n"+
+		"Subject: =?utf-8?q?MEGA=5F6=5F45_d=E1=BB=B1_=C4=91o=C3=A1n?=\r\n"+
+		"Date: Wed, 14 Oct 2026 18:30:00 +0000\r\n"+
+		"MIME-Version: 1.0\r\n"+
+		"Content-Type: text/plain; charset=UTF-8\r\n"+
+		"Content-Transfer-Encoding: 8bit\r\n"+
+		"\r\n"+
+		"line one\r\nline two\r\n", mail.msg)
+}
+
+func TestEmailNotifier_SubjectFromText(t *testing.T) {
+	notifier, sent := newTestEmail(t, EmailConfig{Host: "localhost", Port: 25, From: "bot@localhost", To: []string{"ops@localhost"}})
+
+	require.NoError(t, notifier.Notify(context.Background(), port.Notification{Text: "first line\nsecond line"}))
+
+	require.Len(t, *sent, 1)
+	assert.Equal(t, "localhost:25", (*sent)[0].addr)
+	assert.Nil(t, (*sent)[0].auth, "no username, no auth")
+	assert.Contains(t, (*sent)[0].msg, "Subject: first line\r\n")
+}
+
+func TestEmailNotifier_SendError(t *testing.T) {
+	notifier, _ := newTestEmail(t, EmailConfig{Host: "localhost", From: "bot@localhost", To: []string{"ops@localhost"}})
+	notifier.send = func(context.Context, string, smtp.Auth, string, []string, []byte) error {
+		return errors.New("connection refused")
+	}
+
+	err := notifier.Notify(context.Background(), port.Notification{Text: "hello"})
+	assert.ErrorContains(t, err, "failed to send email: connection refused")
+}
+
+// serveSMTP answers one SMTP session on listener without STARTTLS or AUTH,
+// sending the commands it got and the message to got
+func serveSMTP(listener net.Listener, got chan<- []string) {
+	conn, err := listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	var lines []string
+	reader := bufio.NewReader(conn)
+	reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+	reply("220 localhost ready")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			got <- lines
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		lines = append(lines, line)
+		switch {
+		case strings.HasPrefix(line, "EHLO"):
+			reply("250 localhost")
+		case line == "DATA":
+			reply("354 go ahead")
+			for {
+				data, err := reader.ReadString('\n')
+				if err != nil || data == ".\r\n" {
+					break
+				}
+				lines = append(lines, strings.TrimRight(data, "\r\n"))
+			}
+			reply("250 queued")
+		case line == "QUIT":
+			reply("221 bye")
+			got <- lines
+			return
+		default:
+			reply("250 ok")
+		}
+	}
+}
+
+func TestEmailNotifier_SendsOverSMTP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	got := make(chan []string, 1)
+	go serveSMTP(listener, got)
+
+	addr := listener.Addr().(*net.TCPAddr)
+	notifier, err := NewEmailNotifier(EmailConfig{
+		Host: "127.0.0.1", Port: addr.Port, From: "bot@localhost", To: []string{"ops@localhost"},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, notifier.Notify(context.Background(), port.Notification{Subject: "hi", Text: "hello"}))
+	lines := <-got
+	assert.Contains(t, lines, "MAIL FROM:<bot@localhost>")
+	assert.Contains(t, lines, "RCPT TO:<ops@localhost>")
+	assert.Contains(t, lines, "Subject: hi")
+	assert.Contains(t, lines, "hello")
+	assert.Equal(t, "QUIT", lines[len(lines)-1])
+}
+
+func TestEmailNotifier_TimesOutOnStalledServer(t *testing.T) {
+	// The server accepts but never greets
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			defer conn.Close()
+			time.Sleep(5 * time.Second)
+		}
+	}()
+
+	notifier, err := NewEmailNotifier(EmailConfig{
+		Host: "127.0.0.1", Port: listener.Addr().(*net.TCPAddr).Port, From: "bot@localhost", To: []string{"ops@localhost"},
+		Timeout: 100 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	start := time.Now()
+	err = notifier.Notify(context.Background(), port.Notification{Text: "hello"})
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
+
+func TestNewEmailNotifier_RequiresAddresses(t *testing.T) {
+	_, err := NewEmailNotifier(EmailConfig{From: "bot@localhost", To: []string{"ops@localhost"}})
+	assert.Error(t, err)
+
+	_, err = NewEmailNotifier(EmailConfig{Host: "localhost", To: []string{"ops@localhost"}})
+	assert.Error(t, err)
+
+	_, err = NewEmailNotifier(EmailConfig{Host: "localhost", From: "bot@localhost"})
+	assert.Error(t, err)
+}
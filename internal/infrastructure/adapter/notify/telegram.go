@@ -0,0 +1,170 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tool_predict/internal/application/port"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// DefaultTelegramAPIURL is the Telegram Bot API the notifier posts to
+const DefaultTelegramAPIURL = "https://api.telegram.org"
+
+// TelegramNotifier sends new predictions and verification summaries as
+// plain text messages to a Telegram chat through a bot. Each
+// game type can go to its own chat; games without a chat are not announced.
+type TelegramNotifier struct {
+	client  *http.Client
+	apiURL  string
+	token   string
+	chatIDs map[valueobject.GameType]string
+}
+
+// NewTelegramNotifier creates a notifier sending as the bot with token to
+// each game type's chat in chatIDs
+func NewTelegramNotifier(token string, chatIDs map[valueobject.GameType]string, timeout time.Duration) *TelegramNotifier {
+	return &TelegramNotifier{
+		client:  &http.Client{Timeout: timeout},
+		apiURL:  DefaultTelegramAPIURL,
+		token:   token,
+		chatIDs: chatIDs,
+	}
+}
+
+// telegramMessage is the sendMessage request body
+type telegramMessage struct {
+	ChatID string `json:"chat_id"`
+	Text   string `json:"text"`
+}
+
+// telegramResponse is the Bot API's reply envelope
+type telegramResponse struct {
+	OK          bool   `json:"ok"`
+	Description string `json:"description"`
+}
+
+// NotifyPrediction sends a new prediction to its game's chat
+func (n *TelegramNotifier) NotifyPrediction(ctx context.Context, prediction *entity.EnsemblePrediction) error {
+	return n.send(ctx, prediction.GameType, FormatPredictionMessage(prediction))
+}
+
+// NotifyVerified sends how a verified prediction did to its game's chat
+func (n *TelegramNotifier) NotifyVerified(
+	ctx context.Context,
+	draw *entity.Draw,
+	prediction *entity.EnsemblePrediction,
+) error {
+	return n.send(ctx, draw.GameType, FormatVerifiedMessage(draw, prediction))
+}
+
+// Notify sends the notification's text to its game's chat
+func (n *TelegramNotifier) Notify(ctx context.Context, notification port.Notification) error {
+	return n.send(ctx, notification.GameType, notification.Text)
+}
+
+// send posts text to gameType's chat. A game type without a chat is
+// skipped, and a reply that isn't ok is an error.
+func (n *TelegramNotifier) send(ctx context.Context, gameType valueobject.GameType, text string) error {
+	chatID := n.chatIDs[gameType]
+	if chatID == "" {
+		return nil
+	}
+
+	body, err := json.Marshal(telegramMessage{ChatID: chatID, Text: text})
+	if err != nil {
+		return fmt.Errorf("failed to encode telegram message: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/bot%s/sendMessage", strings.TrimSuffix(n.apiURL, "/"), n.token)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create telegram request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		// The URL holds the bot token; keep it out of the error
+		return fmt.Errorf("failed to send telegram message: %w", unwrapURLError(err))
+	}
+	defer resp.Body.Close()
+
+	var reply telegramResponse
+	content, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if err := json.Unmarshal(content, &reply); err != nil || !reply.OK || resp.StatusCode != http.StatusOK {
+		description := reply.Description
+		if description == "" {
+			description = strings.TrimSpace(string(content))
+		}
+		return fmt.Errorf("telegram returned status %d: %s", resp.StatusCode, description)
+	}
+	return nil
+}
+
+// unwrapURLError drops the request URL from an HTTP client error
+func unwrapURLError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err
+	}
+	return err
+}
+
+// FormatPredictionMessage describes a new prediction: its target draw,
+// numbers, confidence and the algorithms that voted
+func FormatPredictionMessage(prediction *entity.EnsemblePrediction) string {
+	target := "the next draw"
+	if !prediction.ForDate.IsZero() {
+		target = prediction.ForDate.Format("2006-01-02")
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "🔮 %s prediction for %s: %s", prediction.GameType, target, prediction.FinalNumbers)
+	fmt.Fprintf(&b, "\n%s voting over %d algorithm(s)", prediction.VotingStrategy, len(prediction.Predictions))
+	if prediction.Confidence > 0 {
+		fmt.Fprintf(&b, ", confidence %.0f%%", prediction.Confidence*100)
+	}
+	return b.String()
+}
+
+// FormatVerifiedMessage describes how prediction did against draw, with
+// each algorithm's own match count
+func FormatVerifiedMessage(draw *entity.Draw, prediction *entity.EnsemblePrediction) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "🎯 %s draw #%d (%s): %s", draw.GameType, draw.DrawNumber, draw.DrawDate.Format("2006-01-02"), draw.Numbers)
+
+	matches := draw.Numbers.MatchCount(prediction.FinalNumbers)
+	fmt.Fprintf(&b, "\nPrediction %s matched %d number(s)", prediction.FinalNumbers, matches)
+	if tier := draw.PrizeTier(prediction.FinalNumbers); tier != entity.PrizeNone {
+		fmt.Fprintf(&b, ", %s prize", tier)
+	}
+
+	if prediction.Outcome != nil {
+		names := make([]string, 0, len(prediction.Outcome.AlgorithmMatches))
+		for name := range prediction.Outcome.AlgorithmMatches {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(&b, "\n  %s: %d", name, prediction.Outcome.AlgorithmMatches[name])
+		}
+	}
+	return b.String()
+}
+
+// Ensure TelegramNotifier implements port.PredictionNotifier and port.Notifier
+var (
+	_ port.PredictionNotifier = (*TelegramNotifier)(nil)
+	_ port.Notifier           = (*TelegramNotifier)(nil)
+)
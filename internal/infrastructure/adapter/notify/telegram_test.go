@@ -0,0 +1,102 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func newTestEnsemble(t *testing.T, final []int) *entity.EnsemblePrediction {
+	t.Helper()
+
+	pick, err := entity.NewPrediction(valueobject.Mega645, "frequency_analysis", valueobject.MustNewNumbers([]int{3, 9, 17, 22, 31, 42}), 0.5, time.Now())
+	require.NoError(t, err)
+	prediction, err := entity.NewEnsemblePrediction(valueobject.Mega645, []*entity.Prediction{pick},
+		valueobject.MustNewNumbers(final), "weighted", nil)
+	require.NoError(t, err)
+	prediction.ForDate = time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)
+	prediction.Confidence = 0.42
+	return prediction
+}
+
+// newTestTelegram starts a fake Bot API recording the messages it's sent
+func newTestTelegram(t *testing.T, chatIDs map[valueobject.GameType]string) (*TelegramNotifier, *[]telegramMessage, *string) {
+	t.Helper()
+
+	var messages []telegramMessage
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		var message telegramMessage
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&message))
+		messages = append(messages, message)
+		if message.ChatID == "bad-chat" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"ok":false,"error_code":400,"description":"Bad Request: chat not found"}`))
+			return
+		}
+		w.Write([]byte(`{"ok":true,"result":{}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	notifier := NewTelegramNotifier("123:secret", chatIDs, 5*time.Second)
+	notifier.apiURL = server.URL
+	return notifier, &messages, &path
+}
+
+func TestTelegramNotifier_NotifyPrediction(t *testing.T) {
+	notifier, messages, path := newTestTelegram(t, map[valueobject.GameType]string{valueobject.Mega645: "-100200"})
+
+	require.NoError(t, notifier.NotifyPrediction(context.Background(), newTestEnsemble(t, []int{3, 9, 12, 22, 31, 42})))
+
+	require.Len(t, *messages, 1)
+	assert.Equal(t, "/bot123:secret/sendMessage", *path)
+	assert.Equal(t, "-100200", (*messages)[0].ChatID)
+	assert.Equal(t, "🔮 MEGA_6_45 prediction for 2026-10-14: [03, 09, 12, 22, 31, 42]\nweighted voting over 1 algorithm(s), confidence 42%",
+		(*messages)[0].Text)
+}
+
+func TestTelegramNotifier_NotifyVerified(t *testing.T) {
+	notifier, messages, _ := newTestTelegram(t, map[valueobject.GameType]string{valueobject.Mega645: "-100200"})
+
+	prediction := newTestEnsemble(t, []int{3, 9, 12, 22, 31, 42})
+	draw := newTestDraw(t)
+	require.NoError(t, prediction.Verify(draw))
+	require.NoError(t, notifier.NotifyVerified(context.Background(), draw, prediction))
+
+	require.Len(t, *messages, 1)
+	assert.Equal(t, "🎯 MEGA_6_45 draw #1201 (2026-10-14): [03, 09, 17, 22, 30, 41]\n"+
+		"Prediction [03, 09, 12, 22, 31, 42] matched 3 number(s), third prize\n"+
+		"  frequency_analysis: 4", (*messages)[0].Text)
+}
+
+func TestTelegramNotifier_SkipsGamesWithoutChat(t *testing.T) {
+	notifier, messages, _ := newTestTelegram(t, map[valueobject.GameType]string{valueobject.Power655: "-100300"})
+
+	require.NoError(t, notifier.NotifyPrediction(context.Background(), newTestEnsemble(t, []int{1, 2, 3, 4, 5, 6})))
+	assert.Empty(t, *messages)
+}
+
+func TestTelegramNotifier_ErrorReply(t *testing.T) {
+	notifier, _, _ := newTestTelegram(t, map[valueobject.GameType]string{valueobject.Mega645: "bad-chat"})
+
+	err := notifier.NotifyPrediction(context.Background(), newTestEnsemble(t, []int{1, 2, 3, 4, 5, 6}))
+	assert.ErrorContains(t, err, "status 400: Bad Request: chat not found")
+}
+
+func TestTelegramNotifier_ErrorHidesToken(t *testing.T) {
+	notifier := NewTelegramNotifier("123:secret", map[valueobject.GameType]string{valueobject.Mega645: "-100200"}, time.Second)
+	notifier.apiURL = "http://127.0.0.1:1"
+
+	err := notifier.NotifyPrediction(context.Background(), newTestEnsemble(t, []int{1, 2, 3, 4, 5, 6}))
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "secret")
+}
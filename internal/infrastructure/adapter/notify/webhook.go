@@ -0,0 +1,144 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/tool_predict/internal/application/port"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// WebhookNotifier posts draw results as JSON to a chat webhook. The payload
+// carries the message as both "text" (Slack, Mattermost, most Telegram
+// bridges) and "content" (Discord), next to the structured result.
+type WebhookNotifier struct {
+	client *http.Client
+	url    string
+}
+
+// NewWebhookNotifier creates a notifier posting to url
+func NewWebhookNotifier(url string, timeout time.Duration) *WebhookNotifier {
+	return &WebhookNotifier{
+		client: &http.Client{Timeout: timeout},
+		url:    url,
+	}
+}
+
+// WebhookPayload is the JSON body posted for each draw
+type WebhookPayload struct {
+	Text       string               `json:"text"`
+	Content    string               `json:"content"`
+	GameType   valueobject.GameType `json:"game_type"`
+	DrawNumber int                  `json:"draw_number"`
+	DrawDate   string               `json:"draw_date"` // YYYY-MM-DD
+	Numbers    []int                `json:"numbers"`
+	Prediction *PredictionOutcome   `json:"prediction,omitempty"` // Nil when no prediction targeted the draw
+}
+
+// PredictionOutcome is how the ensemble's prediction did against the draw
+type PredictionOutcome struct {
+	Numbers []int            `json:"numbers"`
+	Matches int              `json:"matches"`
+	Tier    entity.PrizeTier `json:"tier"`
+}
+
+// NotifyDraw posts the draw and, if given, how prediction did against it.
+// Any non-2xx response is an error.
+func (n *WebhookNotifier) NotifyDraw(
+	ctx context.Context,
+	draw *entity.Draw,
+	prediction *entity.EnsemblePrediction,
+) error {
+	return n.post(ctx, NewWebhookPayload(draw, prediction))
+}
+
+// EventPayload is the JSON body posted for a generic notification
+type EventPayload struct {
+	Text     string                 `json:"text"`
+	Content  string                 `json:"content"`
+	Event    port.NotificationEvent `json:"event"`
+	GameType valueobject.GameType   `json:"game_type,omitempty"`
+	Subject  string                 `json:"subject,omitempty"`
+}
+
+// Notify posts notification as an EventPayload. Any non-2xx response is an
+// error.
+func (n *WebhookNotifier) Notify(ctx context.Context, notification port.Notification) error {
+	return n.post(ctx, &EventPayload{
+		Text:     notification.Text,
+		Content:  notification.Text,
+		Event:    notification.Event,
+		GameType: notification.GameType,
+		Subject:  notification.Subject,
+	})
+}
+
+// post sends payload as JSON to the webhook
+func (n *WebhookNotifier) post(ctx context.Context, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(snippet)))
+	}
+	return nil
+}
+
+// NewWebhookPayload builds the payload announcing draw. prediction may be nil.
+func NewWebhookPayload(draw *entity.Draw, prediction *entity.EnsemblePrediction) *WebhookPayload {
+	payload := &WebhookPayload{
+		GameType:   draw.GameType,
+		DrawNumber: draw.DrawNumber,
+		DrawDate:   draw.DrawDate.Format("2006-01-02"),
+		Numbers:    draw.Numbers.AsSlice(),
+	}
+
+	text := fmt.Sprintf("🎱 %s draw #%d (%s): %s",
+		draw.GameType, draw.DrawNumber, payload.DrawDate, draw.Numbers)
+	if prediction != nil {
+		payload.Prediction = &PredictionOutcome{
+			Numbers: prediction.FinalNumbers.AsSlice(),
+			Matches: draw.Numbers.MatchCount(prediction.FinalNumbers),
+			Tier:    draw.PrizeTier(prediction.FinalNumbers),
+		}
+		text += fmt.Sprintf("\nPrediction %s matched %d number(s)",
+			prediction.FinalNumbers, payload.Prediction.Matches)
+		if payload.Prediction.Tier != entity.PrizeNone {
+			text += fmt.Sprintf(", %s prize", payload.Prediction.Tier)
+		}
+	} else {
+		text += "\nNo prediction was stored for this draw"
+	}
+
+	payload.Text = text
+	payload.Content = text
+	return payload
+}
+
+// Ensure WebhookNotifier implements port.DrawNotifier and port.Notifier
+var (
+	_ port.DrawNotifier = (*WebhookNotifier)(nil)
+	_ port.Notifier     = (*WebhookNotifier)(nil)
+)
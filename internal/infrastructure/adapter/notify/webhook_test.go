@@ -0,0 +1,99 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/application/port"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func newTestDraw(t *testing.T) *entity.Draw {
+	t.Helper()
+
+	draw, err := entity.NewDraw(valueobject.Mega645, 1201, valueobject.MustNewNumbers([]int{3, 9, 17, 22, 30, 41}),
+		time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC), 0, 0)
+	require.NoError(t, err)
+	return draw
+}
+
+func TestWebhookNotifier_PostsPayload(t *testing.T) {
+	var received WebhookPayload
+	var contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	prediction := &entity.EnsemblePrediction{
+		GameType:     valueobject.Mega645,
+		FinalNumbers: valueobject.MustNewNumbers([]int{3, 9, 17, 25, 33, 44}),
+	}
+
+	notifier := NewWebhookNotifier(server.URL, 5*time.Second)
+	require.NoError(t, notifier.NotifyDraw(context.Background(), newTestDraw(t), prediction))
+
+	assert.Equal(t, "application/json", contentType)
+	assert.Equal(t, valueobject.Mega645, received.GameType)
+	assert.Equal(t, 1201, received.DrawNumber)
+	assert.Equal(t, "2026-10-14", received.DrawDate)
+	assert.Equal(t, []int{3, 9, 17, 22, 30, 41}, received.Numbers)
+	require.NotNil(t, received.Prediction)
+	assert.Equal(t, 3, received.Prediction.Matches)
+	assert.Equal(t, entity.PrizeThird, received.Prediction.Tier)
+	assert.Equal(t, received.Text, received.Content)
+	assert.Contains(t, received.Text, "draw #1201")
+	assert.Contains(t, received.Text, "matched 3 number(s), third prize")
+}
+
+func TestWebhookNotifier_WithoutPrediction(t *testing.T) {
+	payload := NewWebhookPayload(newTestDraw(t), nil)
+
+	assert.Nil(t, payload.Prediction)
+	assert.Contains(t, payload.Text, "No prediction was stored")
+}
+
+func TestWebhookNotifier_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid_token", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(server.URL, 5*time.Second)
+	err := notifier.NotifyDraw(context.Background(), newTestDraw(t), nil)
+	assert.ErrorContains(t, err, "status 403: invalid_token")
+}
+
+func TestWebhookNotifier_Notify(t *testing.T) {
+	var received EventPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(server.URL, 5*time.Second)
+	require.NoError(t, notifier.Notify(context.Background(), port.Notification{
+		Event:    port.EventAccuracyDrop,
+		GameType: valueobject.Power655,
+		Subject:  "accuracy dropped",
+		Text:     "📉 details",
+	}))
+
+	assert.Equal(t, EventPayload{
+		Text:     "📉 details",
+		Content:  "📉 details",
+		Event:    port.EventAccuracyDrop,
+		GameType: valueobject.Power655,
+		Subject:  "accuracy dropped",
+	}, received)
+}
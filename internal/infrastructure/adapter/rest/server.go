@@ -0,0 +1,375 @@
+// Package rest serves draws, predictions and backtests as a JSON HTTP API
+// for web and mobile clients
+package rest
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tool_predict/internal/application/usecase"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/repository"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+// Default and maximum page sizes for the list endpoints
+const (
+	DefaultDrawLimit       = 30
+	DefaultPredictionLimit = 10
+	DefaultBacktestLimit   = 10
+	MaxLimit               = 1000
+)
+
+// maxRequestBodyBytes caps a prediction request's body, which only ever
+// holds max_draws
+const maxRequestBodyBytes = 4 << 10
+
+// Predictor generates ensemble predictions; *usecase.PredictUseCase implements it
+type Predictor interface {
+	Execute(ctx context.Context, req usecase.PredictRequest) (*usecase.EnsembleResult, error)
+}
+
+// Server answers the API's requests from the repositories and the
+// prediction use cases
+type Server struct {
+	draws        repository.DrawRepository
+	predictions  repository.PredictionRepository
+	backtests    repository.BacktestRepository
+	predictors   map[valueobject.GameType]Predictor
+	defaultDraws int
+	corsOrigins  []string
+	token        string        // Bearer token POST requests must carry; empty requires none
+	interval     time.Duration // Least time between generated predictions; 0 doesn't limit them
+	now          func() time.Time
+
+	mu             sync.Mutex
+	lastPrediction time.Time
+}
+
+// NewServer creates the API server. predictors holds the use case for each
+// game type that can be predicted on demand; defaultDraws is the training
+// window used when a prediction request doesn't set max_draws.
+func NewServer(
+	draws repository.DrawRepository,
+	predictions repository.PredictionRepository,
+	backtests repository.BacktestRepository,
+	predictors map[valueobject.GameType]Predictor,
+	defaultDraws int,
+) *Server {
+	return &Server{
+		draws:        draws,
+		predictions:  predictions,
+		backtests:    backtests,
+		predictors:   predictors,
+		defaultDraws: defaultDraws,
+		now:          time.Now,
+	}
+}
+
+// SetCORSOrigins sets the browser origins allowed to call the API; "*"
+// allows any origin
+func (s *Server) SetCORSOrigins(origins []string) {
+	s.corsOrigins = origins
+}
+
+// SetToken requires POST requests to carry token as a bearer token in their
+// Authorization header; empty requires none
+func (s *Server) SetToken(token string) {
+	s.token = token
+}
+
+// SetPredictionInterval rejects prediction requests arriving within interval
+// of the last prediction generated, so clients can't keep the ensemble busy;
+// 0 doesn't limit them
+func (s *Server) SetPredictionInterval(interval time.Duration) {
+	s.interval = interval
+}
+
+// Handler returns the API's routes:
+//
+//	GET  /health
+//	GET  /draws/{gameType}?limit=N        latest draws, newest first
+//	GET  /predictions/{gameType}?limit=N  latest ensemble predictions, newest first
+//	POST /predictions/{gameType}          generate a prediction, body {"max_draws": N} optional
+//	GET  /backtests?game_type=G&limit=N   latest backtest results
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /health", s.handleHealth)
+	mux.HandleFunc("GET /draws/{gameType}", s.handleListDraws)
+	mux.HandleFunc("GET /predictions/{gameType}", s.handleListPredictions)
+	mux.HandleFunc("POST /predictions/{gameType}", s.handleCreatePrediction)
+	mux.HandleFunc("GET /backtests", s.handleListBacktests)
+	return s.withCORS(mux)
+}
+
+// errorResponse is the body of every failed request
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// drawsResponse is the body of GET /draws/{gameType}
+type drawsResponse struct {
+	GameType valueobject.GameType `json:"game_type"`
+	Draws    []*entity.Draw       `json:"draws"`
+}
+
+// predictionsResponse is the body of GET /predictions/{gameType}
+type predictionsResponse struct {
+	GameType    valueobject.GameType         `json:"game_type"`
+	Predictions []*entity.EnsemblePrediction `json:"predictions"`
+}
+
+// backtestsResponse is the body of GET /backtests
+type backtestsResponse struct {
+	Backtests []*entity.BacktestResult `json:"backtests"`
+}
+
+// createPredictionRequest is the optional body of POST /predictions/{gameType}
+type createPredictionRequest struct {
+	MaxDraws int `json:"max_draws"` // Latest draws to train on; 0 for the server default
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func (s *Server) handleListDraws(w http.ResponseWriter, r *http.Request) {
+	gameType, limit, ok := parseListRequest(w, r, r.PathValue("gameType"), DefaultDrawLimit)
+	if !ok {
+		return
+	}
+
+	draws, err := s.draws.FindLatest(r.Context(), gameType, limit)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		writeInternalError(w, "Failed to load draws", err)
+		return
+	}
+	if draws == nil {
+		draws = []*entity.Draw{}
+	}
+	writeJSON(w, http.StatusOK, drawsResponse{GameType: gameType, Draws: draws})
+}
+
+func (s *Server) handleListPredictions(w http.ResponseWriter, r *http.Request) {
+	gameType, limit, ok := parseListRequest(w, r, r.PathValue("gameType"), DefaultPredictionLimit)
+	if !ok {
+		return
+	}
+
+	ensembles, err := s.predictions.FindLatestEnsembles(r.Context(), gameType, limit)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		writeInternalError(w, "Failed to load predictions", err)
+		return
+	}
+	if ensembles == nil {
+		ensembles = []*entity.EnsemblePrediction{}
+	}
+	writeJSON(w, http.StatusOK, predictionsResponse{GameType: gameType, Predictions: ensembles})
+}
+
+func (s *Server) handleCreatePrediction(w http.ResponseWriter, r *http.Request) {
+	gameType, err := valueobject.ParseGameType(r.PathValue("gameType"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	predictor, ok := s.predictors[gameType]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("predictions for %s are not served", gameType))
+		return
+	}
+	if !s.authorized(r) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		writeError(w, http.StatusUnauthorized, "a valid bearer token is required")
+		return
+	}
+
+	var req createPredictionRequest
+	body := http.MaxBytesReader(w, r.Body, maxRequestBodyBytes)
+	if err := json.NewDecoder(body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("request body exceeds %d bytes", tooLarge.Limit))
+			return
+		}
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+	if req.MaxDraws < 0 {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("max_draws must not be negative, got %d", req.MaxDraws))
+		return
+	}
+	if req.MaxDraws == 0 {
+		req.MaxDraws = s.defaultDraws
+	}
+	if wait, ok := s.reservePrediction(); !ok {
+		retryAfter := int(math.Ceil(wait.Seconds()))
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+		writeError(w, http.StatusTooManyRequests, fmt.Sprintf("predictions are limited to one every %s, retry in %ds",
+			s.interval, retryAfter))
+		return
+	}
+
+	// Train on the stored draws: a request mustn't make the server scrape
+	result, err := predictor.Execute(r.Context(), usecase.PredictRequest{
+		GameType: gameType,
+		MaxDraws: req.MaxDraws,
+		Stored:   true,
+	})
+	if err != nil {
+		writeInternalError(w, "Prediction failed", err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, result.Prediction)
+}
+
+func (s *Server) handleListBacktests(w http.ResponseWriter, r *http.Request) {
+	limit, err := parseLimit(r.URL.Query().Get("limit"), DefaultBacktestLimit)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var results []*entity.BacktestResult
+	if value := r.URL.Query().Get("game_type"); value != "" {
+		gameType, parseErr := valueobject.ParseGameType(value)
+		if parseErr != nil {
+			writeError(w, http.StatusBadRequest, parseErr.Error())
+			return
+		}
+		results, err = s.backtests.FindByGameType(r.Context(), gameType)
+		if err == nil {
+			results = latestBacktests(results, limit)
+		}
+	} else {
+		results, err = s.backtests.FindLatest(r.Context(), limit)
+	}
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		writeInternalError(w, "Failed to load backtests", err)
+		return
+	}
+	if results == nil {
+		results = []*entity.BacktestResult{}
+	}
+	writeJSON(w, http.StatusOK, backtestsResponse{Backtests: results})
+}
+
+// authorized reports whether r carries the server's bearer token, or no
+// token is required
+func (s *Server) authorized(r *http.Request) bool {
+	if s.token == "" {
+		return true
+	}
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1
+}
+
+// reservePrediction claims the next prediction slot, or returns how long
+// until there is one
+func (s *Server) reservePrediction() (time.Duration, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if s.interval > 0 && !s.lastPrediction.IsZero() {
+		if wait := s.lastPrediction.Add(s.interval).Sub(now); wait > 0 {
+			return wait, false
+		}
+	}
+	s.lastPrediction = now
+	return 0, true
+}
+
+// latestBacktests orders results by the end of their test period, newest
+// first, and keeps the first limit
+func latestBacktests(results []*entity.BacktestResult, limit int) []*entity.BacktestResult {
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].TestPeriod.EndDate.After(results[j].TestPeriod.EndDate)
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// parseListRequest parses a list endpoint's game type and limit, writing a
+// 400 response and returning false when either is invalid
+func parseListRequest(w http.ResponseWriter, r *http.Request, rawGameType string, defaultLimit int) (valueobject.GameType, int, bool) {
+	gameType, err := valueobject.ParseGameType(rawGameType)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return "", 0, false
+	}
+	limit, err := parseLimit(r.URL.Query().Get("limit"), defaultLimit)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return "", 0, false
+	}
+	return gameType, limit, true
+}
+
+// parseLimit parses a limit query parameter, defaulting when empty
+func parseLimit(value string, defaultLimit int) (int, error) {
+	if value == "" {
+		return defaultLimit, nil
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 1 || limit > MaxLimit {
+		return 0, fmt.Errorf("limit must be between 1 and %d, got %q", MaxLimit, value)
+	}
+	return limit, nil
+}
+
+// withCORS answers preflight requests and adds CORS headers for the
+// allowed origins
+func (s *Server) withCORS(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin != "" && (slices.Contains(s.corsOrigins, "*") || slices.Contains(s.corsOrigins, origin)) {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type")
+			w.Header().Add("Vary", "Origin")
+			if r.Method == http.MethodOptions {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// writeJSON writes body as a JSON response with the given status
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		logger.Warn("Failed to write API response", zap.Error(err))
+	}
+}
+
+// writeError writes a JSON error response
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Error: message})
+}
+
+// writeInternalError logs err and writes a 500 response
+func writeInternalError(w http.ResponseWriter, message string, err error) {
+	logger.Warn(message, zap.Error(err))
+	writeError(w, http.StatusInternalServerError, fmt.Sprintf("%s: %v", message, err))
+}
@@ -0,0 +1,226 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/application/usecase"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/repository"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/adapter/storage"
+	"github.com/tool_predict/internal/infrastructure/sampledata"
+)
+
+// stubPredictor stores a fixed ensemble, as the prediction use case would
+type stubPredictor struct {
+	predictions repository.PredictionRepository
+	requests    []usecase.PredictRequest
+}
+
+func (p *stubPredictor) Execute(ctx context.Context, req usecase.PredictRequest) (*usecase.EnsembleResult, error) {
+	p.requests = append(p.requests, req)
+	ensemble := &entity.EnsemblePrediction{
+		ID:             "ens-1",
+		GameType:       req.GameType,
+		FinalNumbers:   valueobject.MustNewNumbers([]int{3, 9, 17, 22, 30, 41}),
+		VotingStrategy: "weighted",
+		GeneratedAt:    time.Now(),
+	}
+	if err := p.predictions.SaveEnsemble(ctx, ensemble); err != nil {
+		return nil, err
+	}
+	return &usecase.EnsembleResult{Prediction: ensemble}, nil
+}
+
+type testAPI struct {
+	api       *Server
+	server    *httptest.Server
+	predictor *stubPredictor
+	backtests *storage.BacktestJSONStorage
+}
+
+func newTestAPI(t *testing.T) *testAPI {
+	t.Helper()
+	ctx := context.Background()
+	basePath := t.TempDir()
+
+	draws, err := storage.NewJSONStorage(basePath)
+	require.NoError(t, err)
+	samples, err := sampledata.Draws(valueobject.Mega645)
+	require.NoError(t, err)
+	require.NoError(t, draws.SaveBatch(ctx, samples[:40]))
+
+	predictions, err := storage.NewPredictionJSONStorage(basePath)
+	require.NoError(t, err)
+	backtests, err := storage.NewBacktestJSONStorage(basePath)
+	require.NoError(t, err)
+
+	predictor := &stubPredictor{predictions: predictions}
+	api := NewServer(draws, predictions, backtests,
+		map[valueobject.GameType]Predictor{valueobject.Mega645: predictor}, 30)
+	api.SetCORSOrigins([]string{"https://app.example.com"})
+
+	server := httptest.NewServer(api.Handler())
+	t.Cleanup(server.Close)
+	return &testAPI{api: api, server: server, predictor: predictor, backtests: backtests}
+}
+
+// do sends a request to the API and decodes the JSON response into out
+func (a *testAPI) do(t *testing.T, method, path, body string, out any) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(method, a.server.URL+path, strings.NewReader(body))
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	if out != nil {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(out))
+	}
+	return resp
+}
+
+func TestServer_Draws(t *testing.T) {
+	api := newTestAPI(t)
+
+	var body drawsResponse
+	resp := api.do(t, http.MethodGet, "/draws/mega?limit=5", "", &body)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, valueobject.Mega645, body.GameType)
+	require.Len(t, body.Draws, 5)
+	assert.Greater(t, body.Draws[0].DrawNumber, body.Draws[4].DrawNumber, "newest first")
+
+	// A game without stored draws is an empty list
+	resp = api.do(t, http.MethodGet, "/draws/POWER_6_55", "", &body)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Empty(t, body.Draws)
+
+	var errBody errorResponse
+	resp = api.do(t, http.MethodGet, "/draws/LOTO", "", &errBody)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.NotEmpty(t, errBody.Error)
+
+	resp = api.do(t, http.MethodGet, "/draws/mega?limit=0", "", &errBody)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestServer_Predictions(t *testing.T) {
+	api := newTestAPI(t)
+
+	var created entity.EnsemblePrediction
+	resp := api.do(t, http.MethodPost, "/predictions/MEGA_6_45", `{"max_draws": 50}`, &created)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "ens-1", created.ID)
+	require.Len(t, api.predictor.requests, 1)
+	assert.Equal(t, 50, api.predictor.requests[0].MaxDraws)
+
+	// Without a body the server's default training window is used
+	resp = api.do(t, http.MethodPost, "/predictions/mega", "", &created)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, 30, api.predictor.requests[1].MaxDraws)
+
+	var list predictionsResponse
+	resp = api.do(t, http.MethodGet, "/predictions/mega", "", &list)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Len(t, list.Predictions, 1)
+	assert.Equal(t, []int{3, 9, 17, 22, 30, 41}, list.Predictions[0].FinalNumbers.AsSlice())
+
+	var errBody errorResponse
+	resp = api.do(t, http.MethodPost, "/predictions/POWER_6_55", "", &errBody)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp = api.do(t, http.MethodPost, "/predictions/mega", `{"max_draws": -1}`, &errBody)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// Predictions are made from the stored draws, never a scrape
+	for _, req := range api.predictor.requests {
+		assert.True(t, req.Stored)
+	}
+}
+
+func TestServer_CreatePredictionLimits(t *testing.T) {
+	api := newTestAPI(t)
+	now := time.Date(2026, 1, 15, 18, 0, 0, 0, time.UTC)
+	api.api.now = func() time.Time { return now }
+	api.api.SetToken("s3cret")
+	api.api.SetPredictionInterval(time.Minute)
+
+	post := func(token, body string) *http.Response {
+		t.Helper()
+		req, err := http.NewRequest(http.MethodPost, api.server.URL+"/predictions/mega", strings.NewReader(body))
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, post("", "").StatusCode)
+	assert.Equal(t, http.StatusUnauthorized, post("wrong", "").StatusCode)
+	assert.Equal(t, http.StatusRequestEntityTooLarge,
+		post("s3cret", `{"max_draws": 50, "padding": "`+strings.Repeat("x", maxRequestBodyBytes)+`"}`).StatusCode)
+	assert.Empty(t, api.predictor.requests)
+
+	assert.Equal(t, http.StatusCreated, post("s3cret", "").StatusCode)
+	resp := post("s3cret", "")
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "60", resp.Header.Get("Retry-After"))
+
+	now = now.Add(time.Minute)
+	assert.Equal(t, http.StatusCreated, post("s3cret", "").StatusCode)
+	assert.Len(t, api.predictor.requests, 2)
+}
+
+func TestServer_Backtests(t *testing.T) {
+	api := newTestAPI(t)
+	ctx := context.Background()
+
+	for i, gt := range []valueobject.GameType{valueobject.Mega645, valueobject.Power655} {
+		end := time.Date(2026, 1, 15+i, 0, 0, 0, 0, time.UTC)
+		result, err := entity.NewBacktestResult(gt, "frequency_analysis",
+			valueobject.MustNewDateRange(end.AddDate(0, -1, 0), end), 30)
+		require.NoError(t, err)
+		require.NoError(t, api.backtests.Save(ctx, result))
+	}
+
+	var body backtestsResponse
+	resp := api.do(t, http.MethodGet, "/backtests", "", &body)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Len(t, body.Backtests, 2)
+
+	resp = api.do(t, http.MethodGet, "/backtests?game_type=power&limit=5", "", &body)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Len(t, body.Backtests, 1)
+	assert.Equal(t, valueobject.Power655, body.Backtests[0].GameType)
+}
+
+func TestServer_CORS(t *testing.T) {
+	api := newTestAPI(t)
+
+	req, err := http.NewRequest(http.MethodOptions, api.server.URL+"/predictions/mega", nil)
+	require.NoError(t, err)
+	req.Header.Set("Origin", "https://app.example.com")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, "https://app.example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+
+	req, err = http.NewRequest(http.MethodGet, api.server.URL+"/health", nil)
+	require.NoError(t, err)
+	req.Header.Set("Origin", "https://other.example.com")
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+}
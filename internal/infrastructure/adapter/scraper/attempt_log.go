@@ -0,0 +1,41 @@
+package scraper
+
+import (
+	"io"
+	"net/http"
+
+	"go.uber.org/zap"
+)
+
+// attemptFields describes a failed request attempt for the logs: the URL,
+// which attempt out of how many, and the response status and size or the
+// transport error. It drains and closes resp's body to measure it.
+func attemptFields(url string, attempt, maxAttempts int, resp *http.Response, err error) []zap.Field {
+	fields := []zap.Field{
+		zap.String("url", url),
+		zap.Int("attempt", attempt),
+		zap.Int("max_attempts", maxAttempts),
+	}
+	if resp != nil {
+		size, _ := io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		fields = append(fields,
+			zap.Int("status_code", resp.StatusCode),
+			zap.Int64("response_size", size),
+		)
+	}
+	if err != nil {
+		fields = append(fields, zap.Error(err))
+	}
+	return fields
+}
+
+// responseFields describes a response that could not be parsed
+func responseFields(url string, statusCode int, size int, err error) []zap.Field {
+	return []zap.Field{
+		zap.String("url", url),
+		zap.Int("status_code", statusCode),
+		zap.Int("response_size", size),
+		zap.Error(err),
+	}
+}
@@ -0,0 +1,31 @@
+package scraper
+
+import (
+	"context"
+
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/repository"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+// auditLog saves a scrape audit for each results page crawled; without a
+// repository nothing is saved
+type auditLog struct {
+	repo repository.ScrapeAuditRepository
+}
+
+// save finishes audit with the crawl's err and saves it, even when ctx was
+// canceled. A failed save only logs a warning; auditing never fails a crawl.
+func (l auditLog) save(ctx context.Context, audit *entity.ScrapeAudit, err error) {
+	if l.repo == nil {
+		return
+	}
+	audit.Finish(err)
+	if saveErr := l.repo.Save(context.WithoutCancel(ctx), audit); saveErr != nil {
+		logger.Warn("Failed to save scrape audit",
+			zap.String("url", audit.URL),
+			zap.Error(saveErr),
+		)
+	}
+}
@@ -0,0 +1,115 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/api/vietlott"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/repository"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// memoryAuditRepository keeps saved audits in order. Only Save is
+// implemented; the embedded nil interface panics on anything else.
+type memoryAuditRepository struct {
+	repository.ScrapeAuditRepository
+	mu     sync.Mutex
+	audits []*entity.ScrapeAudit
+}
+
+func (m *memoryAuditRepository) Save(ctx context.Context, audit *entity.ScrapeAudit) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.audits = append(m.audits, audit)
+	return nil
+}
+
+func TestVietlottAPIScraper_AuditsPages(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"items":[
+			{"drawNumber":1202,"numbers":[3,9,17,22,30,55],"drawDate":"2026-01-16T00:00:00"},
+			{"drawNumber":1201,"numbers":[3,9,17,22,30,44],"drawDate":"2026-01-14T00:00:00"}
+		]}}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	audits := &memoryAuditRepository{}
+	s := NewVietlottAPIScraper(srv.URL, 5*time.Second, 1, 0)
+	s.SetAuditRepository(audits)
+
+	_, err := s.FetchLatestDraws(context.Background(), valueobject.Mega645, 10)
+	require.NoError(t, err)
+
+	require.Len(t, audits.audits, 1)
+	audit := audits.audits[0]
+	assert.Equal(t, valueobject.Mega645, audit.GameType)
+	assert.Equal(t, entity.ScrapeSourceAPI, audit.Source)
+	assert.Equal(t, srv.URL+vietlott.Mega645ResultsPath+"?page=1&pageSize=10", audit.URL)
+	assert.Equal(t, 1, audit.Attempts)
+	assert.Equal(t, http.StatusOK, audit.StatusCode)
+	assert.Equal(t, 2, audit.ItemsFound)
+	assert.Equal(t, 1, audit.DrawsFound)
+	require.Len(t, audit.ParseErrors, 1)
+	assert.Contains(t, audit.ParseErrors[0], "draw 1202")
+	assert.Empty(t, audit.Error)
+	assert.True(t, audit.Succeeded())
+}
+
+func TestVietlottAPIScraper_AuditsWebFallback(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(resultsPageHTML))
+	}))
+	t.Cleanup(srv.Close)
+
+	audits := &memoryAuditRepository{}
+	s := NewVietlottAPIScraper(srv.URL, 5*time.Second, 1, 0)
+	s.SetAuditRepository(audits)
+
+	draws, err := s.FetchLatestDraws(context.Background(), valueobject.Mega645, 10)
+	require.NoError(t, err)
+	require.Len(t, draws, 1)
+
+	require.Len(t, audits.audits, 2)
+	api, web := audits.audits[0], audits.audits[1]
+	assert.Equal(t, entity.ScrapeSourceAPI, api.Source)
+	assert.Contains(t, api.Error, "invalid API response")
+	assert.False(t, api.Succeeded())
+
+	assert.Equal(t, entity.ScrapeSourceWeb, web.Source)
+	assert.Equal(t, srv.URL+vietlott.Mega645ResultsPath, web.URL)
+	assert.Equal(t, 3, web.ItemsFound)
+	assert.Equal(t, 1, web.DrawsFound)
+	require.Len(t, web.ParseErrors, 2)
+	assert.Equal(t, "row 1: expected 6 numbers, got 5", web.ParseErrors[0])
+	assert.True(t, strings.HasPrefix(web.ParseErrors[1], "row 2: failed to parse date"))
+}
+
+func TestVietlottWebScraper_AuditsFailedCrawl(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(srv.Close)
+
+	audits := &memoryAuditRepository{}
+	s := NewVietlottWebScraper(srv.URL, 5*time.Second, 1, 0)
+	s.SetAuditRepository(audits)
+
+	_, err := s.FetchLatestDraws(context.Background(), valueobject.Power655, 5)
+	require.Error(t, err)
+
+	require.Len(t, audits.audits, 1)
+	audit := audits.audits[0]
+	assert.Equal(t, valueobject.Power655, audit.GameType)
+	assert.Equal(t, 1, audit.Attempts)
+	assert.Equal(t, http.StatusServiceUnavailable, audit.StatusCode)
+	assert.Zero(t, audit.DrawsFound)
+	assert.Contains(t, audit.Error, "status 503")
+}
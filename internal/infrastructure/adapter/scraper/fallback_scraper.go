@@ -0,0 +1,239 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/tool_predict/internal/application/port"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+// EngineFallback is the scraper.engine trying each of scraper.fallback.backends
+// in turn
+const EngineFallback = "fallback"
+
+// Backends selectable via scraper.fallback.backends
+const (
+	BackendAPI      = "api"      // The JSON API, without its own web fallback
+	BackendWeb      = "web"      // The static HTML results pages
+	BackendChromedp = "chromedp" // The results pages rendered in headless Chrome
+	BackendPDF      = "pdf"      // The official result PDFs
+)
+
+// ScraperBackend is one scraper in the fallback chain
+type ScraperBackend struct {
+	Name    string
+	Scraper port.VietlottScraper
+}
+
+// FallbackScraper tries a chain of scrapers until one succeeds, counting
+// each backend's successes and failures. The backend that worked last is
+// tried first next time, so a dead API isn't waited on every fetch; the
+// rest keep their configured order. Health is saved to store after every
+// attempt so it carries across runs.
+type FallbackScraper struct {
+	backends []ScraperBackend
+	store    port.ScraperHealthStore // Optional, nil keeps health in memory only
+	health   *port.ScraperHealth
+	now      func() time.Time
+	mu       sync.Mutex
+}
+
+// NewFallbackScraper creates a fallback chain trying backends in order,
+// starting from the health saved in store. Unreadable health is logged and
+// started afresh rather than failing the scraper.
+func NewFallbackScraper(store port.ScraperHealthStore, backends ...ScraperBackend) *FallbackScraper {
+	health := port.NewScraperHealth()
+	if store != nil {
+		loaded, err := store.Load()
+		if err != nil {
+			logger.Warn("Failed to load scraper health, starting afresh", zap.Error(err))
+		} else {
+			health = loaded
+		}
+	}
+
+	return &FallbackScraper{
+		backends: backends,
+		store:    store,
+		health:   health,
+		now:      time.Now,
+	}
+}
+
+// Health returns a copy of each backend's record and the backend that
+// worked last
+func (s *FallbackScraper) Health() *port.ScraperHealth {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	health := &port.ScraperHealth{
+		Backends:    make(map[string]*port.ScraperBackendHealth, len(s.health.Backends)),
+		LastWorking: s.health.LastWorking,
+		UpdatedAt:   s.health.UpdatedAt,
+	}
+	for name, backend := range s.health.Backends {
+		copied := *backend
+		health.Backends[name] = &copied
+	}
+	return health
+}
+
+// FetchLatestDraws fetches the most recent draws from the first backend
+// that succeeds
+func (s *FallbackScraper) FetchLatestDraws(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	limit int,
+) ([]*entity.Draw, error) {
+	return tryBackends(ctx, s, gameType, func(scraper port.VietlottScraper) ([]*entity.Draw, error) {
+		return scraper.FetchLatestDraws(ctx, gameType, limit)
+	})
+}
+
+// FetchAllDraws fetches all draws from a specified date onwards from the
+// first backend that succeeds
+func (s *FallbackScraper) FetchAllDraws(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	fromDate time.Time,
+) ([]*entity.Draw, error) {
+	return tryBackends(ctx, s, gameType, func(scraper port.VietlottScraper) ([]*entity.Draw, error) {
+		return scraper.FetchAllDraws(ctx, gameType, fromDate)
+	})
+}
+
+// FetchDrawByNumber fetches a specific draw from the first backend that
+// finds it
+func (s *FallbackScraper) FetchDrawByNumber(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	drawNumber int,
+) (*entity.Draw, error) {
+	return tryBackends(ctx, s, gameType, func(scraper port.VietlottScraper) (*entity.Draw, error) {
+		return scraper.FetchDrawByNumber(ctx, gameType, drawNumber)
+	})
+}
+
+// FetchDrawsByDateRange fetches all draws within a date range from the
+// first backend that succeeds
+func (s *FallbackScraper) FetchDrawsByDateRange(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	startDate time.Time,
+	endDate time.Time,
+) ([]*entity.Draw, error) {
+	return tryBackends(ctx, s, gameType, func(scraper port.VietlottScraper) ([]*entity.Draw, error) {
+		return scraper.FetchDrawsByDateRange(ctx, gameType, startDate, endDate)
+	})
+}
+
+// GetLatestDrawNumber returns the most recent draw number from the first
+// backend that succeeds
+func (s *FallbackScraper) GetLatestDrawNumber(
+	ctx context.Context,
+	gameType valueobject.GameType,
+) (int, error) {
+	return tryBackends(ctx, s, gameType, func(scraper port.VietlottScraper) (int, error) {
+		return scraper.GetLatestDrawNumber(ctx, gameType)
+	})
+}
+
+// tryBackends calls fetch on each backend in turn until one succeeds,
+// recording every outcome. A cancelled context stops the chain without
+// counting against the backend that was interrupted.
+func tryBackends[T any](
+	ctx context.Context,
+	s *FallbackScraper,
+	gameType valueobject.GameType,
+	fetch func(scraper port.VietlottScraper) (T, error),
+) (T, error) {
+	var zero T
+	if len(s.backends) == 0 {
+		return zero, fmt.Errorf("no scraper backends configured")
+	}
+
+	errs := make([]error, 0, len(s.backends))
+	for _, backend := range s.ordered() {
+		result, err := fetch(backend.Scraper)
+		if err == nil {
+			s.record(backend.Name, nil)
+			return result, nil
+		}
+		if ctx.Err() != nil {
+			return zero, err
+		}
+
+		logger.Warn("Scraper backend failed, trying the next one",
+			zap.String("backend", backend.Name),
+			zap.String("game_type", string(gameType)),
+			zap.Error(err),
+		)
+		s.record(backend.Name, err)
+		errs = append(errs, fmt.Errorf("%s: %w", backend.Name, err))
+	}
+	return zero, fmt.Errorf("every scraper backend failed: %w", errors.Join(errs...))
+}
+
+// ordered returns the backends with the one that worked last moved to the
+// front
+func (s *FallbackScraper) ordered() []ScraperBackend {
+	s.mu.Lock()
+	lastWorking := s.health.LastWorking
+	s.mu.Unlock()
+
+	ordered := make([]ScraperBackend, 0, len(s.backends))
+	for _, backend := range s.backends {
+		if backend.Name == lastWorking {
+			ordered = append(ordered, backend)
+		}
+	}
+	for _, backend := range s.backends {
+		if backend.Name != lastWorking {
+			ordered = append(ordered, backend)
+		}
+	}
+	return ordered
+}
+
+// record counts a backend's success, when err is nil, or failure and saves
+// the health. Failing to save is logged, never returned, so it can't break
+// a fetch.
+func (s *FallbackScraper) record(name string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	backend, ok := s.health.Backends[name]
+	if !ok {
+		backend = &port.ScraperBackendHealth{Name: name}
+		s.health.Backends[name] = backend
+	}
+
+	now := s.now()
+	if err == nil {
+		backend.Successes++
+		backend.LastSuccess = now
+		s.health.LastWorking = name
+	} else {
+		backend.Failures++
+		backend.LastFailure = now
+		backend.LastError = err.Error()
+	}
+	s.health.UpdatedAt = now
+
+	if s.store == nil {
+		return
+	}
+	if err := s.store.Save(s.health); err != nil {
+		logger.Warn("Failed to save scraper health", zap.Error(err))
+	}
+}
+
+// Ensure FallbackScraper implements port.VietlottScraper
+var _ port.VietlottScraper = (*FallbackScraper)(nil)
@@ -0,0 +1,129 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/application/port"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// stubBackend answers GetLatestDrawNumber with drawNumber or err, counting
+// calls. Other methods aren't stubbed.
+type stubBackend struct {
+	port.VietlottScraper
+	drawNumber int
+	err        error
+	calls      int
+}
+
+func (s *stubBackend) GetLatestDrawNumber(ctx context.Context, gameType valueobject.GameType) (int, error) {
+	s.calls++
+	return s.drawNumber, s.err
+}
+
+// memoryHealthStore keeps the last saved health
+type memoryHealthStore struct {
+	health *port.ScraperHealth
+	saves  int
+}
+
+func (m *memoryHealthStore) Load() (*port.ScraperHealth, error) {
+	if m.health == nil {
+		return port.NewScraperHealth(), nil
+	}
+	return m.health, nil
+}
+
+func (m *memoryHealthStore) Save(health *port.ScraperHealth) error {
+	m.health = health
+	m.saves++
+	return nil
+}
+
+func TestFallbackScraper_FallsBackAndRemembersLastWorking(t *testing.T) {
+	api := &stubBackend{err: errors.New("status 503")}
+	web := &stubBackend{drawNumber: 1201}
+	browser := &stubBackend{drawNumber: 1201}
+	store := &memoryHealthStore{}
+	now := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	s := NewFallbackScraper(store,
+		ScraperBackend{Name: BackendAPI, Scraper: api},
+		ScraperBackend{Name: BackendWeb, Scraper: web},
+		ScraperBackend{Name: BackendChromedp, Scraper: browser},
+	)
+	s.now = func() time.Time { return now }
+
+	drawNumber, err := s.GetLatestDrawNumber(context.Background(), valueobject.Mega645)
+	require.NoError(t, err)
+	assert.Equal(t, 1201, drawNumber)
+	assert.Equal(t, 1, api.calls)
+	assert.Equal(t, 1, web.calls)
+	assert.Zero(t, browser.calls)
+
+	health := s.Health()
+	assert.Equal(t, BackendWeb, health.LastWorking)
+	assert.Equal(t, &port.ScraperBackendHealth{Name: BackendAPI, Failures: 1, LastFailure: now, LastError: "status 503"}, health.Backends[BackendAPI])
+	assert.Equal(t, &port.ScraperBackendHealth{Name: BackendWeb, Successes: 1, LastSuccess: now}, health.Backends[BackendWeb])
+	assert.Equal(t, 2, store.saves)
+
+	// The web backend worked last, so it's tried first and the API is skipped
+	_, err = s.GetLatestDrawNumber(context.Background(), valueobject.Mega645)
+	require.NoError(t, err)
+	assert.Equal(t, 1, api.calls)
+	assert.Equal(t, 2, web.calls)
+
+	// A new chain picks up the saved health
+	reopened := NewFallbackScraper(store,
+		ScraperBackend{Name: BackendAPI, Scraper: api},
+		ScraperBackend{Name: BackendWeb, Scraper: web},
+	)
+	_, err = reopened.GetLatestDrawNumber(context.Background(), valueobject.Mega645)
+	require.NoError(t, err)
+	assert.Equal(t, 1, api.calls)
+	assert.Equal(t, 3, reopened.Health().Backends[BackendWeb].Successes)
+}
+
+func TestFallbackScraper_AllBackendsFail(t *testing.T) {
+	s := NewFallbackScraper(nil,
+		ScraperBackend{Name: BackendAPI, Scraper: &stubBackend{err: errors.New("status 503")}},
+		ScraperBackend{Name: BackendWeb, Scraper: &stubBackend{err: errors.New("no draws found")}},
+	)
+
+	_, err := s.GetLatestDrawNumber(context.Background(), valueobject.Power655)
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "every scraper backend failed")
+	assert.ErrorContains(t, err, "api: status 503")
+	assert.ErrorContains(t, err, "web: no draws found")
+
+	health := s.Health()
+	assert.Empty(t, health.LastWorking)
+	assert.Equal(t, 1, health.Backends[BackendAPI].Failures)
+	assert.Equal(t, 1, health.Backends[BackendWeb].Failures)
+}
+
+func TestFallbackScraper_CancelledContextStopsChain(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	web := &stubBackend{drawNumber: 1201}
+	s := NewFallbackScraper(nil,
+		ScraperBackend{Name: BackendAPI, Scraper: &stubBackend{err: context.Canceled}},
+		ScraperBackend{Name: BackendWeb, Scraper: web},
+	)
+
+	_, err := s.GetLatestDrawNumber(ctx, valueobject.Mega645)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Zero(t, web.calls)
+	assert.Empty(t, s.Health().Backends, "an interrupted fetch isn't the backend's failure")
+}
+
+func TestFallbackScraper_NoBackends(t *testing.T) {
+	_, err := NewFallbackScraper(nil).GetLatestDrawNumber(context.Background(), valueobject.Mega645)
+	assert.Error(t, err)
+}
@@ -0,0 +1,123 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/tool_predict/api/vietlott"
+	"github.com/tool_predict/internal/application/port"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+// kenoTimeLayouts are the draw time formats Keno results pages use, most
+// specific first
+var kenoTimeLayouts = []string{"02/01/2006 15:04", "2006-01-02 15:04", "02/01/2006", "2006-01-02"}
+
+// FetchLatestKenoDraws fetches the most recent Keno draws from the Keno
+// results page, newest first
+func (s *VietlottWebScraper) FetchLatestKenoDraws(ctx context.Context, limit int) ([]*entity.KenoDraw, error) {
+	s.waitForRateLimit()
+
+	url := s.baseURL + vietlott.KenoResultsPath
+	html, err := s.fetchHTML(ctx, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	draws, rowErrs, err := parseKenoPage(html, limit)
+	if err != nil {
+		logger.Warn("Failed to parse page", responseFields(url, http.StatusOK, len(html), err)...)
+		return nil, err
+	}
+	for _, rowErr := range rowErrs {
+		logger.Warn("Failed to parse keno draw row",
+			zap.String("url", url),
+			zap.Int("row", rowErr.Row),
+			zap.Error(rowErr.Err),
+		)
+	}
+
+	if len(draws) == 0 {
+		err := fmt.Errorf("no keno draws found on page")
+		logger.Warn("Failed to parse page", responseFields(url, http.StatusOK, len(html), err)...)
+		return nil, err
+	}
+	return draws, nil
+}
+
+// parseKenoPage parses the draw rows of a Keno results page, stopping after
+// limit valid draws. Rows use the same selectors as the other games' pages.
+func parseKenoPage(html string, limit int) ([]*entity.KenoDraw, []RowError, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	draws := make([]*entity.KenoDraw, 0)
+	var rowErrs []RowError
+	doc.Find(drawRowSelector).Each(func(i int, row *goquery.Selection) {
+		if len(draws) >= limit {
+			return
+		}
+		draw, err := parseKenoRow(row)
+		if err != nil {
+			rowErrs = append(rowErrs, RowError{Row: i, Err: err})
+			return
+		}
+		draws = append(draws, draw)
+	})
+
+	return draws, rowErrs, nil
+}
+
+// parseKenoRow parses a single Keno draw row from HTML
+func parseKenoRow(sel *goquery.Selection) (*entity.KenoDraw, error) {
+	// Keno draw numbers are shown like "#0123456"
+	drawNumber, err := strconv.Atoi(digitsOnly(sel.Find(".draw-number, .period, .ky").First().Text()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse draw number: %w", err)
+	}
+
+	var nums []int
+	sel.Find(".number, .ball, .lottery-num").Each(func(i int, numSel *goquery.Selection) {
+		if num, err := strconv.Atoi(strings.TrimSpace(numSel.Text())); err == nil {
+			nums = append(nums, num)
+		}
+	})
+	if len(nums) != valueobject.KenoDrawSize {
+		return nil, fmt.Errorf("expected %d numbers, got %d", valueobject.KenoDrawSize, len(nums))
+	}
+	numbers, err := valueobject.NewKenoNumbers(nums)
+	if err != nil {
+		return nil, fmt.Errorf("invalid numbers: %w", err)
+	}
+
+	timeText := strings.Join(strings.Fields(sel.Find(".draw-date, .date, .ngay").First().Text()), " ")
+	drawTime, err := parseKenoTime(timeText)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewKenoDraw(drawNumber, numbers, drawTime)
+}
+
+// parseKenoTime parses a Keno draw time in any of kenoTimeLayouts
+func parseKenoTime(text string) (time.Time, error) {
+	for _, layout := range kenoTimeLayouts {
+		if t, err := time.Parse(layout, text); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("failed to parse draw time %q", text)
+}
+
+// Ensure VietlottWebScraper implements port.KenoScraper
+var _ port.KenoScraper = (*VietlottWebScraper)(nil)
@@ -0,0 +1,69 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/api/vietlott"
+)
+
+// kenoRowHTML renders a Keno results row with the numbers first..first+count-1
+func kenoRowHTML(drawNumber, first, count int, drawTime string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, `<div class="result-row"><span class="ky">#%07d</span><span class="ngay">%s</span>`, drawNumber, drawTime)
+	for i := range count {
+		fmt.Fprintf(&b, `<span class="ball">%02d</span>`, first+i)
+	}
+	b.WriteString("</div>\n")
+	return b.String()
+}
+
+func TestVietlottWebScraper_FetchLatestKenoDraws(t *testing.T) {
+	page := "<html><body>" +
+		kenoRowHTML(123458, 61, 20, "14/03/2026 09:16") +
+		kenoRowHTML(123457, 1, 19, "14/03/2026 09:08") + // A number short
+		kenoRowHTML(123456, 21, 20, "14/03/2026  09:00") +
+		"</body></html>"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, vietlott.KenoResultsPath, r.URL.Path)
+		_, _ = w.Write([]byte(page))
+	}))
+	t.Cleanup(srv.Close)
+
+	s := NewVietlottWebScraper(srv.URL, 5*time.Second, 1, 0)
+	draws, err := s.FetchLatestKenoDraws(context.Background(), 10)
+	require.NoError(t, err)
+
+	require.Len(t, draws, 2)
+	assert.Equal(t, 123458, draws[0].DrawNumber)
+	assert.Equal(t, time.Date(2026, 3, 14, 9, 16, 0, 0, time.UTC), draws[0].DrawTime)
+	assert.Equal(t, 61, draws[0].Numbers[0])
+	assert.Equal(t, 80, draws[0].Numbers[19])
+	assert.Equal(t, 123456, draws[1].DrawNumber)
+	assert.Equal(t, time.Date(2026, 3, 14, 9, 0, 0, 0, time.UTC), draws[1].DrawTime)
+
+	draws, err = s.FetchLatestKenoDraws(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Len(t, draws, 1)
+}
+
+func TestParseKenoPage_ReportsBadRows(t *testing.T) {
+	page := "<html><body>" +
+		kenoRowHTML(9, 70, 20, "14/03/2026 09:16") + // Runs past 80
+		kenoRowHTML(10, 1, 20, "someday") +
+		"</body></html>"
+
+	draws, rowErrs, err := parseKenoPage(page, 10)
+	require.NoError(t, err)
+	assert.Empty(t, draws)
+	require.Len(t, rowErrs, 2)
+	assert.Contains(t, rowErrs[0].Err.Error(), "invalid numbers")
+	assert.Contains(t, rowErrs[1].Err.Error(), "draw time")
+}
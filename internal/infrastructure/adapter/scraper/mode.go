@@ -0,0 +1,30 @@
+package scraper
+
+import (
+	"fmt"
+
+	"github.com/tool_predict/internal/application/port"
+)
+
+// Scraper modes selectable via scraper.vietlott.mode
+const (
+	ModeLive   = "live"   // Fetch from Vietlott
+	ModeRecord = "record" // Fetch from Vietlott and save every response
+	ModeReplay = "replay" // Serve saved responses only, no network access
+)
+
+// WithMode wraps the live scraper according to mode. Recordings are read
+// from and written to recordingsDir. An empty mode means live.
+func WithMode(live port.VietlottScraper, mode string, recordingsDir string) (port.VietlottScraper, error) {
+	switch mode {
+	case "", ModeLive:
+		return live, nil
+	case ModeRecord:
+		return NewRecordingScraper(live, recordingsDir)
+	case ModeReplay:
+		return NewReplayScraper(recordingsDir)
+	default:
+		return nil, fmt.Errorf("unknown scraper mode %q (expected %s, %s or %s)",
+			mode, ModeLive, ModeRecord, ModeReplay)
+	}
+}
@@ -0,0 +1,340 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/ledongthuc/pdf"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+// Patterns in the text of an official draw result PDF. Labels are matched
+// after lower-casing and dropping combining accents; the accented vowels of
+// "giải nhất" and the like are left to '.', since PDFs and OCR spell them
+// every which way.
+var (
+	pdfDrawNumberPattern = regexp.MustCompile(`\b(?:k.\s*(?:quay\s*(?:th..ng|s.)\s*)?|draw\s*(?:no\.?)?\s*):?\s*#?\s*0*(\d{1,6})\b`)
+	pdfDatePattern       = regexp.MustCompile(`\b(\d{1,2})/(\d{1,2})/(\d{4})\b`)
+	pdfNumbersLine       = regexp.MustCompile(`^[\d\s|,\-–]+$`)
+	pdfAmountPattern     = regexp.MustCompile(`\d[\d.,]*`)
+)
+
+// pdfPrizeLabels maps the label starting a prize row to its tier, Jackpot 2
+// before the jackpot it would otherwise match
+var pdfPrizeLabels = []struct {
+	pattern *regexp.Regexp
+	tier    entity.PrizeTier
+}{
+	{regexp.MustCompile(`^jackpot\s*2\b`), entity.PrizeJackpot2},
+	{regexp.MustCompile(`^(?:jackpot|gi.i\s+(?:đ|d).c\s+bi.t)`), entity.PrizeJackpot},
+	{regexp.MustCompile(`^(?:gi.i\s+nh.t|first\s+prize)\b`), entity.PrizeFirst},
+	{regexp.MustCompile(`^(?:gi.i\s+nh.|second\s+prize)(?:\s|$)`), entity.PrizeSecond},
+	{regexp.MustCompile(`^(?:gi.i\s+ba|third\s+prize)(?:\s|$)`), entity.PrizeThird},
+}
+
+// drawResultPDFPattern matches the official result PDF names, such as
+// 25.12.23---[655]---01285---draw-result.pdf, capturing the draw number
+var drawResultPDFPattern = regexp.MustCompile(`---0*(\d+)---draw-result\.pdf`)
+
+// ParseDrawResultText parses the text of an official draw result PDF: the
+// draw number and date, the winning numbers and each prize tier's winners
+// and value. Power 6/55's bonus number, printed after the six, isn't kept.
+// The jackpot tier fills the draw's Jackpot and Winners.
+func ParseDrawResultText(gameType valueobject.GameType, text string) (*entity.Draw, error) {
+	lines := make([]string, 0)
+	for _, line := range strings.Split(text, "\n") {
+		if line = foldPDFText(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("no text in draw result")
+	}
+
+	var drawNumber int
+	var drawDate time.Time
+	for _, line := range lines {
+		if drawNumber == 0 {
+			if match := pdfDrawNumberPattern.FindStringSubmatch(line); match != nil {
+				drawNumber, _ = strconv.Atoi(match[1])
+			}
+		}
+		if drawDate.IsZero() {
+			if match := pdfDatePattern.FindStringSubmatch(line); match != nil {
+				day, _ := strconv.Atoi(match[1])
+				month, _ := strconv.Atoi(match[2])
+				year, _ := strconv.Atoi(match[3])
+				drawDate = time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+			}
+		}
+	}
+	if drawNumber == 0 {
+		return nil, fmt.Errorf("no draw number found in draw result")
+	}
+	if drawDate.IsZero() {
+		return nil, fmt.Errorf("no draw date found in draw result")
+	}
+
+	winning, err := findWinningNumbers(gameType, lines)
+	if err != nil {
+		return nil, err
+	}
+	numbers, err := valueobject.NewNumbersForGame(winning, gameType)
+	if err != nil {
+		return nil, fmt.Errorf("invalid winning numbers: %w", err)
+	}
+
+	prizes := parsePrizeRows(lines)
+	var jackpot float64
+	var winners int
+	for _, prize := range prizes {
+		if prize.Tier == entity.PrizeJackpot {
+			jackpot, winners = prize.Amount, prize.Winners
+		}
+	}
+
+	draw, err := entity.NewDraw(gameType, drawNumber, numbers, drawDate, jackpot, winners)
+	if err != nil {
+		return nil, err
+	}
+	draw.Prizes = prizes
+	return draw, nil
+}
+
+// findWinningNumbers finds the winning numbers: the first line, label aside,
+// of nothing but six two-digit numbers (seven with Power 6/55's bonus), or
+// else six numbers printed one per line, as the balls sometimes are
+func findWinningNumbers(gameType valueobject.GameType, lines []string) ([]int, error) {
+	counts := []int{6}
+	if gameType == valueobject.Power655 {
+		counts = append(counts, 7)
+	}
+
+	for _, line := range lines {
+		if i := strings.LastIndex(line, ":"); i >= 0 {
+			line = strings.TrimSpace(line[i+1:])
+		}
+		if !pdfNumbersLine.MatchString(line) {
+			continue
+		}
+		numbers, ok := twoDigitNumbers(strings.FieldsFunc(line, func(r rune) bool {
+			return !unicode.IsDigit(r)
+		}))
+		if ok && slices.Contains(counts, len(numbers)) {
+			return numbers[:6], nil
+		}
+	}
+
+	run := make([]string, 0, 6)
+	for _, line := range lines {
+		if len(line) > 2 || !pdfNumbersLine.MatchString(line) {
+			run = run[:0]
+			continue
+		}
+		if run = append(run, line); len(run) == 6 {
+			numbers, _ := twoDigitNumbers(run)
+			return numbers, nil
+		}
+	}
+	return nil, fmt.Errorf("no winning numbers found in draw result")
+}
+
+// twoDigitNumbers parses fields of one or two digits, reporting false if any
+// is longer
+func twoDigitNumbers(fields []string) ([]int, bool) {
+	numbers := make([]int, 0, len(fields))
+	for _, field := range fields {
+		if len(field) > 2 {
+			return nil, false
+		}
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, false
+		}
+		numbers = append(numbers, n)
+	}
+	return numbers, true
+}
+
+// parsePrizeRows parses the prize table: each row starts with its tier and
+// ends with the winners and the prize value, whatever the match column shows
+// in between. A tier is taken from its first row only.
+func parsePrizeRows(lines []string) []entity.PrizeResult {
+	prizes := make([]entity.PrizeResult, 0)
+	seen := make(map[entity.PrizeTier]bool)
+	for _, line := range lines {
+		for _, label := range pdfPrizeLabels {
+			loc := label.pattern.FindStringIndex(line)
+			if loc == nil {
+				continue
+			}
+			amounts := pdfAmountPattern.FindAllString(line[loc[1]:], -1)
+			if len(amounts) >= 2 && !seen[label.tier] {
+				seen[label.tier] = true
+				prizes = append(prizes, entity.PrizeResult{
+					Tier:    label.tier,
+					Winners: parseCount(amounts[len(amounts)-2]),
+					Amount:  parseVND(amounts[len(amounts)-1]),
+				})
+			}
+			break
+		}
+	}
+	return prizes
+}
+
+// foldPDFText lower-cases a line, drops combining accents and collapses
+// whitespace, so labels match however the PDF or OCR encoded them
+func foldPDFText(line string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(line) {
+		if !unicode.Is(unicode.Mn, r) {
+			b.WriteRune(r)
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// ExtractPDFText extracts the text of a PDF one line per row, top to bottom.
+// The PDF reader panics on some malformed files; that's returned as an error.
+func ExtractPDFText(path string) (text string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			text, err = "", fmt.Errorf("failed to read malformed PDF: %v", r)
+		}
+	}()
+
+	file, reader, err := pdf.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open PDF: %w", err)
+	}
+	defer file.Close()
+
+	var b strings.Builder
+	for i := 1; i <= reader.NumPage(); i++ {
+		page := reader.Page(i)
+		if page.V.IsNull() {
+			continue
+		}
+		rows, err := page.GetTextByRow()
+		if err != nil {
+			return "", fmt.Errorf("failed to read text of page %d: %w", i, err)
+		}
+		// PDF coordinates grow upwards, so the top row has the highest position
+		sort.SliceStable(rows, func(a, c int) bool {
+			return rows[a].Position > rows[c].Position
+		})
+		for _, row := range rows {
+			words := make([]string, 0, len(row.Content))
+			for _, text := range row.Content {
+				words = append(words, text.S)
+			}
+			b.WriteString(strings.Join(words, " "))
+			b.WriteByte('\n')
+		}
+	}
+	return b.String(), nil
+}
+
+// OCRPDF renders the first page of a PDF with pdftoppm and reads it with
+// tesseract in language, for result PDFs that are scanned images with no
+// text to extract. Both tools must be installed.
+func OCRPDF(ctx context.Context, path string, language string) (string, error) {
+	dir, err := os.MkdirTemp("", "draw-result-ocr")
+	if err != nil {
+		return "", fmt.Errorf("failed to create OCR directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	imageBase := filepath.Join(dir, "page")
+	render := exec.CommandContext(ctx, "pdftoppm", "-png", "-r", "300", "-singlefile", path, imageBase)
+	if output, err := render.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("pdftoppm failed: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	args := []string{imageBase + ".png", "stdout", "--psm", "6"}
+	if language != "" {
+		args = append(args, "-l", language)
+	}
+	ocr := exec.CommandContext(ctx, "tesseract", args...)
+	text, err := ocr.Output()
+	if err != nil {
+		return "", fmt.Errorf("tesseract failed: %w", err)
+	}
+	return string(text), nil
+}
+
+// DrawResultPDFParser parses official draw result PDFs, reading their text
+// and falling back to OCR when there's none or it doesn't parse
+type DrawResultPDFParser struct {
+	extract func(path string) (string, error)
+	ocr     func(ctx context.Context, path string) (string, error) // nil disables the OCR fallback
+}
+
+// NewDrawResultPDFParser creates a PDF parser. A non-empty ocrLanguage, a
+// tesseract language such as "vie", enables the OCR fallback.
+func NewDrawResultPDFParser(ocrLanguage string) *DrawResultPDFParser {
+	p := &DrawResultPDFParser{extract: ExtractPDFText}
+	if ocrLanguage != "" {
+		p.ocr = func(ctx context.Context, path string) (string, error) {
+			return OCRPDF(ctx, path, ocrLanguage)
+		}
+	}
+	return p
+}
+
+// ParseFile parses the draw result PDF at path
+func (p *DrawResultPDFParser) ParseFile(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	path string,
+) (*entity.Draw, error) {
+	text, err := p.extract(path)
+	if err == nil {
+		var draw *entity.Draw
+		if draw, err = ParseDrawResultText(gameType, text); err == nil {
+			return draw, nil
+		}
+	}
+	if p.ocr == nil {
+		return nil, err
+	}
+
+	logger.Debug("PDF text didn't parse, falling back to OCR",
+		zap.String("file", path),
+		zap.Error(err),
+	)
+	text, ocrErr := p.ocr(ctx, path)
+	if ocrErr != nil {
+		return nil, fmt.Errorf("%w; OCR fallback: %w", err, ocrErr)
+	}
+	draw, ocrErr := ParseDrawResultText(gameType, text)
+	if ocrErr != nil {
+		return nil, fmt.Errorf("%w; OCR fallback: %w", err, ocrErr)
+	}
+	return draw, nil
+}
+
+// drawNumberFromPDFName returns the draw number in an official result PDF's
+// name, 0 if it has none
+func drawNumberFromPDFName(name string) int {
+	match := drawResultPDFPattern.FindStringSubmatch(name)
+	if match == nil {
+		return 0
+	}
+	drawNumber, _ := strconv.Atoi(match[1])
+	return drawNumber
+}
@@ -0,0 +1,202 @@
+package scraper
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// power655ResultText is the text of an official Power 6/55 result PDF as
+// ExtractPDFText returns it, one row per line
+const power655ResultText = `CÔNG TY XỔ SỐ ĐIỆN TOÁN VIỆT NAM
+KẾT QUẢ QUAY SỐ MỞ THƯỞNG
+Xổ số tự chọn Power 6/55
+Kỳ quay thưởng: #01285 Ngày quay thưởng: 23/12/2025
+Bộ số trúng thưởng: 05 12 19 27 33 41 | 22
+Giải thưởng Kết quả Số lượng giải Giá trị giải (đồng)
+Jackpot 1 O O O O O O 0 45.123.456.789
+Jackpot 2 O O O O O | O 1 3.456.789.000
+Giải Nhất O O O O O 15 40.000.000
+Giải Nhì O O O O 812 500.000
+Giải Ba O O O 17.245 50.000
+`
+
+func TestParseDrawResultText_Power655(t *testing.T) {
+	draw, err := ParseDrawResultText(valueobject.Power655, power655ResultText)
+	require.NoError(t, err)
+
+	assert.Equal(t, valueobject.Power655, draw.GameType)
+	assert.Equal(t, 1285, draw.DrawNumber)
+	assert.Equal(t, time.Date(2025, 12, 23, 0, 0, 0, 0, time.UTC), draw.DrawDate)
+	assert.Equal(t, []int{5, 12, 19, 27, 33, 41}, draw.Numbers.AsSlice())
+	assert.Equal(t, 45123456789.0, draw.Jackpot)
+	assert.Zero(t, draw.Winners)
+	assert.Equal(t, []entity.PrizeResult{
+		{Tier: entity.PrizeJackpot, Winners: 0, Amount: 45123456789},
+		{Tier: entity.PrizeJackpot2, Winners: 1, Amount: 3456789000},
+		{Tier: entity.PrizeFirst, Winners: 15, Amount: 40000000},
+		{Tier: entity.PrizeSecond, Winners: 812, Amount: 500000},
+		{Tier: entity.PrizeThird, Winners: 17245, Amount: 50000},
+	}, draw.Prizes)
+}
+
+func TestParseDrawResultText_Mega645DecomposedAndBallsPerLine(t *testing.T) {
+	// Accents as combining marks, as some PDFs and OCR produce them, and the
+	// winning numbers printed one ball per line
+	text := "Ke\u0302\u0301t qua\u0309 Mega 6/45\n" +
+		"Ky\u0300 quay thu\u031bo\u031b\u0309ng #01201 - 14/01/2026\n" +
+		"03\n09\n17\n22\n30\n44\n" +
+		"Gia\u0309i \u0110a\u0323\u0306c bie\u0323\u0302t 1 12.345.678.900\n" +
+		"Gia\u0309i Nha\u0302\u0301t 20 10.000.000\n"
+
+	draw, err := ParseDrawResultText(valueobject.Mega645, text)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1201, draw.DrawNumber)
+	assert.Equal(t, time.Date(2026, 1, 14, 0, 0, 0, 0, time.UTC), draw.DrawDate)
+	assert.Equal(t, []int{3, 9, 17, 22, 30, 44}, draw.Numbers.AsSlice())
+	assert.Equal(t, 12345678900.0, draw.Jackpot)
+	assert.Equal(t, 1, draw.Winners)
+	first, ok := draw.Prize(entity.PrizeFirst)
+	require.True(t, ok)
+	assert.Equal(t, 20, first.Winners)
+}
+
+func TestParseDrawResultText_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"empty", "  \n", "no text"},
+		{"no draw number", "Ngày 23/12/2025\n05 12 19 27 33 41", "no draw number"},
+		{"no date", "Kỳ #01285\n05 12 19 27 33 41", "no draw date"},
+		{"no numbers", "Kỳ #01285 23/12/2025\nGiải Nhất 15 40.000.000", "no winning numbers"},
+		{"out of range", "Kỳ #01201 14/01/2026\n03 09 17 22 30 50", "invalid winning numbers"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseDrawResultText(valueobject.Mega645, tt.text)
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+func TestDrawResultPDFParser_FallsBackToOCR(t *testing.T) {
+	var ocred []string
+	p := &DrawResultPDFParser{
+		// A scanned PDF has no text to extract
+		extract: func(path string) (string, error) { return "", nil },
+		ocr: func(ctx context.Context, path string) (string, error) {
+			ocred = append(ocred, path)
+			return power655ResultText, nil
+		},
+	}
+
+	draw, err := p.ParseFile(context.Background(), valueobject.Power655, "result.pdf")
+	require.NoError(t, err)
+	assert.Equal(t, 1285, draw.DrawNumber)
+	assert.Equal(t, []string{"result.pdf"}, ocred)
+
+	// Without OCR the extraction's failure is returned
+	p.ocr = nil
+	_, err = p.ParseFile(context.Background(), valueobject.Power655, "result.pdf")
+	assert.ErrorContains(t, err, "no text in draw result")
+
+	p.extract = func(path string) (string, error) { return "", errors.New("not a PDF") }
+	p.ocr = func(ctx context.Context, path string) (string, error) { return "", errors.New("tesseract not found") }
+	_, err = p.ParseFile(context.Background(), valueobject.Power655, "result.pdf")
+	assert.ErrorContains(t, err, "not a PDF; OCR fallback: tesseract not found")
+}
+
+// minimalPDF builds a one-page PDF showing lines top to bottom in
+// Helvetica, enough for ExtractPDFText
+func minimalPDF(lines []string) []byte {
+	var content strings.Builder
+	content.WriteString("BT /F1 12 Tf\n")
+	for i, line := range lines {
+		fmt.Fprintf(&content, "1 0 0 1 50 %d Tm (%s) Tj\n", 750-20*i, line)
+	}
+	content.WriteString("ET")
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Contents 4 0 R /Resources << /Font << /F1 5 0 R >> >> >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.String()),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+	}
+
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = b.Len()
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	xref := b.Len()
+	fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&b, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&b, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return b.Bytes()
+}
+
+func TestExtractPDFText(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "draw-result.pdf")
+	require.NoError(t, os.WriteFile(path, minimalPDF([]string{
+		"Mega 6/45 draw no. 01201 14/01/2026",
+		"03 09 17 22 30 44",
+		"Jackpot 1 12.345.678.900",
+		"First prize 20 10.000.000",
+	}), 0644))
+
+	text, err := ExtractPDFText(path)
+	require.NoError(t, err)
+	assert.Contains(t, text, "03 09 17 22 30 44")
+
+	draw, err := NewDrawResultPDFParser("").ParseFile(context.Background(), valueobject.Mega645, path)
+	require.NoError(t, err)
+	assert.Equal(t, 1201, draw.DrawNumber)
+	assert.Equal(t, []int{3, 9, 17, 22, 30, 44}, draw.Numbers.AsSlice())
+	assert.Equal(t, 12345678900.0, draw.Jackpot)
+	assert.Equal(t, 1, draw.Winners)
+	first, ok := draw.Prize(entity.PrizeFirst)
+	require.True(t, ok)
+	assert.Equal(t, entity.PrizeResult{Tier: entity.PrizeFirst, Winners: 20, Amount: 10000000}, first)
+}
+
+func TestExtractPDFText_NotAPDF(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "draw-result.pdf")
+	require.NoError(t, os.WriteFile(path, []byte("<html>not found</html>"), 0644))
+
+	_, err := ExtractPDFText(path)
+	assert.Error(t, err)
+}
+
+func TestExtractPDFText_MalformedPDF(t *testing.T) {
+	// A trailer key that isn't a name makes the PDF reader panic
+	malformed := bytes.Replace(minimalPDF([]string{"Mega 6/45"}), []byte("<< /Size"), []byte("<< 1 /Size"), 1)
+	path := filepath.Join(t.TempDir(), "draw-result.pdf")
+	require.NoError(t, os.WriteFile(path, malformed, 0644))
+
+	_, err := ExtractPDFText(path)
+	assert.ErrorContains(t, err, "malformed PDF")
+}
+
+func TestDrawNumberFromPDFName(t *testing.T) {
+	assert.Equal(t, 1285, drawNumberFromPDFName("https://media.vietlott.vn/x/25.12.23---[655]---01285---draw-result.pdf"))
+	assert.Zero(t, drawNumberFromPDFName("https://media.vietlott.vn/x/brochure.pdf"))
+}
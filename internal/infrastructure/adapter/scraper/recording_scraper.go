@@ -0,0 +1,151 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/tool_predict/internal/application/port"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+// recordingTimeFormat is used for dates embedded in recording file names
+const recordingTimeFormat = "20060102T150405"
+
+// RecordingScraper decorates a scraper and saves every successful response
+// to disk so ReplayScraper can serve it later without network access
+type RecordingScraper struct {
+	inner port.VietlottScraper
+	dir   string
+}
+
+// NewRecordingScraper creates a recording decorator writing into dir
+func NewRecordingScraper(inner port.VietlottScraper, dir string) (*RecordingScraper, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create recordings directory: %w", err)
+	}
+	return &RecordingScraper{inner: inner, dir: dir}, nil
+}
+
+// FetchLatestDraws fetches from the inner scraper and records the draws
+func (s *RecordingScraper) FetchLatestDraws(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	limit int,
+) ([]*entity.Draw, error) {
+	draws, err := s.inner.FetchLatestDraws(ctx, gameType, limit)
+	if err != nil {
+		return nil, err
+	}
+	s.record(latestDrawsKey(gameType, limit), draws)
+	return draws, nil
+}
+
+// FetchAllDraws fetches from the inner scraper and records the draws
+func (s *RecordingScraper) FetchAllDraws(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	fromDate time.Time,
+) ([]*entity.Draw, error) {
+	draws, err := s.inner.FetchAllDraws(ctx, gameType, fromDate)
+	if err != nil {
+		return nil, err
+	}
+	s.record(allDrawsKey(gameType, fromDate), draws)
+	return draws, nil
+}
+
+// FetchDrawByNumber fetches from the inner scraper and records the draw
+func (s *RecordingScraper) FetchDrawByNumber(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	drawNumber int,
+) (*entity.Draw, error) {
+	draw, err := s.inner.FetchDrawByNumber(ctx, gameType, drawNumber)
+	if err != nil {
+		return nil, err
+	}
+	s.record(drawByNumberKey(gameType, drawNumber), draw)
+	return draw, nil
+}
+
+// FetchDrawsByDateRange fetches from the inner scraper and records the draws
+func (s *RecordingScraper) FetchDrawsByDateRange(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	startDate time.Time,
+	endDate time.Time,
+) ([]*entity.Draw, error) {
+	draws, err := s.inner.FetchDrawsByDateRange(ctx, gameType, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	s.record(dateRangeKey(gameType, startDate, endDate), draws)
+	return draws, nil
+}
+
+// GetLatestDrawNumber fetches from the inner scraper and records the number
+func (s *RecordingScraper) GetLatestDrawNumber(
+	ctx context.Context,
+	gameType valueobject.GameType,
+) (int, error) {
+	drawNumber, err := s.inner.GetLatestDrawNumber(ctx, gameType)
+	if err != nil {
+		return 0, err
+	}
+	s.record(latestDrawNumberKey(gameType), drawNumber)
+	return drawNumber, nil
+}
+
+// record writes a response to disk. Failures are logged, never returned, so
+// recording can't break a live fetch.
+func (s *RecordingScraper) record(key string, data interface{}) {
+	filename := filepath.Join(s.dir, key+".json")
+
+	jsonData, err := json.MarshalIndent(data, "", "  ")
+	if err == nil {
+		err = os.WriteFile(filename, jsonData, 0644)
+	}
+	if err != nil {
+		logger.Warn("Failed to record scraper response",
+			zap.String("file", filename),
+			zap.Error(err),
+		)
+		return
+	}
+
+	logger.Debug("Recorded scraper response", zap.String("file", filename))
+}
+
+// Recording keys, shared by RecordingScraper and ReplayScraper
+
+func latestDrawsKey(gameType valueobject.GameType, limit int) string {
+	return fmt.Sprintf("latest_%s_%d", strings.ToLower(string(gameType)), limit)
+}
+
+func allDrawsKey(gameType valueobject.GameType, fromDate time.Time) string {
+	return fmt.Sprintf("all_%s_%s", strings.ToLower(string(gameType)), fromDate.UTC().Format(recordingTimeFormat))
+}
+
+func drawByNumberKey(gameType valueobject.GameType, drawNumber int) string {
+	return fmt.Sprintf("draw_%s_%d", strings.ToLower(string(gameType)), drawNumber)
+}
+
+func dateRangeKey(gameType valueobject.GameType, startDate, endDate time.Time) string {
+	return fmt.Sprintf("range_%s_%s_%s", strings.ToLower(string(gameType)),
+		startDate.UTC().Format(recordingTimeFormat), endDate.UTC().Format(recordingTimeFormat))
+}
+
+func latestDrawNumberKey(gameType valueobject.GameType) string {
+	return fmt.Sprintf("latest_number_%s", strings.ToLower(string(gameType)))
+}
+
+// Ensure RecordingScraper implements port.VietlottScraper
+var _ port.VietlottScraper = (*RecordingScraper)(nil)
@@ -0,0 +1,86 @@
+package scraper
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func TestRecordingScraper_ReplayReturnsIdenticalDraws(t *testing.T) {
+	srv := newPagedAPIServer(t, 40, 100)
+	live := NewVietlottAPIScraper(srv.URL, 5*time.Second, 1, 0)
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	recorder, err := WithMode(live, ModeRecord, dir)
+	require.NoError(t, err)
+
+	recorded, err := recorder.FetchLatestDraws(ctx, valueobject.Power655, 30)
+	require.NoError(t, err)
+	require.Len(t, recorded, 30)
+	recordedNumber, err := recorder.GetLatestDrawNumber(ctx, valueobject.Power655)
+	require.NoError(t, err)
+
+	// Replay must not touch the network
+	srv.Close()
+
+	replayer, err := WithMode(live, ModeReplay, dir)
+	require.NoError(t, err)
+
+	replayed, err := replayer.FetchLatestDraws(ctx, valueobject.Power655, 30)
+	require.NoError(t, err)
+	require.Len(t, replayed, len(recorded))
+	for i := range recorded {
+		assert.Equal(t, recorded[i].DrawNumber, replayed[i].DrawNumber)
+		assert.Equal(t, recorded[i].Numbers, replayed[i].Numbers)
+		assert.True(t, recorded[i].DrawDate.Equal(replayed[i].DrawDate))
+		assert.Equal(t, recorded[i].GameType, replayed[i].GameType)
+	}
+
+	replayedNumber, err := replayer.GetLatestDrawNumber(ctx, valueobject.Power655)
+	require.NoError(t, err)
+	assert.Equal(t, recordedNumber, replayedNumber)
+}
+
+func TestReplayScraper_FetchLatestDraws_TruncatesLargerRecording(t *testing.T) {
+	srv := newPagedAPIServer(t, 40, 100)
+	live := NewVietlottAPIScraper(srv.URL, 5*time.Second, 1, 0)
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	recorder, err := NewRecordingScraper(live, dir)
+	require.NoError(t, err)
+	recorded, err := recorder.FetchLatestDraws(ctx, valueobject.Power655, 30)
+	require.NoError(t, err)
+
+	replayer, err := NewReplayScraper(dir)
+	require.NoError(t, err)
+	replayed, err := replayer.FetchLatestDraws(ctx, valueobject.Power655, 10)
+	require.NoError(t, err)
+	require.Len(t, replayed, 10)
+	assert.Equal(t, recorded[0].DrawNumber, replayed[0].DrawNumber)
+}
+
+func TestReplayScraper_MissingRecording(t *testing.T) {
+	replayer, err := NewReplayScraper(t.TempDir())
+	require.NoError(t, err)
+
+	_, err = replayer.FetchLatestDraws(context.Background(), valueobject.Mega645, 10)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no recording for FetchLatestDraws")
+}
+
+func TestWithMode(t *testing.T) {
+	live := NewVietlottAPIScraper("http://localhost", time.Second, 1, 0)
+
+	s, err := WithMode(live, "", t.TempDir())
+	require.NoError(t, err)
+	assert.Same(t, live, s)
+
+	_, err = WithMode(live, "offline", t.TempDir())
+	assert.Error(t, err)
+}
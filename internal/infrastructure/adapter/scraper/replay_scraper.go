@@ -0,0 +1,157 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tool_predict/internal/application/port"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// ReplayScraper serves responses previously saved by RecordingScraper,
+// allowing offline development and deterministic tests
+type ReplayScraper struct {
+	dir string
+}
+
+// NewReplayScraper creates a scraper replaying recordings from dir
+func NewReplayScraper(dir string) (*ReplayScraper, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("recordings directory not available: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("recordings path %s is not a directory", dir)
+	}
+	return &ReplayScraper{dir: dir}, nil
+}
+
+// FetchLatestDraws replays a recorded FetchLatestDraws call. When no recording
+// exists for this exact limit, the largest recorded limit is truncated instead.
+func (s *ReplayScraper) FetchLatestDraws(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	limit int,
+) ([]*entity.Draw, error) {
+	var draws []*entity.Draw
+	err := s.replay(latestDrawsKey(gameType, limit), &draws)
+	if os.IsNotExist(err) {
+		if fallback, ok := s.largestLatestRecording(gameType); ok {
+			err = s.replay(fallback, &draws)
+		}
+	}
+	if err != nil {
+		return nil, s.replayError("FetchLatestDraws", gameType, err)
+	}
+
+	if len(draws) > limit {
+		draws = draws[:limit]
+	}
+	return draws, nil
+}
+
+// FetchAllDraws replays a recorded FetchAllDraws call
+func (s *ReplayScraper) FetchAllDraws(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	fromDate time.Time,
+) ([]*entity.Draw, error) {
+	var draws []*entity.Draw
+	if err := s.replay(allDrawsKey(gameType, fromDate), &draws); err != nil {
+		return nil, s.replayError("FetchAllDraws", gameType, err)
+	}
+	return draws, nil
+}
+
+// FetchDrawByNumber replays a recorded FetchDrawByNumber call
+func (s *ReplayScraper) FetchDrawByNumber(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	drawNumber int,
+) (*entity.Draw, error) {
+	var draw entity.Draw
+	if err := s.replay(drawByNumberKey(gameType, drawNumber), &draw); err != nil {
+		return nil, s.replayError("FetchDrawByNumber", gameType, err)
+	}
+	return &draw, nil
+}
+
+// FetchDrawsByDateRange replays a recorded FetchDrawsByDateRange call
+func (s *ReplayScraper) FetchDrawsByDateRange(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	startDate time.Time,
+	endDate time.Time,
+) ([]*entity.Draw, error) {
+	var draws []*entity.Draw
+	if err := s.replay(dateRangeKey(gameType, startDate, endDate), &draws); err != nil {
+		return nil, s.replayError("FetchDrawsByDateRange", gameType, err)
+	}
+	return draws, nil
+}
+
+// GetLatestDrawNumber replays a recorded GetLatestDrawNumber call
+func (s *ReplayScraper) GetLatestDrawNumber(
+	ctx context.Context,
+	gameType valueobject.GameType,
+) (int, error) {
+	var drawNumber int
+	if err := s.replay(latestDrawNumberKey(gameType), &drawNumber); err != nil {
+		return 0, s.replayError("GetLatestDrawNumber", gameType, err)
+	}
+	return drawNumber, nil
+}
+
+// replay loads the recording for key into data
+func (s *ReplayScraper) replay(key string, data interface{}) error {
+	file, err := os.ReadFile(filepath.Join(s.dir, key+".json"))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(file, data)
+}
+
+// largestLatestRecording finds the FetchLatestDraws recording with the highest limit
+func (s *ReplayScraper) largestLatestRecording(gameType valueobject.GameType) (string, bool) {
+	files, err := os.ReadDir(s.dir)
+	if err != nil {
+		return "", false
+	}
+
+	prefix := latestDrawsKey(gameType, 0)
+	prefix = strings.TrimSuffix(prefix, "0")
+
+	bestKey, bestLimit := "", -1
+	for _, file := range files {
+		key := strings.TrimSuffix(file.Name(), ".json")
+		if file.IsDir() || !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		limit, err := strconv.Atoi(strings.TrimPrefix(key, prefix))
+		if err != nil {
+			continue
+		}
+		if limit > bestLimit {
+			bestKey, bestLimit = key, limit
+		}
+	}
+	return bestKey, bestLimit >= 0
+}
+
+func (s *ReplayScraper) replayError(method string, gameType valueobject.GameType, err error) error {
+	if os.IsNotExist(err) {
+		return fmt.Errorf("no recording for %s(%s) in %s; record it first with scraper mode \"record\"",
+			method, gameType, s.dir)
+	}
+	return fmt.Errorf("failed to replay %s(%s): %w", method, gameType, err)
+}
+
+// Ensure ReplayScraper implements port.VietlottScraper
+var _ port.VietlottScraper = (*ReplayScraper)(nil)
@@ -14,6 +14,7 @@ import (
 	"github.com/tool_predict/api/vietlott"
 	"github.com/tool_predict/internal/application/port"
 	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/repository"
 	"github.com/tool_predict/internal/domain/valueobject"
 	"github.com/tool_predict/internal/infrastructure/logger"
 	"go.uber.org/zap"
@@ -27,7 +28,10 @@ type VietlottAPIScraper struct {
 	timeout     time.Duration
 	retryCount  int
 	rateLimit   time.Duration
+	pageSize    int
 	lastRequest time.Time
+	audits      auditLog
+	webFallback bool // Whether a failed API fetch retries on the web pages
 }
 
 // NewVietlottAPIScraper creates a new Vietlott API scraper
@@ -41,13 +45,37 @@ func NewVietlottAPIScraper(baseURL string, timeout time.Duration, retryCount int
 				DisableCompression: false,
 			},
 		},
-		baseURL:    baseURL,
-		timeout:    timeout,
-		retryCount: retryCount,
-		rateLimit:  time.Duration(rateLimit) * time.Second,
+		baseURL:     baseURL,
+		timeout:     timeout,
+		retryCount:  retryCount,
+		rateLimit:   time.Duration(rateLimit) * time.Second,
+		pageSize:    vietlott.DefaultPageSize,
+		webFallback: true,
 	}
 }
 
+// SetPageSize sets the maximum number of draws requested per page.
+// Larger limits are fetched across several pages.
+func (s *VietlottAPIScraper) SetPageSize(pageSize int) error {
+	if pageSize < 1 {
+		return fmt.Errorf("page size must be at least 1, got %d", pageSize)
+	}
+	s.pageSize = pageSize
+	return nil
+}
+
+// SetAuditRepository records every results page crawled, by the API or the
+// web fallback, as a scrape audit in repo; nil stops auditing
+func (s *VietlottAPIScraper) SetAuditRepository(repo repository.ScrapeAuditRepository) {
+	s.audits = auditLog{repo: repo}
+}
+
+// SetWebFallback sets whether a failed API fetch falls back to scraping the
+// web pages. The fallback chain turns it off to try the web pages itself.
+func (s *VietlottAPIScraper) SetWebFallback(enabled bool) {
+	s.webFallback = enabled
+}
+
 // FetchLatestDraws fetches the most recent draws for a game type
 func (s *VietlottAPIScraper) FetchLatestDraws(
 	ctx context.Context,
@@ -59,14 +87,16 @@ func (s *VietlottAPIScraper) FetchLatestDraws(
 
 	// Try API first, fall back to web scraping
 	draws, err := s.fetchFromAPI(ctx, gameType, limit)
+	if err != nil && !s.webFallback {
+		return nil, err
+	}
 	if err != nil {
 		logger.Warn("API fetch failed, falling back to web scraping",
 			zap.String("game_type", string(gameType)),
 			zap.Error(err),
 		)
 		// Fall back to web scraper
-		webScraper := NewVietlottWebScraper(s.baseURL, s.timeout, s.retryCount, int(s.rateLimit.Seconds()))
-		return webScraper.FetchLatestDraws(ctx, gameType, limit)
+		return s.webScraper(int(s.rateLimit.Seconds())).FetchLatestDraws(ctx, gameType, limit)
 	}
 
 	return draws, nil
@@ -83,13 +113,15 @@ func (s *VietlottAPIScraper) FetchAllDraws(
 
 	// Try API first
 	draws, err := s.fetchFromAPI(ctx, gameType, 1000) // Fetch large batch
+	if err != nil && !s.webFallback {
+		return nil, err
+	}
 	if err != nil {
 		logger.Warn("API fetch failed, falling back to web scraping",
 			zap.String("game_type", string(gameType)),
 			zap.Error(err),
 		)
-		webScraper := NewVietlottWebScraper(s.baseURL, s.timeout, s.retryCount, int(s.rateLimit.Seconds()))
-		return webScraper.FetchAllDraws(ctx, gameType, fromDate)
+		return s.webScraper(int(s.rateLimit.Seconds())).FetchAllDraws(ctx, gameType, fromDate)
 	}
 
 	// Filter by date
@@ -171,7 +203,58 @@ func (s *VietlottAPIScraper) GetLatestDrawNumber(
 	return draws[0].DrawNumber, nil
 }
 
-// fetchFromAPI attempts to fetch data from the API
+// FetchDrawDetail fetches a draw's jackpot and winners from its detail page.
+// There is no detail API, so the page is scraped.
+func (s *VietlottAPIScraper) FetchDrawDetail(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	drawNumber int,
+) (*port.DrawDetail, error) {
+	// Rate limiting
+	s.waitForRateLimit()
+
+	// Already rate limited above
+	return s.webScraper(0).FetchDrawDetail(ctx, gameType, drawNumber)
+}
+
+// FetchDrawPage fetches one API page of draws, newest first. A page shorter
+// than the page size is the last one.
+func (s *VietlottAPIScraper) FetchDrawPage(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	page int,
+) ([]*entity.Draw, bool, error) {
+	if page < vietlott.DefaultPageNumber {
+		return nil, false, fmt.Errorf("page must be at least %d, got %d", vietlott.DefaultPageNumber, page)
+	}
+
+	apiPath, ok := vietlott.GameTypePathMap[strings.ToLower(string(gameType))]
+	if !ok {
+		return nil, false, fmt.Errorf("unknown game type: %s", gameType)
+	}
+	u, err := url.Parse(s.baseURL + apiPath)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to parse URL: %w", err)
+	}
+
+	// Rate limiting
+	s.waitForRateLimit()
+
+	draws, itemCount, err := s.fetchAPIPage(ctx, u, gameType, page, s.pageSize)
+	if err != nil {
+		return nil, false, err
+	}
+	return draws, itemCount < s.pageSize, nil
+}
+
+// maxAPIPages bounds the pages a single fetch walks, however many draws
+// the site keeps serving
+const maxAPIPages = 1000
+
+// fetchFromAPI attempts to fetch data from the API, paginating when limit
+// exceeds the page size so the server can't silently truncate the result.
+// A page adding no new draw ends the walk, in case the site ignores the page
+// parameter and serves the first page again.
 func (s *VietlottAPIScraper) fetchFromAPI(
 	ctx context.Context,
 	gameType valueobject.GameType,
@@ -189,37 +272,143 @@ func (s *VietlottAPIScraper) fetchFromAPI(
 		return nil, fmt.Errorf("failed to parse URL: %w", err)
 	}
 
+	pageSize := s.pageSize
+	if limit < pageSize {
+		pageSize = limit
+	}
+
+	draws := make([]*entity.Draw, 0, limit)
+	seen := make(map[int]bool)
+	for page := vietlott.DefaultPageNumber; len(draws) < limit; page++ {
+		if page >= vietlott.DefaultPageNumber+maxAPIPages {
+			logger.Warn("Stopped paging the API at the page limit",
+				zap.String("game_type", string(gameType)),
+				zap.Int("pages", maxAPIPages),
+				zap.Int("total_draws", len(draws)),
+			)
+			break
+		}
+		if page > vietlott.DefaultPageNumber {
+			s.waitForRateLimit()
+		}
+
+		pageDraws, itemCount, err := s.fetchAPIPage(ctx, u, gameType, page, pageSize)
+		if err != nil {
+			if page == vietlott.DefaultPageNumber {
+				return nil, err
+			}
+			return nil, fmt.Errorf("failed to fetch page %d: %w", page, err)
+		}
+
+		added := 0
+		for _, draw := range pageDraws {
+			if seen[draw.DrawNumber] {
+				continue
+			}
+			seen[draw.DrawNumber] = true
+			draws = append(draws, draw)
+			added++
+		}
+
+		logger.Debug("Fetched API page",
+			zap.String("game_type", string(gameType)),
+			zap.Int("page", page),
+			zap.Int("items", itemCount),
+			zap.Int("total_draws", len(draws)),
+		)
+
+		// A short page means there is nothing more to fetch
+		if itemCount < pageSize {
+			break
+		}
+		if added == 0 && page > vietlott.DefaultPageNumber {
+			logger.Warn("API page added no new draws, stopping",
+				zap.String("game_type", string(gameType)),
+				zap.Int("page", page),
+			)
+			break
+		}
+	}
+
+	if len(draws) == 0 {
+		return nil, fmt.Errorf("no valid draws found in API response")
+	}
+
+	if len(draws) > limit {
+		draws = draws[:limit]
+	}
+
+	return draws, nil
+}
+
+// webScraper creates the web scraper the API falls back to, auditing into
+// the same repository
+func (s *VietlottAPIScraper) webScraper(rateLimit int) *VietlottWebScraper {
+	webScraper := NewVietlottWebScraper(s.baseURL, s.timeout, s.retryCount, rateLimit)
+	webScraper.audits = s.audits
+	return webScraper
+}
+
+// fetchAPIPage fetches a single page of results and audits the crawl. It
+// returns the valid draws and the raw number of items on the page.
+func (s *VietlottAPIScraper) fetchAPIPage(
+	ctx context.Context,
+	pageURL *url.URL,
+	gameType valueobject.GameType,
+	page int,
+	pageSize int,
+) ([]*entity.Draw, int, error) {
+	u := *pageURL
+
 	// Add query parameters
 	q := u.Query()
-	q.Set("page", strconv.Itoa(vietlott.DefaultPageNumber))
-	q.Set("pageSize", strconv.Itoa(limit))
+	q.Set("page", strconv.Itoa(page))
+	q.Set("pageSize", strconv.Itoa(pageSize))
 	u.RawQuery = q.Encode()
 
+	audit := entity.NewScrapeAudit(gameType, entity.ScrapeSourceAPI, u.String())
+	draws, itemCount, err := s.requestAPIPage(ctx, &u, gameType, audit)
+	s.audits.save(ctx, audit, err)
+	return draws, itemCount, err
+}
+
+// requestAPIPage requests a page of results, recording each attempt, the
+// response status and what parsed in audit
+func (s *VietlottAPIScraper) requestAPIPage(
+	ctx context.Context,
+	u *url.URL,
+	gameType valueobject.GameType,
+	audit *entity.ScrapeAudit,
+) ([]*entity.Draw, int, error) {
 	// Make request with retry
 	var resp *http.Response
+	var err error
 	for attempt := 0; attempt < s.retryCount; attempt++ {
-		req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+		var req *http.Request
+		req, err = http.NewRequestWithContext(ctx, "GET", u.String(), nil)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create request: %w", err)
+			return nil, 0, fmt.Errorf("failed to create request: %w", err)
 		}
 
 		// Set headers
 		req.Header.Set("Accept", "application/json")
 		req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; tool_predict/1.0)")
 
+		audit.Attempts++
 		resp, err = s.client.Do(req)
+		if resp != nil {
+			audit.StatusCode = resp.StatusCode
+		}
 		if err == nil && resp.StatusCode == http.StatusOK {
 			break
 		}
 
-		if resp != nil {
-			resp.Body.Close()
-		}
+		logger.Warn("API request attempt failed", attemptFields(u.String(), attempt+1, s.retryCount, resp, err)...)
 
 		if attempt < s.retryCount-1 {
 			select {
 			case <-ctx.Done():
-				return nil, ctx.Err()
+				return nil, 0, ctx.Err()
 			case <-time.After(time.Second * time.Duration(attempt+1)):
 				// Exponential backoff
 			}
@@ -227,18 +416,21 @@ func (s *VietlottAPIScraper) fetchFromAPI(
 	}
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch from API after %d attempts: %w", s.retryCount, err)
+		return nil, 0, fmt.Errorf("failed to fetch from API after %d attempts: %w", s.retryCount, err)
+	}
+	if resp == nil {
+		return nil, 0, fmt.Errorf("failed to fetch from API: no attempts made")
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
+		return nil, 0, fmt.Errorf("API returned status %d", resp.StatusCode)
 	}
 
 	// Parse response
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, 0, fmt.Errorf("failed to read response body: %w", err)
 	}
 
 	// Try to parse as JSON
@@ -255,19 +447,21 @@ func (s *VietlottAPIScraper) fetchFromAPI(
 	}
 
 	if err := json.Unmarshal(body, &apiResponse); err != nil {
+		logger.Warn("Failed to parse API response", responseFields(u.String(), resp.StatusCode, len(body), err)...)
 		// Not a valid API response, fall back to web scraping
-		return nil, fmt.Errorf("invalid API response: %w", err)
+		return nil, 0, fmt.Errorf("invalid API response: %w", err)
 	}
 
 	// Convert to entities
 	draws := make([]*entity.Draw, 0, len(apiResponse.Data.Items))
 	for _, item := range apiResponse.Data.Items {
-		numbers, err := valueobject.NewNumbers(item.Numbers)
+		numbers, err := valueobject.NewNumbersForGame(item.Numbers, gameType)
 		if err != nil {
 			logger.Warn("Invalid numbers in draw",
 				zap.Int("draw_number", item.DrawNumber),
 				zap.Error(err),
 			)
+			audit.AddParseError(fmt.Errorf("draw %d: %w", item.DrawNumber, err))
 			continue
 		}
 
@@ -281,6 +475,7 @@ func (s *VietlottAPIScraper) fetchFromAPI(
 			// Try alternative date formats
 			drawDate, err = time.Parse("2006-01-02", item.DrawDate)
 			if err != nil {
+				audit.AddParseError(fmt.Errorf("draw %d: invalid date %q", item.DrawNumber, item.DrawDate))
 				continue
 			}
 		}
@@ -297,17 +492,16 @@ func (s *VietlottAPIScraper) fetchFromAPI(
 			logger.Warn("Failed to create draw entity",
 				zap.Error(err),
 			)
+			audit.AddParseError(fmt.Errorf("draw %d: %w", item.DrawNumber, err))
 			continue
 		}
 
 		draws = append(draws, draw)
 	}
 
-	if len(draws) == 0 {
-		return nil, fmt.Errorf("no valid draws found in API response")
-	}
-
-	return draws, nil
+	audit.ItemsFound = len(apiResponse.Data.Items)
+	audit.DrawsFound = len(draws)
+	return draws, len(apiResponse.Data.Items), nil
 }
 
 // waitForRateLimit implements rate limiting
@@ -321,5 +515,10 @@ func (s *VietlottAPIScraper) waitForRateLimit() {
 	}
 }
 
-// Ensure VietlottAPIScraper implements port.VietlottScraper
-var _ port.VietlottScraper = (*VietlottAPIScraper)(nil)
+// Ensure VietlottAPIScraper implements port.VietlottScraper, port.DrawDetailFetcher
+// and port.DrawPageFetcher
+var (
+	_ port.VietlottScraper   = (*VietlottAPIScraper)(nil)
+	_ port.DrawDetailFetcher = (*VietlottAPIScraper)(nil)
+	_ port.DrawPageFetcher   = (*VietlottAPIScraper)(nil)
+)
@@ -0,0 +1,269 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/api/vietlott"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// pagedAPIServer serves totalDraws draws, newest first, and never returns
+// more than maxPageSize items per page regardless of the requested pageSize
+type pagedAPIServer struct {
+	*httptest.Server
+	mu            sync.Mutex
+	requestedSize []int
+}
+
+func newPagedAPIServer(t *testing.T, totalDraws, maxPageSize int) *pagedAPIServer {
+	t.Helper()
+
+	srv := &pagedAPIServer{}
+	srv.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, vietlott.Power655ResultsPath, r.URL.Path)
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		pageSize, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
+
+		srv.mu.Lock()
+		srv.requestedSize = append(srv.requestedSize, pageSize)
+		srv.mu.Unlock()
+
+		if pageSize > maxPageSize {
+			pageSize = maxPageSize
+		}
+
+		type item struct {
+			DrawNumber int    `json:"drawNumber"`
+			Numbers    []int  `json:"numbers"`
+			DrawDate   string `json:"drawDate"`
+		}
+		items := make([]item, 0, pageSize)
+		baseDate := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		for i := (page - 1) * pageSize; i < page*pageSize && i < totalDraws; i++ {
+			drawNumber := totalDraws - i
+			items = append(items, item{
+				DrawNumber: drawNumber,
+				Numbers:    []int{1 + i%40, 42, 43, 44, 45, 46},
+				DrawDate:   baseDate.AddDate(0, 0, drawNumber).Format("2006-01-02T15:04:05"),
+			})
+		}
+
+		var resp struct {
+			Data struct {
+				Items []item `json:"items"`
+			} `json:"data"`
+		}
+		resp.Data.Items = items
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func (s *pagedAPIServer) requests() []int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]int(nil), s.requestedSize...)
+}
+
+func TestVietlottAPIScraper_FetchLatestDraws_Paginates(t *testing.T) {
+	srv := newPagedAPIServer(t, 100, 10)
+
+	s := NewVietlottAPIScraper(srv.URL, 5*time.Second, 1, 0)
+	require.NoError(t, s.SetPageSize(10))
+
+	draws, err := s.FetchLatestDraws(context.Background(), valueobject.Power655, 25)
+	require.NoError(t, err)
+
+	require.Len(t, draws, 25)
+	assert.Equal(t, []int{10, 10, 10}, srv.requests())
+
+	// Pages are stitched together without gaps or duplicates
+	for i, draw := range draws {
+		assert.Equal(t, 100-i, draw.DrawNumber)
+	}
+}
+
+func TestVietlottAPIScraper_FetchLatestDraws_StopsOnShortPage(t *testing.T) {
+	srv := newPagedAPIServer(t, 15, 10)
+
+	s := NewVietlottAPIScraper(srv.URL, 5*time.Second, 1, 0)
+	require.NoError(t, s.SetPageSize(10))
+
+	draws, err := s.FetchLatestDraws(context.Background(), valueobject.Power655, 50)
+	require.NoError(t, err)
+
+	assert.Len(t, draws, 15)
+	assert.Equal(t, []int{10, 10}, srv.requests())
+}
+
+func TestVietlottAPIScraper_FetchLatestDraws_StopsWhenPagesRepeat(t *testing.T) {
+	// A site ignoring the page parameter serves the first page every time
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		items := make([]map[string]any, 0, 10)
+		for i := 0; i < 10; i++ {
+			items = append(items, map[string]any{
+				"drawNumber": 100 - i,
+				"numbers":    []int{1 + i, 42, 43, 44, 45, 46},
+				"drawDate":   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, 100-i).Format("2006-01-02T15:04:05"),
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"items": items}})
+	}))
+	t.Cleanup(srv.Close)
+
+	s := NewVietlottAPIScraper(srv.URL, 5*time.Second, 1, 0)
+	require.NoError(t, s.SetPageSize(10))
+
+	draws, err := s.FetchLatestDraws(context.Background(), valueobject.Power655, 50)
+	require.NoError(t, err)
+	assert.Len(t, draws, 10)
+	assert.EqualValues(t, 2, requests.Load())
+}
+
+func TestVietlottAPIScraper_FetchLatestDraws_SinglePageForSmallLimit(t *testing.T) {
+	srv := newPagedAPIServer(t, 100, 10)
+
+	s := NewVietlottAPIScraper(srv.URL, 5*time.Second, 1, 0)
+	require.NoError(t, s.SetPageSize(10))
+
+	draws, err := s.FetchLatestDraws(context.Background(), valueobject.Power655, 5)
+	require.NoError(t, err)
+
+	assert.Len(t, draws, 5)
+	assert.Equal(t, []int{5}, srv.requests())
+}
+
+func TestVietlottAPIScraper_FetchDrawPage(t *testing.T) {
+	srv := newPagedAPIServer(t, 25, 10)
+
+	s := NewVietlottAPIScraper(srv.URL, 5*time.Second, 1, 0)
+	require.NoError(t, s.SetPageSize(10))
+	ctx := context.Background()
+
+	draws, last, err := s.FetchDrawPage(ctx, valueobject.Power655, 2)
+	require.NoError(t, err)
+	require.Len(t, draws, 10)
+	assert.Equal(t, 15, draws[0].DrawNumber)
+	assert.False(t, last)
+
+	draws, last, err = s.FetchDrawPage(ctx, valueobject.Power655, 3)
+	require.NoError(t, err)
+	require.Len(t, draws, 5)
+	assert.Equal(t, 1, draws[4].DrawNumber)
+	assert.True(t, last)
+
+	_, _, err = s.FetchDrawPage(ctx, valueobject.Power655, 0)
+	assert.Error(t, err)
+}
+
+func TestVietlottAPIScraper_SetPageSize_RejectsNonPositive(t *testing.T) {
+	s := NewVietlottAPIScraper("http://localhost", time.Second, 1, 0)
+	assert.Error(t, s.SetPageSize(0))
+	assert.Error(t, s.SetPageSize(-5))
+}
+
+func TestVietlottAPIScraper_RejectsNumbersOutsideGameRange(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, vietlott.Mega645ResultsPath, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"items":[
+			{"drawNumber":1202,"numbers":[3,9,17,22,30,55],"drawDate":"2026-01-16T00:00:00"},
+			{"drawNumber":1201,"numbers":[3,9,17,22,30,44],"drawDate":"2026-01-14T00:00:00"}
+		]}}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	s := NewVietlottAPIScraper(srv.URL, 5*time.Second, 1, 0)
+
+	// 55 is out of range for Mega 6/45, so that draw is dropped
+	draws, err := s.fetchFromAPI(context.Background(), valueobject.Mega645, 10)
+	require.NoError(t, err)
+	require.Len(t, draws, 1)
+	assert.Equal(t, 1201, draws[0].DrawNumber)
+}
+
+func TestVietlottAPIScraper_LogsScrapeAttempts(t *testing.T) {
+	core, logs := observer.New(zapcore.WarnLevel)
+	t.Cleanup(logger.Replace(zap.New(core)))
+
+	var failing atomic.Bool
+	failing.Store(true)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte("maintenance"))
+			return
+		}
+		_, _ = w.Write([]byte("<html>not json</html>"))
+	}))
+	t.Cleanup(srv.Close)
+
+	s := NewVietlottAPIScraper(srv.URL, 5*time.Second, 1, 0)
+
+	_, err := s.fetchFromAPI(context.Background(), valueobject.Mega645, 10)
+	require.Error(t, err)
+
+	attempts := logs.FilterMessage("API request attempt failed").All()
+	require.Len(t, attempts, 1)
+	fields := attempts[0].ContextMap()
+	assert.Contains(t, fields["url"], srv.URL+vietlott.Mega645ResultsPath)
+	assert.EqualValues(t, 1, fields["attempt"])
+	assert.EqualValues(t, 1, fields["max_attempts"])
+	assert.EqualValues(t, http.StatusServiceUnavailable, fields["status_code"])
+	assert.EqualValues(t, len("maintenance"), fields["response_size"])
+
+	failing.Store(false)
+	_, err = s.fetchFromAPI(context.Background(), valueobject.Mega645, 10)
+	require.Error(t, err)
+
+	parses := logs.FilterMessage("Failed to parse API response").All()
+	require.Len(t, parses, 1)
+	fields = parses[0].ContextMap()
+	assert.Contains(t, fields["url"], srv.URL)
+	assert.EqualValues(t, http.StatusOK, fields["status_code"])
+	assert.EqualValues(t, len("<html>not json</html>"), fields["response_size"])
+	assert.Contains(t, fields, "error")
+}
+
+func TestVietlottAPIScraper_SetWebFallback(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(srv.Close)
+
+	s := NewVietlottAPIScraper(srv.URL, 5*time.Second, 1, 0)
+	s.SetWebFallback(false)
+
+	// Only the API request is made; the web pages aren't tried
+	_, err := s.FetchLatestDraws(context.Background(), valueobject.Mega645, 10)
+	require.Error(t, err)
+	assert.EqualValues(t, 1, requests.Load())
+
+	s.SetWebFallback(true)
+	requests.Store(0)
+	_, err = s.FetchLatestDraws(context.Background(), valueobject.Mega645, 10)
+	require.Error(t, err)
+	assert.Greater(t, requests.Load(), int32(1))
+}
@@ -0,0 +1,255 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chromedp/chromedp"
+	"github.com/tool_predict/api/vietlott"
+	"github.com/tool_predict/internal/application/port"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/repository"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+// Scraper engines selectable via scraper.engine
+const (
+	EngineHTTP     = "http"     // The JSON API, falling back to the static HTML pages
+	EngineChromedp = "chromedp" // The results pages rendered in headless Chrome
+)
+
+// ChromedpOptions configures the headless browser
+type ChromedpOptions struct {
+	ExecPath  string        // Chrome or Chromium binary; empty looks for one in the usual places
+	Timeout   time.Duration // Limit on loading and rendering one page
+	Settle    time.Duration // Wait after the page is ready for its scripts to fill in the results
+	RateLimit int           // Seconds between page loads
+}
+
+// VietlottChromedpScraper scrapes the results pages in headless Chrome, for
+// when they're rendered by JavaScript and the static HTML the web scraper
+// reads holds no draws. The rendered page is parsed with the web scraper's
+// selectors. Each page load starts its own browser, so nothing is left
+// running between crawls.
+type VietlottChromedpScraper struct {
+	baseURL     string
+	options     ChromedpOptions
+	rateLimit   time.Duration
+	parser      *VietlottWebScraper
+	render      func(ctx context.Context, url string) (string, error)
+	audits      auditLog
+	mu          sync.Mutex
+	lastRequest time.Time
+}
+
+// NewVietlottChromedpScraper creates a headless browser scraper for the site
+// at baseURL
+func NewVietlottChromedpScraper(baseURL string, options ChromedpOptions) *VietlottChromedpScraper {
+	s := &VietlottChromedpScraper{
+		baseURL:   baseURL,
+		options:   options,
+		rateLimit: time.Duration(options.RateLimit) * time.Second,
+		parser:    NewVietlottWebScraper(baseURL, options.Timeout, 1, 0),
+	}
+	s.render = s.renderPage
+	return s
+}
+
+// SetAuditRepository records every results page crawled as a scrape audit
+// in repo; nil stops auditing
+func (s *VietlottChromedpScraper) SetAuditRepository(repo repository.ScrapeAuditRepository) {
+	s.audits = auditLog{repo: repo}
+}
+
+// FetchLatestDraws fetches the most recent draws for a game type
+func (s *VietlottChromedpScraper) FetchLatestDraws(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	limit int,
+) ([]*entity.Draw, error) {
+	s.waitForRateLimit()
+
+	resultsPath, ok := vietlott.GameTypePathMap[strings.ToLower(string(gameType))]
+	if !ok {
+		return nil, fmt.Errorf("unknown game type: %s", gameType)
+	}
+	url := s.baseURL + resultsPath
+
+	audit := entity.NewScrapeAudit(gameType, entity.ScrapeSourceBrowser, url)
+	draws, err := s.scrapeDrawsPage(ctx, gameType, url, limit, audit)
+	s.audits.save(ctx, audit, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scrape rendered draws page: %w", err)
+	}
+	return draws, nil
+}
+
+// FetchAllDraws fetches all draws from a specified date onwards
+func (s *VietlottChromedpScraper) FetchAllDraws(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	fromDate time.Time,
+) ([]*entity.Draw, error) {
+	// Everything the results page shows
+	draws, err := s.FetchLatestDraws(ctx, gameType, 1000)
+	if err != nil {
+		return nil, err
+	}
+
+	filteredDraws := make([]*entity.Draw, 0)
+	for _, draw := range draws {
+		if !draw.DrawDate.Before(fromDate) {
+			filteredDraws = append(filteredDraws, draw)
+		}
+	}
+	return filteredDraws, nil
+}
+
+// FetchDrawByNumber fetches a specific draw by its draw number
+func (s *VietlottChromedpScraper) FetchDrawByNumber(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	drawNumber int,
+) (*entity.Draw, error) {
+	draws, err := s.FetchLatestDraws(ctx, gameType, 100)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, draw := range draws {
+		if draw.DrawNumber == drawNumber {
+			return draw, nil
+		}
+	}
+	return nil, fmt.Errorf("draw number %d not found for game type %s", drawNumber, gameType)
+}
+
+// FetchDrawsByDateRange fetches all draws within a date range
+func (s *VietlottChromedpScraper) FetchDrawsByDateRange(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	startDate time.Time,
+	endDate time.Time,
+) ([]*entity.Draw, error) {
+	draws, err := s.FetchAllDraws(ctx, gameType, startDate)
+	if err != nil {
+		return nil, err
+	}
+
+	filteredDraws := make([]*entity.Draw, 0)
+	for _, draw := range draws {
+		if draw.DrawDate.Before(endDate) {
+			filteredDraws = append(filteredDraws, draw)
+		}
+	}
+	return filteredDraws, nil
+}
+
+// GetLatestDrawNumber returns the most recent draw number
+func (s *VietlottChromedpScraper) GetLatestDrawNumber(
+	ctx context.Context,
+	gameType valueobject.GameType,
+) (int, error) {
+	draws, err := s.FetchLatestDraws(ctx, gameType, 1)
+	if err != nil {
+		return 0, err
+	}
+	if len(draws) == 0 {
+		return 0, fmt.Errorf("no draws found for game type %s", gameType)
+	}
+	return draws[0].DrawNumber, nil
+}
+
+// scrapeDrawsPage renders the draws page and parses up to limit draws from
+// it, recording what parsed in audit
+func (s *VietlottChromedpScraper) scrapeDrawsPage(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	url string,
+	limit int,
+	audit *entity.ScrapeAudit,
+) ([]*entity.Draw, error) {
+	audit.Attempts++
+	html, err := s.render(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := s.parser.parseDrawsPage(url, html, gameType, limit)
+	if err != nil {
+		return nil, err
+	}
+	audit.ItemsFound = report.RowsMatched
+	audit.DrawsFound = len(report.Draws)
+	for _, rowErr := range report.RowErrors {
+		logger.Warn("Failed to parse rendered draw row",
+			zap.String("url", url),
+			zap.Int("row", rowErr.Row),
+			zap.Error(rowErr.Err),
+		)
+		audit.AddParseError(fmt.Errorf("row %d: %w", rowErr.Row, rowErr.Err))
+	}
+
+	if len(report.Draws) == 0 {
+		return nil, fmt.Errorf("no draws found on rendered page (%d rows matched)", report.RowsMatched)
+	}
+	return report.Draws, nil
+}
+
+// renderPage loads url in a fresh headless browser and returns the page's
+// HTML once its scripts have had Settle to run
+func (s *VietlottChromedpScraper) renderPage(ctx context.Context, url string) (string, error) {
+	// The sandbox and /dev/shm are often unavailable in containers and CI
+	allocatorOptions := append(chromedp.DefaultExecAllocatorOptions[:],
+		chromedp.Flag("no-sandbox", true),
+		chromedp.Flag("disable-dev-shm-usage", true),
+		chromedp.Flag("disable-gpu", true),
+	)
+	if s.options.ExecPath != "" {
+		allocatorOptions = append(allocatorOptions, chromedp.ExecPath(s.options.ExecPath))
+	}
+
+	allocatorCtx, cancelAllocator := chromedp.NewExecAllocator(ctx, allocatorOptions...)
+	defer cancelAllocator()
+	browserCtx, cancelBrowser := chromedp.NewContext(allocatorCtx)
+	defer cancelBrowser()
+	if s.options.Timeout > 0 {
+		var cancelTimeout context.CancelFunc
+		browserCtx, cancelTimeout = context.WithTimeout(browserCtx, s.options.Timeout)
+		defer cancelTimeout()
+	}
+
+	var html string
+	err := chromedp.Run(browserCtx,
+		chromedp.Navigate(url),
+		chromedp.WaitReady("body", chromedp.ByQuery),
+		chromedp.Sleep(s.options.Settle),
+		chromedp.OuterHTML("html", &html, chromedp.ByQuery),
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to render %s: %w", url, err)
+	}
+	return html, nil
+}
+
+// waitForRateLimit implements rate limiting
+func (s *VietlottChromedpScraper) waitForRateLimit() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.rateLimit > 0 {
+		timeSinceLastRequest := time.Since(s.lastRequest)
+		if timeSinceLastRequest < s.rateLimit {
+			time.Sleep(s.rateLimit - timeSinceLastRequest)
+		}
+		s.lastRequest = time.Now()
+	}
+}
+
+// Ensure VietlottChromedpScraper implements port.VietlottScraper
+var _ port.VietlottScraper = (*VietlottChromedpScraper)(nil)
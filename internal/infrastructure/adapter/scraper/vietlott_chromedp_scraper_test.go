@@ -0,0 +1,101 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/api/vietlott"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// newTestChromedpScraper serves html as every rendered page, recording the
+// URLs rendered, so no browser is needed
+func newTestChromedpScraper(t *testing.T, html string, err error) (*VietlottChromedpScraper, *[]string) {
+	t.Helper()
+
+	var rendered []string
+	s := NewVietlottChromedpScraper("https://vietlott.test", ChromedpOptions{Timeout: time.Second})
+	s.render = func(ctx context.Context, url string) (string, error) {
+		rendered = append(rendered, url)
+		return html, err
+	}
+	return s, &rendered
+}
+
+func TestVietlottChromedpScraper_FetchLatestDraws(t *testing.T) {
+	s, rendered := newTestChromedpScraper(t, resultsPageHTML, nil)
+	audits := &memoryAuditRepository{}
+	s.SetAuditRepository(audits)
+
+	draws, err := s.FetchLatestDraws(context.Background(), valueobject.Mega645, 10)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"https://vietlott.test" + vietlott.Mega645ResultsPath}, *rendered)
+	require.Len(t, draws, 1)
+	assert.Equal(t, 1234, draws[0].DrawNumber)
+	assert.Equal(t, []int{5, 12, 19, 27, 33, 41}, draws[0].Numbers.AsSlice())
+
+	require.Len(t, audits.audits, 1)
+	audit := audits.audits[0]
+	assert.Equal(t, entity.ScrapeSourceBrowser, audit.Source)
+	assert.Equal(t, 1, audit.Attempts)
+	assert.Equal(t, 3, audit.ItemsFound)
+	assert.Equal(t, 1, audit.DrawsFound)
+	assert.Len(t, audit.ParseErrors, 2)
+}
+
+func TestVietlottChromedpScraper_NoDrawsRendered(t *testing.T) {
+	s, _ := newTestChromedpScraper(t, `<html><body><div id="app"></div></body></html>`, nil)
+
+	_, err := s.FetchLatestDraws(context.Background(), valueobject.Power655, 10)
+	assert.ErrorContains(t, err, "no draws found on rendered page (0 rows matched)")
+}
+
+func TestVietlottChromedpScraper_RenderError(t *testing.T) {
+	s, _ := newTestChromedpScraper(t, "", errors.New("chrome not found"))
+	audits := &memoryAuditRepository{}
+	s.SetAuditRepository(audits)
+
+	_, err := s.FetchLatestDraws(context.Background(), valueobject.Mega645, 10)
+	assert.ErrorContains(t, err, "chrome not found")
+	require.Len(t, audits.audits, 1)
+	assert.Equal(t, "chrome not found", audits.audits[0].Error)
+}
+
+func TestVietlottChromedpScraper_Queries(t *testing.T) {
+	s, _ := newTestChromedpScraper(t, resultsPageHTML, nil)
+	ctx := context.Background()
+
+	draw, err := s.FetchDrawByNumber(ctx, valueobject.Mega645, 1234)
+	require.NoError(t, err)
+	assert.Equal(t, 1234, draw.DrawNumber)
+
+	_, err = s.FetchDrawByNumber(ctx, valueobject.Mega645, 999)
+	assert.Error(t, err)
+
+	latest, err := s.GetLatestDrawNumber(ctx, valueobject.Mega645)
+	require.NoError(t, err)
+	assert.Equal(t, 1234, latest)
+
+	inRange, err := s.FetchDrawsByDateRange(ctx, valueobject.Mega645,
+		time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Empty(t, inRange, "the end date is exclusive")
+
+	since, err := s.FetchAllDraws(ctx, valueobject.Mega645, time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Len(t, since, 1)
+}
+
+func TestVietlottChromedpScraper_UnknownGameType(t *testing.T) {
+	s, rendered := newTestChromedpScraper(t, resultsPageHTML, nil)
+
+	_, err := s.FetchLatestDraws(context.Background(), valueobject.GameType("LOTO_5_35"), 10)
+	assert.ErrorContains(t, err, "unknown game type")
+	assert.Empty(t, *rendered)
+}
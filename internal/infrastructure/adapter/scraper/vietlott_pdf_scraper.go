@@ -0,0 +1,335 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/tool_predict/api/vietlott"
+	"github.com/tool_predict/internal/application/port"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/repository"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+// PDFOptions configures the result PDF scraper
+type PDFOptions struct {
+	Timeout     time.Duration
+	RetryCount  int
+	RateLimit   int    // Seconds between requests
+	CacheDir    string // Where downloaded PDFs are kept, so each is fetched once
+	OCRLanguage string // tesseract language for PDFs without text, e.g. "vie"; empty disables OCR
+}
+
+// VietlottPDFScraper reads draws from the official result PDFs linked from
+// the result announcements. It's slower than the other scrapers, a download
+// per draw, but the PDFs are the authoritative results and the only source
+// of every prize tier's winners. PDFs are cached, so a draw's is only ever
+// downloaded once.
+type VietlottPDFScraper struct {
+	baseURL  string
+	cacheDir string
+	pages    *VietlottWebScraper // Fetches the announcements and PDFs
+	parser   *DrawResultPDFParser
+	audits   auditLog
+}
+
+// NewVietlottPDFScraper creates a result PDF scraper for the site at
+// baseURL, caching PDFs in options.CacheDir
+func NewVietlottPDFScraper(baseURL string, options PDFOptions) (*VietlottPDFScraper, error) {
+	if err := os.MkdirAll(options.CacheDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create result PDF cache: %w", err)
+	}
+	return &VietlottPDFScraper{
+		baseURL:  baseURL,
+		cacheDir: options.CacheDir,
+		pages:    NewVietlottWebScraper(baseURL, options.Timeout, max(options.RetryCount, 1), options.RateLimit),
+		parser:   NewDrawResultPDFParser(options.OCRLanguage),
+	}, nil
+}
+
+// SetAuditRepository records every announcement page crawled as a scrape
+// audit in repo; nil stops auditing
+func (s *VietlottPDFScraper) SetAuditRepository(repo repository.ScrapeAuditRepository) {
+	s.audits = auditLog{repo: repo}
+}
+
+// FetchLatestDraws fetches the most recent draws for a game type
+func (s *VietlottPDFScraper) FetchLatestDraws(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	limit int,
+) ([]*entity.Draw, error) {
+	draws := make([]*entity.Draw, 0, max(limit, 0))
+	if limit <= 0 {
+		return draws, nil
+	}
+	err := s.walk(ctx, gameType, func(draw *entity.Draw) bool {
+		draws = append(draws, draw)
+		return len(draws) < limit
+	})
+	if err != nil {
+		return nil, err
+	}
+	return draws, nil
+}
+
+// FetchAllDraws fetches all draws from a specified date onwards
+func (s *VietlottPDFScraper) FetchAllDraws(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	fromDate time.Time,
+) ([]*entity.Draw, error) {
+	draws := make([]*entity.Draw, 0)
+	err := s.walk(ctx, gameType, func(draw *entity.Draw) bool {
+		if draw.DrawDate.Before(fromDate) {
+			return false
+		}
+		draws = append(draws, draw)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return draws, nil
+}
+
+// FetchDrawByNumber fetches a specific draw by its draw number
+func (s *VietlottPDFScraper) FetchDrawByNumber(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	drawNumber int,
+) (*entity.Draw, error) {
+	var found *entity.Draw
+	err := s.walk(ctx, gameType, func(draw *entity.Draw) bool {
+		if draw.DrawNumber == drawNumber {
+			found = draw
+		}
+		return draw.DrawNumber > drawNumber
+	})
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, fmt.Errorf("draw number %d not found for game type %s", drawNumber, gameType)
+	}
+	return found, nil
+}
+
+// FetchDrawsByDateRange fetches all draws within a date range
+func (s *VietlottPDFScraper) FetchDrawsByDateRange(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	startDate time.Time,
+	endDate time.Time,
+) ([]*entity.Draw, error) {
+	draws, err := s.FetchAllDraws(ctx, gameType, startDate)
+	if err != nil {
+		return nil, err
+	}
+
+	filteredDraws := make([]*entity.Draw, 0)
+	for _, draw := range draws {
+		if draw.DrawDate.Before(endDate) {
+			filteredDraws = append(filteredDraws, draw)
+		}
+	}
+	return filteredDraws, nil
+}
+
+// GetLatestDrawNumber returns the most recent draw number
+func (s *VietlottPDFScraper) GetLatestDrawNumber(
+	ctx context.Context,
+	gameType valueobject.GameType,
+) (int, error) {
+	draws, err := s.FetchLatestDraws(ctx, gameType, 1)
+	if err != nil {
+		return 0, err
+	}
+	if len(draws) == 0 {
+		return 0, fmt.Errorf("no draws found for game type %s", gameType)
+	}
+	return draws[0].DrawNumber, nil
+}
+
+// walk visits the game's draws newest first, an announcement page at a time,
+// until visit returns false or the announcements run out. A page linking
+// only draws already seen is taken as the end, in case the site ignores the
+// page parameter.
+func (s *VietlottPDFScraper) walk(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	visit func(draw *entity.Draw) bool,
+) error {
+	announcementsPath, ok := vietlott.GameTypeAnnouncementPathMap[strings.ToLower(string(gameType))]
+	if !ok {
+		return fmt.Errorf("unknown game type: %s", gameType)
+	}
+
+	seen := make(map[int]bool)
+	for page := vietlott.DefaultPageNumber; ; page++ {
+		pageURL := fmt.Sprintf("%s%s?%s=%d", s.baseURL, announcementsPath, vietlott.AnnouncementPageParam, page)
+
+		audit := entity.NewScrapeAudit(gameType, entity.ScrapeSourcePDF, pageURL)
+		draws, err := s.scrapeAnnouncementPage(ctx, gameType, pageURL, seen, audit)
+		s.audits.save(ctx, audit, err)
+		if err != nil {
+			return fmt.Errorf("failed to scrape result announcements page %d: %w", page, err)
+		}
+		if len(draws) == 0 {
+			return nil
+		}
+
+		for _, draw := range draws {
+			seen[draw.DrawNumber] = true
+			if !visit(draw) {
+				return nil
+			}
+		}
+	}
+}
+
+// scrapeAnnouncementPage fetches an announcement page and parses the result
+// PDFs it links, skipping draws in seen, recording what parsed in audit. A
+// page linking PDFs none of which parse is an error.
+func (s *VietlottPDFScraper) scrapeAnnouncementPage(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	pageURL string,
+	seen map[int]bool,
+	audit *entity.ScrapeAudit,
+) ([]*entity.Draw, error) {
+	s.pages.waitForRateLimit()
+	html, err := s.pages.fetchHTML(ctx, pageURL, audit)
+	if err != nil {
+		return nil, err
+	}
+
+	links, err := resultPDFLinks(pageURL, html)
+	if err != nil {
+		return nil, err
+	}
+	audit.ItemsFound = len(links)
+
+	draws := make([]*entity.Draw, 0, len(links))
+	for _, link := range links {
+		if seen[drawNumberFromPDFName(link)] {
+			continue
+		}
+
+		draw, err := s.fetchResultPDF(ctx, gameType, link)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			logger.Warn("Failed to read result PDF",
+				zap.String("url", link),
+				zap.Error(err),
+			)
+			audit.AddParseError(fmt.Errorf("%s: %w", path.Base(link), err))
+			continue
+		}
+		if seen[draw.DrawNumber] {
+			continue
+		}
+		draws = append(draws, draw)
+	}
+	audit.DrawsFound = len(draws)
+
+	if len(draws) == 0 && len(audit.ParseErrors) > 0 {
+		return nil, fmt.Errorf("none of %d result PDFs parsed", len(audit.ParseErrors))
+	}
+	return draws, nil
+}
+
+// fetchResultPDF parses a result PDF, downloading it into the cache unless
+// it's already there. A PDF that doesn't parse is dropped from the cache, so
+// a damaged download is fetched again next time.
+func (s *VietlottPDFScraper) fetchResultPDF(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	link string,
+) (*entity.Draw, error) {
+	u, err := url.Parse(link)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse URL: %w", err)
+	}
+	filename := filepath.Join(s.cacheDir, strings.ToLower(string(gameType)), path.Base(u.Path))
+
+	if _, err := os.Stat(filename); os.IsNotExist(err) {
+		s.pages.waitForRateLimit()
+		content, err := s.pages.fetchHTML(ctx, link, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to download: %w", err)
+		}
+		// Don't cache an error page served in the PDF's place
+		if !strings.HasPrefix(content, "%PDF") {
+			return nil, fmt.Errorf("download is not a PDF")
+		}
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create result PDF cache: %w", err)
+		}
+		if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+			return nil, fmt.Errorf("failed to cache: %w", err)
+		}
+	}
+
+	draw, err := s.parser.ParseFile(ctx, gameType, filename)
+	if err == nil {
+		if want := drawNumberFromPDFName(link); want != 0 && draw.DrawNumber != want {
+			err = fmt.Errorf("PDF is for draw %d, not %d", draw.DrawNumber, want)
+		}
+	}
+	if err != nil {
+		if ctx.Err() == nil {
+			if removeErr := os.Remove(filename); removeErr != nil && !os.IsNotExist(removeErr) {
+				logger.Warn("Failed to drop unreadable result PDF from the cache",
+					zap.String("file", filename),
+					zap.Error(removeErr),
+				)
+			}
+		}
+		return nil, err
+	}
+	return draw, nil
+}
+
+// resultPDFLinks returns the absolute URLs of the result PDFs an
+// announcement page links, in page order
+func resultPDFLinks(pageURL string, html string) ([]string, error) {
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse URL: %w", err)
+	}
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	links := make([]string, 0)
+	seen := make(map[string]bool)
+	doc.Find("a[href]").Each(func(i int, a *goquery.Selection) {
+		href, _ := a.Attr("href")
+		if !drawResultPDFPattern.MatchString(href) {
+			return
+		}
+		link, err := base.Parse(href)
+		if err != nil || seen[link.String()] {
+			return
+		}
+		seen[link.String()] = true
+		links = append(links, link.String())
+	})
+	return links, nil
+}
+
+// Ensure VietlottPDFScraper implements port.VietlottScraper
+var _ port.VietlottScraper = (*VietlottPDFScraper)(nil)
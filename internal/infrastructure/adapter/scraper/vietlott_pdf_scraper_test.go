@@ -0,0 +1,158 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/api/vietlott"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// resultPDFServer serves one page of Mega 6/45 announcements linking a
+// result PDF per entry of pdfs, keyed by file name, and an empty second page.
+// It counts the requests made for each path.
+type resultPDFServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests map[string]int
+}
+
+func newResultPDFServer(t *testing.T, pdfs map[string][]byte) *resultPDFServer {
+	t.Helper()
+
+	srv := &resultPDFServer{requests: make(map[string]int)}
+	srv.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv.mu.Lock()
+		srv.requests[r.URL.Path]++
+		srv.mu.Unlock()
+
+		if r.URL.Path == vietlott.Mega645AnnouncementsPath {
+			if r.URL.Query().Get(vietlott.AnnouncementPageParam) != "1" {
+				_, _ = w.Write([]byte(`<html><body><table></table></body></html>`))
+				return
+			}
+			var rows strings.Builder
+			for name := range pdfs {
+				fmt.Fprintf(&rows, `<tr><td><a href="/media/%s">Kết quả</a></td></tr>`, name)
+			}
+			fmt.Fprintf(w, `<html><body><table>%s<tr><td><a href="/media/brochure.pdf">Thể lệ</a></td></tr></table></body></html>`, rows.String())
+			return
+		}
+
+		content, ok := pdfs[strings.TrimPrefix(r.URL.Path, "/media/")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(content)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func (s *resultPDFServer) count(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[path]
+}
+
+func megaResultPDF(drawNumber int, date string, numbers string) []byte {
+	return minimalPDF([]string{
+		fmt.Sprintf("Mega 6/45 draw no. %05d %s", drawNumber, date),
+		numbers,
+		"Jackpot 0 13.000.000.000",
+		"First prize 12 10.000.000",
+	})
+}
+
+func TestVietlottPDFScraper_FetchLatestDraws(t *testing.T) {
+	srv := newResultPDFServer(t, map[string][]byte{
+		"26.01.16---[645]---01202---draw-result.pdf": megaResultPDF(1202, "16/01/2026", "01 05 12 23 34 45"),
+		"26.01.14---[645]---01201---draw-result.pdf": megaResultPDF(1201, "14/01/2026", "03 09 17 22 30 44"),
+	})
+	s, err := NewVietlottPDFScraper(srv.URL, PDFOptions{Timeout: 5 * time.Second, RetryCount: 1, CacheDir: t.TempDir()})
+	require.NoError(t, err)
+	audits := &memoryAuditRepository{}
+	s.SetAuditRepository(audits)
+
+	draws, err := s.FetchLatestDraws(context.Background(), valueobject.Mega645, 10)
+	require.NoError(t, err)
+	require.Len(t, draws, 2)
+	byNumber := map[int]*entity.Draw{draws[0].DrawNumber: draws[0], draws[1].DrawNumber: draws[1]}
+	require.Contains(t, byNumber, 1201)
+	assert.Equal(t, []int{3, 9, 17, 22, 30, 44}, byNumber[1201].Numbers.AsSlice())
+	assert.Equal(t, 13000000000.0, byNumber[1201].Jackpot)
+	first, ok := byNumber[1201].Prize(entity.PrizeFirst)
+	require.True(t, ok)
+	assert.Equal(t, 12, first.Winners)
+
+	// Both pages were audited; the second, empty one ended the walk
+	require.Len(t, audits.audits, 2)
+	assert.Equal(t, entity.ScrapeSourcePDF, audits.audits[0].Source)
+	assert.Equal(t, 2, audits.audits[0].ItemsFound)
+	assert.Equal(t, 2, audits.audits[0].DrawsFound)
+
+	// PDFs come from the cache the second time
+	_, err = s.FetchDrawByNumber(context.Background(), valueobject.Mega645, 1201)
+	require.NoError(t, err)
+	assert.Equal(t, 1, srv.count("/media/26.01.14---[645]---01201---draw-result.pdf"))
+}
+
+func TestVietlottPDFScraper_NoPDFParses(t *testing.T) {
+	srv := newResultPDFServer(t, map[string][]byte{
+		"26.01.14---[645]---01201---draw-result.pdf": []byte("<html>maintenance</html>"),
+	})
+	s, err := NewVietlottPDFScraper(srv.URL, PDFOptions{Timeout: 5 * time.Second, RetryCount: 1, CacheDir: t.TempDir()})
+	require.NoError(t, err)
+
+	_, err = s.GetLatestDrawNumber(context.Background(), valueobject.Mega645)
+	assert.ErrorContains(t, err, "none of 1 result PDFs parsed")
+
+	// The error page wasn't cached, so it's downloaded again
+	_, err = s.GetLatestDrawNumber(context.Background(), valueobject.Mega645)
+	assert.Error(t, err)
+	assert.Equal(t, 2, srv.count("/media/26.01.14---[645]---01201---draw-result.pdf"))
+}
+
+func TestVietlottPDFScraper_DropsUnparsedPDFFromCache(t *testing.T) {
+	name := "26.01.14---[645]---01201---draw-result.pdf"
+	srv := newResultPDFServer(t, map[string][]byte{
+		name: minimalPDF([]string{"Mega 6/45 draw no. 01201 14/01/2026"}),
+	})
+	cacheDir := t.TempDir()
+	s, err := NewVietlottPDFScraper(srv.URL, PDFOptions{Timeout: 5 * time.Second, RetryCount: 1, CacheDir: cacheDir})
+	require.NoError(t, err)
+
+	// A PDF without the winning numbers doesn't parse and isn't kept
+	_, err = s.FetchDrawByNumber(context.Background(), valueobject.Mega645, 1201)
+	assert.Error(t, err)
+	assert.NoFileExists(t, filepath.Join(cacheDir, "mega_6_45", name))
+
+	_, err = s.FetchDrawByNumber(context.Background(), valueobject.Mega645, 1201)
+	assert.Error(t, err)
+	assert.Equal(t, 2, srv.count("/media/"+name))
+}
+
+func TestResultPDFLinks(t *testing.T) {
+	links, err := resultPDFLinks("https://vietlott.vn/vi/thong-bao?pageindex=1", `<html><body>
+		<a href="https://media.vietlott.vn/a/26.01.16---[645]---01202---draw-result.pdf">1202</a>
+		<a href="/b/26.01.14---[645]---01201---draw-result.pdf">1201</a>
+		<a href="/b/26.01.14---[645]---01201---draw-result.pdf">1201 again</a>
+		<a href="/b/rules.pdf">rules</a>
+	</body></html>`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"https://media.vietlott.vn/a/26.01.16---[645]---01202---draw-result.pdf",
+		"https://vietlott.vn/b/26.01.14---[645]---01201---draw-result.pdf",
+	}, links)
+}
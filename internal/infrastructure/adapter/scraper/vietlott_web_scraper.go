@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"sync"
@@ -14,6 +16,7 @@ import (
 	"github.com/tool_predict/api/vietlott"
 	"github.com/tool_predict/internal/application/port"
 	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/repository"
 	"github.com/tool_predict/internal/domain/valueobject"
 	"github.com/tool_predict/internal/infrastructure/logger"
 	"go.uber.org/zap"
@@ -28,6 +31,7 @@ type VietlottWebScraper struct {
 	rateLimit   time.Duration
 	mu          sync.Mutex
 	lastRequest time.Time
+	audits      auditLog
 }
 
 // NewVietlottWebScraper creates a new Vietlott web scraper
@@ -43,6 +47,12 @@ func NewVietlottWebScraper(baseURL string, timeout time.Duration, retryCount int
 	}
 }
 
+// SetAuditRepository records every results page crawled as a scrape audit
+// in repo; nil stops auditing
+func (s *VietlottWebScraper) SetAuditRepository(repo repository.ScrapeAuditRepository) {
+	s.audits = auditLog{repo: repo}
+}
+
 // FetchLatestDraws fetches the most recent draws for a game type
 func (s *VietlottWebScraper) FetchLatestDraws(
 	ctx context.Context,
@@ -165,95 +175,126 @@ func (s *VietlottWebScraper) GetLatestDrawNumber(
 	return draws[0].DrawNumber, nil
 }
 
-// scrapeDrawsPage scrapes the draws page and extracts draw data
+// scrapeDrawsPage scrapes the draws page, extracts draw data and audits
+// the crawl
 func (s *VietlottWebScraper) scrapeDrawsPage(
 	ctx context.Context,
 	gameType valueobject.GameType,
 	url string,
 	limit int,
 ) ([]*entity.Draw, error) {
-	// Make HTTP request with retry
-	var html string
-	for attempt := 0; attempt < s.retryCount; attempt++ {
-		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create request: %w", err)
-		}
+	audit := entity.NewScrapeAudit(gameType, entity.ScrapeSourceWeb, url)
+	draws, err := s.scrapeAuditedDrawsPage(ctx, gameType, url, limit, audit)
+	s.audits.save(ctx, audit, err)
+	return draws, err
+}
 
-		req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml")
-		req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; tool_predict/1.0)")
+// scrapeAuditedDrawsPage scrapes the draws page, recording the requests and
+// what parsed in audit
+func (s *VietlottWebScraper) scrapeAuditedDrawsPage(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	url string,
+	limit int,
+	audit *entity.ScrapeAudit,
+) ([]*entity.Draw, error) {
+	html, err := s.fetchHTML(ctx, url, audit)
+	if err != nil {
+		return nil, err
+	}
 
-		resp, err := s.client.Do(req)
-		if err != nil {
-			if attempt < s.retryCount-1 {
-				select {
-				case <-ctx.Done():
-					return nil, ctx.Err()
-				case <-time.After(time.Second * time.Duration(attempt+1)):
-				}
-				continue
-			}
-			return nil, fmt.Errorf("failed to fetch page after %d attempts: %w", s.retryCount, err)
-		}
+	report, err := s.parseDrawsPage(url, html, gameType, limit)
+	if err != nil {
+		logger.Warn("Failed to parse page", responseFields(url, http.StatusOK, len(html), err)...)
+		return nil, err
+	}
+	audit.ItemsFound = report.RowsMatched
+	audit.DrawsFound = len(report.Draws)
+	for _, rowErr := range report.RowErrors {
+		logger.Warn("Failed to parse draw row",
+			zap.String("url", url),
+			zap.Int("row", rowErr.Row),
+			zap.Error(rowErr.Err),
+		)
+		audit.AddParseError(fmt.Errorf("row %d: %w", rowErr.Row, rowErr.Err))
+	}
 
-		if resp.StatusCode == http.StatusOK {
-			body, err := io.ReadAll(resp.Body)
-			resp.Body.Close()
-			if err != nil {
-				return nil, fmt.Errorf("failed to read response body: %w", err)
-			}
-			html = string(body)
-			break
-		}
+	if len(report.Draws) == 0 {
+		err := fmt.Errorf("no draws found on page")
+		logger.Warn("Failed to parse page", responseFields(url, http.StatusOK, len(html), err)...)
+		return nil, err
+	}
 
-		resp.Body.Close()
+	return report.Draws, nil
+}
 
-		if attempt < s.retryCount-1 {
-			select {
-			case <-ctx.Done():
-				return nil, ctx.Err()
-			case <-time.After(time.Second * time.Duration(attempt+1)):
-			}
-		} else {
-			return nil, fmt.Errorf("server returned status %d", resp.StatusCode)
-		}
+// drawRowSelector matches the rows of the results page holding one draw each.
+// Note: The actual selectors depend on Vietlott's website structure; these
+// are generic ones that may need adjustment (see CheckSelectors).
+const drawRowSelector = ".result-row, tr.draw-row, .lottery-result"
+
+// SelectorReport is how a results page matched the scraper's selectors
+type SelectorReport struct {
+	URL         string
+	RowsMatched int            // Rows matching the draw row selector
+	Draws       []*entity.Draw // Rows that parsed into valid draws, in page order
+	RowErrors   []RowError     // Rows that didn't
+}
+
+// RowError is why a matched row didn't parse into a draw
+type RowError struct {
+	Row int // 0-based index among the matched rows
+	Err error
+}
+
+// CheckSelectors fetches gameType's results page and parses every draw row
+// on it without saving anything, to catch selectors that no longer match the
+// site's layout. A page without any valid draw is reported, not an error.
+func (s *VietlottWebScraper) CheckSelectors(ctx context.Context, gameType valueobject.GameType) (*SelectorReport, error) {
+	s.waitForRateLimit()
+
+	resultsPath, ok := vietlott.GameTypePathMap[strings.ToLower(string(gameType))]
+	if !ok {
+		return nil, fmt.Errorf("unknown game type: %s", gameType)
 	}
+	url := s.baseURL + resultsPath
 
-	// Parse HTML
+	html, err := s.fetchHTML(ctx, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.parseDrawsPage(url, html, gameType, math.MaxInt)
+}
+
+// parseDrawsPage parses the draw rows of a results page, stopping after limit
+// valid draws
+func (s *VietlottWebScraper) parseDrawsPage(
+	url string,
+	html string,
+	gameType valueobject.GameType,
+	limit int,
+) (*SelectorReport, error) {
 	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse HTML: %w", err)
 	}
 
-	// Extract draw data
-	// Note: The actual selectors will depend on Vietlott's website structure
-	// These are example selectors that may need adjustment
-	draws := make([]*entity.Draw, 0)
-
-	// Common pattern: look for tables or divs containing draw results
-	// This is a generic implementation - adjust selectors based on actual HTML
-	doc.Find(".result-row, tr.draw-row, .lottery-result").Each(func(i int, row *goquery.Selection) {
-		if len(draws) >= limit {
+	report := &SelectorReport{URL: url, Draws: make([]*entity.Draw, 0)}
+	doc.Find(drawRowSelector).Each(func(i int, row *goquery.Selection) {
+		if len(report.Draws) >= limit {
 			return
 		}
+		report.RowsMatched++
 
 		draw, err := s.parseDrawRow(gameType, row)
 		if err != nil {
-			logger.Warn("Failed to parse draw row",
-				zap.Int("row", i),
-				zap.Error(err),
-			)
+			report.RowErrors = append(report.RowErrors, RowError{Row: i, Err: err})
 			return
 		}
-
-		draws = append(draws, draw)
+		report.Draws = append(report.Draws, draw)
 	})
 
-	if len(draws) == 0 {
-		return nil, fmt.Errorf("no draws found on page")
-	}
-
-	return draws, nil
+	return report, nil
 }
 
 // parseDrawRow parses a single draw row from HTML
@@ -284,7 +325,7 @@ func (s *VietlottWebScraper) parseDrawRow(gameType valueobject.GameType, sel *go
 		return nil, fmt.Errorf("expected 6 numbers, got %d", len(numbers))
 	}
 
-	numbersVO, err := valueobject.NewNumbers(numbers)
+	numbersVO, err := valueobject.NewNumbersForGame(numbers, gameType)
 	if err != nil {
 		return nil, fmt.Errorf("invalid numbers: %w", err)
 	}
@@ -301,17 +342,9 @@ func (s *VietlottWebScraper) parseDrawRow(gameType valueobject.GameType, sel *go
 		}
 	}
 
-	// Extract jackpot (optional)
-	jackpotText := sel.Find(".jackpot, .prize").First().Text()
-	jackpotText = strings.TrimSpace(jackpotText)
-	jackpotText = strings.ReplaceAll(jackpotText, ",", "")
-	jackpotText = strings.ReplaceAll(jackpotText, ".", "")
-	jackpot, _ := strconv.ParseFloat(jackpotText, 64)
-
-	// Extract winners (optional)
-	winnersText := sel.Find(".winners, .winner-count").First().Text()
-	winnersText = strings.TrimSpace(winnersText)
-	winners, _ := strconv.Atoi(winnersText)
+	// Extract jackpot and winners (optional, usually only on the detail page)
+	jackpot := parseVND(sel.Find(".jackpot, .prize").First().Text())
+	winners := parseCount(sel.Find(".winners, .winner-count").First().Text())
 
 	// Create draw entity
 	draw, err := entity.NewDraw(
@@ -326,6 +359,135 @@ func (s *VietlottWebScraper) parseDrawRow(gameType valueobject.GameType, sel *go
 	return draw, err
 }
 
+// FetchDrawDetail fetches a draw's detail page (?id=NNNNN) for the jackpot
+// and winners missing from the results list
+func (s *VietlottWebScraper) FetchDrawDetail(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	drawNumber int,
+) (*port.DrawDetail, error) {
+	s.waitForRateLimit()
+
+	detailPath, ok := vietlott.GameTypeDetailPathMap[strings.ToLower(string(gameType))]
+	if !ok {
+		return nil, fmt.Errorf("unknown game type: %s", gameType)
+	}
+
+	u, err := url.Parse(s.baseURL + detailPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse URL: %w", err)
+	}
+	q := u.Query()
+	q.Set(vietlott.DetailIDParam, vietlott.FormatDrawID(drawNumber))
+	u.RawQuery = q.Encode()
+
+	html, err := s.fetchHTML(ctx, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch detail page for draw %d: %w", drawNumber, err)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	jackpotText := doc.Find(".jackpot-value, .jackpot, .so-tien").First().Text()
+	jackpot := parseVND(jackpotText)
+	if jackpot <= 0 {
+		return nil, fmt.Errorf("no jackpot found on detail page for draw %d", drawNumber)
+	}
+
+	return &port.DrawDetail{
+		DrawNumber: drawNumber,
+		Jackpot:    jackpot,
+		Winners:    parseCount(doc.Find(".jackpot-winners, .winners, .winner-count").First().Text()),
+	}, nil
+}
+
+// fetchHTML GETs a page, retrying failed requests with a growing delay. The
+// attempts and last response status are recorded in audit unless it's nil.
+func (s *VietlottWebScraper) fetchHTML(ctx context.Context, url string, audit *entity.ScrapeAudit) (string, error) {
+	for attempt := 0; attempt < s.retryCount; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+		if err != nil {
+			return "", fmt.Errorf("failed to create request: %w", err)
+		}
+
+		req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml")
+		req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; tool_predict/1.0)")
+
+		resp, err := s.client.Do(req)
+		if audit != nil {
+			audit.Attempts++
+			if resp != nil {
+				audit.StatusCode = resp.StatusCode
+			}
+		}
+		if err != nil {
+			logger.Warn("Page request attempt failed", attemptFields(url, attempt+1, s.retryCount, nil, err)...)
+			if attempt < s.retryCount-1 {
+				select {
+				case <-ctx.Done():
+					return "", ctx.Err()
+				case <-time.After(time.Second * time.Duration(attempt+1)):
+				}
+				continue
+			}
+			return "", fmt.Errorf("failed to fetch page after %d attempts: %w", s.retryCount, err)
+		}
+
+		if resp.StatusCode == http.StatusOK {
+			body, err := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				return "", fmt.Errorf("failed to read response body: %w", err)
+			}
+			return string(body), nil
+		}
+
+		statusCode := resp.StatusCode
+		logger.Warn("Page request attempt failed", attemptFields(url, attempt+1, s.retryCount, resp, nil)...)
+
+		if attempt < s.retryCount-1 {
+			select {
+			case <-ctx.Done():
+				return "", ctx.Err()
+			case <-time.After(time.Second * time.Duration(attempt+1)):
+			}
+		} else {
+			return "", fmt.Errorf("server returned status %d", statusCode)
+		}
+	}
+
+	return "", fmt.Errorf("failed to fetch page: no attempts made")
+}
+
+// parseVND parses an amount such as "12.345.678.900 đồng", returning 0 when
+// there are no digits
+func parseVND(text string) float64 {
+	amount, _ := strconv.ParseFloat(digitsOnly(text), 64)
+	return amount
+}
+
+// parseCount parses a count such as "2" or "02 vé", returning 0 when there are
+// no digits
+func parseCount(text string) int {
+	count, _ := strconv.Atoi(digitsOnly(text))
+	return count
+}
+
+// digitsOnly strips everything but ASCII digits, dropping thousands
+// separators and currency suffixes
+func digitsOnly(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
 // waitForRateLimit implements rate limiting
 func (s *VietlottWebScraper) waitForRateLimit() {
 	s.mu.Lock()
@@ -340,5 +502,8 @@ func (s *VietlottWebScraper) waitForRateLimit() {
 	}
 }
 
-// Ensure VietlottWebScraper implements port.VietlottScraper
-var _ port.VietlottScraper = (*VietlottWebScraper)(nil)
+// Ensure VietlottWebScraper implements port.VietlottScraper and port.DrawDetailFetcher
+var (
+	_ port.VietlottScraper   = (*VietlottWebScraper)(nil)
+	_ port.DrawDetailFetcher = (*VietlottWebScraper)(nil)
+)
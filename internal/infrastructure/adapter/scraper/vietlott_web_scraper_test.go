@@ -0,0 +1,130 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/api/vietlott"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+const detailPageHTML = `<html><body>
+<div class="result-detail">
+  <h3>Kỳ quay thưởng #01234</h3>
+  <div class="jackpot-value">45.678.901.500 đồng</div>
+  <table>
+    <tr><td>Jackpot</td><td class="jackpot-winners">2</td></tr>
+  </table>
+</div>
+</body></html>`
+
+func TestVietlottWebScraper_FetchDrawDetail(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, vietlott.Mega645DetailPath, r.URL.Path)
+		assert.Equal(t, "01234", r.URL.Query().Get(vietlott.DetailIDParam))
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(detailPageHTML))
+	}))
+	t.Cleanup(srv.Close)
+
+	s := NewVietlottWebScraper(srv.URL, 5*time.Second, 1, 0)
+
+	detail, err := s.FetchDrawDetail(context.Background(), valueobject.Mega645, 1234)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1234, detail.DrawNumber)
+	assert.Equal(t, 45678901500.0, detail.Jackpot)
+	assert.Equal(t, 2, detail.Winners)
+}
+
+func TestVietlottWebScraper_FetchDrawDetail_NoJackpot(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<html><body><p>Không tìm thấy kỳ quay</p></body></html>`))
+	}))
+	t.Cleanup(srv.Close)
+
+	s := NewVietlottWebScraper(srv.URL, 5*time.Second, 1, 0)
+
+	_, err := s.FetchDrawDetail(context.Background(), valueobject.Power655, 1)
+	assert.Error(t, err)
+}
+
+// resultsPageHTML has three draw rows, of which only the first parses: the
+// second is missing a number and the third has an unreadable date. The last
+// row uses a class the selectors don't know.
+const resultsPageHTML = `<html><body>
+<table>
+  <tr class="draw-row">
+    <td class="ky">01234</td><td class="ngay">15/03/2026</td>
+    <td><span class="ball">05</span><span class="ball">12</span><span class="ball">19</span>
+        <span class="ball">27</span><span class="ball">33</span><span class="ball">41</span></td>
+  </tr>
+  <tr class="draw-row">
+    <td class="ky">01233</td><td class="ngay">13/03/2026</td>
+    <td><span class="ball">02</span><span class="ball">08</span><span class="ball">16</span>
+        <span class="ball">24</span><span class="ball">38</span></td>
+  </tr>
+  <tr class="draw-row">
+    <td class="ky">01232</td><td class="ngay">Thứ 4</td>
+    <td><span class="ball">01</span><span class="ball">09</span><span class="ball">17</span>
+        <span class="ball">25</span><span class="ball">36</span><span class="ball">44</span></td>
+  </tr>
+  <tr class="ket-qua-row">
+    <td class="ky">01231</td><td class="ngay">08/03/2026</td>
+  </tr>
+</table>
+</body></html>`
+
+func TestVietlottWebScraper_CheckSelectors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, vietlott.Mega645ResultsPath, r.URL.Path)
+		_, _ = w.Write([]byte(resultsPageHTML))
+	}))
+	t.Cleanup(srv.Close)
+
+	s := NewVietlottWebScraper(srv.URL, 5*time.Second, 1, 0)
+
+	report, err := s.CheckSelectors(context.Background(), valueobject.Mega645)
+	require.NoError(t, err)
+
+	assert.Equal(t, srv.URL+vietlott.Mega645ResultsPath, report.URL)
+	assert.Equal(t, 3, report.RowsMatched)
+	require.Len(t, report.Draws, 1)
+	assert.Equal(t, 1234, report.Draws[0].DrawNumber)
+	assert.Equal(t, []int{5, 12, 19, 27, 33, 41}, report.Draws[0].Numbers.AsSlice())
+	assert.Equal(t, time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC), report.Draws[0].DrawDate)
+
+	require.Len(t, report.RowErrors, 2)
+	assert.Equal(t, 1, report.RowErrors[0].Row)
+	assert.ErrorContains(t, report.RowErrors[0].Err, "expected 6 numbers, got 5")
+	assert.Equal(t, 2, report.RowErrors[1].Row)
+	assert.ErrorContains(t, report.RowErrors[1].Err, "failed to parse date")
+}
+
+func TestVietlottWebScraper_CheckSelectors_NoRows(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<html><body><div class="new-layout">05 12 19</div></body></html>`))
+	}))
+	t.Cleanup(srv.Close)
+
+	s := NewVietlottWebScraper(srv.URL, 5*time.Second, 1, 0)
+
+	// Selector drift is reported, not an error
+	report, err := s.CheckSelectors(context.Background(), valueobject.Power655)
+	require.NoError(t, err)
+	assert.Zero(t, report.RowsMatched)
+	assert.Empty(t, report.Draws)
+}
+
+func TestParseVNDAndCount(t *testing.T) {
+	assert.Equal(t, 12345678900.0, parseVND(" 12.345.678.900 đồng "))
+	assert.Equal(t, 1500000.0, parseVND("1,500,000"))
+	assert.Equal(t, 0.0, parseVND("N/A"))
+	assert.Equal(t, 2, parseCount("02 vé"))
+	assert.Equal(t, 0, parseCount(""))
+}
@@ -0,0 +1,170 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+// backupDirName is the per-directory subfolder holding the previous version
+// of each rewritten file, plus the temporary files saves write first. Read
+// loops skip directories, so neither is ever loaded as a stored record.
+const backupDirName = ".backup"
+
+// writeJSONFile saves data as indented JSON to filename, crash-safely: see
+// writeFileAtomic
+func writeJSONFile(filename string, data interface{}) error {
+	jsonData, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(filename, jsonData, true)
+}
+
+// writeFileAtomic writes data to a temporary file, syncs it and renames it
+// over filename, so a crash leaves either the old or the new content and
+// never a truncated file. With backup set, the file being replaced is kept
+// in the .backup subdirectory for readJSONFile to recover from.
+func writeFileAtomic(filename string, data []byte, backup bool) error {
+	backupDir := filepath.Join(filepath.Dir(filename), backupDirName)
+	if err := os.MkdirAll(backupDir, 0755); err != nil {
+		return fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(backupDir, ".save-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if backup {
+		if err := backupFile(filename); err != nil {
+			return err
+		}
+	}
+	return os.Rename(tmp.Name(), filename)
+}
+
+// appendLine appends line and a newline to filename, creating it, and syncs
+// it to disk. A save interrupted mid-write leaves the file ending in a
+// partial line; the first append after that starts a new line, so the
+// partial one stays on its own, where reading skips it, instead of running
+// into the appended record and taking it down too.
+func appendLine(filename string, line []byte) error {
+	file, err := os.OpenFile(filename, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	data := make([]byte, 0, len(line)+2)
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	if size := info.Size(); size > 0 {
+		last := make([]byte, 1)
+		if _, err := file.ReadAt(last, size-1); err != nil {
+			file.Close()
+			return err
+		}
+		if last[0] != '\n' {
+			data = append(data, '\n')
+		}
+	}
+	data = append(append(data, line...), '\n')
+
+	// One write per line, so an interrupted save leaves at most one partial
+	// line
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// backupFile hard-links filename as its backup, replacing the previous one.
+// A file that doesn't exist yet has nothing to back up.
+func backupFile(filename string) error {
+	link := filepath.Join(filepath.Dir(filename), backupDirName, ".link-"+filepath.Base(filename))
+	os.Remove(link)
+	if err := os.Link(filename, link); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to back up %s: %w", filename, err)
+	}
+	if err := os.Rename(link, backupFilename(filename)); err != nil {
+		os.Remove(link)
+		return fmt.Errorf("failed to back up %s: %w", filename, err)
+	}
+	return nil
+}
+
+// readJSONFile loads filename's JSON into data. If the file is corrupt but
+// its backup loads, the backup is restored in its place, with a warning.
+func readJSONFile(filename string, data interface{}) error {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	loadErr := json.Unmarshal(content, data)
+	if loadErr == nil {
+		return nil
+	}
+
+	backup, err := os.ReadFile(backupFilename(filename))
+	if err != nil || !json.Valid(backup) {
+		return loadErr
+	}
+	if err := json.Unmarshal(backup, data); err != nil {
+		return loadErr
+	}
+
+	logger.Warn("Recovered corrupt storage file from its backup",
+		zap.String("file", filename),
+		zap.Bool("partial_write", isPartialWrite(loadErr)),
+		zap.Error(loadErr),
+	)
+	if err := writeFileAtomic(filename, backup, false); err != nil {
+		logger.Warn("Failed to restore storage file from its backup",
+			zap.String("file", filename),
+			zap.Error(err),
+		)
+	}
+	return nil
+}
+
+// backupFilename is where writeFileAtomic keeps filename's previous version
+func backupFilename(filename string) string {
+	return filepath.Join(filepath.Dir(filename), backupDirName, filepath.Base(filename)+".bak")
+}
+
+// removeBackup deletes filename's backup, if any
+func removeBackup(filename string) {
+	os.Remove(backupFilename(filename))
+}
@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testRecord struct {
+	Version int `json:"version"`
+}
+
+func TestWriteJSONFile_KeepsPreviousVersionAsBackup(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "record.json")
+
+	require.NoError(t, writeJSONFile(filename, testRecord{Version: 1}))
+	_, err := os.Stat(backupFilename(filename))
+	assert.True(t, os.IsNotExist(err), "a new file has nothing to back up")
+
+	require.NoError(t, writeJSONFile(filename, testRecord{Version: 2}))
+
+	var current, backup testRecord
+	require.NoError(t, readJSONFile(filename, &current))
+	assert.Equal(t, 2, current.Version)
+	require.NoError(t, readJSONFile(backupFilename(filename), &backup))
+	assert.Equal(t, 1, backup.Version)
+
+	temps, err := filepath.Glob(filepath.Join(filepath.Dir(filename), backupDirName, "*.tmp"))
+	require.NoError(t, err)
+	assert.Empty(t, temps)
+}
+
+func TestReadJSONFile_RecoversTruncatedFileFromBackup(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "record.json")
+	require.NoError(t, writeJSONFile(filename, testRecord{Version: 1}))
+	require.NoError(t, writeJSONFile(filename, testRecord{Version: 2}))
+	require.NoError(t, os.WriteFile(filename, []byte(`{"vers`), 0644))
+
+	var record testRecord
+	require.NoError(t, readJSONFile(filename, &record))
+	assert.Equal(t, 1, record.Version)
+
+	// The backup was restored in place of the corrupt file
+	var restored testRecord
+	content, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"version": 1}`, string(content))
+	require.NoError(t, readJSONFile(filename, &restored))
+	assert.Equal(t, 1, restored.Version)
+}
+
+func TestReadJSONFile_CorruptWithoutBackup(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "record.json")
+	require.NoError(t, os.WriteFile(filename, []byte(`{"vers`), 0644))
+
+	var record testRecord
+	err := readJSONFile(filename, &record)
+	require.Error(t, err)
+	assert.True(t, isPartialWrite(err))
+}
+
+func TestMoveToTrash_DropsBackup(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "record.json")
+	require.NoError(t, writeJSONFile(filename, testRecord{Version: 1}))
+	require.NoError(t, writeJSONFile(filename, testRecord{Version: 2}))
+
+	require.NoError(t, moveToTrash(filename))
+	_, err := os.Stat(backupFilename(filename))
+	assert.True(t, os.IsNotExist(err))
+}
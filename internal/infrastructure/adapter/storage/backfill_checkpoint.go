@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/tool_predict/internal/application/port"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// BackfillCheckpointFile keeps every game's backfill checkpoint in one JSON
+// file, rewritten atomically on each save so a crash never loses progress
+// already recorded
+type BackfillCheckpointFile struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewBackfillCheckpointFile creates a checkpoint store backed by the file at
+// path, which is created on the first save
+func NewBackfillCheckpointFile(path string) *BackfillCheckpointFile {
+	return &BackfillCheckpointFile{path: path}
+}
+
+// Load returns a game's checkpoint, or nil if it has none
+func (f *BackfillCheckpointFile) Load(gameType valueobject.GameType) (*port.BackfillCheckpoint, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	checkpoints, err := f.read()
+	if err != nil {
+		return nil, err
+	}
+	return checkpoints[gameType], nil
+}
+
+// Save replaces a game's checkpoint, keeping the other games'
+func (f *BackfillCheckpointFile) Save(checkpoint *port.BackfillCheckpoint) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	checkpoints, err := f.read()
+	if err != nil {
+		return err
+	}
+	checkpoints[checkpoint.GameType] = checkpoint
+
+	if err := os.MkdirAll(filepath.Dir(f.path), 0755); err != nil {
+		return fmt.Errorf("failed to create checkpoint directory: %w", err)
+	}
+	return writeJSONFile(f.path, checkpoints)
+}
+
+// read loads every checkpoint, none when the file doesn't exist yet
+func (f *BackfillCheckpointFile) read() (map[valueobject.GameType]*port.BackfillCheckpoint, error) {
+	checkpoints := make(map[valueobject.GameType]*port.BackfillCheckpoint)
+	if err := readJSONFile(f.path, &checkpoints); err != nil {
+		if os.IsNotExist(err) {
+			return checkpoints, nil
+		}
+		return nil, fmt.Errorf("invalid backfill checkpoint %s: %w", f.path, err)
+	}
+	return checkpoints, nil
+}
+
+// Ensure BackfillCheckpointFile implements port.BackfillCheckpointStore
+var _ port.BackfillCheckpointStore = (*BackfillCheckpointFile)(nil)
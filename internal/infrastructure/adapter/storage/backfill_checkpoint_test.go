@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/application/port"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func TestBackfillCheckpointFile_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "backfill.json")
+	store := NewBackfillCheckpointFile(path)
+
+	checkpoint, err := store.Load(valueobject.Power655)
+	require.NoError(t, err)
+	assert.Nil(t, checkpoint)
+
+	power := &port.BackfillCheckpoint{
+		GameType:   valueobject.Power655,
+		NextPage:   4,
+		Saved:      300,
+		OldestDraw: 990,
+		UpdatedAt:  time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, store.Save(power))
+	require.NoError(t, store.Save(&port.BackfillCheckpoint{GameType: valueobject.Mega645, NextPage: 2, Done: true}))
+
+	// A fresh store reads both games back from the file
+	reopened := NewBackfillCheckpointFile(path)
+	loaded, err := reopened.Load(valueobject.Power655)
+	require.NoError(t, err)
+	assert.Equal(t, power, loaded)
+
+	mega, err := reopened.Load(valueobject.Mega645)
+	require.NoError(t, err)
+	assert.True(t, mega.Done)
+
+	// No temp files are left behind
+	temps, err := filepath.Glob(filepath.Join(filepath.Dir(path), "*", "*.tmp"))
+	require.NoError(t, err)
+	assert.Empty(t, temps)
+}
+
+func TestBackfillCheckpointFile_InvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "backfill.json")
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0644))
+
+	_, err := NewBackfillCheckpointFile(path).Load(valueobject.Power655)
+	assert.Error(t, err)
+}
@@ -0,0 +1,144 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/repository"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// CompositeDrawRepository writes draws to several backends and reads them
+// from the first, the primary. It keeps a second backend in step while
+// migrating to it, so that the two can be compared and the migration rolled
+// back by switching back to the primary alone.
+type CompositeDrawRepository struct {
+	primary     repository.DrawRepository
+	secondaries []repository.DrawRepository
+}
+
+// NewCompositeDrawRepository creates a repository reading from primary and
+// writing to primary and every secondary
+func NewCompositeDrawRepository(
+	primary repository.DrawRepository,
+	secondaries ...repository.DrawRepository,
+) *CompositeDrawRepository {
+	return &CompositeDrawRepository{
+		primary:     primary,
+		secondaries: secondaries,
+	}
+}
+
+// writeAll calls write on every backend, primary first. A failing backend
+// doesn't stop the others; the failures are returned joined, each naming its
+// backend.
+func (r *CompositeDrawRepository) writeAll(write func(repository.DrawRepository) error) error {
+	var errs []error
+	if err := write(r.primary); err != nil {
+		errs = append(errs, fmt.Errorf("primary backend: %w", err))
+	}
+	for i, backend := range r.secondaries {
+		if err := write(backend); err != nil {
+			errs = append(errs, fmt.Errorf("secondary backend %d: %w", i+1, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Save saves a draw to every backend
+func (r *CompositeDrawRepository) Save(ctx context.Context, draw *entity.Draw) error {
+	return r.writeAll(func(backend repository.DrawRepository) error {
+		return backend.Save(ctx, draw)
+	})
+}
+
+// SaveBatch saves draws to every backend
+func (r *CompositeDrawRepository) SaveBatch(ctx context.Context, draws []*entity.Draw) error {
+	return r.writeAll(func(backend repository.DrawRepository) error {
+		return backend.SaveBatch(ctx, draws)
+	})
+}
+
+// DeleteAll deletes a game type's draws from every backend
+func (r *CompositeDrawRepository) DeleteAll(ctx context.Context, gameType valueobject.GameType) error {
+	return r.writeAll(func(backend repository.DrawRepository) error {
+		return backend.DeleteAll(ctx, gameType)
+	})
+}
+
+// FindByID finds a draw by ID in the primary
+func (r *CompositeDrawRepository) FindByID(ctx context.Context, id string) (*entity.Draw, error) {
+	return r.primary.FindByID(ctx, id)
+}
+
+// FindByGameTypeAndDrawNumber finds a draw by game type and draw number in
+// the primary
+func (r *CompositeDrawRepository) FindByGameTypeAndDrawNumber(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	drawNumber int,
+) (*entity.Draw, error) {
+	return r.primary.FindByGameTypeAndDrawNumber(ctx, gameType, drawNumber)
+}
+
+// FindLatest finds the most recent draws in the primary
+func (r *CompositeDrawRepository) FindLatest(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	limit int,
+) ([]*entity.Draw, error) {
+	return r.primary.FindLatest(ctx, gameType, limit)
+}
+
+// FindByDateRange finds draws within a date range in the primary
+func (r *CompositeDrawRepository) FindByDateRange(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	dateRange valueobject.DateRange,
+) ([]*entity.Draw, error) {
+	return r.primary.FindByDateRange(ctx, gameType, dateRange)
+}
+
+// FindByDrawNumberRange finds draws within a draw number range in the primary
+func (r *CompositeDrawRepository) FindByDrawNumberRange(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	startDrawNumber int,
+	endDrawNumber int,
+) ([]*entity.Draw, error) {
+	return r.primary.FindByDrawNumberRange(ctx, gameType, startDrawNumber, endDrawNumber)
+}
+
+// FindPage finds a page of draws in the primary
+func (r *CompositeDrawRepository) FindPage(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	offset int,
+	limit int,
+) ([]*entity.Draw, error) {
+	return r.primary.FindPage(ctx, gameType, offset, limit)
+}
+
+// ForEachDraw iterates over the draws in the primary
+func (r *CompositeDrawRepository) ForEachDraw(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	fn func(draw *entity.Draw) error,
+) error {
+	return r.primary.ForEachDraw(ctx, gameType, fn)
+}
+
+// Count returns the number of draws in the primary
+func (r *CompositeDrawRepository) Count(ctx context.Context, gameType valueobject.GameType) (int64, error) {
+	return r.primary.Count(ctx, gameType)
+}
+
+// GetLatestDrawNumber returns the highest draw number in the primary
+func (r *CompositeDrawRepository) GetLatestDrawNumber(ctx context.Context, gameType valueobject.GameType) (int, error) {
+	return r.primary.GetLatestDrawNumber(ctx, gameType)
+}
+
+// Ensure CompositeDrawRepository implements repository.DrawRepository
+var _ repository.DrawRepository = (*CompositeDrawRepository)(nil)
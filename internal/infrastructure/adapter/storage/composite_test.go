@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func TestCompositeDrawRepository_WritesToAllReadsFromPrimary(t *testing.T) {
+	primary, err := NewJSONStorage(t.TempDir())
+	require.NoError(t, err)
+	secondary, err := NewJSONStorage(t.TempDir())
+	require.NoError(t, err)
+	composite := NewCompositeDrawRepository(primary, secondary)
+	ctx := context.Background()
+
+	draw := newTestDraw(t, valueobject.Mega645, 1200, []int{3, 9, 17, 22, 30, 44})
+	require.NoError(t, composite.Save(ctx, draw))
+
+	for name, backend := range map[string]*JSONStorage{"primary": primary, "secondary": secondary} {
+		stored, err := backend.FindByGameTypeAndDrawNumber(ctx, valueobject.Mega645, 1200)
+		require.NoError(t, err, name)
+		assert.Equal(t, draw.Numbers, stored.Numbers, name)
+	}
+
+	// A draw only the secondary has isn't read
+	require.NoError(t, secondary.Save(ctx, newTestDraw(t, valueobject.Mega645, 1201, []int{1, 2, 3, 4, 5, 6})))
+	_, err = composite.FindByGameTypeAndDrawNumber(ctx, valueobject.Mega645, 1201)
+	assert.Error(t, err)
+	latest, err := composite.GetLatestDrawNumber(ctx, valueobject.Mega645)
+	require.NoError(t, err)
+	assert.Equal(t, 1200, latest)
+	count, err := composite.Count(ctx, valueobject.Mega645)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	require.NoError(t, composite.DeleteAll(ctx, valueobject.Mega645))
+	count, err = secondary.Count(ctx, valueobject.Mega645)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestCompositeDrawRepository_SaveBatchReportsEachBackend(t *testing.T) {
+	primary, err := NewJSONStorage(t.TempDir())
+	require.NoError(t, err)
+	secondary, err := NewJSONStorage(t.TempDir())
+	require.NoError(t, err)
+	composite := NewCompositeDrawRepository(primary, secondary)
+	ctx := context.Background()
+
+	bad := newTestDraw(t, valueobject.Mega645, 1201, []int{1, 2, 3, 4, 5, 6})
+	bad.Jackpot = math.NaN()
+	err = composite.SaveBatch(ctx, []*entity.Draw{
+		newTestDraw(t, valueobject.Mega645, 1200, []int{3, 9, 17, 22, 30, 44}),
+		bad,
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "primary backend")
+	assert.Contains(t, err.Error(), "secondary backend 1")
+
+	var batchErr *BatchSaveError
+	require.True(t, errors.As(err, &batchErr))
+	assert.Equal(t, 1, batchErr.Saved)
+
+	// The good draw still reached both
+	for _, backend := range []*JSONStorage{primary, secondary} {
+		count, err := backend.Count(ctx, valueobject.Mega645)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	}
+}
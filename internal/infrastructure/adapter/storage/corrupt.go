@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"sync/atomic"
+
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+// corruptFiles counts the files read loops skip because they fail to load,
+// so data problems show up instead of silently shrinking the dataset
+type corruptFiles struct {
+	skipped atomic.Int64
+}
+
+// skip logs a file that failed to load and counts it
+func (c *corruptFiles) skip(filename string, err error) {
+	c.skipped.Add(1)
+	logger.Warn("Skipping unreadable storage file",
+		zap.String("file", filename),
+		zap.Bool("partial_write", isPartialWrite(err)),
+		zap.Error(err),
+	)
+}
+
+// count returns how many files have been skipped so far
+func (c *corruptFiles) count() int64 {
+	return c.skipped.Load()
+}
+
+// isPartialWrite reports whether err looks like a truncated or empty JSON
+// file, the usual result of a write interrupted by a crash or full disk
+func isPartialWrite(err error) bool {
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var syntaxErr *json.SyntaxError
+	return errors.As(err, &syntaxErr) && syntaxErr.Error() == "unexpected end of JSON input"
+}
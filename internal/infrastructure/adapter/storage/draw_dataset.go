@@ -0,0 +1,297 @@
+package storage
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// DrawFormat is a flat file format draws are exported to and imported from
+type DrawFormat string
+
+const (
+	DrawFormatCSV     DrawFormat = "csv"     // One row per draw, numbers space separated
+	DrawFormatJSONL   DrawFormat = "jsonl"   // One draw JSON object per line, as stored
+	DrawFormatParquet DrawFormat = "parquet" // The CSV columns, typed, in a Parquet file
+)
+
+// drawCSVHeader names the CSV columns, in order
+var drawCSVHeader = []string{"game_type", "draw_number", "draw_date", "numbers", "jackpot", "winners"}
+
+// ParseDrawFormat parses a draw format name, case-insensitively
+func ParseDrawFormat(s string) (DrawFormat, error) {
+	switch format := DrawFormat(strings.ToLower(strings.TrimSpace(s))); format {
+	case DrawFormatCSV, DrawFormatJSONL, DrawFormatParquet:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unknown draw format %q (csv, jsonl or parquet)", s)
+	}
+}
+
+// DrawFormatFromPath picks the draw format from a file's extension
+func DrawFormatFromPath(path string) (DrawFormat, error) {
+	ext := strings.TrimPrefix(filepath.Ext(path), ".")
+	if ext == "" {
+		return "", fmt.Errorf("can't tell the format of %s without an extension", path)
+	}
+	return ParseDrawFormat(ext)
+}
+
+// WriteDraws writes draws to w in the given format, in slice order
+func WriteDraws(w io.Writer, format DrawFormat, draws []*entity.Draw) error {
+	writer, err := NewDrawWriter(w, format)
+	if err != nil {
+		return err
+	}
+	for _, draw := range draws {
+		if err := writer.Write(draw); err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
+}
+
+// DrawWriter writes draws one at a time, for exports too large to gather
+// first. Parquet being columnar, its draws are gathered and written on Flush.
+type DrawWriter struct {
+	csv     *csv.Writer    // Set for DrawFormatCSV
+	json    *json.Encoder  // Set for DrawFormatJSONL
+	parquet *parquetWriter // Set for DrawFormatParquet
+}
+
+// NewDrawWriter creates a writer of draws to w in the given format, writing
+// the CSV header right away
+func NewDrawWriter(w io.Writer, format DrawFormat) (*DrawWriter, error) {
+	switch format {
+	case DrawFormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(drawCSVHeader); err != nil {
+			return nil, fmt.Errorf("failed to write CSV header: %w", err)
+		}
+		return &DrawWriter{csv: writer}, nil
+	case DrawFormatJSONL:
+		return &DrawWriter{json: json.NewEncoder(w)}, nil
+	case DrawFormatParquet:
+		return &DrawWriter{parquet: &parquetWriter{w: w}}, nil
+	default:
+		return nil, fmt.Errorf("unknown draw format %q", format)
+	}
+}
+
+// Write writes one draw
+func (w *DrawWriter) Write(draw *entity.Draw) error {
+	if w.json != nil {
+		if err := w.json.Encode(draw); err != nil {
+			return fmt.Errorf("failed to write draw #%d: %w", draw.DrawNumber, err)
+		}
+		return nil
+	}
+	if w.parquet != nil {
+		w.parquet.draws = append(w.parquet.draws, draw)
+		return nil
+	}
+
+	row := []string{
+		string(draw.GameType),
+		strconv.Itoa(draw.DrawNumber),
+		draw.DrawDate.Format(time.RFC3339),
+		formatCSVNumbers(draw),
+		strconv.FormatFloat(draw.Jackpot, 'f', -1, 64),
+		strconv.Itoa(draw.Winners),
+	}
+	if err := w.csv.Write(row); err != nil {
+		return fmt.Errorf("failed to write draw #%d: %w", draw.DrawNumber, err)
+	}
+	return nil
+}
+
+// formatCSVNumbers formats a draw's numbers as two digits each, space
+// separated
+func formatCSVNumbers(draw *entity.Draw) string {
+	numbers := make([]string, len(draw.Numbers))
+	for i, num := range draw.Numbers {
+		numbers[i] = fmt.Sprintf("%02d", num)
+	}
+	return strings.Join(numbers, " ")
+}
+
+// Flush writes out any buffered draws; call it after the last Write
+func (w *DrawWriter) Flush() error {
+	if w.parquet != nil {
+		return w.parquet.flush()
+	}
+	if w.csv == nil {
+		return nil
+	}
+	w.csv.Flush()
+	return w.csv.Error()
+}
+
+// ReadDraws reads draws in the given format from r. Every draw is validated
+// like a scraped one and gets its draw number's ID; the first invalid record
+// fails the read with its line number, so a bad file imports nothing.
+func ReadDraws(r io.Reader, format DrawFormat) ([]*entity.Draw, error) {
+	switch format {
+	case DrawFormatCSV:
+		return readDrawsCSV(r)
+	case DrawFormatJSONL:
+		return readDrawsJSONL(r)
+	case DrawFormatParquet:
+		return readDrawsParquet(r)
+	default:
+		return nil, fmt.Errorf("unknown draw format %q", format)
+	}
+}
+
+// readDrawsCSV reads CSV with a header row naming at least the game_type,
+// draw_number, draw_date and numbers columns, in any order. Dates are RFC
+// 3339 or YYYY-MM-DD; numbers are separated by spaces, commas or dashes.
+func readDrawsCSV(r io.Reader) ([]*entity.Draw, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range drawCSVHeader[:4] {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("CSV header lacks the %s column", name)
+		}
+	}
+
+	var draws []*entity.Draw
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return draws, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+		draw, err := parseDrawCSVRow(field)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		draws = append(draws, draw)
+	}
+}
+
+func parseDrawCSVRow(field func(name string) string) (*entity.Draw, error) {
+	gameType, err := valueobject.ParseGameType(field("game_type"))
+	if err != nil {
+		return nil, err
+	}
+	drawNumber, err := strconv.Atoi(field("draw_number"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid draw number %q", field("draw_number"))
+	}
+	drawDate, err := parseDrawDate(field("draw_date"))
+	if err != nil {
+		return nil, err
+	}
+
+	var nums []int
+	for _, part := range strings.FieldsFunc(field("numbers"), func(r rune) bool {
+		return r == ' ' || r == ',' || r == '-'
+	}) {
+		num, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", part)
+		}
+		nums = append(nums, num)
+	}
+
+	jackpot := 0.0
+	if s := field("jackpot"); s != "" {
+		if jackpot, err = strconv.ParseFloat(s, 64); err != nil {
+			return nil, fmt.Errorf("invalid jackpot %q", s)
+		}
+	}
+	winners := 0
+	if s := field("winners"); s != "" {
+		if winners, err = strconv.Atoi(s); err != nil {
+			return nil, fmt.Errorf("invalid winners %q", s)
+		}
+	}
+
+	return newImportedDraw(gameType, drawNumber, nums, drawDate, jackpot, winners)
+}
+
+func readDrawsJSONL(r io.Reader) ([]*entity.Draw, error) {
+	decoder := json.NewDecoder(r)
+	var draws []*entity.Draw
+	for line := 1; ; line++ {
+		var stored entity.Draw
+		if err := decoder.Decode(&stored); err != nil {
+			if errors.Is(err, io.EOF) {
+				return draws, nil
+			}
+			return nil, fmt.Errorf("record %d: %w", line, err)
+		}
+
+		draw, err := newImportedDraw(stored.GameType, stored.DrawNumber, stored.Numbers,
+			stored.DrawDate, stored.Jackpot, stored.Winners)
+		if err != nil {
+			return nil, fmt.Errorf("record %d: %w", line, err)
+		}
+		draws = append(draws, draw)
+	}
+}
+
+// newImportedDraw validates an imported draw's numbers against its game and
+// builds the draw
+func newImportedDraw(
+	gameType valueobject.GameType,
+	drawNumber int,
+	nums []int,
+	drawDate time.Time,
+	jackpot float64,
+	winners int,
+) (*entity.Draw, error) {
+	if drawDate.IsZero() {
+		return nil, fmt.Errorf("draw #%d has no draw date", drawNumber)
+	}
+	numbers, err := valueobject.NewNumbersForGame(nums, gameType)
+	if err != nil {
+		return nil, fmt.Errorf("draw #%d: %w", drawNumber, err)
+	}
+	return entity.NewDraw(gameType, drawNumber, numbers, drawDate, jackpot, winners)
+}
+
+// parseDrawDate parses an RFC 3339 timestamp or a YYYY-MM-DD date, the latter
+// as UTC midnight
+func parseDrawDate(s string) (time.Time, error) {
+	if date, err := time.Parse(time.RFC3339, s); err == nil {
+		return date, nil
+	}
+	date, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid draw date %q, want YYYY-MM-DD or RFC 3339", s)
+	}
+	return date, nil
+}
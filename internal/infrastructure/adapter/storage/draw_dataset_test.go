@@ -0,0 +1,108 @@
+package storage
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func TestWriteReadDraws_RoundTrip(t *testing.T) {
+	mega := newTestDraw(t, valueobject.Mega645, 1201, []int{3, 9, 17, 22, 30, 41})
+	mega.Jackpot = 12500000000.5
+	mega.Winners = 2
+	power := newTestDraw(t, valueobject.Power655, 1100, []int{1, 5, 12, 19, 33, 55})
+	power.DrawDate = time.Date(2026, 1, 15, 18, 0, 0, 0, time.FixedZone("ICT", 7*3600))
+
+	for _, format := range []DrawFormat{DrawFormatCSV, DrawFormatJSONL, DrawFormatParquet} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, WriteDraws(&buf, format, []*entity.Draw{mega, power}))
+
+			draws, err := ReadDraws(&buf, format)
+			require.NoError(t, err)
+			require.Len(t, draws, 2)
+			for i, want := range []*entity.Draw{mega, power} {
+				assert.True(t, want.Equals(draws[i]), "draw %d", i)
+				assert.True(t, want.DrawDate.Equal(draws[i].DrawDate))
+				assert.Equal(t, want.Jackpot, draws[i].Jackpot)
+				assert.Equal(t, want.Winners, draws[i].Winners)
+				assert.Equal(t, want.ID, draws[i].ID)
+			}
+		})
+	}
+}
+
+func TestWriteDraws_CSVLayout(t *testing.T) {
+	var buf bytes.Buffer
+	draw := newTestDraw(t, valueobject.Mega645, 1201, []int{3, 9, 17, 22, 30, 41})
+	require.NoError(t, WriteDraws(&buf, DrawFormatCSV, []*entity.Draw{draw}))
+
+	assert.Equal(t, "game_type,draw_number,draw_date,numbers,jackpot,winners\n"+
+		"MEGA_6_45,1201,2026-01-15T00:00:00Z,03 09 17 22 30 41,0,0\n", buf.String())
+}
+
+func TestReadDraws_CSVExternalDataset(t *testing.T) {
+	input := "Draw_Number,Game_Type,Numbers,Draw_Date\n" +
+		"1201,mega,\"3,9,17,22,30,41\",2026-01-15\n" +
+		"1202,6/45,01-05-12-19-33-45,2026-01-17\n"
+
+	draws, err := ReadDraws(strings.NewReader(input), DrawFormatCSV)
+	require.NoError(t, err)
+	require.Len(t, draws, 2)
+	assert.Equal(t, valueobject.Mega645, draws[0].GameType)
+	assert.Equal(t, valueobject.Numbers{3, 9, 17, 22, 30, 41}, draws[0].Numbers)
+	assert.Equal(t, time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC), draws[0].DrawDate)
+	assert.Equal(t, 1202, draws[1].DrawNumber)
+}
+
+func TestReadDraws_InvalidRecords(t *testing.T) {
+	tests := []struct {
+		name   string
+		format DrawFormat
+		input  string
+		errMsg string
+	}{
+		{"missing column", DrawFormatCSV, "game_type,draw_number,numbers\n", "draw_date column"},
+		{"out of range", DrawFormatCSV, "game_type,draw_number,draw_date,numbers\n" +
+			"MEGA_6_45,1,2026-01-15,01 02 03 04 05 06\n" +
+			"MEGA_6_45,2,2026-01-17,01 02 03 04 05 50\n", "line 3"},
+		{"bad date", DrawFormatCSV, "game_type,draw_number,draw_date,numbers\n" +
+			"MEGA_6_45,1,15/01/2026,01 02 03 04 05 06\n", "invalid draw date"},
+		{"no date", DrawFormatJSONL, `{"game_type":"MEGA_6_45","draw_number":1,"numbers":[1,2,3,4,5,6]}` + "\n", "no draw date"},
+		{"malformed json", DrawFormatJSONL, "{\n", "record 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			draws, err := ReadDraws(strings.NewReader(tt.input), tt.format)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+			assert.Nil(t, draws)
+		})
+	}
+}
+
+func TestParseDrawFormat(t *testing.T) {
+	format, err := ParseDrawFormat(" CSV ")
+	require.NoError(t, err)
+	assert.Equal(t, DrawFormatCSV, format)
+
+	format, err = DrawFormatFromPath("out/draws.jsonl")
+	require.NoError(t, err)
+	assert.Equal(t, DrawFormatJSONL, format)
+
+	format, err = DrawFormatFromPath("draws.parquet")
+	require.NoError(t, err)
+	assert.Equal(t, DrawFormatParquet, format)
+
+	_, err = ParseDrawFormat("xlsx")
+	assert.Error(t, err)
+	_, err = DrawFormatFromPath("draws")
+	assert.Error(t, err)
+}
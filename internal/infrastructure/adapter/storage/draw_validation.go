@@ -0,0 +1,162 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// quarantineDirName is the per-directory subfolder invalid draw files are
+// moved to. Read loops skip directories, so quarantined files are ignored.
+const quarantineDirName = ".quarantine"
+
+// DrawFileProblem is something wrong with a stored draw file
+type DrawFileProblem string
+
+const (
+	ProblemUnreadable      DrawFileProblem = "unreadable"            // Not valid draw JSON
+	ProblemDuplicateNumber DrawFileProblem = "duplicate_draw_number" // Another file holds the same draw number
+	ProblemOutOfRange      DrawFileProblem = "number_out_of_range"
+	ProblemNumberCount     DrawFileProblem = "wrong_number_count" // Too few, too many or repeated numbers
+	ProblemMalformedDate   DrawFileProblem = "malformed_date"
+	ProblemWrongFolder     DrawFileProblem = "wrong_game_type_folder"
+)
+
+// DrawFileIssue is a stored draw file with one or more problems
+type DrawFileIssue struct {
+	File       string
+	GameType   valueobject.GameType // Game type of the folder holding the file
+	DrawNumber int                  // 0 when unreadable
+	Problems   []DrawFileProblem
+	Detail     string // First problem's specifics
+}
+
+// storedDraw mirrors entity.Draw with loosely typed fields, so a bad value
+// is reported as that problem instead of failing the whole file
+type storedDraw struct {
+	GameType   string `json:"game_type"`
+	DrawNumber int    `json:"draw_number"`
+	Numbers    []int  `json:"numbers"`
+	DrawDate   string `json:"draw_date"`
+}
+
+// ValidateFiles checks every stored draw file of gameType for problems the
+// read loops would skip or load silently: unreadable files, draw numbers held
+// by several files, numbers outside the game's range or of the wrong count,
+// malformed draw dates and draws of another game type. Of files sharing a draw
+// number, the first by name without other problems is kept as the valid one.
+// Issues are returned by file name.
+func (s *JSONStorage) ValidateFiles(gameType valueobject.GameType) ([]DrawFileIssue, error) {
+	mu := s.gameLocks.get(gameType)
+	mu.RLock()
+	defer mu.RUnlock()
+
+	dir := s.getGameTypeDir("draws", gameType)
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	issues := make(map[string]*DrawFileIssue)
+	addProblem := func(filename string, drawNumber int, problem DrawFileProblem, detail string) {
+		issue, ok := issues[filename]
+		if !ok {
+			issue = &DrawFileIssue{File: filename, GameType: gameType, DrawNumber: drawNumber, Detail: detail}
+			issues[filename] = issue
+		}
+		issue.Problems = append(issue.Problems, problem)
+	}
+
+	byNumber := make(map[int][]string)
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		filename := filepath.Join(dir, file.Name())
+
+		var draw storedDraw
+		if err := s.loadFromFile(filename, &draw); err != nil {
+			addProblem(filename, 0, ProblemUnreadable, err.Error())
+			continue
+		}
+
+		rules := gameType
+		if draw.GameType != string(gameType) {
+			addProblem(filename, draw.DrawNumber, ProblemWrongFolder,
+				fmt.Sprintf("%s draw in the %s folder", draw.GameType, gameType))
+			if fileGame := valueobject.GameType(draw.GameType); fileGame.Validate() == nil {
+				rules = fileGame
+			}
+		}
+
+		for _, num := range draw.Numbers {
+			if !rules.InRange(num) {
+				minRange, maxRange := rules.NumberRange()
+				addProblem(filename, draw.DrawNumber, ProblemOutOfRange,
+					fmt.Sprintf("number %d outside %d-%d", num, minRange, maxRange))
+				break
+			}
+		}
+
+		distinct := slices.Compact(slices.Sorted(slices.Values(draw.Numbers)))
+		if len(draw.Numbers) != rules.NumberCount() || len(distinct) != len(draw.Numbers) {
+			addProblem(filename, draw.DrawNumber, ProblemNumberCount,
+				fmt.Sprintf("numbers %v, want %d distinct", draw.Numbers, rules.NumberCount()))
+		}
+
+		if date, err := time.Parse(time.RFC3339, draw.DrawDate); err != nil || date.IsZero() {
+			addProblem(filename, draw.DrawNumber, ProblemMalformedDate, fmt.Sprintf("draw date %q", draw.DrawDate))
+		}
+
+		byNumber[draw.DrawNumber] = append(byNumber[draw.DrawNumber], filename)
+	}
+
+	for drawNumber, filenames := range byNumber {
+		if len(filenames) < 2 {
+			continue
+		}
+		sort.Strings(filenames)
+		kept := filenames[0]
+		for _, filename := range filenames {
+			if _, bad := issues[filename]; !bad {
+				kept = filename
+				break
+			}
+		}
+		for _, filename := range filenames {
+			if filename != kept {
+				addProblem(filename, drawNumber, ProblemDuplicateNumber,
+					fmt.Sprintf("draw #%d also in %s", drawNumber, filepath.Base(kept)))
+			}
+		}
+	}
+
+	result := make([]DrawFileIssue, 0, len(issues))
+	for _, issue := range issues {
+		result = append(result, *issue)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].File < result[j].File })
+	return result, nil
+}
+
+// QuarantineFile moves a stored draw file of gameType into the .quarantine
+// subdirectory next to it, taking it out of every query while keeping it
+// for inspection
+func (s *JSONStorage) QuarantineFile(gameType valueobject.GameType, filename string) error {
+	mu := s.gameLocks.get(gameType)
+	mu.Lock()
+	defer mu.Unlock()
+
+	if filepath.Dir(filename) != s.getGameTypeDir("draws", gameType) {
+		return fmt.Errorf("%s is not a stored %s draw file", filename, gameType)
+	}
+	return moveAside(filename, quarantineDirName)
+}
@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func TestJSONStorage_ValidateFiles(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewJSONStorage(dir)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, store.Save(ctx, newTestDraw(t, valueobject.Mega645, 1200, []int{1, 2, 3, 4, 5, 6})))
+	drawDir := filepath.Join(dir, "draws", "mega_6_45")
+	write := func(name, content string) string {
+		filename := filepath.Join(drawDir, name)
+		require.NoError(t, os.WriteFile(filename, []byte(content), 0644))
+		return filename
+	}
+	duplicate := write("zz_duplicate.json", `{"game_type":"MEGA_6_45","draw_number":1200,"numbers":[1,2,3,4,5,6],"draw_date":"2026-01-15T00:00:00Z"}`)
+	outOfRange := write("range.json", `{"game_type":"MEGA_6_45","draw_number":1201,"numbers":[1,2,3,4,5,50],"draw_date":"2026-01-17T00:00:00Z"}`)
+	count := write("count.json", `{"game_type":"MEGA_6_45","draw_number":1202,"numbers":[1,2,3,4,5,5],"draw_date":"2026-01-19T00:00:00Z"}`)
+	date := write("date.json", `{"game_type":"MEGA_6_45","draw_number":1203,"numbers":[1,2,3,4,5,6],"draw_date":"19/01/2026"}`)
+	folder := write("folder.json", `{"game_type":"POWER_6_55","draw_number":1204,"numbers":[1,2,3,4,5,55],"draw_date":"2026-01-21T00:00:00Z"}`)
+	unreadable := write("broken.json", `{"game_type":"MEGA`)
+
+	issues, err := store.ValidateFiles(valueobject.Mega645)
+	require.NoError(t, err)
+
+	problems := make(map[string][]DrawFileProblem)
+	for _, issue := range issues {
+		problems[issue.File] = issue.Problems
+	}
+	assert.Equal(t, map[string][]DrawFileProblem{
+		duplicate:  {ProblemDuplicateNumber},
+		outOfRange: {ProblemOutOfRange},
+		count:      {ProblemNumberCount},
+		date:       {ProblemMalformedDate},
+		folder:     {ProblemWrongFolder},
+		unreadable: {ProblemUnreadable},
+	}, problems, "the Power 6/55 draw is checked against its own rules")
+
+	for _, issue := range issues {
+		require.NoError(t, store.QuarantineFile(valueobject.Mega645, issue.File))
+	}
+	issues, err = store.ValidateFiles(valueobject.Mega645)
+	require.NoError(t, err)
+	assert.Empty(t, issues)
+	quarantined, err := os.ReadDir(filepath.Join(drawDir, quarantineDirName))
+	require.NoError(t, err)
+	assert.Len(t, quarantined, 6)
+
+	assert.Error(t, store.QuarantineFile(valueobject.Mega645, filepath.Join(dir, "stats", "x.json")))
+}
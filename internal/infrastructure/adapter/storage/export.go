@@ -0,0 +1,161 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// JSONArrayWriter streams a JSON array to an io.Writer one element at a
+// time, so exports never hold the whole array in memory
+type JSONArrayWriter struct {
+	w       io.Writer
+	count   int
+	started bool
+	closed  bool
+}
+
+// NewJSONArrayWriter creates a writer streaming a JSON array to w
+func NewJSONArrayWriter(w io.Writer) *JSONArrayWriter {
+	return &JSONArrayWriter{w: w}
+}
+
+// Write marshals v and appends it to the array
+func (a *JSONArrayWriter) Write(v interface{}) error {
+	if a.closed {
+		return fmt.Errorf("json array writer is closed")
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal element %d: %w", a.count, err)
+	}
+
+	separator := ",\n"
+	if !a.started {
+		separator = "[\n"
+		a.started = true
+	}
+	if _, err := io.WriteString(a.w, separator); err != nil {
+		return err
+	}
+	if _, err := a.w.Write(data); err != nil {
+		return err
+	}
+
+	a.count++
+	return nil
+}
+
+// Count returns how many elements have been written
+func (a *JSONArrayWriter) Count() int {
+	return a.count
+}
+
+// Close terminates the array. An array with no elements is written as [].
+// Close doesn't close the underlying writer.
+func (a *JSONArrayWriter) Close() error {
+	if a.closed {
+		return nil
+	}
+	a.closed = true
+
+	closing := "\n]\n"
+	if !a.started {
+		closing = "[]\n"
+	}
+	_, err := io.WriteString(a.w, closing)
+	return err
+}
+
+// ExportDraws streams every stored draw of a game type to w as a JSON array
+// and returns how many were written
+func (s *JSONStorage) ExportDraws(ctx context.Context, gameType valueobject.GameType, w io.Writer) (int, error) {
+	mu := s.gameLocks.get(gameType)
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return exportDir(ctx, &s.corrupt, s.getGameTypeDir("draws", gameType), w, func() interface{} {
+		return &entity.Draw{}
+	})
+}
+
+// ExportPredictions streams every stored prediction of a game type to w as a
+// JSON array and returns how many were written
+func (s *PredictionJSONStorage) ExportPredictions(ctx context.Context, gameType valueobject.GameType, w io.Writer) (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return exportDir(ctx, &s.corrupt, s.getGameTypeDir("predictions", gameType), w, func() interface{} {
+		return &entity.Prediction{}
+	})
+}
+
+// ExportEnsembles streams every stored ensemble prediction of a game type to
+// w as a JSON array and returns how many were written
+func (s *PredictionJSONStorage) ExportEnsembles(ctx context.Context, gameType valueobject.GameType, w io.Writer) (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return exportDir(ctx, &s.corrupt, s.getGameTypeDir("ensembles", gameType), w, func() interface{} {
+		return &entity.EnsemblePrediction{}
+	})
+}
+
+// ExportResults streams every stored backtest result of a game type to w as
+// a JSON array and returns how many were written
+func (s *BacktestJSONStorage) ExportResults(ctx context.Context, gameType valueobject.GameType, w io.Writer) (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return exportDir(ctx, &s.corrupt, s.getGameTypeDir("backtests", gameType), w, func() interface{} {
+		return &entity.BacktestResult{}
+	})
+}
+
+// exportDir decodes each JSON file in dir into a fresh newItem() and streams
+// it to w, in file name order. Unreadable files are skipped and recorded in
+// corrupt like in the Find methods; a missing directory exports an empty array.
+func exportDir(ctx context.Context, corrupt *corruptFiles, dir string, w io.Writer, newItem func() interface{}) (int, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return 0, err
+	}
+
+	array := NewJSONArrayWriter(w)
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return array.Count(), err
+		}
+
+		filename := filepath.Join(dir, file.Name())
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			corrupt.skip(filename, err)
+			continue
+		}
+		item := newItem()
+		if err := json.Unmarshal(data, item); err != nil {
+			corrupt.skip(filename, err)
+			continue
+		}
+
+		if err := array.Write(item); err != nil {
+			return array.Count(), fmt.Errorf("failed to export %s: %w", file.Name(), err)
+		}
+	}
+
+	if err := array.Close(); err != nil {
+		return array.Count(), err
+	}
+	return array.Count(), nil
+}
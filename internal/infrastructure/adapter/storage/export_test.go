@@ -0,0 +1,138 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func TestJSONArrayWriter_MatchesMarshal(t *testing.T) {
+	items := []map[string]interface{}{
+		{"id": "a", "numbers": []int{1, 2, 3}},
+		{"id": "b", "numbers": []int{4, 5, 6}},
+		{"id": "c", "nested": map[string]string{"k": "v"}},
+	}
+
+	var buf bytes.Buffer
+	array := NewJSONArrayWriter(&buf)
+	for _, item := range items {
+		require.NoError(t, array.Write(item))
+	}
+	require.NoError(t, array.Close())
+	assert.Equal(t, 3, array.Count())
+
+	expected, err := json.Marshal(items)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expected), buf.String())
+}
+
+func TestJSONArrayWriter_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	array := NewJSONArrayWriter(&buf)
+	require.NoError(t, array.Close())
+
+	assert.JSONEq(t, "[]", buf.String())
+	assert.Error(t, array.Write(1))
+}
+
+func TestJSONStorage_ExportDraws_MatchesFindLatest(t *testing.T) {
+	store, err := NewJSONStorage(t.TempDir())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	for i, nums := range [][]int{
+		{3, 9, 17, 22, 30, 41},
+		{1, 5, 12, 19, 33, 45},
+		{2, 8, 14, 27, 36, 44},
+	} {
+		require.NoError(t, store.Save(ctx, newTestDraw(t, valueobject.Mega645, 1201+i, nums)))
+	}
+
+	// A corrupt file is skipped like in FindLatest
+	require.NoError(t, os.WriteFile(
+		filepath.Join(store.getGameTypeDir("draws", valueobject.Mega645), "corrupt.json"),
+		[]byte("{not json"), 0644))
+
+	var buf bytes.Buffer
+	count, err := store.ExportDraws(ctx, valueobject.Mega645, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	var streamed []*entity.Draw
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &streamed))
+
+	loaded, err := store.FindLatest(ctx, valueobject.Mega645, 10)
+	require.NoError(t, err)
+
+	byID := func(draws []*entity.Draw) {
+		sort.Slice(draws, func(i, j int) bool { return draws[i].ID < draws[j].ID })
+	}
+	byID(streamed)
+	byID(loaded)
+
+	expected, err := json.Marshal(loaded)
+	require.NoError(t, err)
+	actual, err := json.Marshal(streamed)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expected), string(actual))
+}
+
+func TestPredictionJSONStorage_ExportPredictions(t *testing.T) {
+	store := newTestPredictionStorage(t)
+	ctx := context.Background()
+
+	pred := newTestPrediction(t, time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, store.Save(ctx, pred))
+
+	var buf bytes.Buffer
+	count, err := store.ExportPredictions(ctx, valueobject.Mega645, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	expected, err := json.Marshal([]*entity.Prediction{pred})
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expected), buf.String())
+
+	// Game types without a directory export an empty array
+	buf.Reset()
+	count, err = store.ExportEnsembles(ctx, valueobject.Power655, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+	assert.JSONEq(t, "[]", buf.String())
+}
+
+func TestBacktestJSONStorage_ExportResults(t *testing.T) {
+	store, err := NewBacktestJSONStorage(t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(store.getGameTypeDir("backtests", valueobject.Power655), 0755))
+	ctx := context.Background()
+
+	end := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+	result, err := entity.NewBacktestResult(
+		valueobject.Power655,
+		"hot_cold_analysis",
+		valueobject.MustNewDateRange(end.AddDate(0, -1, 0), end),
+		30,
+	)
+	require.NoError(t, err)
+	require.NoError(t, store.Save(ctx, result))
+
+	var buf bytes.Buffer
+	count, err := store.ExportResults(ctx, valueobject.Power655, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	expected, err := json.Marshal([]*entity.BacktestResult{result})
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expected), buf.String())
+}
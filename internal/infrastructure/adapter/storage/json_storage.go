@@ -2,23 +2,29 @@ package storage
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/tool_predict/internal/domain/entity"
 	"github.com/tool_predict/internal/domain/repository"
 	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
 )
 
 // JSONStorage implements repository.DrawRepository using JSON files
 type JSONStorage struct {
-	basePath string
-	mu       sync.RWMutex
+	basePath  string
+	corrupt   corruptFiles // Files skipped by read loops
+	drawLocks keyedMutex   // Serializes saves of the same draw number
+	gameLocks gameLocks    // Per game type; held for reading by saves, so saves of different draws run concurrently
 }
 
 // NewJSONStorage creates a new JSON storage adapter
@@ -40,33 +46,115 @@ func NewJSONStorage(basePath string) (*JSONStorage, error) {
 	}, nil
 }
 
-// Save saves a draw to JSON file
+// SkippedCorruptFiles returns how many unreadable draw files read
+// operations have skipped since the storage was created. Each skipped file is
+// also logged at warn level.
+func (s *JSONStorage) SkippedCorruptFiles() int64 {
+	return s.corrupt.count()
+}
+
+// Save saves a draw to JSON file.
+// If a draw with the same game type and draw number is already stored, it is
+// replaced in place (keeping the stored ID) instead of being duplicated. When
+// the stored result differs from the new one, the old version is archived and
+// the change is recorded in corrections.log, unless the stored draw was a
+// sample that a real result now replaces. Saves of different draws may run
+// concurrently; files are written atomically so readers never see them half
+// written.
 func (s *JSONStorage) Save(ctx context.Context, draw *entity.Draw) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	mu := s.gameLocks.get(draw.GameType)
+	mu.RLock()
+	defer mu.RUnlock()
+
+	unlock := s.drawLocks.lock(fmt.Sprintf("%s/%d", draw.GameType, draw.DrawNumber))
+	defer unlock()
+
+	existing, existingFile, err := s.findDrawFile(draw.GameType, draw.DrawNumber)
+	if err != nil {
+		return err
+	}
+
+	if existing == nil {
+		if err := os.MkdirAll(s.getGameTypeDir("draws", draw.GameType), 0755); err != nil {
+			return fmt.Errorf("failed to create draws directory: %w", err)
+		}
+		filename := s.getDrawFilename(draw.GameType, draw.ID)
+		return s.saveToFile(filename, draw)
+	}
+
+	if !existing.Equals(draw) && !(existing.Sample && !draw.Sample) {
+		logger.Warn("Draw result changed since it was stored, recording correction",
+			zap.String("game_type", string(draw.GameType)),
+			zap.Int("draw_number", draw.DrawNumber),
+			zap.String("old_numbers", existing.Numbers.String()),
+			zap.String("new_numbers", draw.Numbers.String()),
+		)
+		if err := s.recordCorrection(existingFile, existing, draw); err != nil {
+			return fmt.Errorf("failed to record correction for draw %d: %w", draw.DrawNumber, err)
+		}
+	}
 
-	filename := s.getDrawFilename(draw.GameType, draw.ID)
-	return s.saveToFile(filename, draw)
+	draw.ID = existing.ID
+	return s.saveToFile(existingFile, draw)
 }
 
-// SaveBatch saves multiple draws
+// SaveBatch saves multiple draws. Every draw is attempted even if some fail,
+// until ctx is cancelled; the failures, and the cancellation, are returned
+// together as a *BatchSaveError.
 func (s *JSONStorage) SaveBatch(ctx context.Context, draws []*entity.Draw) error {
-	for _, draw := range draws {
+	var errs []error
+	saved := 0
+	for i, draw := range draws {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, fmt.Errorf("%d draw(s) from index %d not saved: %w", len(draws)-i, i, err))
+			break
+		}
+		if draw == nil {
+			errs = append(errs, fmt.Errorf("draw at index %d is nil", i))
+			continue
+		}
 		if err := s.Save(ctx, draw); err != nil {
-			return err
+			errs = append(errs, fmt.Errorf("draw %d: %w", draw.DrawNumber, err))
+			continue
+		}
+		saved++
+	}
+
+	if len(errs) > 0 {
+		return &BatchSaveError{
+			Saved:  saved,
+			Total:  len(draws),
+			Errors: errs,
 		}
 	}
 	return nil
 }
 
+// BatchSaveError reports the draws of a SaveBatch call that couldn't be
+// saved. The others were saved.
+type BatchSaveError struct {
+	Saved  int     // Draws saved successfully
+	Total  int     // Draws in the batch
+	Errors []error // One per draw that failed, plus one for the draws left when ctx was cancelled
+}
+
+// Error lists every failure after the saved count
+func (e *BatchSaveError) Error() string {
+	return fmt.Sprintf("saved %d of %d draws: %v", e.Saved, e.Total, errors.Join(e.Errors...))
+}
+
+// Unwrap returns the per-draw errors, for errors.Is and errors.As
+func (e *BatchSaveError) Unwrap() []error {
+	return e.Errors
+}
+
 // FindByID finds a draw by ID
 func (s *JSONStorage) FindByID(ctx context.Context, id string) (*entity.Draw, error) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+	unlock := s.gameLocks.rlockAll()
+	defer unlock()
 
 	// Search in all game type directories
-	gameTypes := []valueobject.GameType{valueobject.Mega645, valueobject.Power655}
-	for _, gameType := range gameTypes {
+	for _, gameType := range valueobject.AllGameTypes() {
 		filename := s.getDrawFilename(gameType, id)
 		if _, err := os.Stat(filename); err == nil {
 			var draw entity.Draw
@@ -86,32 +174,19 @@ func (s *JSONStorage) FindByGameTypeAndDrawNumber(
 	gameType valueobject.GameType,
 	drawNumber int,
 ) (*entity.Draw, error) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+	mu := s.gameLocks.get(gameType)
+	mu.RLock()
+	defer mu.RUnlock()
 
-	dir := s.getGameTypeDir("draws", gameType)
-	files, err := os.ReadDir(dir)
+	draw, _, err := s.findDrawFile(gameType, drawNumber)
 	if err != nil {
 		return nil, err
 	}
-
-	for _, file := range files {
-		if file.IsDir() {
-			continue
-		}
-
-		var draw entity.Draw
-		filename := filepath.Join(dir, file.Name())
-		if err := s.loadFromFile(filename, &draw); err != nil {
-			continue
-		}
-
-		if draw.DrawNumber == drawNumber {
-			return &draw, nil
-		}
+	if draw == nil {
+		return nil, fmt.Errorf("draw number %d for game type %s: %w", drawNumber, gameType, repository.ErrNotFound)
 	}
 
-	return nil, fmt.Errorf("draw number %d not found for game type %s", drawNumber, gameType)
+	return draw, nil
 }
 
 // FindLatest finds the most recent draws
@@ -120,8 +195,9 @@ func (s *JSONStorage) FindLatest(
 	gameType valueobject.GameType,
 	limit int,
 ) ([]*entity.Draw, error) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+	mu := s.gameLocks.get(gameType)
+	mu.RLock()
+	defer mu.RUnlock()
 
 	dir := s.getGameTypeDir("draws", gameType)
 	files, err := os.ReadDir(dir)
@@ -138,6 +214,7 @@ func (s *JSONStorage) FindLatest(
 		var draw entity.Draw
 		filename := filepath.Join(dir, file.Name())
 		if err := s.loadFromFile(filename, &draw); err != nil {
+			s.corrupt.skip(filename, err)
 			continue
 		}
 
@@ -159,8 +236,9 @@ func (s *JSONStorage) FindByDateRange(
 	gameType valueobject.GameType,
 	dateRange valueobject.DateRange,
 ) ([]*entity.Draw, error) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+	mu := s.gameLocks.get(gameType)
+	mu.RLock()
+	defer mu.RUnlock()
 
 	dir := s.getGameTypeDir("draws", gameType)
 	files, err := os.ReadDir(dir)
@@ -177,6 +255,7 @@ func (s *JSONStorage) FindByDateRange(
 		var draw entity.Draw
 		filename := filepath.Join(dir, file.Name())
 		if err := s.loadFromFile(filename, &draw); err != nil {
+			s.corrupt.skip(filename, err)
 			continue
 		}
 
@@ -188,14 +267,137 @@ func (s *JSONStorage) FindByDateRange(
 	return draws, nil
 }
 
+// FindPage finds up to limit draws, newest first, after skipping the offset
+// newest. Only the draws of the page are kept in memory.
+func (s *JSONStorage) FindPage(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	offset int,
+	limit int,
+) ([]*entity.Draw, error) {
+	if offset < 0 || limit < 0 {
+		return nil, fmt.Errorf("offset and limit cannot be negative, got %d and %d", offset, limit)
+	}
+
+	mu := s.gameLocks.get(gameType)
+	mu.RLock()
+	defer mu.RUnlock()
+
+	stamps, err := s.drawStamps(gameType)
+	if err != nil {
+		return nil, err
+	}
+
+	page := stamps[min(offset, len(stamps)):min(offset+limit, len(stamps))]
+	draws := make([]*entity.Draw, 0, len(page))
+	for _, stamp := range page {
+		var draw entity.Draw
+		if err := s.loadFromFile(stamp.filename, &draw); err != nil {
+			s.corrupt.skip(stamp.filename, err)
+			continue
+		}
+		draws = append(draws, &draw)
+	}
+	return draws, nil
+}
+
+// ForEachDraw calls fn with each draw, oldest first, loading one file at a
+// time. The game's lock isn't held while fn runs, so fn may save draws; a
+// draw deleted meanwhile is skipped.
+func (s *JSONStorage) ForEachDraw(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	fn func(draw *entity.Draw) error,
+) error {
+	mu := s.gameLocks.get(gameType)
+	mu.RLock()
+	stamps, err := s.drawStamps(gameType)
+	mu.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	for i := len(stamps) - 1; i >= 0; i-- {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var draw entity.Draw
+		mu.RLock()
+		err := s.loadFromFile(stamps[i].filename, &draw)
+		mu.RUnlock()
+		if err != nil {
+			if !os.IsNotExist(err) {
+				s.corrupt.skip(stamps[i].filename, err)
+			}
+			continue
+		}
+
+		if err := fn(&draw); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// drawStamp places a stored draw file in date order without keeping the draw
+type drawStamp struct {
+	filename   string
+	drawNumber int
+	drawDate   time.Time
+}
+
+// drawStamps returns a stamp per readable draw file of gameType, newest
+// first, ties broken by draw number. Callers must hold the game's lock.
+func (s *JSONStorage) drawStamps(gameType valueobject.GameType) ([]drawStamp, error) {
+	dir := s.getGameTypeDir("draws", gameType)
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	stamps := make([]drawStamp, 0, len(files))
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+
+		var header struct {
+			DrawNumber int       `json:"draw_number"`
+			DrawDate   time.Time `json:"draw_date"`
+		}
+		filename := filepath.Join(dir, file.Name())
+		if err := s.loadFromFile(filename, &header); err != nil {
+			s.corrupt.skip(filename, err)
+			continue
+		}
+		stamps = append(stamps, drawStamp{filename: filename, drawNumber: header.DrawNumber, drawDate: header.DrawDate})
+	}
+
+	sort.Slice(stamps, func(i, j int) bool {
+		if !stamps[i].drawDate.Equal(stamps[j].drawDate) {
+			return stamps[i].drawDate.After(stamps[j].drawDate)
+		}
+		return stamps[i].drawNumber > stamps[j].drawNumber
+	})
+	return stamps, nil
+}
+
 // Count returns the total number of draws for a game type
 func (s *JSONStorage) Count(ctx context.Context, gameType valueobject.GameType) (int64, error) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+	mu := s.gameLocks.get(gameType)
+	mu.RLock()
+	defer mu.RUnlock()
 
 	dir := s.getGameTypeDir("draws", gameType)
 	files, err := os.ReadDir(dir)
 	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
 		return 0, err
 	}
 
@@ -211,8 +413,9 @@ func (s *JSONStorage) Count(ctx context.Context, gameType valueobject.GameType)
 
 // DeleteAll deletes all draws for a game type
 func (s *JSONStorage) DeleteAll(ctx context.Context, gameType valueobject.GameType) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	mu := s.gameLocks.get(gameType)
+	mu.Lock()
+	defer mu.Unlock()
 
 	dir := s.getGameTypeDir("draws", gameType)
 	files, err := os.ReadDir(dir)
@@ -229,7 +432,49 @@ func (s *JSONStorage) DeleteAll(ctx context.Context, gameType valueobject.GameTy
 		}
 	}
 
-	return nil
+	return os.RemoveAll(filepath.Join(dir, backupDirName))
+}
+
+// DeleteSampleDraws deletes the sample draws init --sample stored for a game
+// type, leaving real draws alone, and returns how many it deleted
+func (s *JSONStorage) DeleteSampleDraws(ctx context.Context, gameType valueobject.GameType) (int, error) {
+	mu := s.gameLocks.get(gameType)
+	mu.Lock()
+	defer mu.Unlock()
+
+	dir := s.getGameTypeDir("draws", gameType)
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	deleted := 0
+	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return deleted, err
+		}
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+
+		var draw entity.Draw
+		filename := filepath.Join(dir, file.Name())
+		if err := s.loadFromFile(filename, &draw); err != nil {
+			s.corrupt.skip(filename, err)
+			continue
+		}
+		if !draw.Sample {
+			continue
+		}
+		if err := os.Remove(filename); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+	return deleted, nil
 }
 
 // GetLatestDrawNumber returns the highest draw number
@@ -253,8 +498,9 @@ func (s *JSONStorage) FindByDrawNumberRange(
 	startDrawNumber int,
 	endDrawNumber int,
 ) ([]*entity.Draw, error) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+	mu := s.gameLocks.get(gameType)
+	mu.RLock()
+	defer mu.RUnlock()
 
 	dir := s.getGameTypeDir("draws", gameType)
 	files, err := os.ReadDir(dir)
@@ -271,6 +517,7 @@ func (s *JSONStorage) FindByDrawNumberRange(
 		var draw entity.Draw
 		filename := filepath.Join(dir, file.Name())
 		if err := s.loadFromFile(filename, &draw); err != nil {
+			s.corrupt.skip(filename, err)
 			continue
 		}
 
@@ -284,6 +531,104 @@ func (s *JSONStorage) FindByDrawNumberRange(
 
 // Helper methods
 
+// findDrawFile looks up a stored draw by draw number and returns it with its
+// file path. It returns a nil draw if none is stored. The file named with the
+// draw's canonical entity.DrawID is tried first; only when it is missing,
+// unreadable or holds another draw are the other files searched, skipping
+// those named with another draw's canonical ID. Callers must hold the game
+// type's lock, for reading or writing.
+func (s *JSONStorage) findDrawFile(gameType valueobject.GameType, drawNumber int) (*entity.Draw, string, error) {
+	canonical := s.getDrawFilename(gameType, entity.DrawID(gameType, drawNumber))
+	var draw entity.Draw
+	if err := s.loadFromFile(canonical, &draw); err == nil && draw.DrawNumber == drawNumber {
+		return &draw, canonical, nil
+	}
+
+	dir := s.getGameTypeDir("draws", gameType)
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, "", nil
+		}
+		return nil, "", err
+	}
+
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		if n, ok := canonicalDrawNumber(gameType, file.Name()); ok && n != drawNumber {
+			continue
+		}
+
+		var draw entity.Draw
+		filename := filepath.Join(dir, file.Name())
+		if err := s.loadFromFile(filename, &draw); err != nil {
+			s.corrupt.skip(filename, err)
+			continue
+		}
+
+		if draw.DrawNumber == drawNumber {
+			return &draw, filename, nil
+		}
+	}
+
+	return nil, "", nil
+}
+
+// canonicalDrawNumber returns the draw number of a file named with
+// entity.DrawID for gameType, and false for any other name
+func canonicalDrawNumber(gameType valueobject.GameType, name string) (int, bool) {
+	id, ok := strings.CutSuffix(name, ".json")
+	if !ok {
+		return 0, false
+	}
+	prefix := strings.TrimSuffix(entity.DrawID(gameType, 0), "00000")
+	digits, ok := strings.CutPrefix(id, prefix)
+	if !ok {
+		return 0, false
+	}
+	drawNumber, err := strconv.Atoi(digits)
+	if err != nil || entity.DrawID(gameType, drawNumber) != id {
+		return 0, false
+	}
+	return drawNumber, true
+}
+
+// recordCorrection archives the previously stored version of a draw and
+// appends a line describing the change to corrections.log
+func (s *JSONStorage) recordCorrection(existingFile string, old, corrected *entity.Draw) error {
+	now := time.Now()
+
+	archiveDir := filepath.Join(filepath.Dir(existingFile), "archive")
+	if err := os.MkdirAll(archiveDir, 0755); err != nil {
+		return fmt.Errorf("failed to create archive directory: %w", err)
+	}
+
+	archiveName := fmt.Sprintf("%s_%s.json", old.ID, now.Format("20060102T150405"))
+	if err := s.saveToFile(filepath.Join(archiveDir, archiveName), old); err != nil {
+		return fmt.Errorf("failed to archive draw: %w", err)
+	}
+
+	logFile, err := os.OpenFile(filepath.Join(s.basePath, "corrections.log"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open corrections log: %w", err)
+	}
+	defer logFile.Close()
+
+	_, err = fmt.Fprintf(logFile, "%s %s draw #%d (%s): %s -> %s, date %s -> %s\n",
+		now.Format(time.RFC3339),
+		corrected.GameType,
+		corrected.DrawNumber,
+		old.ID,
+		old.Numbers,
+		corrected.Numbers,
+		old.DrawDate.Format("2006-01-02"),
+		corrected.DrawDate.Format("2006-01-02"),
+	)
+	return err
+}
+
 func (s *JSONStorage) getDrawFilename(gameType valueobject.GameType, id string) string {
 	return filepath.Join(s.getGameTypeDir("draws", gameType), id+".json")
 }
@@ -294,19 +639,88 @@ func (s *JSONStorage) getGameTypeDir(subDir string, gameType valueobject.GameTyp
 }
 
 func (s *JSONStorage) saveToFile(filename string, data interface{}) error {
-	jsonData, err := json.MarshalIndent(data, "", "  ")
-	if err != nil {
-		return err
-	}
-	return os.WriteFile(filename, jsonData, 0644)
+	return writeJSONFile(filename, data)
 }
 
 func (s *JSONStorage) loadFromFile(filename string, data interface{}) error {
-	file, err := os.ReadFile(filename)
-	if err != nil {
-		return err
+	return readJSONFile(filename, data)
+}
+
+// keyedMutex hands out one mutex per key, dropping it once no caller holds
+// or waits for it
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*keyedLock
+}
+
+// keyedLock is the mutex of a key and the number of callers holding or
+// waiting for it
+type keyedLock struct {
+	sync.Mutex
+	refs int
+}
+
+// lock locks the mutex of key and returns its unlock function
+func (k *keyedMutex) lock(key string) func() {
+	k.mu.Lock()
+	if k.locks == nil {
+		k.locks = make(map[string]*keyedLock)
+	}
+	l, ok := k.locks[key]
+	if !ok {
+		l = &keyedLock{}
+		k.locks[key] = l
+	}
+	l.refs++
+	k.mu.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+
+		k.mu.Lock()
+		defer k.mu.Unlock()
+		if l.refs--; l.refs == 0 {
+			delete(k.locks, key)
+		}
+	}
+}
+
+// gameLocks hands out one read-write lock per game type, so operations on
+// different games don't contend
+type gameLocks struct {
+	mu    sync.Mutex
+	locks map[valueobject.GameType]*sync.RWMutex
+}
+
+// get returns the lock of gameType
+func (g *gameLocks) get(gameType valueobject.GameType) *sync.RWMutex {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.locks == nil {
+		g.locks = make(map[valueobject.GameType]*sync.RWMutex)
+	}
+	m, ok := g.locks[gameType]
+	if !ok {
+		m = &sync.RWMutex{}
+		g.locks[gameType] = m
+	}
+	return m
+}
+
+// rlockAll read-locks every game type, always in AllGameTypes order, and
+// returns the function that unlocks them
+func (g *gameLocks) rlockAll() func() {
+	gameTypes := valueobject.AllGameTypes()
+	for _, gameType := range gameTypes {
+		g.get(gameType).RLock()
+	}
+	return func() {
+		for i := len(gameTypes) - 1; i >= 0; i-- {
+			g.get(gameTypes[i]).RUnlock()
+		}
 	}
-	return json.Unmarshal(file, data)
 }
 
 func sortDrawsByDate(draws []*entity.Draw, ascending bool) {
@@ -2,7 +2,6 @@ package storage
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -19,6 +18,7 @@ import (
 // BacktestJSONStorage implements repository.BacktestRepository
 type BacktestJSONStorage struct {
 	basePath string
+	corrupt  corruptFiles // Files skipped by read loops
 	mu       sync.RWMutex
 }
 
@@ -38,11 +38,22 @@ func NewBacktestJSONStorage(basePath string) (*BacktestJSONStorage, error) {
 	}, nil
 }
 
+// SkippedCorruptFiles returns how many unreadable backtest files read
+// operations have skipped since the storage was created. Each skipped file is
+// also logged at warn level.
+func (s *BacktestJSONStorage) SkippedCorruptFiles() int64 {
+	return s.corrupt.count()
+}
+
 // Save saves a backtest result
 func (s *BacktestJSONStorage) Save(ctx context.Context, result *entity.BacktestResult) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if err := os.MkdirAll(s.getGameTypeDir("backtests", result.GameType), 0755); err != nil {
+		return fmt.Errorf("failed to create backtests directory: %w", err)
+	}
+
 	filename := s.getBacktestFilename(result.GameType, result.ID)
 	return s.saveToFile(filename, result)
 }
@@ -69,6 +80,7 @@ func (s *BacktestJSONStorage) FindByID(ctx context.Context, id string) (*entity.
 			var result entity.BacktestResult
 			filename := filepath.Join(dir, file.Name())
 			if err := s.loadFromFile(filename, &result); err != nil {
+				s.corrupt.skip(filename, err)
 				continue
 			}
 
@@ -103,6 +115,7 @@ func (s *BacktestJSONStorage) FindLatest(ctx context.Context, limit int) ([]*ent
 			var result entity.BacktestResult
 			filename := filepath.Join(dir, file.Name())
 			if err := s.loadFromFile(filename, &result); err != nil {
+				s.corrupt.skip(filename, err)
 				continue
 			}
 
@@ -144,6 +157,7 @@ func (s *BacktestJSONStorage) FindByAlgorithm(
 		var result entity.BacktestResult
 		filename := filepath.Join(dir, file.Name())
 		if err := s.loadFromFile(filename, &result); err != nil {
+			s.corrupt.skip(filename, err)
 			continue
 		}
 
@@ -184,6 +198,7 @@ func (s *BacktestJSONStorage) FindByGameType(
 		var result entity.BacktestResult
 		filename := filepath.Join(dir, file.Name())
 		if err := s.loadFromFile(filename, &result); err != nil {
+			s.corrupt.skip(filename, err)
 			continue
 		}
 
@@ -196,17 +211,12 @@ func (s *BacktestJSONStorage) FindByGameType(
 // FindByDateRange finds backtest results within a date range
 func (s *BacktestJSONStorage) FindByDateRange(
 	ctx context.Context,
-	startDate interface{},
-	endDate interface{},
+	dateRange valueobject.DateRange,
 ) ([]*entity.BacktestResult, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	start, ok1 := startDate.(time.Time)
-	end, ok2 := endDate.(time.Time)
-	if !ok1 || !ok2 {
-		return nil, fmt.Errorf("startDate and endDate must be time.Time")
-	}
+	start, end := dateRange.StartDate, dateRange.EndDate
 
 	results := make([]*entity.BacktestResult, 0)
 	gameTypes := []valueobject.GameType{valueobject.Mega645, valueobject.Power655}
@@ -225,6 +235,7 @@ func (s *BacktestJSONStorage) FindByDateRange(
 			var result entity.BacktestResult
 			filename := filepath.Join(dir, file.Name())
 			if err := s.loadFromFile(filename, &result); err != nil {
+				s.corrupt.skip(filename, err)
 				continue
 			}
 
@@ -265,6 +276,7 @@ func (s *BacktestJSONStorage) FindBestPerforming(
 		var result entity.BacktestResult
 		filename := filepath.Join(dir, file.Name())
 		if err := s.loadFromFile(filename, &result); err != nil {
+			s.corrupt.skip(filename, err)
 			continue
 		}
 
@@ -294,16 +306,12 @@ func (s *BacktestJSONStorage) FindBestPerforming(
 	return bestResult, nil
 }
 
-// DeleteOld removes backtest results older than a certain date
-func (s *BacktestJSONStorage) DeleteOld(ctx context.Context, beforeDate interface{}) error {
+// DeleteOld moves backtest results older than a certain date to the trash.
+// They stay recoverable with Restore until EmptyTrash is called.
+func (s *BacktestJSONStorage) DeleteOld(ctx context.Context, before time.Time) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	before, ok := beforeDate.(time.Time)
-	if !ok {
-		return fmt.Errorf("beforeDate must be time.Time")
-	}
-
 	// Delete from both game types
 	gameTypes := []valueobject.GameType{valueobject.Mega645, valueobject.Power655}
 	for _, gameType := range gameTypes {
@@ -321,11 +329,14 @@ func (s *BacktestJSONStorage) DeleteOld(ctx context.Context, beforeDate interfac
 			var result entity.BacktestResult
 			filename := filepath.Join(dir, file.Name())
 			if err := s.loadFromFile(filename, &result); err != nil {
+				s.corrupt.skip(filename, err)
 				continue
 			}
 
 			if result.TestPeriod.EndDate.Before(before) {
-				os.Remove(filename)
+				if err := moveToTrash(filename); err != nil {
+					return err
+				}
 			}
 		}
 	}
@@ -333,6 +344,43 @@ func (s *BacktestJSONStorage) DeleteOld(ctx context.Context, beforeDate interfac
 	return nil
 }
 
+// Restore moves a trashed backtest result back into place
+func (s *BacktestJSONStorage) Restore(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	gameTypes := []valueobject.GameType{valueobject.Mega645, valueobject.Power655}
+	for _, gameType := range gameTypes {
+		restored, err := restoreFromTrash(s.getGameTypeDir("backtests", gameType), id)
+		if err != nil {
+			return err
+		}
+		if restored {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("backtest result with ID %s not found in trash", id)
+}
+
+// EmptyTrash permanently removes all trashed backtest results and returns how many were purged
+func (s *BacktestJSONStorage) EmptyTrash(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	purged := 0
+	gameTypes := []valueobject.GameType{valueobject.Mega645, valueobject.Power655}
+	for _, gameType := range gameTypes {
+		n, err := emptyTrash(s.getGameTypeDir("backtests", gameType))
+		purged += n
+		if err != nil {
+			return purged, err
+		}
+	}
+
+	return purged, nil
+}
+
 // Helper methods
 
 func (s *BacktestJSONStorage) getBacktestFilename(gameType valueobject.GameType, id string) string {
@@ -345,19 +393,11 @@ func (s *BacktestJSONStorage) getGameTypeDir(subDir string, gameType valueobject
 }
 
 func (s *BacktestJSONStorage) saveToFile(filename string, data interface{}) error {
-	jsonData, err := json.MarshalIndent(data, "", "  ")
-	if err != nil {
-		return err
-	}
-	return os.WriteFile(filename, jsonData, 0644)
+	return writeJSONFile(filename, data)
 }
 
 func (s *BacktestJSONStorage) loadFromFile(filename string, data interface{}) error {
-	file, err := os.ReadFile(filename)
-	if err != nil {
-		return err
-	}
-	return json.Unmarshal(file, data)
+	return readJSONFile(filename, data)
 }
 
 func sortBacktestsByDate(results []*entity.BacktestResult, ascending bool) {
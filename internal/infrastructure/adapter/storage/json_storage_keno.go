@@ -0,0 +1,149 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/repository"
+)
+
+// KenoJSONStorage implements repository.KenoDrawRepository, storing each
+// Keno draw as draws/keno/keno_<draw number>.json
+type KenoJSONStorage struct {
+	basePath string
+	corrupt  corruptFiles // Files skipped by read loops
+	mu       sync.RWMutex
+}
+
+// NewKenoJSONStorage creates a new Keno draw storage adapter
+func NewKenoJSONStorage(basePath string) (*KenoJSONStorage, error) {
+	if err := os.MkdirAll(filepath.Join(basePath, "draws", "keno"), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create keno draws directory: %w", err)
+	}
+
+	return &KenoJSONStorage{
+		basePath: basePath,
+	}, nil
+}
+
+// SkippedCorruptFiles returns how many unreadable Keno draw files read
+// operations have skipped since the storage was created
+func (s *KenoJSONStorage) SkippedCorruptFiles() int64 {
+	return s.corrupt.count()
+}
+
+// Save saves a Keno draw. A stored draw with the same number is replaced,
+// keeping its ID.
+func (s *KenoJSONStorage) Save(ctx context.Context, draw *entity.KenoDraw) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	filename := s.drawFilename(draw.DrawNumber)
+	var existing entity.KenoDraw
+	if err := s.loadFromFile(filename, &existing); err == nil && existing.ID != "" {
+		draw.ID = existing.ID
+	}
+	return s.saveToFile(filename, draw)
+}
+
+// SaveBatch saves multiple Keno draws. Every draw is attempted even if some
+// fail; the failures are returned together as a *BatchSaveError.
+func (s *KenoJSONStorage) SaveBatch(ctx context.Context, draws []*entity.KenoDraw) error {
+	var errs []error
+	for i, draw := range draws {
+		if draw == nil {
+			errs = append(errs, fmt.Errorf("draw at index %d is nil", i))
+			continue
+		}
+		if err := s.Save(ctx, draw); err != nil {
+			errs = append(errs, fmt.Errorf("draw %d: %w", draw.DrawNumber, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return &BatchSaveError{
+			Saved:  len(draws) - len(errs),
+			Total:  len(draws),
+			Errors: errs,
+		}
+	}
+	return nil
+}
+
+// FindLatest finds the most recent Keno draws by draw number, newest first
+func (s *KenoJSONStorage) FindLatest(ctx context.Context, limit int) ([]*entity.KenoDraw, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	dir := s.drawsDir()
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	draws := make([]*entity.KenoDraw, 0, len(files))
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+
+		var draw entity.KenoDraw
+		filename := filepath.Join(dir, file.Name())
+		if err := s.loadFromFile(filename, &draw); err != nil {
+			s.corrupt.skip(filename, err)
+			continue
+		}
+		draws = append(draws, &draw)
+	}
+
+	sort.Slice(draws, func(i, j int) bool {
+		return draws[i].DrawNumber > draws[j].DrawNumber
+	})
+	if len(draws) > limit {
+		draws = draws[:limit]
+	}
+	return draws, nil
+}
+
+// Count returns the number of stored Keno draws
+func (s *KenoJSONStorage) Count(ctx context.Context) (int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	files, err := os.ReadDir(s.drawsDir())
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	for _, file := range files {
+		if !file.IsDir() && filepath.Ext(file.Name()) == ".json" {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (s *KenoJSONStorage) drawsDir() string {
+	return filepath.Join(s.basePath, "draws", "keno")
+}
+
+func (s *KenoJSONStorage) drawFilename(drawNumber int) string {
+	return filepath.Join(s.drawsDir(), fmt.Sprintf("keno_%07d.json", drawNumber))
+}
+
+func (s *KenoJSONStorage) saveToFile(filename string, data interface{}) error {
+	return writeJSONFile(filename, data)
+}
+
+func (s *KenoJSONStorage) loadFromFile(filename string, data interface{}) error {
+	return readJSONFile(filename, data)
+}
+
+// Ensure KenoJSONStorage implements repository.KenoDrawRepository
+var _ repository.KenoDrawRepository = (*KenoJSONStorage)(nil)
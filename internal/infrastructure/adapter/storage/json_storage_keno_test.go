@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func newTestKenoDraw(t *testing.T, drawNumber, first int) *entity.KenoDraw {
+	t.Helper()
+
+	nums := make([]int, valueobject.KenoDrawSize)
+	for i := range nums {
+		nums[i] = first + i
+	}
+	numbers, err := valueobject.NewKenoNumbers(nums)
+	require.NoError(t, err)
+	drawTime := time.Date(2026, 3, 2, 6, 0, 0, 0, time.UTC).Add(time.Duration(drawNumber) * 8 * time.Minute)
+	draw, err := entity.NewKenoDraw(drawNumber, numbers, drawTime)
+	require.NoError(t, err)
+	return draw
+}
+
+func TestKenoJSONStorage_SaveAndFindLatest(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewKenoJSONStorage(t.TempDir())
+	require.NoError(t, err)
+
+	require.NoError(t, store.SaveBatch(ctx, []*entity.KenoDraw{
+		newTestKenoDraw(t, 101, 1),
+		newTestKenoDraw(t, 103, 41),
+		newTestKenoDraw(t, 102, 21),
+	}))
+
+	count, err := store.Count(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, count)
+
+	latest, err := store.FindLatest(ctx, 2)
+	require.NoError(t, err)
+	require.Len(t, latest, 2)
+	assert.Equal(t, 103, latest[0].DrawNumber)
+	assert.Equal(t, 102, latest[1].DrawNumber)
+	assert.Len(t, latest[0].Numbers, valueobject.KenoDrawSize)
+	assert.Equal(t, 41, latest[0].Numbers[0])
+}
+
+func TestKenoJSONStorage_SaveReplacesSameDraw(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewKenoJSONStorage(t.TempDir())
+	require.NoError(t, err)
+
+	original := newTestKenoDraw(t, 7, 1)
+	require.NoError(t, store.Save(ctx, original))
+	corrected := newTestKenoDraw(t, 7, 61)
+	require.NoError(t, store.Save(ctx, corrected))
+
+	count, err := store.Count(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, count)
+
+	latest, err := store.FindLatest(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, latest, 1)
+	assert.Equal(t, original.ID, latest[0].ID)
+	assert.Equal(t, 61, latest[0].Numbers[0])
+}
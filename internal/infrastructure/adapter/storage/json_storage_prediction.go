@@ -2,7 +2,6 @@ package storage
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -11,15 +10,20 @@ import (
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/tool_predict/internal/domain/entity"
 	"github.com/tool_predict/internal/domain/repository"
 	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
 )
 
 // PredictionJSONStorage implements repository.PredictionRepository
 type PredictionJSONStorage struct {
-	basePath string
-	mu       sync.RWMutex
+	basePath    string
+	keepHistory bool         // Keep superseded ensembles instead of trashing them
+	corrupt     corruptFiles // Files skipped by read loops
+	mu          sync.RWMutex
 }
 
 // NewPredictionJSONStorage creates a new prediction storage adapter
@@ -41,6 +45,13 @@ func NewPredictionJSONStorage(basePath string) (*PredictionJSONStorage, error) {
 	}, nil
 }
 
+// SkippedCorruptFiles returns how many unreadable prediction files read
+// operations have skipped since the storage was created. Each skipped file is
+// also logged at warn level.
+func (s *PredictionJSONStorage) SkippedCorruptFiles() int64 {
+	return s.corrupt.count()
+}
+
 // Save saves a single prediction
 func (s *PredictionJSONStorage) Save(ctx context.Context, prediction *entity.Prediction) error {
 	s.mu.Lock()
@@ -60,15 +71,70 @@ func (s *PredictionJSONStorage) SaveBatch(ctx context.Context, predictions []*en
 	return nil
 }
 
-// SaveEnsemble saves an ensemble prediction
+// SetKeepHistory controls whether SaveEnsemble keeps older ensembles for the
+// same target draw. By default they are superseded and moved to the trash.
+func (s *PredictionJSONStorage) SetKeepHistory(keep bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keepHistory = keep
+}
+
+// SaveEnsemble saves an ensemble prediction, assigning an ID if it has none.
+// Unless history is kept, older ensembles for the same game type and target
+// draw date are moved to the trash, leaving one current prediction per draw.
 func (s *PredictionJSONStorage) SaveEnsemble(ctx context.Context, ensemble *entity.EnsemblePrediction) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if ensemble.ID == "" {
+		ensemble.ID = uuid.New().String()
+	}
+
+	if !s.keepHistory && !ensemble.ForDate.IsZero() {
+		if err := s.supersedeEnsembles(ensemble); err != nil {
+			return err
+		}
+	}
+
 	filename := s.getEnsembleFilename(ensemble.GameType, ensemble.ID)
 	return s.saveToFile(filename, ensemble)
 }
 
+// supersedeEnsembles trashes stored ensembles with the same target as current
+// that were generated no later than it
+func (s *PredictionJSONStorage) supersedeEnsembles(current *entity.EnsemblePrediction) error {
+	dir := s.getGameTypeDir("ensembles", current.GameType)
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+
+		var stored entity.EnsemblePrediction
+		filename := filepath.Join(dir, file.Name())
+		if err := s.loadFromFile(filename, &stored); err != nil {
+			s.corrupt.skip(filename, err)
+			continue
+		}
+
+		if stored.ID == current.ID || !stored.SameTarget(current) || stored.GeneratedAt.After(current.GeneratedAt) {
+			continue
+		}
+		if err := moveToTrash(filename); err != nil {
+			return fmt.Errorf("failed to supersede ensemble %s: %w", stored.ID, err)
+		}
+	}
+
+	return nil
+}
+
 // FindByID finds a prediction by ID
 func (s *PredictionJSONStorage) FindByID(ctx context.Context, id string) (*entity.Prediction, error) {
 	s.mu.RLock()
@@ -91,6 +157,7 @@ func (s *PredictionJSONStorage) FindByID(ctx context.Context, id string) (*entit
 			var pred entity.Prediction
 			filename := filepath.Join(dir, file.Name())
 			if err := s.loadFromFile(filename, &pred); err != nil {
+				s.corrupt.skip(filename, err)
 				continue
 			}
 
@@ -125,6 +192,7 @@ func (s *PredictionJSONStorage) FindEnsembleByID(ctx context.Context, id string)
 			var ensemble entity.EnsemblePrediction
 			filename := filepath.Join(dir, file.Name())
 			if err := s.loadFromFile(filename, &ensemble); err != nil {
+				s.corrupt.skip(filename, err)
 				continue
 			}
 
@@ -161,6 +229,7 @@ func (s *PredictionJSONStorage) FindLatest(
 		var pred entity.Prediction
 		filename := filepath.Join(dir, file.Name())
 		if err := s.loadFromFile(filename, &pred); err != nil {
+			s.corrupt.skip(filename, err)
 			continue
 		}
 
@@ -200,6 +269,7 @@ func (s *PredictionJSONStorage) FindLatestEnsembles(
 		var ensemble entity.EnsemblePrediction
 		filename := filepath.Join(dir, file.Name())
 		if err := s.loadFromFile(filename, &ensemble); err != nil {
+			s.corrupt.skip(filename, err)
 			continue
 		}
 
@@ -215,6 +285,49 @@ func (s *PredictionJSONStorage) FindLatestEnsembles(
 	return ensembles, nil
 }
 
+// FindCurrentEnsemble finds the newest ensemble prediction for a game type
+// and target draw date
+func (s *PredictionJSONStorage) FindCurrentEnsemble(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	forDate time.Time,
+) (*entity.EnsemblePrediction, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	dir := s.getGameTypeDir("ensembles", gameType)
+	files, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	var current *entity.EnsemblePrediction
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+
+		var ensemble entity.EnsemblePrediction
+		filename := filepath.Join(dir, file.Name())
+		if err := s.loadFromFile(filename, &ensemble); err != nil {
+			s.corrupt.skip(filename, err)
+			continue
+		}
+
+		if !ensemble.ForDate.Equal(forDate) {
+			continue
+		}
+		if current == nil || ensemble.GeneratedAt.After(current.GeneratedAt) {
+			current = &ensemble
+		}
+	}
+
+	if current == nil {
+		return nil, fmt.Errorf("ensemble prediction for %s on %s: %w", gameType, forDate.Format("2006-01-02"), repository.ErrNotFound)
+	}
+	return current, nil
+}
+
 // FindByAlgorithm finds predictions for a specific algorithm and game type
 func (s *PredictionJSONStorage) FindByAlgorithm(
 	ctx context.Context,
@@ -240,6 +353,7 @@ func (s *PredictionJSONStorage) FindByAlgorithm(
 		var pred entity.Prediction
 		filename := filepath.Join(dir, file.Name())
 		if err := s.loadFromFile(filename, &pred); err != nil {
+			s.corrupt.skip(filename, err)
 			continue
 		}
 
@@ -261,17 +375,12 @@ func (s *PredictionJSONStorage) FindByAlgorithm(
 func (s *PredictionJSONStorage) FindByDateRange(
 	ctx context.Context,
 	gameType valueobject.GameType,
-	startDate interface{},
-	endDate interface{},
+	dateRange valueobject.DateRange,
 ) ([]*entity.Prediction, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	start, ok1 := startDate.(time.Time)
-	end, ok2 := endDate.(time.Time)
-	if !ok1 || !ok2 {
-		return nil, fmt.Errorf("startDate and endDate must be time.Time")
-	}
+	start, end := dateRange.StartDate, dateRange.EndDate
 
 	dir := s.getGameTypeDir("predictions", gameType)
 	files, err := os.ReadDir(dir)
@@ -288,6 +397,7 @@ func (s *PredictionJSONStorage) FindByDateRange(
 		var pred entity.Prediction
 		filename := filepath.Join(dir, file.Name())
 		if err := s.loadFromFile(filename, &pred); err != nil {
+			s.corrupt.skip(filename, err)
 			continue
 		}
 
@@ -321,43 +431,141 @@ func (s *PredictionJSONStorage) Count(ctx context.Context, gameType valueobject.
 	return count, nil
 }
 
-// DeleteOld removes predictions older than a certain date
-func (s *PredictionJSONStorage) DeleteOld(ctx context.Context, beforeDate interface{}) error {
+// DeleteOld moves predictions and ensemble predictions older than a certain
+// date to the trash. They stay recoverable with Restore until EmptyTrash is
+// called.
+func (s *PredictionJSONStorage) DeleteOld(ctx context.Context, before time.Time) error {
+	_, err := s.TrashOlderThan(ctx, before)
+	return err
+}
+
+// TrashedPredictions counts the files TrashOlderThan moved to the trash
+type TrashedPredictions struct {
+	Predictions int
+	Ensembles   int
+}
+
+// TrashOlderThan moves predictions and ensemble predictions generated before
+// before to the trash and returns how many of each it moved. Each one is
+// logged with its ID.
+func (s *PredictionJSONStorage) TrashOlderThan(ctx context.Context, before time.Time) (TrashedPredictions, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	before, ok := beforeDate.(time.Time)
-	if !ok {
-		return fmt.Errorf("beforeDate must be time.Time")
-	}
-
-	// Delete from both game types
+	var trashed TrashedPredictions
 	gameTypes := []valueobject.GameType{valueobject.Mega645, valueobject.Power655}
 	for _, gameType := range gameTypes {
-		dir := s.getGameTypeDir("predictions", gameType)
-		files, err := os.ReadDir(dir)
+		n, err := s.trashOlderThan(s.getGameTypeDir("predictions", gameType), before, s.loadPredictionStamp)
+		trashed.Predictions += n
+		if err != nil {
+			return trashed, err
+		}
+		n, err = s.trashOlderThan(s.getGameTypeDir("ensembles", gameType), before, s.loadEnsembleStamp)
+		trashed.Ensembles += n
 		if err != nil {
+			return trashed, err
+		}
+	}
+
+	return trashed, nil
+}
+
+// trashOlderThan moves the files in dir whose prediction, read by load, was
+// generated before before to the trash and returns how many it moved
+func (s *PredictionJSONStorage) trashOlderThan(
+	dir string,
+	before time.Time,
+	load func(filename string) (id string, generatedAt time.Time, err error),
+) (int, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, nil
+	}
+
+	trashed := 0
+	for _, file := range files {
+		if file.IsDir() {
 			continue
 		}
 
-		for _, file := range files {
-			if file.IsDir() {
-				continue
-			}
+		filename := filepath.Join(dir, file.Name())
+		id, generatedAt, err := load(filename)
+		if err != nil {
+			s.corrupt.skip(filename, err)
+			continue
+		}
+		if !generatedAt.Before(before) {
+			continue
+		}
 
-			var pred entity.Prediction
-			filename := filepath.Join(dir, file.Name())
-			if err := s.loadFromFile(filename, &pred); err != nil {
-				continue
+		if err := moveToTrash(filename); err != nil {
+			return trashed, err
+		}
+		trashed++
+		logger.Info("Moved old prediction to trash",
+			zap.String("id", id),
+			zap.String("file", filename),
+			zap.Time("generated_at", generatedAt),
+		)
+	}
+
+	return trashed, nil
+}
+
+// loadPredictionStamp reads the ID and generation time of a stored prediction
+func (s *PredictionJSONStorage) loadPredictionStamp(filename string) (string, time.Time, error) {
+	var pred entity.Prediction
+	err := s.loadFromFile(filename, &pred)
+	return pred.ID, pred.GeneratedAt, err
+}
+
+// loadEnsembleStamp reads the ID and generation time of a stored ensemble
+// prediction
+func (s *PredictionJSONStorage) loadEnsembleStamp(filename string) (string, time.Time, error) {
+	var ensemble entity.EnsemblePrediction
+	err := s.loadFromFile(filename, &ensemble)
+	return ensemble.ID, ensemble.GeneratedAt, err
+}
+
+// Restore moves a trashed prediction or ensemble prediction back into place
+func (s *PredictionJSONStorage) Restore(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	gameTypes := []valueobject.GameType{valueobject.Mega645, valueobject.Power655}
+	for _, subDir := range []string{"predictions", "ensembles"} {
+		for _, gameType := range gameTypes {
+			restored, err := restoreFromTrash(s.getGameTypeDir(subDir, gameType), id)
+			if err != nil {
+				return err
+			}
+			if restored {
+				return nil
 			}
+		}
+	}
+
+	return fmt.Errorf("prediction with ID %s not found in trash", id)
+}
+
+// EmptyTrash permanently removes all trashed predictions and returns how many were purged
+func (s *PredictionJSONStorage) EmptyTrash(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-			if pred.GeneratedAt.Before(before) {
-				os.Remove(filename)
+	purged := 0
+	gameTypes := []valueobject.GameType{valueobject.Mega645, valueobject.Power655}
+	for _, subDir := range []string{"predictions", "ensembles"} {
+		for _, gameType := range gameTypes {
+			n, err := emptyTrash(s.getGameTypeDir(subDir, gameType))
+			purged += n
+			if err != nil {
+				return purged, err
 			}
 		}
 	}
 
-	return nil
+	return purged, nil
 }
 
 // Helper methods
@@ -376,19 +584,15 @@ func (s *PredictionJSONStorage) getGameTypeDir(subDir string, gameType valueobje
 }
 
 func (s *PredictionJSONStorage) saveToFile(filename string, data interface{}) error {
-	jsonData, err := json.MarshalIndent(data, "", "  ")
-	if err != nil {
-		return err
+	// Game type directories are created on first save
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
 	}
-	return os.WriteFile(filename, jsonData, 0644)
+	return writeJSONFile(filename, data)
 }
 
 func (s *PredictionJSONStorage) loadFromFile(filename string, data interface{}) error {
-	file, err := os.ReadFile(filename)
-	if err != nil {
-		return err
-	}
-	return json.Unmarshal(file, data)
+	return readJSONFile(filename, data)
 }
 
 func sortPredictionsByDate(predictions []*entity.Prediction, ascending bool) {
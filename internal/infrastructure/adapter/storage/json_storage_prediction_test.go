@@ -0,0 +1,144 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func newTestEnsemble(t *testing.T, forDate, generatedAt time.Time) *entity.EnsemblePrediction {
+	t.Helper()
+
+	numbers := valueobject.MustNewNumbers([]int{3, 9, 17, 22, 30, 41})
+	pred, err := entity.NewPrediction(valueobject.Mega645, "frequency_analysis", numbers, 0.5, forDate)
+	require.NoError(t, err)
+
+	ensemble, err := entity.NewEnsemblePrediction(valueobject.Mega645, []*entity.Prediction{pred}, numbers, "weighted", nil)
+	require.NoError(t, err)
+	ensemble.ForDate = forDate
+	ensemble.GeneratedAt = generatedAt
+	return ensemble
+}
+
+func newTestEnsembleStorage(t *testing.T) *PredictionJSONStorage {
+	t.Helper()
+
+	store, err := NewPredictionJSONStorage(t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(store.getGameTypeDir("ensembles", valueobject.Mega645), 0755))
+	return store
+}
+
+func TestPredictionJSONStorage_SaveEnsemble_SupersedesSameTarget(t *testing.T) {
+	store := newTestEnsembleStorage(t)
+	ctx := context.Background()
+
+	saturday := time.Date(2026, 1, 17, 0, 0, 0, 0, time.UTC)
+	monday := newTestEnsemble(t, saturday, time.Date(2026, 1, 12, 9, 0, 0, 0, time.UTC))
+	friday := newTestEnsemble(t, saturday, time.Date(2026, 1, 16, 9, 0, 0, 0, time.UTC))
+	nextDraw := newTestEnsemble(t, saturday.AddDate(0, 0, 4), time.Date(2026, 1, 16, 9, 0, 0, 0, time.UTC))
+
+	require.NoError(t, store.SaveEnsemble(ctx, monday))
+	require.NoError(t, store.SaveEnsemble(ctx, friday))
+	require.NoError(t, store.SaveEnsemble(ctx, nextDraw))
+
+	ensembles, err := store.FindLatestEnsembles(ctx, valueobject.Mega645, 10)
+	require.NoError(t, err)
+	require.Len(t, ensembles, 2)
+
+	current, err := store.FindCurrentEnsemble(ctx, valueobject.Mega645, saturday)
+	require.NoError(t, err)
+	assert.Equal(t, friday.ID, current.ID)
+
+	// The superseded prediction went to the trash
+	_, err = store.FindEnsembleByID(ctx, monday.ID)
+	require.Error(t, err)
+	require.NoError(t, store.Restore(ctx, monday.ID))
+}
+
+func TestPredictionJSONStorage_SaveEnsemble_KeepHistory(t *testing.T) {
+	store := newTestEnsembleStorage(t)
+	store.SetKeepHistory(true)
+	ctx := context.Background()
+
+	saturday := time.Date(2026, 1, 17, 0, 0, 0, 0, time.UTC)
+	monday := newTestEnsemble(t, saturday, time.Date(2026, 1, 12, 9, 0, 0, 0, time.UTC))
+	friday := newTestEnsemble(t, saturday, time.Date(2026, 1, 16, 9, 0, 0, 0, time.UTC))
+
+	// Saved out of order, the newest is still current
+	require.NoError(t, store.SaveEnsemble(ctx, friday))
+	require.NoError(t, store.SaveEnsemble(ctx, monday))
+
+	ensembles, err := store.FindLatestEnsembles(ctx, valueobject.Mega645, 10)
+	require.NoError(t, err)
+	assert.Len(t, ensembles, 2)
+
+	current, err := store.FindCurrentEnsemble(ctx, valueobject.Mega645, saturday)
+	require.NoError(t, err)
+	assert.Equal(t, friday.ID, current.ID)
+}
+
+func TestPredictionJSONStorage_SaveEnsemble_AssignsID(t *testing.T) {
+	store := newTestEnsembleStorage(t)
+	ctx := context.Background()
+
+	ensemble := newTestEnsemble(t, time.Date(2026, 1, 17, 0, 0, 0, 0, time.UTC), time.Now())
+	ensemble.ID = ""
+	require.NoError(t, store.SaveEnsemble(ctx, ensemble))
+	require.NotEmpty(t, ensemble.ID)
+
+	_, err := store.FindEnsembleByID(ctx, ensemble.ID)
+	assert.NoError(t, err)
+}
+
+func TestPredictionJSONStorage_SaveEnsemble_RoundTripsProvenance(t *testing.T) {
+	store := newTestEnsembleStorage(t)
+	ctx := context.Background()
+
+	ensemble := newTestEnsemble(t, time.Date(2026, 1, 17, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 16, 9, 0, 0, 0, time.UTC))
+	ensemble.Provenance = &entity.Provenance{
+		ToolVersion:     "1.2.3",
+		ConfigHash:      "0123456789abcdef",
+		FirstDrawNumber: 1170,
+		LastDrawNumber:  1199,
+		DrawCount:       30,
+		Algorithms: []entity.AlgorithmProvenance{
+			{Name: "frequency_analysis", Weight: 0.5, Parameters: map[string]string{"recency_half_life": "10"}},
+		},
+	}
+	require.NoError(t, store.SaveEnsemble(ctx, ensemble))
+
+	loaded, err := store.FindEnsembleByID(ctx, ensemble.ID)
+	require.NoError(t, err)
+	assert.Equal(t, ensemble.Provenance, loaded.Provenance)
+}
+
+func TestPredictionJSONStorage_FindByDateRange(t *testing.T) {
+	store := newTestEnsembleStorage(t)
+	ctx := context.Background()
+
+	numbers := valueobject.MustNewNumbers([]int{3, 9, 17, 22, 30, 41})
+	var predictions []*entity.Prediction
+	for day := 1; day <= 3; day++ {
+		pred, err := entity.NewPrediction(valueobject.Mega645, "frequency_analysis", numbers, 0.5, time.Time{})
+		require.NoError(t, err)
+		pred.GeneratedAt = time.Date(2026, 1, day, 0, 0, 0, 0, time.UTC)
+		require.NoError(t, store.Save(ctx, pred))
+		predictions = append(predictions, pred)
+	}
+
+	// The range's end is excluded
+	found, err := store.FindByDateRange(ctx, valueobject.Mega645, valueobject.MustNewDateRange(
+		time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC),
+	))
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, predictions[1].ID, found[0].ID)
+}
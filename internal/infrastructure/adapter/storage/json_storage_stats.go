@@ -2,7 +2,6 @@ package storage
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -17,6 +16,7 @@ import (
 // StatsJSONStorage implements repository.StatsRepository
 type StatsJSONStorage struct {
 	basePath string
+	corrupt  corruptFiles // Files skipped by read loops
 	mu       sync.RWMutex
 }
 
@@ -36,11 +36,22 @@ func NewStatsJSONStorage(basePath string) (*StatsJSONStorage, error) {
 	}, nil
 }
 
+// SkippedCorruptFiles returns how many unreadable stats files read
+// operations have skipped since the storage was created. Each skipped file is
+// also logged at warn level.
+func (s *StatsJSONStorage) SkippedCorruptFiles() int64 {
+	return s.corrupt.count()
+}
+
 // Save saves algorithm statistics
 func (s *StatsJSONStorage) Save(ctx context.Context, stats *entity.AlgorithmStats) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if err := os.MkdirAll(s.getGameTypeDir("stats", stats.GameType), 0755); err != nil {
+		return fmt.Errorf("failed to create stats directory: %w", err)
+	}
+
 	filename := s.getStatsFilename(stats.GameType, stats.AlgorithmName)
 	return s.saveToFile(filename, stats)
 }
@@ -89,6 +100,7 @@ func (s *StatsJSONStorage) FindAll(ctx context.Context) ([]*entity.AlgorithmStat
 			var stats entity.AlgorithmStats
 			filename := filepath.Join(dir, file.Name())
 			if err := s.loadFromFile(filename, &stats); err != nil {
+				s.corrupt.skip(filename, err)
 				continue
 			}
 
@@ -109,6 +121,9 @@ func (s *StatsJSONStorage) FindByGameType(
 
 	dir := s.getGameTypeDir("stats", gameType)
 	files, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return []*entity.AlgorithmStats{}, nil
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -122,6 +137,7 @@ func (s *StatsJSONStorage) FindByGameType(
 		var stats entity.AlgorithmStats
 		filename := filepath.Join(dir, file.Name())
 		if err := s.loadFromFile(filename, &stats); err != nil {
+			s.corrupt.skip(filename, err)
 			continue
 		}
 
@@ -153,6 +169,7 @@ func (s *StatsJSONStorage) FindActive(ctx context.Context) ([]*entity.AlgorithmS
 			var stats entity.AlgorithmStats
 			filename := filepath.Join(dir, file.Name())
 			if err := s.loadFromFile(filename, &stats); err != nil {
+				s.corrupt.skip(filename, err)
 				continue
 			}
 
@@ -257,19 +274,11 @@ func (s *StatsJSONStorage) getGameTypeDir(subDir string, gameType valueobject.Ga
 }
 
 func (s *StatsJSONStorage) saveToFile(filename string, data interface{}) error {
-	jsonData, err := json.MarshalIndent(data, "", "  ")
-	if err != nil {
-		return err
-	}
-	return os.WriteFile(filename, jsonData, 0644)
+	return writeJSONFile(filename, data)
 }
 
 func (s *StatsJSONStorage) loadFromFile(filename string, data interface{}) error {
-	file, err := os.ReadFile(filename)
-	if err != nil {
-		return err
-	}
-	return json.Unmarshal(file, data)
+	return readJSONFile(filename, data)
 }
 
 // Ensure StatsJSONStorage implements repository.StatsRepository
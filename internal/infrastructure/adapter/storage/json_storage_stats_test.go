@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func TestStatsJSONStorage_SaveToFreshDirectory(t *testing.T) {
+	store, err := NewStatsJSONStorage(t.TempDir())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	stored, err := store.FindByGameType(ctx, valueobject.Mega645)
+	require.NoError(t, err, "no stats saved yet is not an error")
+	assert.Empty(t, stored)
+
+	stats, err := entity.NewAlgorithmStats("frequency_analysis", valueobject.Mega645, 0.4)
+	require.NoError(t, err)
+	require.NoError(t, store.Save(ctx, stats))
+
+	stored, err = store.FindByGameType(ctx, valueobject.Mega645)
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+	assert.Equal(t, "frequency_analysis", stored[0].AlgorithmName)
+	assert.Equal(t, 0.4, stored[0].Weight)
+}
@@ -0,0 +1,348 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func newTestDraw(t *testing.T, gameType valueobject.GameType, drawNumber int, nums []int) *entity.Draw {
+	t.Helper()
+
+	draw, err := entity.NewDraw(
+		gameType,
+		drawNumber,
+		valueobject.MustNewNumbers(nums),
+		time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC),
+		0,
+		0,
+	)
+	require.NoError(t, err)
+	return draw
+}
+
+func TestJSONStorage_Save_ReplacesExistingDrawNumber(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewJSONStorage(dir)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	original := newTestDraw(t, valueobject.Power655, 1295, []int{13, 21, 31, 34, 48, 55})
+	require.NoError(t, store.Save(ctx, original))
+
+	rescraped := newTestDraw(t, valueobject.Power655, 1295, []int{13, 21, 31, 34, 48, 55})
+	require.NoError(t, store.Save(ctx, rescraped))
+
+	count, err := store.Count(ctx, valueobject.Power655)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	assert.Equal(t, original.ID, rescraped.ID)
+
+	// Identical results are not corrections
+	_, err = os.Stat(filepath.Join(dir, "corrections.log"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestJSONStorage_Save_LooksUpCanonicalFileFirst(t *testing.T) {
+	store, err := NewJSONStorage(t.TempDir())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// A draw stored under a legacy ID is still found and replaced in place
+	legacy := newTestDraw(t, valueobject.Mega645, 7, []int{1, 2, 3, 4, 5, 6})
+	legacy.ID = "legacy-7"
+	require.NoError(t, store.Save(ctx, legacy))
+	rescraped := newTestDraw(t, valueobject.Mega645, 7, []int{1, 2, 3, 4, 5, 6})
+	require.NoError(t, store.Save(ctx, rescraped))
+	assert.Equal(t, "legacy-7", rescraped.ID)
+
+	// Files named for other draws aren't read when saving a new one
+	drawDir := store.getGameTypeDir("draws", valueobject.Mega645)
+	other := entity.DrawID(valueobject.Mega645, 8) + ".json"
+	require.NoError(t, os.WriteFile(filepath.Join(drawDir, other), []byte(`{"id": "mega_00008", "draw_num`), 0644))
+	require.NoError(t, store.Save(ctx, newTestDraw(t, valueobject.Mega645, 9, []int{7, 8, 9, 10, 11, 12})))
+	assert.Zero(t, store.SkippedCorruptFiles())
+
+	found, err := store.FindByGameTypeAndDrawNumber(ctx, valueobject.Mega645, 9)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DrawID(valueobject.Mega645, 9), found.ID)
+}
+
+func TestCanonicalDrawNumber(t *testing.T) {
+	n, ok := canonicalDrawNumber(valueobject.Power655, entity.DrawID(valueobject.Power655, 1295)+".json")
+	assert.True(t, ok)
+	assert.Equal(t, 1295, n)
+
+	for _, name := range []string{"legacy-7.json", entity.DrawID(valueobject.Mega645, 12) + ".json", entity.DrawID(valueobject.Power655, 12)} {
+		_, ok := canonicalDrawNumber(valueobject.Power655, name)
+		assert.False(t, ok, name)
+	}
+}
+
+func TestJSONStorage_Save_RecordsCorrection(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewJSONStorage(dir)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	original := newTestDraw(t, valueobject.Mega645, 1201, []int{3, 9, 17, 22, 30, 41})
+	require.NoError(t, store.Save(ctx, original))
+
+	corrected := newTestDraw(t, valueobject.Mega645, 1201, []int{3, 9, 17, 22, 30, 44})
+	require.False(t, original.Equals(corrected))
+	require.NoError(t, store.Save(ctx, corrected))
+
+	// The stored draw now holds the corrected numbers
+	stored, err := store.FindByGameTypeAndDrawNumber(ctx, valueobject.Mega645, 1201)
+	require.NoError(t, err)
+	assert.Equal(t, corrected.Numbers, stored.Numbers)
+
+	count, err := store.Count(ctx, valueobject.Mega645)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	// The old version is archived
+	archived, err := filepath.Glob(filepath.Join(dir, "draws", "mega_6_45", "archive", "*.json"))
+	require.NoError(t, err)
+	require.Len(t, archived, 1)
+
+	var old entity.Draw
+	require.NoError(t, store.loadFromFile(archived[0], &old))
+	assert.Equal(t, original.Numbers, old.Numbers)
+
+	// And the change is logged
+	logData, err := os.ReadFile(filepath.Join(dir, "corrections.log"))
+	require.NoError(t, err)
+	assert.Contains(t, string(logData), "draw #1201")
+	assert.Contains(t, string(logData), "[03, 09, 17, 22, 30, 41] -> [03, 09, 17, 22, 30, 44]")
+}
+
+func TestJSONStorage_SampleDraws(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewJSONStorage(dir)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	for n := 1; n <= 3; n++ {
+		sample := newTestDraw(t, valueobject.Mega645, n, []int{1, 2, 3, 4, 5, 6 + n})
+		sample.Sample = true
+		require.NoError(t, store.Save(ctx, sample))
+	}
+
+	// A real result replaces the sample with its draw number, no correction
+	actual := newTestDraw(t, valueobject.Mega645, 2, []int{10, 20, 30, 40, 41, 42})
+	require.NoError(t, store.Save(ctx, actual))
+	_, err = os.Stat(filepath.Join(dir, "corrections.log"))
+	assert.True(t, os.IsNotExist(err))
+
+	deleted, err := store.DeleteSampleDraws(ctx, valueobject.Mega645)
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+
+	remaining, err := store.FindLatest(ctx, valueobject.Mega645, 10)
+	require.NoError(t, err)
+	require.Len(t, remaining, 1)
+	assert.Equal(t, actual.Numbers, remaining[0].Numbers)
+	assert.False(t, remaining[0].Sample)
+}
+
+func TestJSONStorage_Count_NoDrawsStored(t *testing.T) {
+	store, err := NewJSONStorage(t.TempDir())
+	require.NoError(t, err)
+
+	count, err := store.Count(context.Background(), valueobject.Power655)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestJSONStorage_FindLatest_SkipsAndCountsCorruptFiles(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewJSONStorage(dir)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	for i, nums := range [][]int{{1, 2, 3, 4, 5, 6}, {7, 8, 9, 10, 11, 12}, {13, 14, 15, 16, 17, 18}} {
+		require.NoError(t, store.Save(ctx, newTestDraw(t, valueobject.Mega645, 100+i, nums)))
+	}
+
+	// A draw file cut short mid-write
+	drawDir := store.getGameTypeDir("draws", valueobject.Mega645)
+	require.NoError(t, os.WriteFile(filepath.Join(drawDir, "truncated.json"), []byte(`{"id": "truncated", "draw_num`), 0644))
+
+	draws, err := store.FindLatest(ctx, valueobject.Mega645, 10)
+	require.NoError(t, err)
+	assert.Len(t, draws, 3)
+	assert.Equal(t, int64(1), store.SkippedCorruptFiles())
+
+	draws, err = store.FindByDrawNumberRange(ctx, valueobject.Mega645, 100, 102)
+	require.NoError(t, err)
+	assert.Len(t, draws, 3)
+	assert.Equal(t, int64(2), store.SkippedCorruptFiles())
+}
+
+func TestIsPartialWrite(t *testing.T) {
+	var draw entity.Draw
+	assert.True(t, isPartialWrite(json.Unmarshal([]byte(`{"id": "x", "draw_num`), &draw)))
+	assert.True(t, isPartialWrite(json.Unmarshal([]byte(``), &draw)))
+	assert.False(t, isPartialWrite(json.Unmarshal([]byte(`{"id": }`), &draw)))
+	assert.False(t, isPartialWrite(os.ErrNotExist))
+}
+
+func TestJSONStorage_Save_Concurrent(t *testing.T) {
+	store, err := NewJSONStorage(t.TempDir())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// 100 distinct draws, each saved twice at the same time
+	var wg sync.WaitGroup
+	for i := 0; i < 200; i++ {
+		wg.Add(1)
+		go func(drawNumber int) {
+			defer wg.Done()
+			draw := newTestDraw(t, valueobject.Mega645, drawNumber, []int{1, 2, 3, 4, 5, 6})
+			assert.NoError(t, store.Save(ctx, draw))
+		}(i%100 + 1)
+	}
+	wg.Wait()
+
+	count, err := store.Count(ctx, valueobject.Mega645)
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), count)
+
+	draws, err := store.FindLatest(ctx, valueobject.Mega645, 200)
+	require.NoError(t, err)
+	assert.Len(t, draws, 100)
+	assert.Zero(t, store.SkippedCorruptFiles())
+
+	// Each draw's lock is dropped once its saves are done
+	assert.Empty(t, store.drawLocks.locks)
+}
+
+func TestJSONStorage_ConcurrentGameTypes(t *testing.T) {
+	store, err := NewJSONStorage(t.TempDir())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	for i := 1; i <= 20; i++ {
+		require.NoError(t, store.Save(ctx, newTestDraw(t, valueobject.Mega645, i, []int{1, 2, 3, 4, 5, 6})))
+	}
+
+	// Mega reads while Power draws are written
+	var wg sync.WaitGroup
+	for i := 1; i <= 50; i++ {
+		wg.Add(2)
+		go func(drawNumber int) {
+			defer wg.Done()
+			draw := newTestDraw(t, valueobject.Power655, drawNumber, []int{10, 20, 30, 40, 50, 55})
+			assert.NoError(t, store.Save(ctx, draw))
+		}(i)
+		go func() {
+			defer wg.Done()
+			draws, err := store.FindLatest(ctx, valueobject.Mega645, 100)
+			assert.NoError(t, err)
+			assert.Len(t, draws, 20)
+		}()
+	}
+	wg.Wait()
+
+	count, err := store.Count(ctx, valueobject.Power655)
+	require.NoError(t, err)
+	assert.Equal(t, int64(50), count)
+	assert.Zero(t, store.SkippedCorruptFiles())
+}
+
+func TestJSONStorage_GameTypesDoNotContend(t *testing.T) {
+	store, err := NewJSONStorage(t.TempDir())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// Hold Mega's lock as a long scan or DeleteAll would
+	mega := store.gameLocks.get(valueobject.Mega645)
+	mega.Lock()
+	defer mega.Unlock()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- store.Save(ctx, newTestDraw(t, valueobject.Power655, 1, []int{10, 20, 30, 40, 50, 55}))
+	}()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Power save blocked on the Mega lock")
+	}
+}
+
+func TestJSONStorage_SaveBatch_ContinuesPastFailures(t *testing.T) {
+	store, err := NewJSONStorage(t.TempDir())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// A NaN jackpot can't be encoded as JSON
+	malformed := newTestDraw(t, valueobject.Mega645, 2, []int{7, 8, 9, 10, 11, 12})
+	malformed.Jackpot = math.NaN()
+
+	err = store.SaveBatch(ctx, []*entity.Draw{
+		newTestDraw(t, valueobject.Mega645, 1, []int{1, 2, 3, 4, 5, 6}),
+		malformed,
+		newTestDraw(t, valueobject.Mega645, 3, []int{13, 14, 15, 16, 17, 18}),
+		nil,
+		newTestDraw(t, valueobject.Mega645, 4, []int{19, 20, 21, 22, 23, 24}),
+	})
+
+	var batchErr *BatchSaveError
+	require.ErrorAs(t, err, &batchErr)
+	assert.Equal(t, 3, batchErr.Saved)
+	assert.Equal(t, 5, batchErr.Total)
+	assert.Len(t, batchErr.Errors, 2)
+	assert.ErrorContains(t, err, "draw 2:")
+
+	count, err := store.Count(ctx, valueobject.Mega645)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+
+	for _, drawNumber := range []int{1, 3, 4} {
+		_, err := store.FindByGameTypeAndDrawNumber(ctx, valueobject.Mega645, drawNumber)
+		assert.NoError(t, err, "draw %d", drawNumber)
+	}
+}
+
+func TestJSONStorage_SaveBatch_StopsWhenCancelled(t *testing.T) {
+	store, err := NewJSONStorage(t.TempDir())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err = store.SaveBatch(ctx, []*entity.Draw{
+		newTestDraw(t, valueobject.Mega645, 1, []int{1, 2, 3, 4, 5, 6}),
+		newTestDraw(t, valueobject.Mega645, 2, []int{7, 8, 9, 10, 11, 12}),
+	})
+
+	var batchErr *BatchSaveError
+	require.ErrorAs(t, err, &batchErr)
+	assert.Zero(t, batchErr.Saved)
+	assert.Equal(t, 2, batchErr.Total)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	count, err := store.Count(context.Background(), valueobject.Mega645)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestJSONStorage_Paging(t *testing.T) {
+	store, err := NewJSONStorage(t.TempDir())
+	require.NoError(t, err)
+	testDrawPaging(t, store)
+}
@@ -0,0 +1,492 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/repository"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+// JSONLDrawStorage implements repository.DrawRepository with one append-only
+// JSON lines file per game type, draws/<game_type>.jsonl. Each save appends
+// the draw; a later line for the same draw number supersedes earlier ones.
+// A game's file is read once, into an in-memory index by draw number, ID and
+// date that serves every query afterwards, so reads don't touch the disk.
+type JSONLDrawStorage struct {
+	basePath string
+	corrupt  corruptFiles // Lines skipped when loading
+	mu       sync.RWMutex
+	indexes  map[valueobject.GameType]*drawIndex
+}
+
+// drawIndex holds a game's current draws
+type drawIndex struct {
+	byNumber map[int]*entity.Draw
+	byID     map[string]*entity.Draw
+	byDate   []*entity.Draw // Newest first
+}
+
+// NewJSONLDrawStorage creates a JSON lines draw storage under basePath
+func NewJSONLDrawStorage(basePath string) (*JSONLDrawStorage, error) {
+	if err := os.MkdirAll(filepath.Join(basePath, "draws"), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create draws directory: %w", err)
+	}
+	return &JSONLDrawStorage{
+		basePath: basePath,
+		indexes:  make(map[valueobject.GameType]*drawIndex),
+	}, nil
+}
+
+// SkippedCorruptLines returns how many unreadable lines loading has skipped
+// since the storage was created
+func (s *JSONLDrawStorage) SkippedCorruptLines() int64 {
+	return s.corrupt.count()
+}
+
+// Save appends a draw to its game's file. A stored draw with the same draw
+// number is superseded, keeping its ID; if the result changed, the change is
+// logged and the old line stays in the file as history.
+func (s *JSONLDrawStorage) Save(ctx context.Context, draw *entity.Draw) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	index, err := s.save(draw)
+	if err != nil {
+		return err
+	}
+	index.sort()
+	return nil
+}
+
+// SaveBatch saves multiple draws. Every draw is attempted even if some fail;
+// the failures are returned together as a *BatchSaveError.
+func (s *JSONLDrawStorage) SaveBatch(ctx context.Context, draws []*entity.Draw) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var errs []error
+	touched := make(map[*drawIndex]bool)
+	for i, draw := range draws {
+		if draw == nil {
+			errs = append(errs, fmt.Errorf("draw at index %d is nil", i))
+			continue
+		}
+		index, err := s.save(draw)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("draw %d: %w", draw.DrawNumber, err))
+			continue
+		}
+		touched[index] = true
+	}
+	for index := range touched {
+		index.sort()
+	}
+
+	if len(errs) > 0 {
+		return &BatchSaveError{
+			Saved:  len(draws) - len(errs),
+			Total:  len(draws),
+			Errors: errs,
+		}
+	}
+	return nil
+}
+
+// FindByID finds a draw by ID
+func (s *JSONLDrawStorage) FindByID(ctx context.Context, id string) (*entity.Draw, error) {
+	for _, gameType := range valueobject.AllGameTypes() {
+		var found *entity.Draw
+		err := s.read(gameType, func(index *drawIndex) {
+			found = copyDraw(index.byID[id])
+		})
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+	}
+	return nil, fmt.Errorf("draw with ID %s not found", id)
+}
+
+// FindByGameTypeAndDrawNumber finds a draw by game type and draw number
+func (s *JSONLDrawStorage) FindByGameTypeAndDrawNumber(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	drawNumber int,
+) (*entity.Draw, error) {
+	var found *entity.Draw
+	err := s.read(gameType, func(index *drawIndex) {
+		found = copyDraw(index.byNumber[drawNumber])
+	})
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, fmt.Errorf("draw number %d for game type %s: %w", drawNumber, gameType, repository.ErrNotFound)
+	}
+	return found, nil
+}
+
+// FindLatest finds the most recent draws, newest first
+func (s *JSONLDrawStorage) FindLatest(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	limit int,
+) ([]*entity.Draw, error) {
+	var draws []*entity.Draw
+	err := s.read(gameType, func(index *drawIndex) {
+		draws = copyDraws(index.byDate[:min(max(limit, 0), len(index.byDate))])
+	})
+	return draws, err
+}
+
+// FindByDateRange finds draws within a date range, newest first
+func (s *JSONLDrawStorage) FindByDateRange(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	dateRange valueobject.DateRange,
+) ([]*entity.Draw, error) {
+	var draws []*entity.Draw
+	err := s.read(gameType, func(index *drawIndex) {
+		// byDate is newest first: skip the draws after the range, then take
+		// draws until one falls before it
+		start := sort.Search(len(index.byDate), func(i int) bool {
+			return !index.byDate[i].DrawDate.After(dateRange.EndDate)
+		})
+		draws = make([]*entity.Draw, 0)
+		for _, draw := range index.byDate[start:] {
+			if draw.DrawDate.Before(dateRange.StartDate) {
+				break
+			}
+			draws = append(draws, copyDraw(draw))
+		}
+	})
+	return draws, err
+}
+
+// FindByDrawNumberRange finds draws within a draw number range, newest first
+func (s *JSONLDrawStorage) FindByDrawNumberRange(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	startDrawNumber int,
+	endDrawNumber int,
+) ([]*entity.Draw, error) {
+	var draws []*entity.Draw
+	err := s.read(gameType, func(index *drawIndex) {
+		draws = make([]*entity.Draw, 0)
+		for _, draw := range index.byDate {
+			if draw.DrawNumber >= startDrawNumber && draw.DrawNumber <= endDrawNumber {
+				draws = append(draws, copyDraw(draw))
+			}
+		}
+	})
+	return draws, err
+}
+
+// FindPage finds up to limit draws, newest first, after skipping the offset
+// newest
+func (s *JSONLDrawStorage) FindPage(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	offset int,
+	limit int,
+) ([]*entity.Draw, error) {
+	if offset < 0 || limit < 0 {
+		return nil, fmt.Errorf("offset and limit cannot be negative, got %d and %d", offset, limit)
+	}
+
+	var draws []*entity.Draw
+	err := s.read(gameType, func(index *drawIndex) {
+		start := min(offset, len(index.byDate))
+		draws = copyDraws(index.byDate[start:min(start+limit, len(index.byDate))])
+	})
+	return draws, err
+}
+
+// ForEachDraw calls fn with a copy of each draw, oldest first. The draws
+// are those stored when it starts; the lock isn't held while fn runs, so fn
+// may save draws.
+func (s *JSONLDrawStorage) ForEachDraw(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	fn func(draw *entity.Draw) error,
+) error {
+	var snapshot []*entity.Draw
+	err := s.read(gameType, func(index *drawIndex) {
+		snapshot = index.byDate
+	})
+	if err != nil {
+		return err
+	}
+	return forEachNewestLast(ctx, snapshot, fn)
+}
+
+// Count returns the total number of draws for a game type
+func (s *JSONLDrawStorage) Count(ctx context.Context, gameType valueobject.GameType) (int64, error) {
+	var count int64
+	err := s.read(gameType, func(index *drawIndex) {
+		count = int64(len(index.byNumber))
+	})
+	return count, err
+}
+
+// DeleteAll deletes all draws for a game type
+func (s *JSONLDrawStorage) DeleteAll(ctx context.Context, gameType valueobject.GameType) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.Remove(s.filename(gameType)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	s.indexes[gameType] = newDrawIndex()
+	return nil
+}
+
+// GetLatestDrawNumber returns the highest draw number
+func (s *JSONLDrawStorage) GetLatestDrawNumber(ctx context.Context, gameType valueobject.GameType) (int, error) {
+	latest := 0
+	err := s.read(gameType, func(index *drawIndex) {
+		for drawNumber := range index.byNumber {
+			latest = max(latest, drawNumber)
+		}
+	})
+	if err != nil {
+		return 0, err
+	}
+	if latest == 0 {
+		return 0, fmt.Errorf("no draws found for game type %s", gameType)
+	}
+	return latest, nil
+}
+
+// Compact rewrites a game's file with only its current draws, dropping
+// superseded lines. The file is replaced atomically.
+func (s *JSONLDrawStorage) Compact(gameType valueobject.GameType) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	index, err := s.index(gameType)
+	if err != nil {
+		return err
+	}
+	return s.rewrite(gameType, index)
+}
+
+// read runs fn with a game's index under the read lock, loading the index
+// first if needed
+func (s *JSONLDrawStorage) read(gameType valueobject.GameType, fn func(index *drawIndex)) error {
+	s.mu.RLock()
+	index, ok := s.indexes[gameType]
+	if ok {
+		defer s.mu.RUnlock()
+		fn(index)
+		return nil
+	}
+	s.mu.RUnlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	index, err := s.index(gameType)
+	if err != nil {
+		return err
+	}
+	fn(index)
+	return nil
+}
+
+// index returns a game's index, loading it from the file on first use.
+// Callers must hold the write lock.
+func (s *JSONLDrawStorage) index(gameType valueobject.GameType) (*drawIndex, error) {
+	if index, ok := s.indexes[gameType]; ok {
+		return index, nil
+	}
+
+	index, lines, err := s.load(gameType)
+	if err != nil {
+		return nil, err
+	}
+	s.indexes[gameType] = index
+
+	// Keep the file from growing without bound as draws are re-saved
+	if superseded := lines - len(index.byNumber); superseded > len(index.byNumber) {
+		if err := s.rewrite(gameType, index); err != nil {
+			logger.Warn("Failed to compact draw file",
+				zap.String("game_type", string(gameType)),
+				zap.Error(err),
+			)
+		}
+	}
+	return index, nil
+}
+
+// load reads a game's file into a fresh index, returning it with the number
+// of lines read. Unreadable lines are skipped and counted like corrupt files.
+func (s *JSONLDrawStorage) load(gameType valueobject.GameType) (*drawIndex, int, error) {
+	index := newDrawIndex()
+	filename := s.filename(gameType)
+	file, err := os.Open(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return index, 0, nil
+		}
+		return nil, 0, err
+	}
+	defer file.Close()
+
+	lines := 0
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		lines++
+
+		var draw entity.Draw
+		if err := json.Unmarshal(line, &draw); err != nil {
+			s.corrupt.skip(fmt.Sprintf("%s:%d", filename, lines), err)
+			continue
+		}
+		index.put(&draw)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to read %s: %w", filename, err)
+	}
+
+	index.sort()
+	return index, lines, nil
+}
+
+// save appends a draw and adds it to its game's index, which it returns.
+// Callers must hold the write lock and sort the index afterwards.
+func (s *JSONLDrawStorage) save(draw *entity.Draw) (*drawIndex, error) {
+	index, err := s.index(draw.GameType)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing, ok := index.byNumber[draw.DrawNumber]; ok {
+		if !existing.Equals(draw) {
+			logger.Warn("Draw result changed since it was stored, recording correction",
+				zap.String("game_type", string(draw.GameType)),
+				zap.Int("draw_number", draw.DrawNumber),
+				zap.String("old_numbers", existing.Numbers.String()),
+				zap.String("new_numbers", draw.Numbers.String()),
+			)
+		}
+		draw.ID = existing.ID
+	}
+
+	line, err := json.Marshal(draw)
+	if err != nil {
+		return nil, err
+	}
+	if err := appendLine(s.filename(draw.GameType), line); err != nil {
+		return nil, fmt.Errorf("failed to append draw: %w", err)
+	}
+
+	index.put(copyDraw(draw))
+	return index, nil
+}
+
+// rewrite replaces a game's file with one line per draw in the index, oldest
+// first. Callers must hold the write lock.
+func (s *JSONLDrawStorage) rewrite(gameType valueobject.GameType, index *drawIndex) error {
+	var buf bytes.Buffer
+	for i := len(index.byDate) - 1; i >= 0; i-- {
+		line, err := json.Marshal(index.byDate[i])
+		if err != nil {
+			return err
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	return writeFileAtomic(s.filename(gameType), buf.Bytes(), false)
+}
+
+func (s *JSONLDrawStorage) filename(gameType valueobject.GameType) string {
+	return filepath.Join(s.basePath, "draws", strings.ToLower(string(gameType))+".jsonl")
+}
+
+func newDrawIndex() *drawIndex {
+	return &drawIndex{
+		byNumber: make(map[int]*entity.Draw),
+		byID:     make(map[string]*entity.Draw),
+	}
+}
+
+// put adds a draw, replacing the one with the same draw number. Call sort
+// afterwards.
+func (i *drawIndex) put(draw *entity.Draw) {
+	if old, ok := i.byNumber[draw.DrawNumber]; ok {
+		delete(i.byID, old.ID)
+	}
+	i.byNumber[draw.DrawNumber] = draw
+	i.byID[draw.ID] = draw
+}
+
+// sort rebuilds byDate from byNumber, newest first, ties broken by draw
+// number
+func (i *drawIndex) sort() {
+	i.byDate = make([]*entity.Draw, 0, len(i.byNumber))
+	for _, draw := range i.byNumber {
+		i.byDate = append(i.byDate, draw)
+	}
+	sort.Slice(i.byDate, func(a, b int) bool {
+		if !i.byDate[a].DrawDate.Equal(i.byDate[b].DrawDate) {
+			return i.byDate[a].DrawDate.After(i.byDate[b].DrawDate)
+		}
+		return i.byDate[a].DrawNumber > i.byDate[b].DrawNumber
+	})
+}
+
+// forEachNewestLast calls fn with a copy of each draw of byDate, a newest
+// first index slice, from the oldest on. Indexes replace byDate on sort
+// rather than reorder it, so the slice stays valid without the lock.
+func forEachNewestLast(ctx context.Context, byDate []*entity.Draw, fn func(draw *entity.Draw) error) error {
+	for i := len(byDate) - 1; i >= 0; i-- {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(copyDraw(byDate[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyDraw returns a copy of draw that callers may modify without touching
+// the index, or nil for a nil draw
+func copyDraw(draw *entity.Draw) *entity.Draw {
+	if draw == nil {
+		return nil
+	}
+	copied := *draw
+	copied.Numbers = append(valueobject.Numbers(nil), draw.Numbers...)
+	return &copied
+}
+
+func copyDraws(draws []*entity.Draw) []*entity.Draw {
+	copied := make([]*entity.Draw, len(draws))
+	for i, draw := range draws {
+		copied[i] = copyDraw(draw)
+	}
+	return copied
+}
+
+// Ensure JSONLDrawStorage implements repository.DrawRepository
+var _ repository.DrawRepository = (*JSONLDrawStorage)(nil)
@@ -0,0 +1,260 @@
+package storage
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/repository"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func newDatedTestDraw(t *testing.T, drawNumber int, nums []int) *entity.Draw {
+	t.Helper()
+	draw := newTestDraw(t, valueobject.Mega645, drawNumber, nums)
+	draw.DrawDate = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, drawNumber)
+	return draw
+}
+
+func countLines(t *testing.T, filename string) int {
+	t.Helper()
+	file, err := os.Open(filename)
+	require.NoError(t, err)
+	defer file.Close()
+
+	lines := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lines++
+	}
+	return lines
+}
+
+func TestJSONLDrawStorage_SaveAndQuery(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewJSONLDrawStorage(dir)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	var draws []*entity.Draw
+	for n := 1; n <= 5; n++ {
+		draws = append(draws, newDatedTestDraw(t, n, []int{n, 10, 20, 30, 40, 45}))
+	}
+	require.NoError(t, store.SaveBatch(ctx, draws))
+
+	latest, err := store.FindLatest(ctx, valueobject.Mega645, 2)
+	require.NoError(t, err)
+	require.Len(t, latest, 2)
+	assert.Equal(t, 5, latest[0].DrawNumber)
+	assert.Equal(t, 4, latest[1].DrawNumber)
+
+	inRange, err := store.FindByDateRange(ctx, valueobject.Mega645, valueobject.DateRange{
+		StartDate: draws[1].DrawDate,
+		EndDate:   draws[3].DrawDate,
+	})
+	require.NoError(t, err)
+	require.Len(t, inRange, 3)
+	assert.Equal(t, 4, inRange[0].DrawNumber)
+	assert.Equal(t, 2, inRange[2].DrawNumber)
+
+	byNumber, err := store.FindByDrawNumberRange(ctx, valueobject.Mega645, 2, 3)
+	require.NoError(t, err)
+	assert.Len(t, byNumber, 2)
+
+	found, err := store.FindByID(ctx, draws[2].ID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, found.DrawNumber)
+
+	latestNumber, err := store.GetLatestDrawNumber(ctx, valueobject.Mega645)
+	require.NoError(t, err)
+	assert.Equal(t, 5, latestNumber)
+
+	// Returned draws are copies; changing them leaves the store alone
+	latest[0].Numbers[0] = 99
+	again, err := store.FindByGameTypeAndDrawNumber(ctx, valueobject.Mega645, 5)
+	require.NoError(t, err)
+	assert.Equal(t, 5, again.Numbers[0])
+
+	count, err := store.Count(ctx, valueobject.Power655)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestJSONLDrawStorage_ResaveSupersedesAndReloads(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewJSONLDrawStorage(dir)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	original := newDatedTestDraw(t, 7, []int{1, 2, 3, 4, 5, 6})
+	require.NoError(t, store.Save(ctx, original))
+	corrected := newDatedTestDraw(t, 7, []int{1, 2, 3, 4, 5, 7})
+	require.NoError(t, store.Save(ctx, corrected))
+	assert.Equal(t, original.ID, corrected.ID, "the stored ID is kept")
+
+	filename := filepath.Join(dir, "draws", "mega_6_45.jsonl")
+	assert.Equal(t, 2, countLines(t, filename), "saves only append")
+
+	// A new store loads the latest line for the draw number
+	reopened, err := NewJSONLDrawStorage(dir)
+	require.NoError(t, err)
+	count, err := reopened.Count(ctx, valueobject.Mega645)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	draw, err := reopened.FindByGameTypeAndDrawNumber(ctx, valueobject.Mega645, 7)
+	require.NoError(t, err)
+	assert.Equal(t, valueobject.Numbers{1, 2, 3, 4, 5, 7}, draw.Numbers)
+
+	require.NoError(t, reopened.Compact(valueobject.Mega645))
+	assert.Equal(t, 1, countLines(t, filename))
+}
+
+func TestJSONLDrawStorage_SkipsCorruptLinesAndCompactsOnLoad(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewJSONLDrawStorage(dir)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	draw := newDatedTestDraw(t, 1, []int{1, 2, 3, 4, 5, 6})
+	for i := 0; i < 3; i++ {
+		require.NoError(t, store.Save(ctx, draw))
+	}
+	filename := filepath.Join(dir, "draws", "mega_6_45.jsonl")
+	file, err := os.OpenFile(filename, os.O_APPEND|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	_, err = file.WriteString(`{"game_type":"MEGA_6_45","draw_nu`)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	// Three lines supersede each other and one is cut short: loading keeps
+	// the one draw and rewrites the file with just it
+	reopened, err := NewJSONLDrawStorage(dir)
+	require.NoError(t, err)
+	count, err := reopened.Count(ctx, valueobject.Mega645)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	assert.Equal(t, int64(1), reopened.SkippedCorruptLines())
+	assert.Equal(t, 1, countLines(t, filename))
+}
+
+func TestJSONLDrawStorage_SaveAfterPartialLineKeepsDraw(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewJSONLDrawStorage(dir)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, store.Save(ctx, newDatedTestDraw(t, 1, []int{1, 2, 3, 4, 5, 6})))
+	filename := filepath.Join(dir, "draws", "mega_6_45.jsonl")
+	file, err := os.OpenFile(filename, os.O_APPEND|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	_, err = file.WriteString(`{"game_type":"MEGA_6_45","draw_nu`)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	// The save interrupted mid-line is followed by another, as after a crash
+	require.NoError(t, store.Save(ctx, newDatedTestDraw(t, 2, []int{7, 8, 9, 10, 11, 12})))
+
+	reopened, err := NewJSONLDrawStorage(dir)
+	require.NoError(t, err)
+	draw, err := reopened.FindByGameTypeAndDrawNumber(ctx, valueobject.Mega645, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []int{7, 8, 9, 10, 11, 12}, draw.Numbers.AsSlice())
+	count, err := reopened.Count(ctx, valueobject.Mega645)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+	assert.Equal(t, int64(1), reopened.SkippedCorruptLines())
+}
+
+func TestJSONLDrawStorage_DeleteAll(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewJSONLDrawStorage(dir)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, store.Save(ctx, newDatedTestDraw(t, 1, []int{1, 2, 3, 4, 5, 6})))
+	require.NoError(t, store.DeleteAll(ctx, valueobject.Mega645))
+
+	count, err := store.Count(ctx, valueobject.Mega645)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+	_, err = store.GetLatestDrawNumber(ctx, valueobject.Mega645)
+	assert.Error(t, err)
+
+	reopened, err := NewJSONLDrawStorage(dir)
+	require.NoError(t, err)
+	count, err = reopened.Count(ctx, valueobject.Mega645)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+// testDrawPaging checks FindPage and ForEachDraw of an empty draw repository
+func testDrawPaging(t *testing.T, repo repository.DrawRepository) {
+	t.Helper()
+	ctx := context.Background()
+
+	for n := 1; n <= 5; n++ {
+		require.NoError(t, repo.Save(ctx, newDatedTestDraw(t, n, []int{n, 10, 20, 30, 40, 45})))
+	}
+
+	drawNumbers := func(draws []*entity.Draw) []int {
+		numbers := make([]int, len(draws))
+		for i, draw := range draws {
+			numbers[i] = draw.DrawNumber
+		}
+		return numbers
+	}
+
+	page, err := repo.FindPage(ctx, valueobject.Mega645, 0, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []int{5, 4}, drawNumbers(page))
+
+	page, err = repo.FindPage(ctx, valueobject.Mega645, 4, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1}, drawNumbers(page))
+
+	page, err = repo.FindPage(ctx, valueobject.Mega645, 10, 2)
+	require.NoError(t, err)
+	assert.Empty(t, page)
+
+	_, err = repo.FindPage(ctx, valueobject.Mega645, -1, 2)
+	assert.Error(t, err)
+
+	var seen []int
+	require.NoError(t, repo.ForEachDraw(ctx, valueobject.Mega645, func(draw *entity.Draw) error {
+		seen = append(seen, draw.DrawNumber)
+		return nil
+	}))
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, seen)
+
+	// An error from fn stops the iteration
+	errStop := errors.New("stop")
+	seen = nil
+	err = repo.ForEachDraw(ctx, valueobject.Mega645, func(draw *entity.Draw) error {
+		seen = append(seen, draw.DrawNumber)
+		if len(seen) == 2 {
+			return errStop
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, errStop)
+	assert.Equal(t, []int{1, 2}, seen)
+
+	// A game without draws has nothing to iterate
+	require.NoError(t, repo.ForEachDraw(ctx, valueobject.Power655, func(draw *entity.Draw) error {
+		t.Fatalf("unexpected draw %d", draw.DrawNumber)
+		return nil
+	}))
+}
+
+func TestJSONLDrawStorage_Paging(t *testing.T) {
+	store, err := NewJSONLDrawStorage(t.TempDir())
+	require.NoError(t, err)
+	testDrawPaging(t, store)
+}
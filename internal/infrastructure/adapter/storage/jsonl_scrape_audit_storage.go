@@ -0,0 +1,143 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/repository"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// ScrapeAuditJSONLStorage implements repository.ScrapeAuditRepository with
+// one append-only JSON lines file per game type,
+// scrape_audits/<game_type>.jsonl. Audits are never rewritten; queries read
+// the whole file, which stays small at a few crawls a day.
+type ScrapeAuditJSONLStorage struct {
+	basePath string
+	corrupt  corruptFiles // Lines skipped when reading
+	mu       sync.Mutex
+}
+
+// NewScrapeAuditJSONLStorage creates a scrape audit log under basePath
+func NewScrapeAuditJSONLStorage(basePath string) (*ScrapeAuditJSONLStorage, error) {
+	if err := os.MkdirAll(filepath.Join(basePath, "scrape_audits"), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create scrape audits directory: %w", err)
+	}
+	return &ScrapeAuditJSONLStorage{basePath: basePath}, nil
+}
+
+// SkippedCorruptLines returns how many unreadable lines reading has skipped
+// since the storage was created
+func (s *ScrapeAuditJSONLStorage) SkippedCorruptLines() int64 {
+	return s.corrupt.count()
+}
+
+// Save appends an audit to its game's file
+func (s *ScrapeAuditJSONLStorage) Save(ctx context.Context, audit *entity.ScrapeAudit) error {
+	line, err := json.Marshal(audit)
+	if err != nil {
+		return fmt.Errorf("failed to encode scrape audit: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := appendLine(s.filename(audit.GameType), line); err != nil {
+		return fmt.Errorf("failed to append scrape audit: %w", err)
+	}
+	return nil
+}
+
+// FindLatest finds a game's most recent audits, newest first
+func (s *ScrapeAuditJSONLStorage) FindLatest(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	limit int,
+) ([]*entity.ScrapeAudit, error) {
+	audits, err := s.load(gameType)
+	if err != nil {
+		return nil, err
+	}
+	return audits[:min(max(limit, 0), len(audits))], nil
+}
+
+// FindByDateRange finds a game's audits of crawls started within a date
+// range, newest first
+func (s *ScrapeAuditJSONLStorage) FindByDateRange(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	dateRange valueobject.DateRange,
+) ([]*entity.ScrapeAudit, error) {
+	audits, err := s.load(gameType)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make([]*entity.ScrapeAudit, 0)
+	for _, audit := range audits {
+		if dateRange.Contains(audit.Timestamp) {
+			found = append(found, audit)
+		}
+	}
+	return found, nil
+}
+
+// load reads a game's audits, newest first. Unreadable lines are skipped
+// and counted like corrupt files.
+func (s *ScrapeAuditJSONLStorage) load(gameType valueobject.GameType) ([]*entity.ScrapeAudit, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	filename := s.filename(gameType)
+	file, err := os.Open(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*entity.ScrapeAudit{}, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	audits := make([]*entity.ScrapeAudit, 0)
+	lines := 0
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		lines++
+
+		var audit entity.ScrapeAudit
+		if err := json.Unmarshal(line, &audit); err != nil {
+			s.corrupt.skip(fmt.Sprintf("%s:%d", filename, lines), err)
+			continue
+		}
+		audits = append(audits, &audit)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", filename, err)
+	}
+
+	sort.SliceStable(audits, func(i, j int) bool {
+		return audits[i].Timestamp.After(audits[j].Timestamp)
+	})
+	return audits, nil
+}
+
+func (s *ScrapeAuditJSONLStorage) filename(gameType valueobject.GameType) string {
+	return filepath.Join(s.basePath, "scrape_audits", strings.ToLower(string(gameType))+".jsonl")
+}
+
+// Ensure ScrapeAuditJSONLStorage implements repository.ScrapeAuditRepository
+var _ repository.ScrapeAuditRepository = (*ScrapeAuditJSONLStorage)(nil)
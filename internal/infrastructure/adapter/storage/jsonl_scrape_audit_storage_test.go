@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func newTestScrapeAudit(gameType valueobject.GameType, timestamp time.Time, drawsFound int) *entity.ScrapeAudit {
+	audit := entity.NewScrapeAudit(gameType, entity.ScrapeSourceAPI, "https://vietlott.vn/results?page=1")
+	audit.Timestamp = timestamp
+	audit.Attempts = 1
+	audit.StatusCode = 200
+	audit.ItemsFound = drawsFound
+	audit.DrawsFound = drawsFound
+	return audit
+}
+
+func TestScrapeAuditJSONLStorage_SaveAndQuery(t *testing.T) {
+	s, err := NewScrapeAuditJSONLStorage(t.TempDir())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	base := time.Date(2026, 3, 1, 18, 30, 0, 0, time.UTC)
+	for day := 0; day < 3; day++ {
+		require.NoError(t, s.Save(ctx, newTestScrapeAudit(valueobject.Mega645, base.AddDate(0, 0, day), day)))
+	}
+	failed := newTestScrapeAudit(valueobject.Power655, base, 0)
+	failed.AddParseError(assert.AnError)
+	failed.Finish(assert.AnError)
+	require.NoError(t, s.Save(ctx, failed))
+
+	latest, err := s.FindLatest(ctx, valueobject.Mega645, 2)
+	require.NoError(t, err)
+	require.Len(t, latest, 2)
+	assert.Equal(t, base.AddDate(0, 0, 2), latest[0].Timestamp)
+	assert.Equal(t, 2, latest[0].DrawsFound)
+	assert.Equal(t, base.AddDate(0, 0, 1), latest[1].Timestamp)
+
+	power, err := s.FindLatest(ctx, valueobject.Power655, 10)
+	require.NoError(t, err)
+	require.Len(t, power, 1)
+	assert.Equal(t, failed.ID, power[0].ID)
+	assert.Equal(t, []string{assert.AnError.Error()}, power[0].ParseErrors)
+	assert.Equal(t, assert.AnError.Error(), power[0].Error)
+
+	dateRange, err := valueobject.NewDateRange(base, base.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	inRange, err := s.FindByDateRange(ctx, valueobject.Mega645, dateRange)
+	require.NoError(t, err)
+	require.Len(t, inRange, 2)
+	assert.Equal(t, 1, inRange[0].DrawsFound)
+	assert.Equal(t, 0, inRange[1].DrawsFound)
+}
+
+func TestScrapeAuditJSONLStorage_Empty(t *testing.T) {
+	s, err := NewScrapeAuditJSONLStorage(t.TempDir())
+	require.NoError(t, err)
+
+	audits, err := s.FindLatest(context.Background(), valueobject.Mega645, 10)
+	require.NoError(t, err)
+	assert.Empty(t, audits)
+}
+
+func TestScrapeAuditJSONLStorage_SkipsPartialLine(t *testing.T) {
+	s, err := NewScrapeAuditJSONLStorage(t.TempDir())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, s.Save(ctx, newTestScrapeAudit(valueobject.Mega645, time.Now(), 6)))
+	file, err := os.OpenFile(s.filename(valueobject.Mega645), os.O_WRONLY|os.O_APPEND, 0644)
+	require.NoError(t, err)
+	_, err = file.WriteString(`{"id":"trunc`)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	audits, err := s.FindLatest(ctx, valueobject.Mega645, 10)
+	require.NoError(t, err)
+	assert.Len(t, audits, 1)
+	assert.EqualValues(t, 1, s.SkippedCorruptLines())
+
+	// The next audit starts its own line rather than joining the partial one
+	require.NoError(t, s.Save(ctx, newTestScrapeAudit(valueobject.Mega645, time.Now(), 4)))
+	audits, err = s.FindLatest(ctx, valueobject.Mega645, 10)
+	require.NoError(t, err)
+	assert.Len(t, audits, 2)
+	assert.EqualValues(t, 2, s.SkippedCorruptLines())
+}
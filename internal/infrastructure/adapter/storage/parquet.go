@@ -0,0 +1,776 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/tool_predict/internal/domain/entity"
+)
+
+// Parquet support for draw datasets, without a Parquet dependency. Exports
+// are a single row group of required, PLAIN encoded, uncompressed columns
+// laid out like the CSV export. Imports read flat files as pandas and
+// pyarrow write them: required or optional columns, PLAIN or dictionary
+// encoded, uncompressed or Snappy compressed, in v1 or v2 data pages.
+
+// parquetMagic starts and ends every Parquet file
+const parquetMagic = "PAR1"
+
+// Parquet physical types
+const (
+	parquetInt32     = 1
+	parquetInt64     = 2
+	parquetFloat     = 4
+	parquetDouble    = 5
+	parquetByteArray = 6
+)
+
+// Parquet converted types, the older annotation of a column's meaning
+const (
+	parquetConvertedUTF8            = 0
+	parquetConvertedDate            = 6
+	parquetConvertedTimestampMillis = 9
+	parquetConvertedTimestampMicros = 10
+)
+
+// Parquet encodings, page types and compression codecs
+const (
+	parquetEncodingPlain     = 0
+	parquetEncodingPlainDict = 2
+	parquetEncodingRLE       = 3
+	parquetEncodingRLEDict   = 8
+
+	parquetPageData       = 0
+	parquetPageDictionary = 2
+	parquetPageDataV2     = 3
+
+	parquetCodecUncompressed = 0
+	parquetCodecSnappy       = 1
+)
+
+// Parquet repetition types, and the logical type union members and
+// timestamp units read or written
+const (
+	parquetRepetitionRequired = 0
+	parquetRepetitionOptional = 1
+
+	parquetLogicalTypeString    = 1
+	parquetLogicalTypeDate      = 6
+	parquetLogicalTypeTimestamp = 8
+
+	parquetTimestampUnitMillis = 1
+	parquetTimestampUnitMicros = 2
+	parquetTimestampUnitNanos  = 3
+)
+
+// parquetWriter gathers draws and writes them as a Parquet file on flush,
+// since Parquet stores each column's values together
+type parquetWriter struct {
+	w     io.Writer
+	draws []*entity.Draw
+}
+
+// parquetColumn describes a column of the exported file
+type parquetColumn struct {
+	name      string
+	typ       int32
+	converted int32 // -1 for none
+	logical   func(t *thriftWriter)
+	plain     func(buf *bytes.Buffer, draw *entity.Draw)
+}
+
+// drawParquetColumns are the exported columns, named and ordered like
+// drawCSVHeader
+var drawParquetColumns = []parquetColumn{
+	{
+		name: "game_type", typ: parquetByteArray, converted: parquetConvertedUTF8,
+		logical: func(t *thriftWriter) { t.emptyStruct(parquetLogicalTypeString) },
+		plain: func(buf *bytes.Buffer, draw *entity.Draw) {
+			writePlainByteArray(buf, string(draw.GameType))
+		},
+	},
+	{
+		name: "draw_number", typ: parquetInt32, converted: -1,
+		plain: func(buf *bytes.Buffer, draw *entity.Draw) {
+			_ = binary.Write(buf, binary.LittleEndian, int32(draw.DrawNumber))
+		},
+	},
+	{
+		name: "draw_date", typ: parquetInt64, converted: parquetConvertedTimestampMillis,
+		logical: func(t *thriftWriter) {
+			t.beginStruct(parquetLogicalTypeTimestamp)
+			t.boolean(1, true) // isAdjustedToUTC
+			t.beginStruct(2)
+			t.emptyStruct(parquetTimestampUnitMillis)
+			t.endStruct()
+			t.endStruct()
+		},
+		plain: func(buf *bytes.Buffer, draw *entity.Draw) {
+			_ = binary.Write(buf, binary.LittleEndian, draw.DrawDate.UnixMilli())
+		},
+	},
+	{
+		name: "numbers", typ: parquetByteArray, converted: parquetConvertedUTF8,
+		logical: func(t *thriftWriter) { t.emptyStruct(parquetLogicalTypeString) },
+		plain: func(buf *bytes.Buffer, draw *entity.Draw) {
+			writePlainByteArray(buf, formatCSVNumbers(draw))
+		},
+	},
+	{
+		name: "jackpot", typ: parquetDouble, converted: -1,
+		plain: func(buf *bytes.Buffer, draw *entity.Draw) {
+			_ = binary.Write(buf, binary.LittleEndian, math.Float64bits(draw.Jackpot))
+		},
+	},
+	{
+		name: "winners", typ: parquetInt32, converted: -1,
+		plain: func(buf *bytes.Buffer, draw *entity.Draw) {
+			_ = binary.Write(buf, binary.LittleEndian, int32(draw.Winners))
+		},
+	},
+}
+
+func writePlainByteArray(buf *bytes.Buffer, s string) {
+	_ = binary.Write(buf, binary.LittleEndian, uint32(len(s)))
+	buf.WriteString(s)
+}
+
+// flush writes the gathered draws as a complete Parquet file
+func (p *parquetWriter) flush() error {
+	var file bytes.Buffer
+	file.WriteString(parquetMagic)
+
+	// One column chunk of one PLAIN data page per column
+	type chunk struct {
+		offset int64
+		size   int64
+	}
+	var chunks []chunk
+	if len(p.draws) > 0 {
+		for _, col := range drawParquetColumns {
+			var values bytes.Buffer
+			for _, draw := range p.draws {
+				col.plain(&values, draw)
+			}
+
+			header := &thriftWriter{}
+			header.i32(1, parquetPageData)
+			header.i32(2, int32(values.Len()))
+			header.i32(3, int32(values.Len()))
+			header.beginStruct(5)
+			header.i32(1, int32(len(p.draws)))
+			header.i32(2, parquetEncodingPlain)
+			header.i32(3, parquetEncodingRLE)
+			header.i32(4, parquetEncodingRLE)
+			header.endStruct()
+			header.stop()
+
+			offset := int64(file.Len())
+			file.Write(header.buf.Bytes())
+			file.Write(values.Bytes())
+			chunks = append(chunks, chunk{offset: offset, size: int64(file.Len()) - offset})
+		}
+	}
+
+	meta := &thriftWriter{}
+	meta.i32(1, 1) // version
+	meta.listHeader(2, thriftStruct, len(drawParquetColumns)+1)
+	meta.enterStruct()
+	meta.binary(4, "schema")
+	meta.i32(5, int32(len(drawParquetColumns)))
+	meta.endStruct()
+	for _, col := range drawParquetColumns {
+		meta.enterStruct()
+		meta.i32(1, col.typ)
+		meta.i32(3, parquetRepetitionRequired)
+		meta.binary(4, col.name)
+		if col.converted >= 0 {
+			meta.i32(6, col.converted)
+		}
+		if col.logical != nil {
+			meta.beginStruct(10)
+			col.logical(meta)
+			meta.endStruct()
+		}
+		meta.endStruct()
+	}
+	meta.i64(3, int64(len(p.draws)))
+	if len(chunks) == 0 {
+		meta.listHeader(4, thriftStruct, 0)
+	} else {
+		var total int64
+		for _, c := range chunks {
+			total += c.size
+		}
+		meta.listHeader(4, thriftStruct, 1)
+		meta.enterStruct()
+		meta.listHeader(1, thriftStruct, len(chunks))
+		for i, col := range drawParquetColumns {
+			meta.enterStruct()
+			meta.i64(2, chunks[i].offset)
+			meta.beginStruct(3)
+			meta.i32(1, col.typ)
+			meta.listHeader(2, thriftI32, 1)
+			meta.listI32(parquetEncodingPlain)
+			meta.listHeader(3, thriftBinary, 1)
+			meta.listBinary(col.name)
+			meta.i32(4, parquetCodecUncompressed)
+			meta.i64(5, int64(len(p.draws)))
+			meta.i64(6, chunks[i].size)
+			meta.i64(7, chunks[i].size)
+			meta.i64(9, chunks[i].offset)
+			meta.endStruct()
+			meta.endStruct()
+		}
+		meta.i64(2, total)
+		meta.i64(3, int64(len(p.draws)))
+		meta.endStruct()
+	}
+	meta.binary(6, "tool_predict")
+	meta.stop()
+
+	file.Write(meta.buf.Bytes())
+	_ = binary.Write(&file, binary.LittleEndian, uint32(meta.buf.Len()))
+	file.WriteString(parquetMagic)
+
+	if _, err := p.w.Write(file.Bytes()); err != nil {
+		return fmt.Errorf("failed to write Parquet file: %w", err)
+	}
+	p.draws = nil
+	return nil
+}
+
+// readDrawsParquet reads a Parquet file with at least the game_type,
+// draw_number, draw_date and numbers columns, like the CSV columns. Each
+// value is turned into its CSV form, so the same parsing applies: numbers is
+// a string of numbers, and draw_date a timestamp, a date or a string.
+func readDrawsParquet(r io.Reader) ([]*entity.Draw, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Parquet file: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	columns, rows, err := decodeParquet(data)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range drawCSVHeader[:4] {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("Parquet file lacks the %s column", name)
+		}
+	}
+
+	draws := make([]*entity.Draw, 0, rows)
+	for row := 0; row < rows; row++ {
+		field := func(name string) string {
+			if values, ok := columns[name]; ok {
+				return values[row]
+			}
+			return ""
+		}
+		draw, err := parseDrawCSVRow(field)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", row+1, err)
+		}
+		draws = append(draws, draw)
+	}
+	return draws, nil
+}
+
+// parquetSchemaColumn is what decoding needs to know of a leaf column
+type parquetSchemaColumn struct {
+	typ       int64
+	optional  bool
+	converted int64 // -1 for none
+	unit      int64 // Timestamp unit from the logical type, 0 for none
+	date      bool  // Logical DATE
+}
+
+// decodeParquet decodes a flat Parquet file into each column's values in
+// their CSV form, keyed by column name, with "" for nulls
+func decodeParquet(data []byte) (map[string][]string, int, error) {
+	if len(data) < 12 || string(data[:4]) != parquetMagic || string(data[len(data)-4:]) != parquetMagic {
+		return nil, 0, errors.New("not a Parquet file")
+	}
+	footerLen := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	if footerLen > len(data)-12 {
+		return nil, 0, errors.New("corrupt Parquet footer")
+	}
+	meta, err := newThriftReader(data[len(data)-8-footerLen : len(data)-8]).readStruct()
+	if err != nil {
+		return nil, 0, fmt.Errorf("corrupt Parquet footer: %w", err)
+	}
+
+	schema := make(map[string]parquetSchemaColumn)
+	elements := meta.field(2).list
+	for i, el := range elements {
+		if i == 0 {
+			continue // The root
+		}
+		s := el.strct
+		if s.field(5).i > 0 || s.field(3).i > parquetRepetitionOptional {
+			return nil, 0, fmt.Errorf("Parquet column %q is nested or repeated; only flat columns are supported", s.field(4).b)
+		}
+		col := parquetSchemaColumn{typ: s.field(1).i, optional: s.field(3).i == parquetRepetitionOptional, converted: -1}
+		if converted, ok := s[6]; ok {
+			col.converted = converted.i
+		}
+		if logical := s.field(10).strct; logical != nil {
+			if ts := logical.field(parquetLogicalTypeTimestamp).strct; ts != nil {
+				for unit := range ts.field(2).strct {
+					col.unit = int64(unit)
+				}
+			}
+			_, col.date = logical[parquetLogicalTypeDate]
+		}
+		schema[string(s.field(4).b)] = col
+	}
+
+	rows := int(meta.field(3).i)
+	columns := make(map[string][]string, len(schema))
+	for name := range schema {
+		columns[name] = nil
+	}
+	for _, rowGroup := range meta.field(4).list {
+		for _, chunk := range rowGroup.strct.field(1).list {
+			colMeta := chunk.strct.field(3).strct
+			if colMeta == nil {
+				return nil, 0, errors.New("Parquet column chunk without metadata")
+			}
+			path := colMeta.field(3).list
+			if len(path) != 1 {
+				return nil, 0, errors.New("nested Parquet columns are not supported")
+			}
+			name := string(path[0].b)
+			col, ok := schema[name]
+			if !ok {
+				return nil, 0, fmt.Errorf("Parquet column %q is not in the schema", name)
+			}
+			values, err := decodeParquetChunk(data, colMeta, col)
+			if err != nil {
+				return nil, 0, fmt.Errorf("Parquet column %q: %w", name, err)
+			}
+			columns[name] = append(columns[name], values...)
+		}
+	}
+	for name, values := range columns {
+		if len(values) != rows {
+			return nil, 0, fmt.Errorf("Parquet column %q has %d values for %d rows", name, len(values), rows)
+		}
+	}
+	return columns, rows, nil
+}
+
+// decodeParquetChunk decodes the pages of one column chunk
+func decodeParquetChunk(data []byte, colMeta thriftStructValue, col parquetSchemaColumn) ([]string, error) {
+	codec := colMeta.field(4).i
+	if codec != parquetCodecUncompressed && codec != parquetCodecSnappy {
+		return nil, fmt.Errorf("compression codec %d is not supported; write the file uncompressed or with Snappy", codec)
+	}
+	numValues := int(colMeta.field(5).i)
+	offset := colMeta.field(9).i
+	if dictOffset, ok := colMeta[11]; ok && dictOffset.i > 0 && dictOffset.i < offset {
+		offset = dictOffset.i
+	}
+
+	var dictionary []string
+	values := make([]string, 0, numValues)
+	for len(values) < numValues {
+		if offset < 0 || offset >= int64(len(data)) {
+			return nil, errors.New("page offset outside the file")
+		}
+		reader := newThriftReader(data[offset:])
+		header, err := reader.readStruct()
+		if err != nil {
+			return nil, fmt.Errorf("corrupt page header: %w", err)
+		}
+		start := offset + int64(reader.pos)
+		size := header.field(3).i
+		if size < 0 || start+size > int64(len(data)) {
+			return nil, errors.New("page runs past the end of the file")
+		}
+		page := data[start : start+size]
+		offset = start + size
+
+		switch header.field(1).i {
+		case parquetPageDictionary:
+			dictHeader := header.field(7).strct
+			raw, err := decompressParquetPage(codec, page)
+			if err != nil {
+				return nil, err
+			}
+			dictionary, _, err = decodePlainValues(raw, col, int(dictHeader.field(1).i))
+			if err != nil {
+				return nil, fmt.Errorf("dictionary page: %w", err)
+			}
+		case parquetPageData:
+			pageHeader := header.field(5).strct
+			raw, err := decompressParquetPage(codec, page)
+			if err != nil {
+				return nil, err
+			}
+			count := int(pageHeader.field(1).i)
+			var defined []bool
+			if col.optional {
+				if len(raw) < 4 {
+					return nil, errors.New("truncated definition levels")
+				}
+				levelsLen := int(binary.LittleEndian.Uint32(raw))
+				if 4+levelsLen > len(raw) {
+					return nil, errors.New("truncated definition levels")
+				}
+				if defined, err = decodeDefinitionLevels(raw[4:4+levelsLen], count); err != nil {
+					return nil, err
+				}
+				raw = raw[4+levelsLen:]
+			}
+			pageValues, err := decodeParquetPageValues(raw, pageHeader.field(2).i, col, dictionary, defined, count)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, pageValues...)
+		case parquetPageDataV2:
+			pageHeader := header.field(8).strct
+			count := int(pageHeader.field(1).i)
+			defLen := int(pageHeader.field(5).i)
+			repLen := int(pageHeader.field(6).i)
+			if defLen < 0 || repLen < 0 || repLen+defLen > len(page) {
+				return nil, errors.New("truncated levels")
+			}
+			var defined []bool
+			if col.optional {
+				if defined, err = decodeDefinitionLevels(page[repLen:repLen+defLen], count); err != nil {
+					return nil, err
+				}
+			}
+			raw := page[repLen+defLen:]
+			if compressed, ok := pageHeader[7]; !ok || compressed.i != 0 {
+				if raw, err = decompressParquetPage(codec, raw); err != nil {
+					return nil, err
+				}
+			}
+			pageValues, err := decodeParquetPageValues(raw, pageHeader.field(4).i, col, dictionary, defined, count)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, pageValues...)
+		default:
+			// Index pages carry no values
+		}
+	}
+	return values, nil
+}
+
+// decodeParquetPageValues decodes a data page's count values, defined
+// telling which are not null (nil when all are)
+func decodeParquetPageValues(
+	raw []byte,
+	encoding int64,
+	col parquetSchemaColumn,
+	dictionary []string,
+	defined []bool,
+	count int,
+) ([]string, error) {
+	present := count
+	if defined != nil {
+		present = 0
+		for _, ok := range defined {
+			if ok {
+				present++
+			}
+		}
+	}
+
+	var decoded []string
+	switch encoding {
+	case parquetEncodingPlain:
+		var err error
+		if decoded, _, err = decodePlainValues(raw, col, present); err != nil {
+			return nil, err
+		}
+	case parquetEncodingPlainDict, parquetEncodingRLEDict:
+		if dictionary == nil {
+			return nil, errors.New("dictionary encoded page without a dictionary")
+		}
+		if len(raw) == 0 {
+			if present > 0 {
+				return nil, errors.New("truncated dictionary indices")
+			}
+			break
+		}
+		indices, err := decodeRLEHybrid(raw[1:], int(raw[0]), present)
+		if err != nil {
+			return nil, err
+		}
+		decoded = make([]string, present)
+		for i, index := range indices {
+			if index >= len(dictionary) {
+				return nil, fmt.Errorf("dictionary index %d out of range", index)
+			}
+			decoded[i] = dictionary[index]
+		}
+	default:
+		return nil, fmt.Errorf("encoding %d is not supported", encoding)
+	}
+
+	if defined == nil {
+		return decoded, nil
+	}
+	values := make([]string, count)
+	next := 0
+	for i, ok := range defined {
+		if ok {
+			values[i] = decoded[next]
+			next++
+		}
+	}
+	return values, nil
+}
+
+// decodePlainValues decodes n PLAIN encoded values in their CSV form and
+// returns the bytes left over
+func decodePlainValues(raw []byte, col parquetSchemaColumn, n int) ([]string, []byte, error) {
+	values := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		var value string
+		switch col.typ {
+		case parquetInt32:
+			if len(raw) < 4 {
+				return nil, nil, io.ErrUnexpectedEOF
+			}
+			v := int32(binary.LittleEndian.Uint32(raw))
+			raw = raw[4:]
+			if col.date || col.converted == parquetConvertedDate {
+				value = time.Unix(int64(v)*86400, 0).UTC().Format(time.DateOnly)
+			} else {
+				value = strconv.Itoa(int(v))
+			}
+		case parquetInt64:
+			if len(raw) < 8 {
+				return nil, nil, io.ErrUnexpectedEOF
+			}
+			v := int64(binary.LittleEndian.Uint64(raw))
+			raw = raw[8:]
+			value = formatParquetInt64(v, col)
+		case parquetFloat:
+			if len(raw) < 4 {
+				return nil, nil, io.ErrUnexpectedEOF
+			}
+			value = strconv.FormatFloat(float64(math.Float32frombits(binary.LittleEndian.Uint32(raw))), 'f', -1, 32)
+			raw = raw[4:]
+		case parquetDouble:
+			if len(raw) < 8 {
+				return nil, nil, io.ErrUnexpectedEOF
+			}
+			value = strconv.FormatFloat(math.Float64frombits(binary.LittleEndian.Uint64(raw)), 'f', -1, 64)
+			raw = raw[8:]
+		case parquetByteArray:
+			if len(raw) < 4 {
+				return nil, nil, io.ErrUnexpectedEOF
+			}
+			size := int(binary.LittleEndian.Uint32(raw))
+			if size > len(raw)-4 {
+				return nil, nil, io.ErrUnexpectedEOF
+			}
+			value = string(raw[4 : 4+size])
+			raw = raw[4+size:]
+		default:
+			return nil, nil, fmt.Errorf("physical type %d is not supported", col.typ)
+		}
+		values = append(values, value)
+	}
+	return values, raw, nil
+}
+
+// formatParquetInt64 formats an INT64 value, as an RFC 3339 time when the
+// column holds timestamps
+func formatParquetInt64(v int64, col parquetSchemaColumn) string {
+	unit := col.unit
+	switch col.converted {
+	case parquetConvertedTimestampMillis:
+		unit = parquetTimestampUnitMillis
+	case parquetConvertedTimestampMicros:
+		unit = parquetTimestampUnitMicros
+	}
+	switch unit {
+	case parquetTimestampUnitMillis:
+		return time.UnixMilli(v).UTC().Format(time.RFC3339Nano)
+	case parquetTimestampUnitMicros:
+		return time.UnixMicro(v).UTC().Format(time.RFC3339Nano)
+	case parquetTimestampUnitNanos:
+		return time.Unix(0, v).UTC().Format(time.RFC3339Nano)
+	default:
+		return strconv.FormatInt(v, 10)
+	}
+}
+
+// decodeDefinitionLevels decodes the RLE encoded definition levels of a flat
+// optional column, reporting which of count values are not null
+func decodeDefinitionLevels(raw []byte, count int) ([]bool, error) {
+	levels, err := decodeRLEHybrid(raw, 1, count)
+	if err != nil {
+		return nil, fmt.Errorf("definition levels: %w", err)
+	}
+	defined := make([]bool, count)
+	for i, level := range levels {
+		defined[i] = level == 1
+	}
+	return defined, nil
+}
+
+// decodeRLEHybrid decodes count values of bitWidth bits from Parquet's
+// RLE/bit-packed hybrid encoding
+func decodeRLEHybrid(raw []byte, bitWidth, count int) ([]int, error) {
+	if bitWidth < 0 || bitWidth > 32 {
+		return nil, fmt.Errorf("invalid bit width %d", bitWidth)
+	}
+	values := make([]int, 0, count)
+	for len(values) < count {
+		header, n := binary.Uvarint(raw)
+		if n <= 0 {
+			return nil, io.ErrUnexpectedEOF
+		}
+		raw = raw[n:]
+
+		if header&1 == 0 {
+			// A run of one repeated value
+			run := int(header >> 1)
+			width := (bitWidth + 7) / 8
+			if len(raw) < width {
+				return nil, io.ErrUnexpectedEOF
+			}
+			value := 0
+			for i := 0; i < width; i++ {
+				value |= int(raw[i]) << (8 * i)
+			}
+			raw = raw[width:]
+			for i := 0; i < run && len(values) < count; i++ {
+				values = append(values, value)
+			}
+			continue
+		}
+
+		// Groups of eight bit-packed values, least significant bit first
+		groups := int(header >> 1)
+		size := groups * bitWidth
+		if len(raw) < size {
+			return nil, io.ErrUnexpectedEOF
+		}
+		for i := 0; i < groups*8 && len(values) < count; i++ {
+			value := 0
+			for bit := 0; bit < bitWidth; bit++ {
+				pos := i*bitWidth + bit
+				if raw[pos/8]&(1<<(pos%8)) != 0 {
+					value |= 1 << bit
+				}
+			}
+			values = append(values, value)
+		}
+		raw = raw[size:]
+	}
+	return values, nil
+}
+
+// decompressParquetPage decompresses a page's bytes
+func decompressParquetPage(codec int64, page []byte) ([]byte, error) {
+	if codec == parquetCodecSnappy {
+		raw, err := decodeSnappy(page)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress page: %w", err)
+		}
+		return raw, nil
+	}
+	return page, nil
+}
+
+// decodeSnappy decompresses a Snappy block, as Parquet stores pages
+func decodeSnappy(src []byte) ([]byte, error) {
+	length, n := binary.Uvarint(src)
+	if n <= 0 || length > 1<<30 {
+		return nil, errors.New("invalid snappy length")
+	}
+	src = src[n:]
+	dst := make([]byte, 0, length)
+
+	for len(src) > 0 {
+		tag := src[0]
+		src = src[1:]
+		switch tag & 3 {
+		case 0: // Literal
+			size := int(tag >> 2)
+			if size >= 60 {
+				extra := size - 59
+				if len(src) < extra {
+					return nil, io.ErrUnexpectedEOF
+				}
+				size = 0
+				for i := 0; i < extra; i++ {
+					size |= int(src[i]) << (8 * i)
+				}
+				src = src[extra:]
+			}
+			size++
+			if size > len(src) {
+				return nil, io.ErrUnexpectedEOF
+			}
+			dst = append(dst, src[:size]...)
+			src = src[size:]
+			continue
+		case 1: // Copy with a 1-byte offset
+			if len(src) < 1 {
+				return nil, io.ErrUnexpectedEOF
+			}
+			size := 4 + int(tag>>2)&7
+			offset := int(tag>>5)<<8 | int(src[0])
+			src = src[1:]
+			if err := snappyCopy(&dst, offset, size); err != nil {
+				return nil, err
+			}
+		case 2: // Copy with a 2-byte offset
+			if len(src) < 2 {
+				return nil, io.ErrUnexpectedEOF
+			}
+			size := 1 + int(tag>>2)
+			offset := int(binary.LittleEndian.Uint16(src))
+			src = src[2:]
+			if err := snappyCopy(&dst, offset, size); err != nil {
+				return nil, err
+			}
+		case 3: // Copy with a 4-byte offset
+			if len(src) < 4 {
+				return nil, io.ErrUnexpectedEOF
+			}
+			size := 1 + int(tag>>2)
+			offset := int(binary.LittleEndian.Uint32(src))
+			src = src[4:]
+			if err := snappyCopy(&dst, offset, size); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if uint64(len(dst)) != length {
+		return nil, errors.New("snappy length mismatch")
+	}
+	return dst, nil
+}
+
+// snappyCopy appends size bytes copied from offset bytes back, which may
+// overlap what it appends
+func snappyCopy(dst *[]byte, offset, size int) error {
+	if offset <= 0 || offset > len(*dst) {
+		return errors.New("invalid snappy copy offset")
+	}
+	start := len(*dst) - offset
+	for i := 0; i < size; i++ {
+		*dst = append(*dst, (*dst)[start+i])
+	}
+	return nil
+}
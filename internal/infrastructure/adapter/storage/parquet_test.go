@@ -0,0 +1,250 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func TestWriteDraws_ParquetEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteDraws(&buf, DrawFormatParquet, nil))
+	assert.Equal(t, parquetMagic, buf.String()[:4])
+
+	draws, err := ReadDraws(&buf, DrawFormatParquet)
+	require.NoError(t, err)
+	assert.Empty(t, draws)
+}
+
+// pandasParquetFile builds a Parquet file the way pandas writes one by
+// default: optional columns, a dictionary encoded and Snappy compressed
+// string column, nanosecond timestamps, int64 integers and v2 data pages
+func pandasParquetFile(t *testing.T) []byte {
+	t.Helper()
+
+	var file bytes.Buffer
+	file.WriteString(parquetMagic)
+
+	type chunk struct {
+		name       string
+		typ        int32
+		codec      int32
+		dictOffset int64
+		dataOffset int64
+		values     int
+	}
+	var chunks []chunk
+	page := func(header *thriftWriter, body []byte) {
+		file.Write(header.buf.Bytes())
+		file.Write(body)
+	}
+	plainStrings := func(values ...string) []byte {
+		var buf bytes.Buffer
+		for _, v := range values {
+			writePlainByteArray(&buf, v)
+		}
+		return buf.Bytes()
+	}
+	// Both rows defined: a bit-packed group with bits 0 and 1 set
+	allDefined := []byte{0x03, 0x03}
+	withLevels := func(levels, values []byte) []byte {
+		var buf bytes.Buffer
+		_ = binary.Write(&buf, binary.LittleEndian, uint32(len(levels)))
+		buf.Write(levels)
+		buf.Write(values)
+		return buf.Bytes()
+	}
+	dataPageV1 := func(body []byte, compressed int, encoding int32) *thriftWriter {
+		h := &thriftWriter{}
+		h.i32(1, parquetPageData)
+		h.i32(2, int32(len(body)))
+		h.i32(3, int32(compressed))
+		h.beginStruct(5)
+		h.i32(1, 2)
+		h.i32(2, encoding)
+		h.i32(3, parquetEncodingRLE)
+		h.i32(4, parquetEncodingRLE)
+		h.endStruct()
+		h.stop()
+		return h
+	}
+
+	// game_type: dictionary encoded, Snappy compressed
+	dictOffset := int64(file.Len())
+	dict := snappyLiteral(plainStrings("MEGA_6_45"))
+	h := &thriftWriter{}
+	h.i32(1, parquetPageDictionary)
+	h.i32(2, int32(len(plainStrings("MEGA_6_45"))))
+	h.i32(3, int32(len(dict)))
+	h.beginStruct(7)
+	h.i32(1, 1)
+	h.i32(2, parquetEncodingPlain)
+	h.endStruct()
+	h.stop()
+	page(h, dict)
+	dataOffset := int64(file.Len())
+	// Bit width 1, then a run of two zero indices
+	body := withLevels(allDefined, []byte{0x01, 0x04, 0x00})
+	compressed := snappyLiteral(body)
+	page(dataPageV1(body, len(compressed), parquetEncodingRLEDict), compressed)
+	chunks = append(chunks, chunk{"game_type", parquetByteArray, parquetCodecSnappy, dictOffset, dataOffset, 2})
+
+	// draw_number: int64 in a v2 data page
+	dataOffset = int64(file.Len())
+	var numbers bytes.Buffer
+	_ = binary.Write(&numbers, binary.LittleEndian, []int64{1201, 1202})
+	body = append(append([]byte{}, allDefined...), numbers.Bytes()...)
+	h = &thriftWriter{}
+	h.i32(1, parquetPageDataV2)
+	h.i32(2, int32(len(body)))
+	h.i32(3, int32(len(body)))
+	h.beginStruct(8)
+	h.i32(1, 2)
+	h.i32(2, 0)
+	h.i32(3, 2)
+	h.i32(4, parquetEncodingPlain)
+	h.i32(5, int32(len(allDefined)))
+	h.i32(6, 0)
+	h.boolean(7, false)
+	h.endStruct()
+	h.stop()
+	page(h, body)
+	chunks = append(chunks, chunk{"draw_number", parquetInt64, parquetCodecUncompressed, 0, dataOffset, 2})
+
+	// draw_date: nanosecond timestamps
+	dataOffset = int64(file.Len())
+	var dates bytes.Buffer
+	_ = binary.Write(&dates, binary.LittleEndian, []int64{
+		time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC).UnixNano(),
+		time.Date(2026, 1, 17, 0, 0, 0, 0, time.UTC).UnixNano(),
+	})
+	body = withLevels(allDefined, dates.Bytes())
+	page(dataPageV1(body, len(body), parquetEncodingPlain), body)
+	chunks = append(chunks, chunk{"draw_date", parquetInt64, parquetCodecUncompressed, 0, dataOffset, 2})
+
+	// numbers: plain strings
+	dataOffset = int64(file.Len())
+	body = withLevels(allDefined, plainStrings("3,9,17,22,30,41", "1-5-12-19-33-45"))
+	page(dataPageV1(body, len(body), parquetEncodingPlain), body)
+	chunks = append(chunks, chunk{"numbers", parquetByteArray, parquetCodecUncompressed, 0, dataOffset, 2})
+
+	// jackpot: null in the first row, a bit-packed group with only bit 1 set
+	dataOffset = int64(file.Len())
+	var jackpot bytes.Buffer
+	_ = binary.Write(&jackpot, binary.LittleEndian, math.Float64bits(5e9))
+	body = withLevels([]byte{0x03, 0x02}, jackpot.Bytes())
+	page(dataPageV1(body, len(body), parquetEncodingPlain), body)
+	chunks = append(chunks, chunk{"jackpot", parquetDouble, parquetCodecUncompressed, 0, dataOffset, 2})
+
+	meta := &thriftWriter{}
+	meta.i32(1, 2)
+	meta.listHeader(2, thriftStruct, len(chunks)+1)
+	meta.enterStruct()
+	meta.binary(4, "schema")
+	meta.i32(5, int32(len(chunks)))
+	meta.endStruct()
+	for _, c := range chunks {
+		meta.enterStruct()
+		meta.i32(1, c.typ)
+		meta.i32(3, parquetRepetitionOptional)
+		meta.binary(4, c.name)
+		if c.name == "draw_date" {
+			meta.beginStruct(10)
+			meta.beginStruct(parquetLogicalTypeTimestamp)
+			meta.boolean(1, false)
+			meta.beginStruct(2)
+			meta.emptyStruct(parquetTimestampUnitNanos)
+			meta.endStruct()
+			meta.endStruct()
+			meta.endStruct()
+		}
+		meta.endStruct()
+	}
+	meta.i64(3, 2)
+	meta.listHeader(4, thriftStruct, 1)
+	meta.enterStruct()
+	meta.listHeader(1, thriftStruct, len(chunks))
+	for _, c := range chunks {
+		meta.enterStruct()
+		meta.i64(2, c.dataOffset)
+		meta.beginStruct(3)
+		meta.i32(1, c.typ)
+		meta.listHeader(2, thriftI32, 1)
+		meta.listI32(parquetEncodingPlain)
+		meta.listHeader(3, thriftBinary, 1)
+		meta.listBinary(c.name)
+		meta.i32(4, c.codec)
+		meta.i64(5, int64(c.values))
+		meta.i64(6, 0)
+		meta.i64(7, 0)
+		meta.i64(9, c.dataOffset)
+		if c.dictOffset > 0 {
+			meta.i64(11, c.dictOffset)
+		}
+		meta.endStruct()
+		meta.endStruct()
+	}
+	meta.i64(2, 0)
+	meta.i64(3, 2)
+	meta.endStruct()
+	meta.stop()
+
+	file.Write(meta.buf.Bytes())
+	_ = binary.Write(&file, binary.LittleEndian, uint32(meta.buf.Len()))
+	file.WriteString(parquetMagic)
+	return file.Bytes()
+}
+
+// snappyLiteral encodes data as a Snappy block of one literal
+func snappyLiteral(data []byte) []byte {
+	var out []byte
+	out = binary.AppendUvarint(out, uint64(len(data)))
+	if len(data) <= 60 {
+		out = append(out, byte(len(data)-1)<<2)
+	} else {
+		out = append(out, 60<<2, byte(len(data)-1))
+	}
+	return append(out, data...)
+}
+
+func TestReadDraws_ParquetFromPandas(t *testing.T) {
+	draws, err := ReadDraws(bytes.NewReader(pandasParquetFile(t)), DrawFormatParquet)
+	require.NoError(t, err)
+	require.Len(t, draws, 2)
+
+	assert.Equal(t, valueobject.Mega645, draws[0].GameType)
+	assert.Equal(t, 1201, draws[0].DrawNumber)
+	assert.Equal(t, valueobject.Numbers{3, 9, 17, 22, 30, 41}, draws[0].Numbers)
+	assert.Equal(t, time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC), draws[0].DrawDate)
+	assert.Zero(t, draws[0].Jackpot)
+
+	assert.Equal(t, valueobject.Mega645, draws[1].GameType)
+	assert.Equal(t, 1202, draws[1].DrawNumber)
+	assert.Equal(t, 5e9, draws[1].Jackpot)
+}
+
+func TestReadDraws_ParquetInvalid(t *testing.T) {
+	_, err := ReadDraws(bytes.NewReader([]byte("game_type,draw_number\n")), DrawFormatParquet)
+	assert.ErrorContains(t, err, "not a Parquet file")
+
+	file := pandasParquetFile(t)
+	file[len(file)-6] = 0xFF // Footer length past the start of the file
+	_, err = ReadDraws(bytes.NewReader(file), DrawFormatParquet)
+	assert.ErrorContains(t, err, "corrupt Parquet footer")
+}
+
+func TestDecodeSnappy(t *testing.T) {
+	// "abc" as a literal, then six bytes copied from three back
+	out, err := decodeSnappy([]byte{9, 0x08, 'a', 'b', 'c', 0x09, 3})
+	require.NoError(t, err)
+	assert.Equal(t, "abcabcabc", string(out))
+
+	_, err = decodeSnappy([]byte{9, 0x09, 3})
+	assert.Error(t, err)
+}
@@ -0,0 +1,314 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Parquet's metadata is encoded with Thrift's compact protocol. This is just
+// enough of it to write the footer of an export and to read any footer or
+// page header, keeping every field by id.
+
+// Thrift compact protocol types
+const (
+	thriftStop      = 0
+	thriftTrue      = 1
+	thriftFalse     = 2
+	thriftByte      = 3
+	thriftI16       = 4
+	thriftI32       = 5
+	thriftI64       = 6
+	thriftDouble    = 7
+	thriftBinary    = 8
+	thriftList      = 9
+	thriftSet       = 10
+	thriftMap       = 11
+	thriftStruct    = 12
+	thriftMaxNested = 64
+)
+
+// thriftWriter encodes a struct field by field
+type thriftWriter struct {
+	buf     bytes.Buffer
+	lastID  int16
+	parents []int16 // Last field ids of the enclosing structs
+}
+
+func (t *thriftWriter) varint(v uint64) {
+	var b [binary.MaxVarintLen64]byte
+	t.buf.Write(b[:binary.PutUvarint(b[:], v)])
+}
+
+func (t *thriftWriter) zigzag(v int64) {
+	t.varint(uint64((v << 1) ^ (v >> 63)))
+}
+
+func (t *thriftWriter) fieldHeader(id int16, typ byte) {
+	if delta := id - t.lastID; delta > 0 && delta <= 15 {
+		t.buf.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		t.buf.WriteByte(typ)
+		t.zigzag(int64(id))
+	}
+	t.lastID = id
+}
+
+func (t *thriftWriter) i32(id int16, v int32) {
+	t.fieldHeader(id, thriftI32)
+	t.zigzag(int64(v))
+}
+
+func (t *thriftWriter) i64(id int16, v int64) {
+	t.fieldHeader(id, thriftI64)
+	t.zigzag(v)
+}
+
+func (t *thriftWriter) binary(id int16, s string) {
+	t.fieldHeader(id, thriftBinary)
+	t.listBinary(s)
+}
+
+func (t *thriftWriter) boolean(id int16, v bool) {
+	if v {
+		t.fieldHeader(id, thriftTrue)
+	} else {
+		t.fieldHeader(id, thriftFalse)
+	}
+}
+
+// beginStruct starts a struct field; endStruct ends it
+func (t *thriftWriter) beginStruct(id int16) {
+	t.fieldHeader(id, thriftStruct)
+	t.enterStruct()
+}
+
+// emptyStruct writes a struct field with no fields, as union members often
+// are
+func (t *thriftWriter) emptyStruct(id int16) {
+	t.beginStruct(id)
+	t.endStruct()
+}
+
+// enterStruct starts a struct that is a list element
+func (t *thriftWriter) enterStruct() {
+	t.parents = append(t.parents, t.lastID)
+	t.lastID = 0
+}
+
+func (t *thriftWriter) endStruct() {
+	t.stop()
+	t.lastID = t.parents[len(t.parents)-1]
+	t.parents = t.parents[:len(t.parents)-1]
+}
+
+// stop ends the outermost struct
+func (t *thriftWriter) stop() {
+	t.buf.WriteByte(thriftStop)
+}
+
+// listHeader starts a list field of n elements, written next with listI32,
+// listBinary or enterStruct and endStruct
+func (t *thriftWriter) listHeader(id int16, elemType byte, n int) {
+	t.fieldHeader(id, thriftList)
+	if n < 15 {
+		t.buf.WriteByte(byte(n)<<4 | elemType)
+	} else {
+		t.buf.WriteByte(0xF0 | elemType)
+		t.varint(uint64(n))
+	}
+}
+
+func (t *thriftWriter) listI32(v int32) {
+	t.zigzag(int64(v))
+}
+
+func (t *thriftWriter) listBinary(s string) {
+	t.varint(uint64(len(s)))
+	t.buf.WriteString(s)
+}
+
+// thriftValue is a decoded value: an integer (booleans as 0 or 1), a double,
+// bytes, a list or a struct
+type thriftValue struct {
+	i     int64
+	f     float64
+	b     []byte
+	list  []thriftValue
+	strct thriftStructValue
+}
+
+// thriftStructValue holds a decoded struct's fields by id
+type thriftStructValue map[int16]thriftValue
+
+// field returns a field's value, the zero value when it's absent
+func (s thriftStructValue) field(id int16) thriftValue {
+	return s[id]
+}
+
+// thriftReader decodes compact protocol structs from data
+type thriftReader struct {
+	data  []byte
+	pos   int
+	depth int
+}
+
+func newThriftReader(data []byte) *thriftReader {
+	return &thriftReader{data: data}
+}
+
+func (r *thriftReader) byte() (byte, error) {
+	if r.pos >= len(r.data) {
+		return 0, io.ErrUnexpectedEOF
+	}
+	b := r.data[r.pos]
+	r.pos++
+	return b, nil
+}
+
+func (r *thriftReader) varint() (uint64, error) {
+	v, n := binary.Uvarint(r.data[r.pos:])
+	if n <= 0 {
+		return 0, io.ErrUnexpectedEOF
+	}
+	r.pos += n
+	return v, nil
+}
+
+func (r *thriftReader) zigzag() (int64, error) {
+	v, err := r.varint()
+	return int64(v>>1) ^ -int64(v&1), err
+}
+
+// readStruct decodes a struct up to its stop field
+func (r *thriftReader) readStruct() (thriftStructValue, error) {
+	r.depth++
+	defer func() { r.depth-- }()
+	if r.depth > thriftMaxNested {
+		return nil, errors.New("thrift structs nested too deeply")
+	}
+
+	fields := make(thriftStructValue)
+	var lastID int16
+	for {
+		header, err := r.byte()
+		if err != nil {
+			return nil, err
+		}
+		if header == thriftStop {
+			return fields, nil
+		}
+
+		typ := header & 0x0F
+		id := lastID + int16(header>>4)
+		if header>>4 == 0 {
+			v, err := r.zigzag()
+			if err != nil {
+				return nil, err
+			}
+			id = int16(v)
+		}
+		lastID = id
+
+		var value thriftValue
+		switch typ {
+		case thriftTrue:
+			value.i = 1
+		case thriftFalse:
+		default:
+			if value, err = r.readValue(typ); err != nil {
+				return nil, err
+			}
+		}
+		fields[id] = value
+	}
+}
+
+// readValue decodes a value of the given type, as found in a field or list
+func (r *thriftReader) readValue(typ byte) (thriftValue, error) {
+	var value thriftValue
+	switch typ {
+	case thriftTrue, thriftFalse:
+		// In lists a boolean is a byte of its own
+		b, err := r.byte()
+		value.i = int64(b & 1)
+		return value, err
+	case thriftByte:
+		b, err := r.byte()
+		value.i = int64(int8(b))
+		return value, err
+	case thriftI16, thriftI32, thriftI64:
+		v, err := r.zigzag()
+		value.i = v
+		return value, err
+	case thriftDouble:
+		if r.pos+8 > len(r.data) {
+			return value, io.ErrUnexpectedEOF
+		}
+		value.f = math.Float64frombits(binary.LittleEndian.Uint64(r.data[r.pos:]))
+		r.pos += 8
+		return value, nil
+	case thriftBinary:
+		size, err := r.varint()
+		if err != nil {
+			return value, err
+		}
+		if size > uint64(len(r.data)-r.pos) {
+			return value, io.ErrUnexpectedEOF
+		}
+		value.b = r.data[r.pos : r.pos+int(size)]
+		r.pos += int(size)
+		return value, nil
+	case thriftList, thriftSet:
+		header, err := r.byte()
+		if err != nil {
+			return value, err
+		}
+		size := uint64(header >> 4)
+		if size == 15 {
+			if size, err = r.varint(); err != nil {
+				return value, err
+			}
+		}
+		if size > uint64(len(r.data)-r.pos) {
+			return value, io.ErrUnexpectedEOF // Every element takes a byte at least
+		}
+		value.list = make([]thriftValue, 0, size)
+		for i := uint64(0); i < size; i++ {
+			elem, err := r.readValue(header & 0x0F)
+			if err != nil {
+				return value, err
+			}
+			value.list = append(value.list, elem)
+		}
+		return value, nil
+	case thriftMap:
+		// Parquet only uses maps in fields this reader ignores; skip them
+		size, err := r.varint()
+		if err != nil || size == 0 {
+			return value, err
+		}
+		types, err := r.byte()
+		if err != nil {
+			return value, err
+		}
+		for i := uint64(0); i < size; i++ {
+			if _, err := r.readValue(types >> 4); err != nil {
+				return value, err
+			}
+			if _, err := r.readValue(types & 0x0F); err != nil {
+				return value, err
+			}
+		}
+		return value, nil
+	case thriftStruct:
+		strct, err := r.readStruct()
+		value.strct = strct
+		return value, err
+	default:
+		return value, fmt.Errorf("unknown thrift type %d", typ)
+	}
+}
@@ -0,0 +1,412 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/repository"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+// ObjectStore reads and writes whole objects by key, as S3Client does
+type ObjectStore interface {
+	Get(ctx context.Context, key string) ([]byte, error) // ErrObjectNotFound if missing
+	Put(ctx context.Context, key string, data []byte) error
+	Delete(ctx context.Context, key string) error
+}
+
+// S3DrawStorage implements repository.DrawRepository on an object store,
+// with one JSON lines object per game type, <prefix>/draws/<game_type>.jsonl.
+// Objects can't be appended to, so every save rewrites the game's object with
+// its current draws. The object is read once, into the same in-memory index
+// JSONLDrawStorage uses, so only saves reach the bucket afterwards. The
+// storage assumes it is the bucket's only writer while it runs.
+type S3DrawStorage struct {
+	store   ObjectStore
+	prefix  string
+	corrupt corruptFiles // Lines skipped when loading
+	mu      sync.RWMutex
+	indexes map[valueobject.GameType]*drawIndex
+}
+
+// NewS3DrawStorage creates a draw storage keeping its objects under prefix
+func NewS3DrawStorage(store ObjectStore, prefix string) *S3DrawStorage {
+	return &S3DrawStorage{
+		store:   store,
+		prefix:  strings.Trim(prefix, "/"),
+		indexes: make(map[valueobject.GameType]*drawIndex),
+	}
+}
+
+// SkippedCorruptLines returns how many unreadable lines loading has skipped
+// since the storage was created
+func (s *S3DrawStorage) SkippedCorruptLines() int64 {
+	return s.corrupt.count()
+}
+
+// Save stores a draw, superseding a stored draw with the same draw number
+// but keeping its ID
+func (s *S3DrawStorage) Save(ctx context.Context, draw *entity.Draw) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.saveGame(ctx, draw.GameType, []*entity.Draw{draw})
+}
+
+// SaveBatch saves multiple draws, writing each game's object once. Draws that
+// can't be saved are returned together as a *BatchSaveError; a failed write
+// fails every draw of that game.
+func (s *S3DrawStorage) SaveBatch(ctx context.Context, draws []*entity.Draw) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var errs []error
+	byGame := make(map[valueobject.GameType][]*entity.Draw)
+	for i, draw := range draws {
+		if draw == nil {
+			errs = append(errs, fmt.Errorf("draw at index %d is nil", i))
+			continue
+		}
+		byGame[draw.GameType] = append(byGame[draw.GameType], draw)
+	}
+
+	for gameType, gameDraws := range byGame {
+		if err := s.saveGame(ctx, gameType, gameDraws); err != nil {
+			for _, draw := range gameDraws {
+				errs = append(errs, fmt.Errorf("draw %d: %w", draw.DrawNumber, err))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return &BatchSaveError{
+			Saved:  len(draws) - len(errs),
+			Total:  len(draws),
+			Errors: errs,
+		}
+	}
+	return nil
+}
+
+// FindByID finds a draw by ID
+func (s *S3DrawStorage) FindByID(ctx context.Context, id string) (*entity.Draw, error) {
+	for _, gameType := range valueobject.AllGameTypes() {
+		var found *entity.Draw
+		err := s.read(ctx, gameType, func(index *drawIndex) {
+			found = copyDraw(index.byID[id])
+		})
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+	}
+	return nil, fmt.Errorf("draw with ID %s not found", id)
+}
+
+// FindByGameTypeAndDrawNumber finds a draw by game type and draw number
+func (s *S3DrawStorage) FindByGameTypeAndDrawNumber(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	drawNumber int,
+) (*entity.Draw, error) {
+	var found *entity.Draw
+	err := s.read(ctx, gameType, func(index *drawIndex) {
+		found = copyDraw(index.byNumber[drawNumber])
+	})
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, fmt.Errorf("draw number %d for game type %s: %w", drawNumber, gameType, repository.ErrNotFound)
+	}
+	return found, nil
+}
+
+// FindLatest finds the most recent draws, newest first
+func (s *S3DrawStorage) FindLatest(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	limit int,
+) ([]*entity.Draw, error) {
+	var draws []*entity.Draw
+	err := s.read(ctx, gameType, func(index *drawIndex) {
+		draws = copyDraws(index.byDate[:min(max(limit, 0), len(index.byDate))])
+	})
+	return draws, err
+}
+
+// FindByDateRange finds draws within a date range, newest first
+func (s *S3DrawStorage) FindByDateRange(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	dateRange valueobject.DateRange,
+) ([]*entity.Draw, error) {
+	var draws []*entity.Draw
+	err := s.read(ctx, gameType, func(index *drawIndex) {
+		start := sort.Search(len(index.byDate), func(i int) bool {
+			return !index.byDate[i].DrawDate.After(dateRange.EndDate)
+		})
+		draws = make([]*entity.Draw, 0)
+		for _, draw := range index.byDate[start:] {
+			if draw.DrawDate.Before(dateRange.StartDate) {
+				break
+			}
+			draws = append(draws, copyDraw(draw))
+		}
+	})
+	return draws, err
+}
+
+// FindByDrawNumberRange finds draws within a draw number range, newest first
+func (s *S3DrawStorage) FindByDrawNumberRange(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	startDrawNumber int,
+	endDrawNumber int,
+) ([]*entity.Draw, error) {
+	var draws []*entity.Draw
+	err := s.read(ctx, gameType, func(index *drawIndex) {
+		draws = make([]*entity.Draw, 0)
+		for _, draw := range index.byDate {
+			if draw.DrawNumber >= startDrawNumber && draw.DrawNumber <= endDrawNumber {
+				draws = append(draws, copyDraw(draw))
+			}
+		}
+	})
+	return draws, err
+}
+
+// FindPage finds up to limit draws, newest first, after skipping the offset
+// newest
+func (s *S3DrawStorage) FindPage(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	offset int,
+	limit int,
+) ([]*entity.Draw, error) {
+	if offset < 0 || limit < 0 {
+		return nil, fmt.Errorf("offset and limit cannot be negative, got %d and %d", offset, limit)
+	}
+
+	var draws []*entity.Draw
+	err := s.read(ctx, gameType, func(index *drawIndex) {
+		start := min(offset, len(index.byDate))
+		draws = copyDraws(index.byDate[start:min(start+limit, len(index.byDate))])
+	})
+	return draws, err
+}
+
+// ForEachDraw calls fn with a copy of each draw, oldest first. The draws
+// are those stored when it starts; the lock isn't held while fn runs, so fn
+// may save draws.
+func (s *S3DrawStorage) ForEachDraw(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	fn func(draw *entity.Draw) error,
+) error {
+	var snapshot []*entity.Draw
+	err := s.read(ctx, gameType, func(index *drawIndex) {
+		snapshot = index.byDate
+	})
+	if err != nil {
+		return err
+	}
+	return forEachNewestLast(ctx, snapshot, fn)
+}
+
+// Count returns the total number of draws for a game type
+func (s *S3DrawStorage) Count(ctx context.Context, gameType valueobject.GameType) (int64, error) {
+	var count int64
+	err := s.read(ctx, gameType, func(index *drawIndex) {
+		count = int64(len(index.byNumber))
+	})
+	return count, err
+}
+
+// DeleteAll deletes all draws for a game type
+func (s *S3DrawStorage) DeleteAll(ctx context.Context, gameType valueobject.GameType) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.store.Delete(ctx, s.key(gameType)); err != nil {
+		return err
+	}
+	s.indexes[gameType] = newDrawIndex()
+	return nil
+}
+
+// GetLatestDrawNumber returns the highest draw number
+func (s *S3DrawStorage) GetLatestDrawNumber(ctx context.Context, gameType valueobject.GameType) (int, error) {
+	latest := 0
+	err := s.read(ctx, gameType, func(index *drawIndex) {
+		for drawNumber := range index.byNumber {
+			latest = max(latest, drawNumber)
+		}
+	})
+	if err != nil {
+		return 0, err
+	}
+	if latest == 0 {
+		return 0, fmt.Errorf("no draws found for game type %s", gameType)
+	}
+	return latest, nil
+}
+
+// saveGame adds draws of one game to its index and writes the object. If the
+// write fails the index is dropped, so the next read reloads what the bucket
+// holds. Callers must hold the write lock.
+func (s *S3DrawStorage) saveGame(ctx context.Context, gameType valueobject.GameType, draws []*entity.Draw) error {
+	index, err := s.index(ctx, gameType)
+	if err != nil {
+		return err
+	}
+
+	for _, draw := range draws {
+		if existing, ok := index.byNumber[draw.DrawNumber]; ok {
+			if !existing.Equals(draw) {
+				logger.Warn("Draw result changed since it was stored, recording correction",
+					zap.String("game_type", string(draw.GameType)),
+					zap.Int("draw_number", draw.DrawNumber),
+					zap.String("old_numbers", existing.Numbers.String()),
+					zap.String("new_numbers", draw.Numbers.String()),
+				)
+			}
+			draw.ID = existing.ID
+		}
+		index.put(copyDraw(draw))
+	}
+	index.sort()
+
+	// Oldest first, like a JSON lines file grown by appends
+	records := make([]*entity.Draw, len(index.byDate))
+	for i, draw := range index.byDate {
+		records[len(records)-1-i] = draw
+	}
+	data, err := encodeJSONLines(records)
+	if err == nil {
+		err = s.store.Put(ctx, s.key(gameType), data)
+	}
+	if err != nil {
+		delete(s.indexes, gameType)
+		return fmt.Errorf("failed to write draws: %w", err)
+	}
+	return nil
+}
+
+// read runs fn with a game's index under the read lock, loading the index
+// first if needed
+func (s *S3DrawStorage) read(ctx context.Context, gameType valueobject.GameType, fn func(index *drawIndex)) error {
+	s.mu.RLock()
+	index, ok := s.indexes[gameType]
+	if ok {
+		defer s.mu.RUnlock()
+		fn(index)
+		return nil
+	}
+	s.mu.RUnlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	index, err := s.index(ctx, gameType)
+	if err != nil {
+		return err
+	}
+	fn(index)
+	return nil
+}
+
+// index returns a game's index, loading it from the bucket on first use.
+// Callers must hold the write lock.
+func (s *S3DrawStorage) index(ctx context.Context, gameType valueobject.GameType) (*drawIndex, error) {
+	if index, ok := s.indexes[gameType]; ok {
+		return index, nil
+	}
+
+	index := newDrawIndex()
+	err := loadJSONLinesObject(ctx, s.store, s.key(gameType), &s.corrupt, func(line []byte) error {
+		var draw entity.Draw
+		if err := json.Unmarshal(line, &draw); err != nil {
+			return err
+		}
+		index.put(&draw)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	index.sort()
+
+	s.indexes[gameType] = index
+	return index, nil
+}
+
+func (s *S3DrawStorage) key(gameType valueobject.GameType) string {
+	return path.Join(s.prefix, "draws", strings.ToLower(string(gameType))+".jsonl")
+}
+
+// loadJSONLinesObject calls add with each non-blank line of the object at
+// key. A missing object has no lines; a line add rejects is skipped and
+// counted as corrupt.
+func loadJSONLinesObject(
+	ctx context.Context,
+	store ObjectStore,
+	key string,
+	corrupt *corruptFiles,
+	add func(line []byte) error,
+) error {
+	data, err := store.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, ErrObjectNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to read %s: %w", key, err)
+	}
+
+	lineNumber := 0
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lineNumber++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if err := add(line); err != nil {
+			corrupt.skip(fmt.Sprintf("%s:%d", key, lineNumber), err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read %s: %w", key, err)
+	}
+	return nil
+}
+
+// encodeJSONLines encodes records as JSON lines, in slice order
+func encodeJSONLines[T any](records []T) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, record := range records {
+		line, err := json.Marshal(record)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+// Ensure S3DrawStorage implements repository.DrawRepository
+var _ repository.DrawRepository = (*S3DrawStorage)(nil)
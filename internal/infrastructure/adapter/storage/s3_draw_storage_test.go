@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func TestS3DrawStorage_SaveAndReload(t *testing.T) {
+	client, fake := newFakeS3Client(t)
+	store := NewS3DrawStorage(client, "/data/")
+	ctx := context.Background()
+
+	var draws []*entity.Draw
+	for n := 1; n <= 5; n++ {
+		draws = append(draws, newDatedTestDraw(t, n, []int{n, 10, 20, 30, 40, 45}))
+	}
+	require.NoError(t, store.SaveBatch(ctx, draws))
+	assert.Equal(t, 1, fake.puts, "a batch writes each game's object once")
+	assert.Contains(t, fake.objects, "/test-bucket/data/draws/mega_6_45.jsonl")
+
+	corrected := newDatedTestDraw(t, 3, []int{1, 2, 3, 4, 5, 6})
+	require.NoError(t, store.Save(ctx, corrected))
+	assert.Equal(t, draws[2].ID, corrected.ID, "a re-saved draw keeps its ID")
+
+	// A fresh storage reads back what the first one wrote
+	reloaded := NewS3DrawStorage(client, "data")
+	count, err := reloaded.Count(ctx, valueobject.Mega645)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), count)
+
+	latest, err := reloaded.FindLatest(ctx, valueobject.Mega645, 2)
+	require.NoError(t, err)
+	require.Len(t, latest, 2)
+	assert.Equal(t, 5, latest[0].DrawNumber)
+
+	found, err := reloaded.FindByGameTypeAndDrawNumber(ctx, valueobject.Mega645, 3)
+	require.NoError(t, err)
+	assert.Equal(t, valueobject.Numbers{1, 2, 3, 4, 5, 6}, found.Numbers)
+
+	latestNumber, err := reloaded.GetLatestDrawNumber(ctx, valueobject.Mega645)
+	require.NoError(t, err)
+	assert.Equal(t, 5, latestNumber)
+}
+
+func TestS3DrawStorage_SkipsCorruptLines(t *testing.T) {
+	client, fake := newFakeS3Client(t)
+	store := NewS3DrawStorage(client, "")
+	ctx := context.Background()
+
+	require.NoError(t, store.Save(ctx, newDatedTestDraw(t, 1, []int{1, 10, 20, 30, 40, 45})))
+	key := "/test-bucket/draws/mega_6_45.jsonl"
+	fake.objects[key] = append(fake.objects[key], []byte("{not json\n")...)
+
+	reloaded := NewS3DrawStorage(client, "")
+	count, err := reloaded.Count(ctx, valueobject.Mega645)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	assert.Equal(t, int64(1), reloaded.SkippedCorruptLines())
+}
+
+func TestS3DrawStorage_DeleteAll(t *testing.T) {
+	client, fake := newFakeS3Client(t)
+	store := NewS3DrawStorage(client, "data")
+	ctx := context.Background()
+
+	require.NoError(t, store.Save(ctx, newDatedTestDraw(t, 1, []int{1, 10, 20, 30, 40, 45})))
+	require.NoError(t, store.DeleteAll(ctx, valueobject.Mega645))
+	assert.Empty(t, fake.objects)
+
+	count, err := store.Count(ctx, valueobject.Mega645)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestS3DrawStorage_FailedWriteReloads(t *testing.T) {
+	client, _ := newFakeS3Client(t)
+	store := NewS3DrawStorage(client, "data")
+	ctx := context.Background()
+
+	require.NoError(t, store.Save(ctx, newDatedTestDraw(t, 1, []int{1, 10, 20, 30, 40, 45})))
+
+	client.cfg.AccessKeyID = "wrong"
+	require.Error(t, store.Save(ctx, newDatedTestDraw(t, 2, []int{2, 10, 20, 30, 40, 45})))
+
+	client.cfg.AccessKeyID = "test-key"
+	count, err := store.Count(ctx, valueobject.Mega645)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count, "the unsaved draw isn't served from memory")
+}
+
+func TestS3DrawStorage_Paging(t *testing.T) {
+	client, _ := newFakeS3Client(t)
+	testDrawPaging(t, NewS3DrawStorage(client, "data"))
+}
@@ -0,0 +1,445 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/repository"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+// S3PredictionStorage implements repository.PredictionRepository on an
+// object store, with one JSON lines object per game type for predictions,
+// <prefix>/predictions/<game_type>.jsonl, and one for ensembles,
+// <prefix>/ensembles/<game_type>.jsonl. Like S3DrawStorage it reads each
+// object once and rewrites it whole on every save. Buckets have no trash:
+// superseded ensembles and old predictions are deleted outright.
+type S3PredictionStorage struct {
+	store       ObjectStore
+	prefix      string
+	keepHistory bool         // Keep superseded ensembles instead of deleting them
+	corrupt     corruptFiles // Lines skipped when loading
+	mu          sync.Mutex
+	predictions map[valueobject.GameType][]*entity.Prediction
+	ensembles   map[valueobject.GameType][]*entity.EnsemblePrediction
+}
+
+// NewS3PredictionStorage creates a prediction storage keeping its objects
+// under prefix
+func NewS3PredictionStorage(store ObjectStore, prefix string) *S3PredictionStorage {
+	return &S3PredictionStorage{
+		store:       store,
+		prefix:      strings.Trim(prefix, "/"),
+		predictions: make(map[valueobject.GameType][]*entity.Prediction),
+		ensembles:   make(map[valueobject.GameType][]*entity.EnsemblePrediction),
+	}
+}
+
+// SkippedCorruptLines returns how many unreadable lines loading has skipped
+// since the storage was created
+func (s *S3PredictionStorage) SkippedCorruptLines() int64 {
+	return s.corrupt.count()
+}
+
+// SetKeepHistory controls whether SaveEnsemble keeps older ensembles for the
+// same target draw. By default they are superseded and deleted.
+func (s *S3PredictionStorage) SetKeepHistory(keep bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keepHistory = keep
+}
+
+// Save saves a single prediction, replacing a stored one with the same ID
+func (s *S3PredictionStorage) Save(ctx context.Context, prediction *entity.Prediction) error {
+	return s.SaveBatch(ctx, []*entity.Prediction{prediction})
+}
+
+// SaveBatch saves multiple predictions, writing each game's object once
+func (s *S3PredictionStorage) SaveBatch(ctx context.Context, predictions []*entity.Prediction) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	byGame := make(map[valueobject.GameType][]*entity.Prediction)
+	for _, pred := range predictions {
+		byGame[pred.GameType] = append(byGame[pred.GameType], pred)
+	}
+
+	for gameType, gamePredictions := range byGame {
+		stored, err := s.loadPredictions(ctx, gameType)
+		if err != nil {
+			return err
+		}
+		for _, pred := range gamePredictions {
+			stored = replaceByID(stored, pred, func(p *entity.Prediction) string { return p.ID })
+		}
+		if err := s.writePredictions(ctx, gameType, stored); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SaveEnsemble saves an ensemble prediction, assigning an ID if it has none.
+// Unless history is kept, older ensembles for the same game type and target
+// draw date are deleted, leaving one current prediction per draw.
+func (s *S3PredictionStorage) SaveEnsemble(ctx context.Context, ensemble *entity.EnsemblePrediction) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if ensemble.ID == "" {
+		ensemble.ID = uuid.New().String()
+	}
+
+	stored, err := s.loadEnsembles(ctx, ensemble.GameType)
+	if err != nil {
+		return err
+	}
+
+	kept := make([]*entity.EnsemblePrediction, 0, len(stored)+1)
+	for _, other := range stored {
+		superseded := !s.keepHistory && !ensemble.ForDate.IsZero() &&
+			other.SameTarget(ensemble) && !other.GeneratedAt.After(ensemble.GeneratedAt)
+		if other.ID != ensemble.ID && !superseded {
+			kept = append(kept, other)
+		}
+	}
+	kept = append(kept, ensemble)
+
+	return s.writeEnsembles(ctx, ensemble.GameType, kept)
+}
+
+// FindByID finds a prediction by ID
+func (s *S3PredictionStorage) FindByID(ctx context.Context, id string) (*entity.Prediction, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, gameType := range valueobject.AllGameTypes() {
+		predictions, err := s.loadPredictions(ctx, gameType)
+		if err != nil {
+			return nil, err
+		}
+		for _, pred := range predictions {
+			if pred.ID == id {
+				return pred, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("prediction with ID %s not found", id)
+}
+
+// FindEnsembleByID finds an ensemble prediction by ID
+func (s *S3PredictionStorage) FindEnsembleByID(ctx context.Context, id string) (*entity.EnsemblePrediction, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, gameType := range valueobject.AllGameTypes() {
+		ensembles, err := s.loadEnsembles(ctx, gameType)
+		if err != nil {
+			return nil, err
+		}
+		for _, ensemble := range ensembles {
+			if ensemble.ID == id {
+				return ensemble, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("ensemble prediction with ID %s not found", id)
+}
+
+// FindLatest finds the most recent predictions for a game type
+func (s *S3PredictionStorage) FindLatest(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	limit int,
+) ([]*entity.Prediction, error) {
+	return s.findPredictions(ctx, gameType, limit, func(*entity.Prediction) bool { return true })
+}
+
+// FindLatestEnsembles finds the most recent ensemble predictions for a game type
+func (s *S3PredictionStorage) FindLatestEnsembles(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	limit int,
+) ([]*entity.EnsemblePrediction, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, err := s.loadEnsembles(ctx, gameType)
+	if err != nil {
+		return nil, err
+	}
+
+	ensembles := append([]*entity.EnsemblePrediction(nil), stored...)
+	sortEnsemblesByDate(ensembles, false)
+	if len(ensembles) > limit {
+		ensembles = ensembles[:limit]
+	}
+	return ensembles, nil
+}
+
+// FindCurrentEnsemble finds the newest ensemble prediction for a game type
+// and target draw date
+func (s *S3PredictionStorage) FindCurrentEnsemble(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	forDate time.Time,
+) (*entity.EnsemblePrediction, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ensembles, err := s.loadEnsembles(ctx, gameType)
+	if err != nil {
+		return nil, err
+	}
+
+	var current *entity.EnsemblePrediction
+	for _, ensemble := range ensembles {
+		if !ensemble.ForDate.Equal(forDate) {
+			continue
+		}
+		if current == nil || ensemble.GeneratedAt.After(current.GeneratedAt) {
+			current = ensemble
+		}
+	}
+
+	if current == nil {
+		return nil, fmt.Errorf("ensemble prediction for %s on %s: %w", gameType, forDate.Format("2006-01-02"), repository.ErrNotFound)
+	}
+	return current, nil
+}
+
+// FindByAlgorithm finds predictions for a specific algorithm and game type
+func (s *S3PredictionStorage) FindByAlgorithm(
+	ctx context.Context,
+	algorithmName string,
+	gameType valueobject.GameType,
+	limit int,
+) ([]*entity.Prediction, error) {
+	return s.findPredictions(ctx, gameType, limit, func(pred *entity.Prediction) bool {
+		return pred.AlgorithmName == algorithmName
+	})
+}
+
+// FindByDateRange finds predictions within a date range for a game type
+func (s *S3PredictionStorage) FindByDateRange(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	dateRange valueobject.DateRange,
+) ([]*entity.Prediction, error) {
+	start, end := dateRange.StartDate, dateRange.EndDate
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, err := s.loadPredictions(ctx, gameType)
+	if err != nil {
+		return nil, err
+	}
+
+	predictions := make([]*entity.Prediction, 0)
+	for _, pred := range stored {
+		if !pred.GeneratedAt.Before(start) && pred.GeneratedAt.Before(end) {
+			predictions = append(predictions, pred)
+		}
+	}
+	return predictions, nil
+}
+
+// Count returns the total number of predictions for a game type
+func (s *S3PredictionStorage) Count(ctx context.Context, gameType valueobject.GameType) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	predictions, err := s.loadPredictions(ctx, gameType)
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(predictions)), nil
+}
+
+// DeleteOld deletes predictions and ensemble predictions generated before
+// before. Each one is logged with its ID.
+func (s *S3PredictionStorage) DeleteOld(ctx context.Context, before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, gameType := range valueobject.AllGameTypes() {
+		predictions, err := s.loadPredictions(ctx, gameType)
+		if err != nil {
+			return err
+		}
+		kept := make([]*entity.Prediction, 0, len(predictions))
+		for _, pred := range predictions {
+			if pred.GeneratedAt.Before(before) {
+				logDeletedPrediction(pred.ID, pred.GeneratedAt)
+				continue
+			}
+			kept = append(kept, pred)
+		}
+		if len(kept) < len(predictions) {
+			if err := s.writePredictions(ctx, gameType, kept); err != nil {
+				return err
+			}
+		}
+
+		ensembles, err := s.loadEnsembles(ctx, gameType)
+		if err != nil {
+			return err
+		}
+		keptEnsembles := make([]*entity.EnsemblePrediction, 0, len(ensembles))
+		for _, ensemble := range ensembles {
+			if ensemble.GeneratedAt.Before(before) {
+				logDeletedPrediction(ensemble.ID, ensemble.GeneratedAt)
+				continue
+			}
+			keptEnsembles = append(keptEnsembles, ensemble)
+		}
+		if len(keptEnsembles) < len(ensembles) {
+			if err := s.writeEnsembles(ctx, gameType, keptEnsembles); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// findPredictions returns a game's predictions match accepts, newest first
+// and at most limit of them
+func (s *S3PredictionStorage) findPredictions(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	limit int,
+	match func(pred *entity.Prediction) bool,
+) ([]*entity.Prediction, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, err := s.loadPredictions(ctx, gameType)
+	if err != nil {
+		return nil, err
+	}
+
+	predictions := make([]*entity.Prediction, 0, limit)
+	for _, pred := range stored {
+		if match(pred) {
+			predictions = append(predictions, pred)
+		}
+	}
+	sortPredictionsByDate(predictions, false)
+	if len(predictions) > limit {
+		predictions = predictions[:limit]
+	}
+	return predictions, nil
+}
+
+// loadPredictions returns a game's stored predictions, reading the object on
+// first use. Callers must hold the lock.
+func (s *S3PredictionStorage) loadPredictions(ctx context.Context, gameType valueobject.GameType) ([]*entity.Prediction, error) {
+	if predictions, ok := s.predictions[gameType]; ok {
+		return predictions, nil
+	}
+
+	var predictions []*entity.Prediction
+	err := loadJSONLinesObject(ctx, s.store, s.key("predictions", gameType), &s.corrupt, func(line []byte) error {
+		var pred entity.Prediction
+		if err := json.Unmarshal(line, &pred); err != nil {
+			return err
+		}
+		predictions = append(predictions, &pred)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.predictions[gameType] = predictions
+	return predictions, nil
+}
+
+// loadEnsembles returns a game's stored ensembles, reading the object on
+// first use. Callers must hold the lock.
+func (s *S3PredictionStorage) loadEnsembles(ctx context.Context, gameType valueobject.GameType) ([]*entity.EnsemblePrediction, error) {
+	if ensembles, ok := s.ensembles[gameType]; ok {
+		return ensembles, nil
+	}
+
+	var ensembles []*entity.EnsemblePrediction
+	err := loadJSONLinesObject(ctx, s.store, s.key("ensembles", gameType), &s.corrupt, func(line []byte) error {
+		var ensemble entity.EnsemblePrediction
+		if err := json.Unmarshal(line, &ensemble); err != nil {
+			return err
+		}
+		ensembles = append(ensembles, &ensemble)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.ensembles[gameType] = ensembles
+	return ensembles, nil
+}
+
+// writePredictions replaces a game's predictions object. On failure the
+// cached copy is dropped, so the next read reloads what the bucket holds.
+func (s *S3PredictionStorage) writePredictions(ctx context.Context, gameType valueobject.GameType, predictions []*entity.Prediction) error {
+	data, err := encodeJSONLines(predictions)
+	if err == nil {
+		err = s.store.Put(ctx, s.key("predictions", gameType), data)
+	}
+	if err != nil {
+		delete(s.predictions, gameType)
+		return fmt.Errorf("failed to write predictions: %w", err)
+	}
+	s.predictions[gameType] = predictions
+	return nil
+}
+
+// writeEnsembles replaces a game's ensembles object, like writePredictions
+func (s *S3PredictionStorage) writeEnsembles(ctx context.Context, gameType valueobject.GameType, ensembles []*entity.EnsemblePrediction) error {
+	data, err := encodeJSONLines(ensembles)
+	if err == nil {
+		err = s.store.Put(ctx, s.key("ensembles", gameType), data)
+	}
+	if err != nil {
+		delete(s.ensembles, gameType)
+		return fmt.Errorf("failed to write ensembles: %w", err)
+	}
+	s.ensembles[gameType] = ensembles
+	return nil
+}
+
+func (s *S3PredictionStorage) key(kind string, gameType valueobject.GameType) string {
+	return path.Join(s.prefix, kind, strings.ToLower(string(gameType))+".jsonl")
+}
+
+// replaceByID returns records with record in place of the one sharing its
+// ID, or appended if none does
+func replaceByID[T any](records []T, record T, id func(T) string) []T {
+	updated := make([]T, 0, len(records)+1)
+	for _, existing := range records {
+		if id(existing) != id(record) {
+			updated = append(updated, existing)
+		}
+	}
+	return append(updated, record)
+}
+
+func logDeletedPrediction(id string, generatedAt time.Time) {
+	logger.Info("Deleted old prediction",
+		zap.String("id", id),
+		zap.Time("generated_at", generatedAt),
+	)
+}
+
+// Ensure S3PredictionStorage implements repository.PredictionRepository
+var _ repository.PredictionRepository = (*S3PredictionStorage)(nil)
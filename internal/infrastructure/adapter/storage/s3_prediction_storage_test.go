@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func TestS3PredictionStorage_SaveEnsemble_SupersedesSameTarget(t *testing.T) {
+	client, _ := newFakeS3Client(t)
+	store := NewS3PredictionStorage(client, "data")
+	ctx := context.Background()
+
+	saturday := time.Date(2026, 1, 17, 0, 0, 0, 0, time.UTC)
+	monday := newTestEnsemble(t, saturday, time.Date(2026, 1, 12, 9, 0, 0, 0, time.UTC))
+	friday := newTestEnsemble(t, saturday, time.Date(2026, 1, 16, 9, 0, 0, 0, time.UTC))
+	nextDraw := newTestEnsemble(t, saturday.AddDate(0, 0, 4), time.Date(2026, 1, 16, 9, 0, 0, 0, time.UTC))
+
+	require.NoError(t, store.SaveEnsemble(ctx, monday))
+	require.NoError(t, store.SaveEnsemble(ctx, friday))
+	require.NoError(t, store.SaveEnsemble(ctx, nextDraw))
+
+	// A fresh storage reads back what the first one wrote
+	reloaded := NewS3PredictionStorage(client, "data")
+	ensembles, err := reloaded.FindLatestEnsembles(ctx, valueobject.Mega645, 10)
+	require.NoError(t, err)
+	require.Len(t, ensembles, 2)
+
+	current, err := reloaded.FindCurrentEnsemble(ctx, valueobject.Mega645, saturday)
+	require.NoError(t, err)
+	assert.Equal(t, friday.ID, current.ID)
+
+	_, err = reloaded.FindEnsembleByID(ctx, monday.ID)
+	assert.Error(t, err)
+}
+
+func TestS3PredictionStorage_SaveEnsemble_KeepHistory(t *testing.T) {
+	client, _ := newFakeS3Client(t)
+	store := NewS3PredictionStorage(client, "data")
+	store.SetKeepHistory(true)
+	ctx := context.Background()
+
+	saturday := time.Date(2026, 1, 17, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, store.SaveEnsemble(ctx, newTestEnsemble(t, saturday, time.Date(2026, 1, 12, 9, 0, 0, 0, time.UTC))))
+	require.NoError(t, store.SaveEnsemble(ctx, newTestEnsemble(t, saturday, time.Date(2026, 1, 16, 9, 0, 0, 0, time.UTC))))
+
+	ensembles, err := store.FindLatestEnsembles(ctx, valueobject.Mega645, 10)
+	require.NoError(t, err)
+	assert.Len(t, ensembles, 2)
+}
+
+func TestS3PredictionStorage_PredictionsAndDeleteOld(t *testing.T) {
+	client, _ := newFakeS3Client(t)
+	store := NewS3PredictionStorage(client, "data")
+	ctx := context.Background()
+
+	numbers := valueobject.MustNewNumbers([]int{3, 9, 17, 22, 30, 41})
+	var predictions []*entity.Prediction
+	for day := 1; day <= 3; day++ {
+		pred, err := entity.NewPrediction(valueobject.Mega645, "frequency_analysis", numbers, 0.5, time.Time{})
+		require.NoError(t, err)
+		pred.GeneratedAt = time.Date(2026, 1, day, 9, 0, 0, 0, time.UTC)
+		predictions = append(predictions, pred)
+	}
+	require.NoError(t, store.SaveBatch(ctx, predictions))
+
+	byAlgorithm, err := store.FindByAlgorithm(ctx, "frequency_analysis", valueobject.Mega645, 2)
+	require.NoError(t, err)
+	require.Len(t, byAlgorithm, 2)
+	assert.Equal(t, predictions[2].ID, byAlgorithm[0].ID)
+
+	require.NoError(t, store.DeleteOld(ctx, time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)))
+
+	reloaded := NewS3PredictionStorage(client, "data")
+	count, err := reloaded.Count(ctx, valueobject.Mega645)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	_, err = reloaded.FindByID(ctx, predictions[0].ID)
+	assert.Error(t, err)
+	found, err := reloaded.FindByID(ctx, predictions[1].ID)
+	require.NoError(t, err)
+	assert.Equal(t, predictions[1].ID, found.ID)
+}
@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/tool_predict/internal/application/port"
+)
+
+// ScraperHealthFile keeps the scraper fallback chain's health in one JSON
+// file, rewritten atomically on each save
+type ScraperHealthFile struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewScraperHealthFile creates a health store backed by the file at path,
+// which is created on the first save
+func NewScraperHealthFile(path string) *ScraperHealthFile {
+	return &ScraperHealthFile{path: path}
+}
+
+// Load returns the saved health, or an empty record when the file doesn't
+// exist yet
+func (f *ScraperHealthFile) Load() (*port.ScraperHealth, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	health := port.NewScraperHealth()
+	if err := readJSONFile(f.path, health); err != nil {
+		if os.IsNotExist(err) {
+			return health, nil
+		}
+		return nil, fmt.Errorf("invalid scraper health %s: %w", f.path, err)
+	}
+	if health.Backends == nil {
+		health.Backends = make(map[string]*port.ScraperBackendHealth)
+	}
+	return health, nil
+}
+
+// Save replaces the saved health
+func (f *ScraperHealthFile) Save(health *port.ScraperHealth) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(f.path), 0755); err != nil {
+		return fmt.Errorf("failed to create scraper health directory: %w", err)
+	}
+	return writeJSONFile(f.path, health)
+}
+
+// Ensure ScraperHealthFile implements port.ScraperHealthStore
+var _ port.ScraperHealthStore = (*ScraperHealthFile)(nil)
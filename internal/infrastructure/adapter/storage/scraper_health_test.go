@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/application/port"
+)
+
+func TestScraperHealthFile_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "scraper_health.json")
+	store := NewScraperHealthFile(path)
+
+	health, err := store.Load()
+	require.NoError(t, err)
+	assert.Empty(t, health.Backends)
+	assert.Empty(t, health.LastWorking)
+
+	saved := &port.ScraperHealth{
+		Backends: map[string]*port.ScraperBackendHealth{
+			"api": {Name: "api", Failures: 2, LastFailure: time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC), LastError: "status 503"},
+			"web": {Name: "web", Successes: 3, LastSuccess: time.Date(2026, 1, 15, 10, 1, 0, 0, time.UTC)},
+		},
+		LastWorking: "web",
+		UpdatedAt:   time.Date(2026, 1, 15, 10, 1, 0, 0, time.UTC),
+	}
+	require.NoError(t, store.Save(saved))
+
+	loaded, err := NewScraperHealthFile(path).Load()
+	require.NoError(t, err)
+	assert.Equal(t, saved, loaded)
+}
+
+func TestScraperHealthFile_LoadCorrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scraper_health.json")
+	require.NoError(t, os.WriteFile(path, []byte("{not json"), 0644))
+
+	_, err := NewScraperHealthFile(path).Load()
+	assert.Error(t, err)
+}
+
+func TestScraperBackendHealth_SuccessRate(t *testing.T) {
+	assert.Zero(t, (&port.ScraperBackendHealth{}).SuccessRate())
+	assert.InDelta(t, 0.75, (&port.ScraperBackendHealth{Successes: 3, Failures: 1}).SuccessRate(), 1e-9)
+}
@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// trashDirName is the per-directory subfolder deleted files are moved to.
+// Read loops skip directories, so trashed files are invisible to queries.
+const trashDirName = ".trash"
+
+// trashTimeFormat prefixes trashed file names so several deletions of the
+// same ID can coexist and sort chronologically
+const trashTimeFormat = "20060102T150405.000000000"
+
+// moveToTrash moves a file into the .trash subdirectory next to it
+func moveToTrash(filename string) error {
+	return moveAside(filename, trashDirName)
+}
+
+// moveAside moves a file into the named subdirectory next to it, prefixing
+// its name with the time so several copies of the same file can coexist
+func moveAside(filename, dirName string) error {
+	dir := filepath.Join(filepath.Dir(filename), dirName)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s directory: %w", dirName, err)
+	}
+
+	moved := filepath.Join(dir, time.Now().UTC().Format(trashTimeFormat)+"_"+filepath.Base(filename))
+	if err := os.Rename(filename, moved); err != nil {
+		return fmt.Errorf("failed to move %s to %s: %w", filename, dirName, err)
+	}
+	// A later file of the same name mustn't be recovered from this one's backup
+	removeBackup(filename)
+	return nil
+}
+
+// restoreFromTrash moves the most recently trashed copy of id back into dir.
+// It reports false if dir's trash holds no copy of id.
+func restoreFromTrash(dir string, id string) (bool, error) {
+	trashDir := filepath.Join(dir, trashDirName)
+	files, err := os.ReadDir(trashDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	// Names sort chronologically thanks to the timestamp prefix
+	latest := ""
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		_, original, ok := strings.Cut(file.Name(), "_")
+		if ok && original == id+".json" && file.Name() > latest {
+			latest = file.Name()
+		}
+	}
+	if latest == "" {
+		return false, nil
+	}
+
+	target := filepath.Join(dir, id+".json")
+	if _, err := os.Stat(target); err == nil {
+		return false, fmt.Errorf("cannot restore %s: a live copy already exists", id)
+	}
+
+	if err := os.Rename(filepath.Join(trashDir, latest), target); err != nil {
+		return false, fmt.Errorf("failed to restore %s: %w", id, err)
+	}
+	return true, nil
+}
+
+// emptyTrash permanently removes every trashed file under dir and returns
+// how many were purged
+func emptyTrash(dir string) (int, error) {
+	trashDir := filepath.Join(dir, trashDirName)
+	files, err := os.ReadDir(trashDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	purged := 0
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		if err := os.Remove(filepath.Join(trashDir, file.Name())); err != nil {
+			return purged, fmt.Errorf("failed to purge %s: %w", file.Name(), err)
+		}
+		purged++
+	}
+	return purged, nil
+}
@@ -0,0 +1,127 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func newTestPredictionStorage(t *testing.T) *PredictionJSONStorage {
+	t.Helper()
+
+	store, err := NewPredictionJSONStorage(t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(store.getGameTypeDir("predictions", valueobject.Mega645), 0755))
+	return store
+}
+
+func newTestPrediction(t *testing.T, generatedAt time.Time) *entity.Prediction {
+	t.Helper()
+
+	pred, err := entity.NewPrediction(
+		valueobject.Mega645,
+		"frequency_analysis",
+		valueobject.MustNewNumbers([]int{3, 9, 17, 22, 30, 41}),
+		0.5,
+		generatedAt.Add(24*time.Hour),
+	)
+	require.NoError(t, err)
+	pred.GeneratedAt = generatedAt
+	return pred
+}
+
+func TestPredictionJSONStorage_DeleteOld_IsRecoverable(t *testing.T) {
+	store := newTestPredictionStorage(t)
+	ctx := context.Background()
+
+	old := newTestPrediction(t, time.Now().AddDate(0, -2, 0))
+	recent := newTestPrediction(t, time.Now())
+	require.NoError(t, store.Save(ctx, old))
+	require.NoError(t, store.Save(ctx, recent))
+
+	require.NoError(t, store.DeleteOld(ctx, time.Now().AddDate(0, -1, 0)))
+
+	// The old prediction is gone from queries
+	_, err := store.FindByID(ctx, old.ID)
+	require.Error(t, err)
+	count, err := store.Count(ctx, valueobject.Mega645)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	// But can be restored
+	require.NoError(t, store.Restore(ctx, old.ID))
+	restored, err := store.FindByID(ctx, old.ID)
+	require.NoError(t, err)
+	assert.Equal(t, old.Numbers, restored.Numbers)
+
+	// Restoring again finds nothing left in the trash
+	assert.Error(t, store.Restore(ctx, old.ID))
+}
+
+func TestPredictionJSONStorage_EmptyTrash(t *testing.T) {
+	store := newTestPredictionStorage(t)
+	ctx := context.Background()
+
+	old := newTestPrediction(t, time.Now().AddDate(0, -2, 0))
+	require.NoError(t, store.Save(ctx, old))
+	require.NoError(t, store.DeleteOld(ctx, time.Now()))
+
+	purged, err := store.EmptyTrash(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+
+	err = store.Restore(ctx, old.ID)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found in trash")
+}
+
+func TestPredictionJSONStorage_Restore_KeepsLiveCopy(t *testing.T) {
+	store := newTestPredictionStorage(t)
+	ctx := context.Background()
+
+	pred := newTestPrediction(t, time.Now().AddDate(0, -2, 0))
+	require.NoError(t, store.Save(ctx, pred))
+	require.NoError(t, store.DeleteOld(ctx, time.Now()))
+	require.NoError(t, store.Save(ctx, pred))
+
+	err := store.Restore(ctx, pred.ID)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "live copy already exists")
+}
+
+func TestBacktestJSONStorage_DeleteOld_IsRecoverable(t *testing.T) {
+	store, err := NewBacktestJSONStorage(t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(store.getGameTypeDir("backtests", valueobject.Power655), 0755))
+	ctx := context.Background()
+
+	end := time.Now().AddDate(0, -2, 0)
+	result, err := entity.NewBacktestResult(
+		valueobject.Power655,
+		"hot_cold_analysis",
+		valueobject.MustNewDateRange(end.AddDate(0, -1, 0), end),
+		30,
+	)
+	require.NoError(t, err)
+	require.NoError(t, store.Save(ctx, result))
+
+	require.NoError(t, store.DeleteOld(ctx, time.Now()))
+	_, err = store.FindByID(ctx, result.ID)
+	require.Error(t, err)
+
+	require.NoError(t, store.Restore(ctx, result.ID))
+	_, err = store.FindByID(ctx, result.ID)
+	require.NoError(t, err)
+
+	require.NoError(t, store.DeleteOld(ctx, time.Now()))
+	purged, err := store.EmptyTrash(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+	assert.Error(t, store.Restore(ctx, result.ID))
+}
@@ -1,10 +1,16 @@
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/spf13/viper"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/pkg/analytics"
 )
 
 // Config represents the application configuration
@@ -12,10 +18,13 @@ type Config struct {
 	App        AppConfig       `mapstructure:"app"`
 	Scraper    ScraperConfig   `mapstructure:"scraper"`
 	GRPC       GRPCConfig      `mapstructure:"grpc"`
+	API        APIConfig       `mapstructure:"api"`
 	Storage    StorageConfig   `mapstructure:"storage"`
 	Algorithms AlgorithmConfig `mapstructure:"algorithms"`
 	Ensemble   EnsembleConfig  `mapstructure:"ensemble"`
 	Backtest   BacktestConfig  `mapstructure:"backtest"`
+	Notify     NotifyConfig    `mapstructure:"notify"`
+	Daemon     DaemonConfig    `mapstructure:"daemon"`
 }
 
 // AppConfig represents application-level configuration
@@ -23,21 +32,52 @@ type AppConfig struct {
 	Name        string `mapstructure:"name"`
 	Environment string `mapstructure:"environment"`
 	LogLevel    string `mapstructure:"log_level"`
+	GamesFile   string `mapstructure:"games_file"` // games.yaml overriding number ranges and draw days; empty keeps the built-in rules
 }
 
 // ScraperConfig represents scraper configuration
 type ScraperConfig struct {
+	Engine   string                `mapstructure:"engine"` // http (API, then static HTML), chromedp (headless Chrome) or fallback
 	Vietlott VietlottScraperConfig `mapstructure:"vietlott"`
+	Chromedp ChromedpConfig        `mapstructure:"chromedp"`
+	Fallback FallbackScraperConfig `mapstructure:"fallback"`
+	PDF      PDFScraperConfig      `mapstructure:"pdf"`
+}
+
+// PDFScraperConfig represents the official result PDFs read by the fallback
+// engine's pdf backend and the parse-pdf command
+type PDFScraperConfig struct {
+	CacheDir    string `mapstructure:"cache_dir"`    // Where downloaded PDFs are kept
+	OCRLanguage string `mapstructure:"ocr_language"` // tesseract language for PDFs without text; empty disables OCR
+}
+
+// FallbackScraperConfig represents the fallback engine, which tries each
+// backend in turn and starts from the one that worked last
+type FallbackScraperConfig struct {
+	Backends []string `mapstructure:"backends"` // Any of api, web, chromedp and pdf, in the order tried
+}
+
+// ChromedpConfig represents the headless browser used by the chromedp
+// engine, for results pages rendered by JavaScript
+type ChromedpConfig struct {
+	ExecPath string        `mapstructure:"exec_path"` // Chrome or Chromium binary; empty looks in the usual places
+	Timeout  time.Duration `mapstructure:"timeout"`   // Limit on loading and rendering one page
+	Settle   time.Duration `mapstructure:"settle"`    // Wait after the page is ready for its scripts to fill in the results
 }
 
 // VietlottScraperConfig represents Vietlott-specific scraper configuration
 type VietlottScraperConfig struct {
-	BaseURL      string        `mapstructure:"base_url"`
-	Mega645Path  string        `mapstructure:"mega_645_path"`
-	Power655Path string        `mapstructure:"power_655_path"`
-	Timeout      time.Duration `mapstructure:"timeout"`
-	RetryCount   int           `mapstructure:"retry_count"`
-	RateLimit    int           `mapstructure:"rate_limit"`
+	BaseURL       string        `mapstructure:"base_url"`
+	Mega645Path   string        `mapstructure:"mega_645_path"`
+	Power655Path  string        `mapstructure:"power_655_path"`
+	Timeout       time.Duration `mapstructure:"timeout"`
+	RetryCount    int           `mapstructure:"retry_count"`
+	RateLimit     int           `mapstructure:"rate_limit"`
+	PageSize      int           `mapstructure:"page_size"`      // Max draws per API page; larger fetches paginate
+	Mode          string        `mapstructure:"mode"`           // live, record or replay
+	RecordingsDir string        `mapstructure:"recordings_dir"` // Where record mode saves and replay mode reads responses
+	FetchDetails  bool          `mapstructure:"fetch_details"`  // Fetch each new draw's detail page for jackpot and winners
+	Audit         bool          `mapstructure:"audit"`          // Log every results page crawl to <base_path>/scrape_audits
 }
 
 // GRPCConfig represents gRPC configuration
@@ -59,39 +99,114 @@ type ServerGRPCConfig struct {
 	EnableReflection bool `mapstructure:"enable_reflection"`
 }
 
+// APIConfig represents REST API server configuration
+type APIConfig struct {
+	Host               string        `mapstructure:"host"` // Interface to listen on; "0.0.0.0" or "" for every interface
+	Port               int           `mapstructure:"port"`
+	CORSOrigins        []string      `mapstructure:"cors_origins"`        // Browser origins allowed to call the API; "*" allows any, empty none
+	Token              string        `mapstructure:"token"`               // Bearer token POST requests must carry; empty falls back to PREDICTOR_API_TOKEN, and without either none is required
+	PredictionInterval time.Duration `mapstructure:"prediction_interval"` // Least time between generated predictions; 0 doesn't limit them
+}
+
 // StorageConfig represents storage configuration
 type StorageConfig struct {
-	Type   string       `mapstructure:"type"` // "json" or "sqlite"
-	SQLite SQLiteConfig `mapstructure:"sqlite"`
-	JSON   JSONConfig   `mapstructure:"json"`
+	Type      string          `mapstructure:"type"` // "json", "jsonl", "s3" or "composite"
+	JSON      JSONConfig      `mapstructure:"json"`
+	S3        S3Config        `mapstructure:"s3"`
+	Composite CompositeConfig `mapstructure:"composite"` // Backends written when type is "composite"
+
+	// Days stored predictions are kept before prune moves them to the trash;
+	// 0 keeps them forever
+	PredictionRetentionDays int `mapstructure:"prediction_retention_days"`
+}
+
+// CompositeConfig lists the draw storage backends written at once, e.g.
+// while migrating between them. Draws are read from the first; at least two
+// different backends are needed.
+type CompositeConfig struct {
+	Backends []string `mapstructure:"backends"`
 }
 
-// SQLiteConfig represents SQLite storage configuration
-type SQLiteConfig struct {
-	Path string `mapstructure:"path"`
+// S3Config represents object storage configuration for any S3 compatible
+// service. For Google Cloud Storage use endpoint https://storage.googleapis.com,
+// region "auto" and an HMAC key. Empty credentials fall back to the
+// AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN variables.
+type S3Config struct {
+	Bucket          string        `mapstructure:"bucket"`
+	Prefix          string        `mapstructure:"prefix"` // Key prefix objects are stored under
+	Region          string        `mapstructure:"region"`
+	Endpoint        string        `mapstructure:"endpoint"`       // Service URL; AWS S3 for the region if empty
+	UsePathStyle    bool          `mapstructure:"use_path_style"` // Address the bucket in the path, as MinIO needs
+	AccessKeyID     string        `mapstructure:"access_key_id"`
+	SecretAccessKey string        `mapstructure:"secret_access_key"`
+	Timeout         time.Duration `mapstructure:"timeout"`
 }
 
 // JSONConfig represents JSON file storage configuration
 type JSONConfig struct {
-	BasePath string `mapstructure:"base_path"`
+	BasePath              string `mapstructure:"base_path"`
+	KeepPredictionHistory bool   `mapstructure:"keep_prediction_history"` // Keep superseded predictions for the same target draw
+	SaveConcurrency       int    `mapstructure:"save_concurrency"`        // Draws saved at once when fetching
 }
 
 // AlgorithmConfig represents algorithm configuration
 type AlgorithmConfig struct {
-	Enabled []string                    `mapstructure:"enabled"`
-	Configs map[string]AlgorithmDetails `mapstructure:",remain"`
+	Enabled         []string                    `mapstructure:"enabled"`
+	RecencyHalfLife float64                     `mapstructure:"recency_half_life"` // Draws until a draw counts half; 0 disables decay
+	Mega645         GameAlgorithmConfig         `mapstructure:"mega_6_45"`         // Overrides for Mega 6/45
+	Power655        GameAlgorithmConfig         `mapstructure:"power_6_55"`        // Overrides for Power 6/55
+	Configs         map[string]AlgorithmDetails `mapstructure:",remain"`
+}
+
+// GameAlgorithmConfig represents per-game-type algorithm overrides
+type GameAlgorithmConfig struct {
+	Disabled     []string            `mapstructure:"disabled"`      // Enabled algorithms to skip for this game type
+	RangeChanges []RangeChangeConfig `mapstructure:"range_changes"` // Smaller pools of older draws, for frequency normalization
+}
+
+// RangeChangeConfig records a past expansion of a game's number range
+type RangeChangeConfig struct {
+	Before   string `mapstructure:"before"`    // YYYY-MM-DD; draws before this date used the smaller pool
+	PoolSize int    `mapstructure:"pool_size"` // Numbers 1 to PoolSize were drawn
 }
 
 // AlgorithmDetails represents individual algorithm configuration
 type AlgorithmDetails struct {
-	Weight float64 `mapstructure:"weight"`
+	Weight float64  `mapstructure:"weight"`
+	Alpha  *float64 `mapstructure:"alpha"` // combined_score: frequency share of the score, 0-1; unset uses the default
+
+	// hot_cold_analysis: latest draws counted for hot numbers, and draws
+	// since last drawn for cold ones; at least 5, unset uses the default
+	HotThreshold  *int `mapstructure:"hot_threshold"`
+	ColdThreshold *int `mapstructure:"cold_threshold"`
+
+	// monte_carlo: draws simulated per prediction, and the random seed that
+	// makes predictions reproducible; unset simulates the default count unseeded
+	Simulations *int    `mapstructure:"simulations"`
+	Seed        *uint64 `mapstructure:"seed"`
+
+	PriorAlpha *float64 `mapstructure:"prior_alpha"` // bayesian: Dirichlet prior concentration of each number, positive; unset uses the default
+	HalfLife   *float64 `mapstructure:"half_life"`   // Recency half-life in draws for this algorithm, 0 disables decay; unset uses recency_half_life
+	MinDraws   *int     `mapstructure:"min_draws"`   // Draws needed before the algorithm predicts; unset uses its default
 	// Add more algorithm-specific settings as needed
 }
 
 // EnsembleConfig represents ensemble configuration
 type EnsembleConfig struct {
-	VotingStrategy string `mapstructure:"voting_strategy"` // "weighted", "majority", "confidence_weighted"
-	MinPredictions int    `mapstructure:"min_predictions"`
+	VotingStrategy   string  `mapstructure:"voting_strategy"` // "weighted", "majority", "confidence_weighted", "per_number_weighted", "stacking"
+	MinPredictions   int     `mapstructure:"min_predictions"`
+	CacheSize        int     `mapstructure:"cache_size"`        // Max cached predictions per process, 0 disables
+	Alternates       int     `mapstructure:"alternates"`        // Runner-up numbers shown as "consider also"
+	NormalizeWeights bool    `mapstructure:"normalize_weights"` // Rescale algorithm weights to sum to WeightTotal
+	WeightTotal      float64 `mapstructure:"weight_total"`
+	AgreementScores  bool    `mapstructure:"agreement_scores"`  // Scale confidence by historical agreement with winning numbers
+	TieBreak         string  `mapstructure:"tie_break"`         // Order of tied numbers: "low", "high", "hot" or "cold"
+	ConfidenceMethod string  `mapstructure:"confidence_method"` // Overall confidence: "mean", "weighted" or "consensus"
+	MinVoters        int     `mapstructure:"min_voters"`        // Prefer final numbers picked by at least this many algorithms; 0 disables
+	UseTunedWeights  bool    `mapstructure:"use_tuned_weights"` // Vote with the weights tuning saved to the algorithm stats over the configured ones
+
+	// Distinct algorithms whose picks the final numbers must include; 0 disables
+	MinAlgorithmDiversity int `mapstructure:"min_algorithm_diversity"`
 }
 
 // BacktestConfig represents backtesting configuration
@@ -101,6 +216,56 @@ type BacktestConfig struct {
 	EnableAutoWeightUpdate bool `mapstructure:"enable_auto_weight_update"`
 }
 
+// NotifyConfig represents notification configuration
+type NotifyConfig struct {
+	WebhookURL string         `mapstructure:"webhook_url"` // Slack, Discord or other chat webhook; empty disables notifications
+	Timeout    time.Duration  `mapstructure:"timeout"`     // Limit on each webhook, Telegram and email delivery
+	Telegram   TelegramConfig `mapstructure:"telegram"`    // Bot announcing new predictions and how they did
+	Email      EmailConfig    `mapstructure:"email"`       // SMTP mail of the events below
+
+	// Events sent to the webhook and by email: prediction, verified and
+	// accuracy_drop; empty sends them all
+	Events []string `mapstructure:"events"`
+	// Smallest fall in an algorithm's 3+ number hit rate between its latest
+	// two backtests that the daemon reports as accuracy_drop, e.g. 0.01
+	AccuracyDropThreshold float64 `mapstructure:"accuracy_drop_threshold"`
+}
+
+// EmailConfig represents the SMTP server notifications are mailed through.
+// An empty password falls back to the SMTP_PASSWORD variable; without a host
+// or any recipient it's disabled.
+type EmailConfig struct {
+	SMTPHost string   `mapstructure:"smtp_host"`
+	SMTPPort int      `mapstructure:"smtp_port"`
+	Username string   `mapstructure:"username"` // Empty sends without authenticating
+	Password string   `mapstructure:"password"`
+	From     string   `mapstructure:"from"`
+	To       []string `mapstructure:"to"`
+}
+
+// TelegramConfig represents the Telegram bot that announces new predictions
+// and verification summaries. An empty bot token falls back to the
+// TELEGRAM_BOT_TOKEN variable; without a token or any chat it's disabled.
+type TelegramConfig struct {
+	BotToken string `mapstructure:"bot_token"`
+	ChatID   string `mapstructure:"chat_id"`    // Chat for every game without its own
+	Mega645  string `mapstructure:"mega_6_45"`  // Chat for Mega 6/45; empty uses chat_id
+	Power655 string `mapstructure:"power_6_55"` // Chat for Power 6/55; empty uses chat_id
+}
+
+// DaemonConfig represents the daemon's schedule. Each game's cron
+// expression (minute hour day-of-month month day-of-week) says when to fetch
+// its latest draw, verify the prediction for it and predict the next one;
+// an empty expression leaves the game out.
+type DaemonConfig struct {
+	Timezone      string        `mapstructure:"timezone"`       // IANA zone the expressions are read in
+	Mega645       string        `mapstructure:"mega_6_45"`      // Mega 6/45 draws Wed, Fri and Sun at 18:00 ICT
+	Power655      string        `mapstructure:"power_6_55"`     // Power 6/55 draws Tue, Thu and Sat at 18:00 ICT
+	FetchLimit    int           `mapstructure:"fetch_limit"`    // Latest draws fetched per run
+	RetryInterval time.Duration `mapstructure:"retry_interval"` // Wait before fetching again when the draw isn't published yet
+	MaxRetries    int           `mapstructure:"max_retries"`    // Fetches retried before predicting without the draw
+}
+
 // Load loads configuration from a file
 func Load(configPath string) (*Config, error) {
 	viper.SetConfigFile(configPath)
@@ -159,11 +324,24 @@ func setDefaults() {
 	viper.SetDefault("app.name", "tool_predict")
 	viper.SetDefault("app.environment", "development")
 	viper.SetDefault("app.log_level", "info")
-
+	viper.SetDefault("app.games_file", "")
+
+	viper.SetDefault("scraper.engine", "http")
+	viper.SetDefault("scraper.chromedp.exec_path", "")
+	viper.SetDefault("scraper.chromedp.timeout", 60*time.Second)
+	viper.SetDefault("scraper.chromedp.settle", 2*time.Second)
+	viper.SetDefault("scraper.fallback.backends", []string{"api", "web", "chromedp"})
+	viper.SetDefault("scraper.pdf.cache_dir", "./data/result_pdfs")
+	viper.SetDefault("scraper.pdf.ocr_language", "vie")
 	viper.SetDefault("scraper.vietlott.base_url", "https://vietlott.vn")
 	viper.SetDefault("scraper.vietlott.timeout", 30*time.Second)
 	viper.SetDefault("scraper.vietlott.retry_count", 3)
 	viper.SetDefault("scraper.vietlott.rate_limit", 2)
+	viper.SetDefault("scraper.vietlott.page_size", 100)
+	viper.SetDefault("scraper.vietlott.mode", "live")
+	viper.SetDefault("scraper.vietlott.recordings_dir", "./data/recordings")
+	viper.SetDefault("scraper.vietlott.fetch_details", false)
+	viper.SetDefault("scraper.vietlott.audit", true)
 
 	viper.SetDefault("grpc.too_predict.address", "localhost:50051")
 	viper.SetDefault("grpc.too_predict.timeout", 10*time.Second)
@@ -172,15 +350,56 @@ func setDefaults() {
 	viper.SetDefault("grpc.server.port", 50052)
 	viper.SetDefault("grpc.server.enable_reflection", true)
 
+	viper.SetDefault("api.host", "127.0.0.1")
+	viper.SetDefault("api.port", 8080)
+	viper.SetDefault("api.cors_origins", []string{})
+	viper.SetDefault("api.token", "")
+	viper.SetDefault("api.prediction_interval", 10*time.Second)
+
 	viper.SetDefault("storage.type", "json")
 	viper.SetDefault("storage.json.base_path", "./data")
-
+	viper.SetDefault("storage.json.keep_prediction_history", false)
+	viper.SetDefault("storage.json.save_concurrency", 4)
+	viper.SetDefault("storage.s3.prefix", "data")
+	viper.SetDefault("storage.s3.region", "us-east-1")
+	viper.SetDefault("storage.s3.use_path_style", false)
+	viper.SetDefault("storage.s3.timeout", "30s")
+	viper.SetDefault("storage.composite.backends", []string{"json", "jsonl"})
+	viper.SetDefault("storage.prediction_retention_days", 0)
+
+	viper.SetDefault("algorithms.recency_half_life", 0.0)
 	viper.SetDefault("ensemble.voting_strategy", "weighted")
 	viper.SetDefault("ensemble.min_predictions", 2)
+	viper.SetDefault("ensemble.cache_size", 0)
+	viper.SetDefault("ensemble.alternates", 4)
+	viper.SetDefault("ensemble.normalize_weights", false)
+	viper.SetDefault("ensemble.weight_total", 1.0)
+	viper.SetDefault("ensemble.agreement_scores", false)
+	viper.SetDefault("ensemble.tie_break", "low")
+	viper.SetDefault("ensemble.confidence_method", "mean")
+	viper.SetDefault("ensemble.min_voters", 0)
+	viper.SetDefault("ensemble.use_tuned_weights", true)
+	viper.SetDefault("ensemble.min_algorithm_diversity", 0)
 
 	viper.SetDefault("backtest.default_test_period_days", 30)
 	viper.SetDefault("backtest.default_test_period_draws", 30)
-	viper.SetDefault("backtest.enable_auto_weight_update", true)
+	viper.SetDefault("backtest.enable_auto_weight_update", false)
+
+	viper.SetDefault("notify.webhook_url", "")
+	viper.SetDefault("notify.timeout", 10*time.Second)
+	viper.SetDefault("notify.telegram.bot_token", "")
+	viper.SetDefault("notify.telegram.chat_id", "")
+	viper.SetDefault("notify.email.smtp_host", "")
+	viper.SetDefault("notify.email.smtp_port", 587)
+	viper.SetDefault("notify.events", []string{"prediction", "accuracy_drop"})
+	viper.SetDefault("notify.accuracy_drop_threshold", 0.01)
+
+	viper.SetDefault("daemon.timezone", "Asia/Ho_Chi_Minh")
+	viper.SetDefault("daemon.mega_6_45", "30 18 * * 0,3,5")
+	viper.SetDefault("daemon.power_6_55", "30 18 * * 2,4,6")
+	viper.SetDefault("daemon.fetch_limit", 5)
+	viper.SetDefault("daemon.retry_interval", 15*time.Minute)
+	viper.SetDefault("daemon.max_retries", 4)
 }
 
 // GetAlgorithmWeight returns the weight for a specific algorithm
@@ -191,6 +410,82 @@ func (c *Config) GetAlgorithmWeight(algorithmName string) float64 {
 	return 1.0 // default weight
 }
 
+// EnabledAlgorithmsFor returns the enabled algorithms minus those disabled
+// for the given game type
+func (c *Config) EnabledAlgorithmsFor(gameType valueobject.GameType) []string {
+	overrides := c.gameOverrides(gameType)
+
+	enabled := make([]string, 0, len(c.Algorithms.Enabled))
+	for _, name := range c.Algorithms.Enabled {
+		if !slices.Contains(overrides.Disabled, name) {
+			enabled = append(enabled, name)
+		}
+	}
+	return enabled
+}
+
+// RecencyHalfLifeFor returns the recency half-life of the named algorithm:
+// its own half_life when set, the shared recency_half_life otherwise
+func (c *Config) RecencyHalfLifeFor(algorithmName string) float64 {
+	if halfLife := c.Algorithms.Configs[algorithmName].HalfLife; halfLife != nil {
+		return *halfLife
+	}
+	return c.Algorithms.RecencyHalfLife
+}
+
+// RangeChangesFor returns the parsed range changes of the given game type
+func (c *Config) RangeChangesFor(gameType valueobject.GameType) ([]analytics.RangeChange, error) {
+	configured := c.gameOverrides(gameType).RangeChanges
+	changes := make([]analytics.RangeChange, 0, len(configured))
+	for _, rc := range configured {
+		before, err := time.Parse("2006-01-02", rc.Before)
+		if err != nil {
+			return nil, fmt.Errorf("invalid range change date %q for %s: %w", rc.Before, gameType, err)
+		}
+		changes = append(changes, analytics.RangeChange{Before: before, PoolSize: rc.PoolSize})
+	}
+	return changes, nil
+}
+
+// gameOverrides returns the algorithm overrides of the given game type
+func (c *Config) gameOverrides(gameType valueobject.GameType) GameAlgorithmConfig {
+	switch gameType {
+	case valueobject.Mega645:
+		return c.Algorithms.Mega645
+	case valueobject.Power655:
+		return c.Algorithms.Power655
+	}
+	return GameAlgorithmConfig{}
+}
+
+// ScheduleFor returns the daemon's cron expression for the given game type;
+// empty when the game isn't scheduled
+func (c *Config) ScheduleFor(gameType valueobject.GameType) string {
+	switch gameType {
+	case valueobject.Mega645:
+		return c.Daemon.Mega645
+	case valueobject.Power655:
+		return c.Daemon.Power655
+	}
+	return ""
+}
+
+// TelegramChatFor returns the Telegram chat announcing the given game type:
+// its own chat when set, the shared chat_id otherwise
+func (c *Config) TelegramChatFor(gameType valueobject.GameType) string {
+	chat := ""
+	switch gameType {
+	case valueobject.Mega645:
+		chat = c.Notify.Telegram.Mega645
+	case valueobject.Power655:
+		chat = c.Notify.Telegram.Power655
+	}
+	if chat == "" {
+		return c.Notify.Telegram.ChatID
+	}
+	return chat
+}
+
 // IsAlgorithmEnabled checks if an algorithm is enabled
 func (c *Config) IsAlgorithmEnabled(algorithmName string) bool {
 	for _, enabled := range c.Algorithms.Enabled {
@@ -200,3 +495,14 @@ func (c *Config) IsAlgorithmEnabled(algorithmName string) bool {
 	}
 	return false
 }
+
+// Hash returns a short SHA-256 fingerprint of the effective configuration,
+// including defaults and environment overrides, for prediction provenance
+func (c *Config) Hash() (string, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode config: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:16], nil
+}
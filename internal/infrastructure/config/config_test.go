@@ -0,0 +1,176 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/pkg/analytics"
+)
+
+const devConfigPath = "../../../configs/config.dev.yaml"
+
+func TestConfig_Hash_Stable(t *testing.T) {
+	first, err := Load(devConfigPath)
+	require.NoError(t, err)
+	second, err := Load(devConfigPath)
+	require.NoError(t, err)
+
+	firstHash, err := first.Hash()
+	require.NoError(t, err)
+	secondHash, err := second.Hash()
+	require.NoError(t, err)
+
+	assert.Len(t, firstHash, 16)
+	assert.Equal(t, firstHash, secondHash)
+}
+
+func TestConfig_Hash_ChangesWithValues(t *testing.T) {
+	cfg, err := Load(devConfigPath)
+	require.NoError(t, err)
+	original, err := cfg.Hash()
+	require.NoError(t, err)
+
+	cfg.Ensemble.VotingStrategy = "majority"
+	changed, err := cfg.Hash()
+	require.NoError(t, err)
+	assert.NotEqual(t, original, changed)
+
+	cfg, err = Load(devConfigPath)
+	require.NoError(t, err)
+	details := cfg.Algorithms.Configs["frequency_analysis"]
+	details.Weight += 0.1
+	cfg.Algorithms.Configs["frequency_analysis"] = details
+	changed, err = cfg.Hash()
+	require.NoError(t, err)
+	assert.NotEqual(t, original, changed)
+}
+
+func TestConfig_RangeChangesFor(t *testing.T) {
+	cfg := &Config{}
+	cfg.Algorithms.Mega645.RangeChanges = []RangeChangeConfig{{Before: "2016-07-18", PoolSize: 40}}
+
+	changes, err := cfg.RangeChangesFor(valueobject.Mega645)
+	require.NoError(t, err)
+	assert.Equal(t, []analytics.RangeChange{
+		{Before: time.Date(2016, 7, 18, 0, 0, 0, 0, time.UTC), PoolSize: 40},
+	}, changes)
+
+	changes, err = cfg.RangeChangesFor(valueobject.Power655)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	cfg.Algorithms.Mega645.RangeChanges[0].Before = "18/07/2016"
+	_, err = cfg.RangeChangesFor(valueobject.Mega645)
+	assert.Error(t, err)
+}
+
+func TestConfig_ScheduleFor(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+daemon:
+  power_6_55: ""
+`), 0644))
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, "30 18 * * 0,3,5", cfg.ScheduleFor(valueobject.Mega645), "Wed, Fri and Sun by default")
+	assert.Empty(t, cfg.ScheduleFor(valueobject.Power655), "an empty expression leaves the game out")
+	assert.Equal(t, "Asia/Ho_Chi_Minh", cfg.Daemon.Timezone)
+	assert.Equal(t, 15*time.Minute, cfg.Daemon.RetryInterval)
+}
+
+func TestConfig_TelegramChatFor(t *testing.T) {
+	cfg := &Config{}
+	cfg.Notify.Telegram.ChatID = "-100100"
+	cfg.Notify.Telegram.Power655 = "-100300"
+
+	assert.Equal(t, "-100100", cfg.TelegramChatFor(valueobject.Mega645))
+	assert.Equal(t, "-100300", cfg.TelegramChatFor(valueobject.Power655))
+}
+
+func TestConfig_RecencyHalfLifeFor(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+algorithms:
+  recency_half_life: 20
+  enabled:
+    - "frequency_analysis"
+    - "hot_cold_analysis"
+  frequency_analysis:
+    weight: 1.0
+    half_life: 52
+  hot_cold_analysis:
+    weight: 1.2
+`), 0644))
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, 52.0, cfg.RecencyHalfLifeFor("frequency_analysis"))
+	assert.Equal(t, 20.0, cfg.RecencyHalfLifeFor("hot_cold_analysis"))
+
+	// An explicit 0 turns decay off for one algorithm
+	zero := 0.0
+	details := cfg.Algorithms.Configs["frequency_analysis"]
+	details.HalfLife = &zero
+	cfg.Algorithms.Configs["frequency_analysis"] = details
+	assert.Equal(t, 0.0, cfg.RecencyHalfLifeFor("frequency_analysis"))
+}
+
+func TestLoadGames_CustomDefinition(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "games.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`games:
+  mega:
+    number_range: [1, 50]
+    draw_days: [tue, Thursday]
+`), 0o644))
+
+	registry, err := LoadGames(path)
+	require.NoError(t, err)
+	t.Cleanup(valueobject.UseGameRegistry(registry))
+
+	low, high := valueobject.Mega645.NumberRange()
+	assert.Equal(t, 1, low)
+	assert.Equal(t, 50, high)
+	assert.Equal(t, 6, valueobject.Mega645.NumberCount())
+	assert.Equal(t, []time.Weekday{time.Tuesday, time.Thursday}, valueobject.Mega645.DrawDays())
+	assert.True(t, valueobject.Mega645.IsDrawDay(time.Tuesday))
+	assert.False(t, valueobject.Mega645.IsDrawDay(time.Wednesday))
+
+	// Games left out of the file keep the built-in rules
+	_, high = valueobject.Power655.NumberRange()
+	assert.Equal(t, 55, high)
+}
+
+func TestLoadGames_Invalid(t *testing.T) {
+	for name, content := range map[string]string{
+		"unknown game":   "games:\n  keno:\n    number_range: [1, 80]\n",
+		"bad range":      "games:\n  mega:\n    number_range: [1]\n",
+		"bad pick count": "games:\n  mega:\n    pick_count: 5\n",
+		"bad draw day":   "games:\n  mega:\n    draw_days: [someday]\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "games.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+			_, err := LoadGames(path)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestLoadGames_ShippedFileMatchesDefaults(t *testing.T) {
+	registry, err := LoadGames("../../../configs/games.yaml")
+	require.NoError(t, err)
+	builtin := valueobject.NewGameRegistry()
+	for _, gt := range []valueobject.GameType{valueobject.Mega645, valueobject.Power655} {
+		got, _ := registry.Lookup(gt)
+		want, _ := builtin.Lookup(gt)
+		assert.Equal(t, want, got, gt)
+	}
+}
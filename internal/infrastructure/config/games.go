@@ -0,0 +1,92 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// gamesFile is the layout of a games.yaml file
+type gamesFile struct {
+	Games map[string]GameRules `mapstructure:"games"`
+}
+
+// GameRules overrides a game's built-in rules. Fields left out keep the
+// built-in value.
+type GameRules struct {
+	NumberRange []int    `mapstructure:"number_range"` // [lowest, highest]
+	PickCount   int      `mapstructure:"pick_count"`
+	DrawDays    []string `mapstructure:"draw_days"` // Weekday names, e.g. "wednesday" or "wed"
+}
+
+// LoadGames reads game rules from a games.yaml file into a registry of the
+// built-in rules with the file's applied on top. Games are keyed by any name
+// valueobject.ParseGameType accepts.
+func LoadGames(path string) (*valueobject.GameRegistry, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType("yaml")
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read games file: %w", err)
+	}
+
+	var file gamesFile
+	if err := v.Unmarshal(&file); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal games file: %w", err)
+	}
+
+	registry := valueobject.NewGameRegistry()
+	for name, rules := range file.Games {
+		gameType, err := valueobject.ParseGameType(name)
+		if err != nil {
+			return nil, err
+		}
+		def, _ := registry.Lookup(gameType)
+		if def, err = rules.apply(def); err != nil {
+			return nil, fmt.Errorf("invalid rules for %s: %w", gameType, err)
+		}
+		if err := registry.Define(gameType, def); err != nil {
+			return nil, err
+		}
+	}
+	return registry, nil
+}
+
+// apply returns def with the rules set in r
+func (r GameRules) apply(def valueobject.GameDefinition) (valueobject.GameDefinition, error) {
+	if r.NumberRange != nil {
+		if len(r.NumberRange) != 2 {
+			return def, fmt.Errorf("number_range must be [lowest, highest], got %v", r.NumberRange)
+		}
+		def.MinNumber, def.MaxNumber = r.NumberRange[0], r.NumberRange[1]
+	}
+	if r.PickCount != 0 {
+		def.PickCount = r.PickCount
+	}
+	if r.DrawDays != nil {
+		def.DrawDays = make([]time.Weekday, 0, len(r.DrawDays))
+		for _, name := range r.DrawDays {
+			day, err := parseWeekday(name)
+			if err != nil {
+				return def, err
+			}
+			def.DrawDays = append(def.DrawDays, day)
+		}
+	}
+	return def, nil
+}
+
+// parseWeekday parses an English weekday name or its first three letters
+func parseWeekday(name string) (time.Weekday, error) {
+	normalized := strings.ToLower(strings.TrimSpace(name))
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		full := strings.ToLower(day.String())
+		if normalized == full || normalized == full[:3] {
+			return day, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid draw day %q", name)
+}
@@ -1,7 +1,13 @@
 package logger
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -9,10 +15,19 @@ import (
 
 var (
 	globalLogger *zap.Logger
+
+	// exitFunc is swapped out in tests
+	exitFunc = os.Exit
 )
 
 // Init initializes the global logger
 func Init(logLevel string) error {
+	return InitWithOutput(logLevel, "stdout")
+}
+
+// InitWithOutput initializes the global logger writing to the given output
+// path, e.g. "stderr" when stdout is reserved for machine-readable output
+func InitWithOutput(logLevel string, outputPath string) error {
 	level, err := parseLogLevel(logLevel)
 	if err != nil {
 		return fmt.Errorf("invalid log level: %w", err)
@@ -36,7 +51,7 @@ func Init(logLevel string) error {
 			EncodeDuration: zapcore.SecondsDurationEncoder,
 			EncodeCaller:   zapcore.ShortCallerEncoder,
 		},
-		OutputPaths:      []string{"stdout"},
+		OutputPaths:      []string{outputPath},
 		ErrorOutputPaths: []string{"stderr"},
 	}
 
@@ -94,14 +109,89 @@ func Get() *zap.Logger {
 	return globalLogger
 }
 
-// Sync flushes any buffered log entries
+// Replace swaps the global logger for l, e.g. a zaptest observer in tests,
+// and returns a function restoring the previous one
+func Replace(l *zap.Logger) (restore func()) {
+	previous := globalLogger
+	globalLogger = l
+	return func() {
+		globalLogger = previous
+	}
+}
+
+// Sync flushes any buffered log entries. Errors from syncing a terminal or
+// pipe, which cannot be fsynced, are ignored.
 func Sync() error {
-	if globalLogger != nil {
-		return globalLogger.Sync()
+	if globalLogger == nil {
+		return nil
+	}
+	if err := globalLogger.Sync(); err != nil && !isUnsyncableOutput(err) {
+		return err
 	}
 	return nil
 }
 
+// isUnsyncableOutput reports whether err comes from fsyncing stdout or stderr
+// attached to a terminal or pipe
+func isUnsyncableOutput(err error) bool {
+	return errors.Is(err, syscall.EINVAL) || errors.Is(err, syscall.ENOTTY)
+}
+
+// Setup initializes the global logger writing to outputPath. The returned
+// context is cancelled on SIGINT or SIGTERM so the command can stop and run
+// its deferred calls; a second signal terminates the process right away.
+// Callers defer the returned shutdown function, which stops the signal
+// handler and flushes the logger.
+func Setup(logLevel string, outputPath string) (ctx context.Context, shutdown func(), err error) {
+	if err := InitWithOutput(logLevel, outputPath); err != nil {
+		return nil, nil, err
+	}
+
+	ctx, stop := handleSignals(context.Background())
+	return ctx, func() {
+		stop()
+		if err := Sync(); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to flush logs: %v\n", err)
+		}
+	}, nil
+}
+
+// handleSignals returns a copy of parent cancelled on the first SIGINT or
+// SIGTERM, after which signals get their default behaviour back, until the
+// returned stop function is called
+func handleSignals(parent context.Context) (ctx context.Context, stop func()) {
+	ctx, cancel := context.WithCancel(parent)
+	signals := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		select {
+		case sig := <-signals:
+			Warn("Received signal, shutting down", zap.String("signal", sig.String()))
+			signal.Stop(signals)
+			cancel()
+		case <-done:
+		}
+	}()
+
+	var once sync.Once
+	return ctx, func() {
+		once.Do(func() {
+			signal.Stop(signals)
+			close(done)
+			cancel()
+		})
+	}
+}
+
+// Exit flushes buffered log entries and exits with code. Use it instead of
+// os.Exit, which skips deferred Sync calls, where there is no error to log.
+func Exit(code int) {
+	_ = Sync()
+	exitFunc(code)
+}
+
 // parseLogLevel converts string log level to zapcore.Level
 func parseLogLevel(level string) (zapcore.Level, error) {
 	switch level {
@@ -145,7 +235,8 @@ func Error(msg string, fields ...zap.Field) {
 	Get().Error(msg, fields...)
 }
 
-// Fatal logs a fatal message and exits
+// Fatal logs a fatal message, flushes the logger and exits with status 1.
+// Deferred calls don't run.
 func Fatal(msg string, fields ...zap.Field) {
 	Get().Fatal(msg, fields...)
 }
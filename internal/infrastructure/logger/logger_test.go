@@ -0,0 +1,86 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetup_FlushesOnShutdown(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "app.log")
+
+	_, shutdown, err := Setup("info", logPath)
+	require.NoError(t, err)
+
+	Info("prediction completed")
+	Debug("below the configured level")
+	shutdown()
+
+	data, err := os.ReadFile(logPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"message":"prediction completed"`)
+	assert.NotContains(t, string(data), "below the configured level")
+}
+
+func TestSetup_InvalidLevel(t *testing.T) {
+	ctx, shutdown, err := Setup("verbose", filepath.Join(t.TempDir(), "app.log"))
+
+	assert.Error(t, err)
+	assert.Nil(t, ctx)
+	assert.Nil(t, shutdown)
+}
+
+func TestExit_FlushesBeforeExiting(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "app.log")
+	require.NoError(t, InitWithOutput("info", logPath))
+
+	var code int
+	exitFunc = func(c int) { code = c }
+	defer func() { exitFunc = os.Exit }()
+
+	Info("about to exit")
+	Exit(3)
+
+	assert.Equal(t, 3, code)
+	data, err := os.ReadFile(logPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "about to exit")
+}
+
+func TestIsUnsyncableOutput(t *testing.T) {
+	assert.True(t, isUnsyncableOutput(&os.PathError{Op: "sync", Path: "/dev/stdout", Err: syscall.EINVAL}))
+	assert.True(t, isUnsyncableOutput(fmt.Errorf("sync: %w", syscall.ENOTTY)))
+	assert.False(t, isUnsyncableOutput(&os.PathError{Op: "sync", Path: "app.log", Err: syscall.EIO}))
+}
+
+func TestHandleSignals_CancelsContext(t *testing.T) {
+	ctx, stop := handleSignals(context.Background())
+	defer stop()
+
+	process, err := os.FindProcess(os.Getpid())
+	require.NoError(t, err)
+	if err := process.Signal(os.Interrupt); err != nil {
+		t.Skipf("cannot signal the test process: %v", err)
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("context not cancelled on interrupt")
+	}
+}
+
+func TestHandleSignals_StopCancelsContext(t *testing.T) {
+	ctx, stop := handleSignals(context.Background())
+	stop()
+	stop()
+
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+}
@@ -0,0 +1,58 @@
+// Package sampledata bundles a realistic history of sample draws for each
+// game, so a fresh install can be tried out before fetching real results
+package sampledata
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+//go:embed *.json
+var files embed.FS
+
+// sampleDraw is the layout of a draw in the bundled files
+type sampleDraw struct {
+	DrawNumber int       `json:"draw_number"`
+	Numbers    []int     `json:"numbers"`
+	DrawDate   time.Time `json:"draw_date"`
+	Jackpot    float64   `json:"jackpot"`
+	Winners    int       `json:"winners"`
+}
+
+// Draws returns the sample draws bundled for gameType, oldest first, each
+// marked Sample
+func Draws(gameType valueobject.GameType) ([]*entity.Draw, error) {
+	if err := gameType.Validate(); err != nil {
+		return nil, err
+	}
+
+	data, err := files.ReadFile(strings.ToLower(string(gameType)) + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("no sample draws for %s: %w", gameType, err)
+	}
+	var samples []sampleDraw
+	if err := json.Unmarshal(data, &samples); err != nil {
+		return nil, fmt.Errorf("failed to parse %s sample draws: %w", gameType, err)
+	}
+
+	draws := make([]*entity.Draw, 0, len(samples))
+	for _, s := range samples {
+		numbers, err := valueobject.NewNumbers(s.Numbers)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s sample draw #%d: %w", gameType, s.DrawNumber, err)
+		}
+		draw, err := entity.NewDraw(gameType, s.DrawNumber, numbers, s.DrawDate, s.Jackpot, s.Winners)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s sample draw #%d: %w", gameType, s.DrawNumber, err)
+		}
+		draw.Sample = true
+		draws = append(draws, draw)
+	}
+	return draws, nil
+}
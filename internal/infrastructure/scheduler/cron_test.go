@@ -0,0 +1,60 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedule_Next(t *testing.T) {
+	ict := time.FixedZone("ICT", 7*60*60)
+	// Monday 2026-03-02 10:15 ICT
+	monday := time.Date(2026, 3, 2, 10, 15, 0, 0, ict)
+
+	tests := []struct {
+		name string
+		spec string
+		from time.Time
+		want time.Time
+	}{
+		{"every minute", "* * * * *", monday, monday.Add(time.Minute)},
+		{"skips the current minute", "15 10 * * *", monday, monday.AddDate(0, 0, 1)},
+		{"steps", "*/20 * * * *", monday, time.Date(2026, 3, 2, 10, 20, 0, 0, ict)},
+		{"power draw days", "30 18 * * 2,4,6", monday, time.Date(2026, 3, 3, 18, 30, 0, 0, ict)},
+		{"mega draw days from a draw", "30 18 * * 0,3,5", time.Date(2026, 3, 4, 18, 30, 0, 0, ict), time.Date(2026, 3, 6, 18, 30, 0, 0, ict)},
+		{"sunday as 7", "0 9 * * 7", monday, time.Date(2026, 3, 8, 9, 0, 0, 0, ict)},
+		{"ranges", "0 9-17/4 * * 1-5", monday, time.Date(2026, 3, 2, 13, 0, 0, 0, ict)},
+		{"month rollover", "0 0 1 * *", monday, time.Date(2026, 4, 1, 0, 0, 0, 0, ict)},
+		{"either day field", "0 0 13 * 5", monday, time.Date(2026, 3, 6, 0, 0, 0, 0, ict)},
+		{"leap day", "0 0 29 2 *", monday, time.Date(2028, 2, 29, 0, 0, 0, 0, ict)},
+		{"never", "0 0 30 2 *", monday, time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseCron(tt.spec)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, schedule.Next(tt.from))
+		})
+	}
+}
+
+func TestParseCron_Invalid(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+	} {
+		_, err := ParseCron(spec)
+		assert.Error(t, err, spec)
+	}
+}
@@ -0,0 +1,119 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tool_predict/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+// Job is a named task run on a cron schedule
+type Job struct {
+	Name     string
+	Schedule *Schedule
+	Run      func(ctx context.Context) error
+}
+
+// Scheduler runs jobs on their cron schedules, read in its location. Jobs
+// due at the same minute run one after another in the order they were
+// added, so jobs sharing storage never write at once; a job still running
+// when it's due again skips that run.
+type Scheduler struct {
+	location *time.Location
+	jobs     []Job
+	now      func() time.Time
+	after    func(time.Duration) <-chan time.Time
+}
+
+// New creates a scheduler reading schedules in location; UTC if nil
+func New(location *time.Location) *Scheduler {
+	if location == nil {
+		location = time.UTC
+	}
+	return &Scheduler{
+		location: location,
+		now:      time.Now,
+		after:    time.After,
+	}
+}
+
+// Add schedules run under name on the given cron expression
+func (s *Scheduler) Add(name, spec string, run func(ctx context.Context) error) error {
+	schedule, err := ParseCron(spec)
+	if err != nil {
+		return fmt.Errorf("failed to schedule %s: %w", name, err)
+	}
+	s.jobs = append(s.jobs, Job{Name: name, Schedule: schedule, Run: run})
+	return nil
+}
+
+// Jobs returns the scheduled jobs in the order they were added
+func (s *Scheduler) Jobs() []Job {
+	return append([]Job(nil), s.jobs...)
+}
+
+// NextRun returns when job next runs after t
+func (s *Scheduler) NextRun(job Job, t time.Time) time.Time {
+	return job.Schedule.Next(t.In(s.location))
+}
+
+// Run runs the jobs as they fall due until ctx is done. A failed job is
+// logged and runs again at its next scheduled time.
+func (s *Scheduler) Run(ctx context.Context) error {
+	if len(s.jobs) == 0 {
+		return fmt.Errorf("no jobs scheduled")
+	}
+
+	for {
+		now := s.now()
+		var next time.Time
+		for _, job := range s.jobs {
+			if run := s.NextRun(job, now); !run.IsZero() && (next.IsZero() || run.Before(next)) {
+				next = run
+			}
+		}
+		if next.IsZero() {
+			return fmt.Errorf("no scheduled job runs again")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.after(next.Sub(now)):
+		}
+
+		for _, job := range s.jobs {
+			if !s.NextRun(job, next.Add(-time.Minute)).Equal(next) {
+				continue
+			}
+			if ctx.Err() != nil {
+				return nil
+			}
+			s.runJob(ctx, job, next)
+		}
+	}
+}
+
+// runJob runs one job, logging how it went
+func (s *Scheduler) runJob(ctx context.Context, job Job, due time.Time) {
+	logger.Info("Running scheduled job",
+		zap.String("job", job.Name),
+		zap.Time("due", due),
+	)
+	start := s.now()
+	if err := job.Run(ctx); err != nil {
+		logger.Error("Scheduled job failed",
+			zap.String("job", job.Name),
+			zap.Duration("duration", s.now().Sub(start)),
+			zap.Error(err),
+		)
+		return
+	}
+	logger.Info("Finished scheduled job",
+		zap.String("job", job.Name),
+		zap.Duration("duration", s.now().Sub(start)),
+		zap.Time("next_run", s.NextRun(job, s.now())),
+	)
+}
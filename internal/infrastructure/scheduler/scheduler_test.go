@@ -0,0 +1,88 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock advances to each wait's end at once instead of sleeping
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.now = c.now.Add(d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+func TestScheduler_Run(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 3, 2, 10, 0, 30, 0, time.UTC)}
+	s := New(time.UTC)
+	s.now, s.after = clock.Now, clock.After
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var runs []string
+	require.NoError(t, s.Add("hourly", "0 * * * *", func(ctx context.Context) error {
+		runs = append(runs, "hourly@"+clock.now.Format("15:04"))
+		return nil
+	}))
+	require.NoError(t, s.Add("half-hourly", "*/30 * * * *", func(ctx context.Context) error {
+		runs = append(runs, "half-hourly@"+clock.now.Format("15:04"))
+		if len(runs) >= 5 {
+			cancel()
+		}
+		return errors.New("failed jobs run again on schedule")
+	}))
+
+	require.NoError(t, s.Run(ctx))
+	assert.Equal(t, []string{
+		"half-hourly@10:30",
+		"hourly@11:00",
+		"half-hourly@11:00",
+		"half-hourly@11:30",
+		"hourly@12:00",
+		"half-hourly@12:00",
+	}, runs)
+}
+
+func TestScheduler_SkipsRunsMissedWhileBusy(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)}
+	s := New(time.UTC)
+	s.now, s.after = clock.Now, clock.After
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var runs []string
+	require.NoError(t, s.Add("slow", "*/10 * * * *", func(ctx context.Context) error {
+		runs = append(runs, clock.now.Format("15:04"))
+		clock.now = clock.now.Add(25 * time.Minute)
+		if len(runs) == 3 {
+			cancel()
+		}
+		return nil
+	}))
+
+	require.NoError(t, s.Run(ctx))
+	assert.Equal(t, []string{"10:10", "10:40", "11:10"}, runs)
+}
+
+func TestScheduler_Validation(t *testing.T) {
+	s := New(nil)
+	assert.Error(t, s.Run(context.Background()), "nothing to run")
+	assert.Error(t, s.Add("bad", "61 * * * *", func(ctx context.Context) error { return nil }))
+	assert.Empty(t, s.Jobs())
+}
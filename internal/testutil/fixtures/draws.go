@@ -0,0 +1,57 @@
+// Package fixtures provides reproducible draw histories shared by tests and
+// benchmarks across packages
+package fixtures
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// DefaultSeed is the seed used by Draws
+const DefaultSeed uint64 = 42
+
+// StartDate is the day before the first fixture draw
+var StartDate = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Draws returns count uniformly random draws generated from DefaultSeed.
+// See DrawsWithSeed.
+func Draws(gameType valueobject.GameType, count int) []*entity.Draw {
+	return DrawsWithSeed(gameType, count, DefaultSeed)
+}
+
+// DrawsWithSeed returns count uniformly random draws in chronological order.
+// Draw numbers start at 1 and dates follow the game's draw schedule from
+// StartDate, so the history has no gaps. The same seed always yields the
+// same history. It panics if gameType is invalid.
+func DrawsWithSeed(gameType valueobject.GameType, count int, seed uint64) []*entity.Draw {
+	rng := rand.New(rand.NewPCG(seed, uint64(count)))
+	minRange, maxRange := gameType.NumberRange()
+
+	draws := make([]*entity.Draw, count)
+	date := StartDate
+	for i := range draws {
+		perm := rng.Perm(maxRange - minRange + 1)
+		nums := make([]int, gameType.NumberCount())
+		for j := range nums {
+			nums[j] = perm[j] + minRange
+		}
+
+		date = gameType.NextDrawDate(date)
+		draw, err := entity.NewDraw(
+			gameType,
+			i+1,
+			valueobject.MustNewNumbers(nums),
+			date,
+			float64(12_000_000_000+rng.IntN(50)*100_000_000),
+			rng.IntN(4)/3,
+		)
+		if err != nil {
+			panic(err)
+		}
+		draws[i] = draw
+	}
+	return draws
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/tool_predict/internal/domain/entity"
 	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/pkg/analytics"
 )
 
 // Test helper function to create mock historical data
@@ -118,6 +119,137 @@ func TestFrequencyAnalyzer_Predict_Power655(t *testing.T) {
 	}
 }
 
+func TestFrequencyAnalyzer_RecencyHalfLife(t *testing.T) {
+	// 20 old draws of 1-6 followed by 10 recent draws of 40-45
+	baseDate := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	draws := make([]*entity.Draw, 0, 30)
+	for i := 0; i < 30; i++ {
+		nums := []int{1, 2, 3, 4, 5, 6}
+		if i >= 20 {
+			nums = []int{40, 41, 42, 43, 44, 45}
+		}
+		draw, err := entity.NewDraw(valueobject.Mega645, i+1, valueobject.MustNewNumbers(nums),
+			baseDate.AddDate(0, 0, i), 0, 0)
+		require.NoError(t, err)
+		draws = append(draws, draw)
+	}
+
+	analyzer := NewFrequencyAnalyzer(1.0)
+	prediction, err := analyzer.Predict(context.Background(), valueobject.Mega645, draws)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, prediction.Numbers.AsSlice())
+	assert.NotContains(t, prediction.Metadata, "recency_half_life")
+
+	require.NoError(t, analyzer.SetRecencyHalfLife(3))
+	prediction, err = analyzer.Predict(context.Background(), valueobject.Mega645, draws)
+	require.NoError(t, err)
+	assert.Equal(t, []int{40, 41, 42, 43, 44, 45}, prediction.Numbers.AsSlice())
+	assert.Equal(t, "3", prediction.Metadata["recency_half_life"])
+
+	assert.Error(t, analyzer.SetRecencyHalfLife(-1))
+}
+
+func TestFrequencyAnalyzer_RangeChanges(t *testing.T) {
+	// 24 draws of 1-6 from a 40-number pool, then 12 draws of 40-45 after the
+	// synthetic range change to 45
+	baseDate := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+	draws := make([]*entity.Draw, 0, 36)
+	for i := 0; i < 36; i++ {
+		nums := []int{1, 2, 3, 4, 5, 6}
+		if i >= 24 {
+			nums = []int{40, 41, 42, 43, 44, 45}
+		}
+		draw, err := entity.NewDraw(valueobject.Mega645, i+1, valueobject.MustNewNumbers(nums),
+			baseDate.AddDate(0, 0, i), 0, 0)
+		require.NoError(t, err)
+		draws = append(draws, draw)
+	}
+
+	analyzer := NewFrequencyAnalyzer(1.0)
+	prediction, err := analyzer.Predict(context.Background(), valueobject.Mega645, draws)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, prediction.Numbers.AsSlice())
+
+	// 41-45 only had a third of the draws to appear in, while 1-6 came from
+	// the smaller pool, so 41-45 lead and 1 takes the last slot
+	require.NoError(t, analyzer.SetRangeChanges(valueobject.Mega645, []analytics.RangeChange{
+		{Before: baseDate.AddDate(0, 0, 24), PoolSize: 40},
+	}))
+	prediction, err = analyzer.Predict(context.Background(), valueobject.Mega645, draws)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 41, 42, 43, 44, 45}, prediction.Numbers.AsSlice())
+	assert.Equal(t, "1", prediction.Metadata["range_changes"])
+
+	// Range changes are per game
+	prediction, err = analyzer.Predict(context.Background(), valueobject.Power655, draws)
+	require.NoError(t, err)
+	assert.NotContains(t, prediction.Metadata, "range_changes")
+
+	assert.Error(t, analyzer.SetRangeChanges(valueobject.Mega645, []analytics.RangeChange{
+		{Before: baseDate, PoolSize: 45},
+	}))
+}
+
+// createWinnerSplitDraws alternates jackpot-winning draws of 1-6 with
+// rollover draws of 40-45, count of each
+func createWinnerSplitDraws(t *testing.T, count int) []*entity.Draw {
+	t.Helper()
+
+	baseDate := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	draws := make([]*entity.Draw, 0, 2*count)
+	for i := 0; i < 2*count; i++ {
+		nums, winners := []int{1, 2, 3, 4, 5, 6}, 1+i%3
+		if i%2 == 1 {
+			nums, winners = []int{40, 41, 42, 43, 44, 45}, 0
+		}
+		draw, err := entity.NewDraw(valueobject.Mega645, i+1, valueobject.MustNewNumbers(nums),
+			baseDate.AddDate(0, 0, i), 0, winners)
+		require.NoError(t, err)
+		draws = append(draws, draw)
+	}
+	return draws
+}
+
+func TestFrequencyAnalyzer_WinnersFilter(t *testing.T) {
+	draws := createWinnerSplitDraws(t, 10)
+	ctx := context.Background()
+
+	analyzer := NewFrequencyAnalyzer(1.0)
+
+	require.NoError(t, analyzer.SetWinnersFilter(1, analytics.NoMaxWinners))
+	prediction, err := analyzer.Predict(ctx, valueobject.Mega645, draws)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int{1, 2, 3, 4, 5, 6}, prediction.Numbers.AsSlice())
+	assert.Equal(t, "1+", prediction.Metadata["winners_filter"])
+	assert.Equal(t, "10", prediction.Metadata["draws_filtered_out"])
+
+	require.NoError(t, analyzer.SetWinnersFilter(0, 0))
+	prediction, err = analyzer.Predict(ctx, valueobject.Mega645, draws)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int{40, 41, 42, 43, 44, 45}, prediction.Numbers.AsSlice())
+
+	analyzer.ClearWinnersFilter()
+	prediction, err = analyzer.Predict(ctx, valueobject.Mega645, draws)
+	require.NoError(t, err)
+	assert.NotContains(t, prediction.Metadata, "winners_filter")
+}
+
+func TestFrequencyAnalyzer_WinnersFilter_NeedsEnoughMatchingDraws(t *testing.T) {
+	// 5 jackpot draws are below the 8 draw minimum even though 10 are supplied
+	draws := createWinnerSplitDraws(t, 5)
+
+	analyzer := NewFrequencyAnalyzer(1.0)
+	require.NoError(t, analyzer.Validate(draws))
+
+	require.NoError(t, analyzer.SetWinnersFilter(1, analytics.NoMaxWinners))
+	err := analyzer.Validate(draws)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "after winners filter")
+
+	assert.Error(t, analyzer.SetWinnersFilter(-1, 0))
+	assert.Error(t, analyzer.SetWinnersFilter(3, 1))
+}
+
 func TestHotColdAnalyzer_Name(t *testing.T) {
 	analyzer := NewHotColdAnalyzer(1.0)
 	assert.Equal(t, "hot_cold_analysis", analyzer.Name())
@@ -0,0 +1,201 @@
+package algorithm
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/tool_predict/internal/domain/entity"
+)
+
+// DefaultAnomalyThreshold is the |z-score| from which a draw's feature is
+// flagged as unusual
+const DefaultAnomalyThreshold = 2.5
+
+// rareShapeRate is the share of baseline draws below which a combination
+// shape counts as unusual
+const rareShapeRate = 0.05
+
+// minAnomalyBaseline is the fewest earlier draws a feature's mean and
+// standard deviation are computed from
+const minAnomalyBaseline = 30
+
+// DrawFeature is a number describing the shape of a draw, compared against
+// its historical mean to spot unusual draws
+type DrawFeature struct {
+	Name        string
+	Description string
+	value       func(sorted []int) float64
+}
+
+// drawFeatures lists the features every draw is scored on
+var drawFeatures = []DrawFeature{
+	{
+		Name:        "sum",
+		Description: "sum of the numbers",
+		value: func(sorted []int) float64 {
+			sum := 0
+			for _, num := range sorted {
+				sum += num
+			}
+			return float64(sum)
+		},
+	},
+	{
+		Name:        "same_decade",
+		Description: "most numbers in one decade (1-9, 10-19, ...)",
+		value: func(sorted []int) float64 {
+			counts := make(map[int]int)
+			most := 0
+			for _, num := range sorted {
+				counts[num/10]++
+				most = max(most, counts[num/10])
+			}
+			return float64(most)
+		},
+	},
+	{
+		Name:        "longest_run",
+		Description: "longest run of consecutive numbers",
+		value: func(sorted []int) float64 {
+			longest, run := 1, 1
+			for i := 1; i < len(sorted); i++ {
+				if sorted[i] == sorted[i-1]+1 {
+					run++
+					longest = max(longest, run)
+				} else {
+					run = 1
+				}
+			}
+			return float64(longest)
+		},
+	},
+	{
+		Name:        "odd_count",
+		Description: "count of odd numbers",
+		value: func(sorted []int) float64 {
+			odd := 0
+			for _, num := range sorted {
+				if num%2 == 1 {
+					odd++
+				}
+			}
+			return float64(odd)
+		},
+	},
+}
+
+// FeatureScore is a draw's value of a feature against the baseline
+type FeatureScore struct {
+	Feature DrawFeature
+	Value   float64
+	Mean    float64
+	StdDev  float64
+	ZScore  float64 // (Value - Mean) / StdDev; 0 when the baseline doesn't vary
+}
+
+// DrawAnomaly is a draw that is statistically unusual for its history
+type DrawAnomaly struct {
+	Draw     *entity.Draw
+	Features []FeatureScore     // Features at least the threshold's z-score away from the mean
+	Shapes   []CombinationShape // Combination shapes rare in the baseline
+}
+
+// DetectAnomalies checks the latest recent draws against the draws before
+// them. A draw is flagged for each feature whose z-score against the
+// baseline is at least threshold in absolute value, and for each combination
+// shape (see CombinationShapes) it has that came up in under 5% of the
+// baseline. Only flagged draws are returned, newest first.
+func DetectAnomalies(draws []*entity.Draw, recent int, threshold float64) ([]DrawAnomaly, error) {
+	if recent <= 0 {
+		return nil, fmt.Errorf("recent draws to check must be positive, got %d", recent)
+	}
+	if threshold <= 0 {
+		return nil, fmt.Errorf("threshold must be positive, got %f", threshold)
+	}
+
+	// Oldest first, so the baseline is everything before the checked draws
+	ordered := append([]*entity.Draw(nil), draws...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].DrawDate.Before(ordered[j].DrawDate)
+	})
+	start := max(len(ordered)-recent, 0)
+	baseline := ordered[:start]
+	if len(baseline) < minAnomalyBaseline {
+		return nil, fmt.Errorf("need at least %d draws before the checked ones for a baseline, got %d",
+			minAnomalyBaseline, len(baseline))
+	}
+
+	means, stdDevs := featureStats(baseline)
+	shapeRates := make(map[string]float64)
+	for _, report := range AvoidReport(baseline) {
+		shapeRates[report.Shape.Name] = report.Rate
+	}
+
+	anomalies := make([]DrawAnomaly, 0)
+	for i := len(ordered) - 1; i >= start; i-- {
+		draw := ordered[i]
+		sorted := sortedNumbers(draw)
+
+		anomaly := DrawAnomaly{Draw: draw}
+		for j, feature := range drawFeatures {
+			score := FeatureScore{
+				Feature: feature,
+				Value:   feature.value(sorted),
+				Mean:    means[j],
+				StdDev:  stdDevs[j],
+			}
+			if score.StdDev > 0 {
+				score.ZScore = (score.Value - score.Mean) / score.StdDev
+			}
+			if math.Abs(score.ZScore) >= threshold {
+				anomaly.Features = append(anomaly.Features, score)
+			}
+		}
+		for _, shape := range FlagShareRisk(sorted, ordered[:i]) {
+			if shapeRates[shape.Name] < rareShapeRate {
+				anomaly.Shapes = append(anomaly.Shapes, shape)
+			}
+		}
+
+		if len(anomaly.Features) > 0 || len(anomaly.Shapes) > 0 {
+			anomalies = append(anomalies, anomaly)
+		}
+	}
+
+	return anomalies, nil
+}
+
+// featureStats returns the mean and population standard deviation of each
+// of drawFeatures over draws
+func featureStats(draws []*entity.Draw) (means, stdDevs []float64) {
+	means = make([]float64, len(drawFeatures))
+	stdDevs = make([]float64, len(drawFeatures))
+
+	values := make([][]float64, len(drawFeatures))
+	for _, draw := range draws {
+		sorted := sortedNumbers(draw)
+		for j, feature := range drawFeatures {
+			values[j] = append(values[j], feature.value(sorted))
+		}
+	}
+
+	for j, vals := range values {
+		for _, v := range vals {
+			means[j] += v
+		}
+		means[j] /= float64(len(vals))
+		for _, v := range vals {
+			stdDevs[j] += (v - means[j]) * (v - means[j])
+		}
+		stdDevs[j] = math.Sqrt(stdDevs[j] / float64(len(vals)))
+	}
+	return means, stdDevs
+}
+
+// sortedNumbers returns a draw's numbers in ascending order
+func sortedNumbers(draw *entity.Draw) []int {
+	sorted := append([]int(nil), draw.Numbers...)
+	sort.Ints(sorted)
+	return sorted
+}
@@ -0,0 +1,72 @@
+package algorithm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/testutil/fixtures"
+)
+
+func featureNames(scores []FeatureScore) []string {
+	names := make([]string, len(scores))
+	for i, score := range scores {
+		names[i] = score.Feature.Name
+	}
+	return names
+}
+
+func TestDetectAnomalies_FlagsEngineeredDraw(t *testing.T) {
+	draws := fixtures.Draws(valueobject.Mega645, 201)
+
+	// Replace the latest draw with six consecutive numbers in one decade
+	latest := draws[len(draws)-1]
+	anomalous, err := entity.NewDraw(valueobject.Mega645, latest.DrawNumber,
+		valueobject.MustNewNumbers([]int{1, 2, 3, 4, 5, 6}), latest.DrawDate, 0, 0)
+	require.NoError(t, err)
+	draws[len(draws)-1] = anomalous
+
+	anomalies, err := DetectAnomalies(draws, 1, DefaultAnomalyThreshold)
+	require.NoError(t, err)
+	require.Len(t, anomalies, 1)
+
+	anomaly := anomalies[0]
+	assert.Equal(t, anomalous.DrawNumber, anomaly.Draw.DrawNumber)
+	assert.ElementsMatch(t, []string{"sum", "same_decade", "longest_run"}, featureNames(anomaly.Features))
+	for _, score := range anomaly.Features {
+		if score.Feature.Name == "sum" {
+			assert.Equal(t, 21.0, score.Value)
+			assert.Less(t, score.ZScore, -DefaultAnomalyThreshold)
+		}
+	}
+	assert.Contains(t, shapeNames(anomaly.Shapes), "consecutive_run")
+	assert.Contains(t, shapeNames(anomaly.Shapes), "arithmetic_sequence")
+	assert.NotContains(t, shapeNames(anomaly.Shapes), "birthday", "all numbers under 32 is common")
+}
+
+func TestDetectAnomalies_TypicalDrawNotFlagged(t *testing.T) {
+	draws := fixtures.Draws(valueobject.Mega645, 201)
+
+	latest := draws[len(draws)-1]
+	typical, err := entity.NewDraw(valueobject.Mega645, latest.DrawNumber,
+		valueobject.MustNewNumbers([]int{4, 13, 22, 28, 37, 41}), latest.DrawDate, 0, 0)
+	require.NoError(t, err)
+	draws[len(draws)-1] = typical
+
+	anomalies, err := DetectAnomalies(draws, 1, DefaultAnomalyThreshold)
+	require.NoError(t, err)
+	assert.Empty(t, anomalies)
+}
+
+func TestDetectAnomalies_Validation(t *testing.T) {
+	draws := fixtures.Draws(valueobject.Mega645, 40)
+
+	_, err := DetectAnomalies(draws, 0, DefaultAnomalyThreshold)
+	assert.Error(t, err)
+	_, err = DetectAnomalies(draws, 5, 0)
+	assert.Error(t, err)
+	_, err = DetectAnomalies(draws, 20, DefaultAnomalyThreshold)
+	assert.ErrorContains(t, err, "baseline")
+}
@@ -0,0 +1,210 @@
+package algorithm
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// DefaultBayesianPriorAlpha is the Dirichlet concentration of every number
+// before any draw is seen, a uniform prior worth one draw of each number
+const DefaultBayesianPriorAlpha = 1.0
+
+// BayesianAnalyzer keeps a Dirichlet posterior over the probability of each
+// number being drawn, per game type. The posterior starts at a symmetric
+// prior and every draw adds one to the concentration of each of its
+// numbers. The prediction is the MAP set: the numbers with the highest
+// posterior concentration.
+//
+// The posterior is rebuilt from each prediction's history, so a prediction
+// only ever sees the window it's given.
+type BayesianAnalyzer struct {
+	name       string
+	weight     float64
+	minDraws   int
+	priorAlpha float64
+	mu         sync.RWMutex
+}
+
+// NewBayesianAnalyzer creates a Bayesian analyzer with DefaultBayesianPriorAlpha
+func NewBayesianAnalyzer(weight float64) *BayesianAnalyzer {
+	return &BayesianAnalyzer{
+		name:       "bayesian",
+		weight:     weight,
+		minDraws:   10,
+		priorAlpha: DefaultBayesianPriorAlpha,
+	}
+}
+
+// Name returns the algorithm name
+func (ba *BayesianAnalyzer) Name() string {
+	return ba.name
+}
+
+// GetWeight returns the algorithm's weight
+func (ba *BayesianAnalyzer) GetWeight() float64 {
+	ba.mu.RLock()
+	defer ba.mu.RUnlock()
+	return ba.weight
+}
+
+// SetWeight sets the algorithm's weight
+func (ba *BayesianAnalyzer) SetWeight(weight float64) error {
+	if weight < 0 {
+		return fmt.Errorf("weight cannot be negative, got %f", weight)
+	}
+	ba.mu.Lock()
+	defer ba.mu.Unlock()
+	ba.weight = weight
+	return nil
+}
+
+// GetPriorAlpha returns the Dirichlet prior concentration of each number
+func (ba *BayesianAnalyzer) GetPriorAlpha() float64 {
+	ba.mu.RLock()
+	defer ba.mu.RUnlock()
+	return ba.priorAlpha
+}
+
+// SetPriorAlpha sets the Dirichlet prior concentration of each number. Larger
+// values trust the uniform prior longer; it must be positive.
+func (ba *BayesianAnalyzer) SetPriorAlpha(alpha float64) error {
+	if alpha <= 0 {
+		return fmt.Errorf("prior alpha must be positive, got %f", alpha)
+	}
+	ba.mu.Lock()
+	defer ba.mu.Unlock()
+	ba.priorAlpha = alpha
+	return nil
+}
+
+// SetMinDraws sets the minimum number of draws required
+func (ba *BayesianAnalyzer) SetMinDraws(minDraws int) error {
+	if minDraws < 1 {
+		return fmt.Errorf("minimum draws must be at least 1, got %d", minDraws)
+	}
+	ba.mu.Lock()
+	defer ba.mu.Unlock()
+	ba.minDraws = minDraws
+	return nil
+}
+
+// GetMinDraws returns the minimum number of draws required
+func (ba *BayesianAnalyzer) GetMinDraws() int {
+	ba.mu.RLock()
+	defer ba.mu.RUnlock()
+	return ba.minDraws
+}
+
+// Validate checks if there's enough data for prediction
+func (ba *BayesianAnalyzer) Validate(historicalData []*entity.Draw) error {
+	if len(historicalData) < ba.minDraws {
+		return fmt.Errorf("need at least %d draws for Bayesian analysis, got %d",
+			ba.minDraws, len(historicalData))
+	}
+	return nil
+}
+
+// Train updates algorithm parameters (the posterior is built from each
+// prediction's history, so there's nothing to train)
+func (ba *BayesianAnalyzer) Train(ctx context.Context, historicalData []*entity.Draw) error {
+	return nil
+}
+
+// Predict builds gameType's posterior from historicalData and picks the
+// numbers with the highest posterior concentration, lower numbers first on
+// ties
+func (ba *BayesianAnalyzer) Predict(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	historicalData []*entity.Draw,
+) (*entity.Prediction, error) {
+	if err := ba.Validate(historicalData); err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	priorAlpha := ba.GetPriorAlpha()
+	counts, drawsObserved := countDraws(gameType, historicalData)
+
+	minRange, _ := gameType.NumberRange()
+	posterior := make([]float64, len(counts))
+	total := 0.0
+	for i, count := range counts {
+		posterior[i] = priorAlpha + count
+		total += posterior[i]
+	}
+
+	order := make([]int, len(posterior))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return posterior[order[i]] > posterior[order[j]]
+	})
+
+	picks := order[:gameType.NumberCount()]
+	predictedNums := make([]int, len(picks))
+	// Confidence is the posterior mean probability mass of the picks
+	confidence := 0.0
+	for i, idx := range picks {
+		predictedNums[i] = idx + minRange
+		confidence += posterior[idx] / total
+	}
+	sort.Ints(predictedNums)
+
+	numbers, err := valueobject.NewNumbers(predictedNums)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create numbers: %w", err)
+	}
+
+	return &entity.Prediction{
+		ID:            "",
+		GameType:      gameType,
+		AlgorithmName: ba.name,
+		Numbers:       numbers,
+		Confidence:    confidence,
+		GeneratedAt:   time.Now(),
+		ForDate:       time.Now().Add(24 * time.Hour),
+		Metadata: map[string]string{
+			"prior_alpha":      strconv.FormatFloat(priorAlpha, 'g', -1, 64),
+			"draws_observed":   strconv.Itoa(drawsObserved),
+			"total_draws_used": strconv.Itoa(len(historicalData)),
+		},
+	}, nil
+}
+
+// countDraws counts how often each number, from the pool's lowest, was drawn
+// in historicalData, each draw number counted once, and how many draws
+// were counted
+func countDraws(gameType valueobject.GameType, historicalData []*entity.Draw) ([]float64, int) {
+	minRange, maxRange := gameType.NumberRange()
+	counts := make([]float64, maxRange-minRange+1)
+	seen := make(map[int]bool, len(historicalData))
+	for _, draw := range historicalData {
+		if seen[draw.DrawNumber] {
+			continue
+		}
+		seen[draw.DrawNumber] = true
+		for _, num := range draw.Numbers {
+			if num >= minRange && num <= maxRange {
+				counts[num-minRange]++
+			}
+		}
+	}
+	return counts, len(seen)
+}
+
+// Ensure BayesianAnalyzer implements Algorithm
+var _ Algorithm = (*BayesianAnalyzer)(nil)
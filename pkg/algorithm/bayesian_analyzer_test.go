@@ -0,0 +1,90 @@
+package algorithm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func TestBayesianAnalyzer_PicksMAPSet(t *testing.T) {
+	analyzer := NewBayesianAnalyzer(1.0)
+
+	// combinedScoreHistory draws 20-25 in 12 of its 20 draws
+	prediction, err := analyzer.Predict(context.Background(), valueobject.Mega645, combinedScoreHistory(t))
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{20, 21, 22, 23, 24, 25}, prediction.Numbers.AsSlice())
+	assert.Equal(t, "bayesian", prediction.AlgorithmName)
+	assert.Equal(t, "20", prediction.Metadata["draws_observed"])
+	// (6 * (1+13)) / (45 + 120)
+	assert.InDelta(t, 84.0/165, prediction.Confidence, 1e-9)
+}
+
+func TestBayesianAnalyzer_PosteriorFollowsWindow(t *testing.T) {
+	ctx := context.Background()
+	history := combinedScoreHistory(t)
+	analyzer := NewBayesianAnalyzer(1.0)
+	require.NoError(t, analyzer.SetMinDraws(8))
+
+	// Draws 1-8 run through the pool once, drawing 1-3 twice
+	require.NoError(t, analyzer.Train(ctx, history[:8]))
+	early, err := analyzer.Predict(ctx, valueobject.Mega645, history[:8])
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, early.Numbers.AsSlice())
+	assert.Equal(t, "8", early.Metadata["draws_observed"])
+	// (3 * (1+2) + 3 * (1+1)) / (45 + 48)
+	assert.InDelta(t, 15.0/93, early.Confidence, 1e-9)
+
+	// A later window isn't skewed by the draws an earlier one saw
+	late, err := analyzer.Predict(ctx, valueobject.Mega645, history[10:])
+	require.NoError(t, err)
+	assert.Equal(t, []int{20, 21, 22, 23, 24, 25}, late.Numbers.AsSlice())
+	assert.Equal(t, "10", late.Metadata["draws_observed"])
+	// (6 * (1+10)) / (45 + 60)
+	assert.InDelta(t, 66.0/105, late.Confidence, 1e-9)
+
+	// Nor is an earlier window by a later one
+	again, err := analyzer.Predict(ctx, valueobject.Mega645, history[:8])
+	require.NoError(t, err)
+	assert.Equal(t, early.Numbers.AsSlice(), again.Numbers.AsSlice())
+	assert.InDelta(t, early.Confidence, again.Confidence, 1e-9)
+}
+
+func TestBayesianAnalyzer_CountsEachDrawOnce(t *testing.T) {
+	history := combinedScoreHistory(t)
+	analyzer := NewBayesianAnalyzer(1.0)
+
+	prediction, err := analyzer.Predict(context.Background(), valueobject.Mega645, append(history[10:], history[10:]...))
+	require.NoError(t, err)
+	assert.Equal(t, "10", prediction.Metadata["draws_observed"])
+	assert.Equal(t, "20", prediction.Metadata["total_draws_used"])
+}
+
+func TestBayesianAnalyzer_PriorAlpha(t *testing.T) {
+	history := combinedScoreHistory(t)
+
+	confidence := func(alpha float64) float64 {
+		analyzer := NewBayesianAnalyzer(1.0)
+		require.NoError(t, analyzer.SetPriorAlpha(alpha))
+		prediction, err := analyzer.Predict(context.Background(), valueobject.Mega645, history)
+		require.NoError(t, err)
+		assert.Equal(t, []int{20, 21, 22, 23, 24, 25}, prediction.Numbers.AsSlice())
+		return prediction.Confidence
+	}
+
+	// A stronger prior pulls the posterior towards uniform, 6/45
+	assert.Greater(t, confidence(0.5), confidence(1))
+	assert.InDelta(t, 6.0/45, confidence(1e6), 1e-3)
+}
+
+func TestBayesianAnalyzer_Validation(t *testing.T) {
+	analyzer := NewBayesianAnalyzer(1.0)
+
+	assert.Equal(t, DefaultBayesianPriorAlpha, analyzer.GetPriorAlpha())
+	assert.Error(t, analyzer.SetPriorAlpha(0))
+	assert.Error(t, analyzer.SetWeight(-1))
+	assert.Error(t, analyzer.Validate(combinedScoreHistory(t)[:5]))
+}
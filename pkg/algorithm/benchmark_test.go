@@ -0,0 +1,69 @@
+package algorithm
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/testutil/fixtures"
+)
+
+// benchmarkHistorySizes are the history lengths every analyzer is benchmarked on
+var benchmarkHistorySizes = []int{200, 1000}
+
+// benchmarkAnalyzers builds a fresh instance of every analyzer
+func benchmarkAnalyzers() []Algorithm {
+	return []Algorithm{
+		NewFrequencyAnalyzer(1.0),
+		NewHotColdAnalyzer(1.0),
+		NewPatternAnalyzer(1.0),
+		NewRandomAnalyzer(1.0),
+		NewGapAnalyzer(1.0),
+		NewMonteCarloAnalyzer(1.0),
+		NewBayesianAnalyzer(1.0),
+		NewCombinedScoreAnalyzer(1.0),
+		NewDigitAnalyzer(1.0),
+	}
+}
+
+func BenchmarkPredict(b *testing.B) {
+	ctx := context.Background()
+	for _, size := range benchmarkHistorySizes {
+		draws := fixtures.Draws(valueobject.Mega645, size)
+		for _, algo := range benchmarkAnalyzers() {
+			b.Run(fmt.Sprintf("%s/draws=%d", algo.Name(), size), func(b *testing.B) {
+				b.ReportAllocs()
+				for b.Loop() {
+					if _, err := algo.Predict(ctx, valueobject.Mega645, draws); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkEnsemble_GeneratePredictions(b *testing.B) {
+	ctx := context.Background()
+	draws := fixtures.Draws(valueobject.Mega645, 200)
+
+	for _, strategy := range []VotingStrategy{WeightedVoting, MajorityVoting, ConfidenceWeighted} {
+		registry := NewRegistry()
+		for _, algo := range benchmarkAnalyzers() {
+			if err := registry.Register(algo, algo.GetWeight()); err != nil {
+				b.Fatal(err)
+			}
+		}
+		ensemble := NewEnsemble(registry, strategy)
+
+		b.Run(string(strategy), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := ensemble.GeneratePredictions(ctx, valueobject.Mega645, draws); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package algorithm
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// MaxCandidateSets bounds how many ranked sets a prediction may carry
+const MaxCandidateSets = 10
+
+// candidateSets returns count sets ranked by their numbers' mean share of
+// the votes, best first. The first is always the final six; the rest are
+// the best scoring other lines of the RankedPoolSize best ranked numbers
+// that keep every fixed number. Sets scoring the same keep the lower
+// numbers first.
+func candidateSets(
+	ranked []int,
+	fixed []int,
+	final valueobject.Numbers,
+	share func(num int) float64,
+	count int,
+) []entity.CandidateSet {
+	score := func(nums []int) float64 {
+		sum := 0.0
+		for _, num := range nums {
+			sum += share(num)
+		}
+		return sum / float64(len(nums))
+	}
+
+	finalNums := final.AsSlice()
+	sets := []entity.CandidateSet{{Numbers: finalNums, Confidence: score(finalNums)}}
+	if count <= 1 {
+		return sets
+	}
+
+	pool := betPool(ranked, fixed, final, RankedPoolSize)
+	var fixedMask uint32
+	for i, num := range pool {
+		if slices.Contains(fixed, num) {
+			fixedMask |= 1 << i
+		}
+	}
+
+	others := make([]entity.CandidateSet, 0)
+	for _, mask := range combinationMasks(len(pool), len(finalNums)) {
+		if mask&fixedMask != fixedMask {
+			continue
+		}
+		nums := maskNumbers(mask, pool)
+		if slices.Equal(nums, finalNums) {
+			continue
+		}
+		others = append(others, entity.CandidateSet{Numbers: nums, Confidence: score(nums)})
+	}
+	sort.SliceStable(others, func(i, j int) bool {
+		if others[i].Confidence != others[j].Confidence {
+			return others[i].Confidence > others[j].Confidence
+		}
+		return slices.Compare(others[i].Numbers, others[j].Numbers) < 0
+	})
+
+	return append(sets, others[:min(count-1, len(others))]...)
+}
@@ -0,0 +1,74 @@
+package algorithm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func TestCandidateSets_RankedByVoteShare(t *testing.T) {
+	ranked := []int{5, 12, 33, 1, 40, 18, 7, 29, 44, 2, 21, 36, 9, 15, 27, 30}
+	final := valueobject.MustNewNumbers([]int{1, 5, 12, 18, 33, 40})
+	// Shares fall with rank: 5 has 1.0, 12 0.95, ...
+	share := func(num int) float64 {
+		for i, r := range ranked {
+			if r == num {
+				return 1 - 0.05*float64(i)
+			}
+		}
+		return 0
+	}
+
+	sets := candidateSets(ranked, nil, final, share, 3)
+	require.Len(t, sets, 3)
+	assert.Equal(t, []int{1, 5, 12, 18, 33, 40}, sets[0].Numbers)
+	assert.InDelta(t, 0.875, sets[0].Confidence, 1e-9)
+	// The next best swap the sixth ranked (18) for the seventh (7), then the
+	// fifth (40) for it
+	assert.Equal(t, []int{1, 5, 7, 12, 33, 40}, sets[1].Numbers)
+	assert.Equal(t, []int{1, 5, 7, 12, 18, 33}, sets[2].Numbers)
+	assert.Greater(t, sets[0].Confidence, sets[1].Confidence)
+	assert.GreaterOrEqual(t, sets[1].Confidence, sets[2].Confidence)
+
+	// Fixed numbers stay in every set
+	fixed := []int{44}
+	blended := valueobject.MustNewNumbers([]int{1, 5, 12, 33, 40, 44})
+	for _, set := range candidateSets(ranked, fixed, blended, share, 5) {
+		assert.Contains(t, set.Numbers, 44)
+	}
+
+	assert.Len(t, candidateSets(ranked, nil, final, share, 1), 1)
+}
+
+func TestEnsemble_GeneratePredictions_CandidateSets(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.Register(NewFrequencyAnalyzer(1.0), 1.0))
+	require.NoError(t, registry.Register(NewHotColdAnalyzer(1.2), 1.2))
+	ensemble := NewEnsemble(registry, WeightedVoting)
+
+	prediction, err := ensemble.GeneratePredictions(context.Background(), valueobject.Mega645, createMockDraws(valueobject.Mega645, 150))
+	require.NoError(t, err)
+	assert.Empty(t, prediction.CandidateSets)
+
+	require.NoError(t, ensemble.SetCandidateSetCount(5))
+	prediction, err = ensemble.GeneratePredictions(context.Background(), valueobject.Mega645, createMockDraws(valueobject.Mega645, 150))
+	require.NoError(t, err)
+	require.Len(t, prediction.CandidateSets, 5)
+	assert.Equal(t, prediction.FinalNumbers.AsSlice(), prediction.CandidateSets[0].Numbers)
+	seen := make(map[string]bool)
+	for _, set := range prediction.CandidateSets {
+		assert.Len(t, set.Numbers, 6)
+		assert.IsIncreasing(t, set.Numbers)
+		assert.GreaterOrEqual(t, set.Confidence, 0.0)
+		assert.LessOrEqual(t, set.Confidence, 1.0)
+		key := setKey(set.Numbers, 0)
+		assert.False(t, seen[key], "%v suggested twice", set.Numbers)
+		seen[key] = true
+	}
+
+	assert.Error(t, ensemble.SetCandidateSetCount(-1))
+	assert.Error(t, ensemble.SetCandidateSetCount(MaxCandidateSets+1))
+}
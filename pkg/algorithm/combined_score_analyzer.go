@@ -0,0 +1,257 @@
+package algorithm
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// DefaultCombinedAlpha weighs frequency and overdue equally
+const DefaultCombinedAlpha = 0.5
+
+// CombinedScoreAnalyzer scores every number by how often it was drawn and how
+// long it has been overdue, as
+//
+//	alpha*normalizedFrequency + (1-alpha)*normalizedOverdue
+//
+// Both terms are min-max normalized across the pool. An alpha of 1 plays the
+// trends, 0 plays the overdue numbers.
+type CombinedScoreAnalyzer struct {
+	name     string
+	weight   float64
+	minDraws int
+	alpha    float64
+	mu       sync.RWMutex
+}
+
+// NewCombinedScoreAnalyzer creates a combined score analyzer with
+// DefaultCombinedAlpha
+func NewCombinedScoreAnalyzer(weight float64) *CombinedScoreAnalyzer {
+	return &CombinedScoreAnalyzer{
+		name:     "combined_score",
+		weight:   weight,
+		minDraws: 10,
+		alpha:    DefaultCombinedAlpha,
+	}
+}
+
+// Name returns the algorithm name
+func (ca *CombinedScoreAnalyzer) Name() string {
+	return ca.name
+}
+
+// GetWeight returns the algorithm's weight
+func (ca *CombinedScoreAnalyzer) GetWeight() float64 {
+	ca.mu.RLock()
+	defer ca.mu.RUnlock()
+	return ca.weight
+}
+
+// SetWeight sets the algorithm's weight
+func (ca *CombinedScoreAnalyzer) SetWeight(weight float64) error {
+	if weight < 0 {
+		return fmt.Errorf("weight cannot be negative, got %f", weight)
+	}
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+	ca.weight = weight
+	return nil
+}
+
+// GetAlpha returns the share of the score given to frequency
+func (ca *CombinedScoreAnalyzer) GetAlpha() float64 {
+	ca.mu.RLock()
+	defer ca.mu.RUnlock()
+	return ca.alpha
+}
+
+// SetAlpha sets the share of the score given to frequency, between 0 (only
+// overdue counts) and 1 (only frequency counts)
+func (ca *CombinedScoreAnalyzer) SetAlpha(alpha float64) error {
+	if alpha < 0 || alpha > 1 {
+		return fmt.Errorf("alpha must be between 0 and 1, got %f", alpha)
+	}
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+	ca.alpha = alpha
+	return nil
+}
+
+// SetMinDraws sets the minimum number of draws required
+func (ca *CombinedScoreAnalyzer) SetMinDraws(minDraws int) error {
+	if minDraws < 1 {
+		return fmt.Errorf("minimum draws must be at least 1, got %d", minDraws)
+	}
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+	ca.minDraws = minDraws
+	return nil
+}
+
+// GetMinDraws returns the minimum number of draws required
+func (ca *CombinedScoreAnalyzer) GetMinDraws() int {
+	ca.mu.RLock()
+	defer ca.mu.RUnlock()
+	return ca.minDraws
+}
+
+// PredictsConcurrently reports that predictions only depend on their history
+func (ca *CombinedScoreAnalyzer) PredictsConcurrently() bool {
+	return true
+}
+
+// Validate checks if there's enough data for prediction
+func (ca *CombinedScoreAnalyzer) Validate(historicalData []*entity.Draw) error {
+	if len(historicalData) < ca.minDraws {
+		return fmt.Errorf("need at least %d draws for combined score analysis, got %d",
+			ca.minDraws, len(historicalData))
+	}
+	return nil
+}
+
+// Train updates algorithm parameters (combined score doesn't need training)
+func (ca *CombinedScoreAnalyzer) Train(ctx context.Context, historicalData []*entity.Draw) error {
+	return nil
+}
+
+// Predict picks the six numbers with the highest combined score
+func (ca *CombinedScoreAnalyzer) Predict(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	historicalData []*entity.Draw,
+) (*entity.Prediction, error) {
+	if err := ca.Validate(historicalData); err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	alpha := ca.GetAlpha()
+	ranked, scores := ca.rankByScore(gameType, historicalData, alpha)
+
+	predictedNums := make([]int, 6)
+	copy(predictedNums, ranked[:6])
+	sort.Ints(predictedNums)
+
+	numbers, err := valueobject.NewNumbers(predictedNums)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create numbers: %w", err)
+	}
+
+	// Confidence is the average combined score of the picks, already in [0, 1]
+	confidence := 0.0
+	for _, num := range predictedNums {
+		confidence += scores[num]
+	}
+	confidence /= float64(len(predictedNums))
+
+	return &entity.Prediction{
+		ID:            "",
+		GameType:      gameType,
+		AlgorithmName: ca.name,
+		Numbers:       numbers,
+		Confidence:    confidence,
+		GeneratedAt:   time.Now(),
+		ForDate:       time.Now().Add(24 * time.Hour),
+		Metadata: map[string]string{
+			"alpha":            fmt.Sprintf("%g", alpha),
+			"total_draws_used": fmt.Sprintf("%d", len(historicalData)),
+		},
+	}, nil
+}
+
+// RankNumbers returns every number in the pool ordered by combined score
+func (ca *CombinedScoreAnalyzer) RankNumbers(
+	gameType valueobject.GameType,
+	historicalData []*entity.Draw,
+) ([]int, error) {
+	if err := ca.Validate(historicalData); err != nil {
+		return nil, err
+	}
+	ranked, _ := ca.rankByScore(gameType, historicalData, ca.GetAlpha())
+	return ranked, nil
+}
+
+// rankByScore orders the pool by combined score, lowest number first on
+// ties. A number's overdue value is how many draws ago it last came up, or
+// the number of draws if it never did.
+func (ca *CombinedScoreAnalyzer) rankByScore(
+	gameType valueobject.GameType,
+	historicalData []*entity.Draw,
+	alpha float64,
+) ([]int, map[int]float64) {
+	minRange, maxRange := gameType.NumberRange()
+
+	newestFirst := make([]*entity.Draw, len(historicalData))
+	copy(newestFirst, historicalData)
+	sort.SliceStable(newestFirst, func(i, j int) bool {
+		return newestFirst[i].DrawDate.After(newestFirst[j].DrawDate)
+	})
+
+	frequency := make(map[int]float64)
+	overdue := make(map[int]float64)
+	for num := minRange; num <= maxRange; num++ {
+		overdue[num] = float64(len(newestFirst))
+	}
+	for i, draw := range newestFirst {
+		for _, num := range draw.Numbers {
+			frequency[num]++
+			if float64(i) < overdue[num] {
+				overdue[num] = float64(i)
+			}
+		}
+	}
+
+	normFrequency := minMaxNormalize(frequency, minRange, maxRange)
+	normOverdue := minMaxNormalize(overdue, minRange, maxRange)
+
+	scores := make(map[int]float64, maxRange-minRange+1)
+	ranked := make([]int, 0, maxRange-minRange+1)
+	for num := minRange; num <= maxRange; num++ {
+		scores[num] = alpha*normFrequency[num] + (1-alpha)*normOverdue[num]
+		ranked = append(ranked, num)
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return scores[ranked[i]] > scores[ranked[j]]
+	})
+
+	return ranked, scores
+}
+
+// minMaxNormalize rescales values for minRange..maxRange to [0, 1]. Missing
+// numbers count as 0; if every value is equal they all normalize to 0.
+func minMaxNormalize(values map[int]float64, minRange, maxRange int) map[int]float64 {
+	lo, hi := values[minRange], values[minRange]
+	for num := minRange; num <= maxRange; num++ {
+		if values[num] < lo {
+			lo = values[num]
+		}
+		if values[num] > hi {
+			hi = values[num]
+		}
+	}
+
+	normalized := make(map[int]float64, maxRange-minRange+1)
+	for num := minRange; num <= maxRange; num++ {
+		if hi > lo {
+			normalized[num] = (values[num] - lo) / (hi - lo)
+		}
+	}
+	return normalized
+}
+
+// Ensure CombinedScoreAnalyzer implements Algorithm and Ranker
+var (
+	_ Algorithm = (*CombinedScoreAnalyzer)(nil)
+	_ Ranker    = (*CombinedScoreAnalyzer)(nil)
+)
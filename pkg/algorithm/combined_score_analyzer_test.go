@@ -0,0 +1,86 @@
+package algorithm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// combinedScoreHistory builds 20 Mega 6/45 draws, oldest first. The oldest
+// 8 cover the whole pool once (1, 2 and 3 twice); the latest 12 all draw
+// 20-25. So 20-25 are the most frequent, 4, 5 and 6 the most overdue (19
+// draws) followed by 7-12 (18 draws).
+func combinedScoreHistory(t *testing.T) []*entity.Draw {
+	t.Helper()
+
+	pool := make([]int, 0, 48)
+	for num := 1; num <= 45; num++ {
+		pool = append(pool, num)
+	}
+	pool = append(pool, 1, 2, 3)
+
+	start := time.Date(2026, 1, 1, 18, 0, 0, 0, time.UTC)
+	draws := make([]*entity.Draw, 0, 20)
+	for i := 0; i < 20; i++ {
+		nums := []int{20, 21, 22, 23, 24, 25}
+		if i < 8 {
+			nums = pool[i*6 : i*6+6]
+		}
+		draw, err := entity.NewDraw(valueobject.Mega645, i+1, valueobject.MustNewNumbers(nums),
+			start.AddDate(0, 0, 2*i), 0, 0)
+		require.NoError(t, err)
+		draws = append(draws, draw)
+	}
+	return draws
+}
+
+func TestCombinedScoreAnalyzer_AlphaShiftsSelection(t *testing.T) {
+	history := combinedScoreHistory(t)
+
+	tests := []struct {
+		alpha float64
+		want  []int
+	}{
+		{1, []int{20, 21, 22, 23, 24, 25}}, // Play the trends
+		{0, []int{4, 5, 6, 7, 8, 9}},       // Play the overdue
+		// 4-6 (overdue 1, frequency 0) tie 20-25 (0, 1) at 0.5; lower first
+		{0.5, []int{4, 5, 6, 20, 21, 22}},
+	}
+
+	for _, tt := range tests {
+		analyzer := NewCombinedScoreAnalyzer(1.0)
+		require.NoError(t, analyzer.SetAlpha(tt.alpha))
+
+		prediction, err := analyzer.Predict(context.Background(), valueobject.Mega645, history)
+		require.NoError(t, err)
+
+		assert.Equal(t, tt.want, prediction.Numbers.AsSlice(), "alpha %g", tt.alpha)
+		assert.GreaterOrEqual(t, prediction.Confidence, 0.0)
+		assert.LessOrEqual(t, prediction.Confidence, 1.0)
+	}
+}
+
+func TestCombinedScoreAnalyzer_RankNumbersCoversPool(t *testing.T) {
+	analyzer := NewCombinedScoreAnalyzer(1.0)
+
+	ranked, err := analyzer.RankNumbers(valueobject.Mega645, combinedScoreHistory(t))
+	require.NoError(t, err)
+
+	assert.Len(t, ranked, 45)
+	assert.Equal(t, []int{4, 5, 6, 20, 21, 22}, ranked[:6])
+}
+
+func TestCombinedScoreAnalyzer_Validation(t *testing.T) {
+	analyzer := NewCombinedScoreAnalyzer(1.0)
+
+	assert.Equal(t, DefaultCombinedAlpha, analyzer.GetAlpha())
+	assert.Error(t, analyzer.SetAlpha(-0.1))
+	assert.Error(t, analyzer.SetAlpha(1.1))
+	assert.Error(t, analyzer.SetWeight(-1))
+	assert.Error(t, analyzer.Validate(combinedScoreHistory(t)[:5]))
+}
@@ -0,0 +1,75 @@
+package algorithm
+
+import (
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// NumberComparison describes how two algorithms treat a single number
+type NumberComparison struct {
+	Number int
+	InA    bool
+	InB    bool
+	RankA  int // 1-based rank in algorithm A's ranking, 0 if unknown
+	RankB  int // 1-based rank in algorithm B's ranking, 0 if unknown
+}
+
+// Comparison is the side-by-side result of two algorithm predictions
+type Comparison struct {
+	A       *entity.Prediction
+	B       *entity.Prediction
+	Overlap []int              // Numbers picked by both, ascending
+	OnlyA   []int              // Numbers picked only by A, ascending
+	OnlyB   []int              // Numbers picked only by B, ascending
+	Numbers []NumberComparison // Every number picked by either, ascending
+}
+
+// OverlapCount returns how many numbers both algorithms picked
+func (c *Comparison) OverlapCount() int {
+	return len(c.Overlap)
+}
+
+// Compare builds a side-by-side comparison of two predictions. rankA and rankB
+// are the full rankings from each algorithm (see Ranker) and may be nil for
+// algorithms that can't rank, in which case their ranks are left at 0.
+func Compare(a, b *entity.Prediction, rankA, rankB []int) *Comparison {
+	positionsA := rankPositions(rankA)
+	positionsB := rankPositions(rankB)
+
+	comparison := &Comparison{A: a, B: b}
+
+	for _, num := range valueobject.UnionInts(a.Numbers, b.Numbers) {
+		inA := a.Numbers.Contains(num)
+		inB := b.Numbers.Contains(num)
+
+		switch {
+		case inA && inB:
+			comparison.Overlap = append(comparison.Overlap, num)
+		case inA:
+			comparison.OnlyA = append(comparison.OnlyA, num)
+		default:
+			comparison.OnlyB = append(comparison.OnlyB, num)
+		}
+
+		comparison.Numbers = append(comparison.Numbers, NumberComparison{
+			Number: num,
+			InA:    inA,
+			InB:    inB,
+			RankA:  positionsA[num],
+			RankB:  positionsB[num],
+		})
+	}
+
+	return comparison
+}
+
+// rankPositions maps each number to its 1-based position in a ranking
+func rankPositions(ranking []int) map[int]int {
+	positions := make(map[int]int, len(ranking))
+	for i, num := range ranking {
+		if _, exists := positions[num]; !exists {
+			positions[num] = i + 1
+		}
+	}
+	return positions
+}
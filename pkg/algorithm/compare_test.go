@@ -0,0 +1,98 @@
+package algorithm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func newComparePrediction(name string, nums []int) *entity.Prediction {
+	return &entity.Prediction{
+		GameType:      valueobject.Mega645,
+		AlgorithmName: name,
+		Numbers:       valueobject.MustNewNumbers(nums),
+	}
+}
+
+func TestCompare_Overlap(t *testing.T) {
+	a := newComparePrediction("frequency_analysis", []int{1, 5, 9, 12, 30, 44})
+	b := newComparePrediction("hot_cold_analysis", []int{5, 9, 13, 21, 30, 45})
+
+	comparison := Compare(a, b, nil, nil)
+
+	assert.Equal(t, 3, comparison.OverlapCount())
+	assert.Equal(t, []int{5, 9, 30}, comparison.Overlap)
+	assert.Equal(t, []int{1, 12, 44}, comparison.OnlyA)
+	assert.Equal(t, []int{13, 21, 45}, comparison.OnlyB)
+	assert.Len(t, comparison.Numbers, 9)
+}
+
+func TestCompare_NoOverlap(t *testing.T) {
+	a := newComparePrediction("a", []int{1, 2, 3, 4, 5, 6})
+	b := newComparePrediction("b", []int{7, 8, 9, 10, 11, 12})
+
+	comparison := Compare(a, b, nil, nil)
+
+	assert.Equal(t, 0, comparison.OverlapCount())
+	assert.Empty(t, comparison.Overlap)
+	assert.Len(t, comparison.OnlyA, 6)
+	assert.Len(t, comparison.OnlyB, 6)
+}
+
+func TestCompare_IdenticalPicks(t *testing.T) {
+	nums := []int{3, 9, 17, 22, 30, 41}
+	comparison := Compare(newComparePrediction("a", nums), newComparePrediction("b", nums), nil, nil)
+
+	assert.Equal(t, 6, comparison.OverlapCount())
+	assert.Empty(t, comparison.OnlyA)
+	assert.Empty(t, comparison.OnlyB)
+}
+
+func TestCompare_Ranks(t *testing.T) {
+	a := newComparePrediction("a", []int{1, 2, 3, 4, 5, 6})
+	b := newComparePrediction("b", []int{4, 5, 6, 7, 8, 9})
+	rankA := []int{3, 1, 2, 6, 5, 4, 9, 8, 7}
+	rankB := []int{9, 8, 7, 6, 5, 4, 3, 2, 1}
+
+	comparison := Compare(a, b, rankA, rankB)
+
+	byNumber := make(map[int]NumberComparison)
+	for _, nc := range comparison.Numbers {
+		byNumber[nc.Number] = nc
+	}
+
+	assert.Equal(t, NumberComparison{Number: 3, InA: true, InB: false, RankA: 1, RankB: 7}, byNumber[3])
+	assert.Equal(t, NumberComparison{Number: 4, InA: true, InB: true, RankA: 6, RankB: 6}, byNumber[4])
+	assert.Equal(t, NumberComparison{Number: 9, InA: false, InB: true, RankA: 7, RankB: 1}, byNumber[9])
+}
+
+func TestFrequencyAnalyzer_RankNumbers_MatchesPrediction(t *testing.T) {
+	analyzer := NewFrequencyAnalyzer(1.0)
+	draws := createMockDraws(valueobject.Mega645, 50)
+
+	ranked, err := analyzer.RankNumbers(valueobject.Mega645, draws)
+	require.NoError(t, err)
+	assert.Len(t, ranked, 45)
+
+	pred, err := analyzer.Predict(context.Background(), valueobject.Mega645, draws)
+	require.NoError(t, err)
+	for _, num := range ranked[:6] {
+		assert.True(t, pred.Numbers.Contains(num))
+	}
+}
+
+func TestCompare_UnrankedAlgorithm(t *testing.T) {
+	a := newComparePrediction("a", []int{1, 2, 3, 4, 5, 6})
+	b := newComparePrediction("b", []int{4, 5, 6, 7, 8, 9})
+
+	comparison := Compare(a, b, []int{6, 5, 4, 3, 2, 1, 7, 8, 9}, nil)
+
+	for _, nc := range comparison.Numbers {
+		assert.NotZero(t, nc.RankA)
+		assert.Zero(t, nc.RankB)
+	}
+}
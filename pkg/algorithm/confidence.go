@@ -0,0 +1,75 @@
+package algorithm
+
+import (
+	"fmt"
+
+	"github.com/tool_predict/internal/domain/entity"
+)
+
+// ConfidenceMethod decides how an ensemble prediction's overall confidence
+// is computed from the algorithms' confidences
+type ConfidenceMethod string
+
+const (
+	ConfidenceMethodMean      ConfidenceMethod = "mean"      // Plain mean (default)
+	ConfidenceMethodWeighted  ConfidenceMethod = "weighted"  // Mean weighted by algorithm weight
+	ConfidenceMethodConsensus ConfidenceMethod = "consensus" // Mean times the consensus score
+)
+
+// ParseConfidenceMethod parses an ensemble.confidence_method value. Empty
+// means ConfidenceMethodMean.
+func ParseConfidenceMethod(s string) (ConfidenceMethod, error) {
+	switch method := ConfidenceMethod(s); method {
+	case "":
+		return ConfidenceMethodMean, nil
+	case ConfidenceMethodMean, ConfidenceMethodWeighted, ConfidenceMethodConsensus:
+		return method, nil
+	default:
+		return "", fmt.Errorf("unknown confidence method %q (expected %s, %s or %s)",
+			s, ConfidenceMethodMean, ConfidenceMethodWeighted, ConfidenceMethodConsensus)
+	}
+}
+
+// SetConfidenceMethod sets how predictions' overall confidence is computed
+func (e *Ensemble) SetConfidenceMethod(method ConfidenceMethod) error {
+	parsed, err := ParseConfidenceMethod(string(method))
+	if err != nil {
+		return err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.confidenceMethod = parsed
+	return nil
+}
+
+// OverallConfidence combines the confidences of pred's algorithms into one,
+// between 0 and 1. The weighted method uses the weights recorded in
+// pred.AlgorithmStats and falls back to the mean when they are all zero.
+func (e *Ensemble) OverallConfidence(pred *entity.EnsemblePrediction, method ConfidenceMethod) float64 {
+	if len(pred.Predictions) == 0 {
+		return 0
+	}
+
+	total := 0.0
+	for _, p := range pred.Predictions {
+		total += p.Confidence
+	}
+	mean := total / float64(len(pred.Predictions))
+
+	switch method {
+	case ConfidenceMethodWeighted:
+		weighted, weights := 0.0, 0.0
+		for _, stat := range pred.AlgorithmStats {
+			weighted += stat.Weight * stat.Confidence
+			weights += stat.Weight
+		}
+		if weights > 0 {
+			return weighted / weights
+		}
+		return mean
+	case ConfidenceMethodConsensus:
+		return mean * e.GetConsensusScore(pred.Predictions)
+	default:
+		return mean
+	}
+}
@@ -0,0 +1,78 @@
+package algorithm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func TestEnsemble_OverallConfidence(t *testing.T) {
+	ensemble := NewEnsemble(NewRegistry(), WeightedVoting)
+
+	// The two algorithms share 3 of 6 numbers: consensus 0.5
+	pred := &entity.EnsemblePrediction{
+		Predictions: []*entity.Prediction{
+			{AlgorithmName: "frequency_analysis", Confidence: 0.8, Numbers: valueobject.MustNewNumbers([]int{1, 2, 3, 4, 5, 6})},
+			{AlgorithmName: "pattern_analysis", Confidence: 0.4, Numbers: valueobject.MustNewNumbers([]int{1, 2, 3, 7, 8, 9})},
+		},
+		AlgorithmStats: []entity.AlgorithmContribution{
+			{AlgorithmName: "frequency_analysis", Weight: 3, Confidence: 0.8},
+			{AlgorithmName: "pattern_analysis", Weight: 1, Confidence: 0.4},
+		},
+	}
+
+	tests := []struct {
+		method ConfidenceMethod
+		want   float64
+	}{
+		{ConfidenceMethodMean, 0.6},
+		{ConfidenceMethodWeighted, 0.7},
+		{ConfidenceMethodConsensus, 0.3},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.method), func(t *testing.T) {
+			assert.InDelta(t, tt.want, ensemble.OverallConfidence(pred, tt.method), 1e-9)
+		})
+	}
+
+	// Without weights the weighted method is the mean
+	for i := range pred.AlgorithmStats {
+		pred.AlgorithmStats[i].Weight = 0
+	}
+	assert.InDelta(t, 0.6, ensemble.OverallConfidence(pred, ConfidenceMethodWeighted), 1e-9)
+
+	assert.Zero(t, ensemble.OverallConfidence(&entity.EnsemblePrediction{}, ConfidenceMethodMean))
+}
+
+func TestEnsemble_GeneratePredictions_SetsConfidence(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.Register(NewFrequencyAnalyzer(1.0), 1.0))
+	require.NoError(t, registry.Register(NewHotColdAnalyzer(1.2), 1.2))
+	ensemble := NewEnsemble(registry, WeightedVoting)
+	draws := createMockDraws(valueobject.Mega645, 150)
+
+	for _, method := range []ConfidenceMethod{ConfidenceMethodMean, ConfidenceMethodWeighted, ConfidenceMethodConsensus} {
+		require.NoError(t, ensemble.SetConfidenceMethod(method))
+		prediction, err := ensemble.GeneratePredictions(context.Background(), valueobject.Mega645, draws)
+		require.NoError(t, err)
+		assert.Equal(t, ensemble.OverallConfidence(prediction, method), prediction.Confidence, method)
+	}
+}
+
+func TestParseConfidenceMethod(t *testing.T) {
+	method, err := ParseConfidenceMethod("")
+	require.NoError(t, err)
+	assert.Equal(t, ConfidenceMethodMean, method)
+
+	method, err = ParseConfidenceMethod("consensus")
+	require.NoError(t, err)
+	assert.Equal(t, ConfidenceMethodConsensus, method)
+
+	_, err = ParseConfidenceMethod("median")
+	assert.Error(t, err)
+	assert.Error(t, NewEnsemble(NewRegistry(), WeightedVoting).SetConfidenceMethod("median"))
+}
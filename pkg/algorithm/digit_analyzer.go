@@ -0,0 +1,316 @@
+package algorithm
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// DigitAnalyzer models how often each units digit (0-9) and each tens group
+// (1-9, 10-19, ...) is drawn, rather than each number. Every number scores
+// the product of its units digit's and tens group's draw rate, each divided
+// by how many numbers in the pool share it, so that 0 (four numbers in Mega
+// 6/45) isn't penalized against 1 (five numbers).
+//
+// The six picks follow the typical spread: each tens group and, where the
+// two allow it, each units digit gets its historical share of the six
+// numbers, filled with the best scoring numbers.
+type DigitAnalyzer struct {
+	name     string
+	weight   float64
+	minDraws int
+	mu       sync.RWMutex
+}
+
+// NewDigitAnalyzer creates a new digit analyzer
+func NewDigitAnalyzer(weight float64) *DigitAnalyzer {
+	return &DigitAnalyzer{
+		name:     "digit_analysis",
+		weight:   weight,
+		minDraws: 20,
+	}
+}
+
+// Name returns the algorithm name
+func (da *DigitAnalyzer) Name() string {
+	return da.name
+}
+
+// GetWeight returns the algorithm's weight
+func (da *DigitAnalyzer) GetWeight() float64 {
+	da.mu.RLock()
+	defer da.mu.RUnlock()
+	return da.weight
+}
+
+// SetWeight sets the algorithm's weight
+func (da *DigitAnalyzer) SetWeight(weight float64) error {
+	if weight < 0 {
+		return fmt.Errorf("weight cannot be negative, got %f", weight)
+	}
+	da.mu.Lock()
+	defer da.mu.Unlock()
+	da.weight = weight
+	return nil
+}
+
+// SetMinDraws sets the minimum number of draws required
+func (da *DigitAnalyzer) SetMinDraws(minDraws int) error {
+	if minDraws < 1 {
+		return fmt.Errorf("minimum draws must be at least 1, got %d", minDraws)
+	}
+	da.mu.Lock()
+	defer da.mu.Unlock()
+	da.minDraws = minDraws
+	return nil
+}
+
+// GetMinDraws returns the minimum number of draws required
+func (da *DigitAnalyzer) GetMinDraws() int {
+	da.mu.RLock()
+	defer da.mu.RUnlock()
+	return da.minDraws
+}
+
+// PredictsConcurrently reports that predictions only depend on their history
+func (da *DigitAnalyzer) PredictsConcurrently() bool {
+	return true
+}
+
+// Validate checks if there's enough data for prediction
+func (da *DigitAnalyzer) Validate(historicalData []*entity.Draw) error {
+	if len(historicalData) < da.minDraws {
+		return fmt.Errorf("need at least %d draws for digit analysis, got %d",
+			da.minDraws, len(historicalData))
+	}
+	return nil
+}
+
+// Train updates algorithm parameters (digit analysis doesn't need training)
+func (da *DigitAnalyzer) Train(ctx context.Context, historicalData []*entity.Draw) error {
+	return nil
+}
+
+// Predict picks six numbers matching the historical digit spread
+func (da *DigitAnalyzer) Predict(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	historicalData []*entity.Draw,
+) (*entity.Prediction, error) {
+	if err := da.Validate(historicalData); err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	dist := newDigitDistribution(gameType, historicalData)
+	tensQuota := largestRemainder(dist.tens, dist.tensPool, gameType.NumberCount())
+	unitsQuota := largestRemainder(dist.units, dist.unitsPool, gameType.NumberCount())
+	predictedNums := dist.pick(gameType, tensQuota, unitsQuota)
+	sort.Ints(predictedNums)
+
+	numbers, err := valueobject.NewNumbers(predictedNums)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create numbers: %w", err)
+	}
+
+	// Confidence is the picks' average score relative to the best number's
+	best := dist.score(dist.ranked(gameType)[0])
+	confidence := 0.0
+	if best > 0 {
+		for _, num := range predictedNums {
+			confidence += dist.score(num) / best
+		}
+		confidence /= float64(len(predictedNums))
+	}
+
+	return &entity.Prediction{
+		ID:            "",
+		GameType:      gameType,
+		AlgorithmName: da.name,
+		Numbers:       numbers,
+		Confidence:    confidence,
+		GeneratedAt:   time.Now(),
+		ForDate:       time.Now().Add(24 * time.Hour),
+		Metadata: map[string]string{
+			"units_digits":     formatShares(dist.units, "%d"),
+			"tens_groups":      formatShares(dist.tens, "%dx"),
+			"tens_quota":       fmt.Sprintf("%v", tensQuota),
+			"units_quota":      fmt.Sprintf("%v", unitsQuota),
+			"total_draws_used": fmt.Sprintf("%d", len(historicalData)),
+		},
+	}, nil
+}
+
+// RankNumbers returns every number in the pool ordered by digit score
+func (da *DigitAnalyzer) RankNumbers(
+	gameType valueobject.GameType,
+	historicalData []*entity.Draw,
+) ([]int, error) {
+	if err := da.Validate(historicalData); err != nil {
+		return nil, err
+	}
+	return newDigitDistribution(gameType, historicalData).ranked(gameType), nil
+}
+
+// digitDistribution holds how many drawn numbers had each units digit and
+// tens group, and how many numbers in the pool have each
+type digitDistribution struct {
+	units     []float64 // Drawn numbers per units digit
+	tens      []float64 // Drawn numbers per tens group (number / 10)
+	unitsPool []int
+	tensPool  []int
+}
+
+// newDigitDistribution counts the digits of every number in historicalData
+func newDigitDistribution(gameType valueobject.GameType, historicalData []*entity.Draw) *digitDistribution {
+	minRange, maxRange := gameType.NumberRange()
+	dist := &digitDistribution{
+		units:     make([]float64, 10),
+		tens:      make([]float64, maxRange/10+1),
+		unitsPool: make([]int, 10),
+		tensPool:  make([]int, maxRange/10+1),
+	}
+
+	for num := minRange; num <= maxRange; num++ {
+		dist.unitsPool[num%10]++
+		dist.tensPool[num/10]++
+	}
+	for _, draw := range historicalData {
+		for _, num := range draw.Numbers {
+			dist.units[num%10]++
+			dist.tens[num/10]++
+		}
+	}
+
+	return dist
+}
+
+// score is the product of num's units digit and tens group draw rates, each
+// per number of the pool sharing it
+func (d *digitDistribution) score(num int) float64 {
+	return d.units[num%10] / float64(d.unitsPool[num%10]) *
+		d.tens[num/10] / float64(d.tensPool[num/10])
+}
+
+// ranked returns the pool ordered by score, lowest number first on ties
+func (d *digitDistribution) ranked(gameType valueobject.GameType) []int {
+	minRange, maxRange := gameType.NumberRange()
+	ranked := make([]int, 0, maxRange-minRange+1)
+	for num := minRange; num <= maxRange; num++ {
+		ranked = append(ranked, num)
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return d.score(ranked[i]) > d.score(ranked[j])
+	})
+	return ranked
+}
+
+// largestRemainder splits count picks across keys in proportion to counts,
+// by largest remainder. No key gets more picks than its capacity.
+func largestRemainder(counts []float64, capacity []int, count int) []int {
+	total := 0.0
+	for _, n := range counts {
+		total += n
+	}
+
+	quota := make([]int, len(counts))
+	remainders := make([]float64, len(counts))
+	assigned := 0
+	for key, n := range counts {
+		share := 0.0
+		if total > 0 {
+			share = float64(count) * n / total
+		}
+		quota[key] = min(int(math.Floor(share)), capacity[key])
+		remainders[key] = share - float64(quota[key])
+		assigned += quota[key]
+	}
+
+	order := make([]int, len(counts))
+	for key := range order {
+		order[key] = key
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return remainders[order[i]] > remainders[order[j]]
+	})
+	for progress := true; assigned < count && progress; {
+		progress = false
+		for _, key := range order {
+			if assigned == count {
+				break
+			}
+			if quota[key] < capacity[key] {
+				quota[key]++
+				assigned++
+				progress = true
+			}
+		}
+	}
+
+	return quota
+}
+
+// pick takes the best scoring numbers so that each tens group gets exactly
+// tensQuota picks and, as far as that allows, each units digit unitsQuota
+func (d *digitDistribution) pick(gameType valueobject.GameType, tensQuota, unitsQuota []int) []int {
+	tensLeft := append([]int(nil), tensQuota...)
+	unitsLeft := append([]int(nil), unitsQuota...)
+	ranked := d.ranked(gameType)
+
+	picked := make([]int, 0, gameType.NumberCount())
+	taken := make(map[int]bool)
+	for _, num := range ranked {
+		if tensLeft[num/10] > 0 && unitsLeft[num%10] > 0 {
+			picked = append(picked, num)
+			taken[num] = true
+			tensLeft[num/10]--
+			unitsLeft[num%10]--
+		}
+	}
+	// Relax the units quota where it conflicts with the tens quota
+	for _, num := range ranked {
+		if !taken[num] && tensLeft[num/10] > 0 {
+			picked = append(picked, num)
+			tensLeft[num/10]--
+		}
+	}
+
+	return picked
+}
+
+// formatShares formats counts as each key's share of the total, e.g.
+// "0:9.8% 1:10.2%". label is the format of the key.
+func formatShares(counts []float64, label string) string {
+	total := 0.0
+	for _, n := range counts {
+		total += n
+	}
+
+	parts := make([]string, 0, len(counts))
+	for key, n := range counts {
+		share := 0.0
+		if total > 0 {
+			share = 100 * n / total
+		}
+		parts = append(parts, fmt.Sprintf(label+":%.1f%%", key, share))
+	}
+	return strings.Join(parts, " ")
+}
+
+// Ensure DigitAnalyzer implements Algorithm and Ranker
+var (
+	_ Algorithm = (*DigitAnalyzer)(nil)
+	_ Ranker    = (*DigitAnalyzer)(nil)
+)
@@ -0,0 +1,74 @@
+package algorithm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/testutil/fixtures"
+)
+
+func TestDigitAnalyzer_Predict_RangeAndUnique(t *testing.T) {
+	for _, gameType := range valueobject.AllGameTypes() {
+		analyzer := NewDigitAnalyzer(1.0)
+
+		prediction, err := analyzer.Predict(context.Background(), gameType, fixtures.Draws(gameType, 200))
+		require.NoError(t, err)
+
+		nums := prediction.Numbers.AsSlice()
+		assert.Len(t, nums, 6)
+		seen := make(map[int]bool)
+		for _, num := range nums {
+			assert.True(t, gameType.InRange(num), "%s: %d out of range", gameType, num)
+			assert.False(t, seen[num], "%s: %d picked twice", gameType, num)
+			seen[num] = true
+		}
+
+		assert.Equal(t, "digit_analysis", prediction.AlgorithmName)
+		assert.GreaterOrEqual(t, prediction.Confidence, 0.0)
+		assert.LessOrEqual(t, prediction.Confidence, 1.0)
+		assert.Contains(t, prediction.Metadata, "units_digits")
+		assert.Contains(t, prediction.Metadata, "tens_groups")
+		assert.Contains(t, prediction.Metadata, "tens_quota")
+	}
+}
+
+func TestDigitAnalyzer_FollowsDigitSpread(t *testing.T) {
+	// Every draw has three numbers in the 10s and three in the 30s, always
+	// ending in 1, 3 and 7
+	start := time.Date(2026, 1, 1, 18, 0, 0, 0, time.UTC)
+	draws := make([]*entity.Draw, 0, 20)
+	for i := 0; i < 20; i++ {
+		draw, err := entity.NewDraw(valueobject.Mega645, i+1, valueobject.MustNewNumbers([]int{11, 13, 17, 31, 33, 37}),
+			start.AddDate(0, 0, 2*i), 0, 0)
+		require.NoError(t, err)
+		draws = append(draws, draw)
+	}
+
+	analyzer := NewDigitAnalyzer(1.0)
+	prediction, err := analyzer.Predict(context.Background(), valueobject.Mega645, draws)
+	require.NoError(t, err)
+
+	// Three per group and two per drawn digit
+	assert.Equal(t, []int{11, 13, 17, 31, 33, 37}, prediction.Numbers.AsSlice())
+	assert.Equal(t, "[0 3 0 3 0]", prediction.Metadata["tens_quota"])
+	assert.Equal(t, "[0 2 0 2 0 0 0 2 0 0]", prediction.Metadata["units_quota"])
+	assert.Equal(t, "0x:0.0% 1x:50.0% 2x:0.0% 3x:50.0% 4x:0.0%", prediction.Metadata["tens_groups"])
+
+	ranked, err := analyzer.RankNumbers(valueobject.Mega645, draws)
+	require.NoError(t, err)
+	assert.Len(t, ranked, 45)
+	assert.ElementsMatch(t, []int{11, 13, 17, 31, 33, 37}, ranked[:6])
+}
+
+func TestDigitAnalyzer_Validation(t *testing.T) {
+	analyzer := NewDigitAnalyzer(1.0)
+
+	assert.Error(t, analyzer.SetWeight(-1))
+	assert.Error(t, analyzer.Validate(fixtures.Draws(valueobject.Mega645, 19)))
+	assert.NoError(t, analyzer.Validate(fixtures.Draws(valueobject.Mega645, 20)))
+}
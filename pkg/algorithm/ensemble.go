@@ -3,7 +3,11 @@ package algorithm
 import (
 	"context"
 	"fmt"
+	"math"
+	"slices"
 	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -18,21 +22,193 @@ const (
 	WeightedVoting     VotingStrategy = "weighted"
 	MajorityVoting     VotingStrategy = "majority"
 	ConfidenceWeighted VotingStrategy = "confidence_weighted"
+	PerNumberWeighted  VotingStrategy = "per_number_weighted"
+	Stacking           VotingStrategy = "stacking"
 )
 
 // Ensemble combines multiple algorithms using voting strategies
 type Ensemble struct {
-	registry       *Registry
-	votingStrategy VotingStrategy
-	mu             sync.RWMutex
+	registry         *Registry
+	votingStrategy   VotingStrategy
+	alternateCount   int                // Runner-up numbers reported beside the final six
+	multipliers      map[string]float64 // Per-algorithm confidence multipliers; nil leaves confidence as predicted
+	tieBreak         TieBreak           // Order of numbers with equal votes
+	betType          valueobject.BetType
+	coreCount        int // Best ranked numbers predictions carry as Core; 0 disables
+	wheelGuarantee   WheelGuarantee
+	wheelTickets     int // Most tickets of the abbreviated wheel predictions carry; 0 disables
+	candidateSets    int // Ranked sets predictions carry; 0 disables
+	confidenceMethod ConfidenceMethod
+	minVoters        int            // Distinct algorithms a number needs to be preferred for the final six; 0 or 1 disables
+	minDiversity     int            // Distinct algorithms the final six must draw on; 0 or 1 disables
+	stacking         *StackingModel // Meta-model Stacking voting scores with; nil votes like WeightedVoting
+	mu               sync.RWMutex
 }
 
+// MetadataConfidenceMultiplier is the prediction metadata key recording the
+// multiplier applied to its confidence
+const MetadataConfidenceMultiplier = "confidence_multiplier"
+
+// DefaultAlternateCount is how many runner-up numbers (ranked 7-10 by vote)
+// ensemble predictions carry by default
+const DefaultAlternateCount = 4
+
+// RankedPoolSize is how many of the best ranked numbers by vote ensemble
+// predictions carry as their ranked pool
+const RankedPoolSize = 15
+
 // NewEnsemble creates a new ensemble with the given registry and voting strategy
 func NewEnsemble(registry *Registry, votingStrategy VotingStrategy) *Ensemble {
 	return &Ensemble{
-		registry:       registry,
-		votingStrategy: votingStrategy,
+		registry:         registry,
+		votingStrategy:   votingStrategy,
+		alternateCount:   DefaultAlternateCount,
+		tieBreak:         TieBreakLow,
+		betType:          valueobject.BetSingle,
+		confidenceMethod: ConfidenceMethodMean,
+	}
+}
+
+// SetTieBreak sets which of several numbers with equal votes ranks first
+func (e *Ensemble) SetTieBreak(tieBreak TieBreak) error {
+	tb, err := ParseTieBreak(string(tieBreak))
+	if err != nil {
+		return err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.tieBreak = tb
+	return nil
+}
+
+// SetAlternateCount sets how many runner-up numbers predictions carry.
+// Zero disables alternates.
+func (e *Ensemble) SetAlternateCount(count int) error {
+	if count < 0 {
+		return fmt.Errorf("alternate count cannot be negative, got %d", count)
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.alternateCount = count
+	return nil
+}
+
+// SetBetType makes predictions carry a pool of numbers for a bao bet besides
+// the final six: the best ranked numbers by vote, as many as the bet marks.
+// valueobject.BetSingle disables the pool.
+func (e *Ensemble) SetBetType(betType valueobject.BetType) error {
+	if betType.NumberCount() < 1 {
+		return fmt.Errorf("bet type must mark at least one number, got %d", betType.NumberCount())
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.betType = betType
+	return nil
+}
+
+// SetCoreCount makes predictions carry the count best ranked numbers with
+// their confidence, for players who pick the rest of the ticket themselves.
+// Zero disables it.
+func (e *Ensemble) SetCoreCount(count int) error {
+	if count < 0 || count >= 6 {
+		return fmt.Errorf("core count must be between 0 and 5, got %d", count)
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.coreCount = count
+	return nil
+}
+
+// SetWheel makes predictions carry an abbreviated wheel with the given
+// guarantee over as many of the best ranked numbers as fit in maxTickets
+// tickets. Zero tickets disables it.
+func (e *Ensemble) SetWheel(guarantee WheelGuarantee, maxTickets int) error {
+	g, err := ParseWheelGuarantee(string(guarantee))
+	if err != nil {
+		return err
+	}
+	if maxTickets < 0 {
+		return fmt.Errorf("wheel tickets cannot be negative, got %d", maxTickets)
 	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.wheelGuarantee = g
+	e.wheelTickets = maxTickets
+	return nil
+}
+
+// SetCandidateSetCount makes predictions carry the count best lines by vote,
+// the final six first, each with a confidence. Zero disables them.
+func (e *Ensemble) SetCandidateSetCount(count int) error {
+	if count < 0 || count > MaxCandidateSets {
+		return fmt.Errorf("candidate set count must be between 0 and %d, got %d", MaxCandidateSets, count)
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.candidateSets = count
+	return nil
+}
+
+// SetMinVoters makes the final six prefer numbers picked by at least k
+// distinct algorithms, so no single algorithm decides the ticket. When fewer
+// than six numbers have that much consensus the rest are filled by vote as
+// usual. Zero or one disables it.
+func (e *Ensemble) SetMinVoters(k int) error {
+	if k < 0 {
+		return fmt.Errorf("min voters cannot be negative, got %d", k)
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.minVoters = k
+	return nil
+}
+
+// SetMinAlgorithmDiversity makes the final six draw on the picks of at least
+// k distinct algorithms, so a single heavily weighted algorithm can't fill the
+// ticket alone. When the vote leaves too few represented, the worst ranked
+// numbers give way to the best ranked picks of the missing algorithms. Zero
+// or one disables it.
+func (e *Ensemble) SetMinAlgorithmDiversity(k int) error {
+	if k < 0 {
+		return fmt.Errorf("min algorithm diversity cannot be negative, got %d", k)
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.minDiversity = k
+	return nil
+}
+
+// SetConfidenceMultipliers scales each algorithm's prediction confidence by
+// its multiplier, e.g. analytics.AgreementIndex scores, capped at 1.
+// Algorithms without a multiplier keep their confidence; nil disables
+// scaling.
+func (e *Ensemble) SetConfidenceMultipliers(multipliers map[string]float64) error {
+	for name, m := range multipliers {
+		if m < 0 {
+			return fmt.Errorf("confidence multiplier for %s cannot be negative, got %f", name, m)
+		}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if multipliers == nil {
+		e.multipliers = nil
+		return nil
+	}
+	e.multipliers = make(map[string]float64, len(multipliers))
+	for name, m := range multipliers {
+		e.multipliers[name] = m
+	}
+	return nil
+}
+
+// SetStackingModel sets the meta-model Stacking voting scores numbers with,
+// e.g. one from TrainStackingModel. Without one Stacking votes like
+// WeightedVoting.
+func (e *Ensemble) SetStackingModel(model *StackingModel) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.stacking = model
 }
 
 // SetVotingStrategy changes the voting strategy
@@ -49,11 +225,73 @@ func (e *Ensemble) GetVotingStrategy() VotingStrategy {
 	return e.votingStrategy
 }
 
+// MinDrawsRequired returns the number of draws needed for every registered
+// algorithm to contribute, and the algorithm that needs the most
+func (e *Ensemble) MinDrawsRequired() (int, string) {
+	return e.registry.MinDrawsRequired()
+}
+
+// Metadata keys describing a blended prediction
+const (
+	MetadataBlend      = "blend"       // Blend mode, "mine" when the player's numbers were fixed
+	MetadataBlendMine  = "blend_mine"  // The player's fixed numbers
+	MetadataBlendVoted = "blend_voted" // The numbers the ensemble chose for the remaining slots
+)
+
 // GeneratePredictions generates predictions from all algorithms and combines them
 func (e *Ensemble) GeneratePredictions(
 	ctx context.Context,
 	gameType valueobject.GameType,
 	historicalData []*entity.Draw,
+) (*entity.EnsemblePrediction, error) {
+	return e.generate(ctx, gameType, historicalData, nil)
+}
+
+// GenerateBlendedPredictions works like GeneratePredictions but always keeps
+// the player's own numbers. The ensemble fills the remaining slots from its
+// vote ranking, skipping the player's numbers, and the blend is recorded in
+// the prediction metadata.
+func (e *Ensemble) GenerateBlendedPredictions(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	historicalData []*entity.Draw,
+	mine []int,
+) (*entity.EnsemblePrediction, error) {
+	if err := ValidateMine(gameType, mine); err != nil {
+		return nil, err
+	}
+	return e.generate(ctx, gameType, historicalData, mine)
+}
+
+// ValidateMine checks that the player's numbers can be blended into a ticket:
+// between one and five distinct numbers within the game's range
+func ValidateMine(gameType valueobject.GameType, mine []int) error {
+	if len(mine) == 0 {
+		return fmt.Errorf("at least one number of your own is required to blend")
+	}
+	if len(mine) >= gameType.NumberCount() {
+		return fmt.Errorf("at most %d numbers of your own can be blended, got %d",
+			gameType.NumberCount()-1, len(mine))
+	}
+
+	for _, num := range mine {
+		if err := gameType.ValidateNumber(num); err != nil {
+			return err
+		}
+	}
+	if distinct := valueobject.UnionInts(mine); len(distinct) < len(mine) {
+		return fmt.Errorf("your numbers contain duplicates: %s", joinInts(mine))
+	}
+	return nil
+}
+
+// generate runs every algorithm and votes on the result. Numbers in fixed
+// are kept as-is and only the remaining slots are voted on.
+func (e *Ensemble) generate(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	historicalData []*entity.Draw,
+	fixed []int,
 ) (*entity.EnsemblePrediction, error) {
 	algorithms := e.registry.GetAll()
 
@@ -85,9 +323,18 @@ func (e *Ensemble) GeneratePredictions(
 	// Apply voting strategy
 	e.mu.RLock()
 	strategy := e.votingStrategy
+	alternateCount := e.alternateCount
+	betType := e.betType
+	coreCount := e.coreCount
+	wheelGuarantee, wheelTickets := e.wheelGuarantee, e.wheelTickets
+	candidateSetCount := e.candidateSets
+	confidenceMethod := e.confidenceMethod
+	breakTie := newTieBreaker(e.tieBreak, historicalData)
+	rules := consensusRules{minVoters: e.minVoters, minDiversity: e.minDiversity}
+	applyConfidenceMultipliers(predictions, e.multipliers)
 	e.mu.RUnlock()
 
-	finalNumbers, err := e.applyVotingStrategy(predictions, strategy)
+	finalNumbers, ranked, err := e.applyVotingStrategy(predictions, strategy, fixed, breakTie, rules)
 	if err != nil {
 		return nil, fmt.Errorf("failed to apply voting strategy: %w", err)
 	}
@@ -103,31 +350,246 @@ func (e *Ensemble) GeneratePredictions(
 		FinalNumbers:   finalNumbers,
 		VotingStrategy: string(strategy),
 		GeneratedAt:    time.Now(),
+		ForDate:        nextDrawDate(gameType, historicalData),
 		AlgorithmStats: contributions,
+		Alternates:     alternatesFrom(ranked, finalNumbers, alternateCount),
+		RankedPool:     rankedPool(fullRanking(ranked, gameType, breakTie)),
+	}
+
+	ensemblePred.Confidence = e.OverallConfidence(ensemblePred, confidenceMethod)
+
+	if len(fixed) > 0 {
+		ensemblePred.Metadata = blendMetadata(fixed, finalNumbers)
+	}
+
+	if betType.IsBao() {
+		if err := betType.ValidateFor(gameType); err != nil {
+			return nil, err
+		}
+		ensemblePred.BetType = betType.String()
+		ensemblePred.BetPool = betPool(fullRanking(ranked, gameType, breakTie), fixed, finalNumbers, betType.NumberCount())
+	}
+
+	if coreCount > 0 {
+		ensemblePred.Core = coreNumbers(ranked, e.countVotes(predictions, strategy), predictions, coreCount)
+	}
+
+	if candidateSetCount > 0 {
+		share := voteShare(e.countVotes(predictions, strategy), predictions)
+		ensemblePred.CandidateSets = candidateSets(fullRanking(ranked, gameType, breakTie), fixed, finalNumbers, share, candidateSetCount)
+	}
+
+	if wheelTickets > 0 {
+		generator, err := NewWheelGenerator(wheelGuarantee, gameType.NumberCount())
+		if err != nil {
+			return nil, err
+		}
+		ensemblePred.Wheel, err = generator.Widest(fullRanking(ranked, gameType, breakTie), finalNumbers, wheelTickets)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build wheel: %w", err)
+		}
 	}
 
 	return ensemblePred, nil
 }
 
-// applyVotingStrategy applies the specified voting strategy
+// applyConfidenceMultipliers scales the confidence of predictions whose
+// algorithm has a multiplier, capping it at 1
+func applyConfidenceMultipliers(predictions []*entity.Prediction, multipliers map[string]float64) {
+	for _, pred := range predictions {
+		m, ok := multipliers[pred.AlgorithmName]
+		if !ok {
+			continue
+		}
+		pred.Confidence = math.Min(pred.Confidence*m, 1)
+		if pred.Metadata == nil {
+			pred.Metadata = make(map[string]string)
+		}
+		pred.Metadata[MetadataConfidenceMultiplier] = strconv.FormatFloat(m, 'f', 3, 64)
+	}
+}
+
+// nextDrawDate returns the scheduled draw following the latest draw in
+// historicalData, i.e. the draw a prediction made from it targets
+func nextDrawDate(gameType valueobject.GameType, historicalData []*entity.Draw) time.Time {
+	var latest time.Time
+	for _, draw := range historicalData {
+		if draw.DrawDate.After(latest) {
+			latest = draw.DrawDate
+		}
+	}
+	if latest.IsZero() {
+		return time.Time{}
+	}
+	return gameType.NextDrawDate(latest)
+}
+
+// blendMetadata describes how a blended prediction was put together
+func blendMetadata(mine []int, final valueobject.Numbers) map[string]string {
+	return map[string]string{
+		MetadataBlend:      "mine",
+		MetadataBlendMine:  joinInts(valueobject.UnionInts(mine)),
+		MetadataBlendVoted: joinInts(valueobject.DifferenceInts(final, mine)),
+	}
+}
+
+// joinInts formats numbers as a comma-separated list
+func joinInts(nums []int) string {
+	parts := make([]string, len(nums))
+	for i, num := range nums {
+		parts[i] = strconv.Itoa(num)
+	}
+	return strings.Join(parts, ",")
+}
+
+// consensusRules are the agreement minimums the final six must meet, read
+// from the ensemble's settings once per run
+type consensusRules struct {
+	minVoters    int
+	minDiversity int
+}
+
+// applyVotingStrategy applies the specified voting strategy and returns the
+// final six numbers together with the full vote ranking. Numbers in fixed
+// always make the final six; the rest are taken from the ranking under rules.
 func (e *Ensemble) applyVotingStrategy(
 	predictions []*entity.Prediction,
 	strategy VotingStrategy,
-) (valueobject.Numbers, error) {
+	fixed []int,
+	breakTie tieBreaker,
+	rules consensusRules,
+) (valueobject.Numbers, []int, error) {
+	ranked := rankByVotes(e.countVotes(predictions, strategy), breakTie)
+
+	// Keep the fixed numbers, then take the best ranked up to 6
+	result := append(make([]int, 0, 6), fixed...)
+	for _, num := range preferConsensus(ranked, predictions, rules.minVoters) {
+		if len(result) >= 6 {
+			break
+		}
+		if !slices.Contains(fixed, num) {
+			result = append(result, num)
+		}
+	}
+
+	// Handle ties - if we have less than 6, add more
+	if len(result) < 6 {
+		// This is rare, but handle it by adding from predictions
+		result = e.fillRemainingFromPredictions(result, predictions)
+	}
+	result = diversify(result, ranked, fixed, predictions, rules.minDiversity)
+
+	sort.Ints(result)
+	numbers, err := valueobject.NewNumbers(result)
+	if err != nil {
+		return nil, nil, err
+	}
+	return numbers, ranked, nil
+}
+
+// preferConsensus moves the numbers picked by at least minVoters distinct
+// algorithms ahead of the rest, keeping each group in rank order. With
+// minVoters of 1 or less ranked is returned as-is.
+func preferConsensus(ranked []int, predictions []*entity.Prediction, minVoters int) []int {
+	if minVoters <= 1 {
+		return ranked
+	}
+
+	voters := make(map[int]map[string]bool)
+	for _, pred := range predictions {
+		for _, num := range pred.Numbers {
+			if voters[num] == nil {
+				voters[num] = make(map[string]bool)
+			}
+			voters[num][pred.AlgorithmName] = true
+		}
+	}
+
+	preferred := make([]int, 0, len(ranked))
+	var rest []int
+	for _, num := range ranked {
+		if len(voters[num]) >= minVoters {
+			preferred = append(preferred, num)
+		} else {
+			rest = append(rest, num)
+		}
+	}
+	return append(preferred, rest...)
+}
+
+// diversify swaps numbers of result for the best ranked picks of
+// unrepresented algorithms until the picks of at least minDiversity distinct
+// algorithms are among result, or no swap adds one. An algorithm is
+// represented when result holds any of its picks. Each swap gives up the
+// last taken number the candidate can stand in for, which isn't always the
+// worst ranked: after preferConsensus, numbers short of the minimum voters
+// come last and go before any consensus number. Fixed numbers are never
+// swapped out.
+func diversify(result, ranked, fixed []int, predictions []*entity.Prediction, minDiversity int) []int {
+	if minDiversity <= 1 {
+		return result
+	}
+
+	picks := make(map[string][]int)
+	for _, pred := range predictions {
+		picks[pred.AlgorithmName] = append(picks[pred.AlgorithmName], pred.Numbers...)
+	}
+	represented := func(nums []int) int {
+		count := 0
+		for _, p := range picks {
+			if slices.ContainsFunc(p, func(num int) bool { return slices.Contains(nums, num) }) {
+				count++
+			}
+		}
+		return count
+	}
+
+	for current := represented(result); current < minDiversity; current = represented(result) {
+		swapped := false
+		for _, candidate := range ranked {
+			if swapped {
+				break
+			}
+			if slices.Contains(result, candidate) {
+				continue
+			}
+			// Replace the worst ranked number the candidate can stand in for
+			for i := len(result) - 1; i >= 0; i-- {
+				if slices.Contains(fixed, result[i]) {
+					continue
+				}
+				trial := append(slices.Delete(slices.Clone(result), i, i+1), candidate)
+				if represented(trial) > current {
+					result, swapped = trial, true
+					break
+				}
+			}
+		}
+		if !swapped {
+			break
+		}
+	}
+	return result
+}
+
+// countVotes tallies each number's votes under strategy
+func (e *Ensemble) countVotes(predictions []*entity.Prediction, strategy VotingStrategy) map[int]float64 {
 	switch strategy {
-	case WeightedVoting:
-		return e.weightedVoting(predictions)
 	case MajorityVoting:
 		return e.majorityVoting(predictions)
 	case ConfidenceWeighted:
 		return e.confidenceWeightedVoting(predictions)
+	case PerNumberWeighted:
+		return e.perNumberWeightedVoting(predictions)
+	case Stacking:
+		return e.stackingVoting(predictions)
 	default:
 		return e.weightedVoting(predictions)
 	}
 }
 
 // weightedVoting uses algorithm weights from the registry for voting
-func (e *Ensemble) weightedVoting(predictions []*entity.Prediction) (valueobject.Numbers, error) {
+func (e *Ensemble) weightedVoting(predictions []*entity.Prediction) map[int]float64 {
 	voteCount := make(map[int]float64)
 
 	for _, pred := range predictions {
@@ -137,105 +599,192 @@ func (e *Ensemble) weightedVoting(predictions []*entity.Prediction) (valueobject
 		}
 	}
 
-	// Sort by vote count
-	type numVote struct {
-		num   int
-		votes float64
-	}
+	return voteCount
+}
+
+// majorityVoting uses simple majority voting
+func (e *Ensemble) majorityVoting(predictions []*entity.Prediction) map[int]float64 {
+	voteCount := make(map[int]float64)
 
-	sorted := make([]numVote, 0, len(voteCount))
-	for num, votes := range voteCount {
-		sorted = append(sorted, numVote{num: num, votes: votes})
+	for _, pred := range predictions {
+		for _, num := range pred.Numbers {
+			voteCount[num]++
+		}
 	}
 
-	sort.Slice(sorted, func(i, j int) bool {
-		return sorted[i].votes > sorted[j].votes
-	})
+	return voteCount
+}
 
-	// Take top 6
-	result := make([]int, 6)
-	for i := 0; i < 6 && i < len(sorted); i++ {
-		result[i] = sorted[i].num
+// confidenceWeightedVoting uses confidence scores as weights
+func (e *Ensemble) confidenceWeightedVoting(predictions []*entity.Prediction) map[int]float64 {
+	voteCount := make(map[int]float64)
+
+	for _, pred := range predictions {
+		for _, num := range pred.Numbers {
+			voteCount[num] += pred.Confidence
+		}
 	}
 
-	// Handle ties - if we have less than 6, add more
-	if len(sorted) < 6 {
-		// This is rare, but handle it by adding from predictions
-		result = e.fillRemainingFromPredictions(result, predictions)
+	return voteCount
+}
+
+// perNumberWeightedVoting scales each algorithm's weight by how reliably it
+// predicts the number being voted for (see Registry.SetPerNumberWeights)
+func (e *Ensemble) perNumberWeightedVoting(predictions []*entity.Prediction) map[int]float64 {
+	voteCount := make(map[int]float64)
+
+	for _, pred := range predictions {
+		weight := e.registry.GetWeight(pred.AlgorithmName)
+		for _, num := range pred.Numbers {
+			voteCount[num] += weight * e.registry.GetPerNumberWeight(pred.AlgorithmName, num)
+		}
 	}
 
-	sort.Ints(result)
-	return valueobject.NewNumbers(result)
+	return voteCount
 }
 
-// majorityVoting uses simple majority voting
-func (e *Ensemble) majorityVoting(predictions []*entity.Prediction) (valueobject.Numbers, error) {
-	voteCount := make(map[int]int)
+// stackingVoting scores each picked number with the stacking meta-model's
+// probability of it being drawn given which algorithms picked it
+func (e *Ensemble) stackingVoting(predictions []*entity.Prediction) map[int]float64 {
+	e.mu.RLock()
+	model := e.stacking
+	e.mu.RUnlock()
+	if model == nil {
+		return e.weightedVoting(predictions)
+	}
 
+	pickedBy := make(map[int][]string)
 	for _, pred := range predictions {
 		for _, num := range pred.Numbers {
-			voteCount[num]++
+			pickedBy[num] = append(pickedBy[num], pred.AlgorithmName)
 		}
 	}
 
-	// Sort by vote count
-	type numVote struct {
-		num   int
-		votes int
+	voteCount := make(map[int]float64, len(pickedBy))
+	for num, names := range pickedBy {
+		voteCount[num] = model.Probability(names)
+	}
+	return voteCount
+}
+
+// rankByVotes orders numbers by vote count, breaking ties with breakTie or,
+// when nil, lowest number first
+func rankByVotes(voteCount map[int]float64, breakTie tieBreaker) []int {
+	if breakTie == nil {
+		breakTie = newTieBreaker(TieBreakLow, nil)
 	}
 
-	sorted := make([]numVote, 0, len(voteCount))
-	for num, votes := range voteCount {
-		sorted = append(sorted, numVote{num: num, votes: votes})
+	ranked := make([]int, 0, len(voteCount))
+	for num := range voteCount {
+		ranked = append(ranked, num)
 	}
 
-	sort.Slice(sorted, func(i, j int) bool {
-		return sorted[i].votes > sorted[j].votes
+	sort.Slice(ranked, func(i, j int) bool {
+		if voteCount[ranked[i]] != voteCount[ranked[j]] {
+			return voteCount[ranked[i]] > voteCount[ranked[j]]
+		}
+		return breakTie(ranked[i], ranked[j])
 	})
 
-	// Take top 6
-	result := make([]int, 6)
-	for i := 0; i < 6 && i < len(sorted); i++ {
-		result[i] = sorted[i].num
-	}
+	return ranked
+}
 
-	sort.Ints(result)
-	return valueobject.NewNumbers(result)
+// alternatesFrom returns up to count numbers from ranked that are not in
+// final, in rank order
+func alternatesFrom(ranked []int, final valueobject.Numbers, count int) []int {
+	alternates := make([]int, 0, count)
+	for _, num := range ranked {
+		if len(alternates) >= count {
+			break
+		}
+		if !final.Contains(num) {
+			alternates = append(alternates, num)
+		}
+	}
+	return alternates
 }
 
-// confidenceWeightedVoting uses confidence scores as weights
-func (e *Ensemble) confidenceWeightedVoting(predictions []*entity.Prediction) (valueobject.Numbers, error) {
-	voteCount := make(map[int]float64)
+// rankedPool returns the first RankedPoolSize numbers of a full ranking
+func rankedPool(full []int) []int {
+	return slices.Clone(full[:min(len(full), RankedPoolSize)])
+}
 
-	for _, pred := range predictions {
-		for _, num := range pred.Numbers {
-			voteCount[num] += pred.Confidence
+// fullRanking extends the vote ranking with the numbers nobody voted for,
+// ordered by breakTie, so that it covers the game's whole range
+func fullRanking(ranked []int, gameType valueobject.GameType, breakTie tieBreaker) []int {
+	minRange, maxRange := gameType.NumberRange()
+	unvoted := make([]int, 0, maxRange-minRange+1)
+	for num := minRange; num <= maxRange; num++ {
+		if !slices.Contains(ranked, num) {
+			unvoted = append(unvoted, num)
 		}
 	}
+	sort.Slice(unvoted, func(i, j int) bool {
+		return breakTie(unvoted[i], unvoted[j])
+	})
+	return append(slices.Clone(ranked), unvoted...)
+}
 
-	// Sort by vote count
-	type numVote struct {
-		num   int
-		votes float64
+// betPool returns the size best numbers for a bao bet, ascending: the
+// player's fixed numbers, then the final six, then the rest, each in rank
+// order. A pool smaller than six drops the lowest ranked of the final six.
+func betPool(ranked []int, fixed []int, final valueobject.Numbers, size int) []int {
+	pool := make([]int, 0, size)
+	add := func(num int) {
+		if len(pool) < size && !slices.Contains(pool, num) {
+			pool = append(pool, num)
+		}
 	}
 
-	sorted := make([]numVote, 0, len(voteCount))
-	for num, votes := range voteCount {
-		sorted = append(sorted, numVote{num: num, votes: votes})
+	for _, num := range fixed {
+		add(num)
+	}
+	for _, num := range ranked {
+		if final.Contains(num) {
+			add(num)
+		}
+	}
+	for _, num := range final {
+		add(num)
+	}
+	for _, num := range ranked {
+		add(num)
 	}
 
-	sort.Slice(sorted, func(i, j int) bool {
-		return sorted[i].votes > sorted[j].votes
-	})
+	sort.Ints(pool)
+	return pool
+}
+
+// coreNumbers returns the count best ranked numbers with their share of the
+// votes
+func coreNumbers(ranked []int, voteCount map[int]float64, predictions []*entity.Prediction, count int) []entity.CoreNumber {
+	share := voteShare(voteCount, predictions)
+	core := make([]entity.CoreNumber, 0, count)
+	for _, num := range ranked[:min(count, len(ranked))] {
+		core = append(core, entity.CoreNumber{Number: num, Confidence: share(num)})
+	}
+	return core
+}
 
-	// Take top 6
-	result := make([]int, 6)
-	for i := 0; i < 6 && i < len(sorted); i++ {
-		result[i] = sorted[i].num
+// voteShare returns a number's share of the votes, 0-1. Each prediction
+// spreads its vote over its numbers, so a number every algorithm picked gets
+// the total votes divided by the numbers per prediction.
+func voteShare(voteCount map[int]float64, predictions []*entity.Prediction) func(num int) float64 {
+	total, picks := 0.0, 0
+	for _, votes := range voteCount {
+		total += votes
+	}
+	for _, pred := range predictions {
+		picks += len(pred.Numbers)
 	}
 
-	sort.Ints(result)
-	return valueobject.NewNumbers(result)
+	return func(num int) float64 {
+		if total <= 0 || picks == 0 {
+			return 0
+		}
+		full := total * float64(len(predictions)) / float64(picks)
+		return math.Min(voteCount[num]/full, 1)
+	}
 }
 
 // fillRemainingFromPredictions fills remaining slots from predictions
@@ -243,31 +792,15 @@ func (e *Ensemble) fillRemainingFromPredictions(
 	current []int,
 	predictions []*entity.Prediction,
 ) []int {
-	used := make(map[int]bool)
-	for _, num := range current {
-		if num != 0 {
-			used[num] = true
-		}
-	}
-
-	result := make([]int, 0, 6)
-	for _, num := range current {
-		if num != 0 {
-			result = append(result, num)
-		}
-	}
+	result := append([]int(nil), current...)
 
-	// Fill remaining slots from predictions
+	// Fill remaining slots from predictions, lowest unused numbers first
 	for _, pred := range predictions {
-		for _, num := range pred.Numbers {
-			if !used[num] && len(result) < 6 {
-				result = append(result, num)
-				used[num] = true
-			}
-		}
 		if len(result) >= 6 {
 			break
 		}
+		unused := valueobject.DifferenceInts(pred.Numbers, result)
+		result = append(result, unused[:min(len(unused), 6-len(result))]...)
 	}
 
 	return result
@@ -9,14 +9,31 @@ import (
 
 	"github.com/tool_predict/internal/domain/entity"
 	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/pkg/analytics"
 )
 
 // FrequencyAnalyzer analyzes number frequency in historical draws
 type FrequencyAnalyzer struct {
-	name     string
-	weight   float64
-	minDraws int
-	mu       sync.RWMutex
+	name          string
+	weight        float64
+	minDraws      int
+	winnersFilter *winnersRange // Optional; only draws in range are analyzed
+	halfLife      float64       // Recency half-life in draws; 0 counts every draw equally
+	rangeChanges  map[valueobject.GameType][]analytics.RangeChange
+	mu            sync.RWMutex
+}
+
+// winnersRange bounds the jackpot winner count of analyzed draws
+type winnersRange struct {
+	min int
+	max int // analytics.NoMaxWinners for no upper bound
+}
+
+func (r winnersRange) String() string {
+	if r.max == analytics.NoMaxWinners {
+		return fmt.Sprintf("%d+", r.min)
+	}
+	return fmt.Sprintf("%d-%d", r.min, r.max)
 }
 
 // NewFrequencyAnalyzer creates a new frequency analyzer
@@ -51,9 +68,86 @@ func (fa *FrequencyAnalyzer) SetWeight(weight float64) error {
 	return nil
 }
 
-// Validate checks if there's enough data for prediction
+// SetWinnersFilter restricts analysis to draws whose jackpot winner count is
+// within [minWinners, maxWinners], e.g. (1, analytics.NoMaxWinners) for
+// jackpot-winning draws or (0, 0) for rollovers. It relies on Draw.Winners
+// being populated.
+func (fa *FrequencyAnalyzer) SetWinnersFilter(minWinners, maxWinners int) error {
+	if minWinners < 0 {
+		return fmt.Errorf("minimum winners cannot be negative, got %d", minWinners)
+	}
+	if maxWinners != analytics.NoMaxWinners && maxWinners < minWinners {
+		return fmt.Errorf("maximum winners %d is below minimum %d", maxWinners, minWinners)
+	}
+	fa.mu.Lock()
+	defer fa.mu.Unlock()
+	fa.winnersFilter = &winnersRange{min: minWinners, max: maxWinners}
+	return nil
+}
+
+// SetRecencyHalfLife makes a draw halfLife draws old count half as much as
+// the latest draw. Zero counts every draw equally.
+func (fa *FrequencyAnalyzer) SetRecencyHalfLife(halfLife float64) error {
+	if halfLife < 0 {
+		return fmt.Errorf("recency half-life cannot be negative, got %f", halfLife)
+	}
+	fa.mu.Lock()
+	defer fa.mu.Unlock()
+	fa.halfLife = halfLife
+	return nil
+}
+
+// SetRangeChanges normalizes the frequency of gameType by the pool size in
+// effect at each draw's date: draws from a smaller pool count less, and
+// numbers missing from it are scored only over the draws they could appear
+// in. No changes, the case for both current games, leaves scores unchanged.
+func (fa *FrequencyAnalyzer) SetRangeChanges(gameType valueobject.GameType, changes []analytics.RangeChange) error {
+	minRange, maxRange := gameType.NumberRange()
+	if err := analytics.ValidateRangeChanges(changes, maxRange-minRange+1); err != nil {
+		return fmt.Errorf("invalid range changes for %s: %w", gameType, err)
+	}
+	fa.mu.Lock()
+	defer fa.mu.Unlock()
+	if fa.rangeChanges == nil {
+		fa.rangeChanges = make(map[valueobject.GameType][]analytics.RangeChange)
+	}
+	fa.rangeChanges[gameType] = append([]analytics.RangeChange(nil), changes...)
+	return nil
+}
+
+// ClearWinnersFilter analyzes all draws again
+func (fa *FrequencyAnalyzer) ClearWinnersFilter() {
+	fa.mu.Lock()
+	defer fa.mu.Unlock()
+	fa.winnersFilter = nil
+}
+
+// filterHistory applies the winners filter, if any
+func (fa *FrequencyAnalyzer) filterHistory(historicalData []*entity.Draw) []*entity.Draw {
+	fa.mu.RLock()
+	filter := fa.winnersFilter
+	fa.mu.RUnlock()
+
+	if filter == nil {
+		return historicalData
+	}
+	return analytics.FilterByWinners(historicalData, filter.min, filter.max)
+}
+
+// PredictsConcurrently reports that predictions only depend on their history
+func (fa *FrequencyAnalyzer) PredictsConcurrently() bool {
+	return true
+}
+
+// Validate checks if there's enough data for prediction, after applying
+// the winners filter
 func (fa *FrequencyAnalyzer) Validate(historicalData []*entity.Draw) error {
-	if len(historicalData) < fa.minDraws {
+	filtered := fa.filterHistory(historicalData)
+	if len(filtered) < fa.minDraws {
+		if len(filtered) < len(historicalData) {
+			return fmt.Errorf("need at least %d draws for frequency analysis, got %d of %d after winners filter",
+				fa.minDraws, len(filtered), len(historicalData))
+		}
 		return fmt.Errorf("need at least %d draws for frequency analysis, got %d",
 			fa.minDraws, len(historicalData))
 	}
@@ -84,27 +178,138 @@ func (fa *FrequencyAnalyzer) Predict(
 	default:
 	}
 
+	allDraws := len(historicalData)
+	historicalData = fa.filterHistory(historicalData)
+
+	ranked, frequency, expectedFreq := fa.rankByFrequency(gameType, historicalData)
+
+	// Take top 6 most frequent numbers
+	predictedNums := make([]int, 6)
+	copy(predictedNums, ranked[:6])
+
+	// Create numbers value object
+	numbers, err := valueobject.NewNumbers(predictedNums)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create numbers: %w", err)
+	}
+
+	// Calculate confidence based on frequency consistency
+	confidence := fa.calculateConfidence(frequency, numbers, expectedFreq)
+
+	// Create prediction
+	prediction := &entity.Prediction{
+		ID:            "", // Will be set by repository
+		GameType:      gameType,
+		AlgorithmName: fa.name,
+		Numbers:       numbers,
+		Confidence:    confidence,
+		GeneratedAt:   time.Now(),
+		ForDate:       time.Now().Add(24 * time.Hour), // Predict for tomorrow
+		Metadata: map[string]string{
+			"min_draws_required": fmt.Sprintf("%d", fa.minDraws),
+			"total_draws_used":   fmt.Sprintf("%d", len(historicalData)),
+			"expected_freq":      fmt.Sprintf("%.4f", expectedFreq),
+		},
+	}
+
+	fa.mu.RLock()
+	if fa.winnersFilter != nil {
+		prediction.Metadata["winners_filter"] = fa.winnersFilter.String()
+		prediction.Metadata["draws_filtered_out"] = fmt.Sprintf("%d", allDraws-len(historicalData))
+	}
+	if fa.halfLife > 0 {
+		prediction.Metadata["recency_half_life"] = fmt.Sprintf("%g", fa.halfLife)
+	}
+	if changes := fa.rangeChanges[gameType]; len(changes) > 0 {
+		prediction.Metadata["range_changes"] = fmt.Sprintf("%d", len(changes))
+	}
+	fa.mu.RUnlock()
+
+	return prediction, nil
+}
+
+// RankNumbers returns every number in the pool ordered by frequency score
+func (fa *FrequencyAnalyzer) RankNumbers(
+	gameType valueobject.GameType,
+	historicalData []*entity.Draw,
+) ([]int, error) {
+	if err := fa.Validate(historicalData); err != nil {
+		return nil, err
+	}
+	ranked, _, _ := fa.rankByFrequency(gameType, fa.filterHistory(historicalData))
+	return ranked, nil
+}
+
+// rankByFrequency orders the number pool by how much each number's frequency
+// exceeds the expected frequency, lowest number first on ties. With a
+// recency half-life, frequencies are decay-weighted counts rescaled to the
+// number of draws, so scores stay comparable to unweighted ones. With range
+// changes, a draw from a pool of P numbers counts P/poolSize, and a number's
+// frequency is divided by the share of draw weight whose pool included it.
+func (fa *FrequencyAnalyzer) rankByFrequency(
+	gameType valueobject.GameType,
+	historicalData []*entity.Draw,
+) ([]int, map[int]float64, float64) {
 	// Get number range for game type
 	minRange, maxRange := gameType.NumberRange()
 
+	fa.mu.RLock()
+	halfLife := fa.halfLife
+	changes := fa.rangeChanges[gameType]
+	fa.mu.RUnlock()
+	weights := analytics.RecencyWeights(historicalData, halfLife)
+
+	totalWeight := 0.0
+	for _, weight := range weights {
+		totalWeight += weight
+	}
+	scale := 1.0
+	if totalWeight > 0 {
+		scale = float64(len(historicalData)) / totalWeight
+	}
+
 	// Count frequency of each number
-	frequency := make(map[int]int)
+	frequency := make(map[int]float64)
 	totalNumbers := 0
 
-	for _, draw := range historicalData {
+	poolSize := maxRange - minRange + 1
+	var pools []int
+	if len(changes) > 0 {
+		pools = analytics.PoolSizes(historicalData, changes, poolSize)
+	}
+
+	for i, draw := range historicalData {
+		contribution := weights[i] * scale
+		if pools != nil {
+			contribution *= float64(pools[i]) / float64(poolSize)
+		}
 		for _, num := range draw.Numbers {
-			frequency[num]++
+			frequency[num] += contribution
 			totalNumbers++
 		}
 	}
 
+	if pools != nil && totalWeight > 0 {
+		for num := minRange; num <= maxRange; num++ {
+			exposure := 0.0
+			for i := range historicalData {
+				if num < minRange+pools[i] {
+					exposure += weights[i]
+				}
+			}
+			if exposure > 0 {
+				frequency[num] *= totalWeight / exposure
+			}
+		}
+	}
+
 	// Calculate expected frequency and variance
 	expectedFreq := float64(totalNumbers) / float64((maxRange-minRange+1)*len(historicalData))
 
 	// Create number-frequency pairs
 	type numFreq struct {
 		num   int
-		count int
+		count float64
 		score float64
 	}
 
@@ -112,7 +317,7 @@ func (fa *FrequencyAnalyzer) Predict(
 	for i := minRange; i <= maxRange; i++ {
 		count := frequency[i]
 		// Score is based on how much the frequency exceeds expected
-		score := float64(count) / expectedFreq
+		score := count / expectedFreq
 		pairs = append(pairs, numFreq{
 			num:   i,
 			count: count,
@@ -121,57 +326,30 @@ func (fa *FrequencyAnalyzer) Predict(
 	}
 
 	// Sort by frequency score (descending), then by frequency
-	sort.Slice(pairs, func(i, j int) bool {
+	sort.SliceStable(pairs, func(i, j int) bool {
 		if pairs[i].score != pairs[j].score {
 			return pairs[i].score > pairs[j].score
 		}
 		return pairs[i].count > pairs[j].count
 	})
 
-	// Take top 6 most frequent numbers
-	predictedNums := make([]int, 6)
-	for i := 0; i < 6; i++ {
-		predictedNums[i] = pairs[i].num
-	}
-
-	// Create numbers value object
-	numbers, err := valueobject.NewNumbers(predictedNums)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create numbers: %w", err)
+	ranked := make([]int, len(pairs))
+	for i, p := range pairs {
+		ranked[i] = p.num
 	}
-
-	// Calculate confidence based on frequency consistency
-	confidence := fa.calculateConfidence(frequency, numbers, expectedFreq)
-
-	// Create prediction
-	prediction := &entity.Prediction{
-		ID:            "", // Will be set by repository
-		GameType:      gameType,
-		AlgorithmName: fa.name,
-		Numbers:       numbers,
-		Confidence:    confidence,
-		GeneratedAt:   time.Now(),
-		ForDate:       time.Now().Add(24 * time.Hour), // Predict for tomorrow
-		Metadata: map[string]string{
-			"min_draws_required": fmt.Sprintf("%d", fa.minDraws),
-			"total_draws_used":   fmt.Sprintf("%d", len(historicalData)),
-			"expected_freq":      fmt.Sprintf("%.4f", expectedFreq),
-		},
-	}
-
-	return prediction, nil
+	return ranked, frequency, expectedFreq
 }
 
 // calculateConfidence calculates prediction confidence
 func (fa *FrequencyAnalyzer) calculateConfidence(
-	frequency map[int]int,
+	frequency map[int]float64,
 	numbers valueobject.Numbers,
 	expectedFreq float64,
 ) float64 {
 	// Calculate average relative frequency of selected numbers
 	totalScore := 0.0
 	for _, num := range numbers {
-		score := float64(frequency[num]) / expectedFreq
+		score := frequency[num] / expectedFreq
 		totalScore += score
 	}
 	avgScore := totalScore / 6.0
@@ -205,3 +383,9 @@ func (fa *FrequencyAnalyzer) GetMinDraws() int {
 	defer fa.mu.RUnlock()
 	return fa.minDraws
 }
+
+// Ensure FrequencyAnalyzer supports recency weighting and pool normalization
+var (
+	_ RecencyWeighted = (*FrequencyAnalyzer)(nil)
+	_ PoolNormalized  = (*FrequencyAnalyzer)(nil)
+)
@@ -0,0 +1,231 @@
+package algorithm
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// GapAnalyzer picks the numbers most overdue relative to their own history.
+// A number's gap is the draws between two of its appearances; its score is
+// the current gap (draws since it last came up) divided by its mean gap, so
+// a number drawn every 5 draws that has been out for 15 outranks one drawn
+// every 10 that has been out for 20. Unlike the hot/cold analyzer's fixed
+// cold threshold, each number is measured against its own rhythm.
+type GapAnalyzer struct {
+	name     string
+	weight   float64
+	minDraws int
+	mu       sync.RWMutex
+}
+
+// NumberGap is how overdue a number is against its historical gaps
+type NumberGap struct {
+	Number     int
+	MeanGap    float64 // Mean draws between appearances; the pool's expected gap with fewer than two
+	CurrentGap int     // Draws since the number last came up; all draws if it never did
+}
+
+// Ratio is the current gap over the mean gap; above 1 means overdue
+func (g NumberGap) Ratio() float64 {
+	return float64(g.CurrentGap) / g.MeanGap
+}
+
+// NewGapAnalyzer creates a new gap analyzer
+func NewGapAnalyzer(weight float64) *GapAnalyzer {
+	return &GapAnalyzer{
+		name:     "gap_analysis",
+		weight:   weight,
+		minDraws: 30,
+	}
+}
+
+// Name returns the algorithm name
+func (ga *GapAnalyzer) Name() string {
+	return ga.name
+}
+
+// GetWeight returns the algorithm's weight
+func (ga *GapAnalyzer) GetWeight() float64 {
+	ga.mu.RLock()
+	defer ga.mu.RUnlock()
+	return ga.weight
+}
+
+// SetWeight sets the algorithm's weight
+func (ga *GapAnalyzer) SetWeight(weight float64) error {
+	if weight < 0 {
+		return fmt.Errorf("weight cannot be negative, got %f", weight)
+	}
+	ga.mu.Lock()
+	defer ga.mu.Unlock()
+	ga.weight = weight
+	return nil
+}
+
+// SetMinDraws sets the minimum number of draws required
+func (ga *GapAnalyzer) SetMinDraws(minDraws int) error {
+	if minDraws < 1 {
+		return fmt.Errorf("minimum draws must be at least 1, got %d", minDraws)
+	}
+	ga.mu.Lock()
+	defer ga.mu.Unlock()
+	ga.minDraws = minDraws
+	return nil
+}
+
+// GetMinDraws returns the minimum number of draws required
+func (ga *GapAnalyzer) GetMinDraws() int {
+	ga.mu.RLock()
+	defer ga.mu.RUnlock()
+	return ga.minDraws
+}
+
+// PredictsConcurrently reports that predictions only depend on their history
+func (ga *GapAnalyzer) PredictsConcurrently() bool {
+	return true
+}
+
+// Validate checks if there's enough data for prediction
+func (ga *GapAnalyzer) Validate(historicalData []*entity.Draw) error {
+	if len(historicalData) < ga.minDraws {
+		return fmt.Errorf("need at least %d draws for gap analysis, got %d",
+			ga.minDraws, len(historicalData))
+	}
+	return nil
+}
+
+// Train updates algorithm parameters (gap analysis doesn't need training)
+func (ga *GapAnalyzer) Train(ctx context.Context, historicalData []*entity.Draw) error {
+	return nil
+}
+
+// Predict picks the numbers whose current gap most exceeds their mean gap
+func (ga *GapAnalyzer) Predict(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	historicalData []*entity.Draw,
+) (*entity.Prediction, error) {
+	if err := ga.Validate(historicalData); err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	ranked := rankByGapRatio(NumberGaps(gameType, historicalData))
+	picks := ranked[:gameType.NumberCount()]
+
+	predictedNums := make([]int, len(picks))
+	ratios := make([]string, len(picks))
+	overdue := 0
+	confidence := 0.0
+	for i, gap := range picks {
+		predictedNums[i] = gap.Number
+		ratios[i] = fmt.Sprintf("%d:%.2f", gap.Number, gap.Ratio())
+		// 0 at the mean gap, approaching 1 as the number grows more overdue
+		if ratio := gap.Ratio(); ratio > 1 {
+			overdue++
+			confidence += 1 - 1/ratio
+		}
+	}
+	confidence /= float64(len(picks))
+	sort.Ints(predictedNums)
+
+	numbers, err := valueobject.NewNumbers(predictedNums)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create numbers: %w", err)
+	}
+
+	return &entity.Prediction{
+		ID:            "",
+		GameType:      gameType,
+		AlgorithmName: ga.name,
+		Numbers:       numbers,
+		Confidence:    confidence,
+		GeneratedAt:   time.Now(),
+		ForDate:       time.Now().Add(24 * time.Hour),
+		Metadata: map[string]string{
+			"gap_ratios":       strings.Join(ratios, ","),
+			"overdue_picks":    fmt.Sprintf("%d", overdue),
+			"total_draws_used": fmt.Sprintf("%d", len(historicalData)),
+		},
+	}, nil
+}
+
+// RankNumbers returns every number in the pool ordered by gap ratio
+func (ga *GapAnalyzer) RankNumbers(
+	gameType valueobject.GameType,
+	historicalData []*entity.Draw,
+) ([]int, error) {
+	if err := ga.Validate(historicalData); err != nil {
+		return nil, err
+	}
+	ranked := rankByGapRatio(NumberGaps(gameType, historicalData))
+	numbers := make([]int, len(ranked))
+	for i, gap := range ranked {
+		numbers[i] = gap.Number
+	}
+	return numbers, nil
+}
+
+// NumberGaps measures every number in the game's pool against its gaps in
+// draws. Numbers seen fewer than twice have no gap of their own and are
+// given the pool's expected gap, the pool size over the numbers per draw.
+func NumberGaps(gameType valueobject.GameType, draws []*entity.Draw) []NumberGap {
+	minRange, maxRange := gameType.NumberRange()
+	expectedGap := float64(maxRange-minRange+1) / float64(gameType.NumberCount())
+
+	oldestFirst := make([]*entity.Draw, len(draws))
+	copy(oldestFirst, draws)
+	sort.SliceStable(oldestFirst, func(i, j int) bool {
+		return oldestFirst[i].DrawDate.Before(oldestFirst[j].DrawDate)
+	})
+
+	appearances := make(map[int][]int)
+	for i, draw := range oldestFirst {
+		for _, num := range draw.Numbers {
+			appearances[num] = append(appearances[num], i)
+		}
+	}
+
+	gaps := make([]NumberGap, 0, maxRange-minRange+1)
+	for num := minRange; num <= maxRange; num++ {
+		seen := appearances[num]
+		gap := NumberGap{Number: num, MeanGap: expectedGap, CurrentGap: len(oldestFirst)}
+		if len(seen) > 0 {
+			gap.CurrentGap = len(oldestFirst) - 1 - seen[len(seen)-1]
+		}
+		if len(seen) > 1 {
+			gap.MeanGap = float64(seen[len(seen)-1]-seen[0]) / float64(len(seen)-1)
+		}
+		gaps = append(gaps, gap)
+	}
+	return gaps
+}
+
+// rankByGapRatio orders gaps by ratio, highest first, lowest number first
+// on ties
+func rankByGapRatio(gaps []NumberGap) []NumberGap {
+	ranked := make([]NumberGap, len(gaps))
+	copy(ranked, gaps)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Ratio() > ranked[j].Ratio()
+	})
+	return ranked
+}
+
+// Ensure GapAnalyzer implements Algorithm and Ranker
+var (
+	_ Algorithm = (*GapAnalyzer)(nil)
+	_ Ranker    = (*GapAnalyzer)(nil)
+)
@@ -0,0 +1,90 @@
+package algorithm
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// gapHistory builds 40 Mega 6/45 draws, oldest first. 1 is drawn every
+// other draw up to draw 30 (mean gap 2, out for 9), 2 every tenth draw up
+// to draw 30 (mean gap 10, out for 9) and 3 only in the latest draw; 40-45
+// fill the rest of each draw. 4-39 are never drawn.
+func gapHistory(t *testing.T) []*entity.Draw {
+	t.Helper()
+
+	start := time.Date(2026, 1, 1, 18, 0, 0, 0, time.UTC)
+	draws := make([]*entity.Draw, 0, 40)
+	for i := 0; i < 40; i++ {
+		var nums []int
+		if i%2 == 0 && i <= 30 {
+			nums = append(nums, 1)
+		}
+		if i%10 == 0 && i <= 30 {
+			nums = append(nums, 2)
+		}
+		if i == 39 {
+			nums = append(nums, 3)
+		}
+		for filler := 40; len(nums) < 6; filler++ {
+			nums = append(nums, filler)
+		}
+		draw, err := entity.NewDraw(valueobject.Mega645, i+1, valueobject.MustNewNumbers(nums),
+			start.AddDate(0, 0, 2*i), 0, 0)
+		require.NoError(t, err)
+		draws = append(draws, draw)
+	}
+	return draws
+}
+
+func TestNumberGaps(t *testing.T) {
+	history := gapHistory(t)
+	// Order of the input doesn't matter
+	history[0], history[39] = history[39], history[0]
+
+	gaps := NumberGaps(valueobject.Mega645, history)
+	require.Len(t, gaps, 45)
+
+	assert.Equal(t, NumberGap{Number: 1, MeanGap: 2, CurrentGap: 9}, gaps[0])
+	assert.Equal(t, NumberGap{Number: 2, MeanGap: 10, CurrentGap: 9}, gaps[1])
+	// Seen once or never: the pool's expected gap, 45 / 6
+	assert.Equal(t, NumberGap{Number: 3, MeanGap: 7.5, CurrentGap: 0}, gaps[2])
+	assert.Equal(t, NumberGap{Number: 4, MeanGap: 7.5, CurrentGap: 40}, gaps[3])
+
+	assert.InDelta(t, 4.5, gaps[0].Ratio(), 1e-9)
+	assert.InDelta(t, 0.9, gaps[1].Ratio(), 1e-9)
+}
+
+func TestGapAnalyzer_PicksMostOverdue(t *testing.T) {
+	analyzer := NewGapAnalyzer(1.0)
+
+	prediction, err := analyzer.Predict(context.Background(), valueobject.Mega645, gapHistory(t))
+	require.NoError(t, err)
+
+	// Never drawn in 40 draws beats 1's 9 draws out; ties go to the lower numbers
+	assert.Equal(t, []int{4, 5, 6, 7, 8, 9}, prediction.Numbers.AsSlice())
+	assert.Equal(t, "gap_analysis", prediction.AlgorithmName)
+	assert.InDelta(t, 1-7.5/40, prediction.Confidence, 1e-9)
+	assert.Equal(t, "6", prediction.Metadata["overdue_picks"])
+
+	ranked, err := analyzer.RankNumbers(valueobject.Mega645, gapHistory(t))
+	require.NoError(t, err)
+	assert.Len(t, ranked, 45)
+	// 1 is out for 4.5 mean gaps, 2 for less than one
+	assert.Equal(t, 1, ranked[36])
+	assert.Less(t, slices.Index(ranked, 1), slices.Index(ranked, 2))
+}
+
+func TestGapAnalyzer_Validation(t *testing.T) {
+	analyzer := NewGapAnalyzer(1.0)
+
+	assert.Error(t, analyzer.SetWeight(-1))
+	assert.Error(t, analyzer.Validate(gapHistory(t)[:29]))
+	assert.NoError(t, analyzer.Validate(gapHistory(t)[:30]))
+}
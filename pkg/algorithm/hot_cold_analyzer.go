@@ -9,6 +9,7 @@ import (
 
 	"github.com/tool_predict/internal/domain/entity"
 	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/pkg/analytics"
 )
 
 // HotColdAnalyzer identifies hot (recently drawn) and cold (overdue) numbers
@@ -16,8 +17,9 @@ type HotColdAnalyzer struct {
 	name          string
 	weight        float64
 	minDraws      int
-	hotThreshold  int // Number of recent draws to consider for "hot" numbers
-	coldThreshold int // Number of draws since last appearance for "cold" numbers
+	hotThreshold  int     // Number of recent draws to consider for "hot" numbers
+	coldThreshold int     // Number of draws since last appearance for "cold" numbers
+	halfLife      float64 // Recency half-life for hot counts in draws; 0 counts every draw equally
 	mu            sync.RWMutex
 }
 
@@ -55,6 +57,11 @@ func (hca *HotColdAnalyzer) SetWeight(weight float64) error {
 	return nil
 }
 
+// PredictsConcurrently reports that predictions only depend on their history
+func (hca *HotColdAnalyzer) PredictsConcurrently() bool {
+	return true
+}
+
 // Validate checks if there's enough data for prediction
 func (hca *HotColdAnalyzer) Validate(historicalData []*entity.Draw) error {
 	if len(historicalData) < hca.minDraws {
@@ -88,13 +95,14 @@ func (hca *HotColdAnalyzer) Predict(
 	hca.mu.RLock()
 	hotThreshold := hca.hotThreshold
 	coldThreshold := hca.coldThreshold
+	halfLife := hca.halfLife
 	hca.mu.RUnlock()
 
 	// Reverse to get most recent first
 	recentDraws := reverseDraws(historicalData)
 
 	// Find hot numbers (frequently drawn in recent draws)
-	hotNumbers := hca.findHotNumbers(recentDraws, hotThreshold, gameType)
+	hotNumbers := hca.findHotNumbers(recentDraws, hotThreshold, halfLife, gameType)
 
 	// Find cold numbers (haven't been drawn recently)
 	coldNumbers := hca.findColdNumbers(recentDraws, coldThreshold, gameType)
@@ -127,35 +135,42 @@ func (hca *HotColdAnalyzer) Predict(
 			"cold_numbers":   fmt.Sprintf("%v", coldNumbers),
 		},
 	}
+	if halfLife > 0 {
+		prediction.Metadata["recency_half_life"] = fmt.Sprintf("%g", halfLife)
+	}
 
 	return prediction, nil
 }
 
-// findHotNumbers identifies numbers that have appeared frequently in recent draws
+// findHotNumbers identifies numbers that have appeared frequently in recent
+// draws, most recent first. With a positive halfLife, older draws in the
+// window count for less.
 func (hca *HotColdAnalyzer) findHotNumbers(
 	draws []*entity.Draw,
 	limit int,
+	halfLife float64,
 	gameType valueobject.GameType,
 ) []int {
 	minRange, maxRange := gameType.NumberRange()
 
 	// Count frequency in recent draws
-	frequency := make(map[int]int)
+	frequency := make(map[int]float64)
 	drawsToCheck := limit
 	if drawsToCheck > len(draws) {
 		drawsToCheck = len(draws)
 	}
 
+	weights := analytics.DecayWeights(drawsToCheck, halfLife)
 	for i := 0; i < drawsToCheck; i++ {
 		for _, num := range draws[i].Numbers {
-			frequency[num]++
+			frequency[num] += weights[i]
 		}
 	}
 
 	// Sort by frequency
 	type numFreq struct {
 		num   int
-		count int
+		count float64
 	}
 
 	sorted := make([]numFreq, 0)
@@ -271,6 +286,18 @@ func reverseDraws(draws []*entity.Draw) []*entity.Draw {
 	return reversed
 }
 
+// SetRecencyHalfLife makes a draw halfLife draws old count half as much as
+// the latest draw when finding hot numbers. Zero counts every draw equally.
+func (hca *HotColdAnalyzer) SetRecencyHalfLife(halfLife float64) error {
+	if halfLife < 0 {
+		return fmt.Errorf("recency half-life cannot be negative, got %f", halfLife)
+	}
+	hca.mu.Lock()
+	defer hca.mu.Unlock()
+	hca.halfLife = halfLife
+	return nil
+}
+
 // SetHotThreshold sets the threshold for hot number detection
 func (hca *HotColdAnalyzer) SetHotThreshold(threshold int) error {
 	if threshold < 5 {
@@ -306,3 +333,62 @@ func (hca *HotColdAnalyzer) GetColdThreshold() int {
 	defer hca.mu.RUnlock()
 	return hca.coldThreshold
 }
+
+// SetMinDraws sets the minimum number of draws required
+func (hca *HotColdAnalyzer) SetMinDraws(minDraws int) error {
+	if minDraws < 1 {
+		return fmt.Errorf("minimum draws must be at least 1, got %d", minDraws)
+	}
+	hca.mu.Lock()
+	defer hca.mu.Unlock()
+	hca.minDraws = minDraws
+	return nil
+}
+
+// GetMinDraws returns the minimum number of draws required
+func (hca *HotColdAnalyzer) GetMinDraws() int {
+	hca.mu.RLock()
+	defer hca.mu.RUnlock()
+	return hca.minDraws
+}
+
+// RankNumbers returns every number in the pool ordered by how hot it is,
+// i.e. its frequency within the hot threshold window of recent draws
+func (hca *HotColdAnalyzer) RankNumbers(
+	gameType valueobject.GameType,
+	historicalData []*entity.Draw,
+) ([]int, error) {
+	if err := hca.Validate(historicalData); err != nil {
+		return nil, err
+	}
+
+	hca.mu.RLock()
+	hotThreshold := hca.hotThreshold
+	hca.mu.RUnlock()
+
+	recentDraws := reverseDraws(historicalData)
+	if hotThreshold > len(recentDraws) {
+		hotThreshold = len(recentDraws)
+	}
+
+	frequency := make(map[int]int)
+	for _, draw := range recentDraws[:hotThreshold] {
+		for _, num := range draw.Numbers {
+			frequency[num]++
+		}
+	}
+
+	minRange, maxRange := gameType.NumberRange()
+	ranked := make([]int, 0, maxRange-minRange+1)
+	for num := minRange; num <= maxRange; num++ {
+		ranked = append(ranked, num)
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return frequency[ranked[i]] > frequency[ranked[j]]
+	})
+
+	return ranked, nil
+}
+
+// Ensure HotColdAnalyzer supports recency weighting
+var _ RecencyWeighted = (*HotColdAnalyzer)(nil)
@@ -5,6 +5,7 @@ import (
 
 	"github.com/tool_predict/internal/domain/entity"
 	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/pkg/analytics"
 )
 
 // Algorithm defines the interface for prediction algorithms
@@ -25,9 +26,52 @@ type Algorithm interface {
 	// Validate checks if algorithm can make predictions with the given data
 	Validate(historicalData []*entity.Draw) error
 
+	// GetMinDraws returns the minimum number of historical draws required
+	GetMinDraws() int
+
 	// GetWeight returns the algorithm's weight for ensemble voting
 	GetWeight() float64
 
 	// SetWeight sets the algorithm's weight for ensemble voting
 	SetWeight(weight float64) error
 }
+
+// Ranker is implemented by algorithms that can order the whole number pool,
+// not just the six numbers they pick
+type Ranker interface {
+	// RankNumbers returns every number in the game's range, most favoured first
+	RankNumbers(
+		gameType valueobject.GameType,
+		historicalData []*entity.Draw,
+	) ([]int, error)
+}
+
+// RecencyWeighted is implemented by frequency-based algorithms that can weigh
+// recent draws more heavily using analytics.DecayWeights
+type RecencyWeighted interface {
+	// SetRecencyHalfLife sets how many draws back a draw counts half as
+	// much as the latest one. Zero disables decay.
+	SetRecencyHalfLife(halfLife float64) error
+}
+
+// PoolNormalized is implemented by algorithms that can correct for draws made
+// from a smaller number pool before a game expanded its range
+type PoolNormalized interface {
+	// SetRangeChanges sets the pool size changes of a game type
+	SetRangeChanges(gameType valueobject.GameType, changes []analytics.RangeChange) error
+}
+
+// MinDrawsConfigurable is implemented by algorithms whose minimum history
+// can be changed from the default
+type MinDrawsConfigurable interface {
+	// SetMinDraws sets how many draws the algorithm needs before predicting
+	SetMinDraws(minDraws int) error
+}
+
+// ConcurrentPredictor is implemented by algorithms whose predictions depend
+// only on the history they are given, so one instance can predict several
+// histories at once
+type ConcurrentPredictor interface {
+	// PredictsConcurrently reports whether Predict calls may overlap
+	PredictsConcurrently() bool
+}
@@ -0,0 +1,54 @@
+package algorithm
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// KenoPick is a Keno ticket picked from the numbers drawn most often
+type KenoPick struct {
+	Numbers valueobject.KenoNumbers
+	Hits    map[int]int // Times each picked number was drawn
+	Draws   int         // Draws the hits were counted over
+}
+
+// PredictKeno picks the spots numbers drawn most often in draws, breaking
+// ties with the lower number. Keno draws 20 of 80 numbers, so each number is
+// expected in a quarter of the draws; like the other analyzers this only
+// reflects past frequency.
+func PredictKeno(draws []*entity.KenoDraw, spots int) (*KenoPick, error) {
+	if spots < 1 || spots > valueobject.KenoMaxSpots {
+		return nil, fmt.Errorf("spots must be between 1 and %d, got %d", valueobject.KenoMaxSpots, spots)
+	}
+	if len(draws) == 0 {
+		return nil, fmt.Errorf("no keno draws to predict from")
+	}
+
+	hits := make(map[int]int, valueobject.KenoMaxNumber)
+	for _, draw := range draws {
+		for _, num := range draw.Numbers {
+			hits[num]++
+		}
+	}
+
+	ranked := make([]int, 0, valueobject.KenoMaxNumber)
+	for num := valueobject.KenoMinNumber; num <= valueobject.KenoMaxNumber; num++ {
+		ranked = append(ranked, num)
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return hits[ranked[i]] > hits[ranked[j]]
+	})
+
+	numbers, err := valueobject.NewKenoTicket(ranked[:spots])
+	if err != nil {
+		return nil, err
+	}
+	pick := &KenoPick{Numbers: numbers, Hits: make(map[int]int, spots), Draws: len(draws)}
+	for _, num := range numbers {
+		pick.Hits[num] = hits[num]
+	}
+	return pick, nil
+}
@@ -0,0 +1,54 @@
+package algorithm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func newKenoDraw(t *testing.T, drawNumber int, nums []int) *entity.KenoDraw {
+	t.Helper()
+	numbers, err := valueobject.NewKenoNumbers(nums)
+	require.NoError(t, err)
+	draw, err := entity.NewKenoDraw(drawNumber, numbers, time.Date(2026, 3, 14, 9, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	return draw
+}
+
+func TestPredictKeno(t *testing.T) {
+	seq := func(first int) []int {
+		nums := make([]int, valueobject.KenoDrawSize)
+		for i := range nums {
+			nums[i] = first + i
+		}
+		return nums
+	}
+	// 1-20 twice, 11-30 once: 11-20 were drawn three times, 1-10 twice
+	draws := []*entity.KenoDraw{
+		newKenoDraw(t, 1, seq(1)),
+		newKenoDraw(t, 2, seq(1)),
+		newKenoDraw(t, 3, seq(11)),
+	}
+
+	pick, err := PredictKeno(draws, 10)
+	require.NoError(t, err)
+	assert.Equal(t, valueobject.KenoNumbers{11, 12, 13, 14, 15, 16, 17, 18, 19, 20}, pick.Numbers)
+	assert.Equal(t, 3, pick.Hits[11])
+	assert.Equal(t, 3, pick.Draws)
+
+	// Equal counts favour the lower numbers
+	pick, err = PredictKeno(draws[:1], 3)
+	require.NoError(t, err)
+	assert.Equal(t, valueobject.KenoNumbers{1, 2, 3}, pick.Numbers)
+
+	_, err = PredictKeno(draws, 0)
+	assert.Error(t, err)
+	_, err = PredictKeno(draws, valueobject.KenoMaxSpots+1)
+	assert.Error(t, err)
+	_, err = PredictKeno(nil, 5)
+	assert.Error(t, err)
+}
@@ -0,0 +1,294 @@
+package algorithm
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+const (
+	// DefaultMonteCarloSimulations is the number of draws simulated per prediction
+	DefaultMonteCarloSimulations = 10000
+	// MaxMonteCarloSimulations bounds the simulations a prediction may run
+	MaxMonteCarloSimulations = 1000000
+)
+
+// MonteCarloAnalyzer simulates draws in which each number is drawn with a
+// probability proportional to how often it was drawn in the history, and
+// plays the numbers that came up in the most simulated draws. Whole sets
+// aren't compared: with millions of possible sets, nearly every simulated
+// set of a real history comes up once, and any twice only by chance. Every
+// number's count is smoothed by one so numbers never drawn can still come
+// up. With a seed the same history always yields the same set.
+type MonteCarloAnalyzer struct {
+	name        string
+	weight      float64
+	minDraws    int
+	simulations int
+	seed        *uint64 // nil seeds each prediction randomly
+	mu          sync.RWMutex
+}
+
+// NewMonteCarloAnalyzer creates a Monte Carlo analyzer running
+// DefaultMonteCarloSimulations unseeded simulations
+func NewMonteCarloAnalyzer(weight float64) *MonteCarloAnalyzer {
+	return &MonteCarloAnalyzer{
+		name:        "monte_carlo",
+		weight:      weight,
+		minDraws:    10,
+		simulations: DefaultMonteCarloSimulations,
+	}
+}
+
+// Name returns the algorithm name
+func (ma *MonteCarloAnalyzer) Name() string {
+	return ma.name
+}
+
+// GetWeight returns the algorithm's weight
+func (ma *MonteCarloAnalyzer) GetWeight() float64 {
+	ma.mu.RLock()
+	defer ma.mu.RUnlock()
+	return ma.weight
+}
+
+// SetWeight sets the algorithm's weight
+func (ma *MonteCarloAnalyzer) SetWeight(weight float64) error {
+	if weight < 0 {
+		return fmt.Errorf("weight cannot be negative, got %f", weight)
+	}
+	ma.mu.Lock()
+	defer ma.mu.Unlock()
+	ma.weight = weight
+	return nil
+}
+
+// GetSimulations returns the number of draws simulated per prediction
+func (ma *MonteCarloAnalyzer) GetSimulations() int {
+	ma.mu.RLock()
+	defer ma.mu.RUnlock()
+	return ma.simulations
+}
+
+// SetSimulations sets the number of draws simulated per prediction, between
+// 1 and MaxMonteCarloSimulations
+func (ma *MonteCarloAnalyzer) SetSimulations(simulations int) error {
+	if simulations < 1 || simulations > MaxMonteCarloSimulations {
+		return fmt.Errorf("simulations must be between 1 and %d, got %d",
+			MaxMonteCarloSimulations, simulations)
+	}
+	ma.mu.Lock()
+	defer ma.mu.Unlock()
+	ma.simulations = simulations
+	return nil
+}
+
+// SetSeed makes predictions reproducible: every prediction restarts the
+// random source from seed
+func (ma *MonteCarloAnalyzer) SetSeed(seed uint64) {
+	ma.mu.Lock()
+	defer ma.mu.Unlock()
+	ma.seed = &seed
+}
+
+// SetMinDraws sets the minimum number of draws required
+func (ma *MonteCarloAnalyzer) SetMinDraws(minDraws int) error {
+	if minDraws < 1 {
+		return fmt.Errorf("minimum draws must be at least 1, got %d", minDraws)
+	}
+	ma.mu.Lock()
+	defer ma.mu.Unlock()
+	ma.minDraws = minDraws
+	return nil
+}
+
+// GetMinDraws returns the minimum number of draws required
+func (ma *MonteCarloAnalyzer) GetMinDraws() int {
+	ma.mu.RLock()
+	defer ma.mu.RUnlock()
+	return ma.minDraws
+}
+
+// Validate checks if there's enough data for prediction
+func (ma *MonteCarloAnalyzer) Validate(historicalData []*entity.Draw) error {
+	if len(historicalData) < ma.minDraws {
+		return fmt.Errorf("need at least %d draws for Monte Carlo simulation, got %d",
+			ma.minDraws, len(historicalData))
+	}
+	return nil
+}
+
+// Train updates algorithm parameters (the simulation doesn't need training)
+func (ma *MonteCarloAnalyzer) Train(ctx context.Context, historicalData []*entity.Draw) error {
+	return nil
+}
+
+// Predict simulates draws weighted by the historical frequencies and returns
+// the numbers simulated most often, lower numbers first on ties
+func (ma *MonteCarloAnalyzer) Predict(
+	ctx context.Context,
+	gameType valueobject.GameType,
+	historicalData []*entity.Draw,
+) (*entity.Prediction, error) {
+	if err := ma.Validate(historicalData); err != nil {
+		return nil, err
+	}
+
+	ma.mu.RLock()
+	simulations := ma.simulations
+	seed := ma.seed
+	ma.mu.RUnlock()
+
+	var rng *rand.Rand
+	if seed != nil {
+		rng = rand.New(rand.NewPCG(*seed, 0))
+	} else {
+		rng = rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	}
+
+	minRange, maxRange := gameType.NumberRange()
+	weights := make([]float64, maxRange-minRange+1)
+	for i := range weights {
+		weights[i] = 1
+	}
+	for _, draw := range historicalData {
+		for _, num := range draw.Numbers {
+			if num >= minRange && num <= maxRange {
+				weights[num-minRange]++
+			}
+		}
+	}
+
+	count := gameType.NumberCount()
+	setHits := make(map[string]int)
+	numberHits := make([]int, len(weights))
+	for sim := 0; sim < simulations; sim++ {
+		if sim%1000 == 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			default:
+			}
+		}
+
+		set := simulateDraw(rng, weights, count)
+		for _, idx := range set {
+			numberHits[idx]++
+		}
+		setHits[setKey(set, minRange)]++
+	}
+
+	predictedNums := mostSimulatedNumbers(numberHits, count, minRange)
+	played := make([]int, len(predictedNums))
+	for i, num := range predictedNums {
+		played[i] = num - minRange
+	}
+
+	// Confidence is the picks' mean share of simulated draws they came up in
+	confidence := 0.0
+	for _, num := range predictedNums {
+		confidence += float64(numberHits[num-minRange]) / float64(simulations)
+	}
+	confidence /= float64(len(predictedNums))
+
+	numbers, err := valueobject.NewNumbers(predictedNums)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create numbers: %w", err)
+	}
+
+	metadata := map[string]string{
+		"simulations":      strconv.Itoa(simulations),
+		"set_hits":         strconv.Itoa(setHits[setKey(played, minRange)]),
+		"distinct_sets":    strconv.Itoa(len(setHits)),
+		"total_draws_used": strconv.Itoa(len(historicalData)),
+	}
+	if seed != nil {
+		metadata["seed"] = strconv.FormatUint(*seed, 10)
+	}
+
+	return &entity.Prediction{
+		ID:            "",
+		GameType:      gameType,
+		AlgorithmName: ma.name,
+		Numbers:       numbers,
+		Confidence:    confidence,
+		GeneratedAt:   time.Now(),
+		ForDate:       time.Now().Add(24 * time.Hour),
+		Metadata:      metadata,
+	}, nil
+}
+
+// mostSimulatedNumbers returns the count numbers with the most simulated
+// hits, lower numbers first on ties, in ascending order
+func mostSimulatedNumbers(numberHits []int, count int, minRange int) []int {
+	order := make([]int, len(numberHits))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return numberHits[order[i]] > numberHits[order[j]]
+	})
+
+	nums := make([]int, count)
+	for i, idx := range order[:count] {
+		nums[i] = idx + minRange
+	}
+	sort.Ints(nums)
+	return nums
+}
+
+// simulateDraw draws count distinct pool indexes without replacement, each
+// with a probability proportional to its weight, in ascending order
+func simulateDraw(rng *rand.Rand, weights []float64, count int) []int {
+	remaining := make([]float64, len(weights))
+	copy(remaining, weights)
+	total := 0.0
+	for _, w := range remaining {
+		total += w
+	}
+
+	set := make([]int, 0, count)
+	for len(set) < count {
+		target := rng.Float64() * total
+		idx := 0
+		for ; idx < len(remaining)-1; idx++ {
+			if remaining[idx] == 0 {
+				continue
+			}
+			target -= remaining[idx]
+			if target < 0 {
+				break
+			}
+		}
+		// Rounding can leave the walk on an already drawn last index
+		for remaining[idx] == 0 {
+			idx--
+		}
+		set = append(set, idx)
+		total -= remaining[idx]
+		remaining[idx] = 0
+	}
+	sort.Ints(set)
+	return set
+}
+
+// setKey encodes a simulated set of pool indexes as its zero-padded numbers,
+// so keys compare like the sets they encode
+func setKey(set []int, minRange int) string {
+	parts := make([]string, len(set))
+	for i, idx := range set {
+		parts[i] = fmt.Sprintf("%02d", idx+minRange)
+	}
+	return strings.Join(parts, ",")
+}
+
+// Ensure MonteCarloAnalyzer implements Algorithm
+var _ Algorithm = (*MonteCarloAnalyzer)(nil)
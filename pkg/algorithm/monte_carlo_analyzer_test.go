@@ -0,0 +1,113 @@
+package algorithm
+
+import (
+	"context"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// repeatedDraws builds count Mega 6/45 draws of the same numbers
+func repeatedDraws(t *testing.T, count int, nums []int) []*entity.Draw {
+	t.Helper()
+
+	start := time.Date(2026, 1, 1, 18, 0, 0, 0, time.UTC)
+	draws := make([]*entity.Draw, 0, count)
+	for i := 0; i < count; i++ {
+		draw, err := entity.NewDraw(valueobject.Mega645, i+1, valueobject.MustNewNumbers(nums),
+			start.AddDate(0, 0, 2*i), 0, 0)
+		require.NoError(t, err)
+		draws = append(draws, draw)
+	}
+	return draws
+}
+
+func TestMonteCarloAnalyzer_PlaysMostSimulatedNumbers(t *testing.T) {
+	analyzer := NewMonteCarloAnalyzer(1.0)
+	require.NoError(t, analyzer.SetSimulations(2000))
+	analyzer.SetSeed(7)
+
+	// 7-12 are drawn 31 times as often as any other number
+	prediction, err := analyzer.Predict(context.Background(), valueobject.Mega645,
+		repeatedDraws(t, 30, []int{7, 8, 9, 10, 11, 12}))
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{7, 8, 9, 10, 11, 12}, prediction.Numbers.AsSlice())
+	assert.Equal(t, "monte_carlo", prediction.AlgorithmName)
+	assert.Equal(t, "2000", prediction.Metadata["simulations"])
+	assert.Equal(t, "7", prediction.Metadata["seed"])
+	assert.Greater(t, prediction.Confidence, 0.5)
+	assert.LessOrEqual(t, prediction.Confidence, 1.0)
+}
+
+func TestMonteCarloAnalyzer_RealisticHistory(t *testing.T) {
+	// A realistic history: 200 varied draws, each with three of the high
+	// numbers 36-45 and three of 1-35, so no set dominates but 36-45 are
+	// drawn over three times as often as the rest. Comparing whole sets
+	// would play whichever happened to repeat, or the lowest.
+	rng := rand.New(rand.NewPCG(1, 2))
+	start := time.Date(2026, 1, 1, 18, 0, 0, 0, time.UTC)
+	history := make([]*entity.Draw, 0, 200)
+	for i := 0; i < 200; i++ {
+		nums := make([]int, 0, 6)
+		for _, idx := range rng.Perm(10)[:3] {
+			nums = append(nums, 36+idx)
+		}
+		for _, idx := range rng.Perm(35)[:3] {
+			nums = append(nums, 1+idx)
+		}
+		draw, err := entity.NewDraw(valueobject.Mega645, i+1, valueobject.MustNewNumbers(nums),
+			start.AddDate(0, 0, 2*i), 0, 0)
+		require.NoError(t, err)
+		history = append(history, draw)
+	}
+
+	analyzer := NewMonteCarloAnalyzer(1.0)
+	require.NoError(t, analyzer.SetSimulations(2000))
+	analyzer.SetSeed(7)
+	prediction, err := analyzer.Predict(context.Background(), valueobject.Mega645, history)
+	require.NoError(t, err)
+
+	for _, num := range prediction.Numbers.AsSlice() {
+		assert.GreaterOrEqual(t, num, 36, "picked %v", prediction.Numbers.AsSlice())
+	}
+}
+
+func TestMonteCarloAnalyzer_SeedIsReproducible(t *testing.T) {
+	history := combinedScoreHistory(t)
+
+	predict := func(seed uint64) *entity.Prediction {
+		analyzer := NewMonteCarloAnalyzer(1.0)
+		require.NoError(t, analyzer.SetSimulations(500))
+		analyzer.SetSeed(seed)
+		prediction, err := analyzer.Predict(context.Background(), valueobject.Mega645, history)
+		require.NoError(t, err)
+		return prediction
+	}
+
+	first := predict(42)
+	again := predict(42)
+	assert.Equal(t, first.Numbers, again.Numbers)
+	assert.Equal(t, first.Metadata, again.Metadata)
+	assert.Equal(t, first.Confidence, again.Confidence)
+}
+
+func TestMonteCarloAnalyzer_Validation(t *testing.T) {
+	analyzer := NewMonteCarloAnalyzer(1.0)
+
+	assert.Equal(t, DefaultMonteCarloSimulations, analyzer.GetSimulations())
+	assert.Error(t, analyzer.SetSimulations(0))
+	assert.Error(t, analyzer.SetSimulations(MaxMonteCarloSimulations+1))
+	assert.Error(t, analyzer.SetWeight(-1))
+	assert.Error(t, analyzer.Validate(combinedScoreHistory(t)[:5]))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := analyzer.Predict(ctx, valueobject.Mega645, combinedScoreHistory(t))
+	assert.ErrorIs(t, err, context.Canceled)
+}
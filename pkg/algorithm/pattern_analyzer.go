@@ -53,6 +53,29 @@ func (pa *PatternAnalyzer) SetWeight(weight float64) error {
 	return nil
 }
 
+// SetMinDraws sets the minimum number of draws required
+func (pa *PatternAnalyzer) SetMinDraws(minDraws int) error {
+	if minDraws < 1 {
+		return fmt.Errorf("minimum draws must be at least 1, got %d", minDraws)
+	}
+	pa.mu.Lock()
+	defer pa.mu.Unlock()
+	pa.minDraws = minDraws
+	return nil
+}
+
+// GetMinDraws returns the minimum number of draws required
+func (pa *PatternAnalyzer) GetMinDraws() int {
+	pa.mu.RLock()
+	defer pa.mu.RUnlock()
+	return pa.minDraws
+}
+
+// PredictsConcurrently reports that predictions only depend on their history
+func (pa *PatternAnalyzer) PredictsConcurrently() bool {
+	return true
+}
+
 // Validate checks if there's enough data for prediction
 func (pa *PatternAnalyzer) Validate(historicalData []*entity.Draw) error {
 	if len(historicalData) < pa.minDraws {
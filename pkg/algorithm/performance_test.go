@@ -0,0 +1,67 @@
+//go:build perf
+
+package algorithm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/testutil/fixtures"
+)
+
+// Performance budgets are loose enough for -race and slow machines but tight
+// enough to catch an accidental quadratic loop. Wall-clock checks depend on
+// the machine running them, so they only build with the perf tag.
+const (
+	predictBudget  = 5 * time.Millisecond
+	ensembleBudget = 250 * time.Millisecond
+)
+
+// predictBudgets overrides predictBudget for the analyzers that simulate
+// rather than count: Monte Carlo runs DefaultMonteCarloSimulations draws
+var predictBudgets = map[string]time.Duration{
+	"monte_carlo": 250 * time.Millisecond,
+}
+
+func TestPerformanceBudget(t *testing.T) {
+	ctx := context.Background()
+	draws := fixtures.Draws(valueobject.Mega645, 1000)
+
+	// Average over a fixed number of runs rather than testing.Benchmark so the
+	// guard stays fast enough to run with the regular suite
+	const runs = 20
+	check := func(name string, budget time.Duration, run func() error) {
+		start := time.Now()
+		for range runs {
+			if err := run(); err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+		}
+		if perOp := time.Since(start) / runs; perOp > budget {
+			t.Errorf("%s took %v per op, budget is %v", name, perOp, budget)
+		}
+	}
+
+	registry := NewRegistry()
+	for _, algo := range benchmarkAnalyzers() {
+		budget, ok := predictBudgets[algo.Name()]
+		if !ok {
+			budget = predictBudget
+		}
+		check(algo.Name()+".Predict", budget, func() error {
+			_, err := algo.Predict(ctx, valueobject.Mega645, draws)
+			return err
+		})
+		if err := registry.Register(algo, algo.GetWeight()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ensemble := NewEnsemble(registry, WeightedVoting)
+	check("Ensemble.GeneratePredictions", ensembleBudget, func() error {
+		_, err := ensemble.GeneratePredictions(ctx, valueobject.Mega645, draws)
+		return err
+	})
+}
@@ -3,6 +3,7 @@ package algorithm
 import (
 	"context"
 	"fmt"
+	"math"
 	"math/rand/v2"
 	"sync"
 	"time"
@@ -11,12 +12,40 @@ import (
 	"github.com/tool_predict/internal/domain/valueobject"
 )
 
+const (
+	// realisticMinDraws is the history needed to derive norms in realistic mode
+	realisticMinDraws = 10
+	// realisticMaxAttempts bounds how often a set is resampled in realistic mode
+	realisticMaxAttempts = 1000
+)
+
 // RandomAnalyzer generates purely random predictions
 type RandomAnalyzer struct {
-	name     string
-	weight   float64
-	minDraws int
-	mu       sync.RWMutex
+	name      string
+	weight    float64
+	minDraws  int
+	realistic bool // Resample sets whose sum or odd/even balance is atypical
+	mu        sync.RWMutex
+}
+
+// realisticBand holds the historical norms a realistic ticket must fall within
+type realisticBand struct {
+	minSum int
+	maxSum int
+	minOdd int
+	maxOdd int
+}
+
+// contains reports whether a set of numbers falls within the band
+func (b realisticBand) contains(nums []int) bool {
+	sum := sumIntSlice(nums)
+	odd := 0
+	for _, n := range nums {
+		if n%2 == 1 {
+			odd++
+		}
+	}
+	return sum >= b.minSum && sum <= b.maxSum && odd >= b.minOdd && odd <= b.maxOdd
 }
 
 // NewRandomAnalyzer creates a new random analyzer
@@ -53,7 +82,11 @@ func (ra *RandomAnalyzer) SetWeight(weight float64) error {
 
 // Validate checks if there's enough data for prediction
 func (ra *RandomAnalyzer) Validate(historicalData []*entity.Draw) error {
-	// Random analysis doesn't require any historical data
+	// Basic random analysis doesn't require any historical data
+	if minDraws := ra.GetMinDraws(); len(historicalData) < minDraws {
+		return fmt.Errorf("need at least %d draws for realistic random analysis, got %d",
+			minDraws, len(historicalData))
+	}
 	return nil
 }
 
@@ -68,6 +101,10 @@ func (ra *RandomAnalyzer) Predict(
 	gameType valueobject.GameType,
 	historicalData []*entity.Draw,
 ) (*entity.Prediction, error) {
+	if err := ra.Validate(historicalData); err != nil {
+		return nil, err
+	}
+
 	// Check for cancellation
 	select {
 	case <-ctx.Done():
@@ -75,21 +112,30 @@ func (ra *RandomAnalyzer) Predict(
 	default:
 	}
 
-	// Get number range for game type
-	minRange, maxRange := gameType.NumberRange()
-
-	// Generate unique random numbers
-	predictedNums := make([]int, 0, 6)
-	used := make(map[int]bool)
+	ra.mu.RLock()
+	realistic := ra.realistic
+	ra.mu.RUnlock()
 
-	for len(predictedNums) < 6 {
-		// Generate random number in range [minRange, maxRange]
-		num := rand.IntN(maxRange-minRange+1) + minRange
+	metadata := map[string]string{
+		"min_draws_required": fmt.Sprintf("%d", ra.GetMinDraws()),
+		"total_draws_used":   fmt.Sprintf("%d", len(historicalData)),
+		"type":               "random",
+	}
 
-		if !used[num] {
-			used[num] = true
-			predictedNums = append(predictedNums, num)
+	predictedNums := randomSet(gameType)
+	if realistic {
+		// Resample until the set looks like a historical draw
+		band := newRealisticBand(historicalData)
+		attempts := 1
+		for !band.contains(predictedNums) && attempts < realisticMaxAttempts {
+			predictedNums = randomSet(gameType)
+			attempts++
 		}
+
+		metadata["type"] = "realistic_random"
+		metadata["sum_band"] = fmt.Sprintf("%d-%d", band.minSum, band.maxSum)
+		metadata["odd_band"] = fmt.Sprintf("%d-%d", band.minOdd, band.maxOdd)
+		metadata["attempts"] = fmt.Sprintf("%d", attempts)
 	}
 
 	// Sort for consistency
@@ -113,11 +159,7 @@ func (ra *RandomAnalyzer) Predict(
 		Confidence:    confidence,
 		GeneratedAt:   time.Now(),
 		ForDate:       time.Now().Add(24 * time.Hour), // Predict for tomorrow
-		Metadata: map[string]string{
-			"min_draws_required": "0",
-			"total_draws_used":   fmt.Sprintf("%d", len(historicalData)),
-			"type":               "random",
-		},
+		Metadata:      metadata,
 	}
 
 	return prediction, nil
@@ -136,9 +178,76 @@ func (ra *RandomAnalyzer) SetMinDraws(minDraws int) error {
 func (ra *RandomAnalyzer) GetMinDraws() int {
 	ra.mu.RLock()
 	defer ra.mu.RUnlock()
+	if ra.realistic && ra.minDraws < realisticMinDraws {
+		return realisticMinDraws
+	}
 	return ra.minDraws
 }
 
+// SetRealistic toggles realistic mode, which rejects and resamples sets whose
+// sum or odd/even balance falls outside the norms of the provided draws
+func (ra *RandomAnalyzer) SetRealistic(realistic bool) {
+	ra.mu.Lock()
+	defer ra.mu.Unlock()
+	ra.realistic = realistic
+}
+
+// IsRealistic reports whether realistic mode is on
+func (ra *RandomAnalyzer) IsRealistic() bool {
+	ra.mu.RLock()
+	defer ra.mu.RUnlock()
+	return ra.realistic
+}
+
+// randomSet draws 6 unique uniform random numbers from the game's range
+func randomSet(gameType valueobject.GameType) []int {
+	// Get number range for game type
+	minRange, maxRange := gameType.NumberRange()
+
+	// Generate unique random numbers
+	predictedNums := make([]int, 0, 6)
+	used := make(map[int]bool)
+
+	for len(predictedNums) < 6 {
+		// Generate random number in range [minRange, maxRange]
+		num := rand.IntN(maxRange-minRange+1) + minRange
+
+		if !used[num] {
+			used[num] = true
+			predictedNums = append(predictedNums, num)
+		}
+	}
+
+	return predictedNums
+}
+
+// newRealisticBand derives the sum band (mean ± one standard deviation) and the
+// odd count band (likewise, widened to whole counts) from historical draws
+func newRealisticBand(draws []*entity.Draw) realisticBand {
+	sums := make([]int, len(draws))
+	odds := make([]int, len(draws))
+	for i, draw := range draws {
+		sums[i] = draw.Numbers.Sum()
+		for _, n := range draw.Numbers {
+			if n%2 == 1 {
+				odds[i]++
+			}
+		}
+	}
+
+	sumMean := calculateMean(sums)
+	sumStdDev := calculateStdDev(sums, sumMean)
+	oddMean := calculateMean(odds)
+	oddStdDev := calculateStdDev(odds, oddMean)
+
+	return realisticBand{
+		minSum: int(math.Floor(sumMean - sumStdDev)),
+		maxSum: int(math.Ceil(sumMean + sumStdDev)),
+		minOdd: int(math.Floor(oddMean - oddStdDev)),
+		maxOdd: int(math.Ceil(oddMean + oddStdDev)),
+	}
+}
+
 // sortNumbers sorts a slice of integers in place
 func sortNumbers(nums []int) {
 	for i := 0; i < len(nums)-1; i++ {
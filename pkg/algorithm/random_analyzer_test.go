@@ -0,0 +1,87 @@
+package algorithm
+
+import (
+	"context"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// createRandomDraws generates reproducible uniformly random draws
+func createRandomDraws(t *testing.T, gameType valueobject.GameType, count int) []*entity.Draw {
+	t.Helper()
+
+	rng := rand.New(rand.NewPCG(42, 2024))
+	minRange, maxRange := gameType.NumberRange()
+	baseDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	draws := make([]*entity.Draw, count)
+	for i := range draws {
+		perm := rng.Perm(maxRange - minRange + 1)
+		nums := make([]int, 6)
+		for j := range nums {
+			nums[j] = perm[j] + minRange
+		}
+
+		draw, err := entity.NewDraw(gameType, i+1, valueobject.MustNewNumbers(nums), baseDate.AddDate(0, 0, i), 0, 0)
+		require.NoError(t, err)
+		draws[i] = draw
+	}
+	return draws
+}
+
+func TestRandomAnalyzer_BasicModeNeedsNoHistory(t *testing.T) {
+	analyzer := NewRandomAnalyzer(1.0)
+
+	assert.Equal(t, 0, analyzer.GetMinDraws())
+	pred, err := analyzer.Predict(context.Background(), valueobject.Mega645, nil)
+	require.NoError(t, err)
+	assert.Len(t, pred.Numbers, 6)
+	assert.Equal(t, "random", pred.Metadata["type"])
+}
+
+func TestRandomAnalyzer_RealisticModeRequiresHistory(t *testing.T) {
+	analyzer := NewRandomAnalyzer(1.0)
+	analyzer.SetRealistic(true)
+
+	assert.True(t, analyzer.IsRealistic())
+	assert.Equal(t, realisticMinDraws, analyzer.GetMinDraws())
+
+	_, err := analyzer.Predict(context.Background(), valueobject.Mega645, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "realistic")
+}
+
+func TestRandomAnalyzer_RealisticModeStaysWithinSumBand(t *testing.T) {
+	draws := createRandomDraws(t, valueobject.Power655, 200)
+	band := newRealisticBand(draws)
+	require.Less(t, band.minSum, band.maxSum)
+
+	analyzer := NewRandomAnalyzer(1.0)
+	analyzer.SetRealistic(true)
+
+	for i := 0; i < 200; i++ {
+		pred, err := analyzer.Predict(context.Background(), valueobject.Power655, draws)
+		require.NoError(t, err)
+
+		sum := pred.Numbers.Sum()
+		assert.GreaterOrEqual(t, sum, band.minSum)
+		assert.LessOrEqual(t, sum, band.maxSum)
+		assert.True(t, band.contains(pred.Numbers))
+		assert.Equal(t, "realistic_random", pred.Metadata["type"])
+	}
+}
+
+func TestRealisticBand_Contains(t *testing.T) {
+	band := realisticBand{minSum: 100, maxSum: 200, minOdd: 2, maxOdd: 4}
+
+	assert.True(t, band.contains([]int{10, 15, 20, 25, 30, 35}))  // sum 135, 3 odd
+	assert.False(t, band.contains([]int{1, 2, 3, 4, 5, 6}))       // sum too low
+	assert.False(t, band.contains([]int{40, 41, 42, 43, 44, 45})) // sum too high
+	assert.False(t, band.contains([]int{11, 13, 15, 17, 19, 31})) // 6 odd
+}
@@ -9,16 +9,18 @@ import (
 
 // Registry manages algorithm registration and weights
 type Registry struct {
-	mu         sync.RWMutex
-	algorithms map[string]Algorithm
-	weights    map[string]float64 // For ensemble voting
+	mu               sync.RWMutex
+	algorithms       map[string]Algorithm
+	weights          map[string]float64   // For ensemble voting
+	perNumberWeights map[string][]float64 // Optional reliability multipliers; index n-1 is number n
 }
 
 // NewRegistry creates a new algorithm registry
 func NewRegistry() *Registry {
 	return &Registry{
-		algorithms: make(map[string]Algorithm),
-		weights:    make(map[string]float64),
+		algorithms:       make(map[string]Algorithm),
+		weights:          make(map[string]float64),
+		perNumberWeights: make(map[string][]float64),
 	}
 }
 
@@ -130,6 +132,83 @@ func (r *Registry) UpdateWeight(name string, weight float64) error {
 	return nil
 }
 
+// SetPerNumberWeights sets how reliably an algorithm predicts each number,
+// as multipliers of its weight used by PerNumberWeighted voting. Index n-1
+// holds the multiplier for number n; numbers beyond the slice count at 1.
+// A nil slice removes the algorithm's per-number weights.
+func (r *Registry) SetPerNumberWeights(name string, weights []float64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.algorithms[name]; !exists {
+		return fmt.Errorf("algorithm %s not found", name)
+	}
+
+	if weights == nil {
+		delete(r.perNumberWeights, name)
+		return nil
+	}
+
+	for i, weight := range weights {
+		if weight < 0 {
+			return fmt.Errorf("per-number weight for %d cannot be negative, got %f", i+1, weight)
+		}
+	}
+
+	r.perNumberWeights[name] = append([]float64(nil), weights...)
+	return nil
+}
+
+// GetPerNumberWeight returns the multiplier for num set by
+// SetPerNumberWeights, or 1 if none was set
+func (r *Registry) GetPerNumberWeight(name string, num int) float64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	weights := r.perNumberWeights[name]
+	if num < 1 || num > len(weights) {
+		return 1.0
+	}
+	return weights[num-1]
+}
+
+// PerNumberWeights returns a copy of every algorithm's per-number weights
+func (r *Registry) PerNumberWeights() map[string][]float64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	weights := make(map[string][]float64, len(r.perNumberWeights))
+	for name, w := range r.perNumberWeights {
+		weights[name] = append([]float64(nil), w...)
+	}
+	return weights
+}
+
+// NormalizeWeights rescales the registered weights so they sum to total,
+// keeping their ratios. Relative voting is unchanged, but absolute weights
+// become comparable across configurations (e.g. for UpdateWeights clamping).
+func (r *Registry) NormalizeWeights(total float64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if total <= 0 {
+		return fmt.Errorf("weight total must be positive, got %f", total)
+	}
+
+	sum := 0.0
+	for _, weight := range r.weights {
+		sum += weight
+	}
+	if sum == 0 {
+		return fmt.Errorf("cannot normalize weights: all %d weights are zero", len(r.weights))
+	}
+
+	for name, weight := range r.weights {
+		r.weights[name] = weight / sum * total
+	}
+	return nil
+}
+
 // GetAlgorithmsForGameType returns algorithms that can predict for a specific game type
 func (r *Registry) GetAlgorithmsForGameType(gameType valueobject.GameType) []Algorithm {
 	r.mu.RLock()
@@ -145,6 +224,25 @@ func (r *Registry) GetAlgorithmsForGameType(gameType valueobject.GameType) []Alg
 	return algos
 }
 
+// MinDrawsRequired returns the largest minimum draw requirement among the
+// registered algorithms, together with the name of the algorithm requiring it
+func (r *Registry) MinDrawsRequired() (int, string) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	maxDraws := 0
+	name := ""
+	for algoName, algo := range r.algorithms {
+		minDraws := algo.GetMinDraws()
+		if minDraws > maxDraws || (minDraws == maxDraws && (name == "" || algoName < name)) {
+			maxDraws = minDraws
+			name = algoName
+		}
+	}
+
+	return maxDraws, name
+}
+
 // Count returns the number of registered algorithms
 func (r *Registry) Count() int {
 	r.mu.RLock()
@@ -164,6 +262,7 @@ func (r *Registry) Unregister(name string) error {
 
 	delete(r.algorithms, name)
 	delete(r.weights, name)
+	delete(r.perNumberWeights, name)
 
 	return nil
 }
@@ -175,4 +274,5 @@ func (r *Registry) Clear() {
 
 	r.algorithms = make(map[string]Algorithm)
 	r.weights = make(map[string]float64)
+	r.perNumberWeights = make(map[string][]float64)
 }
@@ -3,9 +3,11 @@ package algorithm
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
 	"github.com/tool_predict/internal/domain/valueobject"
 )
 
@@ -42,6 +44,59 @@ func TestRegistry_RegisterOrUpdate(t *testing.T) {
 	assert.Equal(t, 1.5, weight)
 }
 
+func TestRegistry_NormalizeWeights(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.Register(NewFrequencyAnalyzer(1.0), 1.0))
+	require.NoError(t, registry.Register(NewHotColdAnalyzer(1.2), 1.2))
+	require.NoError(t, registry.Register(NewPatternAnalyzer(0.8), 0.8))
+
+	for _, total := range []float64{1.0, 3.0} {
+		require.NoError(t, registry.NormalizeWeights(total))
+
+		freq := registry.GetWeight("frequency_analysis")
+		hotCold := registry.GetWeight("hot_cold_analysis")
+		pattern := registry.GetWeight("pattern_analysis")
+
+		assert.InDelta(t, total, freq+hotCold+pattern, 1e-9)
+
+		// Relative ordering and ratios are preserved
+		assert.Greater(t, hotCold, freq)
+		assert.Greater(t, freq, pattern)
+		assert.InDelta(t, 1.2, hotCold/freq, 1e-9)
+	}
+}
+
+func TestRegistry_NormalizeWeights_Invalid(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.Register(NewFrequencyAnalyzer(0), 0))
+
+	assert.Error(t, registry.NormalizeWeights(1.0))
+	assert.Error(t, registry.NormalizeWeights(0))
+}
+
+func TestRegistry_PerNumberWeights(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.Register(NewFrequencyAnalyzer(1.0), 1.0))
+
+	assert.Equal(t, 1.0, registry.GetPerNumberWeight("frequency_analysis", 5))
+
+	require.NoError(t, registry.SetPerNumberWeights("frequency_analysis", []float64{0.5, 2.0}))
+	assert.Equal(t, 0.5, registry.GetPerNumberWeight("frequency_analysis", 1))
+	assert.Equal(t, 2.0, registry.GetPerNumberWeight("frequency_analysis", 2))
+	assert.Equal(t, 1.0, registry.GetPerNumberWeight("frequency_analysis", 3))
+	assert.Equal(t, map[string][]float64{"frequency_analysis": {0.5, 2.0}}, registry.PerNumberWeights())
+
+	assert.Error(t, registry.SetPerNumberWeights("unknown", []float64{1}))
+	assert.Error(t, registry.SetPerNumberWeights("frequency_analysis", []float64{1, -1}))
+
+	require.NoError(t, registry.SetPerNumberWeights("frequency_analysis", nil))
+	assert.Equal(t, 1.0, registry.GetPerNumberWeight("frequency_analysis", 2))
+
+	require.NoError(t, registry.SetPerNumberWeights("frequency_analysis", []float64{3}))
+	require.NoError(t, registry.Unregister("frequency_analysis"))
+	assert.Empty(t, registry.PerNumberWeights())
+}
+
 func TestRegistry_Get(t *testing.T) {
 	registry := NewRegistry()
 	analyzer := NewFrequencyAnalyzer(1.0)
@@ -223,6 +278,470 @@ func TestEnsemble_VotingStrategies(t *testing.T) {
 	assert.Equal(t, "confidence_weighted", prediction.VotingStrategy)
 }
 
+func TestEnsemble_GeneratePredictions_TargetsNextDraw(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.Register(NewFrequencyAnalyzer(1.0), 1.0))
+	ensemble := NewEnsemble(registry, WeightedVoting)
+
+	draws := createWinnerSplitDraws(t, 10)
+	prediction, err := ensemble.GeneratePredictions(context.Background(), valueobject.Mega645, draws)
+	require.NoError(t, err)
+
+	// The latest draw is Tue 20 Jan 2026; Mega 6/45's next draw is Wednesday
+	assert.Equal(t, time.Date(2026, 1, 21, 0, 0, 0, 0, time.UTC), prediction.ForDate)
+}
+
+func TestEnsemble_Alternates(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.Register(NewFrequencyAnalyzer(3), 3))
+	require.NoError(t, registry.Register(NewHotColdAnalyzer(2), 2))
+	require.NoError(t, registry.Register(NewPatternAnalyzer(1), 1))
+	ensemble := NewEnsemble(registry, WeightedVoting)
+
+	newPrediction := func(name string, nums []int) *entity.Prediction {
+		return &entity.Prediction{AlgorithmName: name, Numbers: valueobject.MustNewNumbers(nums)}
+	}
+	predictions := []*entity.Prediction{
+		newPrediction("frequency_analysis", []int{1, 2, 3, 4, 5, 6}),
+		newPrediction("hot_cold_analysis", []int{1, 2, 3, 7, 8, 9}),
+		newPrediction("pattern_analysis", []int{1, 10, 11, 12, 13, 14}),
+	}
+
+	// Votes: 1=6, 2-3=5, 4-6=3, 7-9=2, 10-14=1
+	final, ranked, err := ensemble.applyVotingStrategy(predictions, WeightedVoting, nil, nil, consensusRules{})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, final.AsSlice())
+
+	alternates := alternatesFrom(ranked, final, DefaultAlternateCount)
+	assert.Equal(t, []int{7, 8, 9, 10}, alternates)
+	for _, num := range alternates {
+		assert.False(t, final.Contains(num), "alternate %d is in the final six", num)
+	}
+
+	assert.Empty(t, alternatesFrom(ranked, final, 0))
+	assert.Error(t, ensemble.SetAlternateCount(-1))
+}
+
+func TestEnsemble_MinVoters(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.Register(NewFrequencyAnalyzer(10), 10))
+	require.NoError(t, registry.Register(NewHotColdAnalyzer(1), 1))
+	require.NoError(t, registry.Register(NewPatternAnalyzer(1), 1))
+	ensemble := NewEnsemble(registry, WeightedVoting)
+
+	newPrediction := func(name string, nums []int) *entity.Prediction {
+		return &entity.Prediction{AlgorithmName: name, Numbers: valueobject.MustNewNumbers(nums)}
+	}
+	predictions := []*entity.Prediction{
+		newPrediction("frequency_analysis", []int{1, 2, 3, 4, 5, 6}),
+		newPrediction("hot_cold_analysis", []int{7, 8, 9, 10, 11, 12}),
+		newPrediction("pattern_analysis", []int{7, 8, 9, 10, 11, 12}),
+	}
+
+	// The heavily weighted frequency analyzer alone outvotes the other two
+	final, _, err := ensemble.applyVotingStrategy(predictions, WeightedVoting, nil, nil, consensusRules{})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, final.AsSlice())
+
+	// With K=2 only the numbers both other algorithms picked are chosen
+	rules := consensusRules{minVoters: 2}
+	final, ranked, err := ensemble.applyVotingStrategy(predictions, WeightedVoting, nil, nil, rules)
+	require.NoError(t, err)
+	assert.Equal(t, []int{7, 8, 9, 10, 11, 12}, final.AsSlice())
+	assert.Equal(t, []int{1, 2, 3, 4}, alternatesFrom(ranked, final, DefaultAlternateCount))
+
+	// Too little consensus falls back to the vote for the remaining slots
+	predictions[2] = newPrediction("pattern_analysis", []int{7, 8, 9, 13, 14, 15})
+	final, _, err = ensemble.applyVotingStrategy(predictions, WeightedVoting, nil, nil, rules)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 7, 8, 9}, final.AsSlice())
+
+	assert.Error(t, ensemble.SetMinVoters(-1))
+}
+
+func TestEnsemble_MinAlgorithmDiversity(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.Register(NewFrequencyAnalyzer(10), 10))
+	require.NoError(t, registry.Register(NewHotColdAnalyzer(2), 2))
+	require.NoError(t, registry.Register(NewPatternAnalyzer(1), 1))
+	ensemble := NewEnsemble(registry, WeightedVoting)
+
+	newPrediction := func(name string, nums []int) *entity.Prediction {
+		return &entity.Prediction{AlgorithmName: name, Numbers: valueobject.MustNewNumbers(nums)}
+	}
+	predictions := []*entity.Prediction{
+		newPrediction("frequency_analysis", []int{1, 2, 3, 4, 5, 6}),
+		newPrediction("hot_cold_analysis", []int{7, 8, 9, 10, 11, 12}),
+		newPrediction("pattern_analysis", []int{13, 14, 15, 16, 17, 18}),
+	}
+
+	// The frequency analyzer alone fills the ticket
+	final, _, err := ensemble.applyVotingStrategy(predictions, WeightedVoting, nil, nil, consensusRules{})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, final.AsSlice())
+
+	// Two algorithms: its worst ranked number gives way to hot/cold's best
+	final, _, err = ensemble.applyVotingStrategy(predictions, WeightedVoting, nil, nil, consensusRules{minDiversity: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 7}, final.AsSlice())
+
+	// Three algorithms, keeping the fixed numbers
+	final, _, err = ensemble.applyVotingStrategy(predictions, WeightedVoting, []int{5, 6}, nil, consensusRules{minDiversity: 3})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 5, 6, 7, 13}, final.AsSlice())
+
+	// More than there are algorithms represents every one
+	final, _, err = ensemble.applyVotingStrategy(predictions, WeightedVoting, nil, nil, consensusRules{minDiversity: 5})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 7, 13}, final.AsSlice())
+
+	assert.Error(t, ensemble.SetMinAlgorithmDiversity(-1))
+}
+
+func TestEnsemble_MinAlgorithmDiversity_KeepsConsensusNumbers(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.Register(NewFrequencyAnalyzer(10), 10))
+	require.NoError(t, registry.Register(NewHotColdAnalyzer(2), 2))
+	require.NoError(t, registry.Register(NewPatternAnalyzer(1), 1))
+	require.NoError(t, registry.Register(NewGapAnalyzer(1), 1))
+	ensemble := NewEnsemble(registry, WeightedVoting)
+
+	newPrediction := func(name string, nums []int) *entity.Prediction {
+		return &entity.Prediction{AlgorithmName: name, Numbers: valueobject.MustNewNumbers(nums)}
+	}
+	predictions := []*entity.Prediction{
+		newPrediction("frequency_analysis", []int{1, 2, 3, 4, 5, 6}),
+		newPrediction("hot_cold_analysis", []int{3, 4, 5, 6, 7, 8}),
+		newPrediction("pattern_analysis", []int{7, 13, 14, 15, 16, 17}),
+		newPrediction("gap_analysis", []int{20, 21, 22, 23, 24, 25}),
+	}
+
+	// 3-6 and 7 have two voters, so the ticket is 3, 4, 5, 6, 7 and 1. For
+	// the fourth algorithm 1 gives way, though it outranks 7: 10 votes to 3
+	final, _, err := ensemble.applyVotingStrategy(predictions, WeightedVoting, nil, nil,
+		consensusRules{minVoters: 2, minDiversity: 4})
+	require.NoError(t, err)
+	assert.Equal(t, []int{3, 4, 5, 6, 7, 20}, final.AsSlice())
+}
+
+func TestEnsemble_GeneratePredictions_CarriesAlternates(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.Register(NewFrequencyAnalyzer(1.0), 1.0))
+	require.NoError(t, registry.Register(NewHotColdAnalyzer(1.2), 1.2))
+	ensemble := NewEnsemble(registry, WeightedVoting)
+
+	prediction, err := ensemble.GeneratePredictions(context.Background(), valueobject.Mega645, createMockDraws(valueobject.Mega645, 150))
+	require.NoError(t, err)
+
+	require.NotEmpty(t, prediction.Alternates)
+	assert.LessOrEqual(t, len(prediction.Alternates), DefaultAlternateCount)
+
+	// The ranked pool starts with the final six, then the alternates
+	require.Len(t, prediction.RankedPool, RankedPoolSize)
+	assert.ElementsMatch(t, prediction.FinalNumbers.AsSlice(), prediction.RankedPool[:6])
+	assert.Equal(t, prediction.Alternates, prediction.RankedPool[6:6+len(prediction.Alternates)])
+	for _, num := range prediction.Alternates {
+		assert.False(t, prediction.FinalNumbers.Contains(num))
+	}
+
+	require.NoError(t, ensemble.SetAlternateCount(0))
+	prediction, err = ensemble.GeneratePredictions(context.Background(), valueobject.Mega645, createMockDraws(valueobject.Mega645, 150))
+	require.NoError(t, err)
+	assert.Empty(t, prediction.Alternates)
+}
+
+func TestEnsemble_GeneratePredictions_Wheel(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.Register(NewFrequencyAnalyzer(1.0), 1.0))
+	require.NoError(t, registry.Register(NewHotColdAnalyzer(1.2), 1.2))
+	ensemble := NewEnsemble(registry, WeightedVoting)
+
+	prediction, err := ensemble.GeneratePredictions(context.Background(), valueobject.Mega645, createMockDraws(valueobject.Mega645, 150))
+	require.NoError(t, err)
+	assert.Nil(t, prediction.Wheel)
+
+	require.NoError(t, ensemble.SetWheel(Wheel4If5, 10))
+	prediction, err = ensemble.GeneratePredictions(context.Background(), valueobject.Mega645, createMockDraws(valueobject.Mega645, 150))
+	require.NoError(t, err)
+	require.NotNil(t, prediction.Wheel)
+	assert.Equal(t, "4if5", prediction.Wheel.Guarantee)
+	assert.LessOrEqual(t, len(prediction.Wheel.Tickets), 10)
+	assert.Subset(t, prediction.Wheel.Candidates, prediction.FinalNumbers.AsSlice())
+	assertWheelGuarantee(t, Wheel4If5, prediction.Wheel.Candidates, prediction.Wheel.Tickets)
+
+	assert.Error(t, ensemble.SetWheel(Wheel3If4, -1))
+	assert.Error(t, ensemble.SetWheel("6if6", 10))
+}
+
+func TestEnsemble_GeneratePredictions_BaoPool(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.Register(NewFrequencyAnalyzer(1.0), 1.0))
+	require.NoError(t, registry.Register(NewHotColdAnalyzer(1.2), 1.2))
+	ensemble := NewEnsemble(registry, WeightedVoting)
+	require.NoError(t, ensemble.SetBetType(valueobject.BetType(8)))
+
+	for _, gameType := range valueobject.AllGameTypes() {
+		prediction, err := ensemble.GeneratePredictions(context.Background(), gameType, createMockDraws(gameType, 150))
+		require.NoError(t, err)
+
+		assert.Equal(t, "bao8", prediction.BetType)
+		require.Len(t, prediction.BetPool, 8)
+		seen := make(map[int]bool)
+		for _, num := range prediction.BetPool {
+			assert.True(t, gameType.InRange(num), "%s: %d out of range", gameType, num)
+			assert.False(t, seen[num], "%s: %d in the pool twice", gameType, num)
+			seen[num] = true
+		}
+		for _, num := range prediction.FinalNumbers {
+			assert.Contains(t, prediction.BetPool, num)
+		}
+	}
+
+	// bao16 isn't sold
+	require.NoError(t, ensemble.SetBetType(valueobject.BetType(16)))
+	_, err := ensemble.GeneratePredictions(context.Background(), valueobject.Mega645, createMockDraws(valueobject.Mega645, 150))
+	assert.Error(t, err)
+
+	require.NoError(t, ensemble.SetBetType(valueobject.BetSingle))
+	prediction, err := ensemble.GeneratePredictions(context.Background(), valueobject.Mega645, createMockDraws(valueobject.Mega645, 150))
+	require.NoError(t, err)
+	assert.Empty(t, prediction.BetType)
+	assert.Empty(t, prediction.BetPool)
+}
+
+func TestBetPool(t *testing.T) {
+	final := valueobject.MustNewNumbers([]int{3, 9, 12, 20, 33, 41})
+	ranked := []int{12, 3, 41, 9, 33, 20, 7, 44, 1}
+
+	assert.Equal(t, []int{3, 7, 9, 12, 20, 33, 41, 44}, betPool(ranked, nil, final, 8))
+	// bao5 drops the lowest ranked of the final six
+	assert.Equal(t, []int{3, 9, 12, 33, 41}, betPool(ranked, nil, final, 5))
+	// Fixed numbers come first
+	assert.Equal(t, []int{1, 3, 9, 12, 41}, betPool(ranked, []int{1}, final, 5))
+}
+
+func TestEnsemble_CoreNumbers(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.Register(NewFrequencyAnalyzer(3), 3))
+	require.NoError(t, registry.Register(NewHotColdAnalyzer(2), 2))
+	require.NoError(t, registry.Register(NewPatternAnalyzer(1), 1))
+	ensemble := NewEnsemble(registry, WeightedVoting)
+
+	predictions := []*entity.Prediction{
+		{AlgorithmName: "frequency_analysis", Numbers: valueobject.MustNewNumbers([]int{1, 2, 3, 4, 5, 6})},
+		{AlgorithmName: "hot_cold_analysis", Numbers: valueobject.MustNewNumbers([]int{1, 2, 3, 7, 8, 9})},
+		{AlgorithmName: "pattern_analysis", Numbers: valueobject.MustNewNumbers([]int{1, 10, 11, 12, 13, 14})},
+	}
+
+	// Votes: 1=6, 2-3=5, 4-6=3 out of 6 if every algorithm picked a number
+	_, ranked, err := ensemble.applyVotingStrategy(predictions, WeightedVoting, nil, newTieBreaker(TieBreakLow, nil), consensusRules{})
+	require.NoError(t, err)
+	core := coreNumbers(ranked, ensemble.countVotes(predictions, WeightedVoting), predictions, 4)
+	assert.Equal(t, []entity.CoreNumber{
+		{Number: 1, Confidence: 1},
+		{Number: 2, Confidence: 5.0 / 6},
+		{Number: 3, Confidence: 5.0 / 6},
+		{Number: 4, Confidence: 0.5},
+	}, core)
+
+	assert.Error(t, ensemble.SetCoreCount(-1))
+	assert.Error(t, ensemble.SetCoreCount(6))
+}
+
+func TestEnsemble_GeneratePredictions_Core(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.Register(NewFrequencyAnalyzer(1.0), 1.0))
+	require.NoError(t, registry.Register(NewHotColdAnalyzer(1.2), 1.2))
+	ensemble := NewEnsemble(registry, WeightedVoting)
+	draws := createMockDraws(valueobject.Mega645, 150)
+
+	prediction, err := ensemble.GeneratePredictions(context.Background(), valueobject.Mega645, draws)
+	require.NoError(t, err)
+	assert.Empty(t, prediction.Core)
+
+	require.NoError(t, ensemble.SetCoreCount(4))
+	prediction, err = ensemble.GeneratePredictions(context.Background(), valueobject.Mega645, draws)
+	require.NoError(t, err)
+
+	// The top four ranked numbers are the best four of the final six
+	require.Len(t, prediction.Core, 4)
+	for i, core := range prediction.Core {
+		assert.True(t, prediction.FinalNumbers.Contains(core.Number), "core %d not in the final six", core.Number)
+		assert.Greater(t, core.Confidence, 0.0)
+		assert.LessOrEqual(t, core.Confidence, 1.0)
+		if i > 0 {
+			assert.LessOrEqual(t, core.Confidence, prediction.Core[i-1].Confidence)
+		}
+	}
+}
+
+func TestEnsemble_PerNumberWeightedVoting(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.Register(NewFrequencyAnalyzer(3), 3))
+	require.NoError(t, registry.Register(NewHotColdAnalyzer(2), 2))
+	require.NoError(t, registry.Register(NewPatternAnalyzer(1), 1))
+	ensemble := NewEnsemble(registry, PerNumberWeighted)
+
+	predictions := []*entity.Prediction{
+		{AlgorithmName: "frequency_analysis", Numbers: valueobject.MustNewNumbers([]int{1, 2, 3, 4, 5, 6})},
+		{AlgorithmName: "hot_cold_analysis", Numbers: valueobject.MustNewNumbers([]int{1, 2, 3, 7, 8, 9})},
+		{AlgorithmName: "pattern_analysis", Numbers: valueobject.MustNewNumbers([]int{1, 10, 11, 12, 13, 14})},
+	}
+
+	// Without per-number weights it votes like WeightedVoting
+	final, _, err := ensemble.applyVotingStrategy(predictions, PerNumberWeighted, nil, nil, consensusRules{})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, final.AsSlice())
+
+	// pattern_analysis is ten times as reliable on 10-14 and frequency_analysis
+	// poor on 4-6: votes become 1=6, 10-14=10, 2-3=5, 7-9=2, 4-6=0.3
+	patternWeights := make([]float64, 45)
+	for i := range patternWeights {
+		patternWeights[i] = 1
+	}
+	for num := 10; num <= 14; num++ {
+		patternWeights[num-1] = 10
+	}
+	require.NoError(t, registry.SetPerNumberWeights("pattern_analysis", patternWeights))
+	require.NoError(t, registry.SetPerNumberWeights("frequency_analysis", []float64{1, 1, 1, 0.1, 0.1, 0.1}))
+
+	final, ranked, err := ensemble.applyVotingStrategy(predictions, PerNumberWeighted, nil, nil, consensusRules{})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 10, 11, 12, 13, 14}, final.AsSlice())
+	assert.Equal(t, []int{2, 3, 7, 8}, alternatesFrom(ranked, final, 4))
+
+	// Other strategies ignore per-number weights
+	final, _, err = ensemble.applyVotingStrategy(predictions, WeightedVoting, nil, nil, consensusRules{})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, final.AsSlice())
+}
+
+func TestEnsemble_ConfidenceMultipliers(t *testing.T) {
+	ensemble := NewEnsemble(NewRegistry(), ConfidenceWeighted)
+
+	newPredictions := func() []*entity.Prediction {
+		return []*entity.Prediction{
+			{AlgorithmName: "frequency_analysis", Confidence: 0.6, Numbers: valueobject.MustNewNumbers([]int{1, 2, 3, 4, 5, 6})},
+			{AlgorithmName: "pattern_analysis", Confidence: 0.5, Numbers: valueobject.MustNewNumbers([]int{1, 10, 11, 12, 13, 14})},
+		}
+	}
+
+	final, _, err := ensemble.applyVotingStrategy(newPredictions(), ConfidenceWeighted, nil, nil, consensusRules{})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, final.AsSlice())
+
+	// pattern_analysis agreed with past draws far more often
+	assert.Error(t, ensemble.SetConfidenceMultipliers(map[string]float64{"pattern_analysis": -1}))
+	require.NoError(t, ensemble.SetConfidenceMultipliers(map[string]float64{
+		"frequency_analysis": 0.5,
+		"pattern_analysis":   3,
+	}))
+
+	predictions := newPredictions()
+	applyConfidenceMultipliers(predictions, ensemble.multipliers)
+	assert.Equal(t, 0.3, predictions[0].Confidence)
+	assert.Equal(t, 1.0, predictions[1].Confidence) // Capped
+	assert.Equal(t, "3.000", predictions[1].Metadata[MetadataConfidenceMultiplier])
+
+	final, _, err = ensemble.applyVotingStrategy(predictions, ConfidenceWeighted, nil, nil, consensusRules{})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 10, 11, 12, 13, 14}, final.AsSlice())
+
+	require.NoError(t, ensemble.SetConfidenceMultipliers(nil))
+	assert.Nil(t, ensemble.multipliers)
+}
+
+func TestPerNumberReliability(t *testing.T) {
+	matches := make([]entity.PredictionMatch, 0, 20)
+	for i := 0; i < 20; i++ {
+		matches = append(matches, entity.PredictionMatch{
+			PredictedNumbers: valueobject.MustNewNumbers([]int{7, 8, 9, 10, 11, 12}),
+			ActualNumbers:    valueobject.MustNewNumbers([]int{7, 20, 21, 22, 23, 24}),
+		})
+	}
+
+	weights := PerNumberReliability(valueobject.Mega645, matches)
+	require.Len(t, weights, 45)
+
+	assert.Greater(t, weights[7-1], 1.0, "7 hit every time it was predicted")
+	assert.Less(t, weights[8-1], 1.0, "8 never hit")
+	assert.Equal(t, 1.0, weights[30-1], "30 was never predicted")
+	assert.Equal(t, weights[8-1], weights[12-1])
+
+	assert.Len(t, PerNumberReliability(valueobject.Power655, nil), 55)
+}
+
+func TestEnsemble_BlendKeepsMineAndVotesTheRest(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.Register(NewFrequencyAnalyzer(3), 3))
+	require.NoError(t, registry.Register(NewHotColdAnalyzer(2), 2))
+	require.NoError(t, registry.Register(NewPatternAnalyzer(1), 1))
+	ensemble := NewEnsemble(registry, WeightedVoting)
+
+	predictions := []*entity.Prediction{
+		{AlgorithmName: "frequency_analysis", Numbers: valueobject.MustNewNumbers([]int{1, 2, 3, 4, 5, 6})},
+		{AlgorithmName: "hot_cold_analysis", Numbers: valueobject.MustNewNumbers([]int{1, 2, 3, 7, 8, 9})},
+		{AlgorithmName: "pattern_analysis", Numbers: valueobject.MustNewNumbers([]int{1, 10, 11, 12, 13, 14})},
+	}
+
+	// Votes: 1=6, 2-3=5, 4-6=3, 7-9=2, 10-14=1. 7 is voted for, 21 is not;
+	// neither may take a slot away from the four best voted numbers.
+	final, ranked, err := ensemble.applyVotingStrategy(predictions, WeightedVoting, []int{21, 7}, nil, consensusRules{})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 7, 21}, final.AsSlice())
+	assert.Equal(t, []int{5, 6, 8, 9}, alternatesFrom(ranked, final, DefaultAlternateCount))
+
+	assert.Equal(t, map[string]string{
+		MetadataBlend:      "mine",
+		MetadataBlendMine:  "7,21",
+		MetadataBlendVoted: "1,2,3,4",
+	}, blendMetadata([]int{21, 7}, final))
+}
+
+func TestEnsemble_GenerateBlendedPredictions(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.Register(NewFrequencyAnalyzer(1.0), 1.0))
+	require.NoError(t, registry.Register(NewHotColdAnalyzer(1.2), 1.2))
+	ensemble := NewEnsemble(registry, WeightedVoting)
+	require.NoError(t, ensemble.SetAlternateCount(10))
+
+	ctx := context.Background()
+	draws := createMockDraws(valueobject.Mega645, 150)
+
+	plain, err := ensemble.GeneratePredictions(ctx, valueobject.Mega645, draws)
+	require.NoError(t, err)
+	assert.Empty(t, plain.Metadata)
+
+	mine := []int{7, 21}
+	blended, err := ensemble.GenerateBlendedPredictions(ctx, valueobject.Mega645, draws, mine)
+	require.NoError(t, err)
+
+	require.Len(t, blended.FinalNumbers, 6)
+	for _, num := range mine {
+		assert.True(t, blended.FinalNumbers.Contains(num), "your number %d is missing", num)
+	}
+	assert.Equal(t, "mine", blended.Metadata[MetadataBlend])
+	assert.Equal(t, "7,21", blended.Metadata[MetadataBlendMine])
+
+	// Every other slot comes from the top of the unblended vote ranking
+	candidates := append(plain.FinalNumbers.AsSlice(), plain.Alternates...)
+	for _, num := range blended.FinalNumbers {
+		if num != 7 && num != 21 {
+			assert.Contains(t, candidates, num)
+		}
+	}
+}
+
+func TestValidateMine(t *testing.T) {
+	assert.NoError(t, ValidateMine(valueobject.Mega645, []int{7, 21}))
+	assert.NoError(t, ValidateMine(valueobject.Power655, []int{1, 2, 3, 4, 55}))
+
+	assert.Error(t, ValidateMine(valueobject.Mega645, nil))
+	assert.Error(t, ValidateMine(valueobject.Mega645, []int{1, 2, 3, 4, 5, 6}))
+	assert.Error(t, ValidateMine(valueobject.Mega645, []int{7, 7}))
+	assert.Error(t, ValidateMine(valueobject.Mega645, []int{46}))
+}
+
 func TestEnsemble_ConsensusScore(t *testing.T) {
 	registry := NewRegistry()
 	analyzer1 := NewFrequencyAnalyzer(1.0)
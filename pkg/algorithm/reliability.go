@@ -0,0 +1,44 @@
+package algorithm
+
+import (
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// reliabilityPrior is how many neutral predictions each number starts with,
+// so numbers an algorithm rarely picked stay close to a multiplier of 1
+const reliabilityPrior = 10.0
+
+// PerNumberReliability learns per-number weights from backtest matches for
+// Registry.SetPerNumberWeights. The weight of number n is its hit rate when
+// predicted, the share of predictions containing n that it was drawn in,
+// relative to the hit rate of a random pick. 1 is average, 2 twice as
+// reliable. Hit rates are smoothed towards 1 with reliabilityPrior.
+func PerNumberReliability(gameType valueobject.GameType, matches []entity.PredictionMatch) []float64 {
+	minRange, maxRange := gameType.NumberRange()
+	if maxRange < 1 || minRange > maxRange {
+		return nil
+	}
+	baseline := float64(gameType.NumberCount()) / float64(maxRange-minRange+1)
+
+	predicted := make([]float64, maxRange)
+	hits := make([]float64, maxRange)
+	for _, match := range matches {
+		for _, num := range match.PredictedNumbers {
+			if num < 1 || num > maxRange {
+				continue
+			}
+			predicted[num-1]++
+			if match.ActualNumbers.Contains(num) {
+				hits[num-1]++
+			}
+		}
+	}
+
+	weights := make([]float64, maxRange)
+	for i := range weights {
+		hitRate := (hits[i] + reliabilityPrior*baseline) / (predicted[i] + reliabilityPrior)
+		weights[i] = hitRate / baseline
+	}
+	return weights
+}
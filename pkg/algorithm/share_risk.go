@@ -0,0 +1,219 @@
+package algorithm
+
+import (
+	"sort"
+
+	"github.com/tool_predict/internal/domain/entity"
+)
+
+// ShareRisk estimates how many other players are likely to pick the same
+// combination, and so how likely a jackpot on it would be split. It is
+// guidance about payouts, not about the odds of winning.
+type ShareRisk string
+
+const (
+	ShareRiskLow    ShareRisk = "low"
+	ShareRiskMedium ShareRisk = "medium"
+	ShareRiskHigh   ShareRisk = "high"
+)
+
+// shareRiskOrder ranks risk levels from highest to lowest
+var shareRiskOrder = map[ShareRisk]int{
+	ShareRiskHigh:   0,
+	ShareRiskMedium: 1,
+	ShareRiskLow:    2,
+}
+
+// CombinationShape is a popularity proxy: a kind of combination that many
+// players pick for non-random reasons
+type CombinationShape struct {
+	Name        string
+	Description string
+	Risk        ShareRisk
+
+	// matches reports whether sorted numbers have this shape. history holds
+	// the draws before the combination was played.
+	matches func(sorted []int, history []*entity.Draw) bool
+}
+
+// combinationShapes lists the known popularity proxies
+var combinationShapes = []CombinationShape{
+	{
+		Name:        "birthday",
+		Description: "all numbers 31 or below (dates of birth)",
+		Risk:        ShareRiskHigh,
+		matches: func(sorted []int, _ []*entity.Draw) bool {
+			return sorted[len(sorted)-1] <= 31
+		},
+	},
+	{
+		Name:        "arithmetic_sequence",
+		Description: "evenly spaced numbers (1-2-3-4-5-6, 5-10-15-20-25-30)",
+		Risk:        ShareRiskHigh,
+		matches: func(sorted []int, _ []*entity.Draw) bool {
+			step := sorted[1] - sorted[0]
+			for i := 2; i < len(sorted); i++ {
+				if sorted[i]-sorted[i-1] != step {
+					return false
+				}
+			}
+			return true
+		},
+	},
+	{
+		Name:        "past_winner",
+		Description: "repeats a previous winning combination",
+		Risk:        ShareRiskHigh,
+		matches: func(sorted []int, history []*entity.Draw) bool {
+			for _, draw := range history {
+				if len(draw.Numbers) == len(sorted) && draw.Numbers.MatchCount(sorted) == len(sorted) {
+					return true
+				}
+			}
+			return false
+		},
+	},
+	{
+		Name:        "consecutive_run",
+		Description: "a run of 4 or more consecutive numbers",
+		Risk:        ShareRiskMedium,
+		matches: func(sorted []int, _ []*entity.Draw) bool {
+			run := 1
+			for i := 1; i < len(sorted); i++ {
+				if sorted[i] == sorted[i-1]+1 {
+					run++
+					if run >= 4 {
+						return true
+					}
+				} else {
+					run = 1
+				}
+			}
+			return false
+		},
+	},
+	{
+		Name:        "common_multiple",
+		Description: "every number a multiple of the same 2-9",
+		Risk:        ShareRiskMedium,
+		matches: func(sorted []int, _ []*entity.Draw) bool {
+			for k := 2; k <= 9; k++ {
+				all := true
+				for _, num := range sorted {
+					if num%k != 0 {
+						all = false
+						break
+					}
+				}
+				if all {
+					return true
+				}
+			}
+			return false
+		},
+	},
+	{
+		Name:        "lucky_endings",
+		Description: "every number ending in 6, 8 or 9 (lucky digits)",
+		Risk:        ShareRiskMedium,
+		matches: func(sorted []int, _ []*entity.Draw) bool {
+			for _, num := range sorted {
+				switch num % 10 {
+				case 6, 8, 9:
+				default:
+					return false
+				}
+			}
+			return true
+		},
+	},
+}
+
+// CombinationShapes returns the known popularity proxies
+func CombinationShapes() []CombinationShape {
+	return append([]CombinationShape(nil), combinationShapes...)
+}
+
+// FlagShareRisk returns the shapes numbers match. history holds earlier
+// draws and may be nil.
+func FlagShareRisk(numbers []int, history []*entity.Draw) []CombinationShape {
+	if len(numbers) < 2 {
+		return nil
+	}
+
+	sorted := append([]int(nil), numbers...)
+	sort.Ints(sorted)
+
+	flagged := make([]CombinationShape, 0)
+	for _, shape := range combinationShapes {
+		if shape.matches(sorted, history) {
+			flagged = append(flagged, shape)
+		}
+	}
+	return flagged
+}
+
+// ShareRiskOf returns the highest risk among the shapes numbers match
+func ShareRiskOf(numbers []int, history []*entity.Draw) ShareRisk {
+	risk := ShareRiskLow
+	for _, shape := range FlagShareRisk(numbers, history) {
+		if shareRiskOrder[shape.Risk] < shareRiskOrder[risk] {
+			risk = shape.Risk
+		}
+	}
+	return risk
+}
+
+// ShapeReport describes how often a shape came up in past draws, i.e. how
+// often its jackpot would most likely have been shared
+type ShapeReport struct {
+	Shape       CombinationShape
+	Occurrences int
+	Draws       int
+	Rate        float64 // Occurrences / Draws
+}
+
+// AvoidReport checks every historical draw against each combination shape and
+// returns one report per shape, highest risk first, then most frequent first
+func AvoidReport(draws []*entity.Draw) []ShapeReport {
+	// Oldest first, so each draw is compared only against earlier ones
+	ordered := append([]*entity.Draw(nil), draws...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].DrawDate.Before(ordered[j].DrawDate)
+	})
+
+	reports := make([]ShapeReport, len(combinationShapes))
+	for i, shape := range combinationShapes {
+		reports[i] = ShapeReport{Shape: shape, Draws: len(ordered)}
+	}
+
+	for i, draw := range ordered {
+		if len(draw.Numbers) < 2 {
+			continue
+		}
+		sorted := append([]int(nil), draw.Numbers...)
+		sort.Ints(sorted)
+
+		for j, shape := range combinationShapes {
+			if shape.matches(sorted, ordered[:i]) {
+				reports[j].Occurrences++
+			}
+		}
+	}
+
+	for i := range reports {
+		if reports[i].Draws > 0 {
+			reports[i].Rate = float64(reports[i].Occurrences) / float64(reports[i].Draws)
+		}
+	}
+
+	sort.SliceStable(reports, func(i, j int) bool {
+		ri, rj := shareRiskOrder[reports[i].Shape.Risk], shareRiskOrder[reports[j].Shape.Risk]
+		if ri != rj {
+			return ri < rj
+		}
+		return reports[i].Rate > reports[j].Rate
+	})
+
+	return reports
+}
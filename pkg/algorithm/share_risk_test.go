@@ -0,0 +1,76 @@
+package algorithm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func shapeNames(shapes []CombinationShape) []string {
+	names := make([]string, len(shapes))
+	for i, shape := range shapes {
+		names[i] = shape.Name
+	}
+	return names
+}
+
+func TestFlagShareRisk_BirthdayRangeIsHighRisk(t *testing.T) {
+	numbers := []int{3, 7, 12, 19, 24, 31}
+
+	assert.Contains(t, shapeNames(FlagShareRisk(numbers, nil)), "birthday")
+	assert.Equal(t, ShareRiskHigh, ShareRiskOf(numbers, nil))
+}
+
+func TestFlagShareRisk_Shapes(t *testing.T) {
+	assert.Contains(t, shapeNames(FlagShareRisk([]int{5, 10, 15, 20, 25, 30}, nil)), "arithmetic_sequence")
+	assert.Contains(t, shapeNames(FlagShareRisk([]int{2, 20, 21, 22, 23, 44}, nil)), "consecutive_run")
+	assert.Contains(t, shapeNames(FlagShareRisk([]int{7, 14, 21, 35, 42, 49}, nil)), "common_multiple")
+	assert.Contains(t, shapeNames(FlagShareRisk([]int{6, 18, 29, 36, 48, 49}, nil)), "lucky_endings")
+
+	spread := []int{4, 17, 23, 38, 41, 52}
+	assert.Empty(t, FlagShareRisk(spread, nil))
+	assert.Equal(t, ShareRiskLow, ShareRiskOf(spread, nil))
+}
+
+func TestFlagShareRisk_PastWinner(t *testing.T) {
+	numbers := []int{4, 17, 23, 38, 41, 52}
+	past, err := entity.NewDraw(valueobject.Power655, 1, valueobject.MustNewNumbers(numbers), time.Now(), 0, 0)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"past_winner"}, shapeNames(FlagShareRisk(numbers, []*entity.Draw{past})))
+}
+
+func TestAvoidReport(t *testing.T) {
+	baseDate := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	combos := [][]int{
+		{3, 7, 12, 19, 24, 31},  // birthday
+		{4, 17, 23, 38, 41, 52}, // no shape
+		{1, 9, 14, 22, 28, 30},  // birthday
+		{4, 17, 23, 38, 41, 52}, // repeats the second draw
+	}
+	draws := make([]*entity.Draw, len(combos))
+	for i, combo := range combos {
+		draw, err := entity.NewDraw(valueobject.Power655, i+1, valueobject.MustNewNumbers(combo), baseDate.AddDate(0, 0, i), 0, 0)
+		require.NoError(t, err)
+		draws[i] = draw
+	}
+
+	reports := AvoidReport(draws)
+	require.Len(t, reports, len(CombinationShapes()))
+
+	byName := make(map[string]ShapeReport)
+	for _, report := range reports {
+		byName[report.Shape.Name] = report
+		assert.Equal(t, 4, report.Draws)
+	}
+	assert.Equal(t, 2, byName["birthday"].Occurrences)
+	assert.InDelta(t, 0.5, byName["birthday"].Rate, 1e-9)
+	assert.Equal(t, 1, byName["past_winner"].Occurrences)
+
+	// Highest risk first, most frequent first within a risk level
+	assert.Equal(t, "birthday", reports[0].Shape.Name)
+}
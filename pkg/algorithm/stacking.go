@@ -0,0 +1,147 @@
+package algorithm
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// Gradient descent settings of the stacking meta-model
+const (
+	stackingIterations   = 1000
+	stackingLearningRate = 1.0
+	stackingL2           = 0.001 // Pulls coefficients towards 0 so rarely seen algorithms don't dominate
+)
+
+// StackingModel is a logistic regression meta-model over the ensemble's
+// algorithms: from which algorithms picked a number, it predicts the
+// probability the number is drawn
+type StackingModel struct {
+	Bias    float64            `json:"bias"`
+	Weights map[string]float64 `json:"weights"` // Coefficient of each algorithm's pick, never negative
+	Samples int                `json:"samples"` // Number outcomes the model was trained on
+}
+
+// TrainStackingModel fits a StackingModel to backtest matches keyed by
+// algorithm name. Matches of different algorithms for the same draw are lined
+// up, and every number of every draw is one sample: a feature per algorithm,
+// 1 if it picked the number, labelled 1 if the number was drawn. Coefficients
+// are kept non-negative, so a pick never counts against a number.
+func TrainStackingModel(gameType valueobject.GameType, matches map[string][]entity.PredictionMatch) (*StackingModel, error) {
+	minRange, maxRange := gameType.NumberRange()
+	if maxRange < minRange {
+		return nil, fmt.Errorf("invalid number range for %s", gameType)
+	}
+
+	names := make([]string, 0, len(matches))
+	for name, m := range matches {
+		if len(m) > 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	// Line up each draw's actual numbers with every algorithm's picks
+	type stackingDraw struct {
+		actual valueobject.Numbers
+		picks  [][]int
+	}
+	draws := make(map[string]*stackingDraw)
+	for i, name := range names {
+		for _, match := range matches[name] {
+			key := match.ActualDrawDate.Format("2006-01-02")
+			draw, ok := draws[key]
+			if !ok {
+				draw = &stackingDraw{actual: match.ActualNumbers, picks: make([][]int, len(names))}
+				draws[key] = draw
+			}
+			draw.picks[i] = match.PredictedNumbers
+		}
+	}
+	if len(draws) == 0 {
+		return nil, fmt.Errorf("no backtest matches to train the stacking model on")
+	}
+
+	keys := make([]string, 0, len(draws))
+	for key := range draws {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var features [][]float64
+	var labels []float64
+	for _, key := range keys {
+		draw := draws[key]
+		for num := minRange; num <= maxRange; num++ {
+			x := make([]float64, len(names))
+			for i, picks := range draw.picks {
+				for _, pick := range picks {
+					if pick == num {
+						x[i] = 1
+						break
+					}
+				}
+			}
+			label := 0.0
+			if draw.actual.Contains(num) {
+				label = 1
+			}
+			features = append(features, x)
+			labels = append(labels, label)
+		}
+	}
+
+	// Start from the odds of a random number being drawn
+	base := float64(gameType.NumberCount()) / float64(maxRange-minRange+1)
+	bias := math.Log(base / (1 - base))
+	weights := make([]float64, len(names))
+
+	n := float64(len(features))
+	gradW := make([]float64, len(names))
+	for iter := 0; iter < stackingIterations; iter++ {
+		gradB := 0.0
+		clear(gradW)
+		for s, x := range features {
+			z := bias
+			for i, v := range x {
+				z += weights[i] * v
+			}
+			diff := sigmoid(z) - labels[s]
+			gradB += diff
+			for i, v := range x {
+				gradW[i] += diff * v
+			}
+		}
+
+		bias -= stackingLearningRate * gradB / n
+		for i := range weights {
+			grad := gradW[i]/n + stackingL2*weights[i]
+			weights[i] = math.Max(0, weights[i]-stackingLearningRate*grad)
+		}
+	}
+
+	model := &StackingModel{Bias: bias, Weights: make(map[string]float64, len(names)), Samples: len(features)}
+	for i, name := range names {
+		model.Weights[name] = weights[i]
+	}
+	return model, nil
+}
+
+// Probability returns the model's probability that a number picked by the
+// named algorithms is drawn. Algorithms the model wasn't trained on add
+// nothing.
+func (m *StackingModel) Probability(pickedBy []string) float64 {
+	z := m.Bias
+	for _, name := range pickedBy {
+		z += m.Weights[name]
+	}
+	return sigmoid(z)
+}
+
+// sigmoid is the logistic function
+func sigmoid(z float64) float64 {
+	return 1 / (1 + math.Exp(-z))
+}
@@ -0,0 +1,78 @@
+package algorithm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// stackingMatches backtests two algorithms over the same draws: "sharp"
+// always picks three of the drawn numbers, "blunt" never picks any
+func stackingMatches(draws int) map[string][]entity.PredictionMatch {
+	matches := make(map[string][]entity.PredictionMatch)
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < draws; i++ {
+		offset := i % 30
+		actual := valueobject.MustNewNumbers([]int{offset + 1, offset + 3, offset + 5, offset + 7, offset + 9, offset + 11})
+		date := start.AddDate(0, 0, i)
+		matches["sharp"] = append(matches["sharp"], entity.PredictionMatch{
+			PredictedNumbers: valueobject.MustNewNumbers([]int{offset + 1, offset + 3, offset + 5, offset + 2, offset + 4, offset + 6}),
+			ActualNumbers:    actual,
+			ActualDrawDate:   date,
+		})
+		matches["blunt"] = append(matches["blunt"], entity.PredictionMatch{
+			PredictedNumbers: valueobject.MustNewNumbers([]int{offset + 2, offset + 4, offset + 6, offset + 8, offset + 10, offset + 12}),
+			ActualNumbers:    actual,
+			ActualDrawDate:   date,
+		})
+	}
+	return matches
+}
+
+func TestTrainStackingModel(t *testing.T) {
+	model, err := TrainStackingModel(valueobject.Mega645, stackingMatches(60))
+	require.NoError(t, err)
+	assert.Equal(t, 60*45, model.Samples)
+
+	// Half of sharp's picks are drawn, none of blunt's
+	assert.Greater(t, model.Weights["sharp"], 1.0)
+	assert.Equal(t, 0.0, model.Weights["blunt"], "coefficients are never negative")
+
+	sharp := model.Probability([]string{"sharp"})
+	assert.InDelta(t, 0.5, sharp, 0.1)
+	assert.Equal(t, model.Probability(nil), model.Probability([]string{"blunt", "unknown"}))
+	assert.Less(t, model.Probability(nil), sharp)
+
+	_, err = TrainStackingModel(valueobject.Mega645, nil)
+	assert.Error(t, err)
+}
+
+func TestEnsemble_StackingVoting(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.Register(NewFrequencyAnalyzer(1), 1))
+	require.NoError(t, registry.Register(NewPatternAnalyzer(3), 3))
+	ensemble := NewEnsemble(registry, Stacking)
+
+	predictions := []*entity.Prediction{
+		{AlgorithmName: "frequency_analysis", Numbers: valueobject.MustNewNumbers([]int{1, 2, 3, 4, 5, 6})},
+		{AlgorithmName: "pattern_analysis", Numbers: valueobject.MustNewNumbers([]int{10, 11, 12, 13, 14, 15})},
+	}
+
+	// Without a model it votes like WeightedVoting
+	final, _, err := ensemble.applyVotingStrategy(predictions, Stacking, nil, nil, consensusRules{})
+	require.NoError(t, err)
+	assert.Equal(t, []int{10, 11, 12, 13, 14, 15}, final.AsSlice())
+
+	// The model trusts frequency_analysis over the heavier pattern_analysis
+	ensemble.SetStackingModel(&StackingModel{
+		Bias:    -2,
+		Weights: map[string]float64{"frequency_analysis": 1.5, "pattern_analysis": 0.2},
+	})
+	final, _, err = ensemble.applyVotingStrategy(predictions, Stacking, nil, nil, consensusRules{})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, final.AsSlice())
+}
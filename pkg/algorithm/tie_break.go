@@ -0,0 +1,80 @@
+package algorithm
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/tool_predict/internal/domain/entity"
+)
+
+// TieBreak decides the order of numbers with equal votes
+type TieBreak string
+
+const (
+	TieBreakLow  TieBreak = "low"  // Lower numbers first (default)
+	TieBreakHigh TieBreak = "high" // Higher numbers first
+	TieBreakHot  TieBreak = "hot"  // Most drawn in recent draws first
+	TieBreakCold TieBreak = "cold" // Least drawn in recent draws first
+)
+
+// tieBreakWindow is how many of the latest draws hot and cold tie-breaks
+// count appearances in
+const tieBreakWindow = 20
+
+// ParseTieBreak parses an ensemble.tie_break value. Empty means TieBreakLow.
+func ParseTieBreak(s string) (TieBreak, error) {
+	switch tb := TieBreak(s); tb {
+	case "":
+		return TieBreakLow, nil
+	case TieBreakLow, TieBreakHigh, TieBreakHot, TieBreakCold:
+		return tb, nil
+	default:
+		return "", fmt.Errorf("unknown tie break %q (expected %s, %s, %s or %s)",
+			s, TieBreakLow, TieBreakHigh, TieBreakHot, TieBreakCold)
+	}
+}
+
+// tieBreaker reports whether tied number a ranks before b
+type tieBreaker func(a, b int) bool
+
+// newTieBreaker returns a deterministic tieBreaker for tb. Hot and cold
+// count appearances in the latest tieBreakWindow draws of history and fall
+// back to the lower number when those are equal too.
+func newTieBreaker(tb TieBreak, history []*entity.Draw) tieBreaker {
+	switch tb {
+	case TieBreakHigh:
+		return func(a, b int) bool { return a > b }
+	case TieBreakHot, TieBreakCold:
+		counts := recentAppearances(history, tieBreakWindow)
+		hot := tb == TieBreakHot
+		return func(a, b int) bool {
+			if counts[a] != counts[b] {
+				return (counts[a] > counts[b]) == hot
+			}
+			return a < b
+		}
+	default:
+		return func(a, b int) bool { return a < b }
+	}
+}
+
+// recentAppearances counts how often each number was drawn in the latest
+// window draws, by draw date
+func recentAppearances(history []*entity.Draw, window int) map[int]int {
+	recent := make([]*entity.Draw, len(history))
+	copy(recent, history)
+	sort.SliceStable(recent, func(i, j int) bool {
+		return recent[i].DrawDate.After(recent[j].DrawDate)
+	})
+	if len(recent) > window {
+		recent = recent[:window]
+	}
+
+	counts := make(map[int]int)
+	for _, draw := range recent {
+		for _, num := range draw.Numbers {
+			counts[num]++
+		}
+	}
+	return counts
+}
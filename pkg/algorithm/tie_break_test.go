@@ -0,0 +1,95 @@
+package algorithm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// tieBreakHistory draws 20 three times and 40 once in the latest draws; 10
+// and 30 were only drawn long ago, outside the tie-break window
+func tieBreakHistory(t *testing.T) []*entity.Draw {
+	t.Helper()
+
+	start := time.Date(2026, 1, 1, 18, 0, 0, 0, time.UTC)
+	draws := make([]*entity.Draw, 0, tieBreakWindow+1)
+
+	// Oldest draw, pushed out of the window by the ones after it
+	old, err := entity.NewDraw(valueobject.Mega645, 1,
+		valueobject.MustNewNumbers([]int{10, 30, 41, 42, 43, 44}), start, 0, 0)
+	require.NoError(t, err)
+	draws = append(draws, old)
+
+	for i := 1; i <= tieBreakWindow; i++ {
+		nums := []int{1, 2, 3, 4, 5, 6}
+		switch {
+		case i <= 3:
+			nums[0] = 20
+		case i == 4:
+			nums[0] = 40
+		}
+		draw, err := entity.NewDraw(valueobject.Mega645, i+1,
+			valueobject.MustNewNumbers(nums), start.AddDate(0, 0, 2*i), 0, 0)
+		require.NoError(t, err)
+		draws = append(draws, draw)
+	}
+	return draws
+}
+
+func TestEnsemble_TieBreak(t *testing.T) {
+	history := tieBreakHistory(t)
+
+	// 7-11 lead; 10, 20, 30 and 40 tie for the last slot
+	predictions := []*entity.Prediction{
+		{AlgorithmName: "frequency_analysis", Numbers: valueobject.MustNewNumbers([]int{7, 8, 9, 11, 12, 10})},
+		{AlgorithmName: "hot_cold_analysis", Numbers: valueobject.MustNewNumbers([]int{7, 8, 9, 11, 12, 20})},
+		{AlgorithmName: "pattern_analysis", Numbers: valueobject.MustNewNumbers([]int{7, 8, 9, 11, 12, 30})},
+		{AlgorithmName: "random", Numbers: valueobject.MustNewNumbers([]int{7, 8, 9, 11, 12, 40})},
+	}
+
+	tests := []struct {
+		tieBreak TieBreak
+		want     int
+		ranked   []int
+	}{
+		{TieBreakLow, 10, []int{10, 20, 30, 40}},
+		{TieBreakHigh, 40, []int{40, 30, 20, 10}},
+		{TieBreakHot, 20, []int{20, 40, 10, 30}},
+		{TieBreakCold, 10, []int{10, 30, 40, 20}},
+	}
+
+	ensemble := NewEnsemble(NewRegistry(), MajorityVoting)
+	for _, tt := range tests {
+		t.Run(string(tt.tieBreak), func(t *testing.T) {
+			final, ranked, err := ensemble.applyVotingStrategy(predictions, MajorityVoting, nil,
+				newTieBreaker(tt.tieBreak, history), consensusRules{})
+			require.NoError(t, err)
+
+			assert.ElementsMatch(t, []int{7, 8, 9, 11, 12}, ranked[:5])
+			assert.Equal(t, tt.ranked, ranked[5:])
+			assert.True(t, final.Contains(tt.want))
+		})
+	}
+}
+
+func TestParseTieBreak(t *testing.T) {
+	tb, err := ParseTieBreak("")
+	require.NoError(t, err)
+	assert.Equal(t, TieBreakLow, tb)
+
+	tb, err = ParseTieBreak("cold")
+	require.NoError(t, err)
+	assert.Equal(t, TieBreakCold, tb)
+
+	_, err = ParseTieBreak("lucky")
+	assert.Error(t, err)
+
+	ensemble := NewEnsemble(NewRegistry(), WeightedVoting)
+	assert.Error(t, ensemble.SetTieBreak("lucky"))
+	require.NoError(t, ensemble.SetTieBreak(TieBreakHot))
+	assert.Equal(t, TieBreakHot, ensemble.tieBreak)
+}
@@ -0,0 +1,181 @@
+package algorithm
+
+import (
+	"fmt"
+	"math/bits"
+	"slices"
+	"sort"
+
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// WheelGuarantee is what an abbreviated wheel promises: some ticket matches
+// at least m of the winning numbers whenever n of them are among the wheel's
+// candidates ("m if n")
+type WheelGuarantee string
+
+const (
+	Wheel3If4 WheelGuarantee = "3if4" // 3 matched on a ticket if 4 are among the candidates (default)
+	Wheel4If5 WheelGuarantee = "4if5" // 4 matched on a ticket if 5 are among the candidates
+)
+
+// MaxWheelCandidates bounds how many numbers a wheel covers; the search
+// grows combinatorially with them
+const MaxWheelCandidates = RankedPoolSize
+
+// ParseWheelGuarantee parses a wheel guarantee. Empty means Wheel3If4.
+func ParseWheelGuarantee(s string) (WheelGuarantee, error) {
+	switch g := WheelGuarantee(s); g {
+	case "":
+		return Wheel3If4, nil
+	case Wheel3If4, Wheel4If5:
+		return g, nil
+	default:
+		return "", fmt.Errorf("unknown wheel guarantee %q (expected %s or %s)", s, Wheel3If4, Wheel4If5)
+	}
+}
+
+// matchIf returns the guarantee's matched and drawn counts
+func (g WheelGuarantee) matchIf() (match, drawn int) {
+	if g == Wheel4If5 {
+		return 4, 5
+	}
+	return 3, 4
+}
+
+// WheelGenerator builds abbreviated wheels: fewer tickets than playing every
+// line of the candidates, chosen greedily so the guarantee still holds
+type WheelGenerator struct {
+	guarantee WheelGuarantee
+	lineSize  int
+}
+
+// NewWheelGenerator creates a wheel generator for tickets of lineSize numbers
+func NewWheelGenerator(guarantee WheelGuarantee, lineSize int) (*WheelGenerator, error) {
+	g, err := ParseWheelGuarantee(string(guarantee))
+	if err != nil {
+		return nil, err
+	}
+	if _, drawn := g.matchIf(); lineSize < drawn {
+		return nil, fmt.Errorf("tickets of %d numbers can't carry a %s guarantee", lineSize, g)
+	}
+	return &WheelGenerator{guarantee: g, lineSize: lineSize}, nil
+}
+
+// Generate returns the tickets of an abbreviated wheel over candidates, each
+// ascending. Every candidate set of the guarantee's drawn size shares the
+// guaranteed count with some ticket. The greedy cover isn't always the
+// smallest possible, but the same candidates always give the same tickets.
+func (wg *WheelGenerator) Generate(candidates []int) ([][]int, error) {
+	n := len(candidates)
+	if n <= wg.lineSize || n > MaxWheelCandidates {
+		return nil, fmt.Errorf("a wheel needs %d to %d candidates, got %d", wg.lineSize+1, MaxWheelCandidates, n)
+	}
+	sorted := slices.Clone(candidates)
+	sort.Ints(sorted)
+	if len(slices.Compact(slices.Clone(sorted))) != n {
+		return nil, fmt.Errorf("wheel candidates must be distinct, got %v", candidates)
+	}
+
+	match, drawn := wg.guarantee.matchIf()
+	lines := combinationMasks(n, wg.lineSize)
+	targets := combinationMasks(n, drawn)
+
+	// covers[i] lists the targets line i guarantees
+	covers := make([][]int, len(lines))
+	for i, line := range lines {
+		for j, target := range targets {
+			if bits.OnesCount32(line&target) >= match {
+				covers[i] = append(covers[i], j)
+			}
+		}
+	}
+
+	covered := make([]bool, len(targets))
+	remaining := len(targets)
+	var tickets [][]int
+	for remaining > 0 {
+		best, bestGain := -1, 0
+		for i, targetIdxs := range covers {
+			gain := 0
+			for _, j := range targetIdxs {
+				if !covered[j] {
+					gain++
+				}
+			}
+			if gain > bestGain {
+				best, bestGain = i, gain
+			}
+		}
+
+		for _, j := range covers[best] {
+			if !covered[j] {
+				covered[j] = true
+				remaining--
+			}
+		}
+		tickets = append(tickets, maskNumbers(lines[best], sorted))
+	}
+	return tickets, nil
+}
+
+// Widest wheels the most best ranked numbers whose wheel fits in maxTickets.
+// Candidates are taken like a bao bet's pool: the final numbers, then the
+// rest of ranked in order.
+func (wg *WheelGenerator) Widest(ranked []int, final valueobject.Numbers, maxTickets int) (*entity.Wheel, error) {
+	if maxTickets < 1 {
+		return nil, fmt.Errorf("a wheel needs at least 1 ticket, got %d", maxTickets)
+	}
+
+	var widest *entity.Wheel
+	for size := wg.lineSize + 1; size <= min(len(ranked), MaxWheelCandidates); size++ {
+		candidates := betPool(ranked, nil, final, size)
+		tickets, err := wg.Generate(candidates)
+		if err != nil {
+			return nil, err
+		}
+		if len(tickets) > maxTickets {
+			break
+		}
+		widest = &entity.Wheel{
+			Guarantee:  string(wg.guarantee),
+			Candidates: candidates,
+			Tickets:    tickets,
+		}
+	}
+	if widest == nil {
+		return nil, fmt.Errorf("need more than %d ranked numbers to wheel, got %d", wg.lineSize, len(ranked))
+	}
+	return widest, nil
+}
+
+// combinationMasks returns every k-of-n combination as a bit mask, in
+// ascending order
+func combinationMasks(n, k int) []uint32 {
+	var masks []uint32
+	if k == 0 || k > n {
+		return masks
+	}
+	limit := uint32(1) << n
+	// Gosper's hack steps to the next larger mask with the same bit count
+	for mask := uint32(1)<<k - 1; mask < limit; {
+		masks = append(masks, mask)
+		low := mask & -mask
+		ripple := mask + low
+		mask = ripple | ((mask^ripple)>>2)/low
+	}
+	return masks
+}
+
+// maskNumbers returns the numbers whose index in sorted is set in mask,
+// ascending
+func maskNumbers(mask uint32, sorted []int) []int {
+	nums := make([]int, 0, bits.OnesCount32(mask))
+	for i, num := range sorted {
+		if mask&(1<<i) != 0 {
+			nums = append(nums, num)
+		}
+	}
+	return nums
+}
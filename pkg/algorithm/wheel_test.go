@@ -0,0 +1,120 @@
+package algorithm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// assertWheelGuarantee checks every drawn-size subset of candidates against
+// the tickets
+func assertWheelGuarantee(t *testing.T, guarantee WheelGuarantee, candidates []int, tickets [][]int) {
+	t.Helper()
+	match, drawn := guarantee.matchIf()
+	for _, mask := range combinationMasks(len(candidates), drawn) {
+		subset := maskNumbers(mask, candidates)
+		best := 0
+		for _, ticket := range tickets {
+			shared := 0
+			for _, num := range ticket {
+				for _, s := range subset {
+					if num == s {
+						shared++
+					}
+				}
+			}
+			best = max(best, shared)
+		}
+		require.GreaterOrEqual(t, best, match, "%s broken for %v", guarantee, subset)
+	}
+}
+
+func TestWheelGenerator_Guarantees(t *testing.T) {
+	for _, guarantee := range []WheelGuarantee{Wheel3If4, Wheel4If5} {
+		generator, err := NewWheelGenerator(guarantee, 6)
+		require.NoError(t, err)
+
+		for size := 7; size <= 12; size++ {
+			candidates := make([]int, size)
+			for i := range candidates {
+				candidates[i] = 3*i + 1
+			}
+
+			tickets, err := generator.Generate(candidates)
+			require.NoError(t, err)
+			assertWheelGuarantee(t, guarantee, candidates, tickets)
+
+			// Abbreviated: fewer tickets than every line of the candidates
+			full := valueobject.BetType(size).Lines(valueobject.Mega645)
+			if size > 7 {
+				assert.Less(t, len(tickets), full, "%s over %d", guarantee, size)
+			}
+			for _, ticket := range tickets {
+				assert.Len(t, ticket, 6)
+				assert.IsIncreasing(t, ticket)
+			}
+		}
+	}
+}
+
+func TestWheelGenerator_Deterministic(t *testing.T) {
+	generator, err := NewWheelGenerator(Wheel3If4, 6)
+	require.NoError(t, err)
+
+	first, err := generator.Generate([]int{44, 3, 17, 28, 9, 35, 12, 21, 40})
+	require.NoError(t, err)
+	again, err := generator.Generate([]int{3, 9, 12, 17, 21, 28, 35, 40, 44})
+	require.NoError(t, err)
+	assert.Equal(t, first, again)
+}
+
+func TestWheelGenerator_WidestFitsTickets(t *testing.T) {
+	generator, err := NewWheelGenerator(Wheel3If4, 6)
+	require.NoError(t, err)
+
+	ranked := []int{5, 12, 33, 1, 40, 18, 7, 29, 44, 2, 21, 36, 9, 15, 27, 30}
+	final := valueobject.MustNewNumbers([]int{1, 5, 12, 18, 33, 40})
+
+	wheel, err := generator.Widest(ranked, final, 5)
+	require.NoError(t, err)
+	assert.Equal(t, "3if4", wheel.Guarantee)
+	assert.LessOrEqual(t, len(wheel.Tickets), 5)
+	assert.Greater(t, len(wheel.Candidates), 7)
+	// The final six come first, then the ranking
+	assert.Subset(t, wheel.Candidates, final.AsSlice())
+	assert.Contains(t, wheel.Candidates, 7)
+	assertWheelGuarantee(t, Wheel3If4, wheel.Candidates, wheel.Tickets)
+
+	// One more candidate would need more tickets
+	tickets, err := generator.Generate(betPool(ranked, nil, final, len(wheel.Candidates)+1))
+	require.NoError(t, err)
+	assert.Greater(t, len(tickets), 5)
+
+	// A single ticket still wheels seven numbers
+	wheel, err = generator.Widest(ranked, final, 1)
+	require.NoError(t, err)
+	assert.Len(t, wheel.Candidates, 7)
+	assert.Len(t, wheel.Tickets, 1)
+}
+
+func TestWheelGenerator_Validation(t *testing.T) {
+	_, err := NewWheelGenerator("2if3", 6)
+	assert.Error(t, err)
+	_, err = NewWheelGenerator(Wheel4If5, 4)
+	assert.Error(t, err)
+
+	guarantee, err := ParseWheelGuarantee("")
+	require.NoError(t, err)
+	assert.Equal(t, Wheel3If4, guarantee)
+
+	generator, err := NewWheelGenerator(Wheel3If4, 6)
+	require.NoError(t, err)
+	_, err = generator.Generate([]int{1, 2, 3, 4, 5, 6})
+	assert.Error(t, err, "no more candidates than a ticket")
+	_, err = generator.Generate([]int{1, 2, 3, 4, 5, 6, 6})
+	assert.Error(t, err, "duplicates")
+	_, err = generator.Widest([]int{1, 2, 3, 4, 5, 6, 7}, valueobject.MustNewNumbers([]int{1, 2, 3, 4, 5, 6}), 0)
+	assert.Error(t, err)
+}
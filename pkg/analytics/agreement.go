@@ -0,0 +1,120 @@
+package analytics
+
+import (
+	"time"
+
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// agreementPrior is how many average outcomes each algorithm starts with, so
+// a few lucky or unlucky draws don't swing its score far from 1
+const agreementPrior = 10.0
+
+// agreementKey identifies an algorithm's outcomes for one game
+type agreementKey struct {
+	algorithm string
+	gameType  valueobject.GameType
+}
+
+// agreementTally accumulates an algorithm's matched numbers over outcomes
+type agreementTally struct {
+	outcomes int
+	matches  int
+}
+
+// AgreementIndex scores how often each algorithm historically agreed with
+// the winning numbers. Scores are computed once, when the index is built.
+type AgreementIndex struct {
+	scores   map[agreementKey]float64
+	outcomes map[agreementKey]int
+}
+
+// NewAgreementIndex builds an index from stored ensemble predictions and the
+// draws they targeted. An algorithm's prediction becomes an outcome when a
+// draw of the same game took place on the ensemble's ForDate; predictions
+// for draws not yet stored are ignored.
+func NewAgreementIndex(ensembles []*entity.EnsemblePrediction, draws []*entity.Draw) *AgreementIndex {
+	type drawKey struct {
+		gameType valueobject.GameType
+		day      time.Time
+	}
+	drawn := make(map[drawKey]valueobject.Numbers, len(draws))
+	for _, draw := range draws {
+		drawn[drawKey{draw.GameType, calendarDay(draw.DrawDate)}] = draw.Numbers
+	}
+
+	tallies := make(map[agreementKey]*agreementTally)
+	for _, ensemble := range ensembles {
+		if ensemble.ForDate.IsZero() {
+			continue
+		}
+		actual, ok := drawn[drawKey{ensemble.GameType, calendarDay(ensemble.ForDate)}]
+		if !ok {
+			continue
+		}
+
+		for _, pred := range ensemble.Predictions {
+			key := agreementKey{pred.AlgorithmName, ensemble.GameType}
+			tally, ok := tallies[key]
+			if !ok {
+				tally = &agreementTally{}
+				tallies[key] = tally
+			}
+			tally.outcomes++
+			tally.matches += pred.Numbers.MatchCount(actual)
+		}
+	}
+
+	index := &AgreementIndex{
+		scores:   make(map[agreementKey]float64, len(tallies)),
+		outcomes: make(map[agreementKey]int, len(tallies)),
+	}
+	for key, tally := range tallies {
+		index.scores[key] = agreementScore(key.gameType, tally)
+		index.outcomes[key] = tally.outcomes
+	}
+	return index
+}
+
+// agreementScore compares the average numbers matched per outcome with a
+// random pick's, smoothed towards 1 with agreementPrior
+func agreementScore(gameType valueobject.GameType, tally *agreementTally) float64 {
+	minRange, maxRange := gameType.NumberRange()
+	count := float64(gameType.NumberCount())
+	expected := count * count / float64(maxRange-minRange+1)
+	if expected <= 0 {
+		return 1
+	}
+
+	average := (float64(tally.matches) + agreementPrior*expected) / (float64(tally.outcomes) + agreementPrior)
+	return average / expected
+}
+
+// AgreementScore returns how well algorithmName agreed with past winning
+// numbers for gameType relative to a random pick: 1 is random, 1.5 matched
+// half as many numbers again. Algorithms without outcomes score 1.
+func (a *AgreementIndex) AgreementScore(algorithmName string, gameType valueobject.GameType) float64 {
+	if score, ok := a.scores[agreementKey{algorithmName, gameType}]; ok {
+		return score
+	}
+	return 1
+}
+
+// Outcomes returns how many predictions of algorithmName for gameType were
+// scored against a draw
+func (a *AgreementIndex) Outcomes(algorithmName string, gameType valueobject.GameType) int {
+	return a.outcomes[agreementKey{algorithmName, gameType}]
+}
+
+// Scores returns the agreement score of every algorithm with outcomes for
+// gameType, keyed by algorithm name
+func (a *AgreementIndex) Scores(gameType valueobject.GameType) map[string]float64 {
+	scores := make(map[string]float64)
+	for key, score := range a.scores {
+		if key.gameType == gameType {
+			scores[key.algorithm] = score
+		}
+	}
+	return scores
+}
@@ -0,0 +1,71 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// agreementHistory builds count draws of 1-6 with an ensemble targeting
+// each, in which "sharp" predicted three winning numbers and "dull" none
+func agreementHistory(t *testing.T, count int) ([]*entity.EnsemblePrediction, []*entity.Draw) {
+	t.Helper()
+
+	start := time.Date(2026, 1, 2, 18, 0, 0, 0, time.UTC)
+	draws := make([]*entity.Draw, 0, count)
+	ensembles := make([]*entity.EnsemblePrediction, 0, count)
+	for i := 0; i < count; i++ {
+		date := start.AddDate(0, 0, 2*i)
+		draw, err := entity.NewDraw(valueobject.Mega645, i+1,
+			valueobject.MustNewNumbers([]int{1, 2, 3, 4, 5, 6}), date, 0, 0)
+		require.NoError(t, err)
+		draws = append(draws, draw)
+
+		ensembles = append(ensembles, &entity.EnsemblePrediction{
+			GameType: valueobject.Mega645,
+			// Ensembles target the draw day at midnight
+			ForDate: time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC),
+			Predictions: []*entity.Prediction{
+				{AlgorithmName: "sharp", Numbers: valueobject.MustNewNumbers([]int{1, 2, 3, 40, 41, 42})},
+				{AlgorithmName: "dull", Numbers: valueobject.MustNewNumbers([]int{20, 21, 22, 23, 24, 25})},
+			},
+		})
+	}
+	return ensembles, draws
+}
+
+func TestAgreementIndex_ScoresAgainstRandomBaseline(t *testing.T) {
+	ensembles, draws := agreementHistory(t, 20)
+
+	index := NewAgreementIndex(ensembles, draws)
+
+	// A random Mega 6/45 pick matches 36/45 = 0.8 numbers on average.
+	// sharp: (20*3 + 10*0.8) / (30*0.8); dull: (0 + 10*0.8) / (30*0.8)
+	assert.InDelta(t, 68.0/24.0, index.AgreementScore("sharp", valueobject.Mega645), 1e-9)
+	assert.InDelta(t, 8.0/24.0, index.AgreementScore("dull", valueobject.Mega645), 1e-9)
+	assert.Equal(t, 20, index.Outcomes("sharp", valueobject.Mega645))
+
+	scores := index.Scores(valueobject.Mega645)
+	assert.Len(t, scores, 2)
+	assert.Greater(t, scores["sharp"], 1.0)
+	assert.Less(t, scores["dull"], 1.0)
+}
+
+func TestAgreementIndex_NeutralWithoutOutcomes(t *testing.T) {
+	ensembles, draws := agreementHistory(t, 5)
+
+	// Predictions for a draw not stored yet, or with no target date, aren't outcomes
+	ensembles[0].ForDate = ensembles[0].ForDate.AddDate(1, 0, 0)
+	ensembles[1].ForDate = time.Time{}
+
+	index := NewAgreementIndex(ensembles, draws)
+	assert.Equal(t, 3, index.Outcomes("sharp", valueobject.Mega645))
+
+	assert.Equal(t, 1.0, index.AgreementScore("unknown", valueobject.Mega645))
+	assert.Equal(t, 1.0, index.AgreementScore("sharp", valueobject.Power655))
+	assert.Empty(t, NewAgreementIndex(nil, draws).Scores(valueobject.Mega645))
+}
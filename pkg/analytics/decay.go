@@ -0,0 +1,52 @@
+package analytics
+
+import (
+	"math"
+	"sort"
+
+	"github.com/tool_predict/internal/domain/entity"
+)
+
+// DecayWeights returns n per-draw weights for exponential recency decay.
+// Index 0 is the most recent draw with weight 1; a draw halfLife draws older
+// weighs half as much. A non-positive halfLife disables decay and every
+// weight is 1.
+func DecayWeights(n int, halfLife float64) []float64 {
+	if n <= 0 {
+		return []float64{}
+	}
+
+	weights := make([]float64, n)
+	for i := range weights {
+		if halfLife <= 0 {
+			weights[i] = 1
+			continue
+		}
+		weights[i] = math.Exp2(-float64(i) / halfLife)
+	}
+	return weights
+}
+
+// RecencyWeights returns DecayWeights for draws, aligned with the draws
+// slice whatever its order: the draw with the latest DrawDate weighs 1.
+// Draws on the same date are ranked by draw number.
+func RecencyWeights(draws []*entity.Draw, halfLife float64) []float64 {
+	order := make([]int, len(draws))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := draws[order[i]], draws[order[j]]
+		if !a.DrawDate.Equal(b.DrawDate) {
+			return a.DrawDate.After(b.DrawDate)
+		}
+		return a.DrawNumber > b.DrawNumber
+	})
+
+	decay := DecayWeights(len(draws), halfLife)
+	weights := make([]float64, len(draws))
+	for age, index := range order {
+		weights[index] = decay[age]
+	}
+	return weights
+}
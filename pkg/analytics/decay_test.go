@@ -0,0 +1,50 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func TestDecayWeights_HalfAtHalfLife(t *testing.T) {
+	weights := DecayWeights(30, 10)
+	require.Len(t, weights, 30)
+
+	assert.Equal(t, 1.0, weights[0])
+	assert.Equal(t, 0.5, weights[10])
+	assert.Equal(t, 0.25, weights[20])
+	for i := 1; i < len(weights); i++ {
+		assert.Less(t, weights[i], weights[i-1])
+	}
+
+	// Fractional half-lives too: 3 draws ago is two half-lives of 1.5
+	assert.Equal(t, 0.25, DecayWeights(4, 1.5)[3])
+}
+
+func TestDecayWeights_Disabled(t *testing.T) {
+	assert.Equal(t, []float64{1, 1, 1}, DecayWeights(3, 0))
+	assert.Equal(t, []float64{1, 1}, DecayWeights(2, -5))
+	assert.Empty(t, DecayWeights(0, 10))
+}
+
+func TestRecencyWeights_FollowsDrawDates(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	newDraw := func(number, day int) *entity.Draw {
+		draw, err := entity.NewDraw(valueobject.Mega645, number, valueobject.MustNewNumbers([]int{1, 2, 3, 4, 5, 6}),
+			base.AddDate(0, 0, day), 0, 0)
+		require.NoError(t, err)
+		return draw
+	}
+
+	// Oldest first, as in chronological history
+	draws := []*entity.Draw{newDraw(1, 0), newDraw(2, 2), newDraw(3, 4)}
+	assert.Equal(t, []float64{0.25, 0.5, 1}, RecencyWeights(draws, 1))
+
+	// Newest first, as returned by storage
+	draws = []*entity.Draw{draws[2], draws[1], draws[0]}
+	assert.Equal(t, []float64{1, 0.5, 0.25}, RecencyWeights(draws, 1))
+}
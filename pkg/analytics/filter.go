@@ -0,0 +1,27 @@
+// Package analytics provides reusable helpers for slicing and summarizing
+// draw history before it is analyzed
+package analytics
+
+import "github.com/tool_predict/internal/domain/entity"
+
+// NoMaxWinners disables the upper bound of FilterByWinners
+const NoMaxWinners = -1
+
+// FilterByWinners returns the draws whose jackpot winner count lies within
+// [minWinners, maxWinners], keeping their order. Pass NoMaxWinners to leave
+// the upper bound open, e.g. (1, NoMaxWinners) keeps jackpot-winning draws and
+// (0, 0) keeps rollover draws. Results are only meaningful if Winners was
+// populated by the scraper.
+func FilterByWinners(draws []*entity.Draw, minWinners, maxWinners int) []*entity.Draw {
+	filtered := make([]*entity.Draw, 0, len(draws))
+	for _, draw := range draws {
+		if draw.Winners < minWinners {
+			continue
+		}
+		if maxWinners != NoMaxWinners && draw.Winners > maxWinners {
+			continue
+		}
+		filtered = append(filtered, draw)
+	}
+	return filtered
+}
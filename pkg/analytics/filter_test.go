@@ -0,0 +1,66 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func createWinnerDraws(t *testing.T, winners ...int) []*entity.Draw {
+	t.Helper()
+
+	draws := make([]*entity.Draw, len(winners))
+	for i, w := range winners {
+		draw, err := entity.NewDraw(
+			valueobject.Mega645,
+			i+1,
+			valueobject.MustNewNumbers([]int{1, 2, 3, 4, 5, 6}),
+			time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, i),
+			0,
+			w,
+		)
+		require.NoError(t, err)
+		draws[i] = draw
+	}
+	return draws
+}
+
+func drawNumbers(draws []*entity.Draw) []int {
+	nums := make([]int, len(draws))
+	for i, draw := range draws {
+		nums[i] = draw.DrawNumber
+	}
+	return nums
+}
+
+func TestFilterByWinners(t *testing.T) {
+	draws := createWinnerDraws(t, 0, 1, 0, 3, 2, 0)
+
+	tests := []struct {
+		name       string
+		minWinners int
+		maxWinners int
+		want       []int
+	}{
+		{"jackpot winners", 1, NoMaxWinners, []int{2, 4, 5}},
+		{"rollovers", 0, 0, []int{1, 3, 6}},
+		{"shared jackpots", 2, NoMaxWinners, []int{4, 5}},
+		{"bounded range", 1, 2, []int{2, 5}},
+		{"everything", 0, NoMaxWinners, []int{1, 2, 3, 4, 5, 6}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, drawNumbers(FilterByWinners(draws, tt.minWinners, tt.maxWinners)))
+		})
+	}
+}
+
+func TestFilterByWinners_Empty(t *testing.T) {
+	assert.Empty(t, FilterByWinners(nil, 1, NoMaxWinners))
+	assert.Empty(t, FilterByWinners(createWinnerDraws(t, 0, 0), 1, NoMaxWinners))
+}
@@ -0,0 +1,48 @@
+package analytics
+
+import (
+	"sort"
+
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// NumberFrequency is how often a number was drawn
+type NumberFrequency struct {
+	Number    int
+	Count     int
+	Rate      float64 // Count / draws
+	LastDrawn int     // Number of the latest draw it was in; 0 if never drawn
+}
+
+// FrequencyTable counts how often each number of gameType's pool was drawn
+// in draws. Numbers are ordered by count, most drawn first, then by number.
+func FrequencyTable(draws []*entity.Draw, gameType valueobject.GameType) []NumberFrequency {
+	minRange, maxRange := gameType.NumberRange()
+	table := make([]NumberFrequency, 0, maxRange-minRange+1)
+	for num := minRange; num <= maxRange; num++ {
+		table = append(table, NumberFrequency{Number: num})
+	}
+
+	for _, draw := range draws {
+		for _, num := range draw.Numbers {
+			if num < minRange || num > maxRange {
+				continue
+			}
+			entry := &table[num-minRange]
+			entry.Count++
+			entry.LastDrawn = max(entry.LastDrawn, draw.DrawNumber)
+		}
+	}
+
+	if len(draws) > 0 {
+		for i := range table {
+			table[i].Rate = float64(table[i].Count) / float64(len(draws))
+		}
+	}
+
+	sort.SliceStable(table, func(i, j int) bool {
+		return table[i].Count > table[j].Count
+	})
+	return table
+}
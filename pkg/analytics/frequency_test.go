@@ -0,0 +1,30 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func TestFrequencyTable(t *testing.T) {
+	draws := make([]*entity.Draw, 0, 2)
+	for i, nums := range [][]int{{1, 2, 3, 4, 5, 6}, {1, 2, 3, 40, 41, 42}} {
+		draw, err := entity.NewDraw(valueobject.Mega645, 100+i, valueobject.MustNewNumbers(nums),
+			time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, 2*i), 0, 0)
+		require.NoError(t, err)
+		draws = append(draws, draw)
+	}
+
+	table := FrequencyTable(draws, valueobject.Mega645)
+	require.Len(t, table, 45)
+	assert.Equal(t, NumberFrequency{Number: 1, Count: 2, Rate: 1, LastDrawn: 101}, table[0])
+	assert.Equal(t, []int{1, 2, 3}, []int{table[0].Number, table[1].Number, table[2].Number})
+	assert.Equal(t, NumberFrequency{Number: 4, Count: 1, Rate: 0.5, LastDrawn: 100}, table[3])
+	assert.Equal(t, NumberFrequency{Number: 7}, table[9])
+
+	assert.Zero(t, FrequencyTable(nil, valueobject.Mega645)[0].Count)
+}
@@ -0,0 +1,71 @@
+package analytics
+
+import (
+	"sort"
+	"time"
+
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// FindDateGaps returns the scheduled draw dates (per GameType.DrawDays)
+// between the earliest and latest stored draws that have no stored draw.
+// Dates are compared by calendar day in each draw's own location and are
+// returned as midnight UTC, ascending. Vietlott skips some scheduled days
+// (e.g. over Tết), so a gap is a prompt to check, not proof of a missed draw.
+func FindDateGaps(draws []*entity.Draw, gameType valueobject.GameType) []time.Time {
+	stored := make(map[time.Time]bool)
+	var first, last time.Time
+	for _, draw := range draws {
+		if draw.GameType != gameType {
+			continue
+		}
+		day := calendarDay(draw.DrawDate)
+		stored[day] = true
+		if first.IsZero() || day.Before(first) {
+			first = day
+		}
+		if last.IsZero() || day.After(last) {
+			last = day
+		}
+	}
+
+	gaps := make([]time.Time, 0)
+	if first.IsZero() {
+		return gaps
+	}
+
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		if gameType.IsDrawDay(day.Weekday()) && !stored[day] {
+			gaps = append(gaps, day)
+		}
+	}
+	return gaps
+}
+
+// FindDrawNumberGaps returns the draw numbers missing between the lowest and
+// highest stored draw numbers of a game type, ascending
+func FindDrawNumberGaps(draws []*entity.Draw, gameType valueobject.GameType) []int {
+	numbers := make([]int, 0, len(draws))
+	for _, draw := range draws {
+		if draw.GameType == gameType {
+			numbers = append(numbers, draw.DrawNumber)
+		}
+	}
+	sort.Ints(numbers)
+
+	gaps := make([]int, 0)
+	for i := 1; i < len(numbers); i++ {
+		for missing := numbers[i-1] + 1; missing < numbers[i]; missing++ {
+			gaps = append(gaps, missing)
+		}
+	}
+	return gaps
+}
+
+// calendarDay returns t's calendar date, read in t's own location, as
+// midnight UTC so dates from differently zoned draws compare equal
+func calendarDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
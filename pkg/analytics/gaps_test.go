@@ -0,0 +1,65 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func newDatedDraw(t *testing.T, gameType valueobject.GameType, drawNumber int, date time.Time) *entity.Draw {
+	t.Helper()
+
+	draw, err := entity.NewDraw(gameType, drawNumber, valueobject.MustNewNumbers([]int{1, 2, 3, 4, 5, 6}), date, 0, 0)
+	require.NoError(t, err)
+	return draw
+}
+
+func TestFindDateGaps_MissingScheduledDate(t *testing.T) {
+	ict := time.FixedZone("ICT", 7*60*60)
+	day := func(d int) time.Time {
+		// Evening draws, as Vietlott publishes them
+		return time.Date(2026, 1, d, 18, 0, 0, 0, ict)
+	}
+
+	// Mega 6/45 draws on Wed/Fri/Sun: Jan 2026 has Wed 7, Fri 9, Sun 11, Wed 14
+	draws := []*entity.Draw{
+		newDatedDraw(t, valueobject.Mega645, 1, day(7)),
+		newDatedDraw(t, valueobject.Mega645, 2, day(9)),
+		// Sunday the 11th is missing
+		newDatedDraw(t, valueobject.Mega645, 3, day(14)),
+	}
+
+	gaps := FindDateGaps(draws, valueobject.Mega645)
+	assert.Equal(t, []time.Time{time.Date(2026, 1, 11, 0, 0, 0, 0, time.UTC)}, gaps)
+}
+
+func TestFindDateGaps_Complete(t *testing.T) {
+	draws := []*entity.Draw{
+		newDatedDraw(t, valueobject.Power655, 1, time.Date(2026, 1, 6, 0, 0, 0, 0, time.UTC)),  // Tue
+		newDatedDraw(t, valueobject.Power655, 2, time.Date(2026, 1, 8, 0, 0, 0, 0, time.UTC)),  // Thu
+		newDatedDraw(t, valueobject.Power655, 3, time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)), // Sat
+		newDatedDraw(t, valueobject.Power655, 4, time.Date(2026, 1, 13, 0, 0, 0, 0, time.UTC)), // Tue
+	}
+
+	assert.Empty(t, FindDateGaps(draws, valueobject.Power655))
+	assert.Empty(t, FindDateGaps(nil, valueobject.Power655))
+
+	// Draws of other game types are ignored
+	assert.Empty(t, FindDateGaps(draws, valueobject.Mega645))
+}
+
+func TestFindDrawNumberGaps(t *testing.T) {
+	date := time.Date(2026, 1, 7, 0, 0, 0, 0, time.UTC)
+	draws := []*entity.Draw{
+		newDatedDraw(t, valueobject.Mega645, 10, date),
+		newDatedDraw(t, valueobject.Mega645, 14, date),
+		newDatedDraw(t, valueobject.Mega645, 11, date),
+	}
+
+	assert.Equal(t, []int{12, 13}, FindDrawNumberGaps(draws, valueobject.Mega645))
+	assert.Empty(t, FindDrawNumberGaps(draws, valueobject.Power655))
+}
@@ -0,0 +1,61 @@
+package analytics
+
+import (
+	"time"
+
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+// NumberHitRate is how often a number was drawn when the ensemble
+// recommended it
+type NumberHitRate struct {
+	Number      int
+	Recommended int // Scored predictions whose final numbers included it
+	Hits        int // Of those, how many times the targeted draw had it
+}
+
+// Rate returns Hits / Recommended, 0 if never recommended
+func (h NumberHitRate) Rate() float64 {
+	if h.Recommended == 0 {
+		return 0
+	}
+	return float64(h.Hits) / float64(h.Recommended)
+}
+
+// RecommendationHitRates scores each number the ensembles recommended against
+// the draw they targeted. As with NewAgreementIndex, a prediction only counts
+// once a draw of its game took place on its ForDate. Numbers never
+// recommended in a scored prediction are left out.
+func RecommendationHitRates(ensembles []*entity.EnsemblePrediction, draws []*entity.Draw) map[int]NumberHitRate {
+	type drawKey struct {
+		gameType valueobject.GameType
+		day      time.Time
+	}
+	drawn := make(map[drawKey]valueobject.Numbers, len(draws))
+	for _, draw := range draws {
+		drawn[drawKey{draw.GameType, calendarDay(draw.DrawDate)}] = draw.Numbers
+	}
+
+	rates := make(map[int]NumberHitRate)
+	for _, ensemble := range ensembles {
+		if ensemble.ForDate.IsZero() {
+			continue
+		}
+		actual, ok := drawn[drawKey{ensemble.GameType, calendarDay(ensemble.ForDate)}]
+		if !ok {
+			continue
+		}
+
+		for _, num := range ensemble.FinalNumbers {
+			rate := rates[num]
+			rate.Number = num
+			rate.Recommended++
+			if actual.Contains(num) {
+				rate.Hits++
+			}
+			rates[num] = rate
+		}
+	}
+	return rates
+}
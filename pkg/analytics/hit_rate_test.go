@@ -0,0 +1,53 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func TestRecommendationHitRates(t *testing.T) {
+	start := time.Date(2026, 1, 2, 18, 0, 0, 0, time.UTC)
+	drawn := [][]int{
+		{1, 2, 3, 4, 5, 6},
+		{1, 10, 11, 12, 13, 14},
+		{2, 20, 21, 22, 23, 24},
+		{1, 30, 31, 32, 33, 34},
+	}
+	draws := make([]*entity.Draw, 0, len(drawn))
+	ensembles := make([]*entity.EnsemblePrediction, 0, len(drawn)+1)
+	for i, nums := range drawn {
+		date := start.AddDate(0, 0, 2*i)
+		draw, err := entity.NewDraw(valueobject.Mega645, i+1, valueobject.MustNewNumbers(nums), date, 0, 0)
+		require.NoError(t, err)
+		draws = append(draws, draw)
+
+		// Every ensemble recommended 1, 2 and 40-43
+		ensembles = append(ensembles, &entity.EnsemblePrediction{
+			GameType:     valueobject.Mega645,
+			ForDate:      time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC),
+			FinalNumbers: valueobject.MustNewNumbers([]int{1, 2, 40, 41, 42, 43}),
+		})
+	}
+	// Not drawn yet, and no target date: neither is scored
+	ensembles = append(ensembles,
+		&entity.EnsemblePrediction{GameType: valueobject.Mega645, ForDate: start.AddDate(0, 0, 30),
+			FinalNumbers: valueobject.MustNewNumbers([]int{1, 2, 3, 4, 5, 6})},
+		&entity.EnsemblePrediction{GameType: valueobject.Mega645,
+			FinalNumbers: valueobject.MustNewNumbers([]int{1, 2, 3, 4, 5, 6})},
+	)
+
+	rates := RecommendationHitRates(ensembles, draws)
+
+	assert.Equal(t, NumberHitRate{Number: 1, Recommended: 4, Hits: 3}, rates[1])
+	assert.InDelta(t, 0.75, rates[1].Rate(), 1e-9)
+	assert.Equal(t, NumberHitRate{Number: 2, Recommended: 4, Hits: 2}, rates[2])
+	assert.Equal(t, 0.0, rates[40].Rate())
+	assert.Equal(t, 4, rates[40].Recommended)
+	assert.NotContains(t, rates, 3)
+	assert.Len(t, rates, 6)
+}
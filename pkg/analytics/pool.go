@@ -0,0 +1,69 @@
+package analytics
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/tool_predict/internal/domain/entity"
+)
+
+// RangeChange records that draws before Before were drawn from a smaller
+// pool, the numbers 1 to PoolSize
+type RangeChange struct {
+	Before   time.Time
+	PoolSize int
+}
+
+// ValidateRangeChanges checks that every pool size is positive and below
+// currentPoolSize, that no date repeats, and that older pools are no larger
+// than newer ones
+func ValidateRangeChanges(changes []RangeChange, currentPoolSize int) error {
+	sorted := sortedRangeChanges(changes)
+	for i, change := range sorted {
+		if change.PoolSize <= 0 || change.PoolSize >= currentPoolSize {
+			return fmt.Errorf("pool size before %s must be between 1 and %d, got %d",
+				change.Before.Format("2006-01-02"), currentPoolSize-1, change.PoolSize)
+		}
+		if i == 0 {
+			continue
+		}
+		if sorted[i-1].Before.Equal(change.Before) {
+			return fmt.Errorf("duplicate range change date %s", change.Before.Format("2006-01-02"))
+		}
+		if sorted[i-1].PoolSize > change.PoolSize {
+			return fmt.Errorf("pool size %d before %s is larger than the later pool size %d",
+				sorted[i-1].PoolSize, sorted[i-1].Before.Format("2006-01-02"), change.PoolSize)
+		}
+	}
+	return nil
+}
+
+// PoolSizes returns the pool size in effect at each draw's date, aligned with
+// draws: that of the earliest change dated after the draw, or
+// currentPoolSize when the draw is newer than every change
+func PoolSizes(draws []*entity.Draw, changes []RangeChange, currentPoolSize int) []int {
+	sorted := sortedRangeChanges(changes)
+
+	sizes := make([]int, len(draws))
+	for i, draw := range draws {
+		sizes[i] = currentPoolSize
+		for _, change := range sorted {
+			if draw.DrawDate.Before(change.Before) {
+				sizes[i] = change.PoolSize
+				break
+			}
+		}
+	}
+	return sizes
+}
+
+// sortedRangeChanges returns a copy of changes, oldest first
+func sortedRangeChanges(changes []RangeChange) []RangeChange {
+	sorted := make([]RangeChange, len(changes))
+	copy(sorted, changes)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Before.Before(sorted[j].Before)
+	})
+	return sorted
+}
@@ -0,0 +1,48 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tool_predict/internal/domain/entity"
+	"github.com/tool_predict/internal/domain/valueobject"
+)
+
+func TestPoolSizes_FollowsRangeChanges(t *testing.T) {
+	base := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+	draws := make([]*entity.Draw, 0, 4)
+	for i, day := range []int{0, 40, 100, 200} {
+		draw, err := entity.NewDraw(valueobject.Mega645, i+1, valueobject.MustNewNumbers([]int{1, 2, 3, 4, 5, 6}),
+			base.AddDate(0, 0, day), 0, 0)
+		require.NoError(t, err)
+		draws = append(draws, draw)
+	}
+
+	changes := []RangeChange{
+		{Before: base.AddDate(0, 0, 100), PoolSize: 40},
+		{Before: base.AddDate(0, 0, 30), PoolSize: 35},
+	}
+
+	assert.Equal(t, []int{35, 40, 45, 45}, PoolSizes(draws, changes, 45))
+	assert.Equal(t, []int{45, 45, 45, 45}, PoolSizes(draws, nil, 45))
+}
+
+func TestValidateRangeChanges(t *testing.T) {
+	day := time.Date(2016, 7, 18, 0, 0, 0, 0, time.UTC)
+
+	assert.NoError(t, ValidateRangeChanges(nil, 45))
+	assert.NoError(t, ValidateRangeChanges([]RangeChange{{Before: day, PoolSize: 40}}, 45))
+
+	assert.Error(t, ValidateRangeChanges([]RangeChange{{Before: day, PoolSize: 45}}, 45))
+	assert.Error(t, ValidateRangeChanges([]RangeChange{{Before: day, PoolSize: 0}}, 45))
+	assert.Error(t, ValidateRangeChanges([]RangeChange{
+		{Before: day, PoolSize: 40},
+		{Before: day, PoolSize: 42},
+	}, 45))
+	assert.Error(t, ValidateRangeChanges([]RangeChange{
+		{Before: day, PoolSize: 35},
+		{Before: day.AddDate(-1, 0, 0), PoolSize: 40},
+	}, 45))
+}
@@ -451,6 +451,157 @@ func (x *PredictionStatusResponse) GetErrorMessage() string {
 	return ""
 }
 
+// GeneratePredictionRequest asks for a new ensemble prediction
+type GeneratePredictionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameType      string                 `protobuf:"bytes,1,opt,name=game_type,json=gameType,proto3" json:"game_type,omitempty"`
+	MaxDraws      int32                  `protobuf:"varint,2,opt,name=max_draws,json=maxDraws,proto3" json:"max_draws,omitempty"` // Latest draws to train on; 0 for the server default
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeneratePredictionRequest) Reset() {
+	*x = GeneratePredictionRequest{}
+	mi := &file_proto_prediction_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeneratePredictionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeneratePredictionRequest) ProtoMessage() {}
+
+func (x *GeneratePredictionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_prediction_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeneratePredictionRequest.ProtoReflect.Descriptor instead.
+func (*GeneratePredictionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_prediction_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GeneratePredictionRequest) GetGameType() string {
+	if x != nil {
+		return x.GameType
+	}
+	return ""
+}
+
+func (x *GeneratePredictionRequest) GetMaxDraws() int32 {
+	if x != nil {
+		return x.MaxDraws
+	}
+	return 0
+}
+
+// ListLatestEnsemblesRequest selects the ensemble predictions to list
+type ListLatestEnsemblesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameType      string                 `protobuf:"bytes,1,opt,name=game_type,json=gameType,proto3" json:"game_type,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // 0 for the server default
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLatestEnsemblesRequest) Reset() {
+	*x = ListLatestEnsemblesRequest{}
+	mi := &file_proto_prediction_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLatestEnsemblesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLatestEnsemblesRequest) ProtoMessage() {}
+
+func (x *ListLatestEnsemblesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_prediction_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLatestEnsemblesRequest.ProtoReflect.Descriptor instead.
+func (*ListLatestEnsemblesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_prediction_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListLatestEnsemblesRequest) GetGameType() string {
+	if x != nil {
+		return x.GameType
+	}
+	return ""
+}
+
+func (x *ListLatestEnsemblesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// ListLatestEnsemblesResponse contains ensemble predictions, newest first
+type ListLatestEnsemblesResponse struct {
+	state         protoimpl.MessageState       `protogen:"open.v1"`
+	Ensembles     []*EnsemblePredictionRequest `protobuf:"bytes,1,rep,name=ensembles,proto3" json:"ensembles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLatestEnsemblesResponse) Reset() {
+	*x = ListLatestEnsemblesResponse{}
+	mi := &file_proto_prediction_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLatestEnsemblesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLatestEnsemblesResponse) ProtoMessage() {}
+
+func (x *ListLatestEnsemblesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_prediction_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLatestEnsemblesResponse.ProtoReflect.Descriptor instead.
+func (*ListLatestEnsemblesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_prediction_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListLatestEnsemblesResponse) GetEnsembles() []*EnsemblePredictionRequest {
+	if x != nil {
+		return x.Ensembles
+	}
+	return nil
+}
+
 var File_proto_prediction_proto protoreflect.FileDescriptor
 
 const file_proto_prediction_proto_rawDesc = "" +
@@ -493,10 +644,20 @@ const file_proto_prediction_proto_rawDesc = "" +
 	"\x06status\x18\x02 \x01(\tR\x06status\x12\x17\n" +
 	"\asent_at\x18\x03 \x01(\x03R\x06sentAt\x12!\n" +
 	"\fprocessed_at\x18\x04 \x01(\x03R\vprocessedAt\x12#\n" +
-	"\rerror_message\x18\x05 \x01(\tR\ferrorMessage2\xce\x01\n" +
+	"\rerror_message\x18\x05 \x01(\tR\ferrorMessage\"U\n" +
+	"\x19GeneratePredictionRequest\x12\x1b\n" +
+	"\tgame_type\x18\x01 \x01(\tR\bgameType\x12\x1b\n" +
+	"\tmax_draws\x18\x02 \x01(\x05R\bmaxDraws\"O\n" +
+	"\x1aListLatestEnsemblesRequest\x12\x1b\n" +
+	"\tgame_type\x18\x01 \x01(\tR\bgameType\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"b\n" +
+	"\x1bListLatestEnsemblesResponse\x12C\n" +
+	"\tensembles\x18\x01 \x03(\v2%.prediction.EnsemblePredictionRequestR\tensembles2\x9a\x03\n" +
 	"\x11PredictionService\x12W\n" +
 	"\x0eSendPrediction\x12%.prediction.EnsemblePredictionRequest\x1a\x1e.prediction.PredictionResponse\x12`\n" +
-	"\x13GetPredictionStatus\x12#.prediction.PredictionStatusRequest\x1a$.prediction.PredictionStatusResponseB\x1fZ\x1dgithub.com/tool_predict/protob\x06proto3"
+	"\x13GetPredictionStatus\x12#.prediction.PredictionStatusRequest\x1a$.prediction.PredictionStatusResponse\x12b\n" +
+	"\x12GeneratePrediction\x12%.prediction.GeneratePredictionRequest\x1a%.prediction.EnsemblePredictionRequest\x12f\n" +
+	"\x13ListLatestEnsembles\x12&.prediction.ListLatestEnsemblesRequest\x1a'.prediction.ListLatestEnsemblesResponseB\x1fZ\x1dgithub.com/tool_predict/protob\x06proto3"
 
 var (
 	file_proto_prediction_proto_rawDescOnce sync.Once
@@ -510,27 +671,35 @@ func file_proto_prediction_proto_rawDescGZIP() []byte {
 	return file_proto_prediction_proto_rawDescData
 }
 
-var file_proto_prediction_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_proto_prediction_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_proto_prediction_proto_goTypes = []any{
-	(*EnsemblePredictionRequest)(nil), // 0: prediction.EnsemblePredictionRequest
-	(*IndividualPrediction)(nil),      // 1: prediction.IndividualPrediction
-	(*AlgorithmContribution)(nil),     // 2: prediction.AlgorithmContribution
-	(*PredictionResponse)(nil),        // 3: prediction.PredictionResponse
-	(*PredictionStatusRequest)(nil),   // 4: prediction.PredictionStatusRequest
-	(*PredictionStatusResponse)(nil),  // 5: prediction.PredictionStatusResponse
+	(*EnsemblePredictionRequest)(nil),   // 0: prediction.EnsemblePredictionRequest
+	(*IndividualPrediction)(nil),        // 1: prediction.IndividualPrediction
+	(*AlgorithmContribution)(nil),       // 2: prediction.AlgorithmContribution
+	(*PredictionResponse)(nil),          // 3: prediction.PredictionResponse
+	(*PredictionStatusRequest)(nil),     // 4: prediction.PredictionStatusRequest
+	(*PredictionStatusResponse)(nil),    // 5: prediction.PredictionStatusResponse
+	(*GeneratePredictionRequest)(nil),   // 6: prediction.GeneratePredictionRequest
+	(*ListLatestEnsemblesRequest)(nil),  // 7: prediction.ListLatestEnsemblesRequest
+	(*ListLatestEnsemblesResponse)(nil), // 8: prediction.ListLatestEnsemblesResponse
 }
 var file_proto_prediction_proto_depIdxs = []int32{
 	1, // 0: prediction.EnsemblePredictionRequest.predictions:type_name -> prediction.IndividualPrediction
 	2, // 1: prediction.EnsemblePredictionRequest.algorithm_stats:type_name -> prediction.AlgorithmContribution
-	0, // 2: prediction.PredictionService.SendPrediction:input_type -> prediction.EnsemblePredictionRequest
-	4, // 3: prediction.PredictionService.GetPredictionStatus:input_type -> prediction.PredictionStatusRequest
-	3, // 4: prediction.PredictionService.SendPrediction:output_type -> prediction.PredictionResponse
-	5, // 5: prediction.PredictionService.GetPredictionStatus:output_type -> prediction.PredictionStatusResponse
-	4, // [4:6] is the sub-list for method output_type
-	2, // [2:4] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	0, // 2: prediction.ListLatestEnsemblesResponse.ensembles:type_name -> prediction.EnsemblePredictionRequest
+	0, // 3: prediction.PredictionService.SendPrediction:input_type -> prediction.EnsemblePredictionRequest
+	4, // 4: prediction.PredictionService.GetPredictionStatus:input_type -> prediction.PredictionStatusRequest
+	6, // 5: prediction.PredictionService.GeneratePrediction:input_type -> prediction.GeneratePredictionRequest
+	7, // 6: prediction.PredictionService.ListLatestEnsembles:input_type -> prediction.ListLatestEnsemblesRequest
+	3, // 7: prediction.PredictionService.SendPrediction:output_type -> prediction.PredictionResponse
+	5, // 8: prediction.PredictionService.GetPredictionStatus:output_type -> prediction.PredictionStatusResponse
+	0, // 9: prediction.PredictionService.GeneratePrediction:output_type -> prediction.EnsemblePredictionRequest
+	8, // 10: prediction.PredictionService.ListLatestEnsembles:output_type -> prediction.ListLatestEnsemblesResponse
+	7, // [7:11] is the sub-list for method output_type
+	3, // [3:7] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_proto_prediction_proto_init() }
@@ -544,7 +713,7 @@ func file_proto_prediction_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_prediction_proto_rawDesc), len(file_proto_prediction_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -21,6 +21,8 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	PredictionService_SendPrediction_FullMethodName      = "/prediction.PredictionService/SendPrediction"
 	PredictionService_GetPredictionStatus_FullMethodName = "/prediction.PredictionService/GetPredictionStatus"
+	PredictionService_GeneratePrediction_FullMethodName  = "/prediction.PredictionService/GeneratePrediction"
+	PredictionService_ListLatestEnsembles_FullMethodName = "/prediction.PredictionService/ListLatestEnsembles"
 )
 
 // PredictionServiceClient is the client API for PredictionService service.
@@ -33,6 +35,10 @@ type PredictionServiceClient interface {
 	SendPrediction(ctx context.Context, in *EnsemblePredictionRequest, opts ...grpc.CallOption) (*PredictionResponse, error)
 	// GetPredictionStatus checks the status of a sent prediction
 	GetPredictionStatus(ctx context.Context, in *PredictionStatusRequest, opts ...grpc.CallOption) (*PredictionStatusResponse, error)
+	// GeneratePrediction runs the ensemble on demand and returns the stored prediction
+	GeneratePrediction(ctx context.Context, in *GeneratePredictionRequest, opts ...grpc.CallOption) (*EnsemblePredictionRequest, error)
+	// ListLatestEnsembles lists the most recent stored ensemble predictions
+	ListLatestEnsembles(ctx context.Context, in *ListLatestEnsemblesRequest, opts ...grpc.CallOption) (*ListLatestEnsemblesResponse, error)
 }
 
 type predictionServiceClient struct {
@@ -63,6 +69,26 @@ func (c *predictionServiceClient) GetPredictionStatus(ctx context.Context, in *P
 	return out, nil
 }
 
+func (c *predictionServiceClient) GeneratePrediction(ctx context.Context, in *GeneratePredictionRequest, opts ...grpc.CallOption) (*EnsemblePredictionRequest, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnsemblePredictionRequest)
+	err := c.cc.Invoke(ctx, PredictionService_GeneratePrediction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *predictionServiceClient) ListLatestEnsembles(ctx context.Context, in *ListLatestEnsemblesRequest, opts ...grpc.CallOption) (*ListLatestEnsemblesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLatestEnsemblesResponse)
+	err := c.cc.Invoke(ctx, PredictionService_ListLatestEnsembles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PredictionServiceServer is the server API for PredictionService service.
 // All implementations must embed UnimplementedPredictionServiceServer
 // for forward compatibility.
@@ -73,6 +99,10 @@ type PredictionServiceServer interface {
 	SendPrediction(context.Context, *EnsemblePredictionRequest) (*PredictionResponse, error)
 	// GetPredictionStatus checks the status of a sent prediction
 	GetPredictionStatus(context.Context, *PredictionStatusRequest) (*PredictionStatusResponse, error)
+	// GeneratePrediction runs the ensemble on demand and returns the stored prediction
+	GeneratePrediction(context.Context, *GeneratePredictionRequest) (*EnsemblePredictionRequest, error)
+	// ListLatestEnsembles lists the most recent stored ensemble predictions
+	ListLatestEnsembles(context.Context, *ListLatestEnsemblesRequest) (*ListLatestEnsemblesResponse, error)
 	mustEmbedUnimplementedPredictionServiceServer()
 }
 
@@ -89,6 +119,12 @@ func (UnimplementedPredictionServiceServer) SendPrediction(context.Context, *Ens
 func (UnimplementedPredictionServiceServer) GetPredictionStatus(context.Context, *PredictionStatusRequest) (*PredictionStatusResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetPredictionStatus not implemented")
 }
+func (UnimplementedPredictionServiceServer) GeneratePrediction(context.Context, *GeneratePredictionRequest) (*EnsemblePredictionRequest, error) {
+	return nil, status.Error(codes.Unimplemented, "method GeneratePrediction not implemented")
+}
+func (UnimplementedPredictionServiceServer) ListLatestEnsembles(context.Context, *ListLatestEnsemblesRequest) (*ListLatestEnsemblesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListLatestEnsembles not implemented")
+}
 func (UnimplementedPredictionServiceServer) mustEmbedUnimplementedPredictionServiceServer() {}
 func (UnimplementedPredictionServiceServer) testEmbeddedByValue()                           {}
 
@@ -146,6 +182,42 @@ func _PredictionService_GetPredictionStatus_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PredictionService_GeneratePrediction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GeneratePredictionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PredictionServiceServer).GeneratePrediction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PredictionService_GeneratePrediction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PredictionServiceServer).GeneratePrediction(ctx, req.(*GeneratePredictionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PredictionService_ListLatestEnsembles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLatestEnsemblesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PredictionServiceServer).ListLatestEnsembles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PredictionService_ListLatestEnsembles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PredictionServiceServer).ListLatestEnsembles(ctx, req.(*ListLatestEnsemblesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PredictionService_ServiceDesc is the grpc.ServiceDesc for PredictionService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -161,6 +233,14 @@ var PredictionService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPredictionStatus",
 			Handler:    _PredictionService_GetPredictionStatus_Handler,
 		},
+		{
+			MethodName: "GeneratePrediction",
+			Handler:    _PredictionService_GeneratePrediction_Handler,
+		},
+		{
+			MethodName: "ListLatestEnsembles",
+			Handler:    _PredictionService_ListLatestEnsembles_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/prediction.proto",
@@ -15,6 +15,7 @@ import (
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/chromedp/chromedp"
+	"github.com/tool_predict/internal/domain/valueobject"
 )
 
 const (
@@ -342,7 +343,7 @@ func extractNumbersAlternative(html string) []int {
 		doc.Find(selector).Each(func(i int, s *goquery.Selection) {
 			text := strings.TrimSpace(s.Text())
 			num, err := strconv.Atoi(text)
-			if err == nil && num > 0 && num <= 55 {
+			if err == nil && valueobject.Power655.InRange(num) {
 				numbers = append(numbers, num)
 			}
 		})
@@ -411,7 +412,7 @@ func fetchDrawNumbersWithoutBrowser(drawNumber int) ([]int, error) {
 	doc.Find(".ball, .number").Each(func(i int, s *goquery.Selection) {
 		text := strings.TrimSpace(s.Text())
 		num, err := strconv.Atoi(text)
-		if err == nil && num > 0 && num <= 55 {
+		if err == nil && valueobject.Power655.InRange(num) {
 			numbers = append(numbers, num)
 		}
 	})
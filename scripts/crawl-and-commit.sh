@@ -10,7 +10,7 @@ echo ""
 
 # Run the crawler
 echo "📡 Fetching data from Vietlott..."
-go run scripts/crawl_vietlott.go
+go run ./cmd/crawler --pages 1
 
 echo ""
 echo "📊 Checking for changes..."
@@ -7,7 +7,6 @@ import (
 	"log"
 	"net/http"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"regexp"
 	"strconv"
@@ -16,6 +15,8 @@ import (
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/chromedp/chromedp"
+	"github.com/tool_predict/internal/domain/valueobject"
+	"github.com/tool_predict/internal/infrastructure/adapter/scraper"
 )
 
 const (
@@ -24,9 +25,11 @@ const (
 	gameType       = "POWER_6_55"
 	totalPages     = 5
 	pdfDownloadDir = "/tmp/vietlott_pdfs"
-	imageOutputDir = "/tmp/vietlott_images"
 )
 
+// pdfParser reads the result PDFs, falling back to tesseract for scans
+var pdfParser = scraper.NewDrawResultPDFParser("vie")
+
 type Draw struct {
 	ID         string    `json:"id"`
 	GameType   string    `json:"game_type"`
@@ -39,8 +42,7 @@ func main() {
 	log.Println("Starting MCP-based OCR crawler for Vietlott Power 6/55...")
 	log.Println("This will:")
 	log.Println("1. Download PDFs from announcement pages")
-	log.Println("2. Convert PDFs to images")
-	log.Println("3. Use MCP AI vision to extract winning numbers")
+	log.Println("2. Parse the winning numbers from each PDF, by OCR for scans")
 	log.Println("")
 
 	// Ensure output directory exists
@@ -52,9 +54,6 @@ func main() {
 	if err := os.MkdirAll(pdfDownloadDir, 0755); err != nil {
 		log.Fatalf("Failed to create PDF directory: %v", err)
 	}
-	if err := os.MkdirAll(imageOutputDir, 0755); err != nil {
-		log.Fatalf("Failed to create image directory: %v", err)
-	}
 
 	// Get existing draws
 	existingDraws := getExistingDraws()
@@ -190,7 +189,7 @@ func crawlPageWithMCPOCR(url string, pageNum int) ([]*Draw, error) {
 		year, _ := strconv.Atoi(dateMatches[3])
 		drawDate := time.Date(year, time.Month(month), day, 18, 0, 0, 0, time.UTC)
 
-		// Try to extract numbers from PDF using MCP OCR
+		// Extract the numbers from the result PDF
 		numbers, err := extractNumbersWithMCPOCR(href, drawNumber)
 		if err != nil {
 			log.Printf("  Warning: Could not extract numbers for draw %d: %v", drawNumber, err)
@@ -261,146 +260,16 @@ func extractNumbersWithMCPOCR(pdfURL string, drawNumber int) ([]int, error) {
 		}
 	}
 
-	// Convert PDF to image
-	imagePath, err := convertPDFToImage(pdfPath, drawNumber)
-	if err != nil {
-		return nil, fmt.Errorf("failed to convert PDF to image: %w", err)
-	}
-
-	// Perform OCR on the image using MCP AI Vision
-	text, err := performMCPOCR(imagePath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to perform MCP OCR: %w", err)
-	}
-
-	// Extract 6 numbers from OCR text
-	numbers := extractNumbersFromText(text)
-
-	if len(numbers) != 6 {
-		// Log OCR text for debugging
-		log.Printf("  [%05d] OCR Text: %s", drawNumber, text)
-	}
-
-	return numbers, nil
-}
-
-func convertPDFToImage(pdfPath string, drawNumber int) (string, error) {
-	// Create image output path
-	imageBase := filepath.Join(imageOutputDir, fmt.Sprintf("draw_%05d", drawNumber))
-
-	// Check if image already exists
-	imagePath := imageBase + ".png"
-	if _, err := os.Stat(imagePath); err == nil {
-		// Image already exists from previous run
-		return imagePath, nil
-	}
-
-	// Use pdftoppm to convert PDF to PNG image
-	cmd := exec.Command("pdftoppm", "-png", "-singlefile", pdfPath, imageBase)
-	output, err := cmd.CombinedOutput()
+	// Read the numbers from the PDF's text, or by OCR when it's a scan
+	draw, err := pdfParser.ParseFile(context.Background(), valueobject.Power655, pdfPath)
 	if err != nil {
-		return "", fmt.Errorf("pdftoppm failed: %w, output: %s", err, string(output))
-	}
-
-	// pdftoppm creates a file with .png extension
-	if _, err := os.Stat(imagePath); os.IsNotExist(err) {
-		return "", fmt.Errorf("image file not created: %s", imagePath)
+		return nil, fmt.Errorf("failed to parse PDF: %w", err)
 	}
-
-	return imagePath, nil
-}
-
-func performMCPOCR(imagePath string) (string, error) {
-	// Since we can't directly call MCP tools from Go code,
-	// we'll write a Python script that uses the MCP vision model
-	// For now, let's use a simpler approach with tesseract if available
-
-	// Create a Python script that would call MCP if it were integrated
-	// For now, we'll save the image path for manual processing
-	return "", fmt.Errorf("MCP OCR needs to be implemented via Python script - image saved at %s", imagePath)
-}
-
-func extractNumbersFromText(text string) []int {
-	// Clean up the text
-	text = strings.ReplaceAll(text, "|", "1")
-	text = strings.ReplaceAll(text, "l", "1")
-	text = strings.ReplaceAll(text, "O", "0")
-	text = strings.ReplaceAll(text, "o", "0")
-	text = strings.ReplaceAll(text, "S", "5")
-	text = strings.ReplaceAll(text, "Z", "2")
-
-	// Try multiple patterns
-	patterns := []string{
-		`(\d{1,2})\s*[-–—]\s*(\d{1,2})\s*[-–—]\s*(\d{1,2})\s*[-–—]\s*(\d{1,2})\s*[-–—]\s*(\d{1,2})\s*[-–—]\s*(\d{1,2})`,
-		`(\d{1,2})\s+(\d{1,2})\s+(\d{1,2})\s+(\d{1,2})\s+(\d{1,2})\s+(\d{1,2})`,
-		`Bộ\s*số\s*[:：]?\s*(\d{1,2}).*?(\d{1,2}).*?(\d{1,2}).*?(\d{1,2}).*?(\d{1,2}).*?(\d{1,2})`,
-	}
-
-	for _, pattern := range patterns {
-		re := regexp.MustCompile(pattern)
-		matches := re.FindStringSubmatch(text)
-		if len(matches) >= 7 {
-			numbers := make([]int, 6)
-			valid := true
-			for i := 0; i < 6; i++ {
-				num, err := strconv.Atoi(strings.TrimSpace(matches[i+1]))
-				if err != nil || num < 1 || num > 55 {
-					valid = false
-					break
-				}
-				numbers[i] = num
-			}
-			if valid {
-				return numbers
-			}
-		}
-	}
-
-	// Fallback
-	re := regexp.MustCompile(`\b(\d{1,2})\b`)
-	matches := re.FindAllStringSubmatch(text, -1)
-
-	numbers := make([]int, 0)
-	for _, match := range matches {
-		if len(match) > 1 {
-			num, err := strconv.Atoi(match[1])
-			if err == nil && num >= 1 && num <= 55 {
-				duplicate := false
-				for _, existing := range numbers {
-					if existing == num {
-						duplicate = true
-						break
-					}
-				}
-				if !duplicate {
-					numbers = append(numbers, num)
-				}
-			}
-		}
-	}
-
-	if len(numbers) > 6 {
-		for i := 0; i <= len(numbers)-6; i++ {
-			candidate := numbers[i : i+6]
-			isSorted := true
-			for j := 1; j < 6; j++ {
-				if candidate[j] <= candidate[j-1] {
-					isSorted = false
-					break
-				}
-			}
-			if isSorted {
-				return candidate
-			}
-		}
-		return numbers[len(numbers)-6:]
-	}
-
-	if len(numbers) == 6 {
-		return numbers
+	if draw.DrawNumber != drawNumber {
+		return nil, fmt.Errorf("PDF is for draw %d", draw.DrawNumber)
 	}
 
-	return []int{}
+	return draw.Numbers.AsSlice(), nil
 }
 
 func saveDraw(draw *Draw) error {
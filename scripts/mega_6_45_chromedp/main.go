@@ -13,6 +13,7 @@ import (
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/chromedp/chromedp"
+	"github.com/tool_predict/internal/domain/valueobject"
 )
 
 const (
@@ -190,7 +191,7 @@ func fetchLatestDrawsWithBrowser() ([]Draw, error) {
 			}
 			numStr := strings.TrimSpace(ball.Text())
 			num, err := strconv.Atoi(numStr)
-			if err == nil && num <= 45 { // Only include numbers 01-45 for Mega 6/45
+			if err == nil && valueobject.Mega645.InRange(num) { // Only include numbers 01-45 for Mega 6/45
 				numbers = append(numbers, num)
 			}
 		})
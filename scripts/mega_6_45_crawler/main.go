@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
+	"github.com/tool_predict/internal/domain/valueobject"
 )
 
 const (
@@ -183,7 +184,7 @@ func fetchLatestDraws() ([]Draw, error) {
 			}
 			numStr := strings.TrimSpace(ball.Text())
 			num, err := strconv.Atoi(numStr)
-			if err == nil && num <= 45 { // Only include numbers 01-45 for Mega 6/45
+			if err == nil && valueobject.Mega645.InRange(num) { // Only include numbers 01-45 for Mega 6/45
 				numbers = append(numbers, num)
 			}
 		})